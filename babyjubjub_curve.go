@@ -0,0 +1,640 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+	babyjubjubn "github.com/mikelodder7/curvey/native/babyjubjub"
+	"github.com/mikelodder7/curvey/native/bn254"
+)
+
+// ScalarBabyJubjub is an element of the field defined by the
+// order of the baby jubjub prime order subgroup.
+type ScalarBabyJubjub struct {
+	Value *native.Field4
+}
+
+func (s *ScalarBabyJubjub) Random(reader io.Reader) Scalar {
+	if reader == nil {
+		return nil
+	}
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return s.Hash(seed[:])
+}
+
+func (*ScalarBabyJubjub) Hash(bytes []byte) Scalar {
+	dst := []byte("babyjubjub_XMD:SHA-256_ELL2_RO_")
+	xmd := native.ExpandMsgXmd(native.EllipticPointHasherSha256(), bytes, dst, 48)
+	var t [64]byte
+	copy(t[:48], internal.ReverseBytes(xmd))
+	return &ScalarBabyJubjub{
+		Value: babyjubjubn.FqNew().SetBytesWide(&t),
+	}
+}
+
+func (*ScalarBabyJubjub) Zero() Scalar {
+	return &ScalarBabyJubjub{
+		Value: babyjubjubn.FqNew().SetZero(),
+	}
+}
+
+func (*ScalarBabyJubjub) One() Scalar {
+	return &ScalarBabyJubjub{
+		Value: babyjubjubn.FqNew().SetOne(),
+	}
+}
+
+func (s *ScalarBabyJubjub) IsZero() bool {
+	return s.Value.IsZero() == 1
+}
+
+func (s *ScalarBabyJubjub) IsOne() bool {
+	return s.Value.IsOne() == 1
+}
+
+func (s *ScalarBabyJubjub) IsOdd() bool {
+	return (s.Value.Bytes()[0] & 1) == 1
+}
+
+func (s *ScalarBabyJubjub) IsEven() bool {
+	return (s.Value.Bytes()[0] & 1) == 0
+}
+
+func (*ScalarBabyJubjub) New(value int) Scalar {
+	v := big.NewInt(int64(value))
+	return &ScalarBabyJubjub{
+		Value: babyjubjubn.FqNew().SetBigInt(v),
+	}
+}
+
+func (s *ScalarBabyJubjub) Cmp(rhs Scalar) int {
+	r, ok := rhs.(*ScalarBabyJubjub)
+	if ok {
+		return s.Value.Cmp(r.Value)
+	} else {
+		return -2
+	}
+}
+
+func (s *ScalarBabyJubjub) Square() Scalar {
+	return &ScalarBabyJubjub{
+		Value: babyjubjubn.FqNew().Square(s.Value),
+	}
+}
+
+func (s *ScalarBabyJubjub) Pow(exp uint64) Scalar {
+	expFieldLimb := [native.Field4Limbs]uint64{exp, 0, 0, 0}
+	out := ScalarBabyJubjub{Value: babyjubjubn.FqNew()}
+	native.Pow(&out.Value.Value, &s.Value.Value, &expFieldLimb, s.Value.Params, s.Value.Arithmetic)
+	return &ScalarBabyJubjub{
+		Value: out.Value,
+	}
+}
+
+func (s *ScalarBabyJubjub) Double() Scalar {
+	return &ScalarBabyJubjub{
+		Value: babyjubjubn.FqNew().Double(s.Value),
+	}
+}
+
+func (s *ScalarBabyJubjub) Invert() (Scalar, error) {
+	value, wasInverted := babyjubjubn.FqNew().Invert(s.Value)
+	if !wasInverted {
+		return nil, fmt.Errorf("inverse doesn't exist")
+	}
+	return &ScalarBabyJubjub{
+		value,
+	}, nil
+}
+
+func (s *ScalarBabyJubjub) Sqrt() (Scalar, error) {
+	value, wasSquare := babyjubjubn.FqNew().Sqrt(s.Value)
+	if !wasSquare {
+		return nil, fmt.Errorf("not a square")
+	}
+	return &ScalarBabyJubjub{
+		value,
+	}, nil
+}
+
+func (s *ScalarBabyJubjub) Cube() Scalar {
+	value := babyjubjubn.FqNew().Square(s.Value)
+	value.Mul(value, s.Value)
+	return &ScalarBabyJubjub{
+		value,
+	}
+}
+
+func (s *ScalarBabyJubjub) Add(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBabyJubjub)
+	if ok {
+		return &ScalarBabyJubjub{
+			Value: babyjubjubn.FqNew().Add(s.Value, r.Value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBabyJubjub) Sub(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBabyJubjub)
+	if ok {
+		return &ScalarBabyJubjub{
+			Value: babyjubjubn.FqNew().Sub(s.Value, r.Value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBabyJubjub) Mul(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBabyJubjub)
+	if ok {
+		return &ScalarBabyJubjub{
+			Value: babyjubjubn.FqNew().Mul(s.Value, r.Value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBabyJubjub) MulAdd(y, z Scalar) Scalar {
+	return s.Mul(y).Add(z)
+}
+
+func (s *ScalarBabyJubjub) Div(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBabyJubjub)
+	if ok {
+		v, wasInverted := babyjubjubn.FqNew().Invert(r.Value)
+		if !wasInverted {
+			return nil
+		}
+		v.Mul(v, s.Value)
+		return &ScalarBabyJubjub{Value: v}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBabyJubjub) Neg() Scalar {
+	return &ScalarBabyJubjub{
+		Value: babyjubjubn.FqNew().Neg(s.Value),
+	}
+}
+
+func (*ScalarBabyJubjub) SetBigInt(v *big.Int) (Scalar, error) {
+	return &ScalarBabyJubjub{
+		Value: babyjubjubn.FqNew().SetBigInt(v),
+	}, nil
+}
+
+func (s *ScalarBabyJubjub) BigInt() *big.Int {
+	return s.Value.BigInt()
+}
+
+func (s *ScalarBabyJubjub) Bytes() []byte {
+	t := s.Value.Bytes()
+	return t[:]
+}
+
+func (*ScalarBabyJubjub) SetBytes(bytes []byte) (Scalar, error) {
+	if len(bytes) != 32 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [32]byte
+	copy(seq[:], bytes)
+	value, err := babyjubjubn.FqNew().SetBytes(&seq)
+	if err != nil {
+		return nil, err
+	}
+	return &ScalarBabyJubjub{
+		value,
+	}, nil
+}
+
+func (*ScalarBabyJubjub) SetBytesWide(bytes []byte) (Scalar, error) {
+	if len(bytes) != 64 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [64]byte
+	copy(seq[:], bytes)
+	return &ScalarBabyJubjub{
+		Value: babyjubjubn.FqNew().SetBytesWide(&seq),
+	}, nil
+}
+
+func (*ScalarBabyJubjub) Point() Point {
+	return new(PointBabyJubjub).Identity()
+}
+
+func (s *ScalarBabyJubjub) Clone() Scalar {
+	return &ScalarBabyJubjub{
+		Value: babyjubjubn.FqNew().Set(s.Value),
+	}
+}
+
+func (s *ScalarBabyJubjub) MarshalBinary() ([]byte, error) {
+	return ScalarMarshalBinary(s)
+}
+
+func (s *ScalarBabyJubjub) UnmarshalBinary(input []byte) error {
+	sc, err := ScalarUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarBabyJubjub)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.Value = ss.Value
+	return nil
+}
+
+func (s *ScalarBabyJubjub) MarshalText() ([]byte, error) {
+	return ScalarMarshalText(s)
+}
+
+func (s *ScalarBabyJubjub) UnmarshalText(input []byte) error {
+	sc, err := ScalarUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarBabyJubjub)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.Value = ss.Value
+	return nil
+}
+
+func (s *ScalarBabyJubjub) MarshalJSON() ([]byte, error) {
+	return ScalarMarshalJSON(s)
+}
+
+func (s *ScalarBabyJubjub) UnmarshalJSON(input []byte) error {
+	sc, err := ScalarUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	S, ok := sc.(*ScalarBabyJubjub)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	s.Value = S.Value
+	return nil
+}
+
+// PointBabyJubjub is a point in the prime order subgroup of the baby jubjub curve
+// 168700*x^2 + y^2 = 1 + 168696*x^2*y^2 defined over the BN254 scalar field.
+type PointBabyJubjub struct {
+	value *babyjubjubn.ExtendedPoint
+}
+
+func (p *PointBabyJubjub) Random(reader io.Reader) Point {
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return p.Hash(seed[:])
+}
+
+// Hash uses the hash to curve suite babyjubjub_XMD:SHA-256_ELL2_RO_
+// following RFC 9380.
+func (*PointBabyJubjub) Hash(bytes []byte) Point {
+	return &PointBabyJubjub{
+		value: babyjubjubn.PointNew().HashWithDefaults(bytes),
+	}
+}
+
+func (*PointBabyJubjub) Identity() Point {
+	return &PointBabyJubjub{
+		value: babyjubjubn.PointNew().SetIdentity(),
+	}
+}
+
+func (*PointBabyJubjub) Generator() Point {
+	return &PointBabyJubjub{
+		value: babyjubjubn.PointNew().SetGenerator(),
+	}
+}
+
+func (p *PointBabyJubjub) IsIdentity() bool {
+	return p.value.IsIdentityI() == 1
+}
+
+func (*PointBabyJubjub) IsNegative() bool {
+	// Negative points don't really exist in baby jubjub
+	return false
+}
+
+func (p *PointBabyJubjub) IsOnCurve() bool {
+	return p.value.IsOnCurve() == 1
+}
+
+// IsTorsionFree returns true if the point is in the prime order subgroup
+func (p *PointBabyJubjub) IsTorsionFree() bool {
+	return p.value.IsTorsionFreeI() == 1
+}
+
+// IsSmallOrder returns true if the point is in the torsion subgroup of order 8
+func (p *PointBabyJubjub) IsSmallOrder() bool {
+	return p.value.IsSmallOrderI() == 1
+}
+
+// ClearCofactor returns [8]p which is always in the prime order subgroup
+func (p *PointBabyJubjub) ClearCofactor() *PointBabyJubjub {
+	return &PointBabyJubjub{value: babyjubjubn.PointNew().ClearCofactor(p.value)}
+}
+
+func (p *PointBabyJubjub) Double() Point {
+	return &PointBabyJubjub{value: babyjubjubn.PointNew().Double(p.value)}
+}
+
+func (*PointBabyJubjub) Scalar() Scalar {
+	return new(ScalarBabyJubjub).Zero()
+}
+
+func (p *PointBabyJubjub) Neg() Point {
+	return &PointBabyJubjub{value: babyjubjubn.PointNew().Negate(p.value)}
+}
+
+func (p *PointBabyJubjub) Add(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointBabyJubjub)
+	if ok {
+		return &PointBabyJubjub{value: babyjubjubn.PointNew().Add(p.value, r.value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointBabyJubjub) Sub(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointBabyJubjub)
+	if ok {
+		return &PointBabyJubjub{value: babyjubjubn.PointNew().Sub(p.value, r.value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointBabyJubjub) Mul(rhs Scalar) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*ScalarBabyJubjub)
+	if ok {
+		return &PointBabyJubjub{value: babyjubjubn.PointNew().Mul(p.value, r.Value)}
+	} else {
+		return nil
+	}
+}
+
+func (*PointBabyJubjub) selectPoint(row []Point, index int) Point {
+	value := babyjubjubn.PointNew().SetIdentity()
+	for i, pt := range row {
+		value.CMove(value, pt.(*PointBabyJubjub).value, ctEqual(i+1, index))
+	}
+	return &PointBabyJubjub{value}
+}
+
+func (p *PointBabyJubjub) Equal(rhs Point) bool {
+	r, ok := rhs.(*PointBabyJubjub)
+	if ok {
+		return p.value.EqualI(r.value) == 1
+	} else {
+		return false
+	}
+}
+
+func (p *PointBabyJubjub) Set(x, y *big.Int) (Point, error) {
+	xx := babyjubjubn.FpNew().SetBigInt(x).Bytes()
+	yy := babyjubjubn.FpNew().SetBigInt(y).Bytes()
+
+	var affine [64]byte
+	copy(affine[:32], xx[:])
+	copy(affine[32:], yy[:])
+	return p.FromAffineUncompressed(affine[:])
+}
+
+// ToAffineCompressed returns the 32 byte encoding used by circomlib and go-iden3-crypto.
+func (p *PointBabyJubjub) ToAffineCompressed() []byte {
+	t := p.value.Compress()
+	return t[:]
+}
+
+// ToAffineUncompressed returns the little endian x and y coordinates
+// as 64 bytes x || y.
+func (p *PointBabyJubjub) ToAffineUncompressed() []byte {
+	affine := p.value.ToAffine()
+	x := affine.X.Bytes()
+	y := affine.Y.Bytes()
+	var out [64]byte
+	copy(out[:32], x[:])
+	copy(out[32:], y[:])
+	return out[:]
+}
+
+// FromAffineCompressed decodes the 32 byte encoding used by circomlib and go-iden3-crypto.
+// Points outside the prime order subgroup are rejected.
+func (*PointBabyJubjub) FromAffineCompressed(input []byte) (Point, error) {
+	if len(input) != babyjubjubn.PointBytes {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	var seq babyjubjubn.CompressedPoint
+	copy(seq[:], input)
+	value, err := babyjubjubn.Decompress(&seq)
+	if err != nil {
+		return nil, err
+	}
+	if value.IsTorsionFreeI() != 1 {
+		return nil, fmt.Errorf("invalid point")
+	}
+	return &PointBabyJubjub{value}, nil
+}
+
+func (*PointBabyJubjub) FromAffineUncompressed(input []byte) (Point, error) {
+	if len(input) != 64 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	if bytes.Equal(input, make([]byte, 64)) {
+		return &PointBabyJubjub{value: babyjubjubn.PointNew().SetIdentity()}, nil
+	}
+	var xx, yy [32]byte
+	copy(xx[:], input[:32])
+	copy(yy[:], input[32:])
+	x, err := babyjubjubn.FpNew().SetBytes(&xx)
+	if err != nil {
+		return nil, err
+	}
+	y, err := babyjubjubn.FpNew().SetBytes(&yy)
+	if err != nil {
+		return nil, err
+	}
+	value := (&babyjubjubn.AffinePoint{X: x, Y: y}).ToExtended()
+	if value.IsOnCurve() != 1 || value.IsTorsionFreeI() != 1 {
+		return nil, fmt.Errorf("invalid point")
+	}
+	return &PointBabyJubjub{value}, nil
+}
+
+func (*PointBabyJubjub) CurveName() string {
+	return BabyJubjubName
+}
+
+func (*PointBabyJubjub) SumOfProducts(points []Point, scalars []Scalar) Point {
+	nPoints := make([]*babyjubjubn.ExtendedPoint, len(points))
+	nScalars := make([]*native.Field4, len(scalars))
+	for i, pt := range points {
+		pp, ok := pt.(*PointBabyJubjub)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = pp.value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarBabyJubjub)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.Value
+	}
+	value, err := babyjubjubn.PointNew().SumOfProducts(nPoints, nScalars)
+	if err != nil {
+		return nil
+	}
+	return &PointBabyJubjub{value}
+}
+
+func (p *PointBabyJubjub) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point {
+	return p.VarTimeMultiScalarMult([]Scalar{a, b}, []Point{capA, p.Generator()})
+}
+
+func (p *PointBabyJubjub) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	return varTimeMultiScalarMult(p, scalars, points)
+}
+
+func (*PointBabyJubjub) batchNormalize(points []Point) []Point {
+	nPoints := make([]*babyjubjubn.ExtendedPoint, len(points))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointBabyJubjub)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	out := make([]Point, len(points))
+	for i, value := range babyjubjubn.BatchNormalize(nPoints) {
+		out[i] = &PointBabyJubjub{value}
+	}
+	return out
+}
+
+func (p *PointBabyJubjub) X() *big.Int {
+	return p.value.ToAffine().X.BigInt()
+}
+
+func (p *PointBabyJubjub) Y() *big.Int {
+	return p.value.ToAffine().Y.BigInt()
+}
+
+func (*PointBabyJubjub) Modulus() *big.Int {
+	return babyjubjubn.FpNew().Params.BiModulus
+}
+
+// GetX returns the affine x coordinate as an element of the baby jubjub base field.
+func (p *PointBabyJubjub) GetX() *native.Field4 {
+	return p.value.ToAffine().X
+}
+
+// GetY returns the affine y coordinate as an element of the baby jubjub base field.
+func (p *PointBabyJubjub) GetY() *native.Field4 {
+	return p.value.ToAffine().Y
+}
+
+func (p *PointBabyJubjub) GetExtendedPoint() *babyjubjubn.ExtendedPoint {
+	return babyjubjubn.PointNew().Set(p.value)
+}
+
+// SetExtendedPoint wraps pt without checking if it is in the prime order subgroup.
+func (*PointBabyJubjub) SetExtendedPoint(pt *babyjubjubn.ExtendedPoint) *PointBabyJubjub {
+	return &PointBabyJubjub{value: babyjubjubn.PointNew().Set(pt)}
+}
+
+func (p *PointBabyJubjub) MarshalBinary() ([]byte, error) {
+	return PointMarshalBinary(p)
+}
+
+func (p *PointBabyJubjub) UnmarshalBinary(input []byte) error {
+	pt, err := PointUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointBabyJubjub)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointBabyJubjub) MarshalText() ([]byte, error) {
+	return PointMarshalText(p)
+}
+
+func (p *PointBabyJubjub) UnmarshalText(input []byte) error {
+	pt, err := PointUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointBabyJubjub)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointBabyJubjub) MarshalJSON() ([]byte, error) {
+	return PointMarshalJSON(p)
+}
+
+func (p *PointBabyJubjub) UnmarshalJSON(input []byte) error {
+	pt, err := PointUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	P, ok := pt.(*PointBabyJubjub)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	p.value = P.value
+	return nil
+}
+
+// BabyJubjubBase reinterprets the scalar as an element of the baby jubjub base field.
+func (s *ScalarBn254) BabyJubjubBase() *native.Field4 {
+	return babyjubjubn.FpNew().Set(s.Value)
+}
+
+// SetBabyJubjubBase reinterprets a baby jubjub base field element as a BN254 scalar.
+func (s *ScalarBn254) SetBabyJubjubBase(f *native.Field4) (*ScalarBn254, error) {
+	if f == nil || f.Params.Modulus != bn254.FqNew().Params.Modulus {
+		return nil, fmt.Errorf("invalid field element")
+	}
+	return &ScalarBn254{
+		Value: bn254.FqNew().Set(f),
+		point: s.point,
+	}, nil
+}
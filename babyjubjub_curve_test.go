@@ -0,0 +1,194 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikelodder7/curvey/internal"
+	babyjubjubn "github.com/mikelodder7/curvey/native/babyjubjub"
+)
+
+func TestScalarBabyJubjubSerialize(t *testing.T) {
+	curve := BabyJubjub()
+	for i := 0; i < 25; i++ {
+		s := curve.Scalar.Random(crand.Reader)
+		b := s.Bytes()
+		require.Equal(t, 32, len(b))
+		s2, err := curve.Scalar.SetBytes(b)
+		require.NoError(t, err)
+		require.Equal(t, 0, s.Cmp(s2))
+	}
+	modulus := babyjubjubn.FqNew().Params.BiModulus
+	_, err := curve.Scalar.SetBytes(internal.ReverseBytes(modulus.FillBytes(make([]byte, 32))))
+	require.Error(t, err)
+
+	sc := curve.Scalar.Hash(nil)
+	require.Equal(t, 0, sc.BigInt().Cmp(bhex("02cb90a2a480510716417b9bd5d9e60aec4535f8aaa4d3fc3df66e5935dc7881")))
+
+	one := curve.Scalar.One()
+	require.True(t, curve.Scalar.New(-1).Add(one).IsZero())
+	inv, err := curve.Scalar.New(7).Invert()
+	require.NoError(t, err)
+	require.True(t, inv.Mul(curve.Scalar.New(7)).IsOne())
+}
+
+func TestPointBabyJubjubAddDoubleMul(t *testing.T) {
+	curve := BabyJubjub()
+	g := curve.NewGeneratorPoint()
+	id := curve.NewIdentityPoint()
+	require.True(t, g.Add(id).Equal(g))
+	require.True(t, g.IsOnCurve())
+
+	g2 := g.Add(g)
+	require.True(t, g.Double().Equal(g2))
+	g3 := g.Add(g2)
+	require.True(t, g3.Equal(g.Mul(curve.Scalar.New(3))))
+	g4 := g3.Add(g)
+	require.True(t, g4.Equal(g2.Double()))
+	require.True(t, g4.Equal(g.Mul(curve.Scalar.New(4))))
+
+	require.True(t, g.Mul(curve.Scalar.New(-1)).Equal(g.Neg()))
+	require.True(t, g.Sub(g).IsIdentity())
+
+	k, err := curve.Scalar.SetBigInt(bhex("1234567890abcdef1234567890abcdef1234567890abcdef"))
+	require.NoError(t, err)
+	pt := curve.ScalarBaseMult(k)
+	require.Equal(t, "9f98a221acc21894b8a55699d932ab18e46b1e32c6771f2e6468923766e54b08", hex.EncodeToString(pt.ToAffineCompressed()))
+}
+
+func TestPointBabyJubjubGenerator(t *testing.T) {
+	g := BabyJubjub().NewGeneratorPoint().(*PointBabyJubjub)
+	require.Equal(t, "8b7d2d877a253c4b7733e1b91f05e0fcedf96bd11c2e572549b2a0f703727925", hex.EncodeToString(g.ToAffineCompressed()))
+	require.Equal(t, 0, g.X().Cmp(bhex("0bb77a6ad63e739b4eacb2e09d6277c12ab8d8010534e0b62893f3f6bb957051")))
+	require.Equal(t, 0, g.Y().Cmp(bhex("25797203f7a0b24925572e1cd16bf9edfce0051fb9e133774b3c257a872d7d8b")))
+	require.True(t, g.IsTorsionFree())
+	require.False(t, g.IsSmallOrder())
+}
+
+func TestPointBabyJubjubHash(t *testing.T) {
+	curve := BabyJubjub()
+	h0 := curve.Point.Hash(nil)
+	require.True(t, h0.IsOnCurve())
+	require.Equal(t, "17ab9a4dc8e9524cbe23a49a93dad9bbedc48315bac0a42f3182d67f6cf437b0", hex.EncodeToString(h0.ToAffineCompressed()))
+	h1 := curve.Point.Hash([]byte("abc"))
+	require.True(t, h1.IsOnCurve())
+	require.True(t, h1.(*PointBabyJubjub).IsTorsionFree())
+	require.False(t, h0.Equal(h1))
+}
+
+func TestPointBabyJubjubSerialize(t *testing.T) {
+	curve := BabyJubjub()
+	g := curve.NewGeneratorPoint()
+
+	for i := 0; i < 25; i++ {
+		s := curve.Scalar.Random(crand.Reader)
+		pt := g.Mul(s)
+		cmprs := pt.ToAffineCompressed()
+		require.Equal(t, 32, len(cmprs))
+		retC, err := curve.Point.FromAffineCompressed(cmprs)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(retC))
+
+		un := pt.ToAffineUncompressed()
+		require.Equal(t, 64, len(un))
+		retU, err := curve.Point.FromAffineUncompressed(un)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(retU))
+	}
+
+	// The full group generator is on the curve but not in the subgroup
+	full, _ := hex.DecodeString("010000fc647df850245c6e1e12fa0c4a175660a06d11146e0a684cb89c13190c")
+	_, err := curve.Point.FromAffineCompressed(full)
+	require.Error(t, err)
+
+	// The sign bit is rejected when x = 0
+	id := curve.NewIdentityPoint().ToAffineCompressed()
+	id[31] |= 0x80
+	_, err = curve.Point.FromAffineCompressed(id)
+	require.Error(t, err)
+}
+
+func TestPointBabyJubjubCofactor(t *testing.T) {
+	var enc babyjubjubn.CompressedPoint
+	bb, _ := hex.DecodeString("010000fc647df850245c6e1e12fa0c4a175660a06d11146e0a684cb89c13190c")
+	copy(enc[:], bb)
+	full, err := babyjubjubn.Decompress(&enc)
+	require.NoError(t, err)
+	pt := new(PointBabyJubjub).SetExtendedPoint(full)
+	require.True(t, pt.IsOnCurve())
+	require.False(t, pt.IsTorsionFree())
+	require.False(t, pt.IsSmallOrder())
+
+	cleared := pt.ClearCofactor()
+	require.True(t, cleared.IsTorsionFree())
+	require.True(t, cleared.Equal(BabyJubjub().NewGeneratorPoint()))
+
+	// (0, -1) has order 2
+	bb, _ = hex.DecodeString("000000f093f5e1439170b97948e833285d588181b64550b829a031e1724e6430")
+	copy(enc[:], bb)
+	order2, err := babyjubjubn.Decompress(&enc)
+	require.NoError(t, err)
+	small := new(PointBabyJubjub).SetExtendedPoint(order2)
+	require.True(t, small.IsOnCurve())
+	require.True(t, small.IsSmallOrder())
+	require.False(t, small.IsTorsionFree())
+	require.True(t, small.ClearCofactor().IsIdentity())
+}
+
+func TestPointBabyJubjubSumOfProducts(t *testing.T) {
+	curve := BabyJubjub()
+	lhs := curve.ScalarBaseMult(curve.NewScalar().New(50))
+	points := make([]Point, 5)
+	for i := range points {
+		points[i] = curve.NewGeneratorPoint()
+	}
+	scalars := []Scalar{
+		new(ScalarBabyJubjub).New(8),
+		new(ScalarBabyJubjub).New(9),
+		new(ScalarBabyJubjub).New(10),
+		new(ScalarBabyJubjub).New(11),
+		new(ScalarBabyJubjub).New(12),
+	}
+	rhs := lhs.SumOfProducts(points, scalars)
+	require.NotNil(t, rhs)
+	require.True(t, lhs.Equal(rhs))
+}
+
+func TestPointBabyJubjubMarshal(t *testing.T) {
+	pt := BabyJubjub().Point.Random(crand.Reader).(*PointBabyJubjub)
+	b, err := pt.MarshalBinary()
+	require.NoError(t, err)
+	pt2 := new(PointBabyJubjub)
+	require.NoError(t, pt2.UnmarshalBinary(b))
+	require.True(t, pt.Equal(pt2))
+
+	j, err := pt.MarshalJSON()
+	require.NoError(t, err)
+	pt3 := new(PointBabyJubjub)
+	require.NoError(t, pt3.UnmarshalJSON(j))
+	require.True(t, pt.Equal(pt3))
+	require.Equal(t, BabyJubjubName, GetCurveByName(BabyJubjubName).Name)
+}
+
+func TestScalarBn254BabyJubjubBase(t *testing.T) {
+	// Baby jubjub coordinates are BN254 scalars
+	pt := BabyJubjub().Point.Random(crand.Reader).(*PointBabyJubjub)
+	sc, err := new(ScalarBn254).SetBabyJubjubBase(pt.GetX())
+	require.NoError(t, err)
+	require.Equal(t, 0, sc.BigInt().Cmp(pt.X()))
+	require.Equal(t, 1, sc.BabyJubjubBase().Equal(pt.GetX()))
+
+	bn := BN254G1().Scalar.Random(crand.Reader).(*ScalarBn254)
+	require.Equal(t, 0, bn.BabyJubjubBase().BigInt().Cmp(bn.BigInt()))
+
+	// The baby jubjub scalar field is a different field
+	_, err = new(ScalarBn254).SetBabyJubjubBase(babyjubjubn.FqNew().SetOne())
+	require.Error(t, err)
+}
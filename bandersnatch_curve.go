@@ -0,0 +1,615 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+	bandersnatchn "github.com/mikelodder7/curvey/native/bandersnatch"
+)
+
+// ScalarBandersnatch is an element of the field defined by the
+// order of the bandersnatch prime order subgroup.
+type ScalarBandersnatch struct {
+	Value *native.Field4
+}
+
+func (s *ScalarBandersnatch) Random(reader io.Reader) Scalar {
+	if reader == nil {
+		return nil
+	}
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return s.Hash(seed[:])
+}
+
+func (*ScalarBandersnatch) Hash(bytes []byte) Scalar {
+	dst := []byte("bandersnatch_XMD:SHA-256_ELL2_RO_")
+	xmd := native.ExpandMsgXmd(native.EllipticPointHasherSha256(), bytes, dst, 48)
+	var t [64]byte
+	copy(t[:48], internal.ReverseBytes(xmd))
+	return &ScalarBandersnatch{
+		Value: bandersnatchn.FqNew().SetBytesWide(&t),
+	}
+}
+
+func (*ScalarBandersnatch) Zero() Scalar {
+	return &ScalarBandersnatch{
+		Value: bandersnatchn.FqNew().SetZero(),
+	}
+}
+
+func (*ScalarBandersnatch) One() Scalar {
+	return &ScalarBandersnatch{
+		Value: bandersnatchn.FqNew().SetOne(),
+	}
+}
+
+func (s *ScalarBandersnatch) IsZero() bool {
+	return s.Value.IsZero() == 1
+}
+
+func (s *ScalarBandersnatch) IsOne() bool {
+	return s.Value.IsOne() == 1
+}
+
+func (s *ScalarBandersnatch) IsOdd() bool {
+	return (s.Value.Bytes()[0] & 1) == 1
+}
+
+func (s *ScalarBandersnatch) IsEven() bool {
+	return (s.Value.Bytes()[0] & 1) == 0
+}
+
+func (*ScalarBandersnatch) New(value int) Scalar {
+	t := bandersnatchn.FqNew()
+	v := big.NewInt(int64(value))
+	if value < 0 {
+		v.Mod(v, t.Params.BiModulus)
+	}
+	return &ScalarBandersnatch{
+		Value: t.SetBigInt(v),
+	}
+}
+
+func (s *ScalarBandersnatch) Cmp(rhs Scalar) int {
+	r, ok := rhs.(*ScalarBandersnatch)
+	if ok {
+		return s.Value.Cmp(r.Value)
+	} else {
+		return -2
+	}
+}
+
+func (s *ScalarBandersnatch) Square() Scalar {
+	return &ScalarBandersnatch{
+		Value: bandersnatchn.FqNew().Square(s.Value),
+	}
+}
+
+func (s *ScalarBandersnatch) Pow(exp uint64) Scalar {
+	expFieldLimb := [native.Field4Limbs]uint64{exp, 0, 0, 0}
+	out := ScalarBandersnatch{Value: bandersnatchn.FqNew()}
+	native.Pow(&out.Value.Value, &s.Value.Value, &expFieldLimb, s.Value.Params, s.Value.Arithmetic)
+	return &ScalarBandersnatch{
+		Value: out.Value,
+	}
+}
+
+func (s *ScalarBandersnatch) Double() Scalar {
+	return &ScalarBandersnatch{
+		Value: bandersnatchn.FqNew().Double(s.Value),
+	}
+}
+
+func (s *ScalarBandersnatch) Invert() (Scalar, error) {
+	value, wasInverted := bandersnatchn.FqNew().Invert(s.Value)
+	if !wasInverted {
+		return nil, fmt.Errorf("inverse doesn't exist")
+	}
+	return &ScalarBandersnatch{
+		value,
+	}, nil
+}
+
+func (s *ScalarBandersnatch) Sqrt() (Scalar, error) {
+	value, wasSquare := bandersnatchn.FqNew().Sqrt(s.Value)
+	if !wasSquare {
+		return nil, fmt.Errorf("not a square")
+	}
+	return &ScalarBandersnatch{
+		value,
+	}, nil
+}
+
+func (s *ScalarBandersnatch) Cube() Scalar {
+	value := bandersnatchn.FqNew().Square(s.Value)
+	value.Mul(value, s.Value)
+	return &ScalarBandersnatch{
+		value,
+	}
+}
+
+func (s *ScalarBandersnatch) Add(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBandersnatch)
+	if ok {
+		return &ScalarBandersnatch{
+			Value: bandersnatchn.FqNew().Add(s.Value, r.Value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBandersnatch) Sub(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBandersnatch)
+	if ok {
+		return &ScalarBandersnatch{
+			Value: bandersnatchn.FqNew().Sub(s.Value, r.Value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBandersnatch) Mul(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBandersnatch)
+	if ok {
+		return &ScalarBandersnatch{
+			Value: bandersnatchn.FqNew().Mul(s.Value, r.Value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBandersnatch) MulAdd(y, z Scalar) Scalar {
+	return s.Mul(y).Add(z)
+}
+
+func (s *ScalarBandersnatch) Div(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBandersnatch)
+	if ok {
+		v, wasInverted := bandersnatchn.FqNew().Invert(r.Value)
+		if !wasInverted {
+			return nil
+		}
+		v.Mul(v, s.Value)
+		return &ScalarBandersnatch{Value: v}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBandersnatch) Neg() Scalar {
+	return &ScalarBandersnatch{
+		Value: bandersnatchn.FqNew().Neg(s.Value),
+	}
+}
+
+func (*ScalarBandersnatch) SetBigInt(v *big.Int) (Scalar, error) {
+	return &ScalarBandersnatch{
+		Value: bandersnatchn.FqNew().SetBigInt(v),
+	}, nil
+}
+
+func (s *ScalarBandersnatch) BigInt() *big.Int {
+	return s.Value.BigInt()
+}
+
+// Bytes returns the big endian encoding of the scalar.
+func (s *ScalarBandersnatch) Bytes() []byte {
+	t := s.Value.Bytes()
+	return internal.ReverseBytes(t[:])
+}
+
+// SetBytes decodes a 32 byte big endian scalar.
+func (*ScalarBandersnatch) SetBytes(bytes []byte) (Scalar, error) {
+	if len(bytes) != 32 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [32]byte
+	copy(seq[:], internal.ReverseBytes(bytes))
+	value, err := bandersnatchn.FqNew().SetBytes(&seq)
+	if err != nil {
+		return nil, err
+	}
+	return &ScalarBandersnatch{
+		value,
+	}, nil
+}
+
+func (*ScalarBandersnatch) SetBytesWide(bytes []byte) (Scalar, error) {
+	if len(bytes) != 64 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [64]byte
+	copy(seq[:], bytes)
+	return &ScalarBandersnatch{
+		Value: bandersnatchn.FqNew().SetBytesWide(&seq),
+	}, nil
+}
+
+func (*ScalarBandersnatch) Point() Point {
+	return new(PointBandersnatch).Identity()
+}
+
+func (s *ScalarBandersnatch) Clone() Scalar {
+	return &ScalarBandersnatch{
+		Value: bandersnatchn.FqNew().Set(s.Value),
+	}
+}
+
+func (s *ScalarBandersnatch) MarshalBinary() ([]byte, error) {
+	return ScalarMarshalBinary(s)
+}
+
+func (s *ScalarBandersnatch) UnmarshalBinary(input []byte) error {
+	sc, err := ScalarUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarBandersnatch)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.Value = ss.Value
+	return nil
+}
+
+func (s *ScalarBandersnatch) MarshalText() ([]byte, error) {
+	return ScalarMarshalText(s)
+}
+
+func (s *ScalarBandersnatch) UnmarshalText(input []byte) error {
+	sc, err := ScalarUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarBandersnatch)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.Value = ss.Value
+	return nil
+}
+
+func (s *ScalarBandersnatch) MarshalJSON() ([]byte, error) {
+	return ScalarMarshalJSON(s)
+}
+
+func (s *ScalarBandersnatch) UnmarshalJSON(input []byte) error {
+	sc, err := ScalarUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	S, ok := sc.(*ScalarBandersnatch)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	s.Value = S.Value
+	return nil
+}
+
+// PointBandersnatch is a point in the prime order subgroup of the bandersnatch curve
+// -5*x^2 + y^2 = 1 + d*x^2*y^2 defined over the BLS12-381 scalar field.
+// The compressed form is the banderwagon encoding which identifies
+// the points (x, y) and (-x, -y).
+type PointBandersnatch struct {
+	value *bandersnatchn.ExtendedPoint
+}
+
+func (p *PointBandersnatch) Random(reader io.Reader) Point {
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return p.Hash(seed[:])
+}
+
+// Hash uses the hash to curve suite bandersnatch_XMD:SHA-256_ELL2_RO_
+// following RFC 9380.
+func (*PointBandersnatch) Hash(bytes []byte) Point {
+	return &PointBandersnatch{
+		value: bandersnatchn.PointNew().HashWithDefaults(bytes),
+	}
+}
+
+func (*PointBandersnatch) Identity() Point {
+	return &PointBandersnatch{
+		value: bandersnatchn.PointNew().SetIdentity(),
+	}
+}
+
+func (*PointBandersnatch) Generator() Point {
+	return &PointBandersnatch{
+		value: bandersnatchn.PointNew().SetGenerator(),
+	}
+}
+
+func (p *PointBandersnatch) IsIdentity() bool {
+	return p.value.IsIdentityI() == 1
+}
+
+func (*PointBandersnatch) IsNegative() bool {
+	// Negative points don't really exist in bandersnatch
+	return false
+}
+
+func (p *PointBandersnatch) IsOnCurve() bool {
+	return p.value.IsOnCurve() == 1
+}
+
+// IsTorsionFree returns true if the point is in the prime order subgroup
+func (p *PointBandersnatch) IsTorsionFree() bool {
+	return p.value.IsTorsionFreeI() == 1
+}
+
+// IsSmallOrder returns true if the point is in the torsion subgroup of order 4
+func (p *PointBandersnatch) IsSmallOrder() bool {
+	return p.value.IsSmallOrderI() == 1
+}
+
+// ClearCofactor returns [4]p which is always in the prime order subgroup
+func (p *PointBandersnatch) ClearCofactor() *PointBandersnatch {
+	return &PointBandersnatch{value: bandersnatchn.PointNew().ClearCofactor(p.value)}
+}
+
+func (p *PointBandersnatch) Double() Point {
+	return &PointBandersnatch{value: bandersnatchn.PointNew().Double(p.value)}
+}
+
+func (*PointBandersnatch) Scalar() Scalar {
+	return new(ScalarBandersnatch).Zero()
+}
+
+func (p *PointBandersnatch) Neg() Point {
+	return &PointBandersnatch{value: bandersnatchn.PointNew().Negate(p.value)}
+}
+
+func (p *PointBandersnatch) Add(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointBandersnatch)
+	if ok {
+		return &PointBandersnatch{value: bandersnatchn.PointNew().Add(p.value, r.value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointBandersnatch) Sub(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointBandersnatch)
+	if ok {
+		return &PointBandersnatch{value: bandersnatchn.PointNew().Sub(p.value, r.value)}
+	} else {
+		return nil
+	}
+}
+
+// Mul computes the scalar multiplication using the GLV endomorphism.
+func (p *PointBandersnatch) Mul(rhs Scalar) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*ScalarBandersnatch)
+	if ok {
+		return &PointBandersnatch{value: bandersnatchn.PointNew().Mul(p.value, r.Value)}
+	} else {
+		return nil
+	}
+}
+
+func (*PointBandersnatch) selectPoint(row []Point, index int) Point {
+	value := bandersnatchn.PointNew().SetIdentity()
+	for i, pt := range row {
+		value.CMove(value, pt.(*PointBandersnatch).value, ctEqual(i+1, index))
+	}
+	return &PointBandersnatch{value}
+}
+
+func (p *PointBandersnatch) Equal(rhs Point) bool {
+	r, ok := rhs.(*PointBandersnatch)
+	if ok {
+		return p.value.EqualI(r.value) == 1
+	} else {
+		return false
+	}
+}
+
+func (p *PointBandersnatch) Set(x, y *big.Int) (Point, error) {
+	xx := bandersnatchn.FpNew().SetBigInt(x).Bytes()
+	yy := bandersnatchn.FpNew().SetBigInt(y).Bytes()
+
+	var affine [64]byte
+	copy(affine[:32], internal.ReverseBytes(xx[:]))
+	copy(affine[32:], internal.ReverseBytes(yy[:]))
+	return p.FromAffineUncompressed(affine[:])
+}
+
+// ToAffineCompressed returns the 32 byte banderwagon encoding.
+func (p *PointBandersnatch) ToAffineCompressed() []byte {
+	t := p.value.Compress()
+	return t[:]
+}
+
+// ToAffineUncompressed returns the big endian x and y coordinates
+// as 64 bytes x || y.
+func (p *PointBandersnatch) ToAffineUncompressed() []byte {
+	affine := p.value.ToAffine()
+	x := affine.X.Bytes()
+	y := affine.Y.Bytes()
+	var out [64]byte
+	copy(out[:32], internal.ReverseBytes(x[:]))
+	copy(out[32:], internal.ReverseBytes(y[:]))
+	return out[:]
+}
+
+// FromAffineCompressed decodes the 32 byte banderwagon encoding
+// into the representative in the prime order subgroup.
+func (*PointBandersnatch) FromAffineCompressed(input []byte) (Point, error) {
+	if len(input) != bandersnatchn.PointBytes {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	var seq bandersnatchn.CompressedPoint
+	copy(seq[:], input)
+	value, err := bandersnatchn.Decompress(&seq)
+	if err != nil {
+		return nil, err
+	}
+	return &PointBandersnatch{value}, nil
+}
+
+func (*PointBandersnatch) FromAffineUncompressed(input []byte) (Point, error) {
+	if len(input) != 64 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	var xx, yy [32]byte
+	copy(xx[:], internal.ReverseBytes(input[:32]))
+	copy(yy[:], internal.ReverseBytes(input[32:]))
+	x, err := bandersnatchn.FpNew().SetBytes(&xx)
+	if err != nil {
+		return nil, err
+	}
+	y, err := bandersnatchn.FpNew().SetBytes(&yy)
+	if err != nil {
+		return nil, err
+	}
+	value := (&bandersnatchn.AffinePoint{X: x, Y: y}).ToExtended()
+	if value.IsOnCurve() != 1 || value.IsTorsionFreeI() != 1 {
+		return nil, fmt.Errorf("invalid point")
+	}
+	return &PointBandersnatch{value}, nil
+}
+
+func (*PointBandersnatch) CurveName() string {
+	return BandersnatchName
+}
+
+func (*PointBandersnatch) SumOfProducts(points []Point, scalars []Scalar) Point {
+	nPoints := make([]*bandersnatchn.ExtendedPoint, len(points))
+	nScalars := make([]*native.Field4, len(scalars))
+	for i, pt := range points {
+		pp, ok := pt.(*PointBandersnatch)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = pp.value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarBandersnatch)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.Value
+	}
+	value, err := bandersnatchn.PointNew().SumOfProducts(nPoints, nScalars)
+	if err != nil {
+		return nil
+	}
+	return &PointBandersnatch{value}
+}
+
+func (p *PointBandersnatch) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point {
+	return p.VarTimeMultiScalarMult([]Scalar{a, b}, []Point{capA, p.Generator()})
+}
+
+func (p *PointBandersnatch) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	return varTimeMultiScalarMult(p, scalars, points)
+}
+
+func (*PointBandersnatch) batchNormalize(points []Point) []Point {
+	nPoints := make([]*bandersnatchn.ExtendedPoint, len(points))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointBandersnatch)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	out := make([]Point, len(points))
+	for i, value := range bandersnatchn.BatchNormalize(nPoints) {
+		out[i] = &PointBandersnatch{value}
+	}
+	return out
+}
+
+func (p *PointBandersnatch) X() *big.Int {
+	return p.value.ToAffine().X.BigInt()
+}
+
+func (p *PointBandersnatch) Y() *big.Int {
+	return p.value.ToAffine().Y.BigInt()
+}
+
+func (*PointBandersnatch) Modulus() *big.Int {
+	return bandersnatchn.FpNew().Params.BiModulus
+}
+
+func (p *PointBandersnatch) GetExtendedPoint() *bandersnatchn.ExtendedPoint {
+	return bandersnatchn.PointNew().Set(p.value)
+}
+
+// SetExtendedPoint wraps pt without checking if it is in the prime order subgroup.
+func (*PointBandersnatch) SetExtendedPoint(pt *bandersnatchn.ExtendedPoint) *PointBandersnatch {
+	return &PointBandersnatch{value: bandersnatchn.PointNew().Set(pt)}
+}
+
+func (p *PointBandersnatch) MarshalBinary() ([]byte, error) {
+	return PointMarshalBinary(p)
+}
+
+func (p *PointBandersnatch) UnmarshalBinary(input []byte) error {
+	pt, err := PointUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointBandersnatch)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointBandersnatch) MarshalText() ([]byte, error) {
+	return PointMarshalText(p)
+}
+
+func (p *PointBandersnatch) UnmarshalText(input []byte) error {
+	pt, err := PointUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointBandersnatch)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointBandersnatch) MarshalJSON() ([]byte, error) {
+	return PointMarshalJSON(p)
+}
+
+func (p *PointBandersnatch) UnmarshalJSON(input []byte) error {
+	pt, err := PointUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	P, ok := pt.(*PointBandersnatch)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	p.value = P.value
+	return nil
+}
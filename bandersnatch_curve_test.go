@@ -0,0 +1,180 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	bandersnatchn "github.com/mikelodder7/curvey/native/bandersnatch"
+)
+
+func TestScalarBandersnatchSerialize(t *testing.T) {
+	curve := Bandersnatch()
+	for i := 0; i < 25; i++ {
+		s := curve.Scalar.Random(crand.Reader)
+		b := s.Bytes()
+		require.Equal(t, 32, len(b))
+		s2, err := curve.Scalar.SetBytes(b)
+		require.NoError(t, err)
+		require.Equal(t, 0, s.Cmp(s2))
+	}
+	modulus := bandersnatchn.FqNew().Params.BiModulus
+	_, err := curve.Scalar.SetBytes(modulus.FillBytes(make([]byte, 32)))
+	require.Error(t, err)
+
+	sc := curve.Scalar.New(255)
+	require.Equal(t, byte(0xff), sc.Bytes()[31])
+
+	one := curve.Scalar.One()
+	require.True(t, curve.Scalar.New(-1).Add(one).IsZero())
+	inv, err := curve.Scalar.New(7).Invert()
+	require.NoError(t, err)
+	require.True(t, inv.Mul(curve.Scalar.New(7)).IsOne())
+}
+
+func TestScalarBandersnatchHash(t *testing.T) {
+	sc := Bandersnatch().Scalar.Hash(nil)
+	require.Equal(t, 0, sc.BigInt().Cmp(bhex("173ed27fc0d7c6e690901e036917c97f76b16f31726edf3e50db6aa29dbdde4e")))
+}
+
+func TestPointBandersnatchAddDoubleMul(t *testing.T) {
+	curve := Bandersnatch()
+	g := curve.NewGeneratorPoint()
+	id := curve.NewIdentityPoint()
+	require.True(t, g.Add(id).Equal(g))
+	require.True(t, g.IsOnCurve())
+
+	g2 := g.Add(g)
+	require.True(t, g.Double().Equal(g2))
+	g3 := g.Add(g2)
+	require.True(t, g3.Equal(g.Mul(curve.Scalar.New(3))))
+	g4 := g3.Add(g)
+	require.True(t, g4.Equal(g2.Double()))
+	require.True(t, g4.Equal(g.Mul(curve.Scalar.New(4))))
+
+	require.True(t, g.Mul(curve.Scalar.New(-1)).Equal(g.Neg()))
+	require.True(t, g.Sub(g).IsIdentity())
+
+	k, err := curve.Scalar.SetBigInt(bhex("1234567890abcdef1234567890abcdef1234567890abcdef"))
+	require.NoError(t, err)
+	pt := curve.ScalarBaseMult(k)
+	require.Equal(t, "34007b6869d102c14b398f23baa51664a604637b9ee79197b5bfa32727fb0886", hex.EncodeToString(pt.ToAffineCompressed()))
+}
+
+func TestPointBandersnatchGenerator(t *testing.T) {
+	g := Bandersnatch().NewGeneratorPoint().(*PointBandersnatch)
+	require.Equal(t, "4a2c7486fd924882bf02c6908de395122843e3e05264d7991e18e7985dad51e9", hex.EncodeToString(g.ToAffineCompressed()))
+	require.Equal(t, 0, g.X().Cmp(bhex("29c132cc2c0b34c5743711777bbe42f32b79c022ad998465e1e71866a252ae18")))
+	require.Equal(t, 0, g.Y().Cmp(bhex("2a6c669eda123e0f157d8b50badcd586358cad81eee464605e3167b6cc974166")))
+	require.True(t, g.IsTorsionFree())
+	require.False(t, g.IsSmallOrder())
+}
+
+func TestPointBandersnatchHash(t *testing.T) {
+	curve := Bandersnatch()
+	h0 := curve.Point.Hash(nil)
+	require.True(t, h0.IsOnCurve())
+	require.Equal(t, "2cd02a06b42ea3c1b4ca12ad9dd21c2113e4bffdaf61ba912eff7ccf088bbeb8", hex.EncodeToString(h0.ToAffineCompressed()))
+	h1 := curve.Point.Hash([]byte("abc"))
+	require.True(t, h1.IsOnCurve())
+	require.True(t, h1.(*PointBandersnatch).IsTorsionFree())
+	require.False(t, h0.Equal(h1))
+}
+
+func TestPointBandersnatchSerialize(t *testing.T) {
+	curve := Bandersnatch()
+	g := curve.NewGeneratorPoint()
+
+	for i := 0; i < 25; i++ {
+		s := curve.Scalar.Random(crand.Reader)
+		pt := g.Mul(s)
+		cmprs := pt.ToAffineCompressed()
+		require.Equal(t, 32, len(cmprs))
+		retC, err := curve.Point.FromAffineCompressed(cmprs)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(retC))
+
+		un := pt.ToAffineUncompressed()
+		require.Equal(t, 64, len(un))
+		retU, err := curve.Point.FromAffineUncompressed(un)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(retU))
+	}
+
+	// The identity is encoded as x = 0
+	id, err := curve.Point.FromAffineCompressed(make([]byte, 32))
+	require.NoError(t, err)
+	require.True(t, id.IsIdentity())
+
+	// x = 7 is not the encoding of a banderwagon element
+	invalid := make([]byte, 32)
+	invalid[31] = 7
+	_, err = curve.Point.FromAffineCompressed(invalid)
+	require.Error(t, err)
+}
+
+func TestPointBandersnatchCofactor(t *testing.T) {
+	g := Bandersnatch().NewGeneratorPoint().(*PointBandersnatch)
+	affine := g.GetExtendedPoint().ToAffine()
+	affine.X.Neg(affine.X)
+	affine.Y.Neg(affine.Y)
+	pt := new(PointBandersnatch).SetExtendedPoint(affine.ToExtended())
+	require.True(t, pt.IsOnCurve())
+	require.False(t, pt.IsTorsionFree())
+	require.False(t, pt.IsSmallOrder())
+	require.Equal(t, g.ToAffineCompressed(), pt.ToAffineCompressed())
+	_, err := pt.FromAffineUncompressed(pt.ToAffineUncompressed())
+	require.Error(t, err)
+
+	cleared := pt.ClearCofactor()
+	require.True(t, cleared.IsTorsionFree())
+	require.True(t, cleared.Equal(g.Mul(new(ScalarBandersnatch).New(4))))
+
+	// (0, -1) has order 2
+	small := pt.Sub(g).(*PointBandersnatch)
+	require.True(t, small.IsOnCurve())
+	require.True(t, small.IsSmallOrder())
+	require.False(t, small.IsTorsionFree())
+	require.True(t, small.ClearCofactor().IsIdentity())
+}
+
+func TestPointBandersnatchSumOfProducts(t *testing.T) {
+	curve := Bandersnatch()
+	lhs := curve.ScalarBaseMult(curve.NewScalar().New(50))
+	points := make([]Point, 5)
+	for i := range points {
+		points[i] = curve.NewGeneratorPoint()
+	}
+	scalars := []Scalar{
+		new(ScalarBandersnatch).New(8),
+		new(ScalarBandersnatch).New(9),
+		new(ScalarBandersnatch).New(10),
+		new(ScalarBandersnatch).New(11),
+		new(ScalarBandersnatch).New(12),
+	}
+	rhs := lhs.SumOfProducts(points, scalars)
+	require.NotNil(t, rhs)
+	require.True(t, lhs.Equal(rhs))
+}
+
+func TestPointBandersnatchMarshal(t *testing.T) {
+	pt := Bandersnatch().Point.Random(crand.Reader).(*PointBandersnatch)
+	b, err := pt.MarshalBinary()
+	require.NoError(t, err)
+	pt2 := new(PointBandersnatch)
+	require.NoError(t, pt2.UnmarshalBinary(b))
+	require.True(t, pt.Equal(pt2))
+
+	j, err := pt.MarshalJSON()
+	require.NoError(t, err)
+	pt3 := new(PointBandersnatch)
+	require.NoError(t, pt3.UnmarshalJSON(j))
+	require.True(t, pt.Equal(pt3))
+	require.Equal(t, BandersnatchName, GetCurveByName(BandersnatchName).Name)
+}
@@ -0,0 +1,146 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// batchNormalizer is implemented by points that can convert many points
+// to affine coordinates with a single field inversion.
+type batchNormalizer interface {
+	// batchNormalize returns the points in affine coordinates
+	// or nil if any of them are from a different curve
+	batchNormalize(points []Point) []Point
+}
+
+// BatchNormalize returns the points converted to affine coordinates using
+// Montgomery's trick so all of them together cost one field inversion.
+// Serializing the results with ToAffineCompressed or ToAffineUncompressed
+// then needs no further inversions.
+// All the points must be from the same curve. ErrUnsupported is returned
+// for points that do not implement batch normalization.
+func BatchNormalize(points []Point) ([]Point, error) {
+	if err := checkPoints(points...); err != nil {
+		return nil, err
+	}
+	if len(points) == 0 {
+		return []Point{}, nil
+	}
+	if b, ok := points[0].(batchNormalizer); ok {
+		out := b.batchNormalize(points)
+		if out == nil {
+			return nil, ErrTypeMismatch
+		}
+		return out, nil
+	}
+	t := reflect.TypeOf(points[0])
+	for _, p := range points {
+		if reflect.TypeOf(p) != t {
+			return nil, ErrTypeMismatch
+		}
+	}
+	return nil, ErrUnsupported
+}
+
+// BatchToAffineCompressed serializes the points with ToAffineCompressed
+// sharing one field inversion between them. All the points must be from
+// the same curve.
+func BatchToAffineCompressed(points []Point) ([][]byte, error) {
+	normalized, err := BatchNormalize(points)
+	if err != nil {
+		return nil, err
+	}
+	out := make([][]byte, len(normalized))
+	for i, p := range normalized {
+		out[i] = p.ToAffineCompressed()
+	}
+	return out, nil
+}
+
+// BatchToAffineUncompressed serializes the points with ToAffineUncompressed
+// sharing one field inversion between them. All the points must be from
+// the same curve.
+func BatchToAffineUncompressed(points []Point) ([][]byte, error) {
+	normalized, err := BatchNormalize(points)
+	if err != nil {
+		return nil, err
+	}
+	out := make([][]byte, len(normalized))
+	for i, p := range normalized {
+		out[i] = p.ToAffineUncompressed()
+	}
+	return out, nil
+}
+
+// BatchFromAffineCompressed deserializes every input with
+// p.FromAffineCompressed. The first invalid input is reported
+// with its index and none of the points are returned.
+func BatchFromAffineCompressed(p Point, inputs [][]byte) ([]Point, error) {
+	if err := checkPoints(p); err != nil {
+		return nil, err
+	}
+	return batchDecode(inputs, p.FromAffineCompressed)
+}
+
+// BatchFromAffineUncompressed deserializes every input with
+// p.FromAffineUncompressed. The first invalid input is reported
+// with its index and none of the points are returned.
+func BatchFromAffineUncompressed(p Point, inputs [][]byte) ([]Point, error) {
+	if err := checkPoints(p); err != nil {
+		return nil, err
+	}
+	return batchDecode(inputs, p.FromAffineUncompressed)
+}
+
+func batchDecode(inputs [][]byte, decode func([]byte) (Point, error)) ([]Point, error) {
+	out := make([]Point, len(inputs))
+	for i, input := range inputs {
+		p, err := decode(input)
+		if err != nil {
+			return nil, fmt.Errorf("point %d: %w", i, err)
+		}
+		if isNil(p) {
+			return nil, fmt.Errorf("point %d: invalid encoding", i)
+		}
+		out[i] = p
+	}
+	return out, nil
+}
+
+// BatchInvert returns the inverse of every scalar using Montgomery's trick
+// so only one scalar inversion is needed. An error wrapping ErrNotInvertible
+// is returned if any scalar is zero. All the scalars must be from the same field.
+func BatchInvert(scalars []Scalar) ([]Scalar, error) {
+	if err := checkScalars(scalars...); err != nil {
+		return nil, err
+	}
+	out := make([]Scalar, len(scalars))
+	if len(scalars) == 0 {
+		return out, nil
+	}
+	// out[i] is the product of the scalars before i
+	acc := scalars[0].One()
+	for i, s := range scalars {
+		if s.IsZero() {
+			return nil, fmt.Errorf("%w: scalar %d is zero", ErrNotInvertible, i)
+		}
+		out[i] = acc
+		acc = acc.Mul(s)
+		if isNil(acc) {
+			return nil, ErrTypeMismatch
+		}
+	}
+	inv, err := ScalarInvertE(acc)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(scalars) - 1; i >= 0; i-- {
+		out[i] = out[i].Mul(inv)
+		inv = inv.Mul(scalars[i])
+	}
+	return out, nil
+}
@@ -0,0 +1,120 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchSerializationCurves(t *testing.T) {
+	for _, name := range RegisteredCurves() {
+		curve := GetCurveByName(name)
+		points := make([]Point, 9)
+		for i := range points {
+			points[i] = curve.Point.Random(crand.Reader)
+		}
+		points[2] = curve.Point.Identity()
+		points[5] = curve.Point.Generator()
+
+		normalized, err := BatchNormalize(points)
+		require.NoError(t, err, name)
+		require.Len(t, normalized, len(points), name)
+		for i := range points {
+			require.True(t, points[i].Equal(normalized[i]), name)
+		}
+
+		compressed, err := BatchToAffineCompressed(points)
+		require.NoError(t, err, name)
+		uncompressed, err := BatchToAffineUncompressed(points)
+		require.NoError(t, err, name)
+		for i, p := range points {
+			require.Equal(t, p.ToAffineCompressed(), compressed[i], name)
+			require.Equal(t, p.ToAffineUncompressed(), uncompressed[i], name)
+		}
+
+		// The identity encoding does not round trip on every curve
+		compressed = append(compressed[:2], compressed[3:]...)
+		uncompressed = append(uncompressed[:2], uncompressed[3:]...)
+		points = append(points[:2], points[3:]...)
+		decoded, err := BatchFromAffineCompressed(curve.Point, compressed)
+		require.NoError(t, err, name)
+		for i := range points {
+			require.True(t, points[i].Equal(decoded[i]), name)
+		}
+		decoded, err = BatchFromAffineUncompressed(curve.Point, uncompressed)
+		require.NoError(t, err, name)
+		for i := range points {
+			require.True(t, points[i].Equal(decoded[i]), name)
+		}
+
+		normalized, err = BatchNormalize(nil)
+		require.NoError(t, err, name)
+		require.Empty(t, normalized, name)
+	}
+}
+
+func TestBatchSerializationErrors(t *testing.T) {
+	_, err := BatchNormalize([]Point{K256().Point.Generator(), nil})
+	require.ErrorIs(t, err, ErrNilArgument)
+	_, err = BatchNormalize([]Point{K256().Point.Generator(), P256().Point.Generator()})
+	require.ErrorIs(t, err, ErrTypeMismatch)
+	_, err = BatchNormalize([]Point{testPlainPoint{K256().Point.Generator()}})
+	require.ErrorIs(t, err, ErrUnsupported)
+	_, err = BatchToAffineCompressed([]Point{testPlainPoint{K256().Point.Generator()}})
+	require.ErrorIs(t, err, ErrUnsupported)
+	_, err = BatchNormalize([]Point{testPlainPoint{K256().Point.Generator()}, K256().Point.Generator()})
+	require.ErrorIs(t, err, ErrTypeMismatch)
+	_, err = BatchToAffineCompressed([]Point{ED25519().Point.Generator(), ED448().Point.Generator()})
+	require.ErrorIs(t, err, ErrTypeMismatch)
+	_, err = BatchToAffineUncompressed([]Point{BLS12381G1().Point.Generator(), BLS12381G2().Point.Generator()})
+	require.ErrorIs(t, err, ErrTypeMismatch)
+
+	compressed, err := BatchToAffineCompressed([]Point{K256().Point.Generator(), K256().Point.Generator()})
+	require.NoError(t, err)
+	compressed[1] = compressed[1][1:]
+	_, err = BatchFromAffineCompressed(K256().Point, compressed)
+	require.ErrorContains(t, err, "point 1")
+	_, err = BatchFromAffineCompressed(nil, compressed)
+	require.ErrorIs(t, err, ErrNilArgument)
+
+	uncompressed, err := BatchToAffineUncompressed([]Point{P256().Point.Generator(), P256().Point.Generator()})
+	require.NoError(t, err)
+	uncompressed[0] = uncompressed[0][1:]
+	_, err = BatchFromAffineUncompressed(P256().Point, uncompressed)
+	require.ErrorContains(t, err, "point 0")
+}
+
+func TestBatchInvert(t *testing.T) {
+	for _, name := range RegisteredCurves() {
+		curve := GetCurveByName(name)
+		scalars := make([]Scalar, 7)
+		for i := range scalars {
+			scalars[i] = curve.Scalar.Random(crand.Reader)
+		}
+		scalars[3] = curve.Scalar.One()
+		inverses, err := BatchInvert(scalars)
+		require.NoError(t, err, name)
+		for i, s := range scalars {
+			expected, err := s.Invert()
+			require.NoError(t, err, name)
+			require.Equal(t, 0, expected.Cmp(inverses[i]), name)
+		}
+
+		scalars[4] = curve.Scalar.Zero()
+		_, err = BatchInvert(scalars)
+		require.ErrorIs(t, err, ErrNotInvertible, name)
+	}
+
+	inverses, err := BatchInvert(nil)
+	require.NoError(t, err)
+	require.Empty(t, inverses)
+	_, err = BatchInvert([]Scalar{K256().Scalar.One(), nil})
+	require.ErrorIs(t, err, ErrNilArgument)
+	_, err = BatchInvert([]Scalar{K256().Scalar.One(), P256().Scalar.One()})
+	require.ErrorIs(t, err, ErrTypeMismatch)
+}
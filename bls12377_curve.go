@@ -0,0 +1,1434 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+	"github.com/mikelodder7/curvey/native/bls12377"
+)
+
+var bls12377modulus = bhex("01ae3a4617c510eac63b05c06ca1493b1a22d9f300f5138f1ef3622fba094800170b5d44300000008508c00000000001")
+
+type ScalarBls12377 struct {
+	Value *native.Field4
+	point Point
+}
+
+type PointBls12377G1 struct {
+	Value *bls12377.G1
+}
+
+type PointBls12377G2 struct {
+	Value *bls12377.G2
+}
+
+type ScalarBls12377Gt struct {
+	Value *bls12377.Gt
+}
+
+// PointBls12377Gt exists for convenience if a point is needed
+// for dealing with a scalar
+type PointBls12377Gt struct {
+	Value *bls12377.Gt
+}
+
+func (s *ScalarBls12377) Random(reader io.Reader) Scalar {
+	if reader == nil {
+		return nil
+	}
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return s.Hash(seed[:])
+}
+
+func (s *ScalarBls12377) Hash(bytes []byte) Scalar {
+	dst := []byte("BLS12377_XMD:SHA-256_SVDW_RO_")
+	xmd := native.ExpandMsgXmd(native.EllipticPointHasherSha256(), bytes, dst, 48)
+	var t [64]byte
+	copy(t[:48], internal.ReverseBytes(xmd))
+
+	return &ScalarBls12377{
+		Value: bls12377.FqNew().SetBytesWide(&t),
+		point: s.point,
+	}
+}
+
+func (s *ScalarBls12377) Zero() Scalar {
+	return &ScalarBls12377{
+		Value: bls12377.FqNew().SetZero(),
+		point: s.point,
+	}
+}
+
+func (s *ScalarBls12377) One() Scalar {
+	return &ScalarBls12377{
+		Value: bls12377.FqNew().SetOne(),
+		point: s.point,
+	}
+}
+
+func (s *ScalarBls12377) IsZero() bool {
+	return s.Value.IsZero() == 1
+}
+
+func (s *ScalarBls12377) IsOne() bool {
+	return s.Value.IsOne() == 1
+}
+
+func (s *ScalarBls12377) IsOdd() bool {
+	bytes := s.Value.Bytes()
+	return bytes[0]&1 == 1
+}
+
+func (s *ScalarBls12377) IsEven() bool {
+	bytes := s.Value.Bytes()
+	return bytes[0]&1 == 0
+}
+
+func (s *ScalarBls12377) New(value int) Scalar {
+	t := bls12377.FqNew()
+	v := big.NewInt(int64(value))
+	if value < 0 {
+		v.Mod(v, t.Params.BiModulus)
+	}
+	return &ScalarBls12377{
+		Value: t.SetBigInt(v),
+		point: s.point,
+	}
+}
+
+func (s *ScalarBls12377) Cmp(rhs Scalar) int {
+	r, ok := rhs.(*ScalarBls12377)
+	if ok {
+		return s.Value.Cmp(r.Value)
+	} else {
+		return -2
+	}
+}
+
+func (s *ScalarBls12377) Square() Scalar {
+	return &ScalarBls12377{
+		Value: bls12377.FqNew().Square(s.Value),
+		point: s.point,
+	}
+}
+
+func (s *ScalarBls12377) Pow(exp uint64) Scalar {
+	expFieldLimb := [native.Field4Limbs]uint64{exp, 0, 0, 0}
+	out := ScalarBls12377{Value: bls12377.FqNew(), point: s.point}
+	native.Pow(&out.Value.Value, &s.Value.Value, &expFieldLimb, s.Value.Params, s.Value.Arithmetic)
+	return &ScalarBls12377{
+		Value: out.Value,
+	}
+}
+
+func (s *ScalarBls12377) Double() Scalar {
+	v := bls12377.FqNew().Double(s.Value)
+	return &ScalarBls12377{
+		Value: v,
+		point: s.point,
+	}
+}
+
+func (s *ScalarBls12377) Invert() (Scalar, error) {
+	value, wasInverted := bls12377.FqNew().Invert(s.Value)
+	if !wasInverted {
+		return nil, fmt.Errorf("inverse doesn't exist")
+	}
+	return &ScalarBls12377{
+		Value: value,
+		point: s.point,
+	}, nil
+}
+
+func (s *ScalarBls12377) Sqrt() (Scalar, error) {
+	value, wasSquare := bls12377.FqNew().Sqrt(s.Value)
+	if !wasSquare {
+		return nil, fmt.Errorf("not a square")
+	}
+	return &ScalarBls12377{
+		Value: value,
+		point: s.point,
+	}, nil
+}
+
+func (s *ScalarBls12377) Cube() Scalar {
+	value := bls12377.FqNew().Square(s.Value)
+	value.Mul(value, s.Value)
+	return &ScalarBls12377{
+		Value: value,
+		point: s.point,
+	}
+}
+
+func (s *ScalarBls12377) Add(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBls12377)
+	if ok {
+		return &ScalarBls12377{
+			Value: bls12377.FqNew().Add(s.Value, r.Value),
+			point: s.point,
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBls12377) Sub(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBls12377)
+	if ok {
+		return &ScalarBls12377{
+			Value: bls12377.FqNew().Sub(s.Value, r.Value),
+			point: s.point,
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBls12377) Mul(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBls12377)
+	if ok {
+		return &ScalarBls12377{
+			Value: bls12377.FqNew().Mul(s.Value, r.Value),
+			point: s.point,
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBls12377) MulAdd(y, z Scalar) Scalar {
+	return s.Mul(y).Add(z)
+}
+
+func (s *ScalarBls12377) Div(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBls12377)
+	if ok {
+		v, wasInverted := bls12377.FqNew().Invert(r.Value)
+		if !wasInverted {
+			return nil
+		}
+		v.Mul(v, s.Value)
+		return &ScalarBls12377{
+			Value: v,
+			point: s.point,
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBls12377) Neg() Scalar {
+	return &ScalarBls12377{
+		Value: bls12377.FqNew().Neg(s.Value),
+		point: s.point,
+	}
+}
+
+func (s *ScalarBls12377) SetBigInt(v *big.Int) (Scalar, error) {
+	if v == nil {
+		return nil, fmt.Errorf("invalid value")
+	}
+	return &ScalarBls12377{
+		Value: bls12377.FqNew().SetBigInt(v),
+		point: s.point,
+	}, nil
+}
+
+func (s *ScalarBls12377) BigInt() *big.Int {
+	return s.Value.BigInt()
+}
+
+func (s *ScalarBls12377) Bytes() []byte {
+	t := s.Value.Bytes()
+	return internal.ReverseBytes(t[:])
+}
+
+func (s *ScalarBls12377) SetBytes(bytes []byte) (Scalar, error) {
+	if len(bytes) != 32 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [32]byte
+	copy(seq[:], internal.ReverseBytes(bytes))
+	value, err := bls12377.FqNew().SetBytes(&seq)
+	if err != nil {
+		return nil, err
+	}
+	return &ScalarBls12377{
+		value, s.point,
+	}, nil
+}
+
+func (s *ScalarBls12377) SetBytesWide(bytes []byte) (Scalar, error) {
+	if len(bytes) != 64 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [64]byte
+	copy(seq[:], bytes)
+	return &ScalarBls12377{
+		bls12377.FqNew().SetBytesWide(&seq), s.point,
+	}, nil
+}
+
+func (s *ScalarBls12377) Point() Point {
+	return s.point.Identity()
+}
+
+func (s *ScalarBls12377) Clone() Scalar {
+	return &ScalarBls12377{
+		Value: bls12377.FqNew().Set(s.Value),
+		point: s.point,
+	}
+}
+
+func (s *ScalarBls12377) SetPoint(p Point) PairingScalar {
+	return &ScalarBls12377{
+		Value: bls12377.FqNew().Set(s.Value),
+		point: p,
+	}
+}
+
+func (s *ScalarBls12377) Order() *big.Int {
+	return s.Value.Params.BiModulus
+}
+
+func (s *ScalarBls12377) MarshalBinary() ([]byte, error) {
+	return ScalarMarshalBinary(s)
+}
+
+func (s *ScalarBls12377) UnmarshalBinary(input []byte) error {
+	sc, err := ScalarUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarBls12377)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.Value = ss.Value
+	s.point = ss.point
+	return nil
+}
+
+func (s *ScalarBls12377) MarshalText() ([]byte, error) {
+	return ScalarMarshalText(s)
+}
+
+func (s *ScalarBls12377) UnmarshalText(input []byte) error {
+	sc, err := ScalarUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarBls12377)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.Value = ss.Value
+	s.point = ss.point
+	return nil
+}
+
+func (s *ScalarBls12377) MarshalJSON() ([]byte, error) {
+	return ScalarMarshalJSON(s)
+}
+
+func (s *ScalarBls12377) UnmarshalJSON(input []byte) error {
+	sc, err := ScalarUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	S, ok := sc.(*ScalarBls12377)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	s.Value = S.Value
+	return nil
+}
+
+func (p *PointBls12377G1) Random(reader io.Reader) Point {
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return p.Hash(seed[:])
+}
+
+func (*PointBls12377G1) Hash(bytes []byte) Point {
+	domain := []byte("BLS12377G1_XMD:SHA-256_SVDW_RO_")
+	pt := new(bls12377.G1).Hash(native.EllipticPointHasherSha256(), bytes, domain)
+	return &PointBls12377G1{Value: pt}
+}
+
+func (*PointBls12377G1) Identity() Point {
+	return &PointBls12377G1{
+		Value: new(bls12377.G1).Identity(),
+	}
+}
+
+func (*PointBls12377G1) Generator() Point {
+	return &PointBls12377G1{
+		Value: new(bls12377.G1).Generator(),
+	}
+}
+
+func (p *PointBls12377G1) IsIdentity() bool {
+	return p.Value.IsIdentity() == 1
+}
+
+func (p *PointBls12377G1) IsNegative() bool {
+	// The sort flag of the compressed form is set when
+	// the `y` coordinate is the lexicographically largest root
+	return (p.Value.ToCompressed()[0]>>5)&1 == 1
+}
+
+func (p *PointBls12377G1) IsOnCurve() bool {
+	return p.Value.IsOnCurve() == 1
+}
+
+func (p *PointBls12377G1) Double() Point {
+	return &PointBls12377G1{new(bls12377.G1).Double(p.Value)}
+}
+
+func (*PointBls12377G1) Scalar() Scalar {
+	return &ScalarBls12377{
+		Value: bls12377.FqNew(),
+		point: new(PointBls12377G1),
+	}
+}
+
+func (p *PointBls12377G1) Neg() Point {
+	return &PointBls12377G1{new(bls12377.G1).Neg(p.Value)}
+}
+
+func (p *PointBls12377G1) Add(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointBls12377G1)
+	if ok {
+		return &PointBls12377G1{new(bls12377.G1).Add(p.Value, r.Value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointBls12377G1) Sub(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointBls12377G1)
+	if ok {
+		return &PointBls12377G1{new(bls12377.G1).Sub(p.Value, r.Value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointBls12377G1) Mul(rhs Scalar) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*ScalarBls12377)
+	if ok {
+		return &PointBls12377G1{new(bls12377.G1).Mul(p.Value, r.Value)}
+	} else {
+		return nil
+	}
+}
+
+func (*PointBls12377G1) selectPoint(row []Point, index int) Point {
+	value := new(bls12377.G1).Identity()
+	for i, pt := range row {
+		value.CMove(value, pt.(*PointBls12377G1).Value, ctEqual(i+1, index))
+	}
+	return &PointBls12377G1{value}
+}
+
+func (p *PointBls12377G1) Equal(rhs Point) bool {
+	r, ok := rhs.(*PointBls12377G1)
+	if ok {
+		return p.Value.Equal(r.Value) == 1
+	} else {
+		return false
+	}
+}
+
+func (*PointBls12377G1) Set(x, y *big.Int) (Point, error) {
+	value, err := new(bls12377.G1).SetBigInt(x, y)
+	if err != nil {
+		return nil, fmt.Errorf("invalid coordinates")
+	}
+	return &PointBls12377G1{value}, nil
+}
+
+func (p *PointBls12377G1) ToAffineCompressed() []byte {
+	out := p.Value.ToCompressed()
+	return out[:]
+}
+
+func (p *PointBls12377G1) ToAffineUncompressed() []byte {
+	out := p.Value.ToUncompressed()
+	return out[:]
+}
+
+func (*PointBls12377G1) FromAffineCompressed(bytes []byte) (Point, error) {
+	var b [bls12377.FieldBytes]byte
+	copy(b[:], bytes)
+	value, err := new(bls12377.G1).FromCompressed(&b)
+	if err != nil {
+		return nil, err
+	}
+	return &PointBls12377G1{value}, nil
+}
+
+func (*PointBls12377G1) FromAffineUncompressed(bytes []byte) (Point, error) {
+	var b [bls12377.WideFieldBytes]byte
+	copy(b[:], bytes)
+	value, err := new(bls12377.G1).FromUncompressed(&b)
+	if err != nil {
+		return nil, err
+	}
+	return &PointBls12377G1{value}, nil
+}
+
+func (*PointBls12377G1) CurveName() string {
+	return "BLS12377G1"
+}
+
+func (*PointBls12377G1) SumOfProducts(points []Point, scalars []Scalar) Point {
+	nPoints := make([]*bls12377.G1, len(points))
+	nScalars := make([]*native.Field4, len(scalars))
+	for i, pt := range points {
+		pp, ok := pt.(*PointBls12377G1)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = pp.Value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarBls12377)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.Value
+	}
+	value, err := new(bls12377.G1).SumOfProducts(nPoints, nScalars)
+	if err != nil {
+		return nil
+	}
+	return &PointBls12377G1{value}
+}
+
+func (*PointBls12377G1) sumOfProductsVarTime(points []Point, scalars []Scalar, opts *MsmOptions) Point {
+	nPoints := make([]*bls12377.G1, len(points))
+	nScalars := make([]*native.Field4, len(scalars))
+	for i, pt := range points {
+		pp, ok := pt.(*PointBls12377G1)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = pp.Value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarBls12377)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.Value
+	}
+	value, err := new(bls12377.G1).MsmVarTime(nPoints, nScalars, opts)
+	if err != nil {
+		return nil
+	}
+	return &PointBls12377G1{value}
+}
+
+func (p *PointBls12377G1) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point {
+	return p.VarTimeMultiScalarMult([]Scalar{a, b}, []Point{capA, p.Generator()})
+}
+
+func (p *PointBls12377G1) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	return p.sumOfProductsVarTime(points, scalars, nil)
+}
+
+func (*PointBls12377G1) batchNormalize(points []Point) []Point {
+	nPoints := make([]*bls12377.G1, len(points))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointBls12377G1)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.Value
+	}
+	out := make([]Point, len(points))
+	for i, value := range bls12377.BatchToAffineG1(nPoints) {
+		out[i] = &PointBls12377G1{value}
+	}
+	return out
+}
+
+func (*PointBls12377G1) OtherGroup() PairingPoint {
+	pairingPoint, ok := new(PointBls12377G2).Identity().(PairingPoint)
+	if !ok {
+		return nil
+	}
+	return pairingPoint
+}
+
+func (p *PointBls12377G1) Pairing(rhs PairingPoint) Scalar {
+	pt, ok := rhs.(*PointBls12377G2)
+	if !ok {
+		return nil
+	}
+	e := new(bls12377.Engine)
+	e.AddPair(p.Value, pt.Value)
+
+	value := e.Result()
+
+	return &ScalarBls12377Gt{value}
+}
+
+func (*PointBls12377G1) MultiPairing(points ...PairingPoint) Scalar {
+	return bls12377MultiPairing(points...)
+}
+
+func (p *PointBls12377G1) X() *big.Int {
+	return p.Value.GetX().BigInt()
+}
+
+func (p *PointBls12377G1) Y() *big.Int {
+	return p.Value.GetY().BigInt()
+}
+
+func (*PointBls12377G1) Modulus() *big.Int {
+	return bls12377modulus
+}
+
+func (p *PointBls12377G1) MarshalBinary() ([]byte, error) {
+	return PointMarshalBinary(p)
+}
+
+func (p *PointBls12377G1) UnmarshalBinary(input []byte) error {
+	pt, err := PointUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointBls12377G1)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.Value = ppt.Value
+	return nil
+}
+
+func (p *PointBls12377G1) MarshalText() ([]byte, error) {
+	return PointMarshalText(p)
+}
+
+func (p *PointBls12377G1) UnmarshalText(input []byte) error {
+	pt, err := PointUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointBls12377G1)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.Value = ppt.Value
+	return nil
+}
+
+func (p *PointBls12377G1) MarshalJSON() ([]byte, error) {
+	return PointMarshalJSON(p)
+}
+
+func (p *PointBls12377G1) UnmarshalJSON(input []byte) error {
+	pt, err := PointUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	P, ok := pt.(*PointBls12377G1)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	p.Value = P.Value
+	return nil
+}
+
+func (p *PointBls12377G2) Random(reader io.Reader) Point {
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return p.Hash(seed[:])
+}
+
+func (*PointBls12377G2) Hash(bytes []byte) Point {
+	domain := []byte("BLS12377G2_XMD:SHA-256_SVDW_RO_")
+	pt := new(bls12377.G2).Hash(native.EllipticPointHasherSha256(), bytes, domain)
+	return &PointBls12377G2{Value: pt}
+}
+
+func (*PointBls12377G2) Identity() Point {
+	return &PointBls12377G2{
+		Value: new(bls12377.G2).Identity(),
+	}
+}
+
+func (*PointBls12377G2) Generator() Point {
+	return &PointBls12377G2{
+		Value: new(bls12377.G2).Generator(),
+	}
+}
+
+func (p *PointBls12377G2) IsIdentity() bool {
+	return p.Value.IsIdentity() == 1
+}
+
+func (p *PointBls12377G2) IsNegative() bool {
+	// The sort flag of the compressed form is set when
+	// the `y` coordinate is the lexicographically largest root
+	return (p.Value.ToCompressed()[0]>>5)&1 == 1
+}
+
+func (p *PointBls12377G2) IsOnCurve() bool {
+	return p.Value.IsOnCurve() == 1
+}
+
+func (p *PointBls12377G2) Double() Point {
+	return &PointBls12377G2{new(bls12377.G2).Double(p.Value)}
+}
+
+func (*PointBls12377G2) Scalar() Scalar {
+	return &ScalarBls12377{
+		Value: bls12377.FqNew(),
+		point: new(PointBls12377G2),
+	}
+}
+
+func (p *PointBls12377G2) Neg() Point {
+	return &PointBls12377G2{new(bls12377.G2).Neg(p.Value)}
+}
+
+func (p *PointBls12377G2) Add(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointBls12377G2)
+	if ok {
+		return &PointBls12377G2{new(bls12377.G2).Add(p.Value, r.Value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointBls12377G2) Sub(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointBls12377G2)
+	if ok {
+		return &PointBls12377G2{new(bls12377.G2).Sub(p.Value, r.Value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointBls12377G2) Mul(rhs Scalar) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*ScalarBls12377)
+	if ok {
+		return &PointBls12377G2{new(bls12377.G2).Mul(p.Value, r.Value)}
+	} else {
+		return nil
+	}
+}
+
+func (*PointBls12377G2) selectPoint(row []Point, index int) Point {
+	value := new(bls12377.G2).Identity()
+	for i, pt := range row {
+		value.CMove(value, pt.(*PointBls12377G2).Value, ctEqual(i+1, index))
+	}
+	return &PointBls12377G2{value}
+}
+
+func (p *PointBls12377G2) Equal(rhs Point) bool {
+	r, ok := rhs.(*PointBls12377G2)
+	if ok {
+		return p.Value.Equal(r.Value) == 1
+	} else {
+		return false
+	}
+}
+
+func (*PointBls12377G2) Set(x, y *big.Int) (Point, error) {
+	value, err := new(bls12377.G2).SetBigInt(x, y)
+	if err != nil {
+		return nil, fmt.Errorf("invalid coordinates")
+	}
+	return &PointBls12377G2{value}, nil
+}
+
+func (p *PointBls12377G2) ToAffineCompressed() []byte {
+	out := p.Value.ToCompressed()
+	return out[:]
+}
+
+func (p *PointBls12377G2) ToAffineUncompressed() []byte {
+	out := p.Value.ToUncompressed()
+	return out[:]
+}
+
+func (*PointBls12377G2) FromAffineCompressed(x []byte) (Point, error) {
+	var b [bls12377.WideFieldBytes]byte
+	copy(b[:], x)
+	value, err := new(bls12377.G2).FromCompressed(&b)
+	if err != nil {
+		return nil, err
+	}
+	return &PointBls12377G2{value}, nil
+}
+
+func (*PointBls12377G2) FromAffineUncompressed(x []byte) (Point, error) {
+	var b [bls12377.DoubleWideFieldBytes]byte
+	copy(b[:], x)
+	value, err := new(bls12377.G2).FromUncompressed(&b)
+	if err != nil {
+		return nil, err
+	}
+	return &PointBls12377G2{value}, nil
+}
+
+func (*PointBls12377G2) CurveName() string {
+	return "BLS12377G2"
+}
+
+func (*PointBls12377G2) SumOfProducts(points []Point, scalars []Scalar) Point {
+	nPoints := make([]*bls12377.G2, len(points))
+	nScalars := make([]*native.Field4, len(scalars))
+	for i, pt := range points {
+		pp, ok := pt.(*PointBls12377G2)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = pp.Value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarBls12377)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.Value
+	}
+	value, err := new(bls12377.G2).SumOfProducts(nPoints, nScalars)
+	if err != nil {
+		return nil
+	}
+	return &PointBls12377G2{value}
+}
+
+func (*PointBls12377G2) sumOfProductsVarTime(points []Point, scalars []Scalar, opts *MsmOptions) Point {
+	nPoints := make([]*bls12377.G2, len(points))
+	nScalars := make([]*native.Field4, len(scalars))
+	for i, pt := range points {
+		pp, ok := pt.(*PointBls12377G2)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = pp.Value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarBls12377)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.Value
+	}
+	value, err := new(bls12377.G2).MsmVarTime(nPoints, nScalars, opts)
+	if err != nil {
+		return nil
+	}
+	return &PointBls12377G2{value}
+}
+
+func (p *PointBls12377G2) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point {
+	return p.VarTimeMultiScalarMult([]Scalar{a, b}, []Point{capA, p.Generator()})
+}
+
+func (p *PointBls12377G2) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	return p.sumOfProductsVarTime(points, scalars, nil)
+}
+
+func (*PointBls12377G2) batchNormalize(points []Point) []Point {
+	nPoints := make([]*bls12377.G2, len(points))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointBls12377G2)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.Value
+	}
+	out := make([]Point, len(points))
+	for i, value := range bls12377.BatchToAffineG2(nPoints) {
+		out[i] = &PointBls12377G2{value}
+	}
+	return out
+}
+
+func (*PointBls12377G2) OtherGroup() PairingPoint {
+	pairingPoint, ok := new(PointBls12377G1).Identity().(PairingPoint)
+	if !ok {
+		return nil
+	}
+	return pairingPoint
+}
+
+func (p *PointBls12377G2) Pairing(rhs PairingPoint) Scalar {
+	pt, ok := rhs.(*PointBls12377G1)
+	if !ok {
+		return nil
+	}
+	e := new(bls12377.Engine)
+	e.AddPair(pt.Value, p.Value)
+
+	value := e.Result()
+
+	return &ScalarBls12377Gt{value}
+}
+
+func (*PointBls12377G2) MultiPairing(points ...PairingPoint) Scalar {
+	return bls12377MultiPairing(points...)
+}
+
+func (p *PointBls12377G2) X() *big.Int {
+	x := p.Value.ToUncompressed()
+	return new(big.Int).SetBytes(x[:bls12377.WideFieldBytes])
+}
+
+func (p *PointBls12377G2) Y() *big.Int {
+	y := p.Value.ToUncompressed()
+	return new(big.Int).SetBytes(y[bls12377.WideFieldBytes:])
+}
+
+func (*PointBls12377G2) Modulus() *big.Int {
+	return bls12377modulus
+}
+
+func (p *PointBls12377G2) MarshalBinary() ([]byte, error) {
+	return PointMarshalBinary(p)
+}
+
+func (p *PointBls12377G2) UnmarshalBinary(input []byte) error {
+	pt, err := PointUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointBls12377G2)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.Value = ppt.Value
+	return nil
+}
+
+func (p *PointBls12377G2) MarshalText() ([]byte, error) {
+	return PointMarshalText(p)
+}
+
+func (p *PointBls12377G2) UnmarshalText(input []byte) error {
+	pt, err := PointUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointBls12377G2)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.Value = ppt.Value
+	return nil
+}
+
+func (p *PointBls12377G2) MarshalJSON() ([]byte, error) {
+	return PointMarshalJSON(p)
+}
+
+func (p *PointBls12377G2) UnmarshalJSON(input []byte) error {
+	pt, err := PointUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	P, ok := pt.(*PointBls12377G2)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	p.Value = P.Value
+	return nil
+}
+
+func bls12377MultiPairing(points ...PairingPoint) Scalar {
+	if len(points)%2 != 0 {
+		return nil
+	}
+	valid := true
+	eng := new(bls12377.Engine)
+	for i := 0; i < len(points); i += 2 {
+		pt1, ok := points[i].(*PointBls12377G1)
+		valid = valid && ok
+		pt2, ok := points[i+1].(*PointBls12377G2)
+		valid = valid && ok
+		if valid {
+			eng.AddPair(pt1.Value, pt2.Value)
+		}
+	}
+	if !valid {
+		return nil
+	}
+
+	value := eng.Result()
+	return &ScalarBls12377Gt{value}
+}
+
+func (s *ScalarBls12377Gt) Random(reader io.Reader) Scalar {
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return s.Hash(seed[:])
+}
+
+func (s *ScalarBls12377Gt) Hash(bytes []byte) Scalar {
+	domain := []byte("BLS12377G1_XMD:SHA-256_SVDW_RO_")
+	pt1 := new(bls12377.G1).Hash(native.EllipticPointHasherSha256(), bytes, domain)
+	pt2 := new(bls12377.G2).Generator()
+	engine := new(bls12377.Engine)
+	engine.AddPair(pt1, pt2)
+	return &ScalarBls12377Gt{Value: engine.Result()}
+}
+
+func (*ScalarBls12377Gt) Zero() Scalar {
+	return &ScalarBls12377Gt{new(bls12377.Gt)}
+}
+
+func (*ScalarBls12377Gt) One() Scalar {
+	return &ScalarBls12377Gt{new(bls12377.Gt).SetOne()}
+}
+
+func (s *ScalarBls12377Gt) IsZero() bool {
+	return s.Value.IsZero() == 1
+}
+
+func (s *ScalarBls12377Gt) IsOne() bool {
+	return s.Value.IsOne() == 1
+}
+
+func (s *ScalarBls12377Gt) MarshalBinary() ([]byte, error) {
+	return ScalarMarshalBinary(s)
+}
+
+func (s *ScalarBls12377Gt) UnmarshalBinary(input []byte) error {
+	sc, err := ScalarUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarBls12377Gt)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.Value = ss.Value
+	return nil
+}
+
+func (s *ScalarBls12377Gt) MarshalText() ([]byte, error) {
+	return ScalarMarshalText(s)
+}
+
+func (s *ScalarBls12377Gt) UnmarshalText(input []byte) error {
+	sc, err := ScalarUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarBls12377Gt)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.Value = ss.Value
+	return nil
+}
+
+func (s *ScalarBls12377Gt) MarshalJSON() ([]byte, error) {
+	return ScalarMarshalJSON(s)
+}
+
+func (s *ScalarBls12377Gt) UnmarshalJSON(input []byte) error {
+	sc, err := ScalarUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	S, ok := sc.(*ScalarBls12377Gt)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	s.Value = S.Value
+	return nil
+}
+
+func (s *ScalarBls12377Gt) IsOdd() bool {
+	data := s.Value.Bytes()
+	return data[0]&1 == 1
+}
+
+func (s *ScalarBls12377Gt) IsEven() bool {
+	data := s.Value.Bytes()
+	return data[0]&1 == 0
+}
+
+func (*ScalarBls12377Gt) New(input int) Scalar {
+	var data [bls12377.GtFieldBytes]byte
+	data[3] = byte(input >> 24 & 0xFF)
+	data[2] = byte(input >> 16 & 0xFF)
+	data[1] = byte(input >> 8 & 0xFF)
+	data[0] = byte(input & 0xFF)
+
+	value, isCanonical := new(bls12377.Gt).SetBytes(&data)
+	if isCanonical != 1 {
+		return nil
+	}
+	return &ScalarBls12377Gt{value}
+}
+
+func (s *ScalarBls12377Gt) Cmp(rhs Scalar) int {
+	r, ok := rhs.(*ScalarBls12377Gt)
+	if ok && s.Value.Equal(r.Value) == 1 {
+		return 0
+	} else {
+		return -2
+	}
+}
+
+func (s *ScalarBls12377Gt) Square() Scalar {
+	return &ScalarBls12377Gt{
+		new(bls12377.Gt).Square(s.Value),
+	}
+}
+
+func (s *ScalarBls12377Gt) Pow(exp uint64) Scalar {
+	out := s.Clone()
+
+	for j := 63; j >= 0; j-- {
+		square := out.Square()
+		squareMul := square.Mul(square)
+		out = cSelect(out, square, squareMul, (exp>>j)&1)
+	}
+
+	return out
+}
+
+func (s *ScalarBls12377Gt) Double() Scalar {
+	return &ScalarBls12377Gt{
+		new(bls12377.Gt).Double(s.Value),
+	}
+}
+
+func (s *ScalarBls12377Gt) Invert() (Scalar, error) {
+	value, wasInverted := new(bls12377.Gt).Invert(s.Value)
+	if wasInverted != 1 {
+		return nil, fmt.Errorf("not invertible")
+	}
+	return &ScalarBls12377Gt{
+		value,
+	}, nil
+}
+
+func (*ScalarBls12377Gt) Sqrt() (Scalar, error) {
+	// Not implemented
+	return nil, nil
+}
+
+func (s *ScalarBls12377Gt) Cube() Scalar {
+	value := new(bls12377.Gt).Square(s.Value)
+	value.Add(value, s.Value)
+	return &ScalarBls12377Gt{
+		value,
+	}
+}
+
+func (s *ScalarBls12377Gt) Add(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBls12377Gt)
+	if ok {
+		return &ScalarBls12377Gt{
+			new(bls12377.Gt).Add(s.Value, r.Value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBls12377Gt) Sub(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBls12377Gt)
+	if ok {
+		return &ScalarBls12377Gt{
+			new(bls12377.Gt).Sub(s.Value, r.Value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBls12377Gt) Mul(rhs Scalar) Scalar {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*ScalarBls12377)
+	if ok {
+		return &ScalarBls12377Gt{
+			new(bls12377.Gt).Mul(s.Value, r.Value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBls12377Gt) MulAdd(y, z Scalar) Scalar {
+	return s.Mul(y).Add(z)
+}
+
+func (s *ScalarBls12377Gt) Div(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBls12377Gt)
+	if ok {
+		return &ScalarBls12377Gt{
+			new(bls12377.Gt).Sub(s.Value, r.Value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBls12377Gt) Neg() Scalar {
+	return &ScalarBls12377Gt{
+		new(bls12377.Gt).Neg(s.Value),
+	}
+}
+
+func (s *ScalarBls12377Gt) SetBigInt(v *big.Int) (Scalar, error) {
+	var bytes [bls12377.GtFieldBytes]byte
+	v.FillBytes(bytes[:])
+	return s.SetBytes(bytes[:])
+}
+
+func (s *ScalarBls12377Gt) BigInt() *big.Int {
+	bytes := s.Value.Bytes()
+	return new(big.Int).SetBytes(bytes[:])
+}
+
+func (*ScalarBls12377Gt) Point() Point {
+	return new(PointBls12377Gt).Identity()
+}
+
+func (s *ScalarBls12377Gt) Bytes() []byte {
+	bytes := s.Value.Bytes()
+	return bytes[:]
+}
+
+func (*ScalarBls12377Gt) SetBytes(bytes []byte) (Scalar, error) {
+	var b [bls12377.GtFieldBytes]byte
+	copy(b[:], bytes)
+	ss, isCanonical := new(bls12377.Gt).SetBytes(&b)
+	if isCanonical == 0 {
+		return nil, fmt.Errorf("invalid bytes")
+	}
+	return &ScalarBls12377Gt{ss}, nil
+}
+
+func (*ScalarBls12377Gt) SetBytesWide(bytes []byte) (Scalar, error) {
+	if l := len(bytes); l != bls12377.GtFieldBytes*2 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	var b [bls12377.GtFieldBytes]byte
+	copy(b[:], bytes[:bls12377.GtFieldBytes])
+
+	value, isCanonical := new(bls12377.Gt).SetBytes(&b)
+	if isCanonical == 0 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	copy(b[:], bytes[bls12377.GtFieldBytes:])
+	value2, isCanonical := new(bls12377.Gt).SetBytes(&b)
+	if isCanonical == 0 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	value.Add(value, value2)
+	return &ScalarBls12377Gt{value}, nil
+}
+
+func (s *ScalarBls12377Gt) Clone() Scalar {
+	return &ScalarBls12377Gt{
+		Value: new(bls12377.Gt).Set(s.Value),
+	}
+}
+
+func (p *PointBls12377Gt) Random(reader io.Reader) Point {
+	s := new(ScalarBls12377Gt).Random(reader).(*ScalarBls12377Gt)
+	return &PointBls12377Gt{Value: s.Value}
+}
+
+func (p *PointBls12377Gt) Hash(bytes []byte) Point {
+	s := new(ScalarBls12377Gt).Hash(bytes).(*ScalarBls12377Gt)
+	return &PointBls12377Gt{Value: s.Value}
+}
+
+func (p *PointBls12377Gt) Identity() Point {
+	return &PointBls12377Gt{new(bls12377.Gt).SetOne()}
+}
+
+func (p *PointBls12377Gt) Generator() Point {
+	return &PointBls12377Gt{new(bls12377.Gt).Generator()}
+}
+
+func (p *PointBls12377Gt) IsIdentity() bool {
+	return p.Value.IsOne() == 1
+}
+
+func (p *PointBls12377Gt) IsNegative() bool {
+	// Gt is unitary so there is no such thing as negative really
+	return false
+}
+
+func (p *PointBls12377Gt) IsOnCurve() bool {
+	return true
+}
+
+func (p *PointBls12377Gt) Double() Point {
+	return &PointBls12377Gt{
+		new(bls12377.Gt).Double(p.Value),
+	}
+}
+
+func (p *PointBls12377Gt) Scalar() Scalar {
+	return new(ScalarBls12377).Zero()
+}
+
+func (p *PointBls12377Gt) Neg() Point {
+	return &PointBls12377Gt{
+		new(bls12377.Gt).Neg(p.Value),
+	}
+}
+
+func (p *PointBls12377Gt) Add(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointBls12377Gt)
+	if ok {
+		return &PointBls12377Gt{new(bls12377.Gt).Add(p.Value, r.Value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointBls12377Gt) Sub(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointBls12377Gt)
+	if ok {
+		return &PointBls12377Gt{new(bls12377.Gt).Sub(p.Value, r.Value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointBls12377Gt) Mul(rhs Scalar) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*ScalarBls12377)
+	if ok {
+		return &PointBls12377Gt{new(bls12377.Gt).Mul(p.Value, r.Value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointBls12377Gt) Equal(rhs Point) bool {
+	r, ok := rhs.(*PointBls12377Gt)
+	if ok {
+		return p.Value.Equal(r.Value) == 1
+	} else {
+		return false
+	}
+}
+
+func (p *PointBls12377Gt) Set(x, y *big.Int) (Point, error) {
+	// Not implemented
+	return nil, nil
+}
+
+func (p *PointBls12377Gt) ToAffineCompressed() []byte {
+	bytes := p.Value.Bytes()
+	return bytes[:]
+}
+
+func (p *PointBls12377Gt) ToAffineUncompressed() []byte {
+	bytes := p.Value.Bytes()
+	return bytes[:]
+}
+
+func (p *PointBls12377Gt) FromAffineCompressed(bytes []byte) (Point, error) {
+	var b [bls12377.GtFieldBytes]byte
+	copy(b[:], bytes)
+	ss, isCanonical := new(bls12377.Gt).SetBytes(&b)
+	if isCanonical == 0 {
+		return nil, fmt.Errorf("invalid bytes")
+	}
+	return &PointBls12377Gt{ss}, nil
+}
+
+func (p *PointBls12377Gt) FromAffineUncompressed(bytes []byte) (Point, error) {
+	var b [bls12377.GtFieldBytes]byte
+	copy(b[:], bytes)
+	ss, isCanonical := new(bls12377.Gt).SetBytes(&b)
+	if isCanonical == 0 {
+		return nil, fmt.Errorf("invalid bytes")
+	}
+	return &PointBls12377Gt{ss}, nil
+}
+
+func (p *PointBls12377Gt) CurveName() string {
+	return BLS12377G1Name
+}
+
+func (p *PointBls12377Gt) SumOfProducts(points []Point, scalars []Scalar) Point {
+	nPoints := make([]*bls12377.Gt, len(points))
+	nScalars := make([]*native.Field4, len(scalars))
+
+	for i, pt := range points {
+		pp, ok := pt.(*PointBls12377Gt)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = pp.Value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarBls12377)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.Value
+	}
+	result := new(bls12377.Gt).SetOne()
+	for i, pt := range nPoints {
+		t := new(bls12377.Gt).Mul(pt, nScalars[i])
+		result.Add(result, t)
+	}
+	return &PointBls12377Gt{result}
+}
+
+func (p *PointBls12377Gt) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point {
+	return p.VarTimeMultiScalarMult([]Scalar{a, b}, []Point{capA, p.Generator()})
+}
+
+func (p *PointBls12377Gt) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	return varTimeMultiScalarMult(p, scalars, points)
+}
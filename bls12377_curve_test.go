@@ -0,0 +1,135 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScalarBls12377Serialize(t *testing.T) {
+	curve := BLS12377G1()
+	for i := 0; i < 25; i++ {
+		s := curve.Scalar.Random(crand.Reader)
+		b := s.Bytes()
+		require.Equal(t, 32, len(b))
+		s2, err := curve.Scalar.SetBytes(b)
+		require.NoError(t, err)
+		require.Equal(t, 0, s.Cmp(s2))
+	}
+	_, err := curve.Scalar.SetBytes(curve.Scalar.(*ScalarBls12377).Order().Bytes())
+	require.Error(t, err)
+
+	one := curve.Scalar.One()
+	require.True(t, curve.Scalar.New(-1).Add(one).IsZero())
+	inv, err := curve.Scalar.New(7).Invert()
+	require.NoError(t, err)
+	require.True(t, inv.Mul(curve.Scalar.New(7)).IsOne())
+
+	s := curve.Scalar.Random(crand.Reader)
+	bin, err := s.(*ScalarBls12377).MarshalBinary()
+	require.NoError(t, err)
+	s2 := new(ScalarBls12377)
+	require.NoError(t, s2.UnmarshalBinary(bin))
+	require.Equal(t, 0, s.Cmp(s2))
+}
+
+func TestPointBls12377G1Arithmetic(t *testing.T) {
+	curve := BLS12377G1()
+	g := curve.NewGeneratorPoint()
+	require.True(t, g.Add(curve.NewIdentityPoint()).Equal(g))
+	require.True(t, g.IsOnCurve())
+	require.Equal(t, 0, g.(*PointBls12377G1).X().Cmp(bhex("008848defe740a67c8fc6225bf87ff5485951e2caa9d41bb188282c8bd37cb5cd5481512ffcd394eeab9b16eb21be9ef")))
+	require.Equal(t, 0, g.(*PointBls12377G1).Y().Cmp(bhex("01914a69c5102eff1f674f5d30afeec4bd7fb348ca3e52d96d182ad44fb82305c2fe3d3634a9591afd82de55559c8ea6")))
+
+	g2 := g.Add(g)
+	require.True(t, g.Double().Equal(g2))
+	g3 := g.Add(g2)
+	require.True(t, g3.Equal(g.Mul(curve.Scalar.New(3))))
+	require.True(t, g.Mul(curve.Scalar.New(-1)).Equal(g.Neg()))
+	require.True(t, g.Sub(g).IsIdentity())
+	require.NotEqual(t, g.IsNegative(), g.Neg().IsNegative())
+}
+
+func TestPointBls12377G2Arithmetic(t *testing.T) {
+	curve := BLS12377G2()
+	g := curve.NewGeneratorPoint()
+	require.True(t, g.Add(curve.NewIdentityPoint()).Equal(g))
+	require.True(t, g.IsOnCurve())
+
+	g2 := g.Add(g)
+	require.True(t, g.Double().Equal(g2))
+	g3 := g.Add(g2)
+	require.True(t, g3.Equal(g.Mul(curve.Scalar.New(3))))
+	require.True(t, g.Mul(curve.Scalar.New(-1)).Equal(g.Neg()))
+	require.True(t, g.Sub(g).IsIdentity())
+}
+
+func TestPointBls12377Hash(t *testing.T) {
+	h := BLS12377G1().Point.Hash([]byte("abc"))
+	require.True(t, h.IsOnCurve())
+	require.Equal(t, "003c25cf6bc7b8bce28d3128a49ac38731e69d45251e50a669ab3249feb3e6cb88078d8654f0136441da6713a9baed1d008d3f81d76c770dfed9fa17f9e1b67984fb0093767b97c28beb6715426251af13f22562aeec5c387ba5f1eb883583b3", hex.EncodeToString(h.ToAffineUncompressed()))
+
+	h = BLS12377G2().Point.Hash([]byte("abc"))
+	require.True(t, h.IsOnCurve())
+	require.Equal(t, "00a6e14ef187f05c5e950b76771fe8ef89f7773a19602d5d160f8e81c24ecf03b8a7d639bcefdb33d86ac86d750b168700fcb203855d8477e22fb133071fe042fda9657ad147d2b75213031f86c9c85736cd553fde70c6246150a3f4e7b6a2b2007b71385ba856796316c62bd4318f52ae1c995d03551aae29a0ebd9c61b5cd579cb8f3fa160d8872f20f9443076100c0052190dfb3f538d63cb3e16aeea5fef199fa8e566499b5281dd3ae4ca8f326925d2c5e74b3efc4dc2261155695fd9af", hex.EncodeToString(h.ToAffineUncompressed()))
+}
+
+func TestPointBls12377Serialize(t *testing.T) {
+	for _, curve := range []*Curve{BLS12377G1(), BLS12377G2()} {
+		g := curve.NewGeneratorPoint()
+		for i := 0; i < 10; i++ {
+			pt := g.Mul(curve.Scalar.Random(crand.Reader))
+			retC, err := curve.Point.FromAffineCompressed(pt.ToAffineCompressed())
+			require.NoError(t, err)
+			require.True(t, pt.Equal(retC))
+			retU, err := curve.Point.FromAffineUncompressed(pt.ToAffineUncompressed())
+			require.NoError(t, err)
+			require.True(t, pt.Equal(retU))
+		}
+
+		// The infinity flag is the second most significant bit
+		id := curve.NewIdentityPoint().ToAffineUncompressed()
+		require.Equal(t, byte(0x40), id[0])
+		ret, err := curve.Point.FromAffineUncompressed(id)
+		require.NoError(t, err)
+		require.True(t, ret.IsIdentity())
+
+		bin, err := PointMarshalBinary(g)
+		require.NoError(t, err)
+		pt, err := PointUnmarshalBinary(bin)
+		require.NoError(t, err)
+		require.True(t, g.Equal(pt))
+	}
+	require.Equal(t, 48, len(BLS12377G1().NewGeneratorPoint().ToAffineCompressed()))
+	require.Equal(t, 96, len(BLS12377G1().NewGeneratorPoint().ToAffineUncompressed()))
+	require.Equal(t, 96, len(BLS12377G2().NewGeneratorPoint().ToAffineCompressed()))
+	require.Equal(t, 192, len(BLS12377G2().NewGeneratorPoint().ToAffineUncompressed()))
+}
+
+func TestPointBls12377Pairing(t *testing.T) {
+	curve := BLS12377(BLS12377G1().NewGeneratorPoint())
+	a := curve.Scalar.Random(crand.Reader)
+	b := curve.Scalar.Random(crand.Reader)
+
+	g1 := curve.ScalarG1BaseMult(a)
+	g2 := curve.ScalarG2BaseMult(b)
+	lhs := g1.Pairing(g2)
+	rhs := curve.NewG1GeneratorPoint().Pairing(curve.NewG2GeneratorPoint())
+	require.True(t, lhs.Cmp(rhs.Mul(a.Mul(b))) == 0)
+	require.True(t, lhs.Cmp(g2.Pairing(g1)) == 0)
+
+	// e(aG1, bG2) * e(-abG1, G2) == 1
+	ab := curve.ScalarG1BaseMult(a.Mul(b)).Neg().(PairingPoint)
+	res := g1.MultiPairing(g1, g2, ab, curve.NewG2GeneratorPoint())
+	require.True(t, res.IsOne())
+
+	require.Nil(t, g1.MultiPairing(g1))
+	require.NotNil(t, GetPairingCurveByName(BLS12377Name))
+	require.Equal(t, BLS12377G2Name, GetCurveByName(BLS12377G2Name).Name)
+}
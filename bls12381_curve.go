@@ -429,18 +429,30 @@ func (p *PointBls12381G1) Sub(rhs Point) Point {
 	}
 }
 
+// Mul multiplies p by rhs in constant time, splitting the scalar with the
+// endomorphism. That is only correct in G1, which holds for every point
+// this package can produce: Set and the decoders check the subgroup,
+// hashing clears the cofactor and the group operations stay in G1.
 func (p *PointBls12381G1) Mul(rhs Scalar) Point {
 	if rhs == nil {
 		return nil
 	}
 	r, ok := rhs.(*ScalarBls12381)
 	if ok {
-		return &PointBls12381G1{new(bls12381.G1).Mul(p.Value, r.Value)}
+		return &PointBls12381G1{new(bls12381.G1).MulGlv(p.Value, r.Value)}
 	} else {
 		return nil
 	}
 }
 
+func (*PointBls12381G1) selectPoint(row []Point, index int) Point {
+	value := new(bls12381.G1).Identity()
+	for i, pt := range row {
+		value.CMove(value, pt.(*PointBls12381G1).Value, ctEqual(i+1, index))
+	}
+	return &PointBls12381G1{value}
+}
+
 func (p *PointBls12381G1) Equal(rhs Point) bool {
 	r, ok := rhs.(*PointBls12381G1)
 	if ok {
@@ -492,6 +504,7 @@ func (*PointBls12381G1) CurveName() string {
 	return "BLS12381G1"
 }
 
+// SumOfProducts uses the endomorphism like Mul.
 func (*PointBls12381G1) SumOfProducts(points []Point, scalars []Scalar) Point {
 	nPoints := make([]*bls12381.G1, len(points))
 	nScalars := make([]*native.Field4, len(scalars))
@@ -509,13 +522,61 @@ func (*PointBls12381G1) SumOfProducts(points []Point, scalars []Scalar) Point {
 		}
 		nScalars[i] = s.Value
 	}
-	value, err := new(bls12381.G1).SumOfProducts(nPoints, nScalars)
+	value, err := new(bls12381.G1).SumOfProductsGlv(nPoints, nScalars)
+	if err != nil {
+		return nil
+	}
+	return &PointBls12381G1{value}
+}
+
+func (*PointBls12381G1) sumOfProductsVarTime(points []Point, scalars []Scalar, opts *MsmOptions) Point {
+	nPoints := make([]*bls12381.G1, len(points))
+	nScalars := make([]*native.Field4, len(scalars))
+	for i, pt := range points {
+		pp, ok := pt.(*PointBls12381G1)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = pp.Value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarBls12381)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.Value
+	}
+	value, err := new(bls12381.G1).MsmVarTime(nPoints, nScalars, opts)
 	if err != nil {
 		return nil
 	}
 	return &PointBls12381G1{value}
 }
 
+func (p *PointBls12381G1) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point {
+	return p.VarTimeMultiScalarMult([]Scalar{a, b}, []Point{capA, p.Generator()})
+}
+
+func (p *PointBls12381G1) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	return p.sumOfProductsVarTime(points, scalars, nil)
+}
+
+func (*PointBls12381G1) batchNormalize(points []Point) []Point {
+	nPoints := make([]*bls12381.G1, len(points))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointBls12381G1)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.Value
+	}
+	out := make([]Point, len(points))
+	for i, value := range bls12381.BatchToAffineG1(nPoints) {
+		out[i] = &PointBls12381G1{value}
+	}
+	return out
+}
+
 func (*PointBls12381G1) OtherGroup() PairingPoint {
 	pairingPoint, ok := new(PointBls12381G2).Identity().(PairingPoint)
 	if !ok {
@@ -693,6 +754,14 @@ func (p *PointBls12381G2) Mul(rhs Scalar) Point {
 	}
 }
 
+func (*PointBls12381G2) selectPoint(row []Point, index int) Point {
+	value := new(bls12381.G2).Identity()
+	for i, pt := range row {
+		value.CMove(value, pt.(*PointBls12381G2).Value, ctEqual(i+1, index))
+	}
+	return &PointBls12381G2{value}
+}
+
 func (p *PointBls12381G2) Equal(rhs Point) bool {
 	r, ok := rhs.(*PointBls12381G2)
 	if ok {
@@ -768,6 +837,54 @@ func (*PointBls12381G2) SumOfProducts(points []Point, scalars []Scalar) Point {
 	return &PointBls12381G2{value}
 }
 
+func (*PointBls12381G2) sumOfProductsVarTime(points []Point, scalars []Scalar, opts *MsmOptions) Point {
+	nPoints := make([]*bls12381.G2, len(points))
+	nScalars := make([]*native.Field4, len(scalars))
+	for i, pt := range points {
+		pp, ok := pt.(*PointBls12381G2)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = pp.Value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarBls12381)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.Value
+	}
+	value, err := new(bls12381.G2).MsmVarTime(nPoints, nScalars, opts)
+	if err != nil {
+		return nil
+	}
+	return &PointBls12381G2{value}
+}
+
+func (p *PointBls12381G2) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point {
+	return p.VarTimeMultiScalarMult([]Scalar{a, b}, []Point{capA, p.Generator()})
+}
+
+func (p *PointBls12381G2) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	return p.sumOfProductsVarTime(points, scalars, nil)
+}
+
+func (*PointBls12381G2) batchNormalize(points []Point) []Point {
+	nPoints := make([]*bls12381.G2, len(points))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointBls12381G2)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.Value
+	}
+	out := make([]Point, len(points))
+	for i, value := range bls12381.BatchToAffineG2(nPoints) {
+		out[i] = &PointBls12381G2{value}
+	}
+	return out
+}
+
 func (*PointBls12381G2) OtherGroup() PairingPoint {
 	pairingPoint, ok := new(PointBls12381G1).Identity().(PairingPoint)
 	if !ok {
@@ -1312,3 +1429,11 @@ func (p *PointBls12381Gt) SumOfProducts(points []Point, scalars []Scalar) Point
 	}
 	return &PointBls12381Gt{result}
 }
+
+func (p *PointBls12381Gt) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point {
+	return p.VarTimeMultiScalarMult([]Scalar{a, b}, []Point{capA, p.Generator()})
+}
+
+func (p *PointBls12381Gt) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	return varTimeMultiScalarMult(p, scalars, points)
+}
@@ -451,6 +451,31 @@ func TestPointBls12381G1Mul(t *testing.T) {
 	require.True(t, g.Double().Double().Equal(pt))
 }
 
+// TestPointBls12381G1MulGlv checks the endomorphism based Mul and
+// SumOfProducts against the native multiplication that does not use it.
+func TestPointBls12381G1MulGlv(t *testing.T) {
+	bls12381G1 := BLS12381G1()
+	points := []Point{bls12381G1.Point.Generator(), bls12381G1.Point.Identity()}
+	scalars := []Scalar{bls12381G1.Scalar.Zero(), bls12381G1.Scalar.One(), bls12381G1.Scalar.One().Neg()}
+	for i := 0; i < 8; i++ {
+		points = append(points, bls12381G1.Point.Random(crand.Reader))
+		scalars = append(scalars, bls12381G1.Scalar.Random(crand.Reader))
+	}
+	for _, pt := range points {
+		p := pt.(*PointBls12381G1)
+		expected := new(bls12381.G1).Identity()
+		sum := make([]Point, 0, len(scalars))
+		for _, sc := range scalars {
+			s := sc.(*ScalarBls12381)
+			value := new(bls12381.G1).Mul(p.Value, s.Value)
+			require.True(t, p.Mul(sc).Equal(&PointBls12381G1{value}))
+			expected.Add(expected, value)
+			sum = append(sum, p)
+		}
+		require.True(t, p.SumOfProducts(sum, scalars).Equal(&PointBls12381G1{expected}))
+	}
+}
+
 func TestPointBls12381G1Serialize(t *testing.T) {
 	bls12381G1 := BLS12381G1()
 	ss := bls12381G1.Scalar.Random(testRng())
@@ -0,0 +1,1434 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+	"github.com/mikelodder7/curvey/native/bn254"
+)
+
+var bn254modulus = bhex("30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd47")
+
+type ScalarBn254 struct {
+	Value *native.Field4
+	point Point
+}
+
+type PointBn254G1 struct {
+	Value *bn254.G1
+}
+
+type PointBn254G2 struct {
+	Value *bn254.G2
+}
+
+type ScalarBn254Gt struct {
+	Value *bn254.Gt
+}
+
+// PointBn254Gt exists for convenience if a point is needed
+// for dealing with a scalar
+type PointBn254Gt struct {
+	Value *bn254.Gt
+}
+
+func (s *ScalarBn254) Random(reader io.Reader) Scalar {
+	if reader == nil {
+		return nil
+	}
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return s.Hash(seed[:])
+}
+
+func (s *ScalarBn254) Hash(bytes []byte) Scalar {
+	dst := []byte("BN254_XMD:SHA-256_SVDW_RO_")
+	xmd := native.ExpandMsgXmd(native.EllipticPointHasherSha256(), bytes, dst, 48)
+	var t [64]byte
+	copy(t[:48], internal.ReverseBytes(xmd))
+
+	return &ScalarBn254{
+		Value: bn254.FqNew().SetBytesWide(&t),
+		point: s.point,
+	}
+}
+
+func (s *ScalarBn254) Zero() Scalar {
+	return &ScalarBn254{
+		Value: bn254.FqNew().SetZero(),
+		point: s.point,
+	}
+}
+
+func (s *ScalarBn254) One() Scalar {
+	return &ScalarBn254{
+		Value: bn254.FqNew().SetOne(),
+		point: s.point,
+	}
+}
+
+func (s *ScalarBn254) IsZero() bool {
+	return s.Value.IsZero() == 1
+}
+
+func (s *ScalarBn254) IsOne() bool {
+	return s.Value.IsOne() == 1
+}
+
+func (s *ScalarBn254) IsOdd() bool {
+	bytes := s.Value.Bytes()
+	return bytes[0]&1 == 1
+}
+
+func (s *ScalarBn254) IsEven() bool {
+	bytes := s.Value.Bytes()
+	return bytes[0]&1 == 0
+}
+
+func (s *ScalarBn254) New(value int) Scalar {
+	t := bn254.FqNew()
+	v := big.NewInt(int64(value))
+	if value < 0 {
+		v.Mod(v, t.Params.BiModulus)
+	}
+	return &ScalarBn254{
+		Value: t.SetBigInt(v),
+		point: s.point,
+	}
+}
+
+func (s *ScalarBn254) Cmp(rhs Scalar) int {
+	r, ok := rhs.(*ScalarBn254)
+	if ok {
+		return s.Value.Cmp(r.Value)
+	} else {
+		return -2
+	}
+}
+
+func (s *ScalarBn254) Square() Scalar {
+	return &ScalarBn254{
+		Value: bn254.FqNew().Square(s.Value),
+		point: s.point,
+	}
+}
+
+func (s *ScalarBn254) Pow(exp uint64) Scalar {
+	expFieldLimb := [native.Field4Limbs]uint64{exp, 0, 0, 0}
+	out := ScalarBn254{Value: bn254.FqNew(), point: s.point}
+	native.Pow(&out.Value.Value, &s.Value.Value, &expFieldLimb, s.Value.Params, s.Value.Arithmetic)
+	return &ScalarBn254{
+		Value: out.Value,
+	}
+}
+
+func (s *ScalarBn254) Double() Scalar {
+	v := bn254.FqNew().Double(s.Value)
+	return &ScalarBn254{
+		Value: v,
+		point: s.point,
+	}
+}
+
+func (s *ScalarBn254) Invert() (Scalar, error) {
+	value, wasInverted := bn254.FqNew().Invert(s.Value)
+	if !wasInverted {
+		return nil, fmt.Errorf("inverse doesn't exist")
+	}
+	return &ScalarBn254{
+		Value: value,
+		point: s.point,
+	}, nil
+}
+
+func (s *ScalarBn254) Sqrt() (Scalar, error) {
+	value, wasSquare := bn254.FqNew().Sqrt(s.Value)
+	if !wasSquare {
+		return nil, fmt.Errorf("not a square")
+	}
+	return &ScalarBn254{
+		Value: value,
+		point: s.point,
+	}, nil
+}
+
+func (s *ScalarBn254) Cube() Scalar {
+	value := bn254.FqNew().Square(s.Value)
+	value.Mul(value, s.Value)
+	return &ScalarBn254{
+		Value: value,
+		point: s.point,
+	}
+}
+
+func (s *ScalarBn254) Add(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBn254)
+	if ok {
+		return &ScalarBn254{
+			Value: bn254.FqNew().Add(s.Value, r.Value),
+			point: s.point,
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBn254) Sub(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBn254)
+	if ok {
+		return &ScalarBn254{
+			Value: bn254.FqNew().Sub(s.Value, r.Value),
+			point: s.point,
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBn254) Mul(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBn254)
+	if ok {
+		return &ScalarBn254{
+			Value: bn254.FqNew().Mul(s.Value, r.Value),
+			point: s.point,
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBn254) MulAdd(y, z Scalar) Scalar {
+	return s.Mul(y).Add(z)
+}
+
+func (s *ScalarBn254) Div(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBn254)
+	if ok {
+		v, wasInverted := bn254.FqNew().Invert(r.Value)
+		if !wasInverted {
+			return nil
+		}
+		v.Mul(v, s.Value)
+		return &ScalarBn254{
+			Value: v,
+			point: s.point,
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBn254) Neg() Scalar {
+	return &ScalarBn254{
+		Value: bn254.FqNew().Neg(s.Value),
+		point: s.point,
+	}
+}
+
+func (s *ScalarBn254) SetBigInt(v *big.Int) (Scalar, error) {
+	if v == nil {
+		return nil, fmt.Errorf("invalid value")
+	}
+	return &ScalarBn254{
+		Value: bn254.FqNew().SetBigInt(v),
+		point: s.point,
+	}, nil
+}
+
+func (s *ScalarBn254) BigInt() *big.Int {
+	return s.Value.BigInt()
+}
+
+func (s *ScalarBn254) Bytes() []byte {
+	t := s.Value.Bytes()
+	return internal.ReverseBytes(t[:])
+}
+
+func (s *ScalarBn254) SetBytes(bytes []byte) (Scalar, error) {
+	if len(bytes) != 32 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [32]byte
+	copy(seq[:], internal.ReverseBytes(bytes))
+	value, err := bn254.FqNew().SetBytes(&seq)
+	if err != nil {
+		return nil, err
+	}
+	return &ScalarBn254{
+		value, s.point,
+	}, nil
+}
+
+func (s *ScalarBn254) SetBytesWide(bytes []byte) (Scalar, error) {
+	if len(bytes) != 64 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [64]byte
+	copy(seq[:], bytes)
+	return &ScalarBn254{
+		bn254.FqNew().SetBytesWide(&seq), s.point,
+	}, nil
+}
+
+func (s *ScalarBn254) Point() Point {
+	return s.point.Identity()
+}
+
+func (s *ScalarBn254) Clone() Scalar {
+	return &ScalarBn254{
+		Value: bn254.FqNew().Set(s.Value),
+		point: s.point,
+	}
+}
+
+func (s *ScalarBn254) SetPoint(p Point) PairingScalar {
+	return &ScalarBn254{
+		Value: bn254.FqNew().Set(s.Value),
+		point: p,
+	}
+}
+
+func (s *ScalarBn254) Order() *big.Int {
+	return s.Value.Params.BiModulus
+}
+
+func (s *ScalarBn254) MarshalBinary() ([]byte, error) {
+	return ScalarMarshalBinary(s)
+}
+
+func (s *ScalarBn254) UnmarshalBinary(input []byte) error {
+	sc, err := ScalarUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarBn254)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.Value = ss.Value
+	s.point = ss.point
+	return nil
+}
+
+func (s *ScalarBn254) MarshalText() ([]byte, error) {
+	return ScalarMarshalText(s)
+}
+
+func (s *ScalarBn254) UnmarshalText(input []byte) error {
+	sc, err := ScalarUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarBn254)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.Value = ss.Value
+	s.point = ss.point
+	return nil
+}
+
+func (s *ScalarBn254) MarshalJSON() ([]byte, error) {
+	return ScalarMarshalJSON(s)
+}
+
+func (s *ScalarBn254) UnmarshalJSON(input []byte) error {
+	sc, err := ScalarUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	S, ok := sc.(*ScalarBn254)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	s.Value = S.Value
+	return nil
+}
+
+func (p *PointBn254G1) Random(reader io.Reader) Point {
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return p.Hash(seed[:])
+}
+
+func (*PointBn254G1) Hash(bytes []byte) Point {
+	domain := []byte("BN254G1_XMD:SHA-256_SVDW_RO_")
+	pt := new(bn254.G1).Hash(native.EllipticPointHasherSha256(), bytes, domain)
+	return &PointBn254G1{Value: pt}
+}
+
+func (*PointBn254G1) Identity() Point {
+	return &PointBn254G1{
+		Value: new(bn254.G1).Identity(),
+	}
+}
+
+func (*PointBn254G1) Generator() Point {
+	return &PointBn254G1{
+		Value: new(bn254.G1).Generator(),
+	}
+}
+
+func (p *PointBn254G1) IsIdentity() bool {
+	return p.Value.IsIdentity() == 1
+}
+
+func (p *PointBn254G1) IsNegative() bool {
+	// The top two bits of the compressed form are 0b11 when
+	// the `y` coordinate is the lexicographically largest root
+	return p.Value.ToCompressed()[0]>>6 == 3
+}
+
+func (p *PointBn254G1) IsOnCurve() bool {
+	return p.Value.IsOnCurve() == 1
+}
+
+func (p *PointBn254G1) Double() Point {
+	return &PointBn254G1{new(bn254.G1).Double(p.Value)}
+}
+
+func (*PointBn254G1) Scalar() Scalar {
+	return &ScalarBn254{
+		Value: bn254.FqNew(),
+		point: new(PointBn254G1),
+	}
+}
+
+func (p *PointBn254G1) Neg() Point {
+	return &PointBn254G1{new(bn254.G1).Neg(p.Value)}
+}
+
+func (p *PointBn254G1) Add(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointBn254G1)
+	if ok {
+		return &PointBn254G1{new(bn254.G1).Add(p.Value, r.Value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointBn254G1) Sub(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointBn254G1)
+	if ok {
+		return &PointBn254G1{new(bn254.G1).Sub(p.Value, r.Value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointBn254G1) Mul(rhs Scalar) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*ScalarBn254)
+	if ok {
+		return &PointBn254G1{new(bn254.G1).Mul(p.Value, r.Value)}
+	} else {
+		return nil
+	}
+}
+
+func (*PointBn254G1) selectPoint(row []Point, index int) Point {
+	value := new(bn254.G1).Identity()
+	for i, pt := range row {
+		value.CMove(value, pt.(*PointBn254G1).Value, ctEqual(i+1, index))
+	}
+	return &PointBn254G1{value}
+}
+
+func (p *PointBn254G1) Equal(rhs Point) bool {
+	r, ok := rhs.(*PointBn254G1)
+	if ok {
+		return p.Value.Equal(r.Value) == 1
+	} else {
+		return false
+	}
+}
+
+func (*PointBn254G1) Set(x, y *big.Int) (Point, error) {
+	value, err := new(bn254.G1).SetBigInt(x, y)
+	if err != nil {
+		return nil, fmt.Errorf("invalid coordinates")
+	}
+	return &PointBn254G1{value}, nil
+}
+
+func (p *PointBn254G1) ToAffineCompressed() []byte {
+	out := p.Value.ToCompressed()
+	return out[:]
+}
+
+func (p *PointBn254G1) ToAffineUncompressed() []byte {
+	out := p.Value.ToUncompressed()
+	return out[:]
+}
+
+func (*PointBn254G1) FromAffineCompressed(bytes []byte) (Point, error) {
+	var b [bn254.FieldBytes]byte
+	copy(b[:], bytes)
+	value, err := new(bn254.G1).FromCompressed(&b)
+	if err != nil {
+		return nil, err
+	}
+	return &PointBn254G1{value}, nil
+}
+
+func (*PointBn254G1) FromAffineUncompressed(bytes []byte) (Point, error) {
+	var b [bn254.WideFieldBytes]byte
+	copy(b[:], bytes)
+	value, err := new(bn254.G1).FromUncompressed(&b)
+	if err != nil {
+		return nil, err
+	}
+	return &PointBn254G1{value}, nil
+}
+
+func (*PointBn254G1) CurveName() string {
+	return "BN254G1"
+}
+
+func (*PointBn254G1) SumOfProducts(points []Point, scalars []Scalar) Point {
+	nPoints := make([]*bn254.G1, len(points))
+	nScalars := make([]*native.Field4, len(scalars))
+	for i, pt := range points {
+		pp, ok := pt.(*PointBn254G1)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = pp.Value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarBn254)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.Value
+	}
+	value, err := new(bn254.G1).SumOfProducts(nPoints, nScalars)
+	if err != nil {
+		return nil
+	}
+	return &PointBn254G1{value}
+}
+
+func (*PointBn254G1) sumOfProductsVarTime(points []Point, scalars []Scalar, opts *MsmOptions) Point {
+	nPoints := make([]*bn254.G1, len(points))
+	nScalars := make([]*native.Field4, len(scalars))
+	for i, pt := range points {
+		pp, ok := pt.(*PointBn254G1)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = pp.Value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarBn254)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.Value
+	}
+	value, err := new(bn254.G1).MsmVarTime(nPoints, nScalars, opts)
+	if err != nil {
+		return nil
+	}
+	return &PointBn254G1{value}
+}
+
+func (p *PointBn254G1) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point {
+	return p.VarTimeMultiScalarMult([]Scalar{a, b}, []Point{capA, p.Generator()})
+}
+
+func (p *PointBn254G1) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	return p.sumOfProductsVarTime(points, scalars, nil)
+}
+
+func (*PointBn254G1) batchNormalize(points []Point) []Point {
+	nPoints := make([]*bn254.G1, len(points))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointBn254G1)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.Value
+	}
+	out := make([]Point, len(points))
+	for i, value := range bn254.BatchToAffineG1(nPoints) {
+		out[i] = &PointBn254G1{value}
+	}
+	return out
+}
+
+func (*PointBn254G1) OtherGroup() PairingPoint {
+	pairingPoint, ok := new(PointBn254G2).Identity().(PairingPoint)
+	if !ok {
+		return nil
+	}
+	return pairingPoint
+}
+
+func (p *PointBn254G1) Pairing(rhs PairingPoint) Scalar {
+	pt, ok := rhs.(*PointBn254G2)
+	if !ok {
+		return nil
+	}
+	e := new(bn254.Engine)
+	e.AddPair(p.Value, pt.Value)
+
+	value := e.Result()
+
+	return &ScalarBn254Gt{value}
+}
+
+func (*PointBn254G1) MultiPairing(points ...PairingPoint) Scalar {
+	return bn254MultiPairing(points...)
+}
+
+func (p *PointBn254G1) X() *big.Int {
+	return p.Value.GetX().BigInt()
+}
+
+func (p *PointBn254G1) Y() *big.Int {
+	return p.Value.GetY().BigInt()
+}
+
+func (*PointBn254G1) Modulus() *big.Int {
+	return bn254modulus
+}
+
+func (p *PointBn254G1) MarshalBinary() ([]byte, error) {
+	return PointMarshalBinary(p)
+}
+
+func (p *PointBn254G1) UnmarshalBinary(input []byte) error {
+	pt, err := PointUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointBn254G1)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.Value = ppt.Value
+	return nil
+}
+
+func (p *PointBn254G1) MarshalText() ([]byte, error) {
+	return PointMarshalText(p)
+}
+
+func (p *PointBn254G1) UnmarshalText(input []byte) error {
+	pt, err := PointUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointBn254G1)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.Value = ppt.Value
+	return nil
+}
+
+func (p *PointBn254G1) MarshalJSON() ([]byte, error) {
+	return PointMarshalJSON(p)
+}
+
+func (p *PointBn254G1) UnmarshalJSON(input []byte) error {
+	pt, err := PointUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	P, ok := pt.(*PointBn254G1)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	p.Value = P.Value
+	return nil
+}
+
+func (p *PointBn254G2) Random(reader io.Reader) Point {
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return p.Hash(seed[:])
+}
+
+func (*PointBn254G2) Hash(bytes []byte) Point {
+	domain := []byte("BN254G2_XMD:SHA-256_SVDW_RO_")
+	pt := new(bn254.G2).Hash(native.EllipticPointHasherSha256(), bytes, domain)
+	return &PointBn254G2{Value: pt}
+}
+
+func (*PointBn254G2) Identity() Point {
+	return &PointBn254G2{
+		Value: new(bn254.G2).Identity(),
+	}
+}
+
+func (*PointBn254G2) Generator() Point {
+	return &PointBn254G2{
+		Value: new(bn254.G2).Generator(),
+	}
+}
+
+func (p *PointBn254G2) IsIdentity() bool {
+	return p.Value.IsIdentity() == 1
+}
+
+func (p *PointBn254G2) IsNegative() bool {
+	// The top two bits of the compressed form are 0b11 when
+	// the `y` coordinate is the lexicographically largest root
+	return p.Value.ToCompressed()[0]>>6 == 3
+}
+
+func (p *PointBn254G2) IsOnCurve() bool {
+	return p.Value.IsOnCurve() == 1
+}
+
+func (p *PointBn254G2) Double() Point {
+	return &PointBn254G2{new(bn254.G2).Double(p.Value)}
+}
+
+func (*PointBn254G2) Scalar() Scalar {
+	return &ScalarBn254{
+		Value: bn254.FqNew(),
+		point: new(PointBn254G2),
+	}
+}
+
+func (p *PointBn254G2) Neg() Point {
+	return &PointBn254G2{new(bn254.G2).Neg(p.Value)}
+}
+
+func (p *PointBn254G2) Add(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointBn254G2)
+	if ok {
+		return &PointBn254G2{new(bn254.G2).Add(p.Value, r.Value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointBn254G2) Sub(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointBn254G2)
+	if ok {
+		return &PointBn254G2{new(bn254.G2).Sub(p.Value, r.Value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointBn254G2) Mul(rhs Scalar) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*ScalarBn254)
+	if ok {
+		return &PointBn254G2{new(bn254.G2).Mul(p.Value, r.Value)}
+	} else {
+		return nil
+	}
+}
+
+func (*PointBn254G2) selectPoint(row []Point, index int) Point {
+	value := new(bn254.G2).Identity()
+	for i, pt := range row {
+		value.CMove(value, pt.(*PointBn254G2).Value, ctEqual(i+1, index))
+	}
+	return &PointBn254G2{value}
+}
+
+func (p *PointBn254G2) Equal(rhs Point) bool {
+	r, ok := rhs.(*PointBn254G2)
+	if ok {
+		return p.Value.Equal(r.Value) == 1
+	} else {
+		return false
+	}
+}
+
+func (*PointBn254G2) Set(x, y *big.Int) (Point, error) {
+	value, err := new(bn254.G2).SetBigInt(x, y)
+	if err != nil {
+		return nil, fmt.Errorf("invalid coordinates")
+	}
+	return &PointBn254G2{value}, nil
+}
+
+func (p *PointBn254G2) ToAffineCompressed() []byte {
+	out := p.Value.ToCompressed()
+	return out[:]
+}
+
+func (p *PointBn254G2) ToAffineUncompressed() []byte {
+	out := p.Value.ToUncompressed()
+	return out[:]
+}
+
+func (*PointBn254G2) FromAffineCompressed(x []byte) (Point, error) {
+	var b [bn254.WideFieldBytes]byte
+	copy(b[:], x)
+	value, err := new(bn254.G2).FromCompressed(&b)
+	if err != nil {
+		return nil, err
+	}
+	return &PointBn254G2{value}, nil
+}
+
+func (*PointBn254G2) FromAffineUncompressed(x []byte) (Point, error) {
+	var b [bn254.DoubleWideFieldBytes]byte
+	copy(b[:], x)
+	value, err := new(bn254.G2).FromUncompressed(&b)
+	if err != nil {
+		return nil, err
+	}
+	return &PointBn254G2{value}, nil
+}
+
+func (*PointBn254G2) CurveName() string {
+	return "BN254G2"
+}
+
+func (*PointBn254G2) SumOfProducts(points []Point, scalars []Scalar) Point {
+	nPoints := make([]*bn254.G2, len(points))
+	nScalars := make([]*native.Field4, len(scalars))
+	for i, pt := range points {
+		pp, ok := pt.(*PointBn254G2)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = pp.Value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarBn254)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.Value
+	}
+	value, err := new(bn254.G2).SumOfProducts(nPoints, nScalars)
+	if err != nil {
+		return nil
+	}
+	return &PointBn254G2{value}
+}
+
+func (*PointBn254G2) sumOfProductsVarTime(points []Point, scalars []Scalar, opts *MsmOptions) Point {
+	nPoints := make([]*bn254.G2, len(points))
+	nScalars := make([]*native.Field4, len(scalars))
+	for i, pt := range points {
+		pp, ok := pt.(*PointBn254G2)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = pp.Value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarBn254)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.Value
+	}
+	value, err := new(bn254.G2).MsmVarTime(nPoints, nScalars, opts)
+	if err != nil {
+		return nil
+	}
+	return &PointBn254G2{value}
+}
+
+func (p *PointBn254G2) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point {
+	return p.VarTimeMultiScalarMult([]Scalar{a, b}, []Point{capA, p.Generator()})
+}
+
+func (p *PointBn254G2) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	return p.sumOfProductsVarTime(points, scalars, nil)
+}
+
+func (*PointBn254G2) batchNormalize(points []Point) []Point {
+	nPoints := make([]*bn254.G2, len(points))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointBn254G2)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.Value
+	}
+	out := make([]Point, len(points))
+	for i, value := range bn254.BatchToAffineG2(nPoints) {
+		out[i] = &PointBn254G2{value}
+	}
+	return out
+}
+
+func (*PointBn254G2) OtherGroup() PairingPoint {
+	pairingPoint, ok := new(PointBn254G1).Identity().(PairingPoint)
+	if !ok {
+		return nil
+	}
+	return pairingPoint
+}
+
+func (p *PointBn254G2) Pairing(rhs PairingPoint) Scalar {
+	pt, ok := rhs.(*PointBn254G1)
+	if !ok {
+		return nil
+	}
+	e := new(bn254.Engine)
+	e.AddPair(pt.Value, p.Value)
+
+	value := e.Result()
+
+	return &ScalarBn254Gt{value}
+}
+
+func (*PointBn254G2) MultiPairing(points ...PairingPoint) Scalar {
+	return bn254MultiPairing(points...)
+}
+
+func (p *PointBn254G2) X() *big.Int {
+	x := p.Value.ToUncompressed()
+	return new(big.Int).SetBytes(x[:bn254.WideFieldBytes])
+}
+
+func (p *PointBn254G2) Y() *big.Int {
+	y := p.Value.ToUncompressed()
+	return new(big.Int).SetBytes(y[bn254.WideFieldBytes:])
+}
+
+func (*PointBn254G2) Modulus() *big.Int {
+	return bn254modulus
+}
+
+func (p *PointBn254G2) MarshalBinary() ([]byte, error) {
+	return PointMarshalBinary(p)
+}
+
+func (p *PointBn254G2) UnmarshalBinary(input []byte) error {
+	pt, err := PointUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointBn254G2)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.Value = ppt.Value
+	return nil
+}
+
+func (p *PointBn254G2) MarshalText() ([]byte, error) {
+	return PointMarshalText(p)
+}
+
+func (p *PointBn254G2) UnmarshalText(input []byte) error {
+	pt, err := PointUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointBn254G2)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.Value = ppt.Value
+	return nil
+}
+
+func (p *PointBn254G2) MarshalJSON() ([]byte, error) {
+	return PointMarshalJSON(p)
+}
+
+func (p *PointBn254G2) UnmarshalJSON(input []byte) error {
+	pt, err := PointUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	P, ok := pt.(*PointBn254G2)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	p.Value = P.Value
+	return nil
+}
+
+func bn254MultiPairing(points ...PairingPoint) Scalar {
+	if len(points)%2 != 0 {
+		return nil
+	}
+	valid := true
+	eng := new(bn254.Engine)
+	for i := 0; i < len(points); i += 2 {
+		pt1, ok := points[i].(*PointBn254G1)
+		valid = valid && ok
+		pt2, ok := points[i+1].(*PointBn254G2)
+		valid = valid && ok
+		if valid {
+			eng.AddPair(pt1.Value, pt2.Value)
+		}
+	}
+	if !valid {
+		return nil
+	}
+
+	value := eng.Result()
+	return &ScalarBn254Gt{value}
+}
+
+func (s *ScalarBn254Gt) Random(reader io.Reader) Scalar {
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return s.Hash(seed[:])
+}
+
+func (s *ScalarBn254Gt) Hash(bytes []byte) Scalar {
+	domain := []byte("BN254G1_XMD:SHA-256_SVDW_RO_")
+	pt1 := new(bn254.G1).Hash(native.EllipticPointHasherSha256(), bytes, domain)
+	pt2 := new(bn254.G2).Generator()
+	engine := new(bn254.Engine)
+	engine.AddPair(pt1, pt2)
+	return &ScalarBn254Gt{Value: engine.Result()}
+}
+
+func (*ScalarBn254Gt) Zero() Scalar {
+	return &ScalarBn254Gt{new(bn254.Gt)}
+}
+
+func (*ScalarBn254Gt) One() Scalar {
+	return &ScalarBn254Gt{new(bn254.Gt).SetOne()}
+}
+
+func (s *ScalarBn254Gt) IsZero() bool {
+	return s.Value.IsZero() == 1
+}
+
+func (s *ScalarBn254Gt) IsOne() bool {
+	return s.Value.IsOne() == 1
+}
+
+func (s *ScalarBn254Gt) MarshalBinary() ([]byte, error) {
+	return ScalarMarshalBinary(s)
+}
+
+func (s *ScalarBn254Gt) UnmarshalBinary(input []byte) error {
+	sc, err := ScalarUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarBn254Gt)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.Value = ss.Value
+	return nil
+}
+
+func (s *ScalarBn254Gt) MarshalText() ([]byte, error) {
+	return ScalarMarshalText(s)
+}
+
+func (s *ScalarBn254Gt) UnmarshalText(input []byte) error {
+	sc, err := ScalarUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarBn254Gt)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.Value = ss.Value
+	return nil
+}
+
+func (s *ScalarBn254Gt) MarshalJSON() ([]byte, error) {
+	return ScalarMarshalJSON(s)
+}
+
+func (s *ScalarBn254Gt) UnmarshalJSON(input []byte) error {
+	sc, err := ScalarUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	S, ok := sc.(*ScalarBn254Gt)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	s.Value = S.Value
+	return nil
+}
+
+func (s *ScalarBn254Gt) IsOdd() bool {
+	data := s.Value.Bytes()
+	return data[0]&1 == 1
+}
+
+func (s *ScalarBn254Gt) IsEven() bool {
+	data := s.Value.Bytes()
+	return data[0]&1 == 0
+}
+
+func (*ScalarBn254Gt) New(input int) Scalar {
+	var data [bn254.GtFieldBytes]byte
+	data[3] = byte(input >> 24 & 0xFF)
+	data[2] = byte(input >> 16 & 0xFF)
+	data[1] = byte(input >> 8 & 0xFF)
+	data[0] = byte(input & 0xFF)
+
+	value, isCanonical := new(bn254.Gt).SetBytes(&data)
+	if isCanonical != 1 {
+		return nil
+	}
+	return &ScalarBn254Gt{value}
+}
+
+func (s *ScalarBn254Gt) Cmp(rhs Scalar) int {
+	r, ok := rhs.(*ScalarBn254Gt)
+	if ok && s.Value.Equal(r.Value) == 1 {
+		return 0
+	} else {
+		return -2
+	}
+}
+
+func (s *ScalarBn254Gt) Square() Scalar {
+	return &ScalarBn254Gt{
+		new(bn254.Gt).Square(s.Value),
+	}
+}
+
+func (s *ScalarBn254Gt) Pow(exp uint64) Scalar {
+	out := s.Clone()
+
+	for j := 63; j >= 0; j-- {
+		square := out.Square()
+		squareMul := square.Mul(square)
+		out = cSelect(out, square, squareMul, (exp>>j)&1)
+	}
+
+	return out
+}
+
+func (s *ScalarBn254Gt) Double() Scalar {
+	return &ScalarBn254Gt{
+		new(bn254.Gt).Double(s.Value),
+	}
+}
+
+func (s *ScalarBn254Gt) Invert() (Scalar, error) {
+	value, wasInverted := new(bn254.Gt).Invert(s.Value)
+	if wasInverted != 1 {
+		return nil, fmt.Errorf("not invertible")
+	}
+	return &ScalarBn254Gt{
+		value,
+	}, nil
+}
+
+func (*ScalarBn254Gt) Sqrt() (Scalar, error) {
+	// Not implemented
+	return nil, nil
+}
+
+func (s *ScalarBn254Gt) Cube() Scalar {
+	value := new(bn254.Gt).Square(s.Value)
+	value.Add(value, s.Value)
+	return &ScalarBn254Gt{
+		value,
+	}
+}
+
+func (s *ScalarBn254Gt) Add(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBn254Gt)
+	if ok {
+		return &ScalarBn254Gt{
+			new(bn254.Gt).Add(s.Value, r.Value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBn254Gt) Sub(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBn254Gt)
+	if ok {
+		return &ScalarBn254Gt{
+			new(bn254.Gt).Sub(s.Value, r.Value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBn254Gt) Mul(rhs Scalar) Scalar {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*ScalarBn254)
+	if ok {
+		return &ScalarBn254Gt{
+			new(bn254.Gt).Mul(s.Value, r.Value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBn254Gt) MulAdd(y, z Scalar) Scalar {
+	return s.Mul(y).Add(z)
+}
+
+func (s *ScalarBn254Gt) Div(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBn254Gt)
+	if ok {
+		return &ScalarBn254Gt{
+			new(bn254.Gt).Sub(s.Value, r.Value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBn254Gt) Neg() Scalar {
+	return &ScalarBn254Gt{
+		new(bn254.Gt).Neg(s.Value),
+	}
+}
+
+func (s *ScalarBn254Gt) SetBigInt(v *big.Int) (Scalar, error) {
+	var bytes [bn254.GtFieldBytes]byte
+	v.FillBytes(bytes[:])
+	return s.SetBytes(bytes[:])
+}
+
+func (s *ScalarBn254Gt) BigInt() *big.Int {
+	bytes := s.Value.Bytes()
+	return new(big.Int).SetBytes(bytes[:])
+}
+
+func (*ScalarBn254Gt) Point() Point {
+	return new(PointBn254Gt).Identity()
+}
+
+func (s *ScalarBn254Gt) Bytes() []byte {
+	bytes := s.Value.Bytes()
+	return bytes[:]
+}
+
+func (*ScalarBn254Gt) SetBytes(bytes []byte) (Scalar, error) {
+	var b [bn254.GtFieldBytes]byte
+	copy(b[:], bytes)
+	ss, isCanonical := new(bn254.Gt).SetBytes(&b)
+	if isCanonical == 0 {
+		return nil, fmt.Errorf("invalid bytes")
+	}
+	return &ScalarBn254Gt{ss}, nil
+}
+
+func (*ScalarBn254Gt) SetBytesWide(bytes []byte) (Scalar, error) {
+	if l := len(bytes); l != bn254.GtFieldBytes*2 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	var b [bn254.GtFieldBytes]byte
+	copy(b[:], bytes[:bn254.GtFieldBytes])
+
+	value, isCanonical := new(bn254.Gt).SetBytes(&b)
+	if isCanonical == 0 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	copy(b[:], bytes[bn254.GtFieldBytes:])
+	value2, isCanonical := new(bn254.Gt).SetBytes(&b)
+	if isCanonical == 0 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	value.Add(value, value2)
+	return &ScalarBn254Gt{value}, nil
+}
+
+func (s *ScalarBn254Gt) Clone() Scalar {
+	return &ScalarBn254Gt{
+		Value: new(bn254.Gt).Set(s.Value),
+	}
+}
+
+func (p *PointBn254Gt) Random(reader io.Reader) Point {
+	s := new(ScalarBn254Gt).Random(reader).(*ScalarBn254Gt)
+	return &PointBn254Gt{Value: s.Value}
+}
+
+func (p *PointBn254Gt) Hash(bytes []byte) Point {
+	s := new(ScalarBn254Gt).Hash(bytes).(*ScalarBn254Gt)
+	return &PointBn254Gt{Value: s.Value}
+}
+
+func (p *PointBn254Gt) Identity() Point {
+	return &PointBn254Gt{new(bn254.Gt).SetOne()}
+}
+
+func (p *PointBn254Gt) Generator() Point {
+	return &PointBn254Gt{new(bn254.Gt).Generator()}
+}
+
+func (p *PointBn254Gt) IsIdentity() bool {
+	return p.Value.IsOne() == 1
+}
+
+func (p *PointBn254Gt) IsNegative() bool {
+	// Gt is unitary so there is no such thing as negative really
+	return false
+}
+
+func (p *PointBn254Gt) IsOnCurve() bool {
+	return true
+}
+
+func (p *PointBn254Gt) Double() Point {
+	return &PointBn254Gt{
+		new(bn254.Gt).Double(p.Value),
+	}
+}
+
+func (p *PointBn254Gt) Scalar() Scalar {
+	return new(ScalarBn254).Zero()
+}
+
+func (p *PointBn254Gt) Neg() Point {
+	return &PointBn254Gt{
+		new(bn254.Gt).Neg(p.Value),
+	}
+}
+
+func (p *PointBn254Gt) Add(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointBn254Gt)
+	if ok {
+		return &PointBn254Gt{new(bn254.Gt).Add(p.Value, r.Value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointBn254Gt) Sub(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointBn254Gt)
+	if ok {
+		return &PointBn254Gt{new(bn254.Gt).Sub(p.Value, r.Value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointBn254Gt) Mul(rhs Scalar) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*ScalarBn254)
+	if ok {
+		return &PointBn254Gt{new(bn254.Gt).Mul(p.Value, r.Value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointBn254Gt) Equal(rhs Point) bool {
+	r, ok := rhs.(*PointBn254Gt)
+	if ok {
+		return p.Value.Equal(r.Value) == 1
+	} else {
+		return false
+	}
+}
+
+func (p *PointBn254Gt) Set(x, y *big.Int) (Point, error) {
+	// Not implemented
+	return nil, nil
+}
+
+func (p *PointBn254Gt) ToAffineCompressed() []byte {
+	bytes := p.Value.Bytes()
+	return bytes[:]
+}
+
+func (p *PointBn254Gt) ToAffineUncompressed() []byte {
+	bytes := p.Value.Bytes()
+	return bytes[:]
+}
+
+func (p *PointBn254Gt) FromAffineCompressed(bytes []byte) (Point, error) {
+	var b [bn254.GtFieldBytes]byte
+	copy(b[:], bytes)
+	ss, isCanonical := new(bn254.Gt).SetBytes(&b)
+	if isCanonical == 0 {
+		return nil, fmt.Errorf("invalid bytes")
+	}
+	return &PointBn254Gt{ss}, nil
+}
+
+func (p *PointBn254Gt) FromAffineUncompressed(bytes []byte) (Point, error) {
+	var b [bn254.GtFieldBytes]byte
+	copy(b[:], bytes)
+	ss, isCanonical := new(bn254.Gt).SetBytes(&b)
+	if isCanonical == 0 {
+		return nil, fmt.Errorf("invalid bytes")
+	}
+	return &PointBn254Gt{ss}, nil
+}
+
+func (p *PointBn254Gt) CurveName() string {
+	return BN254G1Name
+}
+
+func (p *PointBn254Gt) SumOfProducts(points []Point, scalars []Scalar) Point {
+	nPoints := make([]*bn254.Gt, len(points))
+	nScalars := make([]*native.Field4, len(scalars))
+
+	for i, pt := range points {
+		pp, ok := pt.(*PointBn254Gt)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = pp.Value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarBn254)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.Value
+	}
+	result := new(bn254.Gt).SetOne()
+	for i, pt := range nPoints {
+		t := new(bn254.Gt).Mul(pt, nScalars[i])
+		result.Add(result, t)
+	}
+	return &PointBn254Gt{result}
+}
+
+func (p *PointBn254Gt) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point {
+	return p.VarTimeMultiScalarMult([]Scalar{a, b}, []Point{capA, p.Generator()})
+}
+
+func (p *PointBn254Gt) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	return varTimeMultiScalarMult(p, scalars, points)
+}
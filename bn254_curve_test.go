@@ -0,0 +1,132 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScalarBn254Serialize(t *testing.T) {
+	curve := BN254G1()
+	for i := 0; i < 25; i++ {
+		s := curve.Scalar.Random(crand.Reader)
+		b := s.Bytes()
+		require.Equal(t, 32, len(b))
+		s2, err := curve.Scalar.SetBytes(b)
+		require.NoError(t, err)
+		require.Equal(t, 0, s.Cmp(s2))
+	}
+	_, err := curve.Scalar.SetBytes(curve.Scalar.(*ScalarBn254).Order().Bytes())
+	require.Error(t, err)
+
+	one := curve.Scalar.One()
+	require.True(t, curve.Scalar.New(-1).Add(one).IsZero())
+	inv, err := curve.Scalar.New(7).Invert()
+	require.NoError(t, err)
+	require.True(t, inv.Mul(curve.Scalar.New(7)).IsOne())
+
+	s := curve.Scalar.Random(crand.Reader)
+	bin, err := s.(*ScalarBn254).MarshalBinary()
+	require.NoError(t, err)
+	s2 := new(ScalarBn254)
+	require.NoError(t, s2.UnmarshalBinary(bin))
+	require.Equal(t, 0, s.Cmp(s2))
+}
+
+func TestPointBn254G1Arithmetic(t *testing.T) {
+	curve := BN254G1()
+	g := curve.NewGeneratorPoint()
+	require.True(t, g.Add(curve.NewIdentityPoint()).Equal(g))
+	require.True(t, g.IsOnCurve())
+	require.Equal(t, 0, g.(*PointBn254G1).X().Cmp(bhex("1")))
+	require.Equal(t, 0, g.(*PointBn254G1).Y().Cmp(bhex("2")))
+
+	g2 := g.Add(g)
+	require.True(t, g.Double().Equal(g2))
+	g3 := g.Add(g2)
+	require.True(t, g3.Equal(g.Mul(curve.Scalar.New(3))))
+	require.True(t, g.Mul(curve.Scalar.New(-1)).Equal(g.Neg()))
+	require.True(t, g.Sub(g).IsIdentity())
+	require.NotEqual(t, g.IsNegative(), g.Neg().IsNegative())
+}
+
+func TestPointBn254G2Arithmetic(t *testing.T) {
+	curve := BN254G2()
+	g := curve.NewGeneratorPoint()
+	require.True(t, g.Add(curve.NewIdentityPoint()).Equal(g))
+	require.True(t, g.IsOnCurve())
+
+	g2 := g.Add(g)
+	require.True(t, g.Double().Equal(g2))
+	g3 := g.Add(g2)
+	require.True(t, g3.Equal(g.Mul(curve.Scalar.New(3))))
+	require.True(t, g.Mul(curve.Scalar.New(-1)).Equal(g.Neg()))
+	require.True(t, g.Sub(g).IsIdentity())
+}
+
+func TestPointBn254Hash(t *testing.T) {
+	h := BN254G1().Point.Hash([]byte("abc"))
+	require.True(t, h.IsOnCurve())
+	require.Equal(t, "0dbec2b8335c31301288c25e3043a4d1e3ca364a7a3e4bdd53ed7cc4edcef85b12c5b8027d822d7bf4f2fbee1f228974c6dc0f63632ebb53b6819e126ae8647d", hex.EncodeToString(h.ToAffineUncompressed()))
+
+	h = BN254G2().Point.Hash([]byte("abc"))
+	require.True(t, h.IsOnCurve())
+	require.Equal(t, "19c129cbd374ceee1afa08eb701a1aa32f8ef420a362d1f0b082add4ba8134a71c2478788d912eb36575cb45f29d0637cc92fee92c4e6b43847e899add26435219d08153d8a5d13d097eb1ff49071f03077416c6dc79168523c95aa601220a0805fa95235d2c386b2d9b9935e915e7f2789f83276b8f7771ca9dfc7222c1615e", hex.EncodeToString(h.ToAffineUncompressed()))
+}
+
+func TestPointBn254Serialize(t *testing.T) {
+	for _, curve := range []*Curve{BN254G1(), BN254G2()} {
+		g := curve.NewGeneratorPoint()
+		for i := 0; i < 10; i++ {
+			pt := g.Mul(curve.Scalar.Random(crand.Reader))
+			retC, err := curve.Point.FromAffineCompressed(pt.ToAffineCompressed())
+			require.NoError(t, err)
+			require.True(t, pt.Equal(retC))
+			retU, err := curve.Point.FromAffineUncompressed(pt.ToAffineUncompressed())
+			require.NoError(t, err)
+			require.True(t, pt.Equal(retU))
+		}
+
+		// EIP-196/197 represent the point at infinity with zeros
+		id := curve.NewIdentityPoint().ToAffineUncompressed()
+		require.Equal(t, make([]byte, len(id)), id)
+
+		bin, err := PointMarshalBinary(g)
+		require.NoError(t, err)
+		pt, err := PointUnmarshalBinary(bin)
+		require.NoError(t, err)
+		require.True(t, g.Equal(pt))
+	}
+	require.Equal(t, 32, len(BN254G1().NewGeneratorPoint().ToAffineCompressed()))
+	require.Equal(t, 64, len(BN254G1().NewGeneratorPoint().ToAffineUncompressed()))
+	require.Equal(t, 64, len(BN254G2().NewGeneratorPoint().ToAffineCompressed()))
+	require.Equal(t, 128, len(BN254G2().NewGeneratorPoint().ToAffineUncompressed()))
+}
+
+func TestPointBn254Pairing(t *testing.T) {
+	curve := BN254(BN254G1().NewGeneratorPoint())
+	a := curve.Scalar.Random(crand.Reader)
+	b := curve.Scalar.Random(crand.Reader)
+
+	g1 := curve.ScalarG1BaseMult(a)
+	g2 := curve.ScalarG2BaseMult(b)
+	lhs := g1.Pairing(g2)
+	rhs := curve.NewG1GeneratorPoint().Pairing(curve.NewG2GeneratorPoint())
+	require.True(t, lhs.Cmp(rhs.Mul(a.Mul(b))) == 0)
+	require.True(t, lhs.Cmp(g2.Pairing(g1)) == 0)
+
+	// e(aG1, bG2) * e(-abG1, G2) == 1
+	ab := curve.ScalarG1BaseMult(a.Mul(b)).Neg().(PairingPoint)
+	res := g1.MultiPairing(g1, g2, ab, curve.NewG2GeneratorPoint())
+	require.True(t, res.IsOne())
+
+	require.Nil(t, g1.MultiPairing(g1))
+	require.NotNil(t, GetPairingCurveByName(BN254Name))
+	require.Equal(t, BN254G2Name, GetCurveByName(BN254G2Name).Name)
+}
@@ -0,0 +1,644 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+	"github.com/mikelodder7/curvey/native/brainpool/p256r1"
+)
+
+type ScalarBrainpoolP256r1 struct {
+	value *native.Field4
+}
+
+type PointBrainpoolP256r1 struct {
+	value *native.EllipticPoint4
+}
+
+func (s *ScalarBrainpoolP256r1) Random(reader io.Reader) Scalar {
+	if reader == nil {
+		return nil
+	}
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return s.Hash(seed[:])
+}
+
+func (*ScalarBrainpoolP256r1) Hash(bytes []byte) Scalar {
+	dst := []byte("brainpoolP256r1_XMD:SHA-256_SSWU_RO_")
+	xmd := native.ExpandMsgXmd(native.EllipticPointHasherSha256(), bytes, dst, 48)
+	var t [64]byte
+	copy(t[:48], internal.ReverseBytes(xmd))
+
+	return &ScalarBrainpoolP256r1{
+		value: p256r1.FqNew().SetBytesWide(&t),
+	}
+}
+
+func (*ScalarBrainpoolP256r1) Zero() Scalar {
+	return &ScalarBrainpoolP256r1{
+		value: p256r1.FqNew().SetZero(),
+	}
+}
+
+func (*ScalarBrainpoolP256r1) One() Scalar {
+	return &ScalarBrainpoolP256r1{
+		value: p256r1.FqNew().SetOne(),
+	}
+}
+
+func (s *ScalarBrainpoolP256r1) IsZero() bool {
+	return s.value.IsZero() == 1
+}
+
+func (s *ScalarBrainpoolP256r1) IsOne() bool {
+	return s.value.IsOne() == 1
+}
+
+func (s *ScalarBrainpoolP256r1) IsOdd() bool {
+	return s.value.Bytes()[0]&1 == 1
+}
+
+func (s *ScalarBrainpoolP256r1) IsEven() bool {
+	return s.value.Bytes()[0]&1 == 0
+}
+
+func (*ScalarBrainpoolP256r1) New(value int) Scalar {
+	t := p256r1.FqNew()
+	v := big.NewInt(int64(value))
+	if value < 0 {
+		v.Mod(v, t.Params.BiModulus)
+	}
+	return &ScalarBrainpoolP256r1{
+		value: t.SetBigInt(v),
+	}
+}
+
+func (s *ScalarBrainpoolP256r1) Cmp(rhs Scalar) int {
+	r, ok := rhs.(*ScalarBrainpoolP256r1)
+	if ok {
+		return s.value.Cmp(r.value)
+	} else {
+		return -2
+	}
+}
+
+func (s *ScalarBrainpoolP256r1) Square() Scalar {
+	return &ScalarBrainpoolP256r1{
+		value: p256r1.FqNew().Square(s.value),
+	}
+}
+
+func (s *ScalarBrainpoolP256r1) Pow(exp uint64) Scalar {
+	expFieldLimb := [native.Field4Limbs]uint64{exp, 0, 0, 0}
+	out := ScalarBrainpoolP256r1{value: p256r1.FqNew()}
+	native.Pow(&out.value.Value, &s.value.Value, &expFieldLimb, s.value.Params, s.value.Arithmetic)
+	return &ScalarBrainpoolP256r1{
+		value: out.value,
+	}
+}
+
+func (s *ScalarBrainpoolP256r1) Double() Scalar {
+	return &ScalarBrainpoolP256r1{
+		value: p256r1.FqNew().Double(s.value),
+	}
+}
+
+func (s *ScalarBrainpoolP256r1) Invert() (Scalar, error) {
+	value, wasInverted := p256r1.FqNew().Invert(s.value)
+	if !wasInverted {
+		return nil, fmt.Errorf("inverse doesn't exist")
+	}
+	return &ScalarBrainpoolP256r1{
+		value,
+	}, nil
+}
+
+func (s *ScalarBrainpoolP256r1) Sqrt() (Scalar, error) {
+	value, wasSquare := p256r1.FqNew().Sqrt(s.value)
+	if !wasSquare {
+		return nil, fmt.Errorf("not a square")
+	}
+	return &ScalarBrainpoolP256r1{
+		value,
+	}, nil
+}
+
+func (s *ScalarBrainpoolP256r1) Cube() Scalar {
+	value := p256r1.FqNew().Square(s.value)
+	value.Mul(value, s.value)
+	return &ScalarBrainpoolP256r1{
+		value,
+	}
+}
+
+func (s *ScalarBrainpoolP256r1) Add(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBrainpoolP256r1)
+	if ok {
+		return &ScalarBrainpoolP256r1{
+			value: p256r1.FqNew().Add(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBrainpoolP256r1) Sub(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBrainpoolP256r1)
+	if ok {
+		return &ScalarBrainpoolP256r1{
+			value: p256r1.FqNew().Sub(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBrainpoolP256r1) Mul(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBrainpoolP256r1)
+	if ok {
+		return &ScalarBrainpoolP256r1{
+			value: p256r1.FqNew().Mul(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBrainpoolP256r1) MulAdd(y, z Scalar) Scalar {
+	return s.Mul(y).Add(z)
+}
+
+func (s *ScalarBrainpoolP256r1) Div(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBrainpoolP256r1)
+	if ok {
+		v, wasInverted := p256r1.FqNew().Invert(r.value)
+		if !wasInverted {
+			return nil
+		}
+		v.Mul(v, s.value)
+		return &ScalarBrainpoolP256r1{value: v}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBrainpoolP256r1) Neg() Scalar {
+	return &ScalarBrainpoolP256r1{
+		value: p256r1.FqNew().Neg(s.value),
+	}
+}
+
+func (*ScalarBrainpoolP256r1) SetBigInt(v *big.Int) (Scalar, error) {
+	if v == nil {
+		return nil, fmt.Errorf("'v' cannot be nil")
+	}
+	value := p256r1.FqNew().SetBigInt(v)
+	return &ScalarBrainpoolP256r1{
+		value,
+	}, nil
+}
+
+func (s *ScalarBrainpoolP256r1) BigInt() *big.Int {
+	return s.value.BigInt()
+}
+
+func (s *ScalarBrainpoolP256r1) Bytes() []byte {
+	t := s.value.Bytes()
+	return internal.ReverseBytes(t[:])
+}
+
+func (*ScalarBrainpoolP256r1) SetBytes(bytes []byte) (Scalar, error) {
+	if len(bytes) != 32 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [32]byte
+	copy(seq[:], internal.ReverseBytes(bytes))
+	value, err := p256r1.FqNew().SetBytes(&seq)
+	if err != nil {
+		return nil, err
+	}
+	return &ScalarBrainpoolP256r1{
+		value,
+	}, nil
+}
+
+func (*ScalarBrainpoolP256r1) SetBytesWide(bytes []byte) (Scalar, error) {
+	if len(bytes) != 64 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [64]byte
+	copy(seq[:], bytes)
+	return &ScalarBrainpoolP256r1{
+		value: p256r1.FqNew().SetBytesWide(&seq),
+	}, nil
+}
+
+func (*ScalarBrainpoolP256r1) Point() Point {
+	return new(PointBrainpoolP256r1).Identity()
+}
+
+func (s *ScalarBrainpoolP256r1) Clone() Scalar {
+	return &ScalarBrainpoolP256r1{
+		value: p256r1.FqNew().Set(s.value),
+	}
+}
+
+func (s *ScalarBrainpoolP256r1) MarshalBinary() ([]byte, error) {
+	return ScalarMarshalBinary(s)
+}
+
+func (s *ScalarBrainpoolP256r1) UnmarshalBinary(input []byte) error {
+	sc, err := ScalarUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarBrainpoolP256r1)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarBrainpoolP256r1) MarshalText() ([]byte, error) {
+	return ScalarMarshalText(s)
+}
+
+func (s *ScalarBrainpoolP256r1) UnmarshalText(input []byte) error {
+	sc, err := ScalarUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarBrainpoolP256r1)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarBrainpoolP256r1) MarshalJSON() ([]byte, error) {
+	return ScalarMarshalJSON(s)
+}
+
+func (s *ScalarBrainpoolP256r1) UnmarshalJSON(input []byte) error {
+	sc, err := ScalarUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	S, ok := sc.(*ScalarBrainpoolP256r1)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	s.value = S.value
+	return nil
+}
+
+func (p *PointBrainpoolP256r1) Random(reader io.Reader) Point {
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return p.Hash(seed[:])
+}
+
+func (*PointBrainpoolP256r1) Hash(bytes []byte) Point {
+	value, err := p256r1.PointNew().Hash(bytes, native.EllipticPointHasherSha256())
+	// TODO: change hash to return an error also
+	if err != nil {
+		return nil
+	}
+
+	return &PointBrainpoolP256r1{value}
+}
+
+func (*PointBrainpoolP256r1) Identity() Point {
+	return &PointBrainpoolP256r1{
+		value: p256r1.PointNew().Identity(),
+	}
+}
+
+func (*PointBrainpoolP256r1) Generator() Point {
+	return &PointBrainpoolP256r1{
+		value: p256r1.PointNew().Generator(),
+	}
+}
+
+func (p *PointBrainpoolP256r1) IsIdentity() bool {
+	return p.value.IsIdentity()
+}
+
+func (p *PointBrainpoolP256r1) IsNegative() bool {
+	return p.value.GetY().Value[0]&1 == 1
+}
+
+func (p *PointBrainpoolP256r1) IsOnCurve() bool {
+	return p.value.IsOnCurve()
+}
+
+func (p *PointBrainpoolP256r1) Double() Point {
+	value := p256r1.PointNew().Double(p.value)
+	return &PointBrainpoolP256r1{value}
+}
+
+func (*PointBrainpoolP256r1) Scalar() Scalar {
+	return new(ScalarBrainpoolP256r1).Zero()
+}
+
+func (p *PointBrainpoolP256r1) Neg() Point {
+	value := p256r1.PointNew().Neg(p.value)
+	return &PointBrainpoolP256r1{value}
+}
+
+func (p *PointBrainpoolP256r1) Add(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointBrainpoolP256r1)
+	if ok {
+		value := p256r1.PointNew().Add(p.value, r.value)
+		return &PointBrainpoolP256r1{value}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointBrainpoolP256r1) Sub(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointBrainpoolP256r1)
+	if ok {
+		value := p256r1.PointNew().Sub(p.value, r.value)
+		return &PointBrainpoolP256r1{value}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointBrainpoolP256r1) Mul(rhs Scalar) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*ScalarBrainpoolP256r1)
+	if ok {
+		value := p256r1.PointNew().Mul(p.value, r.value)
+		return &PointBrainpoolP256r1{value}
+	} else {
+		return nil
+	}
+}
+
+func (*PointBrainpoolP256r1) selectPoint(row []Point, index int) Point {
+	value := p256r1.PointNew().Identity()
+	for i, pt := range row {
+		value.CMove(value, pt.(*PointBrainpoolP256r1).value, ctEqual(i+1, index))
+	}
+	return &PointBrainpoolP256r1{value}
+}
+
+func (p *PointBrainpoolP256r1) Equal(rhs Point) bool {
+	r, ok := rhs.(*PointBrainpoolP256r1)
+	if ok {
+		return p.value.Equal(r.value) == 1
+	} else {
+		return false
+	}
+}
+
+func (*PointBrainpoolP256r1) Set(x, y *big.Int) (Point, error) {
+	value, err := p256r1.PointNew().SetBigInt(x, y)
+	if err != nil {
+		return nil, err
+	}
+	return &PointBrainpoolP256r1{value}, nil
+}
+
+func (p *PointBrainpoolP256r1) ToAffineCompressed() []byte {
+	var x [33]byte
+	x[0] = byte(2)
+
+	t := p256r1.PointNew().ToAffine(p.value)
+
+	x[0] |= t.Y.Bytes()[0] & 1
+
+	xBytes := t.X.Bytes()
+	copy(x[1:], internal.ReverseBytes(xBytes[:]))
+	return x[:]
+}
+
+func (p *PointBrainpoolP256r1) ToAffineUncompressed() []byte {
+	var out [65]byte
+	out[0] = byte(4)
+	t := p256r1.PointNew().ToAffine(p.value)
+	arr := t.X.Bytes()
+	copy(out[1:33], internal.ReverseBytes(arr[:]))
+	arr = t.Y.Bytes()
+	copy(out[33:], internal.ReverseBytes(arr[:]))
+	return out[:]
+}
+
+func (p *PointBrainpoolP256r1) FromAffineCompressed(bytes []byte) (Point, error) {
+	var raw [native.Field4Bytes]byte
+	if len(bytes) != 33 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	sign := int(bytes[0])
+	if sign != 2 && sign != 3 {
+		return nil, fmt.Errorf("invalid sign byte")
+	}
+	sign &= 0x1
+
+	copy(raw[:], internal.ReverseBytes(bytes[1:]))
+	x, err := p256r1.FpNew().SetBytes(&raw)
+	if err != nil {
+		return nil, err
+	}
+
+	value := p256r1.PointNew().Identity()
+	rhs := p256r1.FpNew()
+	p.value.Arithmetic.RhsEquation(rhs, x)
+	// test that rhs is quadratic residue
+	// if not, then this Point is at infinity
+	y, wasQr := p256r1.FpNew().Sqrt(rhs)
+	if wasQr {
+		// fix the sign
+		sigY := int(y.Bytes()[0] & 1)
+		if sigY != sign {
+			y.Neg(y)
+		}
+		value.X = x
+		value.Y = y
+		value.Z.SetOne()
+	}
+	return &PointBrainpoolP256r1{value}, nil
+}
+
+func (*PointBrainpoolP256r1) FromAffineUncompressed(bytes []byte) (Point, error) {
+	var arr [native.Field4Bytes]byte
+	if len(bytes) != 65 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	if bytes[0] != 4 {
+		return nil, fmt.Errorf("invalid sign byte")
+	}
+
+	copy(arr[:], internal.ReverseBytes(bytes[1:33]))
+	x, err := p256r1.FpNew().SetBytes(&arr)
+	if err != nil {
+		return nil, err
+	}
+	copy(arr[:], internal.ReverseBytes(bytes[33:]))
+	y, err := p256r1.FpNew().SetBytes(&arr)
+	if err != nil {
+		return nil, err
+	}
+	value := p256r1.PointNew()
+	value.X = x
+	value.Y = y
+	value.Z.SetOne()
+	return &PointBrainpoolP256r1{value}, nil
+}
+
+func (*PointBrainpoolP256r1) CurveName() string {
+	return BrainpoolP256r1Name
+}
+
+func (*PointBrainpoolP256r1) SumOfProducts(points []Point, scalars []Scalar) Point {
+	nPoints := make([]*native.EllipticPoint4, len(points))
+	nScalars := make([]*native.Field4, len(scalars))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointBrainpoolP256r1)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarBrainpoolP256r1)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.value
+	}
+	value := p256r1.PointNew()
+	_, err := value.SumOfProducts(nPoints, nScalars)
+	if err != nil {
+		return nil
+	}
+	return &PointBrainpoolP256r1{value}
+}
+
+func (*PointBrainpoolP256r1) sumOfProductsVarTime(points []Point, scalars []Scalar, opts *MsmOptions) Point {
+	nPoints := make([]*native.EllipticPoint4, len(points))
+	nScalars := make([]*native.Field4, len(scalars))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointBrainpoolP256r1)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarBrainpoolP256r1)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.value
+	}
+	value := p256r1.PointNew()
+	_, err := value.MsmVarTime(nPoints, nScalars, opts)
+	if err != nil {
+		return nil
+	}
+	return &PointBrainpoolP256r1{value}
+}
+
+func (p *PointBrainpoolP256r1) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point {
+	return p.VarTimeMultiScalarMult([]Scalar{a, b}, []Point{capA, p.Generator()})
+}
+
+func (p *PointBrainpoolP256r1) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	return p.sumOfProductsVarTime(points, scalars, nil)
+}
+
+func (*PointBrainpoolP256r1) batchNormalize(points []Point) []Point {
+	nPoints := make([]*native.EllipticPoint4, len(points))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointBrainpoolP256r1)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	out := make([]Point, len(points))
+	for i, value := range native.BatchToAffine(nPoints) {
+		out[i] = &PointBrainpoolP256r1{value}
+	}
+	return out
+}
+
+func (p *PointBrainpoolP256r1) X() *native.Field4 {
+	return p.value.GetX()
+}
+
+func (p *PointBrainpoolP256r1) Y() *native.Field4 {
+	return p.value.GetY()
+}
+
+func (p *PointBrainpoolP256r1) MarshalBinary() ([]byte, error) {
+	return PointMarshalBinary(p)
+}
+
+func (p *PointBrainpoolP256r1) UnmarshalBinary(input []byte) error {
+	pt, err := PointUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointBrainpoolP256r1)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointBrainpoolP256r1) MarshalText() ([]byte, error) {
+	return PointMarshalText(p)
+}
+
+func (p *PointBrainpoolP256r1) UnmarshalText(input []byte) error {
+	pt, err := PointUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointBrainpoolP256r1)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointBrainpoolP256r1) MarshalJSON() ([]byte, error) {
+	return PointMarshalJSON(p)
+}
+
+func (p *PointBrainpoolP256r1) UnmarshalJSON(input []byte) error {
+	pt, err := PointUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	P, ok := pt.(*PointBrainpoolP256r1)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	p.value = P.value
+	return nil
+}
@@ -0,0 +1,325 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	crand "crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// The k*G vectors in this file were computed with OpenSSL 3.0 and the hash
+// vectors with a direct transcription of RFC 9380 expand_message_xmd and the
+// simplified SWU map written with Python integers, independently of this
+// package.
+
+func TestScalarBrainpoolP256r1Random(t *testing.T) {
+	bp256 := BrainpoolP256r1()
+	sc := bp256.Scalar.Random(testRng())
+	s, ok := sc.(*ScalarBrainpoolP256r1)
+	require.True(t, ok)
+	expected := bhex("8e03dbd5eeba4843b762854e49c746f8486141fd525dd92e2ad43e7e8fa129ca")
+	require.Equal(t, s.value.BigInt(), expected)
+	// Try 10 random values
+	for i := 0; i < 10; i++ {
+		sc := bp256.Scalar.Random(crand.Reader)
+		_, ok := sc.(*ScalarBrainpoolP256r1)
+		require.True(t, ok)
+		require.True(t, !sc.IsZero())
+	}
+}
+
+func TestScalarBrainpoolP256r1Hash(t *testing.T) {
+	tests := []struct {
+		msg      []byte
+		expected string
+	}{
+		{[]byte{}, "a423aa4b81fe57fecdf64a2afb0c2b42d472d96eea16324aceb7e8867c7f3902"},
+		{[]byte("abc"), "77ba0a6ada67fd4057a4fa8823a9957dc7ddc777d9319a553d294557665170ee"},
+		{make([]byte, 32), "6303eeaf3ba09eda6bd01e3d5f76a928061f95d5af4933e3b03e983bfb7622a9"},
+	}
+	bp256 := BrainpoolP256r1()
+	for _, tt := range tests {
+		s, ok := bp256.Scalar.Hash(tt.msg).(*ScalarBrainpoolP256r1)
+		require.True(t, ok)
+		require.Equal(t, s.value.BigInt(), bhex(tt.expected))
+	}
+}
+
+func TestScalarBrainpoolP256r1New(t *testing.T) {
+	bp256 := BrainpoolP256r1()
+	three := bp256.Scalar.New(3)
+	require.True(t, three.IsOdd())
+	four := bp256.Scalar.New(4)
+	require.True(t, four.IsEven())
+	neg1 := bp256.Scalar.New(-1)
+	require.True(t, neg1.IsEven())
+	neg2 := bp256.Scalar.New(-2)
+	require.True(t, neg2.IsOdd())
+	require.Equal(t, three.Square().Cmp(bp256.Scalar.New(9)), 0)
+	require.Equal(t, three.Cube().Cmp(bp256.Scalar.New(27)), 0)
+	require.Equal(t, three.Double().Cmp(bp256.Scalar.New(6)), 0)
+	require.Equal(t, bp256.Scalar.One().Neg().Cmp(neg1), 0)
+}
+
+func TestScalarBrainpoolP256r1Invert(t *testing.T) {
+	bp256 := BrainpoolP256r1()
+	nine := bp256.Scalar.New(9)
+	actual, err := nine.Invert()
+	require.NoError(t, err)
+
+	bn := new(big.Int).SetInt64(9)
+	bn.ModInverse(bn, bhex("a9fb57dba1eea9bc3e660a909d838d718c397aa3b561a6f7901e0e82974856a7"))
+
+	expected, err := bp256.Scalar.SetBigInt(bn)
+	require.NoError(t, err)
+	require.Equal(t, actual.Cmp(expected), 0)
+}
+
+func TestScalarBrainpoolP256r1Sqrt(t *testing.T) {
+	bp256 := BrainpoolP256r1()
+	nine := bp256.Scalar.New(9)
+	actual, err := nine.Sqrt()
+	require.NoError(t, err)
+	three := bp256.Scalar.New(3)
+	require.True(t, actual.Cmp(three) == 0 || actual.Cmp(three.Neg()) == 0)
+}
+
+func TestScalarBrainpoolP256r1Add(t *testing.T) {
+	bp256 := BrainpoolP256r1()
+	nine := bp256.Scalar.New(9)
+	six := bp256.Scalar.New(6)
+	require.Equal(t, nine.Add(six).Cmp(bp256.Scalar.New(15)), 0)
+	n := bhex("a9fb57dba1eea9bc3e660a909d838d718c397aa3b561a6f7901e0e82974856a7")
+	n.Sub(n, big.NewInt(3))
+
+	upper, err := bp256.Scalar.SetBigInt(n)
+	require.NoError(t, err)
+	require.Equal(t, upper.Add(nine).Cmp(six), 0)
+	require.Equal(t, six.Sub(nine).Cmp(upper), 0)
+	require.Equal(t, nine.Mul(six).Cmp(bp256.Scalar.New(54)), 0)
+	require.Equal(t, bp256.Scalar.New(54).Div(nine).Cmp(six), 0)
+}
+
+func TestScalarBrainpoolP256r1Serialize(t *testing.T) {
+	bp256 := BrainpoolP256r1()
+	sc := bp256.Scalar.New(255)
+	sequence := sc.Bytes()
+	require.Equal(t, len(sequence), 32)
+	require.Equal(t, sequence[31], byte(0xff))
+	ret, err := bp256.Scalar.SetBytes(sequence)
+	require.NoError(t, err)
+	require.Equal(t, ret.Cmp(sc), 0)
+
+	// Try 10 random values
+	for i := 0; i < 10; i++ {
+		sc = bp256.Scalar.Random(crand.Reader)
+		sequence = sc.Bytes()
+		require.Equal(t, len(sequence), 32)
+		ret, err = bp256.Scalar.SetBytes(sequence)
+		require.NoError(t, err)
+		require.Equal(t, ret.Cmp(sc), 0)
+	}
+}
+
+func TestScalarBrainpoolP256r1Nil(t *testing.T) {
+	bp256 := BrainpoolP256r1()
+	one := bp256.Scalar.New(1)
+	require.Nil(t, one.Add(nil))
+	require.Nil(t, one.Sub(nil))
+	require.Nil(t, one.Mul(nil))
+	require.Nil(t, one.Div(nil))
+	require.Nil(t, bp256.Scalar.Random(nil))
+	require.Equal(t, one.Cmp(nil), -2)
+	_, err := bp256.Scalar.SetBigInt(nil)
+	require.Error(t, err)
+}
+
+func TestPointBrainpoolP256r1Random(t *testing.T) {
+	bp256 := BrainpoolP256r1()
+	sc := bp256.Point.Random(testRng())
+	s, ok := sc.(*PointBrainpoolP256r1)
+	require.True(t, ok)
+	require.Equal(t, s.X().BigInt(), bhex("2117677159aba955e3ab37b2c53c67ffc1ec487551852fc9dd6abc47d9ac750d"))
+	require.Equal(t, s.Y().BigInt(), bhex("4eb9fb9ab93d6be905f528b7cd107b1994846a76a7c8dd02503a53deedaedee5"))
+	// Try 10 random values
+	for i := 0; i < 10; i++ {
+		sc := bp256.Point.Random(crand.Reader)
+		_, ok := sc.(*PointBrainpoolP256r1)
+		require.True(t, ok)
+		require.True(t, !sc.IsIdentity())
+		require.True(t, sc.IsOnCurve())
+	}
+}
+
+func TestPointBrainpoolP256r1Hash(t *testing.T) {
+	tests := []struct {
+		msg  []byte
+		x, y string
+	}{
+		{
+			msg: []byte{},
+			x:   "6e11d57dacd08a0222398e80436fa21266a6f8888d8742bcf11c8eeb14ab7c98",
+			y:   "2b5731bad407151941e0b70bbae9cc60ed78ae4a933f63921b37723ba5888fe6",
+		},
+		{
+			msg: []byte("abc"),
+			x:   "a95cff074e2ad2ab2927f450936a3acd83a02dc080a012a8eb30285b8c97c1c2",
+			y:   "432d4f7711afb9c9223bba10c481ad63c45329533b2ca009bfe630f73dc857a3",
+		},
+		{
+			msg: make([]byte, 32),
+			x:   "991e50ba2b438aa52df7506c5ceefb46fdc079e1447f0b6ff21ceba1ae998325",
+			y:   "6a942615bdbe18c571bfa17cae69cefe8300b84b3087d6f0b5014d6b7c323066",
+		},
+	}
+	bp256 := BrainpoolP256r1()
+	for _, tt := range tests {
+		pt, ok := bp256.Point.Hash(tt.msg).(*PointBrainpoolP256r1)
+		require.True(t, ok)
+		require.Equal(t, pt.X().BigInt(), bhex(tt.x))
+		require.Equal(t, pt.Y().BigInt(), bhex(tt.y))
+	}
+}
+
+func TestPointBrainpoolP256r1Generator(t *testing.T) {
+	bp256 := BrainpoolP256r1()
+	sc := bp256.Point.Generator()
+	s, ok := sc.(*PointBrainpoolP256r1)
+	require.True(t, ok)
+	require.True(t, s.IsOnCurve())
+	require.Equal(t, s.X().BigInt(), bhex("8bd2aeb9cb7e57cb2c4b482ffc81b7afb9de27e1e3bd23c23a4453bd9ace3262"))
+	require.Equal(t, s.Y().BigInt(), bhex("547ef835c3dac4fd97f8461a14611dc9c27745132ded8e545c1d54c72f046997"))
+	_, err := bp256.Point.Set(s.X().BigInt(), s.Y().BigInt())
+	require.NoError(t, err)
+	iden, err := bp256.Point.Set(big.NewInt(0), big.NewInt(0))
+	require.NoError(t, err)
+	require.True(t, iden.IsIdentity())
+}
+
+func TestPointBrainpoolP256r1Arithmetic(t *testing.T) {
+	bp256 := BrainpoolP256r1()
+	g := bp256.Point.Generator()
+	require.True(t, g.Double().Equal(g.Mul(bp256.Scalar.New(2))))
+	require.True(t, g.Add(g).Add(g).Equal(g.Mul(bp256.Scalar.New(3))))
+	require.True(t, g.Neg().Neg().Equal(g))
+	i := bp256.Point.Identity()
+	require.True(t, i.Double().Equal(i))
+	require.True(t, i.Neg().Equal(i))
+	pt := g.Mul(bp256.Scalar.New(4))
+	require.True(t, pt.Sub(g).Sub(g).Sub(g).Equal(g))
+	require.True(t, pt.Sub(g).Sub(g).Sub(g).Sub(g).IsIdentity())
+	require.True(t, g.Mul(bp256.Scalar.New(-1)).Add(g).IsIdentity())
+}
+
+func TestPointBrainpoolP256r1Mul(t *testing.T) {
+	tests := []struct {
+		k, x, y string
+	}{
+		{
+			k: "2",
+			x: "743cf1b8b5cd4f2eb55f8aa369593ac436ef044166699e37d51a14c2ce13ea0e",
+			y: "36ed163337deba9c946fe0bb776529da38df059f69249406892ada097eeb7cd4",
+		},
+		{
+			k: "3",
+			x: "a8f217b77338f1d4d6624c3ab4f6cc16d2aa843d0c0fca016b91e2ad25cae39d",
+			y: "4b49cafc7dac26bb0aa2a6850a1b40f5fac10e4589348fb77e65cc5602b74f9d",
+		},
+		{
+			k: "1234567890abcdef",
+			x: "668adacf4ed5f35980db33ee865581f20198bc9364bdd94dc8aaa9731cb7bb17",
+			y: "4c51e8d475b04dbb59639f222f130d92d03e72e0f7a8e92af582ef674c3ce64",
+		},
+		{
+			k: "1234567890abcdef1234567890abcdef",
+			x: "90ead69cf4261f9af4742231b86f9501d3168cc8b3a74ddfc0a3c869efea4667",
+			y: "2994047fc60588a5e8931e22d90fbd60f869283920a1e77361e209a6aeefe726",
+		},
+	}
+	bp256 := BrainpoolP256r1()
+	for _, tt := range tests {
+		k, err := bp256.Scalar.SetBigInt(bhex(tt.k))
+		require.NoError(t, err)
+		pt := bp256.ScalarBaseMult(k).(*PointBrainpoolP256r1)
+		require.Equal(t, pt.X().BigInt(), bhex(tt.x))
+		require.Equal(t, pt.Y().BigInt(), bhex(tt.y))
+		require.True(t, bp256.Point.Generator().Mul(k).Equal(pt))
+	}
+}
+
+func TestPointBrainpoolP256r1Serialize(t *testing.T) {
+	bp256 := BrainpoolP256r1()
+	ss := bp256.Scalar.Random(testRng())
+	g := bp256.Point.Generator()
+
+	ppt := g.Mul(ss)
+
+	require.Equal(t, ppt.ToAffineCompressed(), []byte{0x2, 0x23, 0x3a, 0xe7, 0xce, 0x58, 0x28, 0x3, 0xea, 0xa, 0x11, 0xb4, 0x24, 0x9d, 0x73, 0x51, 0x4d, 0xd5, 0xba, 0x54, 0xd0, 0xe3, 0x66, 0x51, 0x87, 0x9e, 0xaa, 0x32, 0x1d, 0xfc, 0xda, 0x12, 0xda})
+	require.Equal(t, ppt.ToAffineUncompressed(), []byte{0x4, 0x23, 0x3a, 0xe7, 0xce, 0x58, 0x28, 0x3, 0xea, 0xa, 0x11, 0xb4, 0x24, 0x9d, 0x73, 0x51, 0x4d, 0xd5, 0xba, 0x54, 0xd0, 0xe3, 0x66, 0x51, 0x87, 0x9e, 0xaa, 0x32, 0x1d, 0xfc, 0xda, 0x12, 0xda, 0x7a, 0xc1, 0x54, 0xf3, 0xa, 0x19, 0xe5, 0xa3, 0xc7, 0x3f, 0x56, 0x27, 0x24, 0x8c, 0x8e, 0x9d, 0x5c, 0xea, 0x2d, 0xa7, 0xa6, 0x30, 0xf7, 0x3b, 0xba, 0x5b, 0x6a, 0xf7, 0xcd, 0x4e, 0x4, 0x16})
+	retP, err := ppt.FromAffineCompressed(ppt.ToAffineCompressed())
+	require.NoError(t, err)
+	require.True(t, ppt.Equal(retP))
+	retP, err = ppt.FromAffineUncompressed(ppt.ToAffineUncompressed())
+	require.NoError(t, err)
+	require.True(t, ppt.Equal(retP))
+
+	// smoke test
+	for i := 0; i < 25; i++ {
+		s := bp256.Scalar.Random(crand.Reader)
+		pt := g.Mul(s)
+		cmprs := pt.ToAffineCompressed()
+		require.Equal(t, len(cmprs), 33)
+		retC, err := pt.FromAffineCompressed(cmprs)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(retC))
+
+		un := pt.ToAffineUncompressed()
+		require.Equal(t, len(un), 65)
+		retU, err := pt.FromAffineUncompressed(un)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(retU))
+	}
+}
+
+func TestPointBrainpoolP256r1Nil(t *testing.T) {
+	bp256 := BrainpoolP256r1()
+	one := bp256.Point.Generator()
+	require.Nil(t, one.Add(nil))
+	require.Nil(t, one.Sub(nil))
+	require.Nil(t, one.Mul(nil))
+	require.False(t, one.Equal(nil))
+}
+
+func TestPointBrainpoolP256r1SumOfProducts(t *testing.T) {
+	lhs := new(PointBrainpoolP256r1).Generator().Mul(new(ScalarBrainpoolP256r1).New(50))
+	points := make([]Point, 5)
+	for i := range points {
+		points[i] = new(PointBrainpoolP256r1).Generator()
+	}
+	scalars := []Scalar{
+		new(ScalarBrainpoolP256r1).New(8),
+		new(ScalarBrainpoolP256r1).New(9),
+		new(ScalarBrainpoolP256r1).New(10),
+		new(ScalarBrainpoolP256r1).New(11),
+		new(ScalarBrainpoolP256r1).New(12),
+	}
+	rhs := lhs.SumOfProducts(points, scalars)
+	require.NotNil(t, rhs)
+	require.True(t, lhs.Equal(rhs))
+}
+
+func TestPointBrainpoolP256r1GetCurveByName(t *testing.T) {
+	curve := GetCurveByName(BrainpoolP256r1Name)
+	require.NotNil(t, curve)
+	g := curve.Point.Generator()
+	bin, err := PointMarshalBinary(g)
+	require.NoError(t, err)
+	pt, err := PointUnmarshalBinary(bin)
+	require.NoError(t, err)
+	require.True(t, g.Equal(pt))
+}
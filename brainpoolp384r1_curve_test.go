@@ -0,0 +1,328 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	crand "crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// The k*G vectors in this file were computed with OpenSSL 3.0 and the hash
+// vectors with a direct transcription of RFC 9380 expand_message_xmd and the
+// simplified SWU map written with Python integers, independently of this
+// package.
+//
+// The point DST names the hash "unknown", as P-384 does, because the
+// SHA-384 hasher has no name; the scalar DST names SHA-384.
+
+func TestScalarBrainpoolP384r1Random(t *testing.T) {
+	bp384 := BrainpoolP384r1()
+	sc := bp384.Scalar.Random(testRng())
+	s, ok := sc.(*ScalarBrainpoolP384r1)
+	require.True(t, ok)
+	expected := bhex("74d849369ed5e54fda59ef5cfeb1ceb75a7fb1c4ef150f38eddaa93dd47b4ffa519715259b4b68e7055579bbbe7920fb")
+	require.Equal(t, s.value.BigInt(), expected)
+	// Try 10 random values
+	for i := 0; i < 10; i++ {
+		sc := bp384.Scalar.Random(crand.Reader)
+		_, ok := sc.(*ScalarBrainpoolP384r1)
+		require.True(t, ok)
+		require.True(t, !sc.IsZero())
+	}
+}
+
+func TestScalarBrainpoolP384r1Hash(t *testing.T) {
+	tests := []struct {
+		msg      []byte
+		expected string
+	}{
+		{[]byte{}, "51a71f9b2386f4b235be624222c54b178406f8094b963887f405cb76b144c6bd3e2d7116ee95fa9173ca5d9621bf1317"},
+		{[]byte("abc"), "29e73e002b6cc3e384faf7c34efa1f48242b21b016191ecded068cf4135116d77785ce8176167ad24d5d28b2e13d7fce"},
+		{make([]byte, 32), "54e390632eec3b1022801d3b91116d5cbce2b29665d45072f3e3983a229356920918ca0a4c4888700346ee7f05128cbc"},
+	}
+	bp384 := BrainpoolP384r1()
+	for _, tt := range tests {
+		s, ok := bp384.Scalar.Hash(tt.msg).(*ScalarBrainpoolP384r1)
+		require.True(t, ok)
+		require.Equal(t, s.value.BigInt(), bhex(tt.expected))
+	}
+}
+
+func TestScalarBrainpoolP384r1New(t *testing.T) {
+	bp384 := BrainpoolP384r1()
+	three := bp384.Scalar.New(3)
+	require.True(t, three.IsOdd())
+	four := bp384.Scalar.New(4)
+	require.True(t, four.IsEven())
+	neg1 := bp384.Scalar.New(-1)
+	require.True(t, neg1.IsEven())
+	neg2 := bp384.Scalar.New(-2)
+	require.True(t, neg2.IsOdd())
+	require.Equal(t, three.Square().Cmp(bp384.Scalar.New(9)), 0)
+	require.Equal(t, three.Cube().Cmp(bp384.Scalar.New(27)), 0)
+	require.Equal(t, three.Double().Cmp(bp384.Scalar.New(6)), 0)
+	require.Equal(t, bp384.Scalar.One().Neg().Cmp(neg1), 0)
+}
+
+func TestScalarBrainpoolP384r1Invert(t *testing.T) {
+	bp384 := BrainpoolP384r1()
+	nine := bp384.Scalar.New(9)
+	actual, err := nine.Invert()
+	require.NoError(t, err)
+
+	bn := new(big.Int).SetInt64(9)
+	bn.ModInverse(bn, bhex("8cb91e82a3386d280f5d6f7e50e641df152f7109ed5456b31f166e6cac0425a7cf3ab6af6b7fc3103b883202e9046565"))
+
+	expected, err := bp384.Scalar.SetBigInt(bn)
+	require.NoError(t, err)
+	require.Equal(t, actual.Cmp(expected), 0)
+}
+
+func TestScalarBrainpoolP384r1Sqrt(t *testing.T) {
+	bp384 := BrainpoolP384r1()
+	nine := bp384.Scalar.New(9)
+	actual, err := nine.Sqrt()
+	require.NoError(t, err)
+	three := bp384.Scalar.New(3)
+	require.True(t, actual.Cmp(three) == 0 || actual.Cmp(three.Neg()) == 0)
+}
+
+func TestScalarBrainpoolP384r1Add(t *testing.T) {
+	bp384 := BrainpoolP384r1()
+	nine := bp384.Scalar.New(9)
+	six := bp384.Scalar.New(6)
+	require.Equal(t, nine.Add(six).Cmp(bp384.Scalar.New(15)), 0)
+	n := bhex("8cb91e82a3386d280f5d6f7e50e641df152f7109ed5456b31f166e6cac0425a7cf3ab6af6b7fc3103b883202e9046565")
+	n.Sub(n, big.NewInt(3))
+
+	upper, err := bp384.Scalar.SetBigInt(n)
+	require.NoError(t, err)
+	require.Equal(t, upper.Add(nine).Cmp(six), 0)
+	require.Equal(t, six.Sub(nine).Cmp(upper), 0)
+	require.Equal(t, nine.Mul(six).Cmp(bp384.Scalar.New(54)), 0)
+	require.Equal(t, bp384.Scalar.New(54).Div(nine).Cmp(six), 0)
+}
+
+func TestScalarBrainpoolP384r1Serialize(t *testing.T) {
+	bp384 := BrainpoolP384r1()
+	sc := bp384.Scalar.New(255)
+	sequence := sc.Bytes()
+	require.Equal(t, len(sequence), 48)
+	require.Equal(t, sequence[47], byte(0xff))
+	ret, err := bp384.Scalar.SetBytes(sequence)
+	require.NoError(t, err)
+	require.Equal(t, ret.Cmp(sc), 0)
+
+	// Try 10 random values
+	for i := 0; i < 10; i++ {
+		sc = bp384.Scalar.Random(crand.Reader)
+		sequence = sc.Bytes()
+		require.Equal(t, len(sequence), 48)
+		ret, err = bp384.Scalar.SetBytes(sequence)
+		require.NoError(t, err)
+		require.Equal(t, ret.Cmp(sc), 0)
+	}
+}
+
+func TestScalarBrainpoolP384r1Nil(t *testing.T) {
+	bp384 := BrainpoolP384r1()
+	one := bp384.Scalar.New(1)
+	require.Nil(t, one.Add(nil))
+	require.Nil(t, one.Sub(nil))
+	require.Nil(t, one.Mul(nil))
+	require.Nil(t, one.Div(nil))
+	require.Nil(t, bp384.Scalar.Random(nil))
+	require.Equal(t, one.Cmp(nil), -2)
+	_, err := bp384.Scalar.SetBigInt(nil)
+	require.Error(t, err)
+}
+
+func TestPointBrainpoolP384r1Random(t *testing.T) {
+	bp384 := BrainpoolP384r1()
+	sc := bp384.Point.Random(testRng())
+	s, ok := sc.(*PointBrainpoolP384r1)
+	require.True(t, ok)
+	require.Equal(t, s.X().BigInt(), bhex("7938870f3c591ed96bed83e79764a17d1ef2393490393603382d2616279a1549e5217883fe08f5f60fb67bf37d807fd8"))
+	require.Equal(t, s.Y().BigInt(), bhex("3fb06cdabfe69129895c7580c455c1cd73addcfa7b26c0a80cb65e158cfafaa2e6af2700a05fa58ad57c75ba7b5ffcbc"))
+	// Try 10 random values
+	for i := 0; i < 10; i++ {
+		sc := bp384.Point.Random(crand.Reader)
+		_, ok := sc.(*PointBrainpoolP384r1)
+		require.True(t, ok)
+		require.True(t, !sc.IsIdentity())
+		require.True(t, sc.IsOnCurve())
+	}
+}
+
+func TestPointBrainpoolP384r1Hash(t *testing.T) {
+	tests := []struct {
+		msg  []byte
+		x, y string
+	}{
+		{
+			msg: []byte{},
+			x:   "4f90cdb1ccdcddb5e1229bf989645196e98177cc022822d2bf68162a3ac2331da8b8b16d7fde375cba5c284caed04c71",
+			y:   "4cf9a7f6996af6645670c9347fdc49c7883e87bf7bebce8268ec50a739a424e2769977166925f0ed82691fdea27e9fb2",
+		},
+		{
+			msg: []byte("abc"),
+			x:   "6d6963ff5af59043abc9b05ab4a00fdb439d3138e5756376c85abae1779b229529da8b8788304bdf3134ff7cfa62720b",
+			y:   "7fd3354dd8a4c210765e848b83b73c2df70be956e0cf635d532e35c51e7ea1f7377bd88f839e840bf3c41be74310da95",
+		},
+		{
+			msg: make([]byte, 32),
+			x:   "6760460ec8a4dacab76957ae7c3a9268a78bcff54a459d5a1bcf4f48b7bf1e16942a0b06244f5e1ef5d1c73103d16973",
+			y:   "869a9d42ba75ccc42bbd00693c1cbbee06f42f7fa8ceae8b7a657041d815c52a119077cf6c78688200cff0f6798920d4",
+		},
+	}
+	bp384 := BrainpoolP384r1()
+	for _, tt := range tests {
+		pt, ok := bp384.Point.Hash(tt.msg).(*PointBrainpoolP384r1)
+		require.True(t, ok)
+		require.Equal(t, pt.X().BigInt(), bhex(tt.x))
+		require.Equal(t, pt.Y().BigInt(), bhex(tt.y))
+	}
+}
+
+func TestPointBrainpoolP384r1Generator(t *testing.T) {
+	bp384 := BrainpoolP384r1()
+	sc := bp384.Point.Generator()
+	s, ok := sc.(*PointBrainpoolP384r1)
+	require.True(t, ok)
+	require.True(t, s.IsOnCurve())
+	require.Equal(t, s.X().BigInt(), bhex("1d1c64f068cf45ffa2a63a81b7c13f6b8847a3e77ef14fe3db7fcafe0cbd10e8e826e03436d646aaef87b2e247d4af1e"))
+	require.Equal(t, s.Y().BigInt(), bhex("8abe1d7520f9c2a45cb1eb8e95cfd55262b70b29feec5864e19c054ff99129280e4646217791811142820341263c5315"))
+	_, err := bp384.Point.Set(s.X().BigInt(), s.Y().BigInt())
+	require.NoError(t, err)
+	iden, err := bp384.Point.Set(big.NewInt(0), big.NewInt(0))
+	require.NoError(t, err)
+	require.True(t, iden.IsIdentity())
+}
+
+func TestPointBrainpoolP384r1Arithmetic(t *testing.T) {
+	bp384 := BrainpoolP384r1()
+	g := bp384.Point.Generator()
+	require.True(t, g.Double().Equal(g.Mul(bp384.Scalar.New(2))))
+	require.True(t, g.Add(g).Add(g).Equal(g.Mul(bp384.Scalar.New(3))))
+	require.True(t, g.Neg().Neg().Equal(g))
+	i := bp384.Point.Identity()
+	require.True(t, i.Double().Equal(i))
+	require.True(t, i.Neg().Equal(i))
+	pt := g.Mul(bp384.Scalar.New(4))
+	require.True(t, pt.Sub(g).Sub(g).Sub(g).Equal(g))
+	require.True(t, pt.Sub(g).Sub(g).Sub(g).Sub(g).IsIdentity())
+	require.True(t, g.Mul(bp384.Scalar.New(-1)).Add(g).IsIdentity())
+}
+
+func TestPointBrainpoolP384r1Mul(t *testing.T) {
+	tests := []struct {
+		k, x, y string
+	}{
+		{
+			k: "2",
+			x: "2282bc382a2f4dfcb95c3495d7b4fd590ad520b3eb6be4d6ec2f80c4e0f70df87c4ba74a09b553ebb427b58df9d59fca",
+			y: "edda83773ac68735768d14a24f37a57ce9bedbc170921ce4d89dd051728fc3eb4b4ea69ab64fc288f1b29502b6e1d30",
+		},
+		{
+			k: "3",
+			x: "7b63205bf00ddae73b17452b6a27ebf53df581348c6949f83ee1b6fcc7463bbe3c11ef6596a3b8897d7cc85b3035f11f",
+			y: "761d3a4a5f8093775521a326bc02baaf7b2eb481ead16a5c7b2bd39462363e0373c0edaea3b8f59381d7129d48772eb3",
+		},
+		{
+			k: "1234567890abcdef",
+			x: "740874ac9542812458e7d25afb0d0c286f153f826fc80b9af19d268a9c0359287db398f601eda0d247af90ea69004dc1",
+			y: "4a666a4c852f3c23d10a15f316e884c9a5fb8c291870336add0c932e56dc4d8a74c0b152d43e82f263b6550825455438",
+		},
+		{
+			k: "1234567890abcdef1234567890abcdef",
+			x: "53684d50854f78187f19be5de56c0495767ba8e5f2b270d172f4d040d307868d0c13084bd87e0d40a306a2cb0e69539d",
+			y: "3f036c260d2a579aed1ca94bc953f8363fe56d26e36a6dc3b179f9cd5eb0b2f828bd07ce6543758d300d7e83ae7329d1",
+		},
+	}
+	bp384 := BrainpoolP384r1()
+	for _, tt := range tests {
+		k, err := bp384.Scalar.SetBigInt(bhex(tt.k))
+		require.NoError(t, err)
+		pt := bp384.ScalarBaseMult(k).(*PointBrainpoolP384r1)
+		require.Equal(t, pt.X().BigInt(), bhex(tt.x))
+		require.Equal(t, pt.Y().BigInt(), bhex(tt.y))
+		require.True(t, bp384.Point.Generator().Mul(k).Equal(pt))
+	}
+}
+
+func TestPointBrainpoolP384r1Serialize(t *testing.T) {
+	bp384 := BrainpoolP384r1()
+	ss := bp384.Scalar.Random(testRng())
+	g := bp384.Point.Generator()
+
+	ppt := g.Mul(ss)
+
+	require.Equal(t, ppt.ToAffineCompressed(), []byte{0x3, 0x10, 0x4e, 0xea, 0xdc, 0x16, 0x74, 0x6d, 0xf5, 0xca, 0x54, 0x15, 0x2f, 0x91, 0x4a, 0x1b, 0xc5, 0xd3, 0xb7, 0x52, 0x64, 0x46, 0x47, 0x38, 0x87, 0xbf, 0x2f, 0x35, 0xde, 0x94, 0x3b, 0x92, 0x3a, 0x26, 0x57, 0x36, 0xf9, 0xea, 0xb2, 0x7c, 0x36, 0x3, 0x8a, 0xc8, 0xf2, 0x8f, 0xd4, 0x81, 0xc2})
+	require.Equal(t, ppt.ToAffineUncompressed(), []byte{0x4, 0x10, 0x4e, 0xea, 0xdc, 0x16, 0x74, 0x6d, 0xf5, 0xca, 0x54, 0x15, 0x2f, 0x91, 0x4a, 0x1b, 0xc5, 0xd3, 0xb7, 0x52, 0x64, 0x46, 0x47, 0x38, 0x87, 0xbf, 0x2f, 0x35, 0xde, 0x94, 0x3b, 0x92, 0x3a, 0x26, 0x57, 0x36, 0xf9, 0xea, 0xb2, 0x7c, 0x36, 0x3, 0x8a, 0xc8, 0xf2, 0x8f, 0xd4, 0x81, 0xc2, 0x5d, 0x88, 0x94, 0xe, 0x4, 0x5a, 0x9e, 0xb7, 0xbb, 0xc6, 0xc2, 0xff, 0x95, 0x21, 0x56, 0x84, 0x59, 0xe4, 0x80, 0xc0, 0xe5, 0x9b, 0xbc, 0x4e, 0x1d, 0x2f, 0xc9, 0x21, 0xe6, 0x43, 0x20, 0x9, 0x62, 0x9d, 0xda, 0xb1, 0xa7, 0x4a, 0x7d, 0xc8, 0x16, 0xd1, 0x5e, 0x68, 0x8a, 0x8e, 0x81, 0xbf})
+	retP, err := ppt.FromAffineCompressed(ppt.ToAffineCompressed())
+	require.NoError(t, err)
+	require.True(t, ppt.Equal(retP))
+	retP, err = ppt.FromAffineUncompressed(ppt.ToAffineUncompressed())
+	require.NoError(t, err)
+	require.True(t, ppt.Equal(retP))
+
+	// smoke test
+	for i := 0; i < 25; i++ {
+		s := bp384.Scalar.Random(crand.Reader)
+		pt := g.Mul(s)
+		cmprs := pt.ToAffineCompressed()
+		require.Equal(t, len(cmprs), 49)
+		retC, err := pt.FromAffineCompressed(cmprs)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(retC))
+
+		un := pt.ToAffineUncompressed()
+		require.Equal(t, len(un), 97)
+		retU, err := pt.FromAffineUncompressed(un)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(retU))
+	}
+}
+
+func TestPointBrainpoolP384r1Nil(t *testing.T) {
+	bp384 := BrainpoolP384r1()
+	one := bp384.Point.Generator()
+	require.Nil(t, one.Add(nil))
+	require.Nil(t, one.Sub(nil))
+	require.Nil(t, one.Mul(nil))
+	require.False(t, one.Equal(nil))
+}
+
+func TestPointBrainpoolP384r1SumOfProducts(t *testing.T) {
+	lhs := new(PointBrainpoolP384r1).Generator().Mul(new(ScalarBrainpoolP384r1).New(50))
+	points := make([]Point, 5)
+	for i := range points {
+		points[i] = new(PointBrainpoolP384r1).Generator()
+	}
+	scalars := []Scalar{
+		new(ScalarBrainpoolP384r1).New(8),
+		new(ScalarBrainpoolP384r1).New(9),
+		new(ScalarBrainpoolP384r1).New(10),
+		new(ScalarBrainpoolP384r1).New(11),
+		new(ScalarBrainpoolP384r1).New(12),
+	}
+	rhs := lhs.SumOfProducts(points, scalars)
+	require.NotNil(t, rhs)
+	require.True(t, lhs.Equal(rhs))
+}
+
+func TestPointBrainpoolP384r1GetCurveByName(t *testing.T) {
+	curve := GetCurveByName(BrainpoolP384r1Name)
+	require.NotNil(t, curve)
+	g := curve.Point.Generator()
+	bin, err := PointMarshalBinary(g)
+	require.NoError(t, err)
+	pt, err := PointUnmarshalBinary(bin)
+	require.NoError(t, err)
+	require.True(t, g.Equal(pt))
+}
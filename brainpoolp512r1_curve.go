@@ -0,0 +1,619 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+	"github.com/mikelodder7/curvey/native/brainpool/p512r1"
+)
+
+type ScalarBrainpoolP512r1 struct {
+	value *native.Field8
+}
+
+type PointBrainpoolP512r1 struct {
+	value *native.EllipticPoint8
+}
+
+func (s *ScalarBrainpoolP512r1) Random(reader io.Reader) Scalar {
+	if reader == nil {
+		return nil
+	}
+	var seed [128]byte
+	_, _ = reader.Read(seed[:])
+	return s.Hash(seed[:])
+}
+
+func (*ScalarBrainpoolP512r1) Hash(bytes []byte) Scalar {
+	dst := []byte("brainpoolP512r1_XMD:SHA-512_SSWU_RO_")
+	xmd := native.ExpandMsgXmd(native.EllipticPointHasherSha512(), bytes, dst, 96)
+	var t [128]byte
+	copy(t[:96], internal.ReverseBytes(xmd))
+
+	return &ScalarBrainpoolP512r1{
+		value: p512r1.FqNew().SetBytesWide(&t),
+	}
+}
+
+func (*ScalarBrainpoolP512r1) Zero() Scalar {
+	return &ScalarBrainpoolP512r1{
+		value: p512r1.FqNew().SetZero(),
+	}
+}
+
+func (*ScalarBrainpoolP512r1) One() Scalar {
+	return &ScalarBrainpoolP512r1{
+		value: p512r1.FqNew().SetOne(),
+	}
+}
+
+func (s *ScalarBrainpoolP512r1) IsZero() bool {
+	return s.value.IsZero() == 1
+}
+
+func (s *ScalarBrainpoolP512r1) IsOne() bool {
+	return s.value.IsOne() == 1
+}
+
+func (s *ScalarBrainpoolP512r1) IsOdd() bool {
+	return s.value.Bytes()[0]&1 == 1
+}
+
+func (s *ScalarBrainpoolP512r1) IsEven() bool {
+	return s.value.Bytes()[0]&1 == 0
+}
+
+func (*ScalarBrainpoolP512r1) New(value int) Scalar {
+	t := p512r1.FqNew()
+	v := big.NewInt(int64(value))
+	if value < 0 {
+		v.Mod(v, t.Params.BiModulus)
+	}
+	return &ScalarBrainpoolP512r1{
+		value: t.SetBigInt(v),
+	}
+}
+
+func (s *ScalarBrainpoolP512r1) Cmp(rhs Scalar) int {
+	r, ok := rhs.(*ScalarBrainpoolP512r1)
+	if ok {
+		return s.value.Cmp(r.value)
+	} else {
+		return -2
+	}
+}
+
+func (s *ScalarBrainpoolP512r1) Square() Scalar {
+	return &ScalarBrainpoolP512r1{
+		value: p512r1.FqNew().Square(s.value),
+	}
+}
+
+func (s *ScalarBrainpoolP512r1) Pow(exp uint64) Scalar {
+	expFieldLimb := [native.Field8Limbs]uint64{exp, 0, 0, 0, 0, 0, 0, 0}
+	out := ScalarBrainpoolP512r1{value: p512r1.FqNew()}
+	native.Pow8(&out.value.Value, &s.value.Value, &expFieldLimb, s.value.Params, s.value.Arithmetic)
+	return &ScalarBrainpoolP512r1{
+		value: out.value,
+	}
+}
+
+func (s *ScalarBrainpoolP512r1) Double() Scalar {
+	return &ScalarBrainpoolP512r1{
+		value: p512r1.FqNew().Double(s.value),
+	}
+}
+
+func (s *ScalarBrainpoolP512r1) Invert() (Scalar, error) {
+	value, wasInverted := p512r1.FqNew().Invert(s.value)
+	if !wasInverted {
+		return nil, fmt.Errorf("inverse doesn't exist")
+	}
+	return &ScalarBrainpoolP512r1{
+		value,
+	}, nil
+}
+
+func (s *ScalarBrainpoolP512r1) Sqrt() (Scalar, error) {
+	value, wasSquare := p512r1.FqNew().Sqrt(s.value)
+	if !wasSquare {
+		return nil, fmt.Errorf("not a square")
+	}
+	return &ScalarBrainpoolP512r1{
+		value,
+	}, nil
+}
+
+func (s *ScalarBrainpoolP512r1) Cube() Scalar {
+	value := p512r1.FqNew().Square(s.value)
+	value.Mul(value, s.value)
+	return &ScalarBrainpoolP512r1{
+		value,
+	}
+}
+
+func (s *ScalarBrainpoolP512r1) Add(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBrainpoolP512r1)
+	if ok {
+		return &ScalarBrainpoolP512r1{
+			value: p512r1.FqNew().Add(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBrainpoolP512r1) Sub(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBrainpoolP512r1)
+	if ok {
+		return &ScalarBrainpoolP512r1{
+			value: p512r1.FqNew().Sub(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBrainpoolP512r1) Mul(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBrainpoolP512r1)
+	if ok {
+		return &ScalarBrainpoolP512r1{
+			value: p512r1.FqNew().Mul(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBrainpoolP512r1) MulAdd(y, z Scalar) Scalar {
+	return s.Mul(y).Add(z)
+}
+
+func (s *ScalarBrainpoolP512r1) Div(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBrainpoolP512r1)
+	if ok {
+		v, wasInverted := p512r1.FqNew().Invert(r.value)
+		if !wasInverted {
+			return nil
+		}
+		v.Mul(v, s.value)
+		return &ScalarBrainpoolP512r1{value: v}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBrainpoolP512r1) Neg() Scalar {
+	return &ScalarBrainpoolP512r1{
+		value: p512r1.FqNew().Neg(s.value),
+	}
+}
+
+func (*ScalarBrainpoolP512r1) SetBigInt(v *big.Int) (Scalar, error) {
+	if v == nil {
+		return nil, fmt.Errorf("'v' cannot be nil")
+	}
+	value := p512r1.FqNew().SetBigInt(v)
+	return &ScalarBrainpoolP512r1{
+		value,
+	}, nil
+}
+
+func (s *ScalarBrainpoolP512r1) BigInt() *big.Int {
+	return s.value.BigInt()
+}
+
+func (s *ScalarBrainpoolP512r1) Bytes() []byte {
+	t := s.value.Bytes()
+	return internal.ReverseBytes(t[:])
+}
+
+func (*ScalarBrainpoolP512r1) SetBytes(bytes []byte) (Scalar, error) {
+	if len(bytes) != 64 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [64]byte
+	copy(seq[:], internal.ReverseBytes(bytes))
+	value, err := p512r1.FqNew().SetBytes(&seq)
+	if err != nil {
+		return nil, err
+	}
+	return &ScalarBrainpoolP512r1{
+		value,
+	}, nil
+}
+
+func (*ScalarBrainpoolP512r1) SetBytesWide(bytes []byte) (Scalar, error) {
+	if len(bytes) != 128 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [128]byte
+	copy(seq[:], bytes)
+	return &ScalarBrainpoolP512r1{
+		value: p512r1.FqNew().SetBytesWide(&seq),
+	}, nil
+}
+
+func (*ScalarBrainpoolP512r1) Point() Point {
+	return new(PointBrainpoolP512r1).Identity()
+}
+
+func (s *ScalarBrainpoolP512r1) Clone() Scalar {
+	return &ScalarBrainpoolP512r1{
+		value: p512r1.FqNew().Set(s.value),
+	}
+}
+
+func (s *ScalarBrainpoolP512r1) MarshalBinary() ([]byte, error) {
+	return ScalarMarshalBinary(s)
+}
+
+func (s *ScalarBrainpoolP512r1) UnmarshalBinary(input []byte) error {
+	sc, err := ScalarUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarBrainpoolP512r1)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarBrainpoolP512r1) MarshalText() ([]byte, error) {
+	return ScalarMarshalText(s)
+}
+
+func (s *ScalarBrainpoolP512r1) UnmarshalText(input []byte) error {
+	sc, err := ScalarUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarBrainpoolP512r1)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarBrainpoolP512r1) MarshalJSON() ([]byte, error) {
+	return ScalarMarshalJSON(s)
+}
+
+func (s *ScalarBrainpoolP512r1) UnmarshalJSON(input []byte) error {
+	sc, err := ScalarUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	S, ok := sc.(*ScalarBrainpoolP512r1)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	s.value = S.value
+	return nil
+}
+
+func (p *PointBrainpoolP512r1) Random(reader io.Reader) Point {
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return p.Hash(seed[:])
+}
+
+func (*PointBrainpoolP512r1) Hash(bytes []byte) Point {
+	value, err := p512r1.PointNew().Hash(bytes, native.EllipticPointHasherSha512())
+	// TODO: change hash to return an error also
+	if err != nil {
+		return nil
+	}
+
+	return &PointBrainpoolP512r1{value}
+}
+
+func (*PointBrainpoolP512r1) Identity() Point {
+	return &PointBrainpoolP512r1{
+		value: p512r1.PointNew().Identity(),
+	}
+}
+
+func (*PointBrainpoolP512r1) Generator() Point {
+	return &PointBrainpoolP512r1{
+		value: p512r1.PointNew().Generator(),
+	}
+}
+
+func (p *PointBrainpoolP512r1) IsIdentity() bool {
+	return p.value.IsIdentity()
+}
+
+func (p *PointBrainpoolP512r1) IsNegative() bool {
+	return p.value.GetY().Value[0]&1 == 1
+}
+
+func (p *PointBrainpoolP512r1) IsOnCurve() bool {
+	return p.value.IsOnCurve()
+}
+
+func (p *PointBrainpoolP512r1) Double() Point {
+	value := p512r1.PointNew().Double(p.value)
+	return &PointBrainpoolP512r1{value}
+}
+
+func (*PointBrainpoolP512r1) Scalar() Scalar {
+	return new(ScalarBrainpoolP512r1).Zero()
+}
+
+func (p *PointBrainpoolP512r1) Neg() Point {
+	value := p512r1.PointNew().Neg(p.value)
+	return &PointBrainpoolP512r1{value}
+}
+
+func (p *PointBrainpoolP512r1) Add(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointBrainpoolP512r1)
+	if ok {
+		value := p512r1.PointNew().Add(p.value, r.value)
+		return &PointBrainpoolP512r1{value}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointBrainpoolP512r1) Sub(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointBrainpoolP512r1)
+	if ok {
+		value := p512r1.PointNew().Sub(p.value, r.value)
+		return &PointBrainpoolP512r1{value}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointBrainpoolP512r1) Mul(rhs Scalar) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*ScalarBrainpoolP512r1)
+	if ok {
+		value := p512r1.PointNew().Mul(p.value, r.value)
+		return &PointBrainpoolP512r1{value}
+	} else {
+		return nil
+	}
+}
+
+func (*PointBrainpoolP512r1) selectPoint(row []Point, index int) Point {
+	value := p512r1.PointNew().Identity()
+	for i, pt := range row {
+		value.CMove(value, pt.(*PointBrainpoolP512r1).value, ctEqual(i+1, index))
+	}
+	return &PointBrainpoolP512r1{value}
+}
+
+func (p *PointBrainpoolP512r1) Equal(rhs Point) bool {
+	r, ok := rhs.(*PointBrainpoolP512r1)
+	if ok {
+		return p.value.Equal(r.value) == 1
+	} else {
+		return false
+	}
+}
+
+func (*PointBrainpoolP512r1) Set(x, y *big.Int) (Point, error) {
+	value, err := p512r1.PointNew().SetBigInt(x, y)
+	if err != nil {
+		return nil, err
+	}
+	return &PointBrainpoolP512r1{value}, nil
+}
+
+func (p *PointBrainpoolP512r1) ToAffineCompressed() []byte {
+	var x [65]byte
+	x[0] = byte(2)
+
+	t := p512r1.PointNew().ToAffine(p.value)
+
+	x[0] |= t.Y.Bytes()[0] & 1
+
+	xBytes := t.X.Bytes()
+	copy(x[1:], internal.ReverseBytes(xBytes[:]))
+	return x[:]
+}
+
+func (p *PointBrainpoolP512r1) ToAffineUncompressed() []byte {
+	var out [129]byte
+	out[0] = byte(4)
+	t := p512r1.PointNew().ToAffine(p.value)
+	arr := t.X.Bytes()
+	copy(out[1:65], internal.ReverseBytes(arr[:]))
+	arr = t.Y.Bytes()
+	copy(out[65:], internal.ReverseBytes(arr[:]))
+	return out[:]
+}
+
+func (p *PointBrainpoolP512r1) FromAffineCompressed(bytes []byte) (Point, error) {
+	var raw [native.Field8Bytes]byte
+	if len(bytes) != 65 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	sign := int(bytes[0])
+	if sign != 2 && sign != 3 {
+		return nil, fmt.Errorf("invalid sign byte")
+	}
+	sign &= 0x1
+
+	copy(raw[:], internal.ReverseBytes(bytes[1:]))
+	x, err := p512r1.FpNew().SetBytes(&raw)
+	if err != nil {
+		return nil, err
+	}
+
+	value := p512r1.PointNew().Identity()
+	rhs := p512r1.FpNew()
+	p.value.Arithmetic.RhsEquation(rhs, x)
+	// test that rhs is quadratic residue
+	// if not, then this Point is at infinity
+	y, wasQr := p512r1.FpNew().Sqrt(rhs)
+	if wasQr {
+		// fix the sign
+		sigY := int(y.Bytes()[0] & 1)
+		if sigY != sign {
+			y.Neg(y)
+		}
+		value.X = x
+		value.Y = y
+		value.Z.SetOne()
+	}
+	return &PointBrainpoolP512r1{value}, nil
+}
+
+func (*PointBrainpoolP512r1) FromAffineUncompressed(bytes []byte) (Point, error) {
+	var arr [native.Field8Bytes]byte
+	if len(bytes) != 129 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	if bytes[0] != 4 {
+		return nil, fmt.Errorf("invalid sign byte")
+	}
+
+	copy(arr[:], internal.ReverseBytes(bytes[1:65]))
+	x, err := p512r1.FpNew().SetBytes(&arr)
+	if err != nil {
+		return nil, err
+	}
+	copy(arr[:], internal.ReverseBytes(bytes[65:]))
+	y, err := p512r1.FpNew().SetBytes(&arr)
+	if err != nil {
+		return nil, err
+	}
+	value := p512r1.PointNew()
+	value.X = x
+	value.Y = y
+	value.Z.SetOne()
+	return &PointBrainpoolP512r1{value}, nil
+}
+
+func (*PointBrainpoolP512r1) CurveName() string {
+	return BrainpoolP512r1Name
+}
+
+func (*PointBrainpoolP512r1) SumOfProducts(points []Point, scalars []Scalar) Point {
+	nPoints := make([]*native.EllipticPoint8, len(points))
+	nScalars := make([]*native.Field8, len(scalars))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointBrainpoolP512r1)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarBrainpoolP512r1)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.value
+	}
+	value := p512r1.PointNew()
+	_, err := value.SumOfProducts(nPoints, nScalars)
+	if err != nil {
+		return nil
+	}
+	return &PointBrainpoolP512r1{value}
+}
+
+func (p *PointBrainpoolP512r1) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point {
+	return p.VarTimeMultiScalarMult([]Scalar{a, b}, []Point{capA, p.Generator()})
+}
+
+func (p *PointBrainpoolP512r1) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	return varTimeMultiScalarMult(p, scalars, points)
+}
+
+func (*PointBrainpoolP512r1) batchNormalize(points []Point) []Point {
+	nPoints := make([]*native.EllipticPoint8, len(points))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointBrainpoolP512r1)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	out := make([]Point, len(points))
+	for i, value := range native.BatchToAffine8(nPoints) {
+		out[i] = &PointBrainpoolP512r1{value}
+	}
+	return out
+}
+
+func (p *PointBrainpoolP512r1) X() *native.Field8 {
+	return p.value.GetX()
+}
+
+func (p *PointBrainpoolP512r1) Y() *native.Field8 {
+	return p.value.GetY()
+}
+
+func (p *PointBrainpoolP512r1) MarshalBinary() ([]byte, error) {
+	return PointMarshalBinary(p)
+}
+
+func (p *PointBrainpoolP512r1) UnmarshalBinary(input []byte) error {
+	pt, err := PointUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointBrainpoolP512r1)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointBrainpoolP512r1) MarshalText() ([]byte, error) {
+	return PointMarshalText(p)
+}
+
+func (p *PointBrainpoolP512r1) UnmarshalText(input []byte) error {
+	pt, err := PointUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointBrainpoolP512r1)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointBrainpoolP512r1) MarshalJSON() ([]byte, error) {
+	return PointMarshalJSON(p)
+}
+
+func (p *PointBrainpoolP512r1) UnmarshalJSON(input []byte) error {
+	pt, err := PointUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	P, ok := pt.(*PointBrainpoolP512r1)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	p.value = P.value
+	return nil
+}
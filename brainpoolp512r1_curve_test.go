@@ -0,0 +1,325 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	crand "crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// The k*G vectors in this file were computed with OpenSSL 3.0 and the hash
+// vectors with a direct transcription of RFC 9380 expand_message_xmd and the
+// simplified SWU map written with Python integers, independently of this
+// package.
+
+func TestScalarBrainpoolP512r1Random(t *testing.T) {
+	bp512 := BrainpoolP512r1()
+	sc := bp512.Scalar.Random(testRng())
+	s, ok := sc.(*ScalarBrainpoolP512r1)
+	require.True(t, ok)
+	expected := bhex("8145862e9541edddd63f32af1c02cc899d28bd63b38858503b7920ca34f190a76614fc74385e29f36fb94961af87fc01f9a2a1777e63b2e4c44491fbd54a55cc")
+	require.Equal(t, s.value.BigInt(), expected)
+	// Try 10 random values
+	for i := 0; i < 10; i++ {
+		sc := bp512.Scalar.Random(crand.Reader)
+		_, ok := sc.(*ScalarBrainpoolP512r1)
+		require.True(t, ok)
+		require.True(t, !sc.IsZero())
+	}
+}
+
+func TestScalarBrainpoolP512r1Hash(t *testing.T) {
+	tests := []struct {
+		msg      []byte
+		expected string
+	}{
+		{[]byte{}, "22a66750d4df5d417b9df5e5156ba2e1037f5d1b9565a0d6a80576ebb3cabe47c949712e57a410ea4010e65ae882e50a19d1eeffb5c0a653686a9ec584643093"},
+		{[]byte("abc"), "3f56516dfa27da915e38a131447df11548b16bf203067112225f324261f085c8a5d8a45e4b9952f0ba026b9817d2ce03a26cfa192fd3b98cae204165b83f7071"},
+		{make([]byte, 32), "295b46c107a8bb17d4494186d45a9e1aac58656594328df7457443ce2b31794129e199d36a92a4f851e88f7d7fa97fa15c5114412b2218a528e62d77c17bb8e1"},
+	}
+	bp512 := BrainpoolP512r1()
+	for _, tt := range tests {
+		s, ok := bp512.Scalar.Hash(tt.msg).(*ScalarBrainpoolP512r1)
+		require.True(t, ok)
+		require.Equal(t, s.value.BigInt(), bhex(tt.expected))
+	}
+}
+
+func TestScalarBrainpoolP512r1New(t *testing.T) {
+	bp512 := BrainpoolP512r1()
+	three := bp512.Scalar.New(3)
+	require.True(t, three.IsOdd())
+	four := bp512.Scalar.New(4)
+	require.True(t, four.IsEven())
+	neg1 := bp512.Scalar.New(-1)
+	require.True(t, neg1.IsEven())
+	neg2 := bp512.Scalar.New(-2)
+	require.True(t, neg2.IsOdd())
+	require.Equal(t, three.Square().Cmp(bp512.Scalar.New(9)), 0)
+	require.Equal(t, three.Cube().Cmp(bp512.Scalar.New(27)), 0)
+	require.Equal(t, three.Double().Cmp(bp512.Scalar.New(6)), 0)
+	require.Equal(t, bp512.Scalar.One().Neg().Cmp(neg1), 0)
+}
+
+func TestScalarBrainpoolP512r1Invert(t *testing.T) {
+	bp512 := BrainpoolP512r1()
+	nine := bp512.Scalar.New(9)
+	actual, err := nine.Invert()
+	require.NoError(t, err)
+
+	bn := new(big.Int).SetInt64(9)
+	bn.ModInverse(bn, bhex("aadd9db8dbe9c48b3fd4e6ae33c9fc07cb308db3b3c9d20ed6639cca70330870553e5c414ca92619418661197fac10471db1d381085ddaddb58796829ca90069"))
+
+	expected, err := bp512.Scalar.SetBigInt(bn)
+	require.NoError(t, err)
+	require.Equal(t, actual.Cmp(expected), 0)
+}
+
+func TestScalarBrainpoolP512r1Sqrt(t *testing.T) {
+	bp512 := BrainpoolP512r1()
+	nine := bp512.Scalar.New(9)
+	actual, err := nine.Sqrt()
+	require.NoError(t, err)
+	three := bp512.Scalar.New(3)
+	require.True(t, actual.Cmp(three) == 0 || actual.Cmp(three.Neg()) == 0)
+}
+
+func TestScalarBrainpoolP512r1Add(t *testing.T) {
+	bp512 := BrainpoolP512r1()
+	nine := bp512.Scalar.New(9)
+	six := bp512.Scalar.New(6)
+	require.Equal(t, nine.Add(six).Cmp(bp512.Scalar.New(15)), 0)
+	n := bhex("aadd9db8dbe9c48b3fd4e6ae33c9fc07cb308db3b3c9d20ed6639cca70330870553e5c414ca92619418661197fac10471db1d381085ddaddb58796829ca90069")
+	n.Sub(n, big.NewInt(3))
+
+	upper, err := bp512.Scalar.SetBigInt(n)
+	require.NoError(t, err)
+	require.Equal(t, upper.Add(nine).Cmp(six), 0)
+	require.Equal(t, six.Sub(nine).Cmp(upper), 0)
+	require.Equal(t, nine.Mul(six).Cmp(bp512.Scalar.New(54)), 0)
+	require.Equal(t, bp512.Scalar.New(54).Div(nine).Cmp(six), 0)
+}
+
+func TestScalarBrainpoolP512r1Serialize(t *testing.T) {
+	bp512 := BrainpoolP512r1()
+	sc := bp512.Scalar.New(255)
+	sequence := sc.Bytes()
+	require.Equal(t, len(sequence), 64)
+	require.Equal(t, sequence[63], byte(0xff))
+	ret, err := bp512.Scalar.SetBytes(sequence)
+	require.NoError(t, err)
+	require.Equal(t, ret.Cmp(sc), 0)
+
+	// Try 10 random values
+	for i := 0; i < 10; i++ {
+		sc = bp512.Scalar.Random(crand.Reader)
+		sequence = sc.Bytes()
+		require.Equal(t, len(sequence), 64)
+		ret, err = bp512.Scalar.SetBytes(sequence)
+		require.NoError(t, err)
+		require.Equal(t, ret.Cmp(sc), 0)
+	}
+}
+
+func TestScalarBrainpoolP512r1Nil(t *testing.T) {
+	bp512 := BrainpoolP512r1()
+	one := bp512.Scalar.New(1)
+	require.Nil(t, one.Add(nil))
+	require.Nil(t, one.Sub(nil))
+	require.Nil(t, one.Mul(nil))
+	require.Nil(t, one.Div(nil))
+	require.Nil(t, bp512.Scalar.Random(nil))
+	require.Equal(t, one.Cmp(nil), -2)
+	_, err := bp512.Scalar.SetBigInt(nil)
+	require.Error(t, err)
+}
+
+func TestPointBrainpoolP512r1Random(t *testing.T) {
+	bp512 := BrainpoolP512r1()
+	sc := bp512.Point.Random(testRng())
+	s, ok := sc.(*PointBrainpoolP512r1)
+	require.True(t, ok)
+	require.Equal(t, s.X().BigInt(), bhex("0db61b37607361d4760e362b9f5428bdd452ce0cbfd0fd4b6a24fd57e4f2ae11d59c71eb2c2076698fbe7b816aaa260cff75a89bb9d309817297087511cb4274"))
+	require.Equal(t, s.Y().BigInt(), bhex("3f798720ee5ea9bec214e2b3cc636f6ab0f914bbdb0f2ded353c2c771aaa3c8180eadbe0077f55c3447b6c176558afda0b2e6dd3ab592d7d558ac267125c586b"))
+	// Try 10 random values
+	for i := 0; i < 10; i++ {
+		sc := bp512.Point.Random(crand.Reader)
+		_, ok := sc.(*PointBrainpoolP512r1)
+		require.True(t, ok)
+		require.True(t, !sc.IsIdentity())
+		require.True(t, sc.IsOnCurve())
+	}
+}
+
+func TestPointBrainpoolP512r1Hash(t *testing.T) {
+	tests := []struct {
+		msg  []byte
+		x, y string
+	}{
+		{
+			msg: []byte{},
+			x:   "21ae39c8180841bc6e9486b03c4b4f8989ae91e50f932570cce6f7cc14273ca0b19dbf10cbe97fe8519bccf66457eafa6b917bfbe0fbad1aa1340289e4bccb9",
+			y:   "36818ed34c99ca9aadde80f9578b1e90780b7c6ed3f910794d2901f95fc18109df35da84e0605d9f51235f52969d49f88cfe6d90edf4e3493f28b882d101e048",
+		},
+		{
+			msg: []byte("abc"),
+			x:   "1c096e4ce48ba242109f50c109d83942c8bfd4b9374c72f9a2c97879469f0d918e0bcb82613f9ec8eacc45eb48b6989cb60de0438a07d5e328f7d8fa56eb70f1",
+			y:   "54a5bfc595abdd34de829b438fc441b2d0534803e382aa1ac3817d9a9717e9ec930ffc4303d83d49330edf36fc3eca983b2b90e8cefdc2732aacf1807fada9b6",
+		},
+		{
+			msg: make([]byte, 32),
+			x:   "8b26a3142e746fe2af11ed458093c72e790f823bed63f1523011f40c5cf3ae168168883e26196e8353340fd72b0339c9cfdde6b190c7577927c0d61ffe97c012",
+			y:   "9f431dc9cb21ae245062be543d1f519bb8a212487749a8c282c2e3bf04fa2a4aafeb034560b10e00f55e2fff24e2abcc83c6c5be1820fa3c2caa07a44ce5699a",
+		},
+	}
+	bp512 := BrainpoolP512r1()
+	for _, tt := range tests {
+		pt, ok := bp512.Point.Hash(tt.msg).(*PointBrainpoolP512r1)
+		require.True(t, ok)
+		require.Equal(t, pt.X().BigInt(), bhex(tt.x))
+		require.Equal(t, pt.Y().BigInt(), bhex(tt.y))
+	}
+}
+
+func TestPointBrainpoolP512r1Generator(t *testing.T) {
+	bp512 := BrainpoolP512r1()
+	sc := bp512.Point.Generator()
+	s, ok := sc.(*PointBrainpoolP512r1)
+	require.True(t, ok)
+	require.True(t, s.IsOnCurve())
+	require.Equal(t, s.X().BigInt(), bhex("81aee4bdd82ed9645a21322e9c4c6a9385ed9f70b5d916c1b43b62eef4d0098eff3b1f78e2d0d48d50d1687b93b97d5f7c6d5047406a5e688b352209bcb9f822"))
+	require.Equal(t, s.Y().BigInt(), bhex("7dde385d566332ecc0eabfa9cf7822fdf209f70024a57b1aa000c55b881f8111b2dcde494a5f485e5bca4bd88a2763aed1ca2b2fa8f0540678cd1e0f3ad80892"))
+	_, err := bp512.Point.Set(s.X().BigInt(), s.Y().BigInt())
+	require.NoError(t, err)
+	iden, err := bp512.Point.Set(big.NewInt(0), big.NewInt(0))
+	require.NoError(t, err)
+	require.True(t, iden.IsIdentity())
+}
+
+func TestPointBrainpoolP512r1Arithmetic(t *testing.T) {
+	bp512 := BrainpoolP512r1()
+	g := bp512.Point.Generator()
+	require.True(t, g.Double().Equal(g.Mul(bp512.Scalar.New(2))))
+	require.True(t, g.Add(g).Add(g).Equal(g.Mul(bp512.Scalar.New(3))))
+	require.True(t, g.Neg().Neg().Equal(g))
+	i := bp512.Point.Identity()
+	require.True(t, i.Double().Equal(i))
+	require.True(t, i.Neg().Equal(i))
+	pt := g.Mul(bp512.Scalar.New(4))
+	require.True(t, pt.Sub(g).Sub(g).Sub(g).Equal(g))
+	require.True(t, pt.Sub(g).Sub(g).Sub(g).Sub(g).IsIdentity())
+	require.True(t, g.Mul(bp512.Scalar.New(-1)).Add(g).IsIdentity())
+}
+
+func TestPointBrainpoolP512r1Mul(t *testing.T) {
+	tests := []struct {
+		k, x, y string
+	}{
+		{
+			k: "2",
+			x: "9f4945f680edf9800a63285758f399b3d18d8141b8a18064a30d3035f4cb6581957877f3a8f0f72597116e702915a4f4f698f404089a4cc5080447def02f4850",
+			y: "6d6b4b188b699c5649826b716292f29d149ce1238d3f1e0f5a2c366b03e5d1b2fdf99bb1709c700fa5c3b602b0960cbf63a42e4181fd929ce269ad21be592e71",
+		},
+		{
+			k: "3",
+			x: "8dd87e12b0a4cc436cdd42543f20afe907c80ef3bc2459309c09cefd830151bc1f6fb975ceecade4780ae53e1853d62f56e34abfa9ac7205d4abf882ccb8d94",
+			y: "26ef5c6e1dab71d756ff0067376fa7543d903b4a6334c4bba0b382e1716d843acdab8eb772327b3febfcb69c0f37c5f8cce5bc75d8de6495cdeafba05b02c37",
+		},
+		{
+			k: "1234567890abcdef",
+			x: "6beff7b4920fb8b049324a3092a9b8c30fe937d3cd3ac533814a46115ddf6ac356598ef6342f0b4dc91748dd0b31e6de50e63699e31972ce0ee0be0afe49ca49",
+			y: "8fc68d70a886eaa8bf5b666fa7d53cfff8bf7bab56c54f033dc035175c43e27591d1b62b8d9639a0da7d30936f43e1638082803b859ba916961c3b5a8af0fcf7",
+		},
+		{
+			k: "1234567890abcdef1234567890abcdef",
+			x: "9c7650dcdcd322ba854d0944f63b1ba01dfead7bec35aedad1d1db34ad2c00a46186eceb41eb5043f63a2a42df1b03f6a056e636612498f821b80b92c0b7bea",
+			y: "31706136e2d8d662edebfdb7e9334af10ff3c4f480571d86cad9283a2eda27acd7f2ba911e75e4141855ede6ddfea67afcaaaa64c29f0b4fb6fd13fa80a857ee",
+		},
+	}
+	bp512 := BrainpoolP512r1()
+	for _, tt := range tests {
+		k, err := bp512.Scalar.SetBigInt(bhex(tt.k))
+		require.NoError(t, err)
+		pt := bp512.ScalarBaseMult(k).(*PointBrainpoolP512r1)
+		require.Equal(t, pt.X().BigInt(), bhex(tt.x))
+		require.Equal(t, pt.Y().BigInt(), bhex(tt.y))
+		require.True(t, bp512.Point.Generator().Mul(k).Equal(pt))
+	}
+}
+
+func TestPointBrainpoolP512r1Serialize(t *testing.T) {
+	bp512 := BrainpoolP512r1()
+	ss := bp512.Scalar.Random(testRng())
+	g := bp512.Point.Generator()
+
+	ppt := g.Mul(ss)
+
+	require.Equal(t, ppt.ToAffineCompressed(), []byte{0x2, 0x22, 0x83, 0x2d, 0xe0, 0x6c, 0x6, 0x99, 0xc9, 0x21, 0xc1, 0x45, 0x1b, 0xb5, 0xa8, 0xba, 0x10, 0xf1, 0x5f, 0xa0, 0xa7, 0x7a, 0xdd, 0x4, 0x42, 0x67, 0x45, 0xe4, 0xab, 0x5c, 0x3c, 0xd1, 0x90, 0xe, 0x84, 0x50, 0xf1, 0x10, 0x5b, 0xdb, 0xc1, 0xdf, 0xd3, 0x74, 0xf1, 0xd0, 0x82, 0x7a, 0xe2, 0xe4, 0xf2, 0xf4, 0x87, 0x15, 0x4, 0xc0, 0x60, 0xe0, 0x4b, 0xec, 0xaf, 0x5d, 0x4a, 0xd3, 0xc4})
+	require.Equal(t, ppt.ToAffineUncompressed(), []byte{0x4, 0x22, 0x83, 0x2d, 0xe0, 0x6c, 0x6, 0x99, 0xc9, 0x21, 0xc1, 0x45, 0x1b, 0xb5, 0xa8, 0xba, 0x10, 0xf1, 0x5f, 0xa0, 0xa7, 0x7a, 0xdd, 0x4, 0x42, 0x67, 0x45, 0xe4, 0xab, 0x5c, 0x3c, 0xd1, 0x90, 0xe, 0x84, 0x50, 0xf1, 0x10, 0x5b, 0xdb, 0xc1, 0xdf, 0xd3, 0x74, 0xf1, 0xd0, 0x82, 0x7a, 0xe2, 0xe4, 0xf2, 0xf4, 0x87, 0x15, 0x4, 0xc0, 0x60, 0xe0, 0x4b, 0xec, 0xaf, 0x5d, 0x4a, 0xd3, 0xc4, 0x8f, 0xb4, 0xf1, 0x8f, 0x5e, 0xf6, 0x9, 0xed, 0xba, 0x27, 0x79, 0x6b, 0x43, 0x2b, 0xed, 0xbb, 0xe7, 0x1f, 0x9f, 0x21, 0x63, 0xe8, 0x13, 0xa9, 0x2b, 0x44, 0xd3, 0x12, 0xb2, 0x8c, 0x44, 0xa5, 0xc0, 0x24, 0x59, 0xee, 0x48, 0x3e, 0xa1, 0x5e, 0x13, 0xab, 0x44, 0x2e, 0x77, 0xce, 0xc3, 0x22, 0x74, 0xe9, 0x3e, 0x7d, 0x1a, 0xd8, 0x40, 0xee, 0x1d, 0x48, 0x6e, 0xe5, 0xb7, 0x20, 0x17, 0x10})
+	retP, err := ppt.FromAffineCompressed(ppt.ToAffineCompressed())
+	require.NoError(t, err)
+	require.True(t, ppt.Equal(retP))
+	retP, err = ppt.FromAffineUncompressed(ppt.ToAffineUncompressed())
+	require.NoError(t, err)
+	require.True(t, ppt.Equal(retP))
+
+	// smoke test
+	for i := 0; i < 25; i++ {
+		s := bp512.Scalar.Random(crand.Reader)
+		pt := g.Mul(s)
+		cmprs := pt.ToAffineCompressed()
+		require.Equal(t, len(cmprs), 65)
+		retC, err := pt.FromAffineCompressed(cmprs)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(retC))
+
+		un := pt.ToAffineUncompressed()
+		require.Equal(t, len(un), 129)
+		retU, err := pt.FromAffineUncompressed(un)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(retU))
+	}
+}
+
+func TestPointBrainpoolP512r1Nil(t *testing.T) {
+	bp512 := BrainpoolP512r1()
+	one := bp512.Point.Generator()
+	require.Nil(t, one.Add(nil))
+	require.Nil(t, one.Sub(nil))
+	require.Nil(t, one.Mul(nil))
+	require.False(t, one.Equal(nil))
+}
+
+func TestPointBrainpoolP512r1SumOfProducts(t *testing.T) {
+	lhs := new(PointBrainpoolP512r1).Generator().Mul(new(ScalarBrainpoolP512r1).New(50))
+	points := make([]Point, 5)
+	for i := range points {
+		points[i] = new(PointBrainpoolP512r1).Generator()
+	}
+	scalars := []Scalar{
+		new(ScalarBrainpoolP512r1).New(8),
+		new(ScalarBrainpoolP512r1).New(9),
+		new(ScalarBrainpoolP512r1).New(10),
+		new(ScalarBrainpoolP512r1).New(11),
+		new(ScalarBrainpoolP512r1).New(12),
+	}
+	rhs := lhs.SumOfProducts(points, scalars)
+	require.NotNil(t, rhs)
+	require.True(t, lhs.Equal(rhs))
+}
+
+func TestPointBrainpoolP512r1GetCurveByName(t *testing.T) {
+	curve := GetCurveByName(BrainpoolP512r1Name)
+	require.NotNil(t, curve)
+	g := curve.Point.Generator()
+	bin, err := PointMarshalBinary(g)
+	require.NoError(t, err)
+	pt, err := PointUnmarshalBinary(bin)
+	require.NoError(t, err)
+	require.True(t, g.Equal(pt))
+}
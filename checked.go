@@ -0,0 +1,263 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// The functions in this file are checked variants of the Point and Scalar
+// methods. Rather than returning nil, which later surfaces as a nil
+// pointer panic, they report failures with the sentinel errors in errors.go.
+// They accept any implementation including the pairing target group types.
+
+// fullReader reads with io.ReadFull semantics and remembers the first
+// error so it can be reported after a Random method has ignored it.
+type fullReader struct {
+	reader io.Reader
+	err    error
+}
+
+func (r *fullReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	n, err := io.ReadFull(r.reader, p)
+	if err != nil {
+		r.err = err
+	}
+	return n, err
+}
+
+// isNil returns true for nil interfaces and interfaces holding a nil pointer.
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
+func checkPoints(points ...Point) error {
+	for _, p := range points {
+		if isNil(p) {
+			return ErrNilArgument
+		}
+	}
+	return nil
+}
+
+func checkScalars(scalars ...Scalar) error {
+	for _, s := range scalars {
+		if isNil(s) {
+			return ErrNilArgument
+		}
+	}
+	return nil
+}
+
+func checkReader(reader io.Reader) (*fullReader, error) {
+	if isNil(reader) {
+		return nil, ErrNilArgument
+	}
+	return &fullReader{reader: reader}, nil
+}
+
+func randomError(err error) error {
+	return fmt.Errorf("%w: %w", ErrRandom, err)
+}
+
+// RandomScalarE returns a random scalar of the same type as s.
+// The reader must supply all the requested bytes otherwise
+// an error wrapping both ErrRandom and the read error is returned.
+func RandomScalarE(s Scalar, reader io.Reader) (Scalar, error) {
+	if err := checkScalars(s); err != nil {
+		return nil, err
+	}
+	r, err := checkReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	out := s.Random(r)
+	if r.err != nil {
+		return nil, randomError(r.err)
+	}
+	if out == nil {
+		return nil, ErrRandom
+	}
+	return out, nil
+}
+
+// RandomPointE returns a random point of the same type as p.
+// The reader must supply all the requested bytes otherwise
+// an error wrapping both ErrRandom and the read error is returned.
+func RandomPointE(p Point, reader io.Reader) (Point, error) {
+	if err := checkPoints(p); err != nil {
+		return nil, err
+	}
+	r, err := checkReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	out := p.Random(r)
+	if r.err != nil {
+		return nil, randomError(r.err)
+	}
+	if out == nil {
+		return nil, ErrRandom
+	}
+	return out, nil
+}
+
+// HashScalarE hashes msg to a scalar of the same type as s.
+func HashScalarE(s Scalar, msg []byte) (Scalar, error) {
+	if err := checkScalars(s); err != nil {
+		return nil, err
+	}
+	out := s.Hash(msg)
+	if out == nil {
+		return nil, ErrHash
+	}
+	return out, nil
+}
+
+// HashPointE hashes msg to a point of the same type as p.
+func HashPointE(p Point, msg []byte) (Point, error) {
+	if err := checkPoints(p); err != nil {
+		return nil, err
+	}
+	out := p.Hash(msg)
+	if out == nil {
+		return nil, ErrHash
+	}
+	return out, nil
+}
+
+// AddE returns lhs + rhs or ErrTypeMismatch if they are on different curves.
+func AddE(lhs, rhs Point) (Point, error) {
+	if err := checkPoints(lhs, rhs); err != nil {
+		return nil, err
+	}
+	return checkedPoint(lhs.Add(rhs))
+}
+
+// SubE returns lhs - rhs or ErrTypeMismatch if they are on different curves.
+func SubE(lhs, rhs Point) (Point, error) {
+	if err := checkPoints(lhs, rhs); err != nil {
+		return nil, err
+	}
+	return checkedPoint(lhs.Sub(rhs))
+}
+
+// MulE returns p * s or ErrTypeMismatch if s is not a scalar for p.
+func MulE(p Point, s Scalar) (Point, error) {
+	if err := checkPoints(p); err != nil {
+		return nil, err
+	}
+	if err := checkScalars(s); err != nil {
+		return nil, err
+	}
+	return checkedPoint(p.Mul(s))
+}
+
+// SumOfProductsE computes the multi-scalar multiplication of points and
+// scalars using p to select the curve.
+func SumOfProductsE(p Point, points []Point, scalars []Scalar) (Point, error) {
+	if len(points) != len(scalars) {
+		return nil, ErrLengthMismatch
+	}
+	if err := checkPoints(p); err != nil {
+		return nil, err
+	}
+	if err := checkPoints(points...); err != nil {
+		return nil, err
+	}
+	if err := checkScalars(scalars...); err != nil {
+		return nil, err
+	}
+	return checkedPoint(p.SumOfProducts(points, scalars))
+}
+
+// MultiPairingE computes the product of the pairings of the G1, G2 pairs
+// in points. An error is returned if points is not a non-empty sequence of
+// pairs from the same pairing curve.
+func MultiPairingE(points ...PairingPoint) (Scalar, error) {
+	if len(points) == 0 || len(points)%2 != 0 {
+		return nil, ErrLengthMismatch
+	}
+	for _, p := range points {
+		if isNil(p) {
+			return nil, ErrNilArgument
+		}
+	}
+	return checkedScalar(points[0].MultiPairing(points...))
+}
+
+// ScalarAddE returns lhs + rhs or ErrTypeMismatch if they are from different fields.
+func ScalarAddE(lhs, rhs Scalar) (Scalar, error) {
+	if err := checkScalars(lhs, rhs); err != nil {
+		return nil, err
+	}
+	return checkedScalar(lhs.Add(rhs))
+}
+
+// ScalarSubE returns lhs - rhs or ErrTypeMismatch if they are from different fields.
+func ScalarSubE(lhs, rhs Scalar) (Scalar, error) {
+	if err := checkScalars(lhs, rhs); err != nil {
+		return nil, err
+	}
+	return checkedScalar(lhs.Sub(rhs))
+}
+
+// ScalarMulE returns lhs * rhs or ErrTypeMismatch if they are from different fields.
+func ScalarMulE(lhs, rhs Scalar) (Scalar, error) {
+	if err := checkScalars(lhs, rhs); err != nil {
+		return nil, err
+	}
+	return checkedScalar(lhs.Mul(rhs))
+}
+
+// ScalarDivE returns lhs / rhs, ErrNotInvertible if rhs is zero
+// or ErrTypeMismatch if they are from different fields.
+func ScalarDivE(lhs, rhs Scalar) (Scalar, error) {
+	if err := checkScalars(lhs, rhs); err != nil {
+		return nil, err
+	}
+	if rhs.IsZero() {
+		return nil, ErrNotInvertible
+	}
+	return checkedScalar(lhs.Div(rhs))
+}
+
+// ScalarInvertE returns the inverse of s or an error wrapping ErrNotInvertible.
+func ScalarInvertE(s Scalar) (Scalar, error) {
+	if err := checkScalars(s); err != nil {
+		return nil, err
+	}
+	out, err := s.Invert()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNotInvertible, err)
+	}
+	if out == nil {
+		return nil, ErrNotInvertible
+	}
+	return out, nil
+}
+
+func checkedPoint(p Point) (Point, error) {
+	if isNil(p) {
+		return nil, ErrTypeMismatch
+	}
+	return p, nil
+}
+
+func checkedScalar(s Scalar) (Scalar, error) {
+	if isNil(s) {
+		return nil, ErrTypeMismatch
+	}
+	return s, nil
+}
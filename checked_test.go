@@ -0,0 +1,157 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"bytes"
+	crand "crypto/rand"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckedRandom(t *testing.T) {
+	gt := BLS12381(BLS12381G1().NewIdentityPoint()).GT
+	scalars := []Scalar{gt}
+	points := []Point{new(PointBls12381Gt).Generator()}
+	for _, name := range RegisteredCurves() {
+		curve := GetCurveByName(name)
+		scalars = append(scalars, curve.Scalar)
+		points = append(points, curve.Point)
+	}
+	failure := errors.New("reader failure")
+	for _, s := range scalars {
+		out, err := RandomScalarE(s, crand.Reader)
+		require.NoError(t, err)
+		require.False(t, out.IsZero())
+
+		_, err = RandomScalarE(s, bytes.NewReader(make([]byte, 10)))
+		require.ErrorIs(t, err, ErrRandom)
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		_, err = RandomScalarE(s, iotest.ErrReader(failure))
+		require.ErrorIs(t, err, ErrRandom)
+		require.ErrorIs(t, err, failure)
+		_, err = RandomScalarE(s, nil)
+		require.ErrorIs(t, err, ErrNilArgument)
+
+		// Readers returning a byte at a time are fine
+		_, err = RandomScalarE(s, iotest.OneByteReader(crand.Reader))
+		require.NoError(t, err)
+	}
+	for _, p := range points {
+		out, err := RandomPointE(p, crand.Reader)
+		require.NoError(t, err)
+		require.False(t, out.IsIdentity())
+
+		_, err = RandomPointE(p, bytes.NewReader(make([]byte, 10)))
+		require.ErrorIs(t, err, ErrRandom)
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		_, err = RandomPointE(p, iotest.ErrReader(failure))
+		require.ErrorIs(t, err, failure)
+		_, err = RandomPointE(p, iotest.OneByteReader(crand.Reader))
+		require.NoError(t, err)
+	}
+	_, err := RandomPointE((*PointK256)(nil), crand.Reader)
+	require.ErrorIs(t, err, ErrNilArgument)
+}
+
+func TestCheckedOperations(t *testing.T) {
+	k256 := K256()
+	g := k256.NewGeneratorPoint()
+	two := k256.Scalar.New(2)
+
+	p, err := AddE(g, g)
+	require.NoError(t, err)
+	q, err := MulE(g, two)
+	require.NoError(t, err)
+	require.True(t, p.Equal(q))
+	p, err = SubE(p, g)
+	require.NoError(t, err)
+	require.True(t, p.Equal(g))
+	p, err = SumOfProductsE(g, []Point{g, g}, []Scalar{two, two})
+	require.NoError(t, err)
+	require.True(t, p.Equal(g.Mul(k256.Scalar.New(4))))
+
+	other := P256().NewGeneratorPoint()
+	_, err = AddE(g, other)
+	require.ErrorIs(t, err, ErrTypeMismatch)
+	_, err = SubE(g, other)
+	require.ErrorIs(t, err, ErrTypeMismatch)
+	_, err = MulE(g, P256().Scalar.One())
+	require.ErrorIs(t, err, ErrTypeMismatch)
+	_, err = SumOfProductsE(g, []Point{other}, []Scalar{two})
+	require.ErrorIs(t, err, ErrTypeMismatch)
+	_, err = SumOfProductsE(g, []Point{g}, nil)
+	require.ErrorIs(t, err, ErrLengthMismatch)
+	_, err = AddE(g, nil)
+	require.ErrorIs(t, err, ErrNilArgument)
+
+	s, err := ScalarAddE(two, two)
+	require.NoError(t, err)
+	s, err = ScalarSubE(s, two)
+	require.NoError(t, err)
+	s, err = ScalarMulE(s, two)
+	require.NoError(t, err)
+	s, err = ScalarDivE(s, two)
+	require.NoError(t, err)
+	require.Equal(t, 0, s.Cmp(two))
+	_, err = ScalarAddE(two, P256().Scalar.One())
+	require.ErrorIs(t, err, ErrTypeMismatch)
+	_, err = ScalarMulE(two, nil)
+	require.ErrorIs(t, err, ErrNilArgument)
+	_, err = ScalarDivE(two, k256.Scalar.Zero())
+	require.ErrorIs(t, err, ErrNotInvertible)
+	_, err = ScalarInvertE(k256.Scalar.Zero())
+	require.ErrorIs(t, err, ErrNotInvertible)
+
+	h, err := HashPointE(g, []byte("checked"))
+	require.NoError(t, err)
+	require.True(t, h.IsOnCurve())
+	_, err = HashScalarE(two, []byte("checked"))
+	require.NoError(t, err)
+
+	var curveErr Error
+	require.True(t, errors.As(err0(AddE(g, other)), &curveErr))
+	require.Equal(t, ErrTypeMismatch, curveErr)
+}
+
+func TestCheckedTargetGroup(t *testing.T) {
+	bls := BLS12381(BLS12381G1().NewIdentityPoint())
+	g1 := bls.PointG1.Generator().(PairingPoint)
+	g2 := bls.PointG2.Generator().(PairingPoint)
+	e, err := MultiPairingE(g1, g2)
+	require.NoError(t, err)
+	e2, err := ScalarMulE(e, bls.Scalar.New(2))
+	require.NoError(t, err)
+	e4, err := MultiPairingE(g1.Mul(bls.Scalar.New(2)).(PairingPoint), g2.Mul(bls.Scalar.New(2)).(PairingPoint))
+	require.NoError(t, err)
+	e4b, err := ScalarMulE(e2, bls.Scalar.New(2))
+	require.NoError(t, err)
+	require.Equal(t, 0, e4.Cmp(e4b))
+
+	_, err = MultiPairingE(g1)
+	require.ErrorIs(t, err, ErrLengthMismatch)
+	_, err = MultiPairingE(g1, BN254G2().NewGeneratorPoint().(PairingPoint))
+	require.ErrorIs(t, err, ErrTypeMismatch)
+	_, err = ScalarMulE(e, BN254G1().Scalar.One())
+	require.ErrorIs(t, err, ErrTypeMismatch)
+
+	gt := new(PointBls12381Gt).Generator()
+	_, err = AddE(gt, gt)
+	require.NoError(t, err)
+	_, err = AddE(gt, BLS12381G1().NewGeneratorPoint())
+	require.ErrorIs(t, err, ErrTypeMismatch)
+	_, err = MulE(gt, BN254G1().Scalar.One())
+	require.ErrorIs(t, err, ErrTypeMismatch)
+	_, err = AddE(new(PointBn254Gt).Generator(), gt)
+	require.ErrorIs(t, err, ErrTypeMismatch)
+}
+
+func err0(_ Point, err error) error {
+	return err
+}
@@ -13,7 +13,9 @@ import (
 	"math/big"
 	"sync"
 
+	"github.com/mikelodder7/curvey/native/bls12377"
 	"github.com/mikelodder7/curvey/native/bls12381"
+	"github.com/mikelodder7/curvey/native/bn254"
 )
 
 var (
@@ -32,26 +34,101 @@ var (
 	p384Initonce sync.Once
 	p384         Curve
 
+	p521Initonce sync.Once
+	p521         Curve
+
 	ed25519Initonce sync.Once
 	ed25519         Curve
 
+	ed448Initonce sync.Once
+	ed448         Curve
+
 	pallasInitonce sync.Once
 	pallas         Curve
 
+	vestaInitonce sync.Once
+	vesta         Curve
+
 	ristretto25519Initonce sync.Once
 	ristretto25519         Curve
+
+	decaf448Initonce sync.Once
+	decaf448         Curve
+
+	jubjubInitonce sync.Once
+	jubjub         Curve
+
+	bn254g1Initonce sync.Once
+	bn254g1         Curve
+
+	bn254g2Initonce sync.Once
+	bn254g2         Curve
+
+	bls12377g1Initonce sync.Once
+	bls12377g1         Curve
+
+	bls12377g2Initonce sync.Once
+	bls12377g2         Curve
+
+	brainpoolP256r1Initonce sync.Once
+	brainpoolP256r1         Curve
+
+	brainpoolP384r1Initonce sync.Once
+	brainpoolP384r1         Curve
+
+	brainpoolP512r1Initonce sync.Once
+	brainpoolP512r1         Curve
+
+	sm2Initonce sync.Once
+	sm2         Curve
+
+	secq256k1Initonce sync.Once
+	secq256k1         Curve
+
+	grumpkinInitonce sync.Once
+	grumpkin         Curve
+
+	bandersnatchInitonce sync.Once
+	bandersnatch         Curve
+
+	babyJubjubInitonce sync.Once
+	babyJubjub         Curve
+
+	starkInitonce sync.Once
+	stark         Curve
 )
 
 const (
-	K256Name           = "secp256k1"
-	BLS12381G1Name     = "BLS12381G1"
-	BLS12381G2Name     = "BLS12381G2"
-	BLS12831Name       = "BLS12831"
-	P256Name           = "P-256"
-	P384Name           = "P-384"
-	ED25519Name        = "ed25519"
-	PallasName         = "pallas"
-	Ristretto25519Name = "ristretto25519"
+	K256Name            = "secp256k1"
+	BLS12381G1Name      = "BLS12381G1"
+	BLS12381G2Name      = "BLS12381G2"
+	BLS12381Name        = "BLS12381"
+	BLS12831Name        = "BLS12831"
+	P256Name            = "P-256"
+	P384Name            = "P-384"
+	P521Name            = "P-521"
+	ED25519Name         = "ed25519"
+	ED448Name           = "ed448"
+	PallasName          = "pallas"
+	VestaName           = "vesta"
+	Ristretto25519Name  = "ristretto25519"
+	Decaf448Name        = "decaf448"
+	JubjubName          = "jubjub"
+	BN254G1Name         = "BN254G1"
+	BN254G2Name         = "BN254G2"
+	BN254Name           = "BN254"
+	BLS12377G1Name      = "BLS12377G1"
+	BLS12377G2Name      = "BLS12377G2"
+	BLS12377Name        = "BLS12377"
+	BrainpoolP256r1Name = "brainpoolP256r1"
+	BrainpoolP384r1Name = "brainpoolP384r1"
+	BrainpoolP512r1Name = "brainpoolP512r1"
+	Sm2Name             = "sm2p256v1"
+	Secq256k1Name       = "secq256k1"
+	GrumpkinName        = "grumpkin"
+	BandersnatchName    = "bandersnatch"
+	BabyJubjubName      = "babyjubjub"
+	StarkName           = "stark-curve"
 )
 
 // Scalar represents an element of the scalar field \mathbb{F}_q
@@ -128,7 +205,9 @@ type PairingScalar interface {
 	SetPoint(p Point) PairingScalar
 }
 
-func unmarshalScalar(input []byte) (*Curve, []byte, error) {
+// unmarshalCurve splits input into the registered curve named before
+// the first ':' and the remaining encoded value.
+func unmarshalCurve(input []byte) (*Curve, []byte, error) {
 	sep := byte(':')
 	i := 0
 	for ; i < len(input); i++ {
@@ -136,6 +215,9 @@ func unmarshalScalar(input []byte) (*Curve, []byte, error) {
 			break
 		}
 	}
+	if i == len(input) {
+		return nil, nil, fmt.Errorf("invalid byte sequence")
+	}
 	name := string(input[:i])
 	curve := GetCurveByName(name)
 	if curve == nil {
@@ -144,11 +226,19 @@ func unmarshalScalar(input []byte) (*Curve, []byte, error) {
 	return curve, input[i+1:], nil
 }
 
+// ScalarMarshalBinary encodes the scalar in the versioned binary envelope
+// with the numeric CurveID of its curve. Curves without a CurveID use the
+// legacy "name:value" format. Releases before the envelope was added only
+// read the legacy format, so they cannot decode this output.
 func ScalarMarshalBinary(scalar Scalar) ([]byte, error) {
+	scalarBytes := scalar.Bytes()
+	if id, ok := pointWireID(scalar.Point()); ok {
+		return appendWireValue(wireTagScalar, id, scalarBytes), nil
+	}
+	// Curves without a wire id fall back to the legacy format.
 	// The last bytes are the actual value
 	// The first remaining bytes are the curve name
 	// separated by a colon
-	scalarBytes := scalar.Bytes()
 	name := []byte(scalar.Point().CurveName())
 	output := make([]byte, len(name)+1+len(scalarBytes))
 	copy(output[:len(name)], name)
@@ -157,15 +247,21 @@ func ScalarMarshalBinary(scalar Scalar) ([]byte, error) {
 	return output, nil
 }
 
+// ScalarUnmarshalBinary decodes a scalar from either the binary envelope
+// or the legacy "name:value" format.
 func ScalarUnmarshalBinary(input []byte) (Scalar, error) {
-	if len(input) < 32+1+len(P256Name) {
-		return nil, fmt.Errorf("invalid byte sequence")
+	var curve *Curve
+	var data []byte
+	var err error
+	if isWireEnvelope(input) {
+		curve, data, err = readWireValue(input, wireTagScalar)
+	} else {
+		curve, data, err = unmarshalCurve(input)
 	}
-	sc, data, err := unmarshalScalar(input)
 	if err != nil {
 		return nil, err
 	}
-	return sc.Scalar.SetBytes(data)
+	return curve.Scalar.SetBytes(data)
 }
 
 func ScalarMarshalText(scalar Scalar) ([]byte, error) {
@@ -182,10 +278,7 @@ func ScalarMarshalText(scalar Scalar) ([]byte, error) {
 }
 
 func ScalarUnmarshalText(input []byte) (Scalar, error) {
-	if len(input) < 32*2+len(P256Name)+1 {
-		return nil, fmt.Errorf("invalid byte sequence")
-	}
-	curve, data, err := unmarshalScalar(input)
+	curve, data, err := unmarshalCurve(input)
 	if err != nil {
 		return nil, err
 	}
@@ -258,12 +351,20 @@ type PairingPoint interface {
 	MultiPairing(...PairingPoint) Scalar
 }
 
+// PointMarshalBinary encodes the compressed point in the versioned binary
+// envelope with the numeric CurveID of its curve. Curves without a CurveID
+// use the legacy "name:value" format. Releases before the envelope was
+// added only read the legacy format, so they cannot decode this output.
 func PointMarshalBinary(point Point) ([]byte, error) {
 	// Always stores points in compressed form
+	t := point.ToAffineCompressed()
+	if id, ok := pointWireID(point); ok {
+		return appendWireValue(wireTagPoint, id, t), nil
+	}
+	// Curves without a wire id fall back to the legacy format.
 	// The first bytes are the curve name
 	// separated by a colon followed by the compressed point
 	// bytes
-	t := point.ToAffineCompressed()
 	name := []byte(point.CurveName())
 	output := make([]byte, len(name)+1+len(t))
 	copy(output[:len(name)], name)
@@ -272,23 +373,21 @@ func PointMarshalBinary(point Point) ([]byte, error) {
 	return output, nil
 }
 
+// PointUnmarshalBinary decodes a point from either the binary envelope
+// or the legacy "name:value" format.
 func PointUnmarshalBinary(input []byte) (Point, error) {
-	if len(input) < 32+1+len(P256Name) {
-		return nil, fmt.Errorf("invalid byte sequence")
-	}
-	sep := byte(':')
-	i := 0
-	for ; i < len(input); i++ {
-		if input[i] == sep {
-			break
-		}
+	var curve *Curve
+	var data []byte
+	var err error
+	if isWireEnvelope(input) {
+		curve, data, err = readWireValue(input, wireTagPoint)
+	} else {
+		curve, data, err = unmarshalCurve(input)
 	}
-	name := string(input[:i])
-	curve := GetCurveByName(name)
-	if curve == nil {
-		return nil, fmt.Errorf("unrecognized curve")
+	if err != nil {
+		return nil, err
 	}
-	return curve.Point.FromAffineCompressed(input[i+1:])
+	return curve.Point.FromAffineCompressed(data)
 }
 
 func PointMarshalText(point Point) ([]byte, error) {
@@ -306,23 +405,12 @@ func PointMarshalText(point Point) ([]byte, error) {
 }
 
 func PointUnmarshalText(input []byte) (Point, error) {
-	if len(input) < 32*2+1+len(P256Name) {
-		return nil, fmt.Errorf("invalid byte sequence")
-	}
-	sep := byte(':')
-	i := 0
-	for ; i < len(input); i++ {
-		if input[i] == sep {
-			break
-		}
-	}
-	name := string(input[:i])
-	curve := GetCurveByName(name)
-	if curve == nil {
-		return nil, fmt.Errorf("unrecognized curve")
+	curve, data, err := unmarshalCurve(input)
+	if err != nil {
+		return nil, err
 	}
-	buffer := make([]byte, (len(input)-i)/2)
-	_, err := hex.Decode(buffer, input[i+1:])
+	buffer := make([]byte, len(data)/2)
+	_, err = hex.Decode(buffer, data)
 	if err != nil {
 		return nil, err
 	}
@@ -365,7 +453,15 @@ type Curve struct {
 	Name   string
 }
 
+// ScalarBaseMult returns the generator multiplied by sc using
+// a precomputed table that is created on first use.
 func (c *Curve) ScalarBaseMult(sc Scalar) Point {
+	if bm, ok := c.Point.(baseMultiplier); ok {
+		return bm.scalarBaseMult(sc)
+	}
+	if table := c.generatorTable(); table != nil {
+		return table.Mul(sc)
+	}
 	return c.Point.Generator().Mul(sc)
 }
 
@@ -395,12 +491,34 @@ func (c *Curve) ToEllipticCurve() (elliptic.Curve, error) {
 		return nil, err
 	case P256Name:
 		return NistP256Curve(), nil
+	case P521Name:
+		return NistP521Curve(), nil
 	case ED25519Name:
 		return nil, err
+	case ED448Name:
+		return nil, err
 	case PallasName:
 		return nil, err
+	case VestaName:
+		return nil, err
 	case Ristretto25519Name:
 		return nil, err
+	case Decaf448Name:
+		return nil, err
+	case JubjubName:
+		return nil, err
+	case BN254G1Name:
+		return nil, err
+	case BN254G2Name:
+		return nil, err
+	case BN254Name:
+		return nil, err
+	case BLS12377G1Name:
+		return nil, err
+	case BLS12377G2Name:
+		return nil, err
+	case BLS12377Name:
+		return nil, err
 	default:
 		return nil, err
 	}
@@ -472,43 +590,16 @@ func (c *PairingCurve) NewScalar() PairingScalar {
 	return pairingPoint
 }
 
-// GetCurveByName returns the correct `Curve` given the name.
+// GetCurveByName returns the registered `Curve` with the given name or alias
+// or nil if no such curve is registered.
 func GetCurveByName(name string) *Curve {
-	switch name {
-	case K256Name:
-		return K256()
-	case BLS12381G1Name:
-		return BLS12381G1()
-	case BLS12381G2Name:
-		return BLS12381G2()
-	case BLS12831Name:
-		return BLS12381G1()
-	case P384Name:
-		return P384()
-	case P256Name:
-		return P256()
-	case ED25519Name:
-		return ED25519()
-	case PallasName:
-		return PALLAS()
-	case Ristretto25519Name:
-		return Ristretto25519()
-	default:
-		return nil
-	}
+	return lookupCurve(name)
 }
 
+// GetPairingCurveByName returns the registered `PairingCurve` with the given
+// name or alias or nil if no such curve is registered.
 func GetPairingCurveByName(name string) *PairingCurve {
-	switch name {
-	case BLS12381G1Name:
-		return BLS12381(BLS12381G1().NewIdentityPoint())
-	case BLS12381G2Name:
-		return BLS12381(BLS12381G2().NewIdentityPoint())
-	case BLS12831Name:
-		return BLS12381(BLS12381G1().NewIdentityPoint())
-	default:
-		return nil
-	}
+	return lookupPairingCurve(name)
 }
 
 // BLS12381G1 returns the BLS12-381 curve with points in G1.
@@ -604,6 +695,136 @@ func p384Init() {
 	}
 }
 
+func P521() *Curve {
+	p521Initonce.Do(p521Init)
+	return &p521
+}
+
+func p521Init() {
+	p521 = Curve{
+		Scalar: new(ScalarP521).Zero(),
+		Point:  new(PointP521).Identity(),
+		Name:   P521Name,
+	}
+}
+
+func BrainpoolP256r1() *Curve {
+	brainpoolP256r1Initonce.Do(brainpoolP256r1Init)
+	return &brainpoolP256r1
+}
+
+func brainpoolP256r1Init() {
+	brainpoolP256r1 = Curve{
+		Scalar: new(ScalarBrainpoolP256r1).Zero(),
+		Point:  new(PointBrainpoolP256r1).Identity(),
+		Name:   BrainpoolP256r1Name,
+	}
+}
+
+func BrainpoolP384r1() *Curve {
+	brainpoolP384r1Initonce.Do(brainpoolP384r1Init)
+	return &brainpoolP384r1
+}
+
+func brainpoolP384r1Init() {
+	brainpoolP384r1 = Curve{
+		Scalar: new(ScalarBrainpoolP384r1).Zero(),
+		Point:  new(PointBrainpoolP384r1).Identity(),
+		Name:   BrainpoolP384r1Name,
+	}
+}
+
+func BrainpoolP512r1() *Curve {
+	brainpoolP512r1Initonce.Do(brainpoolP512r1Init)
+	return &brainpoolP512r1
+}
+
+func brainpoolP512r1Init() {
+	brainpoolP512r1 = Curve{
+		Scalar: new(ScalarBrainpoolP512r1).Zero(),
+		Point:  new(PointBrainpoolP512r1).Identity(),
+		Name:   BrainpoolP512r1Name,
+	}
+}
+
+func SM2() *Curve {
+	sm2Initonce.Do(sm2Init)
+	return &sm2
+}
+
+func sm2Init() {
+	sm2 = Curve{
+		Scalar: new(ScalarSm2).Zero(),
+		Point:  new(PointSm2).Identity(),
+		Name:   Sm2Name,
+	}
+}
+
+func Secq256k1() *Curve {
+	secq256k1Initonce.Do(secq256k1Init)
+	return &secq256k1
+}
+
+func secq256k1Init() {
+	secq256k1 = Curve{
+		Scalar: new(ScalarSecq256k1).Zero(),
+		Point:  new(PointSecq256k1).Identity(),
+		Name:   Secq256k1Name,
+	}
+}
+
+func Grumpkin() *Curve {
+	grumpkinInitonce.Do(grumpkinInit)
+	return &grumpkin
+}
+
+func grumpkinInit() {
+	grumpkin = Curve{
+		Scalar: new(ScalarGrumpkin).Zero(),
+		Point:  new(PointGrumpkin).Identity(),
+		Name:   GrumpkinName,
+	}
+}
+
+func Bandersnatch() *Curve {
+	bandersnatchInitonce.Do(bandersnatchInit)
+	return &bandersnatch
+}
+
+func bandersnatchInit() {
+	bandersnatch = Curve{
+		Scalar: new(ScalarBandersnatch).Zero(),
+		Point:  new(PointBandersnatch).Identity(),
+		Name:   BandersnatchName,
+	}
+}
+
+func BabyJubjub() *Curve {
+	babyJubjubInitonce.Do(babyJubjubInit)
+	return &babyJubjub
+}
+
+func babyJubjubInit() {
+	babyJubjub = Curve{
+		Scalar: new(ScalarBabyJubjub).Zero(),
+		Point:  new(PointBabyJubjub).Identity(),
+		Name:   BabyJubjubName,
+	}
+}
+
+func Stark() *Curve {
+	starkInitonce.Do(starkInit)
+	return &stark
+}
+
+func starkInit() {
+	stark = Curve{
+		Scalar: new(ScalarStark).Zero(),
+		Point:  new(PointStark).Identity(),
+		Name:   StarkName,
+	}
+}
+
 func ED25519() *Curve {
 	ed25519Initonce.Do(ed25519Init)
 	return &ed25519
@@ -617,6 +838,19 @@ func ed25519Init() {
 	}
 }
 
+func ED448() *Curve {
+	ed448Initonce.Do(ed448Init)
+	return &ed448
+}
+
+func ed448Init() {
+	ed448 = Curve{
+		Scalar: new(ScalarEd448).Zero(),
+		Point:  new(PointEd448).Identity(),
+		Name:   ED448Name,
+	}
+}
+
 func PALLAS() *Curve {
 	pallasInitonce.Do(pallasInit)
 	return &pallas
@@ -630,6 +864,19 @@ func pallasInit() {
 	}
 }
 
+func VESTA() *Curve {
+	vestaInitonce.Do(vestaInit)
+	return &vesta
+}
+
+func vestaInit() {
+	vesta = Curve{
+		Scalar: new(ScalarVesta).Zero(),
+		Point:  new(PointVesta).Identity(),
+		Name:   VestaName,
+	}
+}
+
 func Ristretto25519() *Curve {
 	ristretto25519Initonce.Do(ristrettoInit)
 	return &ristretto25519
@@ -643,6 +890,140 @@ func ristrettoInit() {
 	}
 }
 
+func Decaf448() *Curve {
+	decaf448Initonce.Do(decaf448Init)
+	return &decaf448
+}
+
+func decaf448Init() {
+	decaf448 = Curve{
+		Scalar: new(ScalarDecaf448).Zero(),
+		Point:  new(PointDecaf448).Identity(),
+		Name:   Decaf448Name,
+	}
+}
+
+func Jubjub() *Curve {
+	jubjubInitonce.Do(jubjubInit)
+	return &jubjub
+}
+
+func jubjubInit() {
+	jubjub = Curve{
+		Scalar: new(ScalarJubjub).Zero(),
+		Point:  new(PointJubjub).Identity(),
+		Name:   JubjubName,
+	}
+}
+
+// BN254G1 returns the BN254 curve with points in G1.
+func BN254G1() *Curve {
+	bn254g1Initonce.Do(bn254g1Init)
+	return &bn254g1
+}
+
+func bn254g1Init() {
+	bn254g1 = Curve{
+		Scalar: &ScalarBn254{
+			Value: bn254.FqNew(),
+			point: new(PointBn254G1),
+		},
+		Point: new(PointBn254G1).Identity(),
+		Name:  BN254G1Name,
+	}
+}
+
+// BN254G2 returns the BN254 curve with points in G2.
+func BN254G2() *Curve {
+	bn254g2Initonce.Do(bn254g2Init)
+	return &bn254g2
+}
+
+func bn254g2Init() {
+	bn254g2 = Curve{
+		Scalar: &ScalarBn254{
+			Value: bn254.FqNew(),
+			point: new(PointBn254G2),
+		},
+		Point: new(PointBn254G2).Identity(),
+		Name:  BN254G2Name,
+	}
+}
+
+// BN254 returns the BN254 (alt_bn128) pairing curve.
+func BN254(preferredPoint Point) *PairingCurve {
+	return &PairingCurve{
+		Scalar: &ScalarBn254{
+			Value: bn254.FqNew(),
+			point: preferredPoint,
+		},
+		PointG1: &PointBn254G1{
+			Value: new(bn254.G1).Identity(),
+		},
+		PointG2: &PointBn254G2{
+			Value: new(bn254.G2).Identity(),
+		},
+		GT: &ScalarBn254Gt{
+			Value: new(bn254.Gt).SetOne(),
+		},
+		Name: BN254Name,
+	}
+}
+
+// BLS12377G1 returns the BLS12-377 curve with points in G1.
+func BLS12377G1() *Curve {
+	bls12377g1Initonce.Do(bls12377g1Init)
+	return &bls12377g1
+}
+
+func bls12377g1Init() {
+	bls12377g1 = Curve{
+		Scalar: &ScalarBls12377{
+			Value: bls12377.FqNew(),
+			point: new(PointBls12377G1),
+		},
+		Point: new(PointBls12377G1).Identity(),
+		Name:  BLS12377G1Name,
+	}
+}
+
+// BLS12377G2 returns the BLS12-377 curve with points in G2.
+func BLS12377G2() *Curve {
+	bls12377g2Initonce.Do(bls12377g2Init)
+	return &bls12377g2
+}
+
+func bls12377g2Init() {
+	bls12377g2 = Curve{
+		Scalar: &ScalarBls12377{
+			Value: bls12377.FqNew(),
+			point: new(PointBls12377G2),
+		},
+		Point: new(PointBls12377G2).Identity(),
+		Name:  BLS12377G2Name,
+	}
+}
+
+// BLS12377 returns the BLS12-377 pairing curve.
+func BLS12377(preferredPoint Point) *PairingCurve {
+	return &PairingCurve{
+		Scalar: &ScalarBls12377{
+			Value: bls12377.FqNew(),
+			point: preferredPoint,
+		},
+		PointG1: &PointBls12377G1{
+			Value: new(bls12377.G1).Identity(),
+		},
+		PointG2: &PointBls12377G2{
+			Value: new(bls12377.G2).Identity(),
+		},
+		GT: &ScalarBls12377Gt{
+			Value: new(bls12377.Gt).SetOne(),
+		},
+		Name: BLS12377Name,
+	}
+}
+
 func bhex(s string) *big.Int {
 	r, _ := new(big.Int).SetString(s, 16)
 	return r
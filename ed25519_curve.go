@@ -511,6 +511,31 @@ func (p *PointEd25519) Mul(rhs Scalar) Point {
 	}
 }
 
+func (*PointEd25519) scalarBaseMult(rhs Scalar) Point {
+	r, ok := rhs.(*ScalarEd25519)
+	if !ok {
+		return nil
+	}
+	return &PointEd25519{edwards25519.NewIdentityPoint().ScalarBaseMult(r.value)}
+}
+
+func (*PointEd25519) selectPoint(row []Point, index int) Point {
+	x, y, z, t := edwards25519.NewIdentityPoint().ExtendedCoordinates()
+	for i, entry := range row {
+		ex, ey, ez, et := entry.(*PointEd25519).value.ExtendedCoordinates()
+		choice := ctEqual(i+1, index)
+		x.Select(ex, x, choice)
+		y.Select(ey, y, choice)
+		z.Select(ez, z, choice)
+		t.Select(et, t, choice)
+	}
+	value, err := edwards25519.NewIdentityPoint().SetExtendedCoordinates(x, y, z, t)
+	if err != nil {
+		return nil
+	}
+	return &PointEd25519{value}
+}
+
 // MangleScalarBitsAndMulByBasepointToProducePublicKey
 // is a function for mangling the bits of a (formerly
 // mathematically well-defined) "scalar" and multiplying it to produce a
@@ -564,9 +589,12 @@ func (p *PointEd25519) ToAffineCompressed() []byte {
 
 func (p *PointEd25519) ToAffineUncompressed() []byte {
 	x, y, z, _ := p.value.ExtendedCoordinates()
-	recip := new(field.Element).Invert(z)
-	x.Multiply(x, recip)
-	y.Multiply(y, recip)
+	// Points from BatchNormalize need no inversion
+	if z.Equal(new(field.Element).One()) == 0 {
+		recip := new(field.Element).Invert(z)
+		x.Multiply(x, recip)
+		y.Multiply(y, recip)
+	}
 	var out [64]byte
 	copy(out[:32], x.Bytes())
 	copy(out[32:], y.Bytes())
@@ -647,6 +675,67 @@ func (*PointEd25519) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar)
 	return &PointEd25519{value}
 }
 
+func (*PointEd25519) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	if len(scalars) != len(points) {
+		return nil
+	}
+	nScalars := make([]*edwards25519.Scalar, len(scalars))
+	nPoints := make([]*edwards25519.Point, len(points))
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarEd25519)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.value
+	}
+	for i, pt := range points {
+		pp, ok := pt.(*PointEd25519)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = pp.value
+	}
+	value := edwards25519.NewIdentityPoint().VarTimeMultiScalarMult(nScalars, nPoints)
+	return &PointEd25519{value}
+}
+
+func (*PointEd25519) batchNormalize(points []Point) []Point {
+	// x, y and z hold the extended coordinates of every point
+	x := make([]*field.Element, len(points))
+	y := make([]*field.Element, len(points))
+	z := make([]*field.Element, len(points))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointEd25519)
+		if !ok {
+			return nil
+		}
+		x[i], y[i], z[i], _ = ptv.value.ExtendedCoordinates()
+	}
+	// acc[i] is the product of the z coordinates before i,
+	// which are never zero for edwards25519 points
+	acc := make([]field.Element, len(points))
+	t := new(field.Element).One()
+	for i := range points {
+		acc[i].Set(t)
+		t.Multiply(t, z[i])
+	}
+	inv := new(field.Element).Invert(t)
+	out := make([]Point, len(points))
+	for i := len(points) - 1; i >= 0; i-- {
+		zInv := new(field.Element).Multiply(inv, &acc[i])
+		inv.Multiply(inv, z[i])
+		x[i].Multiply(x[i], zInv)
+		y[i].Multiply(y[i], zInv)
+		value, err := edwards25519.NewIdentityPoint().SetExtendedCoordinates(
+			x[i], y[i], new(field.Element).One(), new(field.Element).Multiply(x[i], y[i]))
+		if err != nil {
+			return nil
+		}
+		out[i] = &PointEd25519{value}
+	}
+	return out
+}
+
 func (p *PointEd25519) MarshalBinary() ([]byte, error) {
 	return PointMarshalBinary(p)
 }
@@ -1130,6 +1219,22 @@ func (p *PointRistretto25519) Mul(rhs Scalar) Point {
 	}
 }
 
+func (*PointRistretto25519) scalarBaseMult(rhs Scalar) Point {
+	r, ok := rhs.(*ScalarRistretto25519)
+	if !ok {
+		return nil
+	}
+	return &PointRistretto25519{value: new(ristretto.Point).ScalarMultBase(r.value)}
+}
+
+func (*PointRistretto25519) selectPoint(row []Point, index int) Point {
+	value := new(ristretto.Point).SetZero()
+	for i, pt := range row {
+		value.ConditionalSet(pt.(*PointRistretto25519).value, int32(ctEqual(i+1, index)))
+	}
+	return &PointRistretto25519{value: value}
+}
+
 func (p *PointRistretto25519) Equal(rhs Point) bool {
 	r, ok := rhs.(*PointRistretto25519)
 	if ok {
@@ -1162,9 +1267,13 @@ func (p *PointRistretto25519) ToAffineCompressed() []byte {
 func (p *PointRistretto25519) ToAffineUncompressed() []byte {
 	temp := new(ristretto.Point).SetZero()
 
-	recip := temp.Z.Inverse(&p.value.Z)
-	x := temp.X.Mul(&p.value.X, recip)
-	y := temp.Y.Mul(&p.value.Y, recip)
+	x, y := &p.value.X, &p.value.Y
+	// Points from BatchNormalize need no inversion
+	if !p.value.Z.Equals(new(ed.FieldElement).SetOne()) {
+		recip := temp.Z.Inverse(&p.value.Z)
+		x = temp.X.Mul(&p.value.X, recip)
+		y = temp.Y.Mul(&p.value.Y, recip)
+	}
 	xBytes := x.Bytes()
 	yBytes := y.Bytes()
 	var out [64]byte
@@ -1199,7 +1308,7 @@ func (*PointRistretto25519) FromAffineUncompressed(b []byte) (Point, error) {
 	copy(input[:], b[:32])
 	value.X.SetBytes(&input)
 	copy(input[:], b[32:])
-	value.T.SetBytes(&input)
+	value.Y.SetBytes(&input)
 	value.Z.SetOne()
 	value.T.Mul(&value.X, &value.Y)
 	return &PointRistretto25519{value}, nil
@@ -1250,6 +1359,47 @@ func (p *PointRistretto25519) SumOfProducts(points []Point, scalars []Scalar) Po
 	return &PointRistretto25519{value}
 }
 
+func (p *PointRistretto25519) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point {
+	return p.VarTimeMultiScalarMult([]Scalar{a, b}, []Point{capA, p.Generator()})
+}
+
+func (p *PointRistretto25519) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	return varTimeMultiScalarMult(p, scalars, points)
+}
+
+func (*PointRistretto25519) batchNormalize(points []Point) []Point {
+	values := make([]*ristretto.Point, len(points))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointRistretto25519)
+		if !ok {
+			return nil
+		}
+		values[i] = ptv.value
+	}
+	// acc[i] is the product of the z coordinates before i,
+	// which are never zero for ristretto255 representatives
+	acc := make([]ed.FieldElement, len(points))
+	var t, inv, zInv ed.FieldElement
+	t.SetOne()
+	for i, value := range values {
+		acc[i].Set(&t)
+		t.Mul(&t, &value.Z)
+	}
+	inv.Inverse(&t)
+	out := make([]Point, len(points))
+	for i := len(values) - 1; i >= 0; i-- {
+		zInv.Mul(&inv, &acc[i])
+		inv.Mul(&inv, &values[i].Z)
+		value := new(ristretto.Point)
+		value.X.Mul(&values[i].X, &zInv)
+		value.Y.Mul(&values[i].Y, &zInv)
+		value.Z.SetOne()
+		value.T.Mul(&value.X, &value.Y)
+		out[i] = &PointRistretto25519{value}
+	}
+	return out
+}
+
 func (p *PointRistretto25519) MarshalBinary() ([]byte, error) {
 	return PointMarshalBinary(p)
 }
@@ -342,6 +342,24 @@ func TestPointEd25519Serialize(t *testing.T) {
 	}
 }
 
+func TestPointRistretto25519SerializeUncompressed(t *testing.T) {
+	ristretto := Ristretto25519()
+	pt := ristretto.Point.Generator().Mul(ristretto.Scalar.New(5))
+	un := pt.ToAffineUncompressed()
+	require.Equal(t, 64, len(un))
+	ret, err := ristretto.Point.FromAffineUncompressed(un)
+	require.NoError(t, err)
+	require.True(t, pt.Equal(ret))
+	require.Equal(t, un, ret.ToAffineUncompressed())
+
+	for i := 0; i < 25; i++ {
+		pt = ristretto.Point.Random(crand.Reader)
+		ret, err = ristretto.Point.FromAffineUncompressed(pt.ToAffineUncompressed())
+		require.NoError(t, err)
+		require.True(t, pt.Equal(ret))
+	}
+}
+
 func TestPointEd25519Nil(t *testing.T) {
 	ed25519 := ED25519()
 	one := ed25519.Point.Generator()
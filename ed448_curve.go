@@ -0,0 +1,1224 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/mikelodder7/curvey/internal"
+	ed448n "github.com/mikelodder7/curvey/native/ed448"
+)
+
+type ScalarEd448 struct {
+	value *ed448n.Fq
+}
+
+type PointEd448 struct {
+	value *ed448n.EdwardsPoint
+}
+
+type ScalarDecaf448 struct {
+	value *ed448n.Fq
+}
+
+type PointDecaf448 struct {
+	value *ed448n.EdwardsPoint
+}
+
+func (s *ScalarEd448) Random(reader io.Reader) Scalar {
+	if reader == nil {
+		return nil
+	}
+	var seed [64]byte
+	n, err := reader.Read(seed[:])
+	if err != nil {
+		return nil
+	}
+	if n != len(seed) {
+		return nil
+	}
+	return s.Hash(seed[:])
+}
+
+func (*ScalarEd448) Hash(bytes []byte) Scalar {
+	return &ScalarEd448{
+		value: ed448n.FqNew().Hash(bytes),
+	}
+}
+
+func (*ScalarEd448) Zero() Scalar {
+	return &ScalarEd448{
+		value: ed448n.FqNew().SetZero(),
+	}
+}
+
+func (*ScalarEd448) One() Scalar {
+	return &ScalarEd448{
+		value: ed448n.FqNew().SetOne(),
+	}
+}
+
+func (s *ScalarEd448) IsZero() bool {
+	return s.value.IsZero() == 1
+}
+
+func (s *ScalarEd448) IsOne() bool {
+	return s.value.IsOne() == 1
+}
+
+func (s *ScalarEd448) IsOdd() bool {
+	return s.value.Bytes()[0]&1 == 1
+}
+
+func (s *ScalarEd448) IsEven() bool {
+	return s.value.Bytes()[0]&1 == 0
+}
+
+func (*ScalarEd448) New(value int) Scalar {
+	t := ed448n.FqNew()
+	v := big.NewInt(int64(value))
+	if value < 0 {
+		v.Mod(v, t.Value.Params.BiModulus)
+	}
+	return &ScalarEd448{
+		value: t.SetBigInt(v),
+	}
+}
+
+func (s *ScalarEd448) Cmp(rhs Scalar) int {
+	r, ok := rhs.(*ScalarEd448)
+	if ok {
+		return s.value.BigInt().Cmp(r.value.BigInt())
+	} else {
+		return -2
+	}
+}
+
+func (s *ScalarEd448) Square() Scalar {
+	return &ScalarEd448{
+		value: ed448n.FqNew().Square(s.value),
+	}
+}
+
+func (s *ScalarEd448) Pow(exp uint64) Scalar {
+	out := ed448n.FqNew()
+	internal.Pow(&out.Value.Value, s.value.Value.Value, []uint64{exp, 0, 0, 0, 0, 0, 0}, out.Value.Params, out.Value.Arithmetic)
+	return &ScalarEd448{
+		value: out,
+	}
+}
+
+func (s *ScalarEd448) Double() Scalar {
+	return &ScalarEd448{
+		value: ed448n.FqNew().Double(s.value),
+	}
+}
+
+func (s *ScalarEd448) Invert() (Scalar, error) {
+	value, wasInverted := ed448n.FqNew().Invert(s.value)
+	if wasInverted != 1 {
+		return nil, fmt.Errorf("inverse doesn't exist")
+	}
+	return &ScalarEd448{
+		value,
+	}, nil
+}
+
+func (s *ScalarEd448) Sqrt() (Scalar, error) {
+	value, wasSquare := ed448n.FqNew().Sqrt(s.value)
+	if wasSquare != 1 {
+		return nil, fmt.Errorf("not a square")
+	}
+	return &ScalarEd448{
+		value,
+	}, nil
+}
+
+func (s *ScalarEd448) Cube() Scalar {
+	value := ed448n.FqNew().Square(s.value)
+	value.Mul(value, s.value)
+	return &ScalarEd448{
+		value,
+	}
+}
+
+func (s *ScalarEd448) Add(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarEd448)
+	if ok {
+		return &ScalarEd448{
+			value: ed448n.FqNew().Add(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarEd448) Sub(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarEd448)
+	if ok {
+		return &ScalarEd448{
+			value: ed448n.FqNew().Sub(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarEd448) Mul(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarEd448)
+	if ok {
+		return &ScalarEd448{
+			value: ed448n.FqNew().Mul(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarEd448) MulAdd(y, z Scalar) Scalar {
+	return s.Mul(y).Add(z)
+}
+
+func (s *ScalarEd448) Div(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarEd448)
+	if ok {
+		v, wasInverted := ed448n.FqNew().Invert(r.value)
+		if wasInverted != 1 {
+			return nil
+		}
+		v.Mul(v, s.value)
+		return &ScalarEd448{value: v}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarEd448) Neg() Scalar {
+	return &ScalarEd448{
+		value: ed448n.FqNew().Neg(s.value),
+	}
+}
+
+func (*ScalarEd448) SetBigInt(v *big.Int) (Scalar, error) {
+	if v == nil {
+		return nil, fmt.Errorf("'v' cannot be nil")
+	}
+	value := ed448n.FqNew().SetBigInt(v)
+	return &ScalarEd448{
+		value,
+	}, nil
+}
+
+func (s *ScalarEd448) BigInt() *big.Int {
+	return s.value.BigInt()
+}
+
+// Bytes returns the 57 byte little endian encoding of the scalar
+// as described in RFC 8032.
+func (s *ScalarEd448) Bytes() []byte {
+	t := s.value.Bytes()
+	return t[:]
+}
+
+// SetBytes takes input a 57-byte long little endian array and returns an ed448 scalar.
+// The input must be reduced.
+func (*ScalarEd448) SetBytes(input []byte) (Scalar, error) {
+	if len(input) != 57 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [57]byte
+	copy(seq[:], input)
+	value, err := ed448n.FqNew().SetBytes(&seq)
+	if err != nil {
+		return nil, err
+	}
+	return &ScalarEd448{
+		value,
+	}, nil
+}
+
+// SetBytesWide takes input a 114-byte long little endian array, reduces it
+// and returns an ed448 scalar. This is the size of the SHAKE256 output used by RFC 8032.
+func (*ScalarEd448) SetBytesWide(input []byte) (Scalar, error) {
+	if len(input) != 114 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [114]byte
+	copy(seq[:], input)
+	return &ScalarEd448{
+		value: ed448n.FqNew().SetBytesWide(&seq),
+	}, nil
+}
+
+// SetBytesClamping applies the buffer pruning described in RFC 8032, Section 5.2.5
+// to the 57-byte input and returns the result reduced by the group order.
+// The input is not modified.
+func (*ScalarEd448) SetBytesClamping(input []byte) (Scalar, error) {
+	if len(input) != 57 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [114]byte
+	copy(seq[:57], input)
+	seq[0] &= 0xfc
+	seq[55] |= 0x80
+	seq[56] = 0
+	return &ScalarEd448{
+		value: ed448n.FqNew().SetBytesWide(&seq),
+	}, nil
+}
+
+func (*ScalarEd448) Point() Point {
+	return new(PointEd448).Identity()
+}
+
+func (s *ScalarEd448) Clone() Scalar {
+	return &ScalarEd448{
+		value: ed448n.FqNew().Set(s.value),
+	}
+}
+
+func (s *ScalarEd448) GetEdwardsScalar() *ed448n.Fq {
+	return ed448n.FqNew().Set(s.value)
+}
+
+func (*ScalarEd448) SetEdwardsScalar(sc *ed448n.Fq) *ScalarEd448 {
+	return &ScalarEd448{value: ed448n.FqNew().Set(sc)}
+}
+
+func (s *ScalarEd448) MarshalBinary() ([]byte, error) {
+	return ScalarMarshalBinary(s)
+}
+
+func (s *ScalarEd448) UnmarshalBinary(input []byte) error {
+	sc, err := ScalarUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarEd448)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarEd448) MarshalText() ([]byte, error) {
+	return ScalarMarshalText(s)
+}
+
+func (s *ScalarEd448) UnmarshalText(input []byte) error {
+	sc, err := ScalarUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarEd448)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarEd448) MarshalJSON() ([]byte, error) {
+	return ScalarMarshalJSON(s)
+}
+
+func (s *ScalarEd448) UnmarshalJSON(input []byte) error {
+	sc, err := ScalarUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	S, ok := sc.(*ScalarEd448)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	s.value = S.value
+	return nil
+}
+
+func (p *PointEd448) Random(reader io.Reader) Point {
+	if reader == nil {
+		return nil
+	}
+	var seed [64]byte
+	n, err := reader.Read(seed[:])
+	if err != nil {
+		return nil
+	}
+	if n != len(seed) {
+		return nil
+	}
+	return p.Hash(seed[:])
+}
+
+// Hash uses the hash to curve suite edwards448_XOF:SHAKE256_ELL2_RO_
+// described in RFC 9380.
+func (*PointEd448) Hash(bytes []byte) Point {
+	return &PointEd448{
+		value: ed448n.EdwardsPointNew().HashWithDefaults(bytes),
+	}
+}
+
+func (*PointEd448) Identity() Point {
+	return &PointEd448{
+		value: ed448n.EdwardsPointNew().SetIdentity(),
+	}
+}
+
+func (*PointEd448) Generator() Point {
+	return &PointEd448{
+		value: ed448n.EdwardsPointNew().SetGenerator(),
+	}
+}
+
+func (p *PointEd448) IsIdentity() bool {
+	return p.value.IsIdentityI() == 1
+}
+
+func (*PointEd448) IsNegative() bool {
+	// Negative points don't really exist in ed448
+	return false
+}
+
+func (p *PointEd448) IsOnCurve() bool {
+	return p.value.IsOnCurve() == 1
+}
+
+func (p *PointEd448) Double() Point {
+	return &PointEd448{value: ed448n.EdwardsPointNew().Double(p.value)}
+}
+
+func (*PointEd448) Scalar() Scalar {
+	return new(ScalarEd448).Zero()
+}
+
+func (p *PointEd448) Neg() Point {
+	return &PointEd448{value: ed448n.EdwardsPointNew().Negate(p.value)}
+}
+
+func (p *PointEd448) Add(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointEd448)
+	if ok {
+		return &PointEd448{value: ed448n.EdwardsPointNew().Add(p.value, r.value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointEd448) Sub(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointEd448)
+	if ok {
+		return &PointEd448{value: ed448n.EdwardsPointNew().Sub(p.value, r.value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointEd448) Mul(rhs Scalar) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*ScalarEd448)
+	if ok {
+		return &PointEd448{value: ed448n.EdwardsPointNew().Mul(p.value, r.value)}
+	} else {
+		return nil
+	}
+}
+
+func (*PointEd448) selectPoint(row []Point, index int) Point {
+	value := ed448n.EdwardsPointNew().SetIdentity()
+	for i, pt := range row {
+		value.CMove(value, pt.(*PointEd448).value, ctEqual(i+1, index))
+	}
+	return &PointEd448{value}
+}
+
+func (p *PointEd448) Equal(rhs Point) bool {
+	r, ok := rhs.(*PointEd448)
+	if ok {
+		return p.value.EqualI(r.value) == 1
+	} else {
+		return false
+	}
+}
+
+func (p *PointEd448) Set(x, y *big.Int) (Point, error) {
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return p.Identity(), nil
+	}
+	xx := ed448n.FpNew().SetBigInt(x).Bytes()
+	yy := ed448n.FpNew().SetBigInt(y).Bytes()
+
+	var affine [112]byte
+	copy(affine[:56], xx[:])
+	copy(affine[56:], yy[:])
+	return p.FromAffineUncompressed(affine[:])
+}
+
+// ToAffineCompressed returns the 57 byte encoding described in RFC 8032.
+func (p *PointEd448) ToAffineCompressed() []byte {
+	t := p.value.Compress()
+	return t[:]
+}
+
+// ToAffineUncompressed returns the little endian x and y coordinates
+// as 112 bytes x || y.
+func (p *PointEd448) ToAffineUncompressed() []byte {
+	affine := p.value.ToAffine()
+	x := affine.X.Bytes()
+	y := affine.Y.Bytes()
+	var out [112]byte
+	copy(out[:56], x[:])
+	copy(out[56:], y[:])
+	return out[:]
+}
+
+func (*PointEd448) FromAffineCompressed(input []byte) (Point, error) {
+	if len(input) != 57 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	var seq ed448n.CompressedEdwardsY
+	copy(seq[:], input)
+	value, err := seq.Decompress()
+	if err != nil {
+		return nil, err
+	}
+	return &PointEd448{value}, nil
+}
+
+func (*PointEd448) FromAffineUncompressed(input []byte) (Point, error) {
+	if len(input) != 112 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	if bytes.Equal(input, make([]byte, 112)) {
+		return &PointEd448{value: ed448n.EdwardsPointNew().SetIdentity()}, nil
+	}
+	var xx, yy [56]byte
+	copy(xx[:], input[:56])
+	copy(yy[:], input[56:])
+	x, err := ed448n.FpNew().SetCanonicalBytes(&xx)
+	if err != nil {
+		return nil, err
+	}
+	y, err := ed448n.FpNew().SetCanonicalBytes(&yy)
+	if err != nil {
+		return nil, err
+	}
+	value := (&ed448n.AffinePoint{X: x, Y: y}).ToEdwards()
+	if value.IsOnCurve() != 1 || value.IsTorsionFree() != 1 {
+		return nil, fmt.Errorf("invalid point")
+	}
+	return &PointEd448{value}, nil
+}
+
+func (*PointEd448) CurveName() string {
+	return ED448Name
+}
+
+func (*PointEd448) SumOfProducts(points []Point, scalars []Scalar) Point {
+	nPoints := make([]*ed448n.EdwardsPoint, len(points))
+	nScalars := make([]*ed448n.Fq, len(scalars))
+	for i, pt := range points {
+		pp, ok := pt.(*PointEd448)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = pp.value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarEd448)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.value
+	}
+	value, err := ed448n.EdwardsPointNew().SumOfProducts(nPoints, nScalars)
+	if err != nil {
+		return nil
+	}
+	return &PointEd448{value}
+}
+
+func (p *PointEd448) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point {
+	return p.VarTimeMultiScalarMult([]Scalar{a, b}, []Point{capA, p.Generator()})
+}
+
+func (p *PointEd448) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	return varTimeMultiScalarMult(p, scalars, points)
+}
+
+func (*PointEd448) batchNormalize(points []Point) []Point {
+	nPoints := make([]*ed448n.EdwardsPoint, len(points))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointEd448)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	out := make([]Point, len(points))
+	for i, value := range ed448n.BatchNormalize(nPoints) {
+		out[i] = &PointEd448{value}
+	}
+	return out
+}
+
+func (p *PointEd448) X() *big.Int {
+	return p.value.ToAffine().X.BigInt()
+}
+
+func (p *PointEd448) Y() *big.Int {
+	return p.value.ToAffine().Y.BigInt()
+}
+
+func (*PointEd448) Modulus() *big.Int {
+	return ed448n.FpNew().Value.Params.BiModulus
+}
+
+func (p *PointEd448) GetEdwardsPoint() *ed448n.EdwardsPoint {
+	return ed448n.EdwardsPointNew().Set(p.value)
+}
+
+func (*PointEd448) SetEdwardsPoint(pt *ed448n.EdwardsPoint) *PointEd448 {
+	return &PointEd448{value: ed448n.EdwardsPointNew().Set(pt)}
+}
+
+func (p *PointEd448) MarshalBinary() ([]byte, error) {
+	return PointMarshalBinary(p)
+}
+
+func (p *PointEd448) UnmarshalBinary(input []byte) error {
+	pt, err := PointUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointEd448)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointEd448) MarshalText() ([]byte, error) {
+	return PointMarshalText(p)
+}
+
+func (p *PointEd448) UnmarshalText(input []byte) error {
+	pt, err := PointUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointEd448)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointEd448) MarshalJSON() ([]byte, error) {
+	return PointMarshalJSON(p)
+}
+
+func (p *PointEd448) UnmarshalJSON(input []byte) error {
+	pt, err := PointUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	P, ok := pt.(*PointEd448)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	p.value = P.value
+	return nil
+}
+
+func (s *ScalarDecaf448) Random(reader io.Reader) Scalar {
+	if reader == nil {
+		return nil
+	}
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return s.Hash(seed[:])
+}
+
+func (*ScalarDecaf448) Hash(bytes []byte) Scalar {
+	return &ScalarDecaf448{
+		value: ed448n.FqNew().Hash(bytes),
+	}
+}
+
+func (*ScalarDecaf448) Zero() Scalar {
+	return &ScalarDecaf448{
+		value: ed448n.FqNew().SetZero(),
+	}
+}
+
+func (*ScalarDecaf448) One() Scalar {
+	return &ScalarDecaf448{
+		value: ed448n.FqNew().SetOne(),
+	}
+}
+
+func (s *ScalarDecaf448) IsZero() bool {
+	return s.value.IsZero() == 1
+}
+
+func (s *ScalarDecaf448) IsOne() bool {
+	return s.value.IsOne() == 1
+}
+
+func (s *ScalarDecaf448) IsOdd() bool {
+	return s.value.Bytes()[0]&1 == 1
+}
+
+func (s *ScalarDecaf448) IsEven() bool {
+	return s.value.Bytes()[0]&1 == 0
+}
+
+func (*ScalarDecaf448) New(value int) Scalar {
+	t := ed448n.FqNew()
+	v := big.NewInt(int64(value))
+	if value < 0 {
+		v.Mod(v, t.Value.Params.BiModulus)
+	}
+	return &ScalarDecaf448{
+		value: t.SetBigInt(v),
+	}
+}
+
+func (s *ScalarDecaf448) Cmp(rhs Scalar) int {
+	r, ok := rhs.(*ScalarDecaf448)
+	if ok {
+		return s.value.BigInt().Cmp(r.value.BigInt())
+	} else {
+		return -2
+	}
+}
+
+func (s *ScalarDecaf448) Square() Scalar {
+	return &ScalarDecaf448{
+		value: ed448n.FqNew().Square(s.value),
+	}
+}
+
+func (s *ScalarDecaf448) Pow(exp uint64) Scalar {
+	out := ed448n.FqNew()
+	internal.Pow(&out.Value.Value, s.value.Value.Value, []uint64{exp, 0, 0, 0, 0, 0, 0}, out.Value.Params, out.Value.Arithmetic)
+	return &ScalarDecaf448{
+		value: out,
+	}
+}
+
+func (s *ScalarDecaf448) Double() Scalar {
+	return &ScalarDecaf448{
+		value: ed448n.FqNew().Double(s.value),
+	}
+}
+
+func (s *ScalarDecaf448) Invert() (Scalar, error) {
+	value, wasInverted := ed448n.FqNew().Invert(s.value)
+	if wasInverted != 1 {
+		return nil, fmt.Errorf("inverse doesn't exist")
+	}
+	return &ScalarDecaf448{
+		value,
+	}, nil
+}
+
+func (s *ScalarDecaf448) Sqrt() (Scalar, error) {
+	value, wasSquare := ed448n.FqNew().Sqrt(s.value)
+	if wasSquare != 1 {
+		return nil, fmt.Errorf("not a square")
+	}
+	return &ScalarDecaf448{
+		value,
+	}, nil
+}
+
+func (s *ScalarDecaf448) Cube() Scalar {
+	value := ed448n.FqNew().Square(s.value)
+	value.Mul(value, s.value)
+	return &ScalarDecaf448{
+		value,
+	}
+}
+
+func (s *ScalarDecaf448) Add(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarDecaf448)
+	if ok {
+		return &ScalarDecaf448{
+			value: ed448n.FqNew().Add(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarDecaf448) Sub(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarDecaf448)
+	if ok {
+		return &ScalarDecaf448{
+			value: ed448n.FqNew().Sub(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarDecaf448) Mul(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarDecaf448)
+	if ok {
+		return &ScalarDecaf448{
+			value: ed448n.FqNew().Mul(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarDecaf448) MulAdd(y, z Scalar) Scalar {
+	return s.Mul(y).Add(z)
+}
+
+func (s *ScalarDecaf448) Div(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarDecaf448)
+	if ok {
+		v, wasInverted := ed448n.FqNew().Invert(r.value)
+		if wasInverted != 1 {
+			return nil
+		}
+		v.Mul(v, s.value)
+		return &ScalarDecaf448{value: v}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarDecaf448) Neg() Scalar {
+	return &ScalarDecaf448{
+		value: ed448n.FqNew().Neg(s.value),
+	}
+}
+
+func (*ScalarDecaf448) SetBigInt(v *big.Int) (Scalar, error) {
+	if v == nil {
+		return nil, fmt.Errorf("'v' cannot be nil")
+	}
+	value := ed448n.FqNew().SetBigInt(v)
+	return &ScalarDecaf448{
+		value,
+	}, nil
+}
+
+func (s *ScalarDecaf448) BigInt() *big.Int {
+	return s.value.BigInt()
+}
+
+// Bytes returns the 56 byte little endian encoding of the scalar
+// as described in RFC 9496.
+func (s *ScalarDecaf448) Bytes() []byte {
+	t := s.value.Bytes()
+	return t[:ed448n.DecafBytes]
+}
+
+// SetBytes takes input a 56-byte long little endian array and returns a decaf448 scalar.
+// The input must be reduced.
+func (*ScalarDecaf448) SetBytes(input []byte) (Scalar, error) {
+	if len(input) != ed448n.DecafBytes {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [57]byte
+	copy(seq[:], input)
+	value, err := ed448n.FqNew().SetBytes(&seq)
+	if err != nil {
+		return nil, err
+	}
+	return &ScalarDecaf448{
+		value,
+	}, nil
+}
+
+// SetBytesWide takes input a 112-byte long little endian array, reduces it
+// and returns a decaf448 scalar.
+func (*ScalarDecaf448) SetBytesWide(input []byte) (Scalar, error) {
+	if len(input) != 2*ed448n.DecafBytes {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [114]byte
+	copy(seq[:], input)
+	return &ScalarDecaf448{
+		value: ed448n.FqNew().SetBytesWide(&seq),
+	}, nil
+}
+
+func (*ScalarDecaf448) Point() Point {
+	return new(PointDecaf448).Identity()
+}
+
+func (s *ScalarDecaf448) Clone() Scalar {
+	return &ScalarDecaf448{
+		value: ed448n.FqNew().Set(s.value),
+	}
+}
+
+func (s *ScalarDecaf448) GetEdwardsScalar() *ed448n.Fq {
+	return ed448n.FqNew().Set(s.value)
+}
+
+func (*ScalarDecaf448) SetEdwardsScalar(sc *ed448n.Fq) *ScalarDecaf448 {
+	return &ScalarDecaf448{value: ed448n.FqNew().Set(sc)}
+}
+
+func (s *ScalarDecaf448) MarshalBinary() ([]byte, error) {
+	return ScalarMarshalBinary(s)
+}
+
+func (s *ScalarDecaf448) UnmarshalBinary(input []byte) error {
+	sc, err := ScalarUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarDecaf448)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarDecaf448) MarshalText() ([]byte, error) {
+	return ScalarMarshalText(s)
+}
+
+func (s *ScalarDecaf448) UnmarshalText(input []byte) error {
+	sc, err := ScalarUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarDecaf448)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarDecaf448) MarshalJSON() ([]byte, error) {
+	return ScalarMarshalJSON(s)
+}
+
+func (s *ScalarDecaf448) UnmarshalJSON(input []byte) error {
+	sc, err := ScalarUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	S, ok := sc.(*ScalarDecaf448)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	s.value = S.value
+	return nil
+}
+func (p *PointDecaf448) Random(reader io.Reader) Point {
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return p.Hash(seed[:])
+}
+
+// Hash uses the hash to group suite decaf448_XOF:SHAKE256_D448MAP_RO_
+// described in RFC 9380 with the element derivation from RFC 9496.
+func (*PointDecaf448) Hash(bytes []byte) Point {
+	return &PointDecaf448{
+		value: ed448n.EdwardsPointNew().DecafHashWithDefaults(bytes),
+	}
+}
+
+// FromUniformBytes derives an element from 112 uniformly random bytes
+// as described in RFC 9496 §5.3.4.
+func (*PointDecaf448) FromUniformBytes(input []byte) (Point, error) {
+	if len(input) != 2*ed448n.DecafBytes {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [2 * ed448n.DecafBytes]byte
+	copy(seq[:], input)
+	return &PointDecaf448{
+		value: ed448n.EdwardsPointNew().DecafFromUniformBytes(&seq),
+	}, nil
+}
+
+func (*PointDecaf448) Identity() Point {
+	return &PointDecaf448{
+		value: ed448n.EdwardsPointNew().SetIdentity(),
+	}
+}
+
+func (*PointDecaf448) Generator() Point {
+	return &PointDecaf448{
+		value: ed448n.EdwardsPointNew().SetDecafGenerator(),
+	}
+}
+
+func (p *PointDecaf448) IsIdentity() bool {
+	return p.value.IsDecafIdentityI() == 1
+}
+
+func (*PointDecaf448) IsNegative() bool {
+	return false
+}
+
+func (p *PointDecaf448) IsOnCurve() bool {
+	return p.value.IsOnCurve() == 1
+}
+
+func (p *PointDecaf448) Double() Point {
+	return &PointDecaf448{value: ed448n.EdwardsPointNew().Double(p.value)}
+}
+
+func (*PointDecaf448) Scalar() Scalar {
+	return new(ScalarDecaf448).Zero()
+}
+
+func (p *PointDecaf448) Neg() Point {
+	return &PointDecaf448{value: ed448n.EdwardsPointNew().Negate(p.value)}
+}
+
+func (p *PointDecaf448) Add(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointDecaf448)
+	if ok {
+		return &PointDecaf448{value: ed448n.EdwardsPointNew().Add(p.value, r.value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointDecaf448) Sub(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointDecaf448)
+	if ok {
+		return &PointDecaf448{value: ed448n.EdwardsPointNew().Sub(p.value, r.value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointDecaf448) Mul(rhs Scalar) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*ScalarDecaf448)
+	if ok {
+		return &PointDecaf448{value: ed448n.EdwardsPointNew().Mul(p.value, r.value)}
+	} else {
+		return nil
+	}
+}
+
+func (*PointDecaf448) selectPoint(row []Point, index int) Point {
+	value := ed448n.EdwardsPointNew().SetIdentity()
+	for i, pt := range row {
+		value.CMove(value, pt.(*PointDecaf448).value, ctEqual(i+1, index))
+	}
+	return &PointDecaf448{value}
+}
+
+// Equal compares the points in the quotient group as described in RFC 9496 §5.3.3.
+func (p *PointDecaf448) Equal(rhs Point) bool {
+	r, ok := rhs.(*PointDecaf448)
+	if ok {
+		return p.value.DecafEqualI(r.value) == 1
+	} else {
+		return false
+	}
+}
+
+func (p *PointDecaf448) Set(x, y *big.Int) (Point, error) {
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return p.Identity(), nil
+	}
+	xx := ed448n.FpNew().SetBigInt(x).Bytes()
+	yy := ed448n.FpNew().SetBigInt(y).Bytes()
+
+	var affine [112]byte
+	copy(affine[:56], xx[:])
+	copy(affine[56:], yy[:])
+	return p.FromAffineUncompressed(affine[:])
+}
+
+// ToAffineCompressed returns the 56 byte canonical encoding described in RFC 9496.
+func (p *PointDecaf448) ToAffineCompressed() []byte {
+	t := p.value.DecafEncode()
+	return t[:]
+}
+
+// ToAffineUncompressed returns the little endian x and y coordinates
+// of the internal representative as 112 bytes x || y.
+func (p *PointDecaf448) ToAffineUncompressed() []byte {
+	affine := p.value.ToAffine()
+	x := affine.X.Bytes()
+	y := affine.Y.Bytes()
+	var out [112]byte
+	copy(out[:56], x[:])
+	copy(out[56:], y[:])
+	return out[:]
+}
+
+// FromAffineCompressed decodes the 56 byte canonical encoding described in RFC 9496.
+func (*PointDecaf448) FromAffineCompressed(input []byte) (Point, error) {
+	if len(input) != ed448n.DecafBytes {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	var seq ed448n.DecafEncoding
+	copy(seq[:], input)
+	value, err := ed448n.DecafDecode(&seq)
+	if err != nil {
+		return nil, err
+	}
+	return &PointDecaf448{value}, nil
+}
+
+func (*PointDecaf448) FromAffineUncompressed(input []byte) (Point, error) {
+	if len(input) != 112 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	if bytes.Equal(input, make([]byte, 112)) {
+		return &PointDecaf448{value: ed448n.EdwardsPointNew().SetIdentity()}, nil
+	}
+	var xx, yy [56]byte
+	copy(xx[:], input[:56])
+	copy(yy[:], input[56:])
+	x, err := ed448n.FpNew().SetCanonicalBytes(&xx)
+	if err != nil {
+		return nil, err
+	}
+	y, err := ed448n.FpNew().SetCanonicalBytes(&yy)
+	if err != nil {
+		return nil, err
+	}
+	value := (&ed448n.AffinePoint{X: x, Y: y}).ToEdwards()
+	if value.IsDecafValidI() != 1 {
+		return nil, fmt.Errorf("invalid point")
+	}
+	return &PointDecaf448{value}, nil
+}
+
+func (*PointDecaf448) CurveName() string {
+	return Decaf448Name
+}
+
+func (*PointDecaf448) SumOfProducts(points []Point, scalars []Scalar) Point {
+	nPoints := make([]*ed448n.EdwardsPoint, len(points))
+	nScalars := make([]*ed448n.Fq, len(scalars))
+	for i, pt := range points {
+		pp, ok := pt.(*PointDecaf448)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = pp.value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarDecaf448)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.value
+	}
+	value, err := ed448n.EdwardsPointNew().SumOfProducts(nPoints, nScalars)
+	if err != nil {
+		return nil
+	}
+	return &PointDecaf448{value}
+}
+
+func (p *PointDecaf448) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point {
+	return p.VarTimeMultiScalarMult([]Scalar{a, b}, []Point{capA, p.Generator()})
+}
+
+func (p *PointDecaf448) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	return varTimeMultiScalarMult(p, scalars, points)
+}
+
+func (*PointDecaf448) batchNormalize(points []Point) []Point {
+	nPoints := make([]*ed448n.EdwardsPoint, len(points))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointDecaf448)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	out := make([]Point, len(points))
+	for i, value := range ed448n.BatchNormalize(nPoints) {
+		out[i] = &PointDecaf448{value}
+	}
+	return out
+}
+
+func (p *PointDecaf448) MarshalBinary() ([]byte, error) {
+	return PointMarshalBinary(p)
+}
+
+func (p *PointDecaf448) UnmarshalBinary(input []byte) error {
+	pt, err := PointUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointDecaf448)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointDecaf448) MarshalText() ([]byte, error) {
+	return PointMarshalText(p)
+}
+
+func (p *PointDecaf448) UnmarshalText(input []byte) error {
+	pt, err := PointUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointDecaf448)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointDecaf448) MarshalJSON() ([]byte, error) {
+	return PointMarshalJSON(p)
+}
+
+func (p *PointDecaf448) UnmarshalJSON(input []byte) error {
+	pt, err := PointUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	P, ok := pt.(*PointDecaf448)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	p.value = P.value
+	return nil
+}
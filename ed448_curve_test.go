@@ -0,0 +1,576 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"bytes"
+	crand "crypto/rand"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+)
+
+func TestScalarEd448Random(t *testing.T) {
+	ed448 := ED448()
+	sc := ed448.Scalar.Random(testRng())
+	s, ok := sc.(*ScalarEd448)
+	require.True(t, ok)
+	expected := bhex("2b17cf8efd379d314130d41079a0610e30d9d97e8648242610f89e22703075eede0af8673f23d077561b8640e500b7816b2ab67b4b5bb9dd")
+	require.Equal(t, s.value.BigInt(), expected)
+	// Try 10 random values
+	for i := 0; i < 10; i++ {
+		sc := ed448.Scalar.Random(crand.Reader)
+		_, ok := sc.(*ScalarEd448)
+		require.True(t, ok)
+		require.True(t, !sc.IsZero())
+	}
+}
+
+func TestScalarEd448Hash(t *testing.T) {
+	var b [32]byte
+	ed448 := ED448()
+	sc := ed448.Scalar.Hash(b[:])
+	s, ok := sc.(*ScalarEd448)
+	require.True(t, ok)
+	expected := bhex("34a01232fc1d475b0127edaa271b001a37ad53788aa5314ed35504ec420d739045d39db92823a071c11a424b486a734532034386be28bceb")
+	require.Equal(t, s.value.BigInt(), expected)
+}
+
+func TestScalarEd448Zero(t *testing.T) {
+	ed448 := ED448()
+	sc := ed448.Scalar.Zero()
+	require.True(t, sc.IsZero())
+	require.True(t, sc.IsEven())
+}
+
+func TestScalarEd448One(t *testing.T) {
+	ed448 := ED448()
+	sc := ed448.Scalar.One()
+	require.True(t, sc.IsOne())
+	require.True(t, sc.IsOdd())
+}
+
+func TestScalarEd448New(t *testing.T) {
+	ed448 := ED448()
+	three := ed448.Scalar.New(3)
+	require.True(t, three.IsOdd())
+	four := ed448.Scalar.New(4)
+	require.True(t, four.IsEven())
+	neg1 := ed448.Scalar.New(-1)
+	require.True(t, neg1.IsEven())
+	neg2 := ed448.Scalar.New(-2)
+	require.True(t, neg2.IsOdd())
+}
+
+func TestScalarEd448Square(t *testing.T) {
+	ed448 := ED448()
+	three := ed448.Scalar.New(3)
+	nine := ed448.Scalar.New(9)
+	require.Equal(t, three.Square().Cmp(nine), 0)
+}
+
+func TestScalarEd448Cube(t *testing.T) {
+	ed448 := ED448()
+	three := ed448.Scalar.New(3)
+	twentySeven := ed448.Scalar.New(27)
+	require.Equal(t, three.Cube().Cmp(twentySeven), 0)
+}
+
+func TestScalarEd448Double(t *testing.T) {
+	ed448 := ED448()
+	three := ed448.Scalar.New(3)
+	six := ed448.Scalar.New(6)
+	require.Equal(t, three.Double().Cmp(six), 0)
+}
+
+func TestScalarEd448Neg(t *testing.T) {
+	ed448 := ED448()
+	one := ed448.Scalar.One()
+	neg1 := ed448.Scalar.New(-1)
+	require.Equal(t, one.Neg().Cmp(neg1), 0)
+	lotsOfThrees := ed448.Scalar.New(333333)
+	expected := ed448.Scalar.New(-333333)
+	require.Equal(t, lotsOfThrees.Neg().Cmp(expected), 0)
+}
+
+func TestScalarEd448Invert(t *testing.T) {
+	ed448 := ED448()
+	nine := ed448.Scalar.New(9)
+	actual, _ := nine.Invert()
+	sa, _ := actual.(*ScalarEd448)
+	expected := bhex("238e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e34553db1017ba080c7d9373a567e716b16b34ddd9f74316c342bf42c0")
+	require.Equal(t, sa.value.BigInt(), expected)
+}
+
+func TestScalarEd448Sqrt(t *testing.T) {
+	ed448 := ED448()
+	nine := ed448.Scalar.New(9)
+	actual, err := nine.Sqrt()
+	require.NoError(t, err)
+	require.Equal(t, actual.Square().Cmp(nine), 0)
+}
+
+func TestScalarEd448Add(t *testing.T) {
+	ed448 := ED448()
+	nine := ed448.Scalar.New(9)
+	six := ed448.Scalar.New(6)
+	fifteen := nine.Add(six)
+	require.NotNil(t, fifteen)
+	expected := ed448.Scalar.New(15)
+	require.Equal(t, expected.Cmp(fifteen), 0)
+
+	upper := ed448.Scalar.New(-3)
+	actual := upper.Add(nine)
+	require.NotNil(t, actual)
+	require.Equal(t, actual.Cmp(six), 0)
+}
+
+func TestScalarEd448Sub(t *testing.T) {
+	ed448 := ED448()
+	nine := ed448.Scalar.New(9)
+	six := ed448.Scalar.New(6)
+	expected := ed448.Scalar.New(-3)
+
+	actual := six.Sub(nine)
+	require.Equal(t, expected.Cmp(actual), 0)
+
+	actual = nine.Sub(six)
+	require.Equal(t, actual.Cmp(ed448.Scalar.New(3)), 0)
+}
+
+func TestScalarEd448Mul(t *testing.T) {
+	ed448 := ED448()
+	nine := ed448.Scalar.New(9)
+	six := ed448.Scalar.New(6)
+	actual := nine.Mul(six)
+	require.Equal(t, actual.Cmp(ed448.Scalar.New(54)), 0)
+
+	upper := ed448.Scalar.New(-1)
+	require.Equal(t, upper.Mul(upper).Cmp(ed448.Scalar.New(1)), 0)
+}
+
+func TestScalarEd448Div(t *testing.T) {
+	ed448 := ED448()
+	nine := ed448.Scalar.New(9)
+	actual := nine.Div(nine)
+	require.Equal(t, actual.Cmp(ed448.Scalar.New(1)), 0)
+	require.Equal(t, ed448.Scalar.New(54).Div(nine).Cmp(ed448.Scalar.New(6)), 0)
+}
+
+func TestScalarEd448Serialize(t *testing.T) {
+	ed448 := ED448()
+	sc := ed448.Scalar.New(255)
+	sequence := sc.Bytes()
+	require.Equal(t, len(sequence), 57)
+	expected := make([]byte, 57)
+	expected[0] = 0xff
+	require.Equal(t, sequence, expected)
+	ret, err := ed448.Scalar.SetBytes(sequence)
+	require.NoError(t, err)
+	require.Equal(t, ret.Cmp(sc), 0)
+
+	// Try 10 random values
+	for i := 0; i < 10; i++ {
+		sc = ed448.Scalar.Random(crand.Reader)
+		sequence = sc.Bytes()
+		require.Equal(t, len(sequence), 57)
+		ret, err = ed448.Scalar.SetBytes(sequence)
+		require.NoError(t, err)
+		require.Equal(t, ret.Cmp(sc), 0)
+	}
+
+	// Non canonical values are rejected
+	for i := range sequence {
+		sequence[i] = 0xff
+	}
+	_, err = ed448.Scalar.SetBytes(sequence)
+	require.Error(t, err)
+}
+
+func TestScalarEd448Nil(t *testing.T) {
+	ed448 := ED448()
+	one := ed448.Scalar.New(1)
+	require.Nil(t, one.Add(nil))
+	require.Nil(t, one.Sub(nil))
+	require.Nil(t, one.Mul(nil))
+	require.Nil(t, one.Div(nil))
+	require.Nil(t, ed448.Scalar.Random(nil))
+	require.Nil(t, ed448.Scalar.Random(iotest.ErrReader(errors.New("reader failure"))))
+	require.Equal(t, one.Cmp(nil), -2)
+	_, err := ed448.Scalar.SetBigInt(nil)
+	require.Error(t, err)
+}
+
+func TestPointEd448Random(t *testing.T) {
+	ed448 := ED448()
+	sc := ed448.Point.Random(testRng())
+	s, ok := sc.(*PointEd448)
+	require.True(t, ok)
+	expected, _ := hex.DecodeString("a8aa09a39096d84847b4e583e55a77e4a280cecc229bb4bfa685199a9c1fa6dbf17557e2a000076639a792e5114e45bd498cec127a76a32180")
+	require.Equal(t, s.ToAffineCompressed(), expected)
+	// Try 25 random values
+	for i := 0; i < 25; i++ {
+		sc := ed448.Point.Random(crand.Reader)
+		_, ok := sc.(*PointEd448)
+		require.True(t, ok)
+		require.True(t, !sc.IsIdentity())
+		require.True(t, sc.IsOnCurve())
+	}
+}
+
+func TestPointEd448Hash(t *testing.T) {
+	var b [32]byte
+	ed448 := ED448()
+	sc := ed448.Point.Hash(b[:])
+	s, ok := sc.(*PointEd448)
+	require.True(t, ok)
+	expected, _ := hex.DecodeString("ceab94625db59c2b914edb789751e9bf3f7ee5f3a2ba6801695b161ae7be416ee4af6ae506695de181f114c0f1e0d55c8ffcd737486f07a880")
+	require.Equal(t, s.ToAffineCompressed(), expected)
+
+	// Fuzz test
+	for i := 0; i < 25; i++ {
+		_, _ = crand.Read(b[:])
+		sc = ed448.Point.Hash(b[:])
+		require.NotNil(t, sc)
+	}
+}
+
+func TestPointEd448Identity(t *testing.T) {
+	ed448 := ED448()
+	sc := ed448.Point.Identity()
+	require.True(t, sc.IsIdentity())
+	expected := make([]byte, 57)
+	expected[0] = 1
+	require.Equal(t, sc.ToAffineCompressed(), expected)
+}
+
+func TestPointEd448Generator(t *testing.T) {
+	ed448 := ED448()
+	sc := ed448.Point.Generator()
+	s, ok := sc.(*PointEd448)
+	require.True(t, ok)
+	expected, _ := hex.DecodeString("14fa30f25b790898adc8d74e2c13bdfdc4397ce61cffd33ad7c2a0051e9c78874098a36c7373ea4b62c7c9563720768824bcb66e71463f6900")
+	require.Equal(t, s.ToAffineCompressed(), expected)
+}
+
+func TestPointEd448Set(t *testing.T) {
+	ed448 := ED448()
+	iden, err := ed448.Point.Set(big.NewInt(0), big.NewInt(0))
+	require.NoError(t, err)
+	require.True(t, iden.IsIdentity())
+	x := bhex("4f1970c66bed0ded221d15a622bf36da9e146570470f1767ea6de324a3d3a46412ae1af72ab66511433b80e18b00938e2626a82bc70cc05e")
+	y := bhex("693f46716eb6bc248876203756c9c7624bea73736ca3984087789c1e05a0c2d73ad3ff1ce67c39c4fdbd132c4ed7c8ad9808795bf230fa14")
+	newPoint, err := ed448.Point.Set(x, y)
+	require.NoError(t, err)
+	require.True(t, newPoint.Equal(ed448.Point.Generator()))
+
+	_, err = ed448.Point.Set(x, x)
+	require.Error(t, err)
+}
+
+func TestPointEd448Double(t *testing.T) {
+	ed448 := ED448()
+	g := ed448.Point.Generator()
+	g2 := g.Double()
+	require.True(t, g2.Equal(g.Mul(ed448.Scalar.New(2))))
+	i := ed448.Point.Identity()
+	require.True(t, i.Double().Equal(i))
+}
+
+func TestPointEd448Neg(t *testing.T) {
+	ed448 := ED448()
+	g := ed448.Point.Generator().Neg()
+	require.True(t, g.Neg().Equal(ed448.Point.Generator()))
+	require.True(t, ed448.Point.Identity().Neg().Equal(ed448.Point.Identity()))
+}
+
+func TestPointEd448Add(t *testing.T) {
+	ed448 := ED448()
+	pt := ed448.Point.Generator()
+	require.True(t, pt.Add(pt).Equal(pt.Double()))
+	require.True(t, pt.Mul(ed448.Scalar.New(3)).Equal(pt.Add(pt).Add(pt)))
+}
+
+func TestPointEd448Sub(t *testing.T) {
+	ed448 := ED448()
+	g := ed448.Point.Generator()
+	pt := ed448.Point.Generator().Mul(ed448.Scalar.New(4))
+	require.True(t, pt.Sub(g).Sub(g).Sub(g).Equal(g))
+	require.True(t, pt.Sub(g).Sub(g).Sub(g).Sub(g).IsIdentity())
+}
+
+func TestPointEd448Mul(t *testing.T) {
+	ed448 := ED448()
+	g := ed448.Point.Generator()
+	pt := ed448.Point.Generator().Mul(ed448.Scalar.New(4))
+	require.True(t, g.Double().Double().Equal(pt))
+}
+
+func TestPointEd448PublicKey(t *testing.T) {
+	// RFC 8032 §7.4 -----TEST 1
+	ed448 := ED448()
+	sk, _ := hex.DecodeString("6c82a562cb808d10d632be89c8513ebf6c929f34ddfa8c9f63c9960ef6e348a3528c8a3fcc2f044e39a3fc5b94492f8f032e7549a20098f95b")
+	var h [114]byte
+	sha3.ShakeSum256(h[:], sk)
+	s, err := new(ScalarEd448).SetBytesClamping(h[:57])
+	require.NoError(t, err)
+	pk := ed448.ScalarBaseMult(s)
+	expected, _ := hex.DecodeString("5fd7449b59b461fd2ce787ec616ad46a1da1342485a70e1f8a0ea75d80e96778edf124769b46c7061bd6783df1e50f6cd1fa1abeafe8256180")
+	require.Equal(t, pk.ToAffineCompressed(), expected)
+}
+
+func TestPointEd448Serialize(t *testing.T) {
+	ed448 := ED448()
+	ss := ed448.Scalar.Random(testRng())
+	g := ed448.Point.Generator()
+
+	ppt := g.Mul(ss)
+	expectedC, _ := hex.DecodeString("b8f4351e514b4689274eebf021fa839846882a16df1d780150318e6efe636cdc0f6ab5251eb092ad1cdaf76839145048f9b6e6975ed3376580")
+	expectedU, _ := hex.DecodeString("85510c30d75120d68820b847e499d8ecee362b2cc8ce7ff116a862696f4a2b9df87ed14f71e938f5b0c7cd32b9abbeaf098d7372b53245f1b8f4351e514b4689274eebf021fa839846882a16df1d780150318e6efe636cdc0f6ab5251eb092ad1cdaf76839145048f9b6e6975ed33765")
+	require.Equal(t, ppt.ToAffineCompressed(), expectedC)
+	require.Equal(t, ppt.ToAffineUncompressed(), expectedU)
+	retP, err := ppt.FromAffineCompressed(ppt.ToAffineCompressed())
+	require.NoError(t, err)
+	require.True(t, ppt.Equal(retP))
+	retP, err = ppt.FromAffineUncompressed(ppt.ToAffineUncompressed())
+	require.NoError(t, err)
+	require.True(t, ppt.Equal(retP))
+
+	// smoke test
+	for i := 0; i < 25; i++ {
+		s := ed448.Scalar.Random(crand.Reader)
+		pt := g.Mul(s)
+		cmprs := pt.ToAffineCompressed()
+		require.Equal(t, len(cmprs), 57)
+		retC, err := pt.FromAffineCompressed(cmprs)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(retC))
+
+		un := pt.ToAffineUncompressed()
+		require.Equal(t, len(un), 112)
+		retU, err := pt.FromAffineUncompressed(un)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(retU))
+	}
+}
+
+func TestPointEd448Nil(t *testing.T) {
+	ed448 := ED448()
+	one := ed448.Point.Generator()
+	require.Nil(t, one.Add(nil))
+	require.Nil(t, one.Sub(nil))
+	require.Nil(t, one.Mul(nil))
+	require.Nil(t, ed448.Scalar.Random(nil))
+	require.Nil(t, ed448.Point.Random(nil))
+	require.Nil(t, ed448.Point.Random(iotest.ErrReader(errors.New("reader failure"))))
+	require.Nil(t, ed448.Point.Random(bytes.NewReader(make([]byte, 10))))
+	require.False(t, one.Equal(nil))
+	_, err := ed448.Scalar.SetBigInt(nil)
+	require.Error(t, err)
+}
+
+func TestPointEd448SumOfProducts(t *testing.T) {
+	lhs := new(PointEd448).Generator().Mul(new(ScalarEd448).New(50))
+	points := make([]Point, 5)
+	for i := range points {
+		points[i] = new(PointEd448).Generator()
+	}
+	scalars := []Scalar{
+		new(ScalarEd448).New(8),
+		new(ScalarEd448).New(9),
+		new(ScalarEd448).New(10),
+		new(ScalarEd448).New(11),
+		new(ScalarEd448).New(12),
+	}
+	rhs := lhs.SumOfProducts(points, scalars)
+	require.NotNil(t, rhs)
+	require.True(t, lhs.Equal(rhs))
+}
+
+func TestPointEd448Marshal(t *testing.T) {
+	ed448 := ED448()
+	pt := ed448.Point.Hash([]byte("TestPointEd448Marshal"))
+	sc := ed448.Scalar.Hash([]byte("TestPointEd448Marshal"))
+
+	bin, err := pt.(*PointEd448).MarshalBinary()
+	require.NoError(t, err)
+	retP := new(PointEd448)
+	require.NoError(t, retP.UnmarshalBinary(bin))
+	require.True(t, pt.Equal(retP))
+
+	txt, err := pt.(*PointEd448).MarshalText()
+	require.NoError(t, err)
+	retP = new(PointEd448)
+	require.NoError(t, retP.UnmarshalText(txt))
+	require.True(t, pt.Equal(retP))
+
+	js, err := sc.(*ScalarEd448).MarshalJSON()
+	require.NoError(t, err)
+	retS := new(ScalarEd448)
+	require.NoError(t, retS.UnmarshalJSON(js))
+	require.Equal(t, sc.Cmp(retS), 0)
+
+	require.Equal(t, ED448Name, GetCurveByName(ED448Name).Name)
+}
+
+func TestScalarDecaf448Serialize(t *testing.T) {
+	decaf := Decaf448()
+	sc := decaf.Scalar.New(255)
+	sequence := sc.Bytes()
+	require.Equal(t, len(sequence), 56)
+	expected := make([]byte, 56)
+	expected[0] = 0xff
+	require.Equal(t, sequence, expected)
+	ret, err := decaf.Scalar.SetBytes(sequence)
+	require.NoError(t, err)
+	require.Equal(t, ret.Cmp(sc), 0)
+
+	for i := 0; i < 10; i++ {
+		sc = decaf.Scalar.Random(crand.Reader)
+		sequence = sc.Bytes()
+		require.Equal(t, len(sequence), 56)
+		ret, err = decaf.Scalar.SetBytes(sequence)
+		require.NoError(t, err)
+		require.Equal(t, ret.Cmp(sc), 0)
+	}
+
+	// Non canonical values are rejected
+	for i := range sequence {
+		sequence[i] = 0xff
+	}
+	_, err = decaf.Scalar.SetBytes(sequence)
+	require.Error(t, err)
+	_, err = decaf.Scalar.SetBytes(make([]byte, 57))
+	require.Error(t, err)
+
+	wide := make([]byte, 112)
+	wide[0] = 0xff
+	ret, err = decaf.Scalar.SetBytesWide(wide)
+	require.NoError(t, err)
+	require.Equal(t, ret.Cmp(decaf.Scalar.New(255)), 0)
+}
+
+func TestPointDecaf448Generator(t *testing.T) {
+	decaf := Decaf448()
+	g := decaf.Point.Generator()
+	expected, _ := hex.DecodeString("6666666666666666666666666666666666666666666666666666666633333333333333333333333333333333333333333333333333333333")
+	require.Equal(t, g.ToAffineCompressed(), expected)
+	require.True(t, g.IsOnCurve())
+	require.False(t, g.IsIdentity())
+	require.True(t, decaf.Point.Identity().IsIdentity())
+	require.Equal(t, decaf.Point.Identity().ToAffineCompressed(), make([]byte, 56))
+
+	// RFC 9496 Appendix B.1
+	expected, _ = hex.DecodeString("a0c09bf2ba7208fda0f4bfe3d0f5b29a543012306d43831b5adc6fe7f8596fa308763db15468323b11cf6e4aeb8c18fe44678f44545a69bc")
+	require.Equal(t, g.Mul(decaf.Scalar.New(3)).ToAffineCompressed(), expected)
+	require.Equal(t, g.Double().Add(g).ToAffineCompressed(), expected)
+}
+
+func TestPointDecaf448Arithmetic(t *testing.T) {
+	decaf := Decaf448()
+	g := decaf.Point.Generator()
+	a := decaf.Scalar.Random(crand.Reader)
+	b := decaf.Scalar.Random(crand.Reader)
+	require.True(t, g.Mul(a).Add(g.Mul(b)).Equal(g.Mul(a.Add(b))))
+	require.True(t, g.Mul(a).Sub(g.Mul(b)).Equal(g.Mul(a.Sub(b))))
+	require.True(t, g.Mul(a).Neg().Equal(g.Mul(a.Neg())))
+	require.True(t, g.Mul(a).Sub(g.Mul(a)).IsIdentity())
+	require.True(t, g.Mul(decaf.Scalar.Zero()).IsIdentity())
+}
+
+func TestPointDecaf448Serialize(t *testing.T) {
+	decaf := Decaf448()
+	g := decaf.Point.Generator()
+
+	for i := 0; i < 25; i++ {
+		s := decaf.Scalar.Random(crand.Reader)
+		pt := g.Mul(s)
+		cmprs := pt.ToAffineCompressed()
+		require.Equal(t, len(cmprs), 56)
+		retC, err := pt.FromAffineCompressed(cmprs)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(retC))
+		require.Equal(t, cmprs, retC.ToAffineCompressed())
+
+		un := pt.ToAffineUncompressed()
+		require.Equal(t, len(un), 112)
+		retU, err := pt.FromAffineUncompressed(un)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(retU))
+	}
+
+	// Non-canonical and negative encodings are rejected
+	invalid := make([]byte, 56)
+	invalid[0] = 1
+	_, err := g.FromAffineCompressed(invalid)
+	require.Error(t, err)
+	for i := range invalid {
+		invalid[i] = 0xff
+	}
+	_, err = g.FromAffineCompressed(invalid)
+	require.Error(t, err)
+	_, err = g.FromAffineCompressed(make([]byte, 57))
+	require.Error(t, err)
+}
+
+func TestPointDecaf448FromUniformBytes(t *testing.T) {
+	// RFC 9496 Appendix B.2
+	input, _ := hex.DecodeString("cbb8c991fd2f0b7e1913462d6463e4fd2ce4ccdd28274dc2ca1f4165d5ee6cdccea57be3416e166fd06718a31af45a2f8e987e301be59ae6673e963001dbbda80df47014a21a26d6c7eb4ebe0312aa6fffb8d1b26bc62ca40ed51f8057a635a02c2b8c83f48fa6a2d70f58a1185902c0")
+	expected, _ := hex.DecodeString("0c709c9607dbb01c94513358745b7c23953d03b33e39c7234e268d1d6e24f34014ccbc2216b965dd231d5327e591dc3c0e8844ccfd568848")
+	pt, err := new(PointDecaf448).FromUniformBytes(input)
+	require.NoError(t, err)
+	require.Equal(t, pt.ToAffineCompressed(), expected)
+	_, err = new(PointDecaf448).FromUniformBytes(input[:56])
+	require.Error(t, err)
+}
+
+func TestPointDecaf448SumOfProducts(t *testing.T) {
+	lhs := new(PointDecaf448).Generator().Mul(new(ScalarDecaf448).New(50))
+	points := make([]Point, 5)
+	for i := range points {
+		points[i] = new(PointDecaf448).Generator()
+	}
+	scalars := []Scalar{
+		new(ScalarDecaf448).New(8),
+		new(ScalarDecaf448).New(9),
+		new(ScalarDecaf448).New(10),
+		new(ScalarDecaf448).New(11),
+		new(ScalarDecaf448).New(12),
+	}
+	rhs := lhs.SumOfProducts(points, scalars)
+	require.NotNil(t, rhs)
+	require.True(t, lhs.Equal(rhs))
+}
+
+func TestPointDecaf448Marshal(t *testing.T) {
+	decaf := Decaf448()
+	pt := decaf.Point.Hash([]byte("TestPointDecaf448Marshal"))
+	sc := decaf.Scalar.Hash([]byte("TestPointDecaf448Marshal"))
+
+	bin, err := pt.(*PointDecaf448).MarshalBinary()
+	require.NoError(t, err)
+	retP := new(PointDecaf448)
+	require.NoError(t, retP.UnmarshalBinary(bin))
+	require.True(t, pt.Equal(retP))
+
+	txt, err := pt.(*PointDecaf448).MarshalText()
+	require.NoError(t, err)
+	retP = new(PointDecaf448)
+	require.NoError(t, retP.UnmarshalText(txt))
+	require.True(t, pt.Equal(retP))
+
+	js, err := sc.(*ScalarDecaf448).MarshalJSON()
+	require.NoError(t, err)
+	retS := new(ScalarDecaf448)
+	require.NoError(t, retS.UnmarshalJSON(js))
+	require.Equal(t, sc.Cmp(retS), 0)
+
+	require.Equal(t, Decaf448Name, GetCurveByName(Decaf448Name).Name)
+}
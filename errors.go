@@ -0,0 +1,35 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+// Error is the type of the sentinel errors returned by the checked
+// operations. Match them with errors.Is, or use errors.As with an
+// Error to detect any of them.
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+const (
+	// ErrNilArgument is returned when a point, scalar or reader is nil.
+	ErrNilArgument = Error("argument cannot be nil")
+	// ErrTypeMismatch is returned when values from different curves
+	// or groups are combined.
+	ErrTypeMismatch = Error("values belong to different curves")
+	// ErrLengthMismatch is returned when the number of points and
+	// scalars given to a multi-scalar multiplication differ.
+	ErrLengthMismatch = Error("points and scalars have different lengths")
+	// ErrRandom is returned when the reader fails or does not supply
+	// enough bytes. The underlying read error is wrapped as well.
+	ErrRandom = Error("unable to read random bytes")
+	// ErrHash is returned when hashing to a scalar or point fails.
+	ErrHash = Error("unable to hash to curve")
+	// ErrNotInvertible is returned when inverting or dividing by zero.
+	ErrNotInvertible = Error("value is not invertible")
+	// ErrUnsupported is returned when a curve does not
+	// implement an optional operation.
+	ErrUnsupported = Error("operation is not supported by this curve")
+)
@@ -0,0 +1,260 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+const (
+	// fixedBaseEntries is the number of non-identity multiples
+	// in each table row, one per non-zero 4-bit digit
+	fixedBaseEntries = 15
+	fixedBaseVersion = 1
+)
+
+var (
+	generatorTablesLock sync.RWMutex
+	// generatorTables caches the generator table of each point type
+	generatorTables = make(map[reflect.Type]*FixedBase)
+)
+
+// FixedBase holds precomputed multiples of a base point so multiplying
+// the base by a scalar needs one addition for every 4 bits of the scalar
+// and no doublings. This suits points multiplied by many scalars such as
+// curve generators or Pedersen commitment generators.
+//
+// Each row is read in constant time so the scalar may be secret.
+// The base must be from a curve that implements constant time table
+// selection, which all the built-in curves do.
+type FixedBase struct {
+	base Point
+	// table[i][j] is (j+1) * 16^i * base
+	table    [][]Point
+	selector pointSelector
+	// littleEndian is true if the scalar bytes are little endian
+	littleEndian bool
+}
+
+// pointSelector is implemented by points that can select a FixedBase
+// row entry without the memory access depending on the index.
+type pointSelector interface {
+	// selectPoint returns row[index-1] or the identity if index is 0
+	selectPoint(row []Point, index int) Point
+}
+
+// baseMultiplier is implemented by points whose underlying
+// implementation already has a precomputed generator table.
+type baseMultiplier interface {
+	scalarBaseMult(sc Scalar) Point
+}
+
+// NewFixedBase precomputes the multiples of base.
+// An error is returned if base is nil or the identity and
+// ErrUnsupported if its curve has no constant time table selection.
+func NewFixedBase(base Point) (*FixedBase, error) {
+	if isNil(base) {
+		return nil, ErrNilArgument
+	}
+	if base.IsIdentity() {
+		return nil, fmt.Errorf("base cannot be the identity")
+	}
+	if _, ok := base.(pointSelector); !ok {
+		return nil, ErrUnsupported
+	}
+	rows := fixedBaseRows(base)
+	table := make([][]Point, rows)
+	b := base
+	for i := range table {
+		row := make([]Point, fixedBaseEntries)
+		row[0] = b
+		for j := 1; j < fixedBaseEntries; j++ {
+			row[j] = row[j-1].Add(b)
+			if isNil(row[j]) {
+				return nil, ErrTypeMismatch
+			}
+		}
+		table[i] = row
+		b = row[fixedBaseEntries-1].Add(b)
+	}
+	selector := tableSelector(base, table)
+	if selector == nil {
+		return nil, ErrTypeMismatch
+	}
+	return &FixedBase{base, table, selector, scalarLittleEndian(base.Scalar())}, nil
+}
+
+// Base returns the point the table was computed for.
+func (f *FixedBase) Base() Point {
+	return f.base
+}
+
+// Mul returns base * sc or nil if sc is not a scalar for base.
+// The digits are read from the fixed length encoding of sc
+// so the scalar never passes through variable time code.
+func (f *FixedBase) Mul(sc Scalar) Point {
+	if isNil(sc) || reflect.TypeOf(sc) != reflect.TypeOf(f.base.Scalar()) {
+		return nil
+	}
+	digits := sc.Bytes()
+	if 2*len(digits) != len(f.table) {
+		return nil
+	}
+
+	out := f.base.Identity()
+	for i, row := range f.table {
+		// the low nibble of each byte is the first digit
+		b := digits[len(digits)-1-i/2]
+		if f.littleEndian {
+			b = digits[i/2]
+		}
+		digit := int(b>>(4*(i&1))) & 0xf
+		out = out.Add(f.selector.selectPoint(row, digit))
+	}
+	return out
+}
+
+// MarshalBinary encodes the table so it can be loaded
+// instead of being computed again.
+func (f *FixedBase) MarshalBinary() ([]byte, error) {
+	base, err := PointMarshalBinary(f.base)
+	if err != nil {
+		return nil, err
+	}
+	size := len(f.base.ToAffineUncompressed())
+	out := make([]byte, 0, 1+2*binary.MaxVarintLen64+len(base)+len(f.table)*fixedBaseEntries*size)
+	out = append(out, fixedBaseVersion)
+	out = binary.AppendUvarint(out, uint64(len(base)))
+	out = append(out, base...)
+	out = binary.AppendUvarint(out, uint64(len(f.table)))
+	for _, row := range f.table {
+		for _, pt := range row {
+			out = append(out, pt.ToAffineUncompressed()...)
+		}
+	}
+	return out, nil
+}
+
+// UnmarshalBinary loads a table created by MarshalBinary.
+// Entries are decoded with FromAffineUncompressed and are not checked
+// to be the correct multiples of the base, so tables must come from
+// trusted storage.
+func (f *FixedBase) UnmarshalBinary(input []byte) error {
+	if len(input) == 0 || input[0] != fixedBaseVersion {
+		return fmt.Errorf("invalid fixed base version")
+	}
+	input = input[1:]
+	baseLen, n := binary.Uvarint(input)
+	if n <= 0 || baseLen > uint64(len(input)-n) {
+		return fmt.Errorf("invalid fixed base length")
+	}
+	input = input[n:]
+	base, err := PointUnmarshalBinary(input[:baseLen])
+	if err != nil {
+		return err
+	}
+	if base.IsIdentity() {
+		return fmt.Errorf("base cannot be the identity")
+	}
+	if _, ok := base.(pointSelector); !ok {
+		return ErrUnsupported
+	}
+	input = input[baseLen:]
+	rows, n := binary.Uvarint(input)
+	if n <= 0 || rows != uint64(fixedBaseRows(base)) {
+		return fmt.Errorf("invalid fixed base rows")
+	}
+	input = input[n:]
+	size := len(base.ToAffineUncompressed())
+	if uint64(len(input)) != rows*fixedBaseEntries*uint64(size) {
+		return fmt.Errorf("invalid fixed base length")
+	}
+	if !bytes.Equal(input[:size], base.ToAffineUncompressed()) {
+		return fmt.Errorf("fixed base table does not match the base")
+	}
+	table := make([][]Point, rows)
+	for i := range table {
+		table[i] = make([]Point, fixedBaseEntries)
+		for j := range table[i] {
+			table[i][j], err = base.FromAffineUncompressed(input[:size])
+			if err != nil {
+				return err
+			}
+			input = input[size:]
+		}
+	}
+	table[0][0] = base
+	selector := tableSelector(base, table)
+	if selector == nil {
+		return ErrTypeMismatch
+	}
+	f.base = base
+	f.table = table
+	f.selector = selector
+	f.littleEndian = scalarLittleEndian(base.Scalar())
+	return nil
+}
+
+// tableSelector returns the constant time selector for the table if every
+// entry has the same type as base. Wrappers that embed a built-in point
+// inherit selectPoint but their arithmetic returns the embedded type.
+func tableSelector(base Point, table [][]Point) pointSelector {
+	selector, ok := base.(pointSelector)
+	if !ok {
+		return nil
+	}
+	t := reflect.TypeOf(base)
+	for _, row := range table {
+		for _, pt := range row {
+			if reflect.TypeOf(pt) != t {
+				return nil
+			}
+		}
+	}
+	return selector
+}
+
+// scalarLittleEndian reports if the scalars of sc's field serialize
+// little endian, detected from the public encoding of one.
+func scalarLittleEndian(sc Scalar) bool {
+	return sc.One().Bytes()[0] == 1
+}
+
+// fixedBaseRows returns the number of 4-bit digits in a scalar for base.
+func fixedBaseRows(base Point) int {
+	return 2 * len(base.Scalar().Bytes())
+}
+
+// generatorTable returns the lazily computed table for the generator of c.
+func (c *Curve) generatorTable() *FixedBase {
+	key := reflect.TypeOf(c.Point)
+	generatorTablesLock.RLock()
+	table, ok := generatorTables[key]
+	generatorTablesLock.RUnlock()
+	if ok {
+		return table
+	}
+	generatorTablesLock.Lock()
+	defer generatorTablesLock.Unlock()
+	if table, ok = generatorTables[key]; ok {
+		return table
+	}
+	table, err := NewFixedBase(c.Point.Generator())
+	if err != nil {
+		return nil
+	}
+	generatorTables[key] = table
+	return table
+}
+
+// ctEqual returns 1 if a == b and 0 otherwise in constant time.
+func ctEqual(a, b int) int {
+	return subtle.ConstantTimeEq(int32(a), int32(b))
+}
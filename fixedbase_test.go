@@ -0,0 +1,89 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixedBaseCurves(t *testing.T) {
+	for _, name := range RegisteredCurves() {
+		curve := GetCurveByName(name)
+		base := curve.Point.Random(crand.Reader)
+		table, err := NewFixedBase(base)
+		require.NoError(t, err, name)
+		require.True(t, base.Equal(table.Base()), name)
+
+		require.NotNil(t, table.selector, name)
+
+		for _, sc := range []Scalar{
+			curve.Scalar.Zero(),
+			curve.Scalar.One(),
+			curve.Scalar.New(16),
+			curve.Scalar.One().Neg(),
+			curve.Scalar.Random(crand.Reader),
+		} {
+			require.True(t, base.Mul(sc).Equal(table.Mul(sc)), name)
+			require.True(t, curve.Point.Generator().Mul(sc).Equal(curve.ScalarBaseMult(sc)), name)
+		}
+	}
+}
+
+func TestFixedBaseErrors(t *testing.T) {
+	_, err := NewFixedBase(nil)
+	require.ErrorIs(t, err, ErrNilArgument)
+	_, err = NewFixedBase(K256().NewIdentityPoint())
+	require.Error(t, err)
+
+	table, err := NewFixedBase(K256().NewGeneratorPoint())
+	require.NoError(t, err)
+	require.Nil(t, table.Mul(P256().Scalar.One()))
+	require.Nil(t, table.Mul(nil))
+	require.Nil(t, K256().ScalarBaseMult(P256().Scalar.One()))
+	require.Nil(t, ED25519().ScalarBaseMult(P256().Scalar.One()))
+}
+
+func TestFixedBaseMarshal(t *testing.T) {
+	for _, curve := range []*Curve{K256(), PALLAS(), ED25519(), BLS12381G1(), P521()} {
+		base := curve.Point.Hash([]byte("pedersen"))
+		table, err := NewFixedBase(base)
+		require.NoError(t, err)
+		bin, err := table.MarshalBinary()
+		require.NoError(t, err)
+
+		loaded := new(FixedBase)
+		require.NoError(t, loaded.UnmarshalBinary(bin))
+		require.True(t, base.Equal(loaded.Base()))
+		require.NotNil(t, loaded.selector)
+		sc := curve.Scalar.Random(crand.Reader)
+		require.True(t, base.Mul(sc).Equal(loaded.Mul(sc)))
+
+		require.Error(t, loaded.UnmarshalBinary(bin[:len(bin)-1]))
+		require.Error(t, loaded.UnmarshalBinary(append(bin, 0)))
+		require.Error(t, loaded.UnmarshalBinary(nil))
+		bad := append([]byte{}, bin...)
+		bad[0] = 2
+		require.Error(t, loaded.UnmarshalBinary(bad))
+	}
+}
+
+// testPlainPoint hides the constant time selection of the wrapped point.
+type testPlainPoint struct {
+	Point
+}
+
+func TestFixedBaseUnsupported(t *testing.T) {
+	base := K256().Point.Hash([]byte("unsupported"))
+	_, err := NewFixedBase(testPlainPoint{base})
+	require.ErrorIs(t, err, ErrUnsupported)
+
+	// The test wrapper cannot add two wrapped points so
+	// construction reports an error instead of panicking
+	_, err = NewFixedBase(testRegistryPoint{base.(*PointK256)})
+	require.ErrorIs(t, err, ErrTypeMismatch)
+}
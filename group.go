@@ -0,0 +1,379 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// Group is a statically typed view of a Curve where S and P are the
+// concrete scalar and point types, e.g. Group[*ScalarK256, *PointK256].
+// Passing a point or scalar from another curve is a compile-time error
+// instead of the nil returned by the dynamic Point and Scalar methods.
+// Methods that can still fail return the sentinel errors in errors.go.
+//
+// The arithmetic methods without an error result panic with a message
+// naming the operation if the curve returns nil or a value of another
+// type. That does not happen for the built-in curves, only for a curve
+// whose Point or Scalar wraps a built-in type without overriding the
+// methods that return the embedded type.
+type Group[S Scalar, P Point] struct {
+	curve *Curve
+}
+
+// NewGroup returns the typed view of curve. An error is returned
+// if S and P are not the concrete types used by curve.
+func NewGroup[S Scalar, P Point](curve *Curve) (*Group[S, P], error) {
+	if curve == nil {
+		return nil, fmt.Errorf("curve cannot be nil")
+	}
+	if _, ok := curve.Scalar.(S); !ok {
+		return nil, fmt.Errorf("scalar type %T does not belong to %s", *new(S), curve.Name)
+	}
+	if _, ok := curve.Point.(P); !ok {
+		return nil, fmt.Errorf("point type %T does not belong to %s", *new(P), curve.Name)
+	}
+	return &Group[S, P]{curve}, nil
+}
+
+// PointAs converts a dynamically typed point to P
+// returning an error instead of nil when it is not a P.
+func PointAs[P Point](point Point) (P, error) {
+	p, ok := point.(P)
+	if !ok || point == nil {
+		return p, fmt.Errorf("%w: point %T is not a %T", ErrTypeMismatch, point, p)
+	}
+	return p, nil
+}
+
+// ScalarAs converts a dynamically typed scalar to S
+// returning an error instead of nil when it is not an S.
+func ScalarAs[S Scalar](scalar Scalar) (S, error) {
+	s, ok := scalar.(S)
+	if !ok || scalar == nil {
+		return s, fmt.Errorf("%w: scalar %T is not a %T", ErrTypeMismatch, scalar, s)
+	}
+	return s, nil
+}
+
+// mustPoint returns point as a P or panics naming op.
+func mustPoint[P Point](point Point, op string) P {
+	p, ok := point.(P)
+	if !ok {
+		panic(fmt.Sprintf("curvey: Group.%s returned %T instead of %T", op, point, p))
+	}
+	return p
+}
+
+// mustScalar returns scalar as an S or panics naming op.
+func mustScalar[S Scalar](scalar Scalar, op string) S {
+	s, ok := scalar.(S)
+	if !ok {
+		panic(fmt.Sprintf("curvey: Group.%s returned %T instead of %T", op, scalar, s))
+	}
+	return s
+}
+
+// Curve returns the dynamically typed curve for this group.
+func (g *Group[S, P]) Curve() *Curve {
+	return g.curve
+}
+
+// Name returns the curve name.
+func (g *Group[S, P]) Name() string {
+	return g.curve.Name
+}
+
+// Points converts typed points to the dynamic interface.
+func (*Group[S, P]) Points(points []P) []Point {
+	out := make([]Point, len(points))
+	for i, p := range points {
+		out[i] = p
+	}
+	return out
+}
+
+// Scalars converts typed scalars to the dynamic interface.
+func (*Group[S, P]) Scalars(scalars []S) []Scalar {
+	out := make([]Scalar, len(scalars))
+	for i, s := range scalars {
+		out[i] = s
+	}
+	return out
+}
+
+func (g *Group[S, P]) Identity() P {
+	return mustPoint[P](g.curve.Point.Identity(), "Identity")
+}
+
+func (g *Group[S, P]) Generator() P {
+	return mustPoint[P](g.curve.Point.Generator(), "Generator")
+}
+
+func (g *Group[S, P]) RandomPoint(reader io.Reader) (P, error) {
+	p, err := RandomPointE(g.curve.Point, reader)
+	if err != nil {
+		return *new(P), err
+	}
+	return PointAs[P](p)
+}
+
+func (g *Group[S, P]) HashToPoint(msg []byte) (P, error) {
+	p, err := HashPointE(g.curve.Point, msg)
+	if err != nil {
+		return *new(P), err
+	}
+	return PointAs[P](p)
+}
+
+func (*Group[S, P]) Add(lhs, rhs P) P {
+	return mustPoint[P](lhs.Add(rhs), "Add")
+}
+
+func (*Group[S, P]) Sub(lhs, rhs P) P {
+	return mustPoint[P](lhs.Sub(rhs), "Sub")
+}
+
+func (*Group[S, P]) Neg(point P) P {
+	return mustPoint[P](point.Neg(), "Neg")
+}
+
+func (*Group[S, P]) Double(point P) P {
+	return mustPoint[P](point.Double(), "Double")
+}
+
+func (*Group[S, P]) Mul(point P, scalar S) P {
+	return mustPoint[P](point.Mul(scalar), "Mul")
+}
+
+func (g *Group[S, P]) ScalarBaseMult(scalar S) P {
+	return mustPoint[P](g.curve.ScalarBaseMult(scalar), "ScalarBaseMult")
+}
+
+func (*Group[S, P]) Equal(lhs, rhs P) bool {
+	return lhs.Equal(rhs)
+}
+
+// SumOfProducts computes the multi-scalar multiplication of points and scalars.
+// An error is returned if the lengths differ.
+func (g *Group[S, P]) SumOfProducts(points []P, scalars []S) (P, error) {
+	if len(points) != len(scalars) {
+		return *new(P), ErrLengthMismatch
+	}
+	return PointAs[P](g.curve.Point.SumOfProducts(g.Points(points), g.Scalars(scalars)))
+}
+
+// SumOfProductsVarTime computes the multi-scalar multiplication of points and
+// scalars in variable time. It must only be used with public scalars.
+// See the package function SumOfProductsVarTime for the curves it speeds up.
+func (g *Group[S, P]) SumOfProductsVarTime(points []P, scalars []S, opts *MsmOptions) (P, error) {
+	out, err := SumOfProductsVarTime(g.curve.Point, g.Points(points), g.Scalars(scalars), opts)
+	if err != nil {
+		return *new(P), err
+	}
+	return PointAs[P](out)
+}
+
+func (g *Group[S, P]) PointFromAffineCompressed(input []byte) (P, error) {
+	p, err := g.curve.Point.FromAffineCompressed(input)
+	if err != nil {
+		return *new(P), err
+	}
+	return PointAs[P](p)
+}
+
+func (g *Group[S, P]) PointFromAffineUncompressed(input []byte) (P, error) {
+	p, err := g.curve.Point.FromAffineUncompressed(input)
+	if err != nil {
+		return *new(P), err
+	}
+	return PointAs[P](p)
+}
+
+func (g *Group[S, P]) ScalarZero() S {
+	return mustScalar[S](g.curve.Scalar.Zero(), "ScalarZero")
+}
+
+func (g *Group[S, P]) ScalarOne() S {
+	return mustScalar[S](g.curve.Scalar.One(), "ScalarOne")
+}
+
+func (g *Group[S, P]) NewScalar(value int) S {
+	return mustScalar[S](g.curve.Scalar.New(value), "NewScalar")
+}
+
+func (g *Group[S, P]) RandomScalar(reader io.Reader) (S, error) {
+	s, err := RandomScalarE(g.curve.Scalar, reader)
+	if err != nil {
+		return *new(S), err
+	}
+	return ScalarAs[S](s)
+}
+
+func (g *Group[S, P]) HashToScalar(msg []byte) (S, error) {
+	s, err := HashScalarE(g.curve.Scalar, msg)
+	if err != nil {
+		return *new(S), err
+	}
+	return ScalarAs[S](s)
+}
+
+func (*Group[S, P]) ScalarAdd(lhs, rhs S) S {
+	return mustScalar[S](lhs.Add(rhs), "ScalarAdd")
+}
+
+func (*Group[S, P]) ScalarSub(lhs, rhs S) S {
+	return mustScalar[S](lhs.Sub(rhs), "ScalarSub")
+}
+
+func (*Group[S, P]) ScalarMul(lhs, rhs S) S {
+	return mustScalar[S](lhs.Mul(rhs), "ScalarMul")
+}
+
+func (*Group[S, P]) ScalarNeg(scalar S) S {
+	return mustScalar[S](scalar.Neg(), "ScalarNeg")
+}
+
+func (*Group[S, P]) ScalarInvert(scalar S) (S, error) {
+	s, err := ScalarInvertE(scalar)
+	if err != nil {
+		return *new(S), err
+	}
+	return ScalarAs[S](s)
+}
+
+func (*Group[S, P]) ScalarEqual(lhs, rhs S) bool {
+	return lhs.Cmp(rhs) == 0
+}
+
+func (g *Group[S, P]) ScalarFromBytes(input []byte) (S, error) {
+	s, err := g.curve.Scalar.SetBytes(input)
+	if err != nil {
+		return *new(S), err
+	}
+	return ScalarAs[S](s)
+}
+
+func (g *Group[S, P]) ScalarFromBytesWide(input []byte) (S, error) {
+	s, err := g.curve.Scalar.SetBytesWide(input)
+	if err != nil {
+		return *new(S), err
+	}
+	return ScalarAs[S](s)
+}
+
+func (g *Group[S, P]) ScalarFromBigInt(v *big.Int) (S, error) {
+	s, err := g.curve.Scalar.SetBigInt(v)
+	if err != nil {
+		return *new(S), err
+	}
+	return ScalarAs[S](s)
+}
+
+func K256Group() *Group[*ScalarK256, *PointK256] {
+	return &Group[*ScalarK256, *PointK256]{K256()}
+}
+
+func P256Group() *Group[*ScalarP256, *PointP256] {
+	return &Group[*ScalarP256, *PointP256]{P256()}
+}
+
+func P384Group() *Group[*ScalarP384, *PointP384] {
+	return &Group[*ScalarP384, *PointP384]{P384()}
+}
+
+func P521Group() *Group[*ScalarP521, *PointP521] {
+	return &Group[*ScalarP521, *PointP521]{P521()}
+}
+
+func ED25519Group() *Group[*ScalarEd25519, *PointEd25519] {
+	return &Group[*ScalarEd25519, *PointEd25519]{ED25519()}
+}
+
+func ED448Group() *Group[*ScalarEd448, *PointEd448] {
+	return &Group[*ScalarEd448, *PointEd448]{ED448()}
+}
+
+func Ristretto25519Group() *Group[*ScalarRistretto25519, *PointRistretto25519] {
+	return &Group[*ScalarRistretto25519, *PointRistretto25519]{Ristretto25519()}
+}
+
+func Decaf448Group() *Group[*ScalarDecaf448, *PointDecaf448] {
+	return &Group[*ScalarDecaf448, *PointDecaf448]{Decaf448()}
+}
+
+func PallasGroup() *Group[*ScalarPallas, *PointPallas] {
+	return &Group[*ScalarPallas, *PointPallas]{PALLAS()}
+}
+
+func VestaGroup() *Group[*ScalarVesta, *PointVesta] {
+	return &Group[*ScalarVesta, *PointVesta]{VESTA()}
+}
+
+func JubjubGroup() *Group[*ScalarJubjub, *PointJubjub] {
+	return &Group[*ScalarJubjub, *PointJubjub]{Jubjub()}
+}
+
+func BLS12381G1Group() *Group[*ScalarBls12381, *PointBls12381G1] {
+	return &Group[*ScalarBls12381, *PointBls12381G1]{BLS12381G1()}
+}
+
+func BLS12381G2Group() *Group[*ScalarBls12381, *PointBls12381G2] {
+	return &Group[*ScalarBls12381, *PointBls12381G2]{BLS12381G2()}
+}
+
+func BLS12377G1Group() *Group[*ScalarBls12377, *PointBls12377G1] {
+	return &Group[*ScalarBls12377, *PointBls12377G1]{BLS12377G1()}
+}
+
+func BLS12377G2Group() *Group[*ScalarBls12377, *PointBls12377G2] {
+	return &Group[*ScalarBls12377, *PointBls12377G2]{BLS12377G2()}
+}
+
+func BN254G1Group() *Group[*ScalarBn254, *PointBn254G1] {
+	return &Group[*ScalarBn254, *PointBn254G1]{BN254G1()}
+}
+
+func BN254G2Group() *Group[*ScalarBn254, *PointBn254G2] {
+	return &Group[*ScalarBn254, *PointBn254G2]{BN254G2()}
+}
+
+func BrainpoolP256r1Group() *Group[*ScalarBrainpoolP256r1, *PointBrainpoolP256r1] {
+	return &Group[*ScalarBrainpoolP256r1, *PointBrainpoolP256r1]{BrainpoolP256r1()}
+}
+
+func BrainpoolP384r1Group() *Group[*ScalarBrainpoolP384r1, *PointBrainpoolP384r1] {
+	return &Group[*ScalarBrainpoolP384r1, *PointBrainpoolP384r1]{BrainpoolP384r1()}
+}
+
+func BrainpoolP512r1Group() *Group[*ScalarBrainpoolP512r1, *PointBrainpoolP512r1] {
+	return &Group[*ScalarBrainpoolP512r1, *PointBrainpoolP512r1]{BrainpoolP512r1()}
+}
+
+func SM2Group() *Group[*ScalarSm2, *PointSm2] {
+	return &Group[*ScalarSm2, *PointSm2]{SM2()}
+}
+
+func Secq256k1Group() *Group[*ScalarSecq256k1, *PointSecq256k1] {
+	return &Group[*ScalarSecq256k1, *PointSecq256k1]{Secq256k1()}
+}
+
+func GrumpkinGroup() *Group[*ScalarGrumpkin, *PointGrumpkin] {
+	return &Group[*ScalarGrumpkin, *PointGrumpkin]{Grumpkin()}
+}
+
+func BandersnatchGroup() *Group[*ScalarBandersnatch, *PointBandersnatch] {
+	return &Group[*ScalarBandersnatch, *PointBandersnatch]{Bandersnatch()}
+}
+
+func BabyJubjubGroup() *Group[*ScalarBabyJubjub, *PointBabyJubjub] {
+	return &Group[*ScalarBabyJubjub, *PointBabyJubjub]{BabyJubjub()}
+}
+
+func StarkGroup() *Group[*ScalarStark, *PointStark] {
+	return &Group[*ScalarStark, *PointStark]{Stark()}
+}
@@ -0,0 +1,158 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testGroup exercises a group generically which also
+// checks that its type parameters match the curve.
+func testGroup[S Scalar, P Point](t *testing.T, g *Group[S, P]) {
+	t.Helper()
+	_, err := NewGroup[S, P](g.Curve())
+	require.NoError(t, err, g.Name())
+
+	gen := g.Generator()
+	two := g.NewScalar(2)
+	three := g.NewScalar(3)
+	require.True(t, g.Equal(g.Double(gen), g.Mul(gen, two)), g.Name())
+	require.True(t, g.Equal(g.Add(g.Double(gen), gen), g.ScalarBaseMult(three)), g.Name())
+	require.True(t, g.Sub(gen, gen).IsIdentity(), g.Name())
+	require.True(t, g.Equal(g.Neg(gen), g.Mul(gen, g.ScalarNeg(g.ScalarOne()))), g.Name())
+	require.True(t, g.Identity().IsIdentity(), g.Name())
+
+	s, err := g.RandomScalar(crand.Reader)
+	require.NoError(t, err)
+	inv, err := g.ScalarInvert(s)
+	require.NoError(t, err)
+	require.True(t, g.ScalarEqual(g.ScalarMul(s, inv), g.ScalarOne()), g.Name())
+	require.True(t, g.ScalarEqual(g.ScalarSub(g.ScalarAdd(s, two), two), s), g.Name())
+	s2, err := g.ScalarFromBytes(s.Bytes())
+	require.NoError(t, err)
+	require.True(t, g.ScalarEqual(s, s2), g.Name())
+	s2, err = g.ScalarFromBigInt(s.BigInt())
+	require.NoError(t, err)
+	require.True(t, g.ScalarEqual(s, s2), g.Name())
+
+	p, err := g.RandomPoint(crand.Reader)
+	require.NoError(t, err)
+	p2, err := g.PointFromAffineCompressed(p.ToAffineCompressed())
+	require.NoError(t, err)
+	require.True(t, g.Equal(p, p2), g.Name())
+	p2, err = g.PointFromAffineUncompressed(p.ToAffineUncompressed())
+	require.NoError(t, err)
+	require.True(t, g.Equal(p, p2), g.Name())
+	h, err := g.HashToPoint([]byte("group"))
+	require.NoError(t, err)
+	require.True(t, h.IsOnCurve(), g.Name())
+	hs, err := g.HashToScalar([]byte("group"))
+	require.NoError(t, err)
+	require.True(t, g.ScalarEqual(hs, g.Curve().Scalar.Hash([]byte("group")).(S)), g.Name())
+
+	sum, err := g.SumOfProducts([]P{gen, p}, []S{two, three})
+	require.NoError(t, err)
+	require.True(t, g.Equal(sum, g.Add(g.Mul(gen, two), g.Mul(p, three))), g.Name())
+	_, err = g.SumOfProducts([]P{gen}, nil)
+	require.Error(t, err)
+}
+
+func TestGroupCurves(t *testing.T) {
+	testGroup(t, K256Group())
+	testGroup(t, P256Group())
+	testGroup(t, P384Group())
+	testGroup(t, P521Group())
+	testGroup(t, ED25519Group())
+	testGroup(t, ED448Group())
+	testGroup(t, Ristretto25519Group())
+	testGroup(t, Decaf448Group())
+	testGroup(t, PallasGroup())
+	testGroup(t, VestaGroup())
+	testGroup(t, JubjubGroup())
+	testGroup(t, BLS12381G1Group())
+	testGroup(t, BLS12381G2Group())
+	testGroup(t, BLS12377G1Group())
+	testGroup(t, BLS12377G2Group())
+	testGroup(t, BN254G1Group())
+	testGroup(t, BN254G2Group())
+	testGroup(t, BrainpoolP256r1Group())
+	testGroup(t, BrainpoolP384r1Group())
+	testGroup(t, BrainpoolP512r1Group())
+	testGroup(t, SM2Group())
+	testGroup(t, Secq256k1Group())
+	testGroup(t, GrumpkinGroup())
+	testGroup(t, BandersnatchGroup())
+	testGroup(t, BabyJubjubGroup())
+	testGroup(t, StarkGroup())
+}
+
+func TestGroupAdapters(t *testing.T) {
+	_, err := NewGroup[*ScalarK256, *PointK256](P256())
+	require.Error(t, err)
+	_, err = NewGroup[*ScalarK256, *PointP256](K256())
+	require.Error(t, err)
+	_, err = NewGroup[*ScalarK256, *PointK256](nil)
+	require.Error(t, err)
+	g, err := NewGroup[*ScalarK256, *PointK256](GetCurveByName(K256Name))
+	require.NoError(t, err)
+
+	// Dynamic values are converted with an error instead of nil
+	pt, err := PointAs[*PointK256](K256().NewGeneratorPoint())
+	require.NoError(t, err)
+	require.True(t, g.Equal(pt, g.Generator()))
+	_, err = PointAs[*PointK256](P256().NewGeneratorPoint())
+	require.Error(t, err)
+	_, err = PointAs[*PointK256](nil)
+	require.Error(t, err)
+	sc, err := ScalarAs[*ScalarK256](K256().Scalar.New(5))
+	require.NoError(t, err)
+	require.True(t, g.ScalarEqual(sc, g.NewScalar(5)))
+	_, err = ScalarAs[*ScalarK256](P256().Scalar.New(5))
+	require.Error(t, err)
+
+	// Typed values remain usable with the dynamic interfaces
+	points := g.Points([]*PointK256{pt, pt})
+	scalars := g.Scalars([]*ScalarK256{sc, sc})
+	require.True(t, K256().Point.SumOfProducts(points, scalars).Equal(g.Mul(pt, g.NewScalar(10))))
+	bin, err := PointMarshalBinary(pt)
+	require.NoError(t, err)
+	ret, err := PointUnmarshalBinary(bin)
+	require.NoError(t, err)
+	require.True(t, pt.Equal(ret))
+
+	_, err = g.RandomScalar(nil)
+	require.Error(t, err)
+	_, err = g.RandomPoint(nil)
+	require.Error(t, err)
+}
+
+// wrappedPointK256 embeds a built-in point so its arithmetic
+// returns *PointK256 instead of the wrapper.
+type wrappedPointK256 struct {
+	*PointK256
+}
+
+func TestGroupPanics(t *testing.T) {
+	gen := K256().Point.Generator().(*PointK256)
+	curve := &Curve{Scalar: K256().Scalar, Point: &wrappedPointK256{gen}, Name: "wrapped"}
+	g, err := NewGroup[*ScalarK256, *wrappedPointK256](curve)
+	require.NoError(t, err)
+	pt := &wrappedPointK256{gen}
+	require.PanicsWithValue(t, "curvey: Group.Generator returned *curvey.PointK256 instead of *curvey.wrappedPointK256", func() {
+		g.Generator()
+	})
+	require.PanicsWithValue(t, "curvey: Group.Double returned *curvey.PointK256 instead of *curvey.wrappedPointK256", func() {
+		g.Double(pt)
+	})
+	// PointK256.Add returns nil for an rhs of another type
+	require.PanicsWithValue(t, "curvey: Group.Add returned <nil> instead of *curvey.wrappedPointK256", func() {
+		g.Add(pt, pt)
+	})
+	// The scalar methods still work as the scalar type matches
+	require.True(t, g.ScalarEqual(g.ScalarAdd(g.ScalarOne(), g.ScalarOne()), g.NewScalar(2)))
+}
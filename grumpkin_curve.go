@@ -0,0 +1,648 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+	grumpkinn "github.com/mikelodder7/curvey/native/grumpkin"
+)
+
+type ScalarGrumpkin struct {
+	value *native.Field4
+}
+
+type PointGrumpkin struct {
+	value *native.EllipticPoint4
+}
+
+func (s *ScalarGrumpkin) Random(reader io.Reader) Scalar {
+	if reader == nil {
+		return nil
+	}
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return s.Hash(seed[:])
+}
+
+func (*ScalarGrumpkin) Hash(bytes []byte) Scalar {
+	dst := []byte("grumpkin_XMD:SHA-256_SVDW_RO_")
+	xmd := native.ExpandMsgXmd(native.EllipticPointHasherSha256(), bytes, dst, 48)
+	var t [64]byte
+	copy(t[:48], internal.ReverseBytes(xmd))
+
+	return &ScalarGrumpkin{
+		value: grumpkinn.FqNew().SetBytesWide(&t),
+	}
+}
+
+func (*ScalarGrumpkin) Zero() Scalar {
+	return &ScalarGrumpkin{
+		value: grumpkinn.FqNew().SetZero(),
+	}
+}
+
+func (*ScalarGrumpkin) One() Scalar {
+	return &ScalarGrumpkin{
+		value: grumpkinn.FqNew().SetOne(),
+	}
+}
+
+func (s *ScalarGrumpkin) IsZero() bool {
+	return s.value.IsZero() == 1
+}
+
+func (s *ScalarGrumpkin) IsOne() bool {
+	return s.value.IsOne() == 1
+}
+
+func (s *ScalarGrumpkin) IsOdd() bool {
+	return s.value.Bytes()[0]&1 == 1
+}
+
+func (s *ScalarGrumpkin) IsEven() bool {
+	return s.value.Bytes()[0]&1 == 0
+}
+
+func (*ScalarGrumpkin) New(value int) Scalar {
+	t := grumpkinn.FqNew()
+	v := big.NewInt(int64(value))
+	if value < 0 {
+		v.Mod(v, t.Params.BiModulus)
+	}
+	return &ScalarGrumpkin{
+		value: t.SetBigInt(v),
+	}
+}
+
+func (s *ScalarGrumpkin) Cmp(rhs Scalar) int {
+	r, ok := rhs.(*ScalarGrumpkin)
+	if ok {
+		return s.value.Cmp(r.value)
+	} else {
+		return -2
+	}
+}
+
+func (s *ScalarGrumpkin) Square() Scalar {
+	return &ScalarGrumpkin{
+		value: grumpkinn.FqNew().Square(s.value),
+	}
+}
+
+func (s *ScalarGrumpkin) Pow(exp uint64) Scalar {
+	expFieldLimb := [native.Field4Limbs]uint64{exp, 0, 0, 0}
+	out := ScalarGrumpkin{value: grumpkinn.FqNew()}
+	native.Pow(&out.value.Value, &s.value.Value, &expFieldLimb, s.value.Params, s.value.Arithmetic)
+	return &ScalarGrumpkin{
+		value: out.value,
+	}
+}
+
+func (s *ScalarGrumpkin) Double() Scalar {
+	return &ScalarGrumpkin{
+		value: grumpkinn.FqNew().Double(s.value),
+	}
+}
+
+func (s *ScalarGrumpkin) Invert() (Scalar, error) {
+	value, wasInverted := grumpkinn.FqNew().Invert(s.value)
+	if !wasInverted {
+		return nil, fmt.Errorf("inverse doesn't exist")
+	}
+	return &ScalarGrumpkin{
+		value,
+	}, nil
+}
+
+func (s *ScalarGrumpkin) Sqrt() (Scalar, error) {
+	value, wasSquare := grumpkinn.FqNew().Sqrt(s.value)
+	if !wasSquare {
+		return nil, fmt.Errorf("not a square")
+	}
+	return &ScalarGrumpkin{
+		value,
+	}, nil
+}
+
+func (s *ScalarGrumpkin) Cube() Scalar {
+	value := grumpkinn.FqNew().Square(s.value)
+	value.Mul(value, s.value)
+	return &ScalarGrumpkin{
+		value,
+	}
+}
+
+func (s *ScalarGrumpkin) Add(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarGrumpkin)
+	if ok {
+		return &ScalarGrumpkin{
+			value: grumpkinn.FqNew().Add(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarGrumpkin) Sub(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarGrumpkin)
+	if ok {
+		return &ScalarGrumpkin{
+			value: grumpkinn.FqNew().Sub(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarGrumpkin) Mul(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarGrumpkin)
+	if ok {
+		return &ScalarGrumpkin{
+			value: grumpkinn.FqNew().Mul(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarGrumpkin) MulAdd(y, z Scalar) Scalar {
+	return s.Mul(y).Add(z)
+}
+
+func (s *ScalarGrumpkin) Div(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarGrumpkin)
+	if ok {
+		v, wasInverted := grumpkinn.FqNew().Invert(r.value)
+		if !wasInverted {
+			return nil
+		}
+		v.Mul(v, s.value)
+		return &ScalarGrumpkin{value: v}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarGrumpkin) Neg() Scalar {
+	return &ScalarGrumpkin{
+		value: grumpkinn.FqNew().Neg(s.value),
+	}
+}
+
+func (*ScalarGrumpkin) SetBigInt(v *big.Int) (Scalar, error) {
+	if v == nil {
+		return nil, fmt.Errorf("'v' cannot be nil")
+	}
+	value := grumpkinn.FqNew().SetBigInt(v)
+	return &ScalarGrumpkin{
+		value,
+	}, nil
+}
+
+func (s *ScalarGrumpkin) BigInt() *big.Int {
+	return s.value.BigInt()
+}
+
+func (s *ScalarGrumpkin) Bytes() []byte {
+	t := s.value.Bytes()
+	return internal.ReverseBytes(t[:])
+}
+
+func (*ScalarGrumpkin) SetBytes(bytes []byte) (Scalar, error) {
+	if len(bytes) != 32 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [32]byte
+	copy(seq[:], internal.ReverseBytes(bytes))
+	value, err := grumpkinn.FqNew().SetBytes(&seq)
+	if err != nil {
+		return nil, err
+	}
+	return &ScalarGrumpkin{
+		value,
+	}, nil
+}
+
+func (*ScalarGrumpkin) SetBytesWide(bytes []byte) (Scalar, error) {
+	if len(bytes) != 64 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [64]byte
+	copy(seq[:], bytes)
+	return &ScalarGrumpkin{
+		value: grumpkinn.FqNew().SetBytesWide(&seq),
+	}, nil
+}
+
+func (*ScalarGrumpkin) Point() Point {
+	return new(PointGrumpkin).Identity()
+}
+
+func (s *ScalarGrumpkin) Clone() Scalar {
+	return &ScalarGrumpkin{
+		value: grumpkinn.FqNew().Set(s.value),
+	}
+}
+
+func (s *ScalarGrumpkin) MarshalBinary() ([]byte, error) {
+	return ScalarMarshalBinary(s)
+}
+
+func (s *ScalarGrumpkin) UnmarshalBinary(input []byte) error {
+	sc, err := ScalarUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarGrumpkin)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarGrumpkin) MarshalText() ([]byte, error) {
+	return ScalarMarshalText(s)
+}
+
+func (s *ScalarGrumpkin) UnmarshalText(input []byte) error {
+	sc, err := ScalarUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarGrumpkin)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarGrumpkin) MarshalJSON() ([]byte, error) {
+	return ScalarMarshalJSON(s)
+}
+
+func (s *ScalarGrumpkin) UnmarshalJSON(input []byte) error {
+	sc, err := ScalarUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	S, ok := sc.(*ScalarGrumpkin)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	s.value = S.value
+	return nil
+}
+
+func (p *PointGrumpkin) Random(reader io.Reader) Point {
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return p.Hash(seed[:])
+}
+
+func (*PointGrumpkin) Hash(bytes []byte) Point {
+	// Grumpkin has no SSWU suite so the dst names the
+	// Shallue-van de Woestijne map used by the native point
+	dst := []byte("grumpkin_XMD:SHA-256_SVDW_RO_")
+	value := grumpkinn.PointNew()
+	err := value.Arithmetic.Hash(value, native.EllipticPointHasherSha256(), bytes, dst)
+	// TODO: change hash to return an error also
+	if err != nil {
+		return nil
+	}
+
+	return &PointGrumpkin{value}
+}
+
+func (*PointGrumpkin) Identity() Point {
+	return &PointGrumpkin{
+		value: grumpkinn.PointNew().Identity(),
+	}
+}
+
+func (*PointGrumpkin) Generator() Point {
+	return &PointGrumpkin{
+		value: grumpkinn.PointNew().Generator(),
+	}
+}
+
+func (p *PointGrumpkin) IsIdentity() bool {
+	return p.value.IsIdentity()
+}
+
+func (p *PointGrumpkin) IsNegative() bool {
+	return p.value.GetY().Value[0]&1 == 1
+}
+
+func (p *PointGrumpkin) IsOnCurve() bool {
+	return p.value.IsOnCurve()
+}
+
+func (p *PointGrumpkin) Double() Point {
+	value := grumpkinn.PointNew().Double(p.value)
+	return &PointGrumpkin{value}
+}
+
+func (*PointGrumpkin) Scalar() Scalar {
+	return new(ScalarGrumpkin).Zero()
+}
+
+func (p *PointGrumpkin) Neg() Point {
+	value := grumpkinn.PointNew().Neg(p.value)
+	return &PointGrumpkin{value}
+}
+
+func (p *PointGrumpkin) Add(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointGrumpkin)
+	if ok {
+		value := grumpkinn.PointNew().Add(p.value, r.value)
+		return &PointGrumpkin{value}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointGrumpkin) Sub(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointGrumpkin)
+	if ok {
+		value := grumpkinn.PointNew().Sub(p.value, r.value)
+		return &PointGrumpkin{value}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointGrumpkin) Mul(rhs Scalar) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*ScalarGrumpkin)
+	if ok {
+		value := grumpkinn.PointNew().Mul(p.value, r.value)
+		return &PointGrumpkin{value}
+	} else {
+		return nil
+	}
+}
+
+func (*PointGrumpkin) selectPoint(row []Point, index int) Point {
+	value := grumpkinn.PointNew().Identity()
+	for i, pt := range row {
+		value.CMove(value, pt.(*PointGrumpkin).value, ctEqual(i+1, index))
+	}
+	return &PointGrumpkin{value}
+}
+
+func (p *PointGrumpkin) Equal(rhs Point) bool {
+	r, ok := rhs.(*PointGrumpkin)
+	if ok {
+		return p.value.Equal(r.value) == 1
+	} else {
+		return false
+	}
+}
+
+func (*PointGrumpkin) Set(x, y *big.Int) (Point, error) {
+	value, err := grumpkinn.PointNew().SetBigInt(x, y)
+	if err != nil {
+		return nil, err
+	}
+	return &PointGrumpkin{value}, nil
+}
+
+func (p *PointGrumpkin) ToAffineCompressed() []byte {
+	var x [33]byte
+	x[0] = byte(2)
+
+	t := grumpkinn.PointNew().ToAffine(p.value)
+
+	x[0] |= t.Y.Bytes()[0] & 1
+
+	xBytes := t.X.Bytes()
+	copy(x[1:], internal.ReverseBytes(xBytes[:]))
+	return x[:]
+}
+
+func (p *PointGrumpkin) ToAffineUncompressed() []byte {
+	var out [65]byte
+	out[0] = byte(4)
+	t := grumpkinn.PointNew().ToAffine(p.value)
+	arr := t.X.Bytes()
+	copy(out[1:33], internal.ReverseBytes(arr[:]))
+	arr = t.Y.Bytes()
+	copy(out[33:], internal.ReverseBytes(arr[:]))
+	return out[:]
+}
+
+func (p *PointGrumpkin) FromAffineCompressed(bytes []byte) (Point, error) {
+	var raw [native.Field4Bytes]byte
+	if len(bytes) != 33 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	sign := int(bytes[0])
+	if sign != 2 && sign != 3 {
+		return nil, fmt.Errorf("invalid sign byte")
+	}
+	sign &= 0x1
+
+	copy(raw[:], internal.ReverseBytes(bytes[1:]))
+	x, err := grumpkinn.FpNew().SetBytes(&raw)
+	if err != nil {
+		return nil, err
+	}
+
+	value := grumpkinn.PointNew().Identity()
+	rhs := grumpkinn.FpNew()
+	p.value.Arithmetic.RhsEquation(rhs, x)
+	// test that rhs is quadratic residue
+	// if not, then this Point is at infinity
+	y, wasQr := grumpkinn.FpNew().Sqrt(rhs)
+	if wasQr {
+		// fix the sign
+		sigY := int(y.Bytes()[0] & 1)
+		if sigY != sign {
+			y.Neg(y)
+		}
+		value.X = x
+		value.Y = y
+		value.Z.SetOne()
+	}
+	return &PointGrumpkin{value}, nil
+}
+
+func (*PointGrumpkin) FromAffineUncompressed(bytes []byte) (Point, error) {
+	var arr [native.Field4Bytes]byte
+	if len(bytes) != 65 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	if bytes[0] != 4 {
+		return nil, fmt.Errorf("invalid sign byte")
+	}
+
+	copy(arr[:], internal.ReverseBytes(bytes[1:33]))
+	x, err := grumpkinn.FpNew().SetBytes(&arr)
+	if err != nil {
+		return nil, err
+	}
+	copy(arr[:], internal.ReverseBytes(bytes[33:]))
+	y, err := grumpkinn.FpNew().SetBytes(&arr)
+	if err != nil {
+		return nil, err
+	}
+	value := grumpkinn.PointNew()
+	value.X = x
+	value.Y = y
+	value.Z.SetOne()
+	return &PointGrumpkin{value}, nil
+}
+
+func (*PointGrumpkin) CurveName() string {
+	return GrumpkinName
+}
+
+func (*PointGrumpkin) SumOfProducts(points []Point, scalars []Scalar) Point {
+	nPoints := make([]*native.EllipticPoint4, len(points))
+	nScalars := make([]*native.Field4, len(scalars))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointGrumpkin)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarGrumpkin)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.value
+	}
+	value := grumpkinn.PointNew()
+	_, err := value.SumOfProducts(nPoints, nScalars)
+	if err != nil {
+		return nil
+	}
+	return &PointGrumpkin{value}
+}
+
+func (*PointGrumpkin) sumOfProductsVarTime(points []Point, scalars []Scalar, opts *MsmOptions) Point {
+	nPoints := make([]*native.EllipticPoint4, len(points))
+	nScalars := make([]*native.Field4, len(scalars))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointGrumpkin)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarGrumpkin)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.value
+	}
+	value := grumpkinn.PointNew()
+	_, err := value.MsmVarTime(nPoints, nScalars, opts)
+	if err != nil {
+		return nil
+	}
+	return &PointGrumpkin{value}
+}
+
+func (p *PointGrumpkin) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point {
+	return p.VarTimeMultiScalarMult([]Scalar{a, b}, []Point{capA, p.Generator()})
+}
+
+func (p *PointGrumpkin) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	return p.sumOfProductsVarTime(points, scalars, nil)
+}
+
+func (*PointGrumpkin) batchNormalize(points []Point) []Point {
+	nPoints := make([]*native.EllipticPoint4, len(points))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointGrumpkin)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	out := make([]Point, len(points))
+	for i, value := range native.BatchToAffine(nPoints) {
+		out[i] = &PointGrumpkin{value}
+	}
+	return out
+}
+
+func (p *PointGrumpkin) X() *native.Field4 {
+	return p.value.GetX()
+}
+
+func (p *PointGrumpkin) Y() *native.Field4 {
+	return p.value.GetY()
+}
+
+func (p *PointGrumpkin) MarshalBinary() ([]byte, error) {
+	return PointMarshalBinary(p)
+}
+
+func (p *PointGrumpkin) UnmarshalBinary(input []byte) error {
+	pt, err := PointUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointGrumpkin)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointGrumpkin) MarshalText() ([]byte, error) {
+	return PointMarshalText(p)
+}
+
+func (p *PointGrumpkin) UnmarshalText(input []byte) error {
+	pt, err := PointUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointGrumpkin)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointGrumpkin) MarshalJSON() ([]byte, error) {
+	return PointMarshalJSON(p)
+}
+
+func (p *PointGrumpkin) UnmarshalJSON(input []byte) error {
+	pt, err := PointUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	P, ok := pt.(*PointGrumpkin)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	p.value = P.value
+	return nil
+}
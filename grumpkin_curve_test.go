@@ -0,0 +1,208 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"bytes"
+	crand "crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// The known answer vectors in this file were produced with the grumpkin
+// package of gnark-crypto v0.21.0 (ecc/grumpkin), which shares the curve
+// parameters used by aztec and barretenberg.
+
+// TestGrumpkinBn254Cycle checks that Grumpkin and BN254 form a cycle:
+// the Grumpkin base field is the BN254 scalar field and the Grumpkin scalar
+// field is the BN254 base field.
+func TestGrumpkinBn254Cycle(t *testing.T) {
+	grumpkin := Grumpkin()
+	bn254 := BN254G1()
+
+	// The Grumpkin group order is the BN254 base field modulus
+	p := new(big.Int).Sub(bn254modulus, big.NewInt(1))
+	pm1, err := grumpkin.Scalar.SetBigInt(p)
+	require.NoError(t, err)
+	require.Equal(t, 0, pm1.Add(grumpkin.Scalar.One()).Cmp(grumpkin.Scalar.Zero()))
+	g := grumpkin.Point.Generator()
+	require.True(t, g.Mul(pm1).Equal(g.Neg()))
+
+	// Grumpkin coordinates are BN254 scalars: y^2 = x^3 - 17
+	for i := 0; i < 10; i++ {
+		pt := grumpkin.Point.Random(crand.Reader).(*PointGrumpkin)
+		x, err := bn254.Scalar.SetBigInt(pt.X().BigInt())
+		require.NoError(t, err)
+		y, err := bn254.Scalar.SetBigInt(pt.Y().BigInt())
+		require.NoError(t, err)
+		require.Equal(t, 0, y.Square().Cmp(x.Cube().Sub(bn254.Scalar.New(17))))
+	}
+
+	// BN254 coordinates are Grumpkin scalars: y^2 = x^3 + 3
+	for i := 0; i < 10; i++ {
+		pt := bn254.Point.Random(crand.Reader).(*PointBn254G1)
+		x, err := grumpkin.Scalar.SetBigInt(pt.X())
+		require.NoError(t, err)
+		y, err := grumpkin.Scalar.SetBigInt(pt.Y())
+		require.NoError(t, err)
+		require.Equal(t, 0, y.Square().Cmp(x.Cube().Add(grumpkin.Scalar.New(3))))
+	}
+}
+
+func TestPointGrumpkinGenerator(t *testing.T) {
+	grumpkin := Grumpkin()
+	g, ok := grumpkin.Point.Generator().(*PointGrumpkin)
+	require.True(t, ok)
+	require.True(t, g.IsOnCurve())
+	require.Equal(t, g.X().BigInt(), bhex("0000000000000000000000000000000000000000000000000000000000000001"))
+	require.Equal(t, g.Y().BigInt(), bhex("0000000000000002cf135e7506a45d632d270d45f1181294833fc48d823f272c"))
+}
+
+func TestPointGrumpkinMul(t *testing.T) {
+	tests := []struct {
+		k, x, y string
+	}{
+		{
+			k: "2",
+			x: "06ce1b0827aafa85ddeb49cdaa36306d19a74caa311e13d46d8bc688cdbffffe",
+			y: "1c122f81a3a14964909ede0ba2a6855fc93faf6fa1a788bf467be7e7a43f80ac",
+		},
+		{
+			k: "3",
+			x: "2941b0928df1b9480273773b36397da3e495430a2a7a3857661bc7a446c94f4d",
+			y: "13ae7e938c892308bef0f45ee7386daa2d3b447349a7d0a11b5aa4cfbe69072c",
+		},
+		{
+			k: "1234567890abcdef",
+			x: "08e5bc23e059847cce37e1a0f084b7b63b2348112c64171a0850df1148fc688a",
+			y: "01241a0d45704280a46be699c4bb8299ccc0f5013eb76ce8ef4158e82e43ece2",
+		},
+		{
+			k: "1234567890abcdef1234567890abcdef",
+			x: "1fabcca293079b334eb61a8722117efe3c6b87606f37d6a83e4d19ebfbe4c98f",
+			y: "1023d712d25ae5e14485bb646c65a1314833acce095a73b76cb0d7e70583cfb6",
+		},
+	}
+	grumpkin := Grumpkin()
+	for _, tt := range tests {
+		k, err := grumpkin.Scalar.SetBigInt(bhex(tt.k))
+		require.NoError(t, err)
+		pt := grumpkin.ScalarBaseMult(k).(*PointGrumpkin)
+		require.Equal(t, pt.X().BigInt(), bhex(tt.x))
+		require.Equal(t, pt.Y().BigInt(), bhex(tt.y))
+		require.True(t, grumpkin.Point.Generator().Mul(k).Equal(pt))
+	}
+}
+
+func TestPointGrumpkinHash(t *testing.T) {
+	tests := []struct {
+		msg  []byte
+		x, y string
+	}{
+		{
+			msg: []byte{},
+			x:   "24994c0773919a627170dee25bed1c810e5193fa967f2ff8ca3334824723deb9",
+			y:   "26bbf6f557119be6d44eb44bdff47fa0941e7fa83e522cc785b483be32c3dff9",
+		},
+		{
+			msg: []byte("abc"),
+			x:   "164738e5f11a67c436955f48393ef574a26e607c4e46147c22b1b5778bf4da87",
+			y:   "0b9ac75ae6b222720383b5cf3f15e9c50232b38c6cd49b1f6c9f3ee4a70dec6f",
+		},
+		{
+			msg: make([]byte, 32),
+			x:   "133635b1c4f6a0f7db40cb83c72bbc37b5c9a0132d9b5ad93aac44becc299423",
+			y:   "226d593c54ff7c7de0180e9e5ea254bbed2244c83579211ee1e89e35a18acad9",
+		},
+	}
+	grumpkin := Grumpkin()
+	for _, tt := range tests {
+		pt, ok := grumpkin.Point.Hash(tt.msg).(*PointGrumpkin)
+		require.True(t, ok)
+		require.Equal(t, pt.X().BigInt(), bhex(tt.x))
+		require.Equal(t, pt.Y().BigInt(), bhex(tt.y))
+	}
+
+	// Random hashes 64 bytes read from the reader
+	pt, ok := grumpkin.Point.Random(testRng()).(*PointGrumpkin)
+	require.True(t, ok)
+	require.Equal(t, pt.X().BigInt(), bhex("18b29e9b998e6b55274b7c98e5a7cde74132e65457200626bc187f89623a787b"))
+	require.Equal(t, pt.Y().BigInt(), bhex("3014a29cb1b6dbb8581ffc2757b0393eea79cb73e356f7292fadd07889ea6b39"))
+}
+
+func TestScalarGrumpkinHash(t *testing.T) {
+	tests := []struct {
+		msg      []byte
+		expected string
+	}{
+		{[]byte{}, "2bbec42d39dc3c7a146642e778e56ca55d1afb2ad9ca146a7187714fdaaab4c2"},
+		{[]byte("abc"), "08972d610186eecd86989cf7a98448e23a67979a51623e4fe560100cd47fdb82"},
+		{make([]byte, 32), "0e84fdf56651e352cce082398778ec418d7d5291446bc0bc8c2b14a8255c627d"},
+	}
+	grumpkin := Grumpkin()
+	for _, tt := range tests {
+		s, ok := grumpkin.Scalar.Hash(tt.msg).(*ScalarGrumpkin)
+		require.True(t, ok)
+		require.Equal(t, s.value.BigInt(), bhex(tt.expected))
+	}
+
+	s, ok := grumpkin.Scalar.Random(testRng()).(*ScalarGrumpkin)
+	require.True(t, ok)
+	require.Equal(t, s.value.BigInt(), bhex("06921f378f7cc0804a31824e47ea8ef8f4c76624863e5f98c27f2ae9e6331ca8"))
+}
+
+func TestPointGrumpkinSerialize(t *testing.T) {
+	grumpkin := Grumpkin()
+	g := grumpkin.Point.Generator()
+
+	// SEC1 style: a parity tag then the big endian x coordinate
+	expected := make([]byte, 33)
+	expected[0] = 0x02
+	expected[32] = 0x01
+	require.Equal(t, expected, g.ToAffineCompressed())
+	expected[0] = 0x03
+	require.Equal(t, expected, g.Neg().ToAffineCompressed())
+
+	un := g.ToAffineUncompressed()
+	require.Equal(t, 65, len(un))
+	require.Equal(t, byte(0x04), un[0])
+	require.Equal(t, bhex("0000000000000000000000000000000000000000000000000000000000000001"), new(big.Int).SetBytes(un[1:33]))
+	require.Equal(t, bhex("0000000000000002cf135e7506a45d632d270d45f1181294833fc48d823f272c"), new(big.Int).SetBytes(un[33:]))
+
+	k, err := grumpkin.Scalar.SetBigInt(bhex("1234567890abcdef"))
+	require.NoError(t, err)
+	pt := g.Mul(k)
+	cmprs := pt.ToAffineCompressed()
+	require.Equal(t, byte(0x02), cmprs[0])
+	require.Equal(t, bhex("08e5bc23e059847cce37e1a0f084b7b63b2348112c64171a0850df1148fc688a"), new(big.Int).SetBytes(cmprs[1:]))
+	require.True(t, bytes.Equal(cmprs[1:], pt.ToAffineUncompressed()[1:33]))
+	retP, err := pt.FromAffineCompressed(cmprs)
+	require.NoError(t, err)
+	require.True(t, pt.Equal(retP))
+	retP, err = pt.FromAffineUncompressed(pt.ToAffineUncompressed())
+	require.NoError(t, err)
+	require.True(t, pt.Equal(retP))
+
+	// The tag bytes of the other encoding are rejected
+	_, err = pt.FromAffineCompressed(append([]byte{0x04}, cmprs[1:]...))
+	require.Error(t, err)
+	_, err = pt.FromAffineUncompressed(append([]byte{0x02}, pt.ToAffineUncompressed()[1:]...))
+	require.Error(t, err)
+	_, err = pt.FromAffineCompressed(cmprs[:32])
+	require.Error(t, err)
+}
+
+func TestPointGrumpkinGetCurveByName(t *testing.T) {
+	curve := GetCurveByName(GrumpkinName)
+	require.NotNil(t, curve)
+	g := curve.Point.Generator()
+	bin, err := PointMarshalBinary(g)
+	require.NoError(t, err)
+	pt, err := PointUnmarshalBinary(bin)
+	require.NoError(t, err)
+	require.True(t, g.Equal(pt))
+}
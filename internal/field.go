@@ -98,6 +98,9 @@ func (f *FieldParams) newFromBytes(beModulusBytes []byte) (*FieldParams, error)
 
 	// Non-square value in field
 	biC4 := new(big.Int).SetInt64(7)
+	for big.Jacobi(biC4, f.BiModulus) != -1 {
+		biC4.Add(biC4, one)
+	}
 
 	biC5 := new(big.Int).Exp(biC4, biC2, f.BiModulus)
 	biC5.Mul(biC5, R)
@@ -136,7 +139,26 @@ func (f *FieldParams) Add(out, arg1, arg2 *[]uint64) {
 
 	// Subtract the modulus to ensure the value
 	// is smaller.
-	f.Sub(out, &t, &f.Modulus)
+	f.reduce(out, t, carry)
+}
+
+// reduce computes (carry:t) - modulus and keeps t if
+// the subtraction underflowed. The carry is the bit that overflowed
+// the top limb which happens for moduli close to 2^(64*limbs).
+func (f *FieldParams) reduce(out *[]uint64, t []uint64, carry uint64) {
+	d := make([]uint64, f.Limbs)
+	var borrow uint64
+
+	for i := 0; i < f.Limbs; i++ {
+		d[i], borrow = sbb(t[i], f.Modulus[i], borrow)
+	}
+	// The subtraction underflowed only if there was a borrow
+	// and no carry to absorb it.
+	mask := -(borrow &^ carry)
+
+	for i := 0; i < f.Limbs; i++ {
+		(*out)[i] = d[i] ^ ((d[i] ^ t[i]) & mask)
+	}
 }
 
 func (f *FieldParams) Sub(out, arg1, arg2 *[]uint64) {
@@ -301,8 +323,7 @@ func (f *FieldParams) montReduce(r []uint64) []uint64 {
 		}
 		r[i+f.Limbs], carry2 = adc(r[i+f.Limbs], carry2, carry)
 	}
-	copy(out, r[f.Limbs:])
-	f.Sub(&out, &out, &f.Modulus)
+	f.reduce(&out, r[f.Limbs:], carry2)
 	return out
 }
 
@@ -434,6 +455,9 @@ func (f *Field) SetBigInt(input *big.Int) *Field {
 
 // Set copies all from the other field into this one
 func (f *Field) Set(a *Field) *Field {
+	if len(f.Value) != len(a.Value) {
+		f.Value = make([]uint64, len(a.Value))
+	}
 	copy(f.Value, a.Value)
 	f.Arithmetic = a.Arithmetic
 	f.Params = a.Params
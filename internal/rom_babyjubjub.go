@@ -0,0 +1,20 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package internal
+
+import "sync"
+
+var (
+	babyJubjubFqInitonce sync.Once
+	babyJubjubFqParams   FieldParams
+)
+
+// BabyJubjubFqParams returns the parameters of the baby jubjub prime order subgroup field.
+func BabyJubjubFqParams() *FieldParams {
+	babyJubjubFqInitonce.Do(func() {
+		_, _ = babyJubjubFqParams.newFromHex("060c89ce5c263405370a08b6d0302b0bab3eedb83920ee0a677297dc392126f1")
+	})
+	return &babyJubjubFqParams
+}
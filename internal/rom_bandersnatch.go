@@ -0,0 +1,20 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package internal
+
+import "sync"
+
+var (
+	bandersnatchFqInitonce sync.Once
+	bandersnatchFqParams   FieldParams
+)
+
+// BandersnatchFqParams returns the parameters of the bandersnatch prime order subgroup field.
+func BandersnatchFqParams() *FieldParams {
+	bandersnatchFqInitonce.Do(func() {
+		_, _ = bandersnatchFqParams.newFromHex("1cfb69d4ca675f520cce760202687600ff8f87007419047174fd06b52876e7e1")
+	})
+	return &bandersnatchFqParams
+}
@@ -0,0 +1,23 @@
+package internal
+
+import (
+	"math/big"
+	"sync"
+)
+
+var bls12377R = new(big.Int).SetBytes([]byte{
+	0x12, 0xab, 0x65, 0x5e, 0x9a, 0x2c, 0xa5, 0x56, 0x60, 0xb4, 0x4d, 0x1e, 0x5c, 0x37, 0xb0, 0x01, 0x59, 0xaa, 0x76, 0xfe, 0xd0, 0x00, 0x00, 0x01, 0x0a, 0x11, 0x80, 0x00, 0x00, 0x00, 0x00, 0x01,
+})
+
+var (
+	bls12377FqInitonce sync.Once
+	bls12377FqParams   FieldParams
+)
+
+// Bls12377FqParams returns the parameters of the bls12-377 scalar field.
+func Bls12377FqParams() *FieldParams {
+	bls12377FqInitonce.Do(func() {
+		_, _ = bls12377FqParams.newFromBytes(bls12377R.Bytes())
+	})
+	return &bls12377FqParams
+}
@@ -0,0 +1,37 @@
+package internal
+
+import (
+	"math/big"
+	"sync"
+)
+
+var bn254Q = new(big.Int).SetBytes([]byte{
+	0x30, 0x64, 0x4e, 0x72, 0xe1, 0x31, 0xa0, 0x29, 0xb8, 0x50, 0x45, 0xb6, 0x81, 0x81, 0x58, 0x5d, 0x28, 0x33, 0xe8, 0x48, 0x79, 0xb9, 0x70, 0x91, 0x43, 0xe1, 0xf5, 0x93, 0xf0, 0x00, 0x00, 0x01,
+})
+
+var bn254P = new(big.Int).SetBytes([]byte{
+	0x30, 0x64, 0x4e, 0x72, 0xe1, 0x31, 0xa0, 0x29, 0xb8, 0x50, 0x45, 0xb6, 0x81, 0x81, 0x58, 0x5d, 0x97, 0x81, 0x6a, 0x91, 0x68, 0x71, 0xca, 0x8d, 0x3c, 0x20, 0x8c, 0x16, 0xd8, 0x7c, 0xfd, 0x47,
+})
+
+var (
+	bn254FqInitonce sync.Once
+	bn254FqParams   FieldParams
+	bn254FpInitonce sync.Once
+	bn254FpParams   FieldParams
+)
+
+// Bn254FqParams returns the parameters of the bn254 scalar field.
+func Bn254FqParams() *FieldParams {
+	bn254FqInitonce.Do(func() {
+		_, _ = bn254FqParams.newFromBytes(bn254Q.Bytes())
+	})
+	return &bn254FqParams
+}
+
+// Bn254FpParams returns the parameters of the bn254 base field.
+func Bn254FpParams() *FieldParams {
+	bn254FpInitonce.Do(func() {
+		_, _ = bn254FpParams.newFromBytes(bn254P.Bytes())
+	})
+	return &bn254FpParams
+}
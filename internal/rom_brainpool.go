@@ -0,0 +1,66 @@
+package internal
+
+import "sync"
+
+var (
+	brainpoolP256r1FpInitonce sync.Once
+	brainpoolP256r1FpParams   FieldParams
+	brainpoolP256r1FqInitonce sync.Once
+	brainpoolP256r1FqParams   FieldParams
+	brainpoolP384r1FpInitonce sync.Once
+	brainpoolP384r1FpParams   FieldParams
+	brainpoolP384r1FqInitonce sync.Once
+	brainpoolP384r1FqParams   FieldParams
+	brainpoolP512r1FpInitonce sync.Once
+	brainpoolP512r1FpParams   FieldParams
+	brainpoolP512r1FqInitonce sync.Once
+	brainpoolP512r1FqParams   FieldParams
+)
+
+// BrainpoolP256r1FpParams returns the parameters of the brainpoolP256r1 base field.
+func BrainpoolP256r1FpParams() *FieldParams {
+	brainpoolP256r1FpInitonce.Do(func() {
+		_, _ = brainpoolP256r1FpParams.newFromHex("a9fb57dba1eea9bc3e660a909d838d726e3bf623d52620282013481d1f6e5377")
+	})
+	return &brainpoolP256r1FpParams
+}
+
+// BrainpoolP256r1FqParams returns the parameters of the brainpoolP256r1 scalar field.
+func BrainpoolP256r1FqParams() *FieldParams {
+	brainpoolP256r1FqInitonce.Do(func() {
+		_, _ = brainpoolP256r1FqParams.newFromHex("a9fb57dba1eea9bc3e660a909d838d718c397aa3b561a6f7901e0e82974856a7")
+	})
+	return &brainpoolP256r1FqParams
+}
+
+// BrainpoolP384r1FpParams returns the parameters of the brainpoolP384r1 base field.
+func BrainpoolP384r1FpParams() *FieldParams {
+	brainpoolP384r1FpInitonce.Do(func() {
+		_, _ = brainpoolP384r1FpParams.newFromHex("8cb91e82a3386d280f5d6f7e50e641df152f7109ed5456b412b1da197fb71123acd3a729901d1a71874700133107ec53")
+	})
+	return &brainpoolP384r1FpParams
+}
+
+// BrainpoolP384r1FqParams returns the parameters of the brainpoolP384r1 scalar field.
+func BrainpoolP384r1FqParams() *FieldParams {
+	brainpoolP384r1FqInitonce.Do(func() {
+		_, _ = brainpoolP384r1FqParams.newFromHex("8cb91e82a3386d280f5d6f7e50e641df152f7109ed5456b31f166e6cac0425a7cf3ab6af6b7fc3103b883202e9046565")
+	})
+	return &brainpoolP384r1FqParams
+}
+
+// BrainpoolP512r1FpParams returns the parameters of the brainpoolP512r1 base field.
+func BrainpoolP512r1FpParams() *FieldParams {
+	brainpoolP512r1FpInitonce.Do(func() {
+		_, _ = brainpoolP512r1FpParams.newFromHex("aadd9db8dbe9c48b3fd4e6ae33c9fc07cb308db3b3c9d20ed6639cca703308717d4d9b009bc66842aecda12ae6a380e62881ff2f2d82c68528aa6056583a48f3")
+	})
+	return &brainpoolP512r1FpParams
+}
+
+// BrainpoolP512r1FqParams returns the parameters of the brainpoolP512r1 scalar field.
+func BrainpoolP512r1FqParams() *FieldParams {
+	brainpoolP512r1FqInitonce.Do(func() {
+		_, _ = brainpoolP512r1FqParams.newFromHex("aadd9db8dbe9c48b3fd4e6ae33c9fc07cb308db3b3c9d20ed6639cca70330870553e5c414ca92619418661197fac10471db1d381085ddaddb58796829ca90069")
+	})
+	return &brainpoolP512r1FqParams
+}
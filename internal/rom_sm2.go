@@ -0,0 +1,30 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package internal
+
+import "sync"
+
+var (
+	sm2FpInitonce sync.Once
+	sm2FpParams   FieldParams
+	sm2FqInitonce sync.Once
+	sm2FqParams   FieldParams
+)
+
+// Sm2FpParams returns the parameters of the SM2 base field.
+func Sm2FpParams() *FieldParams {
+	sm2FpInitonce.Do(func() {
+		_, _ = sm2FpParams.newFromHex("fffffffeffffffffffffffffffffffffffffffff00000000ffffffffffffffff")
+	})
+	return &sm2FpParams
+}
+
+// Sm2FqParams returns the parameters of the SM2 scalar field.
+func Sm2FqParams() *FieldParams {
+	sm2FqInitonce.Do(func() {
+		_, _ = sm2FqParams.newFromHex("fffffffeffffffffffffffffffffffff7203df6b21c6052b53bbf40939d54123")
+	})
+	return &sm2FqParams
+}
@@ -0,0 +1,30 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package internal
+
+import "sync"
+
+var (
+	starkFpInitonce sync.Once
+	starkFpParams   FieldParams
+	starkFqInitonce sync.Once
+	starkFqParams   FieldParams
+)
+
+// StarkFpParams returns the parameters of the STARK curve base field.
+func StarkFpParams() *FieldParams {
+	starkFpInitonce.Do(func() {
+		_, _ = starkFpParams.newFromHex("0800000000000011000000000000000000000000000000000000000000000001")
+	})
+	return &starkFpParams
+}
+
+// StarkFqParams returns the parameters of the STARK curve scalar field.
+func StarkFqParams() *FieldParams {
+	starkFqInitonce.Do(func() {
+		_, _ = starkFqParams.newFromHex("0800000000000010ffffffffffffffffb781126dcae7b2321e66a241adc64d2f")
+	})
+	return &starkFqParams
+}
@@ -0,0 +1,639 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/mikelodder7/curvey/native"
+	"github.com/mikelodder7/curvey/native/bls12381"
+	jubjubn "github.com/mikelodder7/curvey/native/jubjub"
+)
+
+// ScalarJubjub is an element of the field defined by the
+// order of the jubjub prime order subgroup.
+type ScalarJubjub struct {
+	Value *native.Field4
+}
+
+func (s *ScalarJubjub) Random(reader io.Reader) Scalar {
+	if reader == nil {
+		return nil
+	}
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return s.Hash(seed[:])
+}
+
+func (*ScalarJubjub) Hash(bytes []byte) Scalar {
+	dst := []byte("jubjub_XMD:BLAKE2b_ELL2_RO_")
+	xmd := native.ExpandMsgXmd(native.EllipticPointHasherBlake2b(), bytes, dst, 64)
+	var t [64]byte
+	copy(t[:], xmd)
+	return &ScalarJubjub{
+		Value: jubjubn.FqNew().SetBytesWide(&t),
+	}
+}
+
+func (*ScalarJubjub) Zero() Scalar {
+	return &ScalarJubjub{
+		Value: jubjubn.FqNew().SetZero(),
+	}
+}
+
+func (*ScalarJubjub) One() Scalar {
+	return &ScalarJubjub{
+		Value: jubjubn.FqNew().SetOne(),
+	}
+}
+
+func (s *ScalarJubjub) IsZero() bool {
+	return s.Value.IsZero() == 1
+}
+
+func (s *ScalarJubjub) IsOne() bool {
+	return s.Value.IsOne() == 1
+}
+
+func (s *ScalarJubjub) IsOdd() bool {
+	return (s.Value.Bytes()[0] & 1) == 1
+}
+
+func (s *ScalarJubjub) IsEven() bool {
+	return (s.Value.Bytes()[0] & 1) == 0
+}
+
+func (*ScalarJubjub) New(value int) Scalar {
+	v := big.NewInt(int64(value))
+	return &ScalarJubjub{
+		Value: jubjubn.FqNew().SetBigInt(v),
+	}
+}
+
+func (s *ScalarJubjub) Cmp(rhs Scalar) int {
+	r, ok := rhs.(*ScalarJubjub)
+	if ok {
+		return s.Value.Cmp(r.Value)
+	} else {
+		return -2
+	}
+}
+
+func (s *ScalarJubjub) Square() Scalar {
+	return &ScalarJubjub{
+		Value: jubjubn.FqNew().Square(s.Value),
+	}
+}
+
+func (s *ScalarJubjub) Pow(exp uint64) Scalar {
+	expFieldLimb := [native.Field4Limbs]uint64{exp, 0, 0, 0}
+	out := ScalarJubjub{Value: jubjubn.FqNew()}
+	native.Pow(&out.Value.Value, &s.Value.Value, &expFieldLimb, s.Value.Params, s.Value.Arithmetic)
+	return &ScalarJubjub{
+		Value: out.Value,
+	}
+}
+
+func (s *ScalarJubjub) Double() Scalar {
+	return &ScalarJubjub{
+		Value: jubjubn.FqNew().Double(s.Value),
+	}
+}
+
+func (s *ScalarJubjub) Invert() (Scalar, error) {
+	value, wasInverted := jubjubn.FqNew().Invert(s.Value)
+	if !wasInverted {
+		return nil, fmt.Errorf("inverse doesn't exist")
+	}
+	return &ScalarJubjub{
+		value,
+	}, nil
+}
+
+func (s *ScalarJubjub) Sqrt() (Scalar, error) {
+	value, wasSquare := jubjubn.FqNew().Sqrt(s.Value)
+	if !wasSquare {
+		return nil, fmt.Errorf("not a square")
+	}
+	return &ScalarJubjub{
+		value,
+	}, nil
+}
+
+func (s *ScalarJubjub) Cube() Scalar {
+	value := jubjubn.FqNew().Square(s.Value)
+	value.Mul(value, s.Value)
+	return &ScalarJubjub{
+		value,
+	}
+}
+
+func (s *ScalarJubjub) Add(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarJubjub)
+	if ok {
+		return &ScalarJubjub{
+			Value: jubjubn.FqNew().Add(s.Value, r.Value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarJubjub) Sub(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarJubjub)
+	if ok {
+		return &ScalarJubjub{
+			Value: jubjubn.FqNew().Sub(s.Value, r.Value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarJubjub) Mul(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarJubjub)
+	if ok {
+		return &ScalarJubjub{
+			Value: jubjubn.FqNew().Mul(s.Value, r.Value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarJubjub) MulAdd(y, z Scalar) Scalar {
+	return s.Mul(y).Add(z)
+}
+
+func (s *ScalarJubjub) Div(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarJubjub)
+	if ok {
+		v, wasInverted := jubjubn.FqNew().Invert(r.Value)
+		if !wasInverted {
+			return nil
+		}
+		v.Mul(v, s.Value)
+		return &ScalarJubjub{Value: v}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarJubjub) Neg() Scalar {
+	return &ScalarJubjub{
+		Value: jubjubn.FqNew().Neg(s.Value),
+	}
+}
+
+func (*ScalarJubjub) SetBigInt(v *big.Int) (Scalar, error) {
+	return &ScalarJubjub{
+		Value: jubjubn.FqNew().SetBigInt(v),
+	}, nil
+}
+
+func (s *ScalarJubjub) BigInt() *big.Int {
+	return s.Value.BigInt()
+}
+
+func (s *ScalarJubjub) Bytes() []byte {
+	t := s.Value.Bytes()
+	return t[:]
+}
+
+func (*ScalarJubjub) SetBytes(bytes []byte) (Scalar, error) {
+	if len(bytes) != 32 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [32]byte
+	copy(seq[:], bytes)
+	value, err := jubjubn.FqNew().SetBytes(&seq)
+	if err != nil {
+		return nil, err
+	}
+	return &ScalarJubjub{
+		value,
+	}, nil
+}
+
+func (*ScalarJubjub) SetBytesWide(bytes []byte) (Scalar, error) {
+	if len(bytes) != 64 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [64]byte
+	copy(seq[:], bytes)
+	return &ScalarJubjub{
+		Value: jubjubn.FqNew().SetBytesWide(&seq),
+	}, nil
+}
+
+func (*ScalarJubjub) Point() Point {
+	return new(PointJubjub).Identity()
+}
+
+func (s *ScalarJubjub) Clone() Scalar {
+	return &ScalarJubjub{
+		Value: jubjubn.FqNew().Set(s.Value),
+	}
+}
+
+func (s *ScalarJubjub) MarshalBinary() ([]byte, error) {
+	return ScalarMarshalBinary(s)
+}
+
+func (s *ScalarJubjub) UnmarshalBinary(input []byte) error {
+	sc, err := ScalarUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarJubjub)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.Value = ss.Value
+	return nil
+}
+
+func (s *ScalarJubjub) MarshalText() ([]byte, error) {
+	return ScalarMarshalText(s)
+}
+
+func (s *ScalarJubjub) UnmarshalText(input []byte) error {
+	sc, err := ScalarUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarJubjub)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.Value = ss.Value
+	return nil
+}
+
+func (s *ScalarJubjub) MarshalJSON() ([]byte, error) {
+	return ScalarMarshalJSON(s)
+}
+
+func (s *ScalarJubjub) UnmarshalJSON(input []byte) error {
+	sc, err := ScalarUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	S, ok := sc.(*ScalarJubjub)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	s.Value = S.Value
+	return nil
+}
+
+// PointJubjub is a point in the prime order subgroup of the jubjub curve
+// -u^2 + v^2 = 1 + d*u^2*v^2 defined over the BLS12-381 scalar field.
+type PointJubjub struct {
+	value *jubjubn.ExtendedPoint
+}
+
+func (p *PointJubjub) Random(reader io.Reader) Point {
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return p.Hash(seed[:])
+}
+
+// Hash uses the hash to curve suite jubjub_XMD:BLAKE2b_ELL2_RO_
+// following RFC 9380.
+func (*PointJubjub) Hash(bytes []byte) Point {
+	return &PointJubjub{
+		value: jubjubn.PointNew().HashWithDefaults(bytes),
+	}
+}
+
+func (*PointJubjub) Identity() Point {
+	return &PointJubjub{
+		value: jubjubn.PointNew().SetIdentity(),
+	}
+}
+
+func (*PointJubjub) Generator() Point {
+	return &PointJubjub{
+		value: jubjubn.PointNew().SetGenerator(),
+	}
+}
+
+func (p *PointJubjub) IsIdentity() bool {
+	return p.value.IsIdentityI() == 1
+}
+
+func (*PointJubjub) IsNegative() bool {
+	// Negative points don't really exist in jubjub
+	return false
+}
+
+func (p *PointJubjub) IsOnCurve() bool {
+	return p.value.IsOnCurve() == 1
+}
+
+// IsTorsionFree returns true if the point is in the prime order subgroup
+func (p *PointJubjub) IsTorsionFree() bool {
+	return p.value.IsTorsionFreeI() == 1
+}
+
+// IsSmallOrder returns true if the point is in the torsion subgroup of order 8
+func (p *PointJubjub) IsSmallOrder() bool {
+	return p.value.IsSmallOrderI() == 1
+}
+
+// ClearCofactor returns [8]p which is always in the prime order subgroup
+func (p *PointJubjub) ClearCofactor() *PointJubjub {
+	return &PointJubjub{value: jubjubn.PointNew().ClearCofactor(p.value)}
+}
+
+func (p *PointJubjub) Double() Point {
+	return &PointJubjub{value: jubjubn.PointNew().Double(p.value)}
+}
+
+func (*PointJubjub) Scalar() Scalar {
+	return new(ScalarJubjub).Zero()
+}
+
+func (p *PointJubjub) Neg() Point {
+	return &PointJubjub{value: jubjubn.PointNew().Negate(p.value)}
+}
+
+func (p *PointJubjub) Add(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointJubjub)
+	if ok {
+		return &PointJubjub{value: jubjubn.PointNew().Add(p.value, r.value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointJubjub) Sub(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointJubjub)
+	if ok {
+		return &PointJubjub{value: jubjubn.PointNew().Sub(p.value, r.value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointJubjub) Mul(rhs Scalar) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*ScalarJubjub)
+	if ok {
+		return &PointJubjub{value: jubjubn.PointNew().Mul(p.value, r.Value)}
+	} else {
+		return nil
+	}
+}
+
+func (*PointJubjub) selectPoint(row []Point, index int) Point {
+	value := jubjubn.PointNew().SetIdentity()
+	for i, pt := range row {
+		value.CMove(value, pt.(*PointJubjub).value, ctEqual(i+1, index))
+	}
+	return &PointJubjub{value}
+}
+
+func (p *PointJubjub) Equal(rhs Point) bool {
+	r, ok := rhs.(*PointJubjub)
+	if ok {
+		return p.value.EqualI(r.value) == 1
+	} else {
+		return false
+	}
+}
+
+func (p *PointJubjub) Set(x, y *big.Int) (Point, error) {
+	u := jubjubn.FpNew().SetBigInt(x).Bytes()
+	v := jubjubn.FpNew().SetBigInt(y).Bytes()
+
+	var affine [64]byte
+	copy(affine[:32], u[:])
+	copy(affine[32:], v[:])
+	return p.FromAffineUncompressed(affine[:])
+}
+
+// ToAffineCompressed returns the 32 byte encoding described in ZIP-216.
+func (p *PointJubjub) ToAffineCompressed() []byte {
+	t := p.value.Compress()
+	return t[:]
+}
+
+// ToAffineUncompressed returns the little endian u and v coordinates
+// as 64 bytes u || v.
+func (p *PointJubjub) ToAffineUncompressed() []byte {
+	affine := p.value.ToAffine()
+	u := affine.X.Bytes()
+	v := affine.Y.Bytes()
+	var out [64]byte
+	copy(out[:32], u[:])
+	copy(out[32:], v[:])
+	return out[:]
+}
+
+// FromAffineCompressed decodes the 32 byte encoding described in ZIP-216.
+// Points outside the prime order subgroup are rejected.
+func (*PointJubjub) FromAffineCompressed(input []byte) (Point, error) {
+	if len(input) != jubjubn.PointBytes {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	var seq jubjubn.CompressedPoint
+	copy(seq[:], input)
+	value, err := jubjubn.Decompress(&seq)
+	if err != nil {
+		return nil, err
+	}
+	if value.IsTorsionFreeI() != 1 {
+		return nil, fmt.Errorf("invalid point")
+	}
+	return &PointJubjub{value}, nil
+}
+
+func (*PointJubjub) FromAffineUncompressed(input []byte) (Point, error) {
+	if len(input) != 64 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	if bytes.Equal(input, make([]byte, 64)) {
+		return &PointJubjub{value: jubjubn.PointNew().SetIdentity()}, nil
+	}
+	var uu, vv [32]byte
+	copy(uu[:], input[:32])
+	copy(vv[:], input[32:])
+	u, err := jubjubn.FpNew().SetBytes(&uu)
+	if err != nil {
+		return nil, err
+	}
+	v, err := jubjubn.FpNew().SetBytes(&vv)
+	if err != nil {
+		return nil, err
+	}
+	value := (&jubjubn.AffinePoint{X: u, Y: v}).ToExtended()
+	if value.IsOnCurve() != 1 || value.IsTorsionFreeI() != 1 {
+		return nil, fmt.Errorf("invalid point")
+	}
+	return &PointJubjub{value}, nil
+}
+
+func (*PointJubjub) CurveName() string {
+	return JubjubName
+}
+
+func (*PointJubjub) SumOfProducts(points []Point, scalars []Scalar) Point {
+	nPoints := make([]*jubjubn.ExtendedPoint, len(points))
+	nScalars := make([]*native.Field4, len(scalars))
+	for i, pt := range points {
+		pp, ok := pt.(*PointJubjub)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = pp.value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarJubjub)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.Value
+	}
+	value, err := jubjubn.PointNew().SumOfProducts(nPoints, nScalars)
+	if err != nil {
+		return nil
+	}
+	return &PointJubjub{value}
+}
+
+func (p *PointJubjub) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point {
+	return p.VarTimeMultiScalarMult([]Scalar{a, b}, []Point{capA, p.Generator()})
+}
+
+func (p *PointJubjub) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	return varTimeMultiScalarMult(p, scalars, points)
+}
+
+func (*PointJubjub) batchNormalize(points []Point) []Point {
+	nPoints := make([]*jubjubn.ExtendedPoint, len(points))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointJubjub)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	out := make([]Point, len(points))
+	for i, value := range jubjubn.BatchNormalize(nPoints) {
+		out[i] = &PointJubjub{value}
+	}
+	return out
+}
+
+func (p *PointJubjub) X() *big.Int {
+	return p.value.ToAffine().X.BigInt()
+}
+
+func (p *PointJubjub) Y() *big.Int {
+	return p.value.ToAffine().Y.BigInt()
+}
+
+func (*PointJubjub) Modulus() *big.Int {
+	return jubjubn.FpNew().Params.BiModulus
+}
+
+// GetU returns the affine u coordinate as an element of the jubjub base field.
+func (p *PointJubjub) GetU() *native.Field4 {
+	return p.value.ToAffine().X
+}
+
+// GetV returns the affine v coordinate as an element of the jubjub base field.
+func (p *PointJubjub) GetV() *native.Field4 {
+	return p.value.ToAffine().Y
+}
+
+func (p *PointJubjub) GetExtendedPoint() *jubjubn.ExtendedPoint {
+	return jubjubn.PointNew().Set(p.value)
+}
+
+// SetExtendedPoint wraps pt without checking if it is in the prime order subgroup.
+func (*PointJubjub) SetExtendedPoint(pt *jubjubn.ExtendedPoint) *PointJubjub {
+	return &PointJubjub{value: jubjubn.PointNew().Set(pt)}
+}
+
+func (p *PointJubjub) MarshalBinary() ([]byte, error) {
+	return PointMarshalBinary(p)
+}
+
+func (p *PointJubjub) UnmarshalBinary(input []byte) error {
+	pt, err := PointUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointJubjub)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointJubjub) MarshalText() ([]byte, error) {
+	return PointMarshalText(p)
+}
+
+func (p *PointJubjub) UnmarshalText(input []byte) error {
+	pt, err := PointUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointJubjub)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointJubjub) MarshalJSON() ([]byte, error) {
+	return PointMarshalJSON(p)
+}
+
+func (p *PointJubjub) UnmarshalJSON(input []byte) error {
+	pt, err := PointUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	P, ok := pt.(*PointJubjub)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	p.value = P.value
+	return nil
+}
+
+// JubjubBase reinterprets the scalar as an element of the jubjub base field.
+func (s *ScalarBls12381) JubjubBase() *native.Field4 {
+	return jubjubn.FpNew().Set(s.Value)
+}
+
+// SetJubjubBase reinterprets a jubjub base field element as a BLS12-381 scalar.
+func (s *ScalarBls12381) SetJubjubBase(f *native.Field4) (*ScalarBls12381, error) {
+	if f == nil || f.Params.Modulus != bls12381.FqNew().Params.Modulus {
+		return nil, fmt.Errorf("invalid field element")
+	}
+	return &ScalarBls12381{
+		Value: bls12381.FqNew().Set(f),
+		point: s.point,
+	}, nil
+}
@@ -0,0 +1,186 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikelodder7/curvey/internal"
+	jubjubn "github.com/mikelodder7/curvey/native/jubjub"
+)
+
+func TestScalarJubjubSerialize(t *testing.T) {
+	curve := Jubjub()
+	for i := 0; i < 25; i++ {
+		s := curve.Scalar.Random(crand.Reader)
+		b := s.Bytes()
+		require.Equal(t, 32, len(b))
+		s2, err := curve.Scalar.SetBytes(b)
+		require.NoError(t, err)
+		require.Equal(t, 0, s.Cmp(s2))
+	}
+	modulus := jubjubn.FqNew().Params.BiModulus
+	_, err := curve.Scalar.SetBytes(internal.ReverseBytes(modulus.FillBytes(make([]byte, 32))))
+	require.Error(t, err)
+
+	one := curve.Scalar.One()
+	require.True(t, curve.Scalar.New(-1).Add(one).IsZero())
+	inv, err := curve.Scalar.New(7).Invert()
+	require.NoError(t, err)
+	require.True(t, inv.Mul(curve.Scalar.New(7)).IsOne())
+}
+
+func TestPointJubjubAddDoubleMul(t *testing.T) {
+	curve := Jubjub()
+	g := curve.NewGeneratorPoint()
+	id := curve.NewIdentityPoint()
+	require.True(t, g.Add(id).Equal(g))
+	require.True(t, g.IsOnCurve())
+
+	g2 := g.Add(g)
+	require.True(t, g.Double().Equal(g2))
+	g3 := g.Add(g2)
+	require.True(t, g3.Equal(g.Mul(curve.Scalar.New(3))))
+	g4 := g3.Add(g)
+	require.True(t, g4.Equal(g2.Double()))
+	require.True(t, g4.Equal(g.Mul(curve.Scalar.New(4))))
+
+	require.True(t, g.Mul(curve.Scalar.New(-1)).Equal(g.Neg()))
+	require.True(t, g.Sub(g).IsIdentity())
+}
+
+func TestPointJubjubGenerator(t *testing.T) {
+	g := Jubjub().NewGeneratorPoint().(*PointJubjub)
+	require.Equal(t, "cb550cd538ea0cc1138480408e6eaab9b36c613f0dd3f7784fdb6eea837b13d7", hex.EncodeToString(g.ToAffineCompressed()))
+	require.Equal(t, 0, g.X().Cmp(bhex("3ea5c4673a121ca35ed37ee3b172f5ee04315c657fbe375f512dfea318d56fe5")))
+	require.Equal(t, 0, g.Y().Cmp(bhex("57137b83ea6edb4f78f7d30d3f616cb3b9aa6e8e40808413c10cea38d50c55cb")))
+	require.True(t, g.IsTorsionFree())
+	require.False(t, g.IsSmallOrder())
+}
+
+func TestPointJubjubHash(t *testing.T) {
+	curve := Jubjub()
+	h0 := curve.Point.Hash(nil)
+	require.True(t, h0.IsOnCurve())
+	require.Equal(t, "50b41dfe011937fb66a879f436f03cc42518fdf15226d0e43bb849981e0d882b", hex.EncodeToString(h0.ToAffineCompressed()))
+	h1 := curve.Point.Hash([]byte("abc"))
+	require.True(t, h1.IsOnCurve())
+	require.True(t, h1.(*PointJubjub).IsTorsionFree())
+	require.False(t, h0.Equal(h1))
+}
+
+func TestPointJubjubSerialize(t *testing.T) {
+	curve := Jubjub()
+	g := curve.NewGeneratorPoint()
+
+	for i := 0; i < 25; i++ {
+		s := curve.Scalar.Random(crand.Reader)
+		pt := g.Mul(s)
+		cmprs := pt.ToAffineCompressed()
+		require.Equal(t, 32, len(cmprs))
+		retC, err := curve.Point.FromAffineCompressed(cmprs)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(retC))
+
+		un := pt.ToAffineUncompressed()
+		require.Equal(t, 64, len(un))
+		retU, err := curve.Point.FromAffineUncompressed(un)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(retU))
+	}
+
+	// The full group generator is on the curve but not in the subgroup
+	full := make([]byte, 32)
+	full[0] = 11
+	_, err := curve.Point.FromAffineCompressed(full)
+	require.Error(t, err)
+
+	// ZIP-216 rejects the sign bit when u = 0
+	id := curve.NewIdentityPoint().ToAffineCompressed()
+	id[31] |= 0x80
+	_, err = curve.Point.FromAffineCompressed(id)
+	require.Error(t, err)
+}
+
+func TestPointJubjubCofactor(t *testing.T) {
+	var enc jubjubn.CompressedPoint
+	enc[0] = 11
+	full, err := jubjubn.Decompress(&enc)
+	require.NoError(t, err)
+	pt := new(PointJubjub).SetExtendedPoint(full)
+	require.True(t, pt.IsOnCurve())
+	require.False(t, pt.IsTorsionFree())
+	require.False(t, pt.IsSmallOrder())
+
+	cleared := pt.ClearCofactor()
+	require.True(t, cleared.IsTorsionFree())
+	require.True(t, cleared.Equal(Jubjub().NewGeneratorPoint()))
+
+	// (0, -1) has order 2
+	bb, _ := hex.DecodeString("00000000fffffffffe5bfeff02a4bd5305d8a10908d83933487d9d2953a7ed73")
+	copy(enc[:], bb)
+	order2, err := jubjubn.Decompress(&enc)
+	require.NoError(t, err)
+	small := new(PointJubjub).SetExtendedPoint(order2)
+	require.True(t, small.IsOnCurve())
+	require.True(t, small.IsSmallOrder())
+	require.False(t, small.IsTorsionFree())
+	require.True(t, small.ClearCofactor().IsIdentity())
+}
+
+func TestPointJubjubSumOfProducts(t *testing.T) {
+	curve := Jubjub()
+	lhs := curve.ScalarBaseMult(curve.NewScalar().New(50))
+	points := make([]Point, 5)
+	for i := range points {
+		points[i] = curve.NewGeneratorPoint()
+	}
+	scalars := []Scalar{
+		new(ScalarJubjub).New(8),
+		new(ScalarJubjub).New(9),
+		new(ScalarJubjub).New(10),
+		new(ScalarJubjub).New(11),
+		new(ScalarJubjub).New(12),
+	}
+	rhs := lhs.SumOfProducts(points, scalars)
+	require.NotNil(t, rhs)
+	require.True(t, lhs.Equal(rhs))
+}
+
+func TestPointJubjubMarshal(t *testing.T) {
+	pt := Jubjub().Point.Random(crand.Reader).(*PointJubjub)
+	b, err := pt.MarshalBinary()
+	require.NoError(t, err)
+	pt2 := new(PointJubjub)
+	require.NoError(t, pt2.UnmarshalBinary(b))
+	require.True(t, pt.Equal(pt2))
+
+	j, err := pt.MarshalJSON()
+	require.NoError(t, err)
+	pt3 := new(PointJubjub)
+	require.NoError(t, pt3.UnmarshalJSON(j))
+	require.True(t, pt.Equal(pt3))
+	require.Equal(t, JubjubName, GetCurveByName(JubjubName).Name)
+}
+
+func TestScalarBls12381JubjubBase(t *testing.T) {
+	// Jubjub coordinates are BLS12-381 scalars
+	pt := Jubjub().Point.Random(crand.Reader).(*PointJubjub)
+	sc, err := new(ScalarBls12381).SetJubjubBase(pt.GetU())
+	require.NoError(t, err)
+	require.Equal(t, 0, sc.BigInt().Cmp(pt.X()))
+	require.Equal(t, 1, sc.JubjubBase().Equal(pt.GetU()))
+
+	bls := BLS12381G1().Scalar.Random(crand.Reader).(*ScalarBls12381)
+	require.Equal(t, 0, bls.JubjubBase().BigInt().Cmp(bls.BigInt()))
+
+	// The jubjub scalar field is a different field
+	_, err = new(ScalarBls12381).SetJubjubBase(jubjubn.FqNew().SetOne())
+	require.Error(t, err)
+}
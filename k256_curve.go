@@ -481,6 +481,14 @@ func (p *PointK256) Mul(rhs Scalar) Point {
 	}
 }
 
+func (*PointK256) selectPoint(row []Point, index int) Point {
+	value := secp256k1.PointNew().Identity()
+	for i, pt := range row {
+		value.CMove(value, pt.(*PointK256).value, ctEqual(i+1, index))
+	}
+	return &PointK256{value}
+}
+
 func (p *PointK256) Equal(rhs Point) bool {
 	r, ok := rhs.(*PointK256)
 	if ok {
@@ -613,6 +621,55 @@ func (*PointK256) SumOfProducts(points []Point, scalars []Scalar) Point {
 	return &PointK256{value}
 }
 
+func (*PointK256) sumOfProductsVarTime(points []Point, scalars []Scalar, opts *MsmOptions) Point {
+	nPoints := make([]*native.EllipticPoint4, len(points))
+	nScalars := make([]*native.Field4, len(scalars))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointK256)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarK256)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.value
+	}
+	value := secp256k1.PointNew()
+	_, err := value.MsmVarTime(nPoints, nScalars, opts)
+	if err != nil {
+		return nil
+	}
+	return &PointK256{value}
+}
+
+func (p *PointK256) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point {
+	return p.VarTimeMultiScalarMult([]Scalar{a, b}, []Point{capA, p.Generator()})
+}
+
+func (p *PointK256) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	return p.sumOfProductsVarTime(points, scalars, nil)
+}
+
+func (*PointK256) batchNormalize(points []Point) []Point {
+	nPoints := make([]*native.EllipticPoint4, len(points))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointK256)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	out := make([]Point, len(points))
+	for i, value := range native.BatchToAffine(nPoints) {
+		out[i] = &PointK256{value}
+	}
+	return out
+}
+
 func (p *PointK256) X() *native.Field4 {
 	return p.value.GetX()
 }
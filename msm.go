@@ -0,0 +1,51 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"github.com/mikelodder7/curvey/native"
+)
+
+// MsmOptions configure SumOfProductsVarTime. A zero Window selects the
+// window size from the number of points and Workers above one splits the
+// work across that many goroutines.
+type MsmOptions = native.MsmOptions
+
+// varTimeSummer is implemented by points with a variable time
+// multi-scalar multiplication that is faster than SumOfProducts.
+type varTimeSummer interface {
+	sumOfProductsVarTime(points []Point, scalars []Scalar, opts *MsmOptions) Point
+}
+
+// SumOfProductsVarTime computes the multi-scalar multiplication of points and
+// scalars using p to select the curve. The running time depends on the
+// scalars so it must only be used when they are public, such as when
+// verifying proofs. opts may be nil to use the defaults.
+//
+// The following curves have a dedicated implementation that uses
+// interleaved wNAF for few points and Pippenger's bucket method otherwise:
+// P-256, secp256k1, secq256k1, brainpoolP256r1, SM2, Pallas, Vesta,
+// Grumpkin, the STARK curve and the G1 and G2 groups of BLS12-381,
+// BLS12-377 and BN254. Every other curve, including P-384, P-521,
+// brainpoolP384r1, brainpoolP512r1 and the Edwards curves, uses
+// Point.SumOfProducts and gets no speedup.
+func SumOfProductsVarTime(p Point, points []Point, scalars []Scalar, opts *MsmOptions) (Point, error) {
+	if len(points) != len(scalars) {
+		return nil, ErrLengthMismatch
+	}
+	if err := checkPoints(p); err != nil {
+		return nil, err
+	}
+	if err := checkPoints(points...); err != nil {
+		return nil, err
+	}
+	if err := checkScalars(scalars...); err != nil {
+		return nil, err
+	}
+	if v, ok := p.(varTimeSummer); ok {
+		return checkedPoint(v.sumOfProductsVarTime(points, scalars, opts))
+	}
+	return checkedPoint(p.SumOfProducts(points, scalars))
+}
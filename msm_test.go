@@ -0,0 +1,93 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSumOfProductsVarTimeCurves(t *testing.T) {
+	for _, name := range RegisteredCurves() {
+		curve := GetCurveByName(name)
+		points := make([]Point, 33)
+		scalars := make([]Scalar, len(points))
+		for i := range points {
+			points[i] = curve.Point.Random(crand.Reader)
+			scalars[i] = curve.Scalar.Random(crand.Reader)
+		}
+		points[3] = curve.Point.Identity()
+		scalars[5] = curve.Scalar.Zero()
+		scalars[6] = curve.Scalar.One().Neg()
+		points[7] = points[8].Neg()
+		points[9] = points[10]
+
+		expected := curve.Point.SumOfProducts(points, scalars)
+		require.NotNil(t, expected, name)
+		for _, opts := range []*MsmOptions{nil, {Window: 3}, {Workers: 4}, {Window: 6, Workers: 40}} {
+			actual, err := SumOfProductsVarTime(curve.Point, points, scalars, opts)
+			require.NoError(t, err, name)
+			require.True(t, expected.Equal(actual), name)
+		}
+
+		actual, err := SumOfProductsVarTime(curve.Point, nil, nil, nil)
+		require.NoError(t, err, name)
+		require.True(t, actual.IsIdentity(), name)
+	}
+}
+
+// TestSumOfProductsVarTimeDedicated keeps the list of curves in the
+// SumOfProductsVarTime documentation accurate.
+func TestSumOfProductsVarTimeDedicated(t *testing.T) {
+	dedicated := map[string]bool{
+		P256Name:            true,
+		K256Name:            true,
+		Secq256k1Name:       true,
+		BrainpoolP256r1Name: true,
+		Sm2Name:             true,
+		PallasName:          true,
+		VestaName:           true,
+		GrumpkinName:        true,
+		StarkName:           true,
+		BLS12381G1Name:      true,
+		BLS12381G2Name:      true,
+		BLS12377G1Name:      true,
+		BLS12377G2Name:      true,
+		BN254G1Name:         true,
+		BN254G2Name:         true,
+	}
+	for _, name := range RegisteredCurves() {
+		_, ok := GetCurveByName(name).Point.(varTimeSummer)
+		require.Equal(t, dedicated[name], ok, name)
+	}
+}
+
+func TestSumOfProductsVarTimeErrors(t *testing.T) {
+	points := []Point{K256().Point.Generator()}
+	scalars := []Scalar{K256().Scalar.One()}
+	_, err := SumOfProductsVarTime(K256().Point, points, nil, nil)
+	require.ErrorIs(t, err, ErrLengthMismatch)
+	_, err = SumOfProductsVarTime(nil, points, scalars, nil)
+	require.ErrorIs(t, err, ErrNilArgument)
+	_, err = SumOfProductsVarTime(K256().Point, []Point{nil}, scalars, nil)
+	require.ErrorIs(t, err, ErrNilArgument)
+	_, err = SumOfProductsVarTime(K256().Point, points, []Scalar{P256().Scalar.One()}, nil)
+	require.ErrorIs(t, err, ErrTypeMismatch)
+	_, err = SumOfProductsVarTime(P256().Point, points, []Scalar{P256().Scalar.One()}, nil)
+	require.ErrorIs(t, err, ErrTypeMismatch)
+}
+
+func TestGroupSumOfProductsVarTime(t *testing.T) {
+	g := BLS12381G1Group()
+	points := []*PointBls12381G1{g.Generator(), g.Generator()}
+	scalars := []*ScalarBls12381{g.NewScalar(2), g.NewScalar(3)}
+	actual, err := g.SumOfProductsVarTime(points, scalars, &MsmOptions{Workers: 2})
+	require.NoError(t, err)
+	require.True(t, g.Equal(g.Mul(g.Generator(), g.NewScalar(5)), actual))
+	_, err = g.SumOfProductsVarTime(points, scalars[:1], nil)
+	require.ErrorIs(t, err, ErrLengthMismatch)
+}
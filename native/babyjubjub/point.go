@@ -0,0 +1,495 @@
+package babyjubjub
+
+import (
+	"fmt"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+	"github.com/mikelodder7/curvey/native/bn254"
+)
+
+// PointBytes is the length of the iden3 point encoding
+const PointBytes = 32
+
+// CompressedPoint is the little endian encoding of the y coordinate
+// with the top bit set when x > (p - 1) / 2
+type CompressedPoint = [PointBytes]byte
+
+var (
+	// edwardsA = 168700
+	edwardsA = FpNew().SetUint64(168700)
+	// edwardsD = 168696
+	edwardsD = FpNew().SetUint64(168696)
+	one      = FpNew().SetOne()
+	minusOne = FpNew().Neg(one)
+	// The birationally equivalent montgomery curve is
+	// t^2 = s^3 + J*s^2 + s with J = 2(a+d)/(a-d) = 168698
+	montgomeryJ = FpNew().SetUint64(168698)
+	// elligatorZ is the non-square used by elligator 2
+	elligatorZ = FpNew().SetUint64(5)
+	// subgroupOrderBytes is the little endian order of the prime order subgroup
+	subgroupOrderBytes = [PointBytes]byte{
+		0xf1, 0x26, 0x21, 0x39, 0xdc, 0x97, 0x72, 0x67, 0x0a, 0xee, 0x20, 0x39, 0xb8, 0xed, 0x3e, 0xab,
+		0x0b, 0x2b, 0x30, 0xd0, 0xb6, 0x08, 0x0a, 0x37, 0x05, 0x34, 0x26, 0x5c, 0xce, 0x89, 0x0c, 0x06,
+	}
+)
+
+// FpNew returns an element of the baby jubjub base field. This is the scalar
+// field of BN254 which is what makes baby jubjub efficient to use inside
+// circuits defined over BN254.
+func FpNew() *native.Field4 {
+	return bn254.FqNew()
+}
+
+// Decompress returns the point encoded as done by circomlib and go-iden3-crypto.
+// Any point on the curve is accepted, use IsTorsionFreeI to check if
+// the point is in the prime order subgroup.
+func Decompress(c *CompressedPoint) (*ExtendedPoint, error) {
+	var yBytes [PointBytes]byte
+	copy(yBytes[:], c[:])
+	signBit := int(yBytes[PointBytes-1] >> 7)
+	yBytes[PointBytes-1] &= 0x7f
+
+	y, err := FpNew().SetBytes(&yBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid point")
+	}
+	// x^2 = (1 - y^2) / (a - d * y^2)
+	yy := FpNew().Square(y)
+	numerator := FpNew().Sub(one, yy)
+	denominator := FpNew().Mul(edwardsD, yy)
+	denominator.Sub(edwardsA, denominator)
+	denominator, _ = FpNew().Invert(denominator)
+	xx := FpNew().Mul(numerator, denominator)
+	x, wasSquare := sqrtI(xx)
+
+	// The sign bit is meaningless for x = 0
+	invalidSign := x.IsZero() & signBit
+	x.CMove(x, FpNew().Neg(x), lexicographicallyLargest(x)^signBit)
+
+	pt := (&AffinePoint{X: x, Y: y}).ToExtended()
+	if wasSquare&(invalidSign^1)&pt.IsOnCurve() == 1 {
+		return pt, nil
+	}
+	return nil, fmt.Errorf("invalid point")
+}
+
+// ExtendedPoint is a baby jubjub point in extended twisted edwards
+// coordinates where X/Z = x, Y/Z = y and T/Z = xy.
+// Since a is a square and d is not the addition formulas are complete.
+type ExtendedPoint struct {
+	X, Y, Z, T *native.Field4
+}
+
+func PointNew() *ExtendedPoint {
+	return &ExtendedPoint{
+		X: FpNew(),
+		Y: FpNew(),
+		Z: FpNew(),
+		T: FpNew(),
+	}
+}
+
+func (e *ExtendedPoint) SetIdentity() *ExtendedPoint {
+	e.X.SetZero()
+	e.Y.SetOne()
+	e.Z.SetOne()
+	e.T.SetZero()
+	return e
+}
+
+// SetGenerator sets the point to the generator of the prime order subgroup
+// which is [8] of the full group generator, called Base8 by circomlib
+func (e *ExtendedPoint) SetGenerator() *ExtendedPoint {
+	e.X.SetLimbs(&[native.Field4Limbs]uint64{
+		0x2893f3f6bb957051,
+		0x2ab8d8010534e0b6,
+		0x4eacb2e09d6277c1,
+		0x0bb77a6ad63e739b,
+	})
+	e.Y.SetLimbs(&[native.Field4Limbs]uint64{
+		0x4b3c257a872d7d8b,
+		0xfce0051fb9e13377,
+		0x25572e1cd16bf9ed,
+		0x25797203f7a0b249,
+	})
+	e.Z.SetOne()
+	e.T.Mul(e.X, e.Y)
+	return e
+}
+
+func (e *ExtendedPoint) IsIdentityI() int {
+	return e.X.IsZero() & e.Y.Equal(e.Z)
+}
+
+func (e *ExtendedPoint) IsOnCurve() int {
+	xy := FpNew().Mul(e.X, e.Y)
+	zt := FpNew().Mul(e.Z, e.T)
+
+	// a * X^2 + Y^2 == Z^2 + T^2 * D
+	yy := FpNew().Square(e.Y)
+	xx := FpNew().Square(e.X)
+	zz := FpNew().Square(e.Z)
+	tt := FpNew().Square(e.T)
+	lhs := FpNew().Mul(xx, edwardsA)
+	lhs.Add(lhs, yy)
+	rhs := FpNew().Mul(tt, edwardsD)
+	rhs.Add(rhs, zz)
+
+	return xy.Equal(zt) & lhs.Equal(rhs) & e.Z.IsNonZero()
+}
+
+// IsSmallOrderI returns 1 if the point is in the torsion subgroup of order 8
+func (e *ExtendedPoint) IsSmallOrderI() int {
+	return PointNew().ClearCofactor(e).IsIdentityI()
+}
+
+// IsTorsionFreeI returns 1 if the point is in the prime order subgroup
+func (e *ExtendedPoint) IsTorsionFreeI() int {
+	return PointNew().mulBytes(e, &subgroupOrderBytes).IsIdentityI()
+}
+
+// ClearCofactor computes [8]arg which always lies in the prime order subgroup
+func (e *ExtendedPoint) ClearCofactor(arg *ExtendedPoint) *ExtendedPoint {
+	e.Double(arg)
+	e.Double(e)
+	return e.Double(e)
+}
+
+func (e *ExtendedPoint) Set(rhs *ExtendedPoint) *ExtendedPoint {
+	e.X.Set(rhs.X)
+	e.Y.Set(rhs.Y)
+	e.Z.Set(rhs.Z)
+	e.T.Set(rhs.T)
+	return e
+}
+
+func (e *ExtendedPoint) EqualI(rhs *ExtendedPoint) int {
+	xz := FpNew().Mul(e.X, rhs.Z)
+	zx := FpNew().Mul(e.Z, rhs.X)
+
+	yz := FpNew().Mul(e.Y, rhs.Z)
+	zy := FpNew().Mul(e.Z, rhs.Y)
+
+	return xz.Equal(zx) & yz.Equal(zy)
+}
+
+// Add computes arg1 + arg2 using the extended coordinate
+// formulas from Hisil–Wong–Carter–Dawson 2008.
+func (e *ExtendedPoint) Add(arg1, arg2 *ExtendedPoint) *ExtendedPoint {
+	a := FpNew().Mul(arg1.X, arg2.X)
+	b := FpNew().Mul(arg1.Y, arg2.Y)
+	c := FpNew().Mul(arg1.T, edwardsD)
+	c.Mul(c, arg2.T)
+	d := FpNew().Mul(arg1.Z, arg2.Z)
+
+	ee := FpNew().Add(arg1.X, arg1.Y)
+	ee.Mul(ee, FpNew().Add(arg2.X, arg2.Y))
+	ee.Sub(ee, a)
+	ee.Sub(ee, b)
+	f := FpNew().Sub(d, c)
+	g := FpNew().Add(d, c)
+	h := FpNew().Mul(edwardsA, a)
+	h.Sub(b, h)
+
+	e.X.Mul(ee, f)
+	e.Y.Mul(g, h)
+	e.T.Mul(ee, h)
+	e.Z.Mul(f, g)
+	return e
+}
+
+// Sub computes arg1 - arg2
+func (e *ExtendedPoint) Sub(arg1, arg2 *ExtendedPoint) *ExtendedPoint {
+	return e.Add(arg1, PointNew().Negate(arg2))
+}
+
+// Double computes 2*arg using the doubling formulas
+// from Hisil–Wong–Carter–Dawson 2008.
+func (e *ExtendedPoint) Double(arg *ExtendedPoint) *ExtendedPoint {
+	a := FpNew().Square(arg.X)
+	b := FpNew().Square(arg.Y)
+	c := FpNew().Square(arg.Z)
+	c.Double(c)
+	d := FpNew().Mul(edwardsA, a)
+
+	ee := FpNew().Add(arg.X, arg.Y)
+	ee.Square(ee)
+	ee.Sub(ee, a)
+	ee.Sub(ee, b)
+	g := FpNew().Add(d, b)
+	f := FpNew().Sub(g, c)
+	h := FpNew().Sub(d, b)
+
+	e.X.Mul(ee, f)
+	e.Y.Mul(g, h)
+	e.T.Mul(ee, h)
+	e.Z.Mul(f, g)
+	return e
+}
+
+func (e *ExtendedPoint) Negate(arg *ExtendedPoint) *ExtendedPoint {
+	e.X.Neg(arg.X)
+	e.Y.Set(arg.Y)
+	e.Z.Set(arg.Z)
+	e.T.Neg(arg.T)
+	return e
+}
+
+// Mul computes arg * s in constant time
+func (e *ExtendedPoint) Mul(arg *ExtendedPoint, s *native.Field4) *ExtendedPoint {
+	bytes := s.Bytes()
+	return e.mulBytes(arg, &bytes)
+}
+
+// mulBytes multiplies arg by the little endian integer in s
+// using a fixed 4-bit window with constant time table lookups
+func (e *ExtendedPoint) mulBytes(arg *ExtendedPoint, s *[PointBytes]byte) *ExtendedPoint {
+	var precomputed [16]*ExtendedPoint
+	precomputed[0] = PointNew().SetIdentity()
+	precomputed[1] = PointNew().Set(arg)
+	for i := 2; i < 16; i += 2 {
+		precomputed[i] = PointNew().Double(precomputed[i>>1])
+		precomputed[i+1] = PointNew().Add(precomputed[i], arg)
+	}
+
+	r := PointNew().SetIdentity()
+	t := PointNew()
+	for i := PointBytes*2 - 1; i >= 0; i-- {
+		r.Double(r)
+		r.Double(r)
+		r.Double(r)
+		r.Double(r)
+
+		window := int(s[i>>1]>>((i&1)<<2)) & 0xf
+		t.SetIdentity()
+		for j := 1; j < 16; j++ {
+			t.CMove(t, precomputed[j], internal.IsZeroI(j-window))
+		}
+		r.Add(r, t)
+	}
+	return e.Set(r)
+}
+
+// SumOfProducts computes the multi-exponentiation for the specified
+// points and scalars and stores the result in `e`.
+// Returns an error if the lengths of the arguments is not equal.
+func (e *ExtendedPoint) SumOfProducts(points []*ExtendedPoint, scalars []*native.Field4) (*ExtendedPoint, error) {
+	const Upper = 256
+	const W = 4
+	const Windows = Upper / W
+	if len(points) != len(scalars) {
+		return nil, fmt.Errorf("length mismatch")
+	}
+
+	bucketSize := 1 << W
+	windows := make([]*ExtendedPoint, Windows)
+	bytes := make([][PointBytes]byte, len(scalars))
+	buckets := make([]*ExtendedPoint, bucketSize)
+
+	for i, scalar := range scalars {
+		bytes[i] = scalar.Bytes()
+	}
+	for i := range windows {
+		windows[i] = PointNew().SetIdentity()
+	}
+	for i := 0; i < bucketSize; i++ {
+		buckets[i] = PointNew().SetIdentity()
+	}
+
+	sum := PointNew()
+
+	for j := 0; j < len(windows); j++ {
+		for i := 0; i < bucketSize; i++ {
+			buckets[i].SetIdentity()
+		}
+
+		for i := 0; i < len(scalars); i++ {
+			index := bytes[i][j*W>>3] >> (W * j & W) & (1<<W - 1) // little-endian
+			buckets[index].Add(buckets[index], points[i])
+		}
+
+		sum.SetIdentity()
+
+		for i := bucketSize - 1; i > 0; i-- {
+			sum.Add(sum, buckets[i])
+			windows[j].Add(windows[j], sum)
+		}
+	}
+
+	e.SetIdentity()
+	for i := len(windows) - 1; i >= 0; i-- {
+		for j := 0; j < W; j++ {
+			e.Double(e)
+		}
+
+		e.Add(e, windows[i])
+	}
+	return e, nil
+}
+
+func (e *ExtendedPoint) ToAffine() *AffinePoint {
+	// Points from BatchNormalize need no inversion
+	if e.Z.IsOne() == 1 {
+		return &AffinePoint{FpNew().Set(e.X), FpNew().Set(e.Y)}
+	}
+	z, _ := FpNew().Invert(e.Z)
+	x := FpNew().Mul(e.X, z)
+	y := FpNew().Mul(e.Y, z)
+	return &AffinePoint{x, y}
+}
+
+// BatchNormalize returns the points scaled so Z = 1 using Montgomery's
+// trick to share a single field inversion between them.
+func BatchNormalize(points []*ExtendedPoint) []*ExtendedPoint {
+	zInv := make([]*native.Field4, len(points))
+	for i, p := range points {
+		zInv[i] = FpNew().Set(p.Z)
+	}
+	native.BatchInvert(zInv)
+	out := make([]*ExtendedPoint, len(points))
+	for i, p := range points {
+		out[i] = PointNew()
+		out[i].X.Mul(p.X, zInv[i])
+		out[i].Y.Mul(p.Y, zInv[i])
+		out[i].Z.SetOne()
+		out[i].T.Mul(out[i].X, out[i].Y)
+	}
+	return out
+}
+
+func (e *ExtendedPoint) CMove(a, b *ExtendedPoint, choice int) *ExtendedPoint {
+	e.X.CMove(a.X, b.X, choice)
+	e.Y.CMove(a.Y, b.Y, choice)
+	e.Z.CMove(a.Z, b.Z, choice)
+	e.T.CMove(a.T, b.T, choice)
+	return e
+}
+
+// Compress returns the iden3 encoding of the point
+func (e *ExtendedPoint) Compress() *CompressedPoint {
+	affine := e.ToAffine()
+
+	output := affine.Y.Bytes()
+	output[PointBytes-1] |= byte(lexicographicallyLargest(affine.X)) << 7
+	return &output
+}
+
+// HashWithDefaults hashes msg to the prime order subgroup
+// using the suite babyjubjub_XMD:SHA-256_ELL2_RO_
+func (e *ExtendedPoint) HashWithDefaults(msg []byte) *ExtendedPoint {
+	return e.Hash(native.EllipticPointHasherSha256(), msg, []byte("babyjubjub_XMD:SHA-256_ELL2_RO_"))
+}
+
+// Hash computes the hash to curve function from RFC 9380 using
+// elligator 2 on the birationally equivalent montgomery curve
+// followed by clearing the cofactor
+func (e *ExtendedPoint) Hash(hash *native.EllipticPointHasher, msg, dst []byte) *ExtendedPoint {
+	var u []byte
+	switch hash.Type() {
+	case native.XMD:
+		u = native.ExpandMsgXmd(hash, msg, dst, 96)
+	case native.XOF:
+		u = native.ExpandMsgXof(hash, msg, dst, 96)
+	}
+	var buf [native.WideField4Bytes]byte
+	copy(buf[:48], internal.ReverseBytes(u[:48]))
+	u0 := FpNew().SetBytesWide(&buf)
+	copy(buf[:48], internal.ReverseBytes(u[48:]))
+	u1 := FpNew().SetBytesWide(&buf)
+	q0 := AffinePointNew().mapToCurveElligator2(u0)
+	q1 := AffinePointNew().mapToCurveElligator2(u1)
+
+	e.Add(q0.ToExtended(), q1.ToExtended())
+	return e.ClearCofactor(e)
+}
+
+// AffinePoint is a baby jubjub point with affine coordinates x and y
+type AffinePoint struct {
+	X, Y *native.Field4
+}
+
+func AffinePointNew() *AffinePoint {
+	return &AffinePoint{
+		X: FpNew(),
+		Y: FpNew(),
+	}
+}
+
+func (a *AffinePoint) SetIdentity() *AffinePoint {
+	a.X.SetZero()
+	a.Y.SetOne()
+	return a
+}
+
+func (a *AffinePoint) ToExtended() *ExtendedPoint {
+	return &ExtendedPoint{
+		X: FpNew().Set(a.X),
+		Y: FpNew().Set(a.Y),
+		Z: FpNew().SetOne(),
+		T: FpNew().Mul(a.X, a.Y),
+	}
+}
+
+func (a *AffinePoint) EqualI(rhs *AffinePoint) int {
+	return a.X.Equal(rhs.X) & a.Y.Equal(rhs.Y)
+}
+
+// mapToCurveElligator2 maps u to the montgomery curve as described in
+// RFC 9380 Appendix G.2.1 then applies the rational map from Appendix D.1.
+// The montgomery curve has K = 1 so no scaling is needed.
+func (a *AffinePoint) mapToCurveElligator2(u *native.Field4) *AffinePoint {
+	tv1 := FpNew().Square(u)
+	tv1.Mul(tv1, elligatorZ)
+	e1 := tv1.Equal(minusOne)
+	tv1.CMove(tv1, FpNew(), e1)
+	x1 := FpNew().Add(tv1, one)
+	x1, _ = FpNew().Invert(x1)
+	x1.Mul(x1, montgomeryJ)
+	x1.Neg(x1)
+	gx1 := FpNew().Add(x1, montgomeryJ)
+	gx1.Mul(gx1, x1)
+	gx1.Add(gx1, one)
+	gx1.Mul(gx1, x1)
+	x2 := FpNew().Neg(x1)
+	x2.Sub(x2, montgomeryJ)
+	gx2 := FpNew().Mul(tv1, gx1)
+	_, e2 := sqrtI(gx1)
+	s := FpNew().CMove(x2, x1, e2)
+	y2 := FpNew().CMove(gx2, gx1, e2)
+	t, _ := FpNew().Sqrt(y2)
+	e3 := sgn0(t)
+	t.CMove(t, FpNew().Neg(t), e2^e3)
+
+	// (s, t) -> (s / t, (s - 1) / (s + 1))
+	tv1.Add(s, one)
+	tv2 := FpNew().Mul(tv1, t)
+	tv2, _ = FpNew().Invert(tv2)
+	a.X.Mul(tv2, tv1)
+	a.X.Mul(a.X, s)
+	a.Y.Mul(tv2, t)
+	a.Y.Mul(a.Y, FpNew().Sub(s, one))
+	a.Y.CMove(a.Y, one, tv2.IsZero())
+	return a
+}
+
+// sqrtI returns the square root of f and 1 if it exists
+// otherwise zero and 0
+func sqrtI(f *native.Field4) (*native.Field4, int) {
+	wasSquare := 0
+	out := FpNew()
+	out.Arithmetic.Sqrt(&wasSquare, &out.Value, &f.Value)
+	return out, wasSquare
+}
+
+// sgn0 returns the lowest bit of the canonical representation of f
+func sgn0(f *native.Field4) int {
+	bytes := f.Bytes()
+	return int(bytes[0] & 1)
+}
+
+// lexicographicallyLargest returns 1 if f > (p - 1) / 2.
+// This is the case exactly when 2f mod p wraps around and is odd.
+func lexicographicallyLargest(f *native.Field4) int {
+	return sgn0(FpNew().Double(f))
+}
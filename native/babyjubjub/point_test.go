@@ -0,0 +1,156 @@
+package babyjubjub
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikelodder7/curvey/native"
+)
+
+func TestPoint_Generator(t *testing.T) {
+	g := PointNew().SetGenerator()
+	require.Equal(t, 1, g.IsOnCurve())
+	require.Equal(t, 1, g.IsTorsionFreeI())
+	require.Equal(t, 0, g.IsSmallOrderI())
+	require.Equal(t, 1, PointNew().SetIdentity().IsOnCurve())
+	require.Equal(t, 1, PointNew().SetIdentity().IsTorsionFreeI())
+
+	// The full group generator from EIP-2494
+	bb, _ := hex.DecodeString("010000fc647df850245c6e1e12fa0c4a175660a06d11146e0a684cb89c13190c")
+	var enc CompressedPoint
+	copy(enc[:], bb)
+	full, err := Decompress(&enc)
+	require.NoError(t, err)
+	require.Equal(t, 0, full.IsTorsionFreeI())
+	require.Equal(t, 1, PointNew().ClearCofactor(full).EqualI(g))
+}
+
+func TestPoint_Arithmetic(t *testing.T) {
+	g := PointNew().SetGenerator()
+	two := PointNew().Double(g)
+	three := PointNew().Add(two, g)
+	require.Equal(t, 1, three.IsOnCurve())
+	require.Equal(t, 1, PointNew().Add(g, g).EqualI(two))
+	require.Equal(t, 1, PointNew().Mul(g, FqNew().SetUint64(3)).EqualI(three))
+	require.Equal(t, 1, PointNew().Sub(three, two).EqualI(g))
+	require.Equal(t, 1, PointNew().Add(g, PointNew().Negate(g)).IsIdentityI())
+	require.Equal(t, 1, PointNew().Mul(g, FqNew().SetZero()).IsIdentityI())
+	minusOneQ := FqNew().Neg(FqNew().SetOne())
+	require.Equal(t, 1, PointNew().Mul(g, minusOneQ).EqualI(PointNew().Negate(g)))
+}
+
+func TestPoint_Multiples(t *testing.T) {
+	multiples := []string{
+		"8b7d2d877a253c4b7733e1b91f05e0fcedf96bd11c2e572549b2a0f703727925",
+		"53686d2b4005178e1843106f2992a867a01d8a84afbe9e8bda300abfaf6c6601",
+		"957cfd431b63e4a96bf4f3ef71dfb4c19c31f98958f2944495ae95220e6fd621",
+		"dc4f6bf477ec17e8f19442c6730e701caaa89050edc595280d3155e00beed782",
+	}
+	g := PointNew().SetGenerator()
+	pt := PointNew().Set(g)
+	for i, m := range multiples {
+		enc := pt.Compress()
+		require.Equal(t, m, hex.EncodeToString(enc[:]), "multiple %d", i+1)
+		decoded, err := Decompress(enc)
+		require.NoError(t, err)
+		require.Equal(t, 1, decoded.EqualI(pt))
+		pt.Add(pt, g)
+	}
+
+	k, _ := hex.DecodeString("38a1b4b919489d416cbde3c3e435cc6bee92778f771bcd0b461e782b8ea1b603")
+	var kb [32]byte
+	copy(kb[:], k)
+	s, err := FqNew().SetBytes(&kb)
+	require.NoError(t, err)
+	enc := PointNew().Mul(g, s).Compress()
+	require.Equal(t, "0bdd0b477fd06eb222e23b7b413871e8df8a49cb6535859929c307786409810b", hex.EncodeToString(enc[:]))
+}
+
+func TestPoint_CompressIden3(t *testing.T) {
+	// Test vectors from go-iden3-crypto
+	tests := []struct{ x, y, expected string }{
+		{
+			"17777552123799933955779906779655732241715742912184938656739573121738514868268",
+			"2626589144620713026669568689430873010625803728049924121243784502389097019475",
+			"53b81ed5bffe9545b54016234682e7b2f699bd42a5e9eae27ff4051bc698ce85",
+		},
+		{
+			"6890855772600357754907169075114257697580319025794532037257385534741338397365",
+			"4338620300185947561074059802482547481416142213883829469920100239455078257889",
+			"e114eb17eddf794f063a68fecac515e3620e131976108555735c8b0773929709",
+		},
+	}
+	for _, tc := range tests {
+		x, _ := new(big.Int).SetString(tc.x, 10)
+		y, _ := new(big.Int).SetString(tc.y, 10)
+		pt := (&AffinePoint{X: FpNew().SetBigInt(x), Y: FpNew().SetBigInt(y)}).ToExtended()
+		require.Equal(t, 1, pt.IsOnCurve())
+		enc := pt.Compress()
+		require.Equal(t, tc.expected, hex.EncodeToString(enc[:]))
+		decoded, err := Decompress(enc)
+		require.NoError(t, err)
+		require.Equal(t, 1, decoded.EqualI(pt))
+	}
+}
+
+func TestPoint_Decompress(t *testing.T) {
+	// (0, -1) is the point of order 2
+	bb, _ := hex.DecodeString("000000f093f5e1439170b97948e833285d588181b64550b829a031e1724e6430")
+	var enc CompressedPoint
+	copy(enc[:], bb)
+	pt, err := Decompress(&enc)
+	require.NoError(t, err)
+	require.Equal(t, 1, pt.IsSmallOrderI())
+	require.Equal(t, 0, pt.IsTorsionFreeI())
+
+	// The sign bit is rejected for x = 0
+	enc[31] |= 0x80
+	_, err = Decompress(&enc)
+	require.Error(t, err)
+
+	identity := PointNew().SetIdentity().Compress()
+	identity[31] |= 0x80
+	_, err = Decompress(identity)
+	require.Error(t, err)
+
+	invalid := []string{
+		// y = p is not canonical
+		"010000f093f5e1439170b97948e833285d588181b64550b829a031e1724e6430",
+		// no x exists for y = 2
+		"0200000000000000000000000000000000000000000000000000000000000000",
+	}
+	for _, tc := range invalid {
+		bb, _ = hex.DecodeString(tc)
+		copy(enc[:], bb)
+		_, err = Decompress(&enc)
+		require.Error(t, err, tc)
+	}
+}
+
+func TestPoint_Hash(t *testing.T) {
+	tests := []struct{ msg, expected string }{
+		{"", "17ab9a4dc8e9524cbe23a49a93dad9bbedc48315bac0a42f3182d67f6cf437b0"},
+		{"abc", "089c6aaebff76506e0ecfa9a380645a29c2e40656a0eedec81440aaf064d4819"},
+	}
+	for _, tc := range tests {
+		pt := PointNew().HashWithDefaults([]byte(tc.msg))
+		require.Equal(t, 1, pt.IsOnCurve())
+		require.Equal(t, 1, pt.IsTorsionFreeI())
+		enc := pt.Compress()
+		require.Equal(t, tc.expected, hex.EncodeToString(enc[:]))
+	}
+}
+
+func TestPoint_SumOfProducts(t *testing.T) {
+	g := PointNew().SetGenerator()
+	h := PointNew().HashWithDefaults([]byte("sum of products"))
+	a := FqNew().SetUint64(12345)
+	b := FqNew().Neg(FqNew().SetUint64(678910))
+	expected := PointNew().Add(PointNew().Mul(g, a), PointNew().Mul(h, b))
+	actual, err := PointNew().SumOfProducts([]*ExtendedPoint{g, h}, []*native.Field4{a, b})
+	require.NoError(t, err)
+	require.Equal(t, 1, expected.EqualI(actual))
+}
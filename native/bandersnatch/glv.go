@@ -0,0 +1,80 @@
+package bandersnatch
+
+import (
+	"math/bits"
+
+	"github.com/mikelodder7/curvey/native"
+)
+
+// glvBytes is the length of the scalars produced by the
+// GLV decomposition which are at most 127 bits
+const glvBytes = 16
+
+// decompose splits s into k1 + k2 * lambda = s mod r
+// with |k1|, |k2| < 2^127 following the GLV method.
+// The absolute values are returned as little endian
+// bytes along with 1 if the value is negative.
+func decompose(s *native.Field4) (k1, k2 *[glvBytes]byte, neg1, neg2 int) {
+	var k [native.Field4Limbs]uint64
+	s.Arithmetic.FromMontgomery(&k, &s.Value)
+
+	// c1 = round(k * b2 / r), c2 = round(k * b1 / r)
+	q1 := mulShiftRound(&k, &glvG1)
+	q2 := mulShiftRound(&k, &glvG2)
+	c1 := FqNew().SetLimbs(&q1)
+	c2 := FqNew().SetLimbs(&q2)
+
+	// k1 = k - c1 * a1 - c2 * a2
+	t1 := FqNew().Mul(c1, glvA1)
+	t1.Add(t1, FqNew().Mul(c2, glvA2))
+	t1.Sub(s, t1)
+	// k2 = c2 * b2 - c1 * b1
+	t2 := FqNew().Mul(c2, glvB2)
+	t2.Sub(t2, FqNew().Mul(c1, glvB1))
+
+	k1, neg1 = glvAbs(t1)
+	k2, neg2 = glvAbs(t2)
+	return k1, k2, neg1, neg2
+}
+
+// glvAbs returns the absolute value of the small signed value
+// in f and 1 if it is negative. Negative values are close
+// to the modulus so their upper half is never zero.
+func glvAbs(f *native.Field4) (*[glvBytes]byte, int) {
+	var t [native.Field4Limbs]uint64
+	f.Arithmetic.FromMontgomery(&t, &f.Value)
+	upper := t[2] | t[3]
+	neg := int(((upper | -upper) >> 63) & 1)
+	f.CMove(f, FqNew().Neg(f), neg)
+
+	var out [glvBytes]byte
+	bytes := f.Bytes()
+	copy(out[:], bytes[:glvBytes])
+	return &out, neg
+}
+
+// mulShiftRound computes round(a * b / 2^256)
+func mulShiftRound(a, b *[native.Field4Limbs]uint64) [native.Field4Limbs]uint64 {
+	var t [2 * native.Field4Limbs]uint64
+	for i := 0; i < native.Field4Limbs; i++ {
+		var carry uint64
+		for j := 0; j < native.Field4Limbs; j++ {
+			hi, lo := bits.Mul64(a[i], b[j])
+			var c uint64
+			lo, c = bits.Add64(lo, t[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			t[i+j] = lo
+			carry = hi
+		}
+		t[i+native.Field4Limbs] = carry
+	}
+	// Add 2^255 to round to the nearest integer
+	var c uint64
+	t[3], c = bits.Add64(t[3], 1<<63, 0)
+	for i := native.Field4Limbs; i < 2*native.Field4Limbs; i++ {
+		t[i], c = bits.Add64(t[i], 0, c)
+	}
+	return [native.Field4Limbs]uint64{t[4], t[5], t[6], t[7]}
+}
@@ -0,0 +1,614 @@
+package bandersnatch
+
+import (
+	"fmt"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+	"github.com/mikelodder7/curvey/native/bls12381"
+)
+
+// PointBytes is the length of the banderwagon point encoding
+const PointBytes = 32
+
+// CompressedPoint is the big endian encoding of the x coordinate
+// negated when y is not lexicographically largest
+type CompressedPoint = [PointBytes]byte
+
+var (
+	// edwardsA = -5
+	edwardsA = FpNew().Neg(FpNew().SetUint64(5))
+	// edwardsD = 138827208126141220649022263972958607803 / 171449701953573178309673572579671231137
+	edwardsD = FpNew().SetLimbs(&[native.Field4Limbs]uint64{
+		0xb369f2f5188d58e7,
+		0xcb66677177e54f92,
+		0xc66e3bf86be3b6d8,
+		0x6389c12633c267cb,
+	})
+	one = FpNew().SetOne()
+	// The birationally equivalent montgomery curve is
+	// K*t^2 = s^3 + J*s^2 + s with J = 2(a+d)/(a-d) and K = 4/(a-d)
+	montgomeryK = FpNew().SetLimbs(&[native.Field4Limbs]uint64{
+		0x926c66eb6fa86d15,
+		0xbd025b636bd74122,
+		0x316b96e5c340cf6a,
+		0x384d1c153c878eea,
+	})
+	// montgomeryJK = J / K
+	montgomeryJK = FpNew().SetLimbs(&[native.Field4Limbs]uint64{
+		0x59b4f97a8c46ac71,
+		0x65b333b8bbf2a7c9,
+		0xe3371dfc35f1db6c,
+		0x31c4e09319e133e5,
+	})
+	// montgomeryKK = 1 / K^2
+	montgomeryKK = FpNew().SetLimbs(&[native.Field4Limbs]uint64{
+		0xdfbb904be14f50e1,
+		0x544bddb76a1e7d86,
+		0xa19b6f1789fe957a,
+		0x4e73b361c820997f,
+	})
+	// elligatorZ is the non-square used by elligator 2
+	elligatorZ = FpNew().SetUint64(5)
+	// The endomorphism psi(x, y) = (c(1-y^2)/(xy), b(y^2+b)/(y^2-b))
+	// acts as multiplication by lambda on the prime order subgroup
+	endoB = FpNew().SetLimbs(&[native.Field4Limbs]uint64{
+		0xee0f014d172510b4,
+		0x2ea712770d9af4d6,
+		0x61f00d3a63511a88,
+		0x52c9f28b828426a5,
+	})
+	endoC = FpNew().SetLimbs(&[native.Field4Limbs]uint64{
+		0x515c806cdf650b3d,
+		0x8456abcfff36f4e9,
+		0xa97c6efd6c17d107,
+		0x6cc624cf865457c3,
+	})
+	// The reduced lattice basis (a1, b1), (a2, -b2) for the GLV
+	// decomposition where a_i + b_i * lambda = 0 mod r
+	glvA1 = FqNew().SetLimbs(&[native.Field4Limbs]uint64{0x4b02f94a9789181f, 0x555fe2004be6928e, 0, 0})
+	glvB1 = FqNew().SetLimbs(&[native.Field4Limbs]uint64{0xf8e2591a23d61f44, 0x0814b3eee55e8f5d, 0, 0})
+	glvA2 = FqNew().SetLimbs(&[native.Field4Limbs]uint64{0xf1c4b23447ac3e88, 0x102967ddcabd1ebb, 0, 0})
+	glvB2 = FqNew().SetLimbs(&[native.Field4Limbs]uint64{0x4b02f94a9789181f, 0x555fe2004be6928e, 0, 0})
+	// glvG1 = round(2^256 * b2 / r)
+	glvG1 = [native.Field4Limbs]uint64{0xdebac77a3f4747c2, 0xf21df5b0541cf632, 0x0000000000000002, 0}
+	// glvG2 = round(2^256 * b1 / r)
+	glvG2 = [native.Field4Limbs]uint64{0x993b75e7547768ab, 0x4760f127d8767bde, 0, 0}
+	// subgroupOrderBytes is the little endian order of the prime order subgroup
+	subgroupOrderBytes = [PointBytes]byte{
+		0xe1, 0xe7, 0x76, 0x28, 0xb5, 0x06, 0xfd, 0x74, 0x71, 0x04, 0x19, 0x74, 0x00, 0x87, 0x8f, 0xff,
+		0x00, 0x76, 0x68, 0x02, 0x02, 0x76, 0xce, 0x0c, 0x52, 0x5f, 0x67, 0xca, 0xd4, 0x69, 0xfb, 0x1c,
+	}
+)
+
+// FpNew returns an element of the bandersnatch base field. This is the scalar
+// field of BLS12-381, the same base field as jubjub.
+func FpNew() *native.Field4 {
+	return bls12381.FqNew()
+}
+
+// Decompress returns the point in the prime order subgroup encoded
+// with the banderwagon serialization. Banderwagon is the quotient of
+// bandersnatch by the 2-torsion point (0, -1) so each encoding
+// represents both (x, y) and (-x, -y), of which exactly one
+// is in the prime order subgroup.
+func Decompress(c *CompressedPoint) (*ExtendedPoint, error) {
+	var xBytes [PointBytes]byte
+	copy(xBytes[:], internal.ReverseBytes(c[:]))
+	x, err := FpNew().SetBytes(&xBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid point")
+	}
+	// y^2 = (1 - a * x^2) / (1 - d * x^2)
+	xx := FpNew().Square(x)
+	numerator := FpNew().Mul(edwardsA, xx)
+	numerator.Sub(one, numerator)
+	denominator := FpNew().Mul(edwardsD, xx)
+	denominator.Sub(one, denominator)
+	denominator, _ = FpNew().Invert(denominator)
+	y, wasSquare := sqrtI(FpNew().Mul(numerator, denominator))
+	y.CMove(FpNew().Neg(y), y, lexicographicallyLargest(y))
+
+	// 1 - a * x^2 is a square exactly for the elements of banderwagon
+	_, inSubgroup := sqrtI(numerator)
+
+	// (x, y) + (0, -1) = (-x, -y)
+	pt := (&AffinePoint{X: x, Y: y}).ToExtended()
+	negPt := (&AffinePoint{X: FpNew().Neg(x), Y: FpNew().Neg(y)}).ToExtended()
+	pt.CMove(negPt, pt, pt.IsTorsionFreeI())
+	if wasSquare&inSubgroup&pt.IsOnCurve() == 1 {
+		return pt, nil
+	}
+	return nil, fmt.Errorf("invalid point")
+}
+
+// ExtendedPoint is a bandersnatch point in extended twisted edwards
+// coordinates where X/Z = x, Y/Z = y and T/Z = xy.
+// Since a = -5 is not a square the addition formulas are not complete
+// on the whole curve but have no exceptional cases in the prime order subgroup.
+type ExtendedPoint struct {
+	X, Y, Z, T *native.Field4
+}
+
+func PointNew() *ExtendedPoint {
+	return &ExtendedPoint{
+		X: FpNew(),
+		Y: FpNew(),
+		Z: FpNew(),
+		T: FpNew(),
+	}
+}
+
+func (e *ExtendedPoint) SetIdentity() *ExtendedPoint {
+	e.X.SetZero()
+	e.Y.SetOne()
+	e.Z.SetOne()
+	e.T.SetZero()
+	return e
+}
+
+// SetGenerator sets the point to the generator of the prime order subgroup
+func (e *ExtendedPoint) SetGenerator() *ExtendedPoint {
+	e.X.SetLimbs(&[native.Field4Limbs]uint64{
+		0xe1e71866a252ae18,
+		0x2b79c022ad998465,
+		0x743711777bbe42f3,
+		0x29c132cc2c0b34c5,
+	})
+	e.Y.SetLimbs(&[native.Field4Limbs]uint64{
+		0x5e3167b6cc974166,
+		0x358cad81eee46460,
+		0x157d8b50badcd586,
+		0x2a6c669eda123e0f,
+	})
+	e.Z.SetOne()
+	e.T.Mul(e.X, e.Y)
+	return e
+}
+
+func (e *ExtendedPoint) IsIdentityI() int {
+	return e.X.IsZero() & e.Y.Equal(e.Z)
+}
+
+func (e *ExtendedPoint) IsOnCurve() int {
+	xy := FpNew().Mul(e.X, e.Y)
+	zt := FpNew().Mul(e.Z, e.T)
+
+	// a * X^2 + Y^2 == Z^2 + T^2 * D
+	yy := FpNew().Square(e.Y)
+	xx := FpNew().Square(e.X)
+	zz := FpNew().Square(e.Z)
+	tt := FpNew().Square(e.T)
+	lhs := FpNew().Mul(xx, edwardsA)
+	lhs.Add(lhs, yy)
+	rhs := FpNew().Mul(tt, edwardsD)
+	rhs.Add(rhs, zz)
+
+	return xy.Equal(zt) & lhs.Equal(rhs) & e.Z.IsNonZero()
+}
+
+// IsSmallOrderI returns 1 if the point is in the torsion subgroup of order 4
+func (e *ExtendedPoint) IsSmallOrderI() int {
+	return PointNew().ClearCofactor(e).IsIdentityI()
+}
+
+// IsTorsionFreeI returns 1 if the point is in the prime order subgroup
+func (e *ExtendedPoint) IsTorsionFreeI() int {
+	return PointNew().mulBytes(e, &subgroupOrderBytes).IsIdentityI()
+}
+
+// ClearCofactor computes [4]arg which always lies in the prime order subgroup
+func (e *ExtendedPoint) ClearCofactor(arg *ExtendedPoint) *ExtendedPoint {
+	e.Double(arg)
+	return e.Double(e)
+}
+
+func (e *ExtendedPoint) Set(rhs *ExtendedPoint) *ExtendedPoint {
+	e.X.Set(rhs.X)
+	e.Y.Set(rhs.Y)
+	e.Z.Set(rhs.Z)
+	e.T.Set(rhs.T)
+	return e
+}
+
+func (e *ExtendedPoint) EqualI(rhs *ExtendedPoint) int {
+	xz := FpNew().Mul(e.X, rhs.Z)
+	zx := FpNew().Mul(e.Z, rhs.X)
+
+	yz := FpNew().Mul(e.Y, rhs.Z)
+	zy := FpNew().Mul(e.Z, rhs.Y)
+
+	return xz.Equal(zx) & yz.Equal(zy)
+}
+
+// Add computes arg1 + arg2 using the extended coordinate
+// formulas from Hisil–Wong–Carter–Dawson 2008.
+func (e *ExtendedPoint) Add(arg1, arg2 *ExtendedPoint) *ExtendedPoint {
+	a := FpNew().Mul(arg1.X, arg2.X)
+	b := FpNew().Mul(arg1.Y, arg2.Y)
+	c := FpNew().Mul(arg1.T, edwardsD)
+	c.Mul(c, arg2.T)
+	d := FpNew().Mul(arg1.Z, arg2.Z)
+
+	ee := FpNew().Add(arg1.X, arg1.Y)
+	ee.Mul(ee, FpNew().Add(arg2.X, arg2.Y))
+	ee.Sub(ee, a)
+	ee.Sub(ee, b)
+	f := FpNew().Sub(d, c)
+	g := FpNew().Add(d, c)
+	h := FpNew().Mul(edwardsA, a)
+	h.Sub(b, h)
+
+	e.X.Mul(ee, f)
+	e.Y.Mul(g, h)
+	e.T.Mul(ee, h)
+	e.Z.Mul(f, g)
+	return e
+}
+
+// Sub computes arg1 - arg2
+func (e *ExtendedPoint) Sub(arg1, arg2 *ExtendedPoint) *ExtendedPoint {
+	return e.Add(arg1, PointNew().Negate(arg2))
+}
+
+// Double computes 2*arg using the doubling formulas
+// from Hisil–Wong–Carter–Dawson 2008.
+func (e *ExtendedPoint) Double(arg *ExtendedPoint) *ExtendedPoint {
+	a := FpNew().Square(arg.X)
+	b := FpNew().Square(arg.Y)
+	c := FpNew().Square(arg.Z)
+	c.Double(c)
+	d := FpNew().Mul(edwardsA, a)
+
+	ee := FpNew().Add(arg.X, arg.Y)
+	ee.Square(ee)
+	ee.Sub(ee, a)
+	ee.Sub(ee, b)
+	g := FpNew().Add(d, b)
+	f := FpNew().Sub(g, c)
+	h := FpNew().Sub(d, b)
+
+	e.X.Mul(ee, f)
+	e.Y.Mul(g, h)
+	e.T.Mul(ee, h)
+	e.Z.Mul(f, g)
+	return e
+}
+
+func (e *ExtendedPoint) Negate(arg *ExtendedPoint) *ExtendedPoint {
+	e.X.Neg(arg.X)
+	e.Y.Set(arg.Y)
+	e.Z.Set(arg.Z)
+	e.T.Neg(arg.T)
+	return e
+}
+
+// Endomorphism computes psi(arg) which equals [lambda]arg for
+// any point in the prime order subgroup where lambda^2 = -2 mod r
+func (e *ExtendedPoint) Endomorphism(arg *ExtendedPoint) *ExtendedPoint {
+	yy := FpNew().Square(arg.Y)
+	zz := FpNew().Square(arg.Z)
+	xy := FpNew().Mul(arg.X, arg.Y)
+	bzz := FpNew().Mul(endoB, zz)
+
+	// f = c * (Z^2 - Y^2)
+	f := FpNew().Sub(zz, yy)
+	f.Mul(f, endoC)
+	// g = b * (Y^2 + b * Z^2)
+	g := FpNew().Add(yy, bzz)
+	g.Mul(g, endoB)
+	// h = Y^2 - b * Z^2
+	h := FpNew().Sub(yy, bzz)
+
+	isIdentity := arg.IsIdentityI()
+	e.X.Mul(f, h)
+	e.Y.Mul(g, xy)
+	e.Z.Mul(h, xy)
+	e.T.Mul(f, g)
+	return e.CMove(e, PointNew().SetIdentity(), isIdentity)
+}
+
+// Mul computes arg * s in constant time using the GLV method.
+// The endomorphism only acts as multiplication by lambda on the
+// prime order subgroup so arg must be torsion free.
+func (e *ExtendedPoint) Mul(arg *ExtendedPoint, s *native.Field4) *ExtendedPoint {
+	k1, k2, neg1, neg2 := decompose(s)
+
+	p1 := PointNew().Set(arg)
+	p1.CMove(p1, PointNew().Negate(p1), neg1)
+	p2 := PointNew().Endomorphism(arg)
+	p2.CMove(p2, PointNew().Negate(p2), neg2)
+	precomputed1 := precompute(p1)
+	precomputed2 := precompute(p2)
+
+	r := PointNew().SetIdentity()
+	t := PointNew()
+	for i := glvBytes*2 - 1; i >= 0; i-- {
+		r.Double(r)
+		r.Double(r)
+		r.Double(r)
+		r.Double(r)
+
+		r.Add(r, t.lookup(&precomputed1, int(k1[i>>1]>>((i&1)<<2))&0xf))
+		r.Add(r, t.lookup(&precomputed2, int(k2[i>>1]>>((i&1)<<2))&0xf))
+	}
+	return e.Set(r)
+}
+
+// mulBytes multiplies arg by the little endian integer in s
+// using a fixed 4-bit window with constant time table lookups
+func (e *ExtendedPoint) mulBytes(arg *ExtendedPoint, s *[PointBytes]byte) *ExtendedPoint {
+	precomputed := precompute(arg)
+
+	r := PointNew().SetIdentity()
+	t := PointNew()
+	for i := PointBytes*2 - 1; i >= 0; i-- {
+		r.Double(r)
+		r.Double(r)
+		r.Double(r)
+		r.Double(r)
+
+		window := int(s[i>>1]>>((i&1)<<2)) & 0xf
+		r.Add(r, t.lookup(&precomputed, window))
+	}
+	return e.Set(r)
+}
+
+// precompute returns the multiples 0 through 15 of arg
+func precompute(arg *ExtendedPoint) [16]*ExtendedPoint {
+	var precomputed [16]*ExtendedPoint
+	precomputed[0] = PointNew().SetIdentity()
+	precomputed[1] = PointNew().Set(arg)
+	for i := 2; i < 16; i += 2 {
+		precomputed[i] = PointNew().Double(precomputed[i>>1])
+		precomputed[i+1] = PointNew().Add(precomputed[i], arg)
+	}
+	return precomputed
+}
+
+// lookup sets e to precomputed[window] scanning the whole table
+func (e *ExtendedPoint) lookup(precomputed *[16]*ExtendedPoint, window int) *ExtendedPoint {
+	e.SetIdentity()
+	for j := 1; j < 16; j++ {
+		e.CMove(e, precomputed[j], internal.IsZeroI(j-window))
+	}
+	return e
+}
+
+// SumOfProducts computes the multi-exponentiation for the specified
+// points and scalars and stores the result in `e`.
+// Each scalar is split with the GLV decomposition so the bucket
+// method runs over half the number of windows.
+// Returns an error if the lengths of the arguments is not equal.
+func (e *ExtendedPoint) SumOfProducts(points []*ExtendedPoint, scalars []*native.Field4) (*ExtendedPoint, error) {
+	const Upper = glvBytes * 8
+	const W = 4
+	const Windows = Upper / W
+	if len(points) != len(scalars) {
+		return nil, fmt.Errorf("length mismatch")
+	}
+
+	bucketSize := 1 << W
+	windows := make([]*ExtendedPoint, Windows)
+	glvPoints := make([]*ExtendedPoint, 2*len(points))
+	bytes := make([]*[glvBytes]byte, 2*len(scalars))
+	buckets := make([]*ExtendedPoint, bucketSize)
+
+	for i, scalar := range scalars {
+		k1, k2, neg1, neg2 := decompose(scalar)
+		p1 := PointNew().Set(points[i])
+		p2 := PointNew().Endomorphism(points[i])
+		glvPoints[2*i] = p1.CMove(p1, PointNew().Negate(p1), neg1)
+		glvPoints[2*i+1] = p2.CMove(p2, PointNew().Negate(p2), neg2)
+		bytes[2*i] = k1
+		bytes[2*i+1] = k2
+	}
+	for i := range windows {
+		windows[i] = PointNew().SetIdentity()
+	}
+	for i := 0; i < bucketSize; i++ {
+		buckets[i] = PointNew().SetIdentity()
+	}
+
+	sum := PointNew()
+
+	for j := 0; j < len(windows); j++ {
+		for i := 0; i < bucketSize; i++ {
+			buckets[i].SetIdentity()
+		}
+
+		for i := 0; i < len(bytes); i++ {
+			index := bytes[i][j*W>>3] >> (W * j & W) & (1<<W - 1) // little-endian
+			buckets[index].Add(buckets[index], glvPoints[i])
+		}
+
+		sum.SetIdentity()
+
+		for i := bucketSize - 1; i > 0; i-- {
+			sum.Add(sum, buckets[i])
+			windows[j].Add(windows[j], sum)
+		}
+	}
+
+	e.SetIdentity()
+	for i := len(windows) - 1; i >= 0; i-- {
+		for j := 0; j < W; j++ {
+			e.Double(e)
+		}
+
+		e.Add(e, windows[i])
+	}
+	return e, nil
+}
+
+func (e *ExtendedPoint) ToAffine() *AffinePoint {
+	// Points from BatchNormalize need no inversion
+	if e.Z.IsOne() == 1 {
+		return &AffinePoint{FpNew().Set(e.X), FpNew().Set(e.Y)}
+	}
+	z, _ := FpNew().Invert(e.Z)
+	x := FpNew().Mul(e.X, z)
+	y := FpNew().Mul(e.Y, z)
+	return &AffinePoint{x, y}
+}
+
+// BatchNormalize returns the points scaled so Z = 1 using Montgomery's
+// trick to share a single field inversion between them.
+func BatchNormalize(points []*ExtendedPoint) []*ExtendedPoint {
+	zInv := make([]*native.Field4, len(points))
+	for i, p := range points {
+		zInv[i] = FpNew().Set(p.Z)
+	}
+	native.BatchInvert(zInv)
+	out := make([]*ExtendedPoint, len(points))
+	for i, p := range points {
+		out[i] = PointNew()
+		out[i].X.Mul(p.X, zInv[i])
+		out[i].Y.Mul(p.Y, zInv[i])
+		out[i].Z.SetOne()
+		out[i].T.Mul(out[i].X, out[i].Y)
+	}
+	return out
+}
+
+func (e *ExtendedPoint) CMove(a, b *ExtendedPoint, choice int) *ExtendedPoint {
+	e.X.CMove(a.X, b.X, choice)
+	e.Y.CMove(a.Y, b.Y, choice)
+	e.Z.CMove(a.Z, b.Z, choice)
+	e.T.CMove(a.T, b.T, choice)
+	return e
+}
+
+// Compress returns the banderwagon encoding of the point which is
+// the big endian x coordinate, negated if y is not lexicographically largest.
+// This matches the serialization used by verkle tries.
+func (e *ExtendedPoint) Compress() *CompressedPoint {
+	affine := e.ToAffine()
+	x := FpNew().CMove(FpNew().Neg(affine.X), affine.X, lexicographicallyLargest(affine.Y))
+
+	var output CompressedPoint
+	bytes := x.Bytes()
+	copy(output[:], internal.ReverseBytes(bytes[:]))
+	return &output
+}
+
+// HashWithDefaults hashes msg to the prime order subgroup
+// using the suite bandersnatch_XMD:SHA-256_ELL2_RO_
+func (e *ExtendedPoint) HashWithDefaults(msg []byte) *ExtendedPoint {
+	return e.Hash(native.EllipticPointHasherSha256(), msg, []byte("bandersnatch_XMD:SHA-256_ELL2_RO_"))
+}
+
+// Hash computes the hash to curve function from RFC 9380 using
+// elligator 2 on the birationally equivalent montgomery curve
+// followed by clearing the cofactor
+func (e *ExtendedPoint) Hash(hash *native.EllipticPointHasher, msg, dst []byte) *ExtendedPoint {
+	var u []byte
+	switch hash.Type() {
+	case native.XMD:
+		u = native.ExpandMsgXmd(hash, msg, dst, 96)
+	case native.XOF:
+		u = native.ExpandMsgXof(hash, msg, dst, 96)
+	}
+	var buf [native.WideField4Bytes]byte
+	copy(buf[:48], internal.ReverseBytes(u[:48]))
+	u0 := FpNew().SetBytesWide(&buf)
+	copy(buf[:48], internal.ReverseBytes(u[48:]))
+	u1 := FpNew().SetBytesWide(&buf)
+	q0 := AffinePointNew().mapToCurveElligator2(u0)
+	q1 := AffinePointNew().mapToCurveElligator2(u1)
+
+	e.Add(q0.ToExtended(), q1.ToExtended())
+	return e.ClearCofactor(e)
+}
+
+// AffinePoint is a bandersnatch point with affine coordinates x and y
+type AffinePoint struct {
+	X, Y *native.Field4
+}
+
+func AffinePointNew() *AffinePoint {
+	return &AffinePoint{
+		X: FpNew(),
+		Y: FpNew(),
+	}
+}
+
+func (a *AffinePoint) SetIdentity() *AffinePoint {
+	a.X.SetZero()
+	a.Y.SetOne()
+	return a
+}
+
+func (a *AffinePoint) ToExtended() *ExtendedPoint {
+	return &ExtendedPoint{
+		X: FpNew().Set(a.X),
+		Y: FpNew().Set(a.Y),
+		Z: FpNew().SetOne(),
+		T: FpNew().Mul(a.X, a.Y),
+	}
+}
+
+func (a *AffinePoint) EqualI(rhs *AffinePoint) int {
+	return a.X.Equal(rhs.X) & a.Y.Equal(rhs.Y)
+}
+
+// mapToCurveElligator2 maps u to the montgomery curve as described in
+// RFC 9380 Appendix G.2.1 then applies the rational map from Appendix D.1
+func (a *AffinePoint) mapToCurveElligator2(u *native.Field4) *AffinePoint {
+	tv1 := FpNew().Square(u)
+	tv1.Mul(tv1, elligatorZ)
+	e1 := tv1.Equal(FpNew().Neg(one))
+	tv1.CMove(tv1, FpNew(), e1)
+	x1 := FpNew().Add(tv1, one)
+	x1, _ = FpNew().Invert(x1)
+	x1.Mul(x1, montgomeryJK)
+	x1.Neg(x1)
+	gx1 := FpNew().Add(x1, montgomeryJK)
+	gx1.Mul(gx1, x1)
+	gx1.Add(gx1, montgomeryKK)
+	gx1.Mul(gx1, x1)
+	x2 := FpNew().Neg(x1)
+	x2.Sub(x2, montgomeryJK)
+	gx2 := FpNew().Mul(tv1, gx1)
+	_, e2 := sqrtI(gx1)
+	x := FpNew().CMove(x2, x1, e2)
+	y2 := FpNew().CMove(gx2, gx1, e2)
+	y, _ := FpNew().Sqrt(y2)
+	e3 := sgn0(y)
+	y.CMove(y, FpNew().Neg(y), e2^e3)
+
+	s := FpNew().Mul(x, montgomeryK)
+	t := FpNew().Mul(y, montgomeryK)
+
+	// (s, t) -> (s / t, (s - 1) / (s + 1))
+	tv1.Add(s, one)
+	tv2 := FpNew().Mul(tv1, t)
+	tv2, _ = FpNew().Invert(tv2)
+	a.X.Mul(tv2, tv1)
+	a.X.Mul(a.X, s)
+	a.Y.Mul(tv2, t)
+	a.Y.Mul(a.Y, FpNew().Sub(s, one))
+	a.Y.CMove(a.Y, one, tv2.IsZero())
+	return a
+}
+
+// sqrtI returns the square root of f and 1 if it exists
+// otherwise zero and 0
+func sqrtI(f *native.Field4) (*native.Field4, int) {
+	wasSquare := 0
+	out := FpNew()
+	out.Arithmetic.Sqrt(&wasSquare, &out.Value, &f.Value)
+	return out, wasSquare
+}
+
+// sgn0 returns the lowest bit of the canonical representation of f
+func sgn0(f *native.Field4) int {
+	bytes := f.Bytes()
+	return int(bytes[0] & 1)
+}
+
+// lexicographicallyLargest returns 1 if f > (p - 1) / 2.
+// This is the case exactly when 2f mod p wraps around and is odd.
+func lexicographicallyLargest(f *native.Field4) int {
+	return sgn0(FpNew().Double(f))
+}
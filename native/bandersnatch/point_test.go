@@ -0,0 +1,186 @@
+package bandersnatch
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikelodder7/curvey/native"
+)
+
+func TestPoint_Generator(t *testing.T) {
+	g := PointNew().SetGenerator()
+	require.Equal(t, 1, g.IsOnCurve())
+	require.Equal(t, 1, g.IsTorsionFreeI())
+	require.Equal(t, 0, g.IsSmallOrderI())
+	require.Equal(t, 1, PointNew().SetIdentity().IsOnCurve())
+	require.Equal(t, 1, PointNew().SetIdentity().IsTorsionFreeI())
+
+	// (x, y) + (0, -1) = (-x, -y) is on the curve but has order 2r
+	affine := g.ToAffine()
+	other := (&AffinePoint{X: FpNew().Neg(affine.X), Y: FpNew().Neg(affine.Y)}).ToExtended()
+	require.Equal(t, 1, other.IsOnCurve())
+	require.Equal(t, 0, other.IsTorsionFreeI())
+	require.Equal(t, 1, PointNew().ClearCofactor(other).EqualI(PointNew().ClearCofactor(g)))
+}
+
+func TestPoint_Arithmetic(t *testing.T) {
+	g := PointNew().SetGenerator()
+	two := PointNew().Double(g)
+	three := PointNew().Add(two, g)
+	require.Equal(t, 1, three.IsOnCurve())
+	require.Equal(t, 1, PointNew().Add(g, g).EqualI(two))
+	require.Equal(t, 1, PointNew().Mul(g, FqNew().SetUint64(3)).EqualI(three))
+	require.Equal(t, 1, PointNew().Sub(three, two).EqualI(g))
+	require.Equal(t, 1, PointNew().Add(g, PointNew().Negate(g)).IsIdentityI())
+	require.Equal(t, 1, PointNew().Mul(g, FqNew().SetZero()).IsIdentityI())
+	require.Equal(t, 1, PointNew().Mul(PointNew().SetIdentity(), FqNew().SetUint64(3)).IsIdentityI())
+	minusOneQ := FqNew().Neg(FqNew().SetOne())
+	require.Equal(t, 1, PointNew().Mul(g, minusOneQ).EqualI(PointNew().Negate(g)))
+
+	k, _ := new(big.Int).SetString("0a8b5c3e2f1d4e6a7b8c9d0e1f2a3b4c5d6e7f8091a2b3c4d5e6f708192a3b4c", 16)
+	enc := PointNew().Mul(g, FqNew().SetBigInt(k)).Compress()
+	require.Equal(t, "5573d0384d1d2e8788a5f3df639168d35adb2a2f2822f8a2ddf80406013c51f3", hex.EncodeToString(enc[:]))
+}
+
+func TestPoint_Endomorphism(t *testing.T) {
+	g := PointNew().SetGenerator()
+	lambda, _ := hex.DecodeString("05df838740213dd16e05a570b99dc4cf2bc7382b5649f8ed93a4394adcf3b413")
+	var lb [PointBytes]byte
+	copy(lb[:], lambda)
+	psi := PointNew().Endomorphism(g)
+	require.Equal(t, 1, psi.IsOnCurve())
+	require.Equal(t, 1, psi.EqualI(PointNew().mulBytes(g, &lb)))
+	// psi^2 = -2
+	psi.Endomorphism(psi)
+	require.Equal(t, 1, psi.EqualI(PointNew().Negate(PointNew().Double(g))))
+	require.Equal(t, 1, PointNew().Endomorphism(PointNew().SetIdentity()).IsIdentityI())
+}
+
+func TestPoint_Glv(t *testing.T) {
+	g := PointNew().SetGenerator()
+	lambda := FqNew().SetLimbs(&[native.Field4Limbs]uint64{
+		0xd13d21408783df05,
+		0xcfc49db970a5056e,
+		0xedf849562b38c72b,
+		0x13b4f3dc4a39a493,
+	})
+	edges := []*native.Field4{
+		FqNew().SetZero(),
+		FqNew().SetOne(),
+		FqNew().Neg(FqNew().SetOne()),
+		FqNew().Set(lambda),
+		FqNew().Neg(lambda),
+	}
+	for i := 0; i < 25; i++ {
+		var wide [native.WideField4Bytes]byte
+		_, _ = crand.Read(wide[:])
+		edges = append(edges, FqNew().SetBytesWide(&wide))
+	}
+	for _, s := range edges {
+		k1, k2, neg1, neg2 := decompose(s)
+		var b1, b2 [PointBytes]byte
+		copy(b1[:], k1[:])
+		copy(b2[:], k2[:])
+		require.Zero(t, k1[glvBytes-1]&0x80)
+		require.Zero(t, k2[glvBytes-1]&0x80)
+		f1, err := FqNew().SetBytes(&b1)
+		require.NoError(t, err)
+		f2, err := FqNew().SetBytes(&b2)
+		require.NoError(t, err)
+		f1.CMove(f1, FqNew().Neg(f1), neg1)
+		f2.CMove(f2, FqNew().Neg(f2), neg2)
+		f2.Mul(f2, lambda)
+		require.Equal(t, 1, f1.Add(f1, f2).Equal(s))
+
+		sb := s.Bytes()
+		require.Equal(t, 1, PointNew().Mul(g, s).EqualI(PointNew().mulBytes(g, &sb)))
+	}
+}
+
+func TestPoint_Banderwagon(t *testing.T) {
+	multiples := []string{
+		"4a2c7486fd924882bf02c6908de395122843e3e05264d7991e18e7985dad51e9",
+		"43aa74ef706605705989e8fd38df46873b7eae5921fbed115ac9d937399ce4d5",
+		"49730da2a2931b0402ee45d704997e8e33d462382e41ad209aa2dd869de5cb9b",
+		"5e5f550494159f38aa54d2ed7f11a7e93e4968617990445cc93ac8e59808c126",
+	}
+	g := PointNew().SetGenerator()
+	pt := PointNew().Set(g)
+	for i, m := range multiples {
+		enc := pt.Compress()
+		require.Equal(t, m, hex.EncodeToString(enc[:]), "multiple %d", i+1)
+		decoded, err := Decompress(enc)
+		require.NoError(t, err)
+		require.Equal(t, 1, decoded.IsTorsionFreeI())
+		require.Equal(t, 1, decoded.EqualI(pt))
+		pt.Add(pt, g)
+	}
+
+	// The identity and (0, -1) share the zero encoding
+	enc := PointNew().SetIdentity().Compress()
+	require.Equal(t, CompressedPoint{}, *enc)
+	decoded, err := Decompress(enc)
+	require.NoError(t, err)
+	require.Equal(t, 1, decoded.IsIdentityI())
+
+	invalid := []string{
+		// x = p is not canonical
+		"73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001",
+		// no y exists for x = 2
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		// x = 7 is on the curve but not in banderwagon
+		"0000000000000000000000000000000000000000000000000000000000000007",
+	}
+	for _, tc := range invalid {
+		bb, _ := hex.DecodeString(tc)
+		copy(enc[:], bb)
+		_, err = Decompress(enc)
+		require.Error(t, err, tc)
+	}
+}
+
+func TestPoint_Hash(t *testing.T) {
+	tests := []struct{ msg, expected string }{
+		{"", "2cd02a06b42ea3c1b4ca12ad9dd21c2113e4bffdaf61ba912eff7ccf088bbeb8"},
+		{"abc", "5d36dbcf62499dadcfa25e5489c08ba1dd722c6479457909897c67df8c3e4c10"},
+	}
+	for _, tc := range tests {
+		pt := PointNew().HashWithDefaults([]byte(tc.msg))
+		require.Equal(t, 1, pt.IsOnCurve())
+		require.Equal(t, 1, pt.IsTorsionFreeI())
+		enc := pt.Compress()
+		require.Equal(t, tc.expected, hex.EncodeToString(enc[:]))
+	}
+}
+
+func TestPoint_SumOfProducts(t *testing.T) {
+	g := PointNew().SetGenerator()
+	h := PointNew().HashWithDefaults([]byte("sum of products"))
+	a := FqNew().SetUint64(12345)
+	b := FqNew().Neg(FqNew().SetUint64(678910))
+	expected := PointNew().Add(PointNew().Mul(g, a), PointNew().Mul(h, b))
+	actual, err := PointNew().SumOfProducts([]*ExtendedPoint{g, h}, []*native.Field4{a, b})
+	require.NoError(t, err)
+	require.Equal(t, 1, expected.EqualI(actual))
+
+	points := make([]*ExtendedPoint, 10)
+	scalars := make([]*native.Field4, 10)
+	expected.SetIdentity()
+	for i := range points {
+		var wide [native.WideField4Bytes]byte
+		_, _ = crand.Read(wide[:])
+		scalars[i] = FqNew().SetBytesWide(&wide)
+		points[i] = PointNew().HashWithDefaults(wide[:])
+		expected.Add(expected, PointNew().Mul(points[i], scalars[i]))
+	}
+	actual, err = PointNew().SumOfProducts(points, scalars)
+	require.NoError(t, err)
+	require.Equal(t, 1, expected.EqualI(actual))
+
+	_, err = PointNew().SumOfProducts(points, scalars[1:])
+	require.Error(t, err)
+}
@@ -0,0 +1,48 @@
+package bls12377
+
+import (
+	"math/bits"
+
+	"github.com/mikelodder7/curvey/native"
+)
+
+var fqModulusBytes = [native.Field4Bytes]byte{0x01, 0x00, 0x00, 0x00, 0x00, 0x80, 0x11, 0x0a, 0x01, 0x00, 0x00, 0xd0, 0xfe, 0x76, 0xaa, 0x59, 0x01, 0xb0, 0x37, 0x5c, 0x1e, 0x4d, 0xb4, 0x60, 0x56, 0xa5, 0x2c, 0x9a, 0x5e, 0x65, 0xab, 0x12}
+
+const (
+	// The BLS parameter x for BLS12-377 is 0x8508c00000000001.
+	paramX               = uint64(0x8508c00000000001)
+	Limbs                = 6
+	FieldBytes           = 48
+	WideFieldBytes       = 96
+	DoubleWideFieldBytes = 192
+)
+
+// mac Multiply and Accumulate - compute a + (b * c) + d, return the result and new carry.
+func mac(a, b, c, d uint64) (lo, hi uint64) {
+	hi, lo = bits.Mul64(b, c)
+	carry2, carry := bits.Add64(a, d, 0)
+	hi, _ = bits.Add64(hi, 0, carry)
+	lo, carry = bits.Add64(lo, carry2, 0)
+	hi, _ = bits.Add64(hi, 0, carry)
+
+	return lo, hi
+}
+
+// adc Add w/Carry.
+func adc(x, y, carry uint64) (sum, carryOut uint64) {
+	sum = x + y + carry
+	// The sum will overflow if both top bits are set (x & y) or if one of them
+	// is (x | y), and a carry from the lower place happened. If such a carry
+	// happens, the top bit will be 1 + 0 + 1 = 0 (&^ sum).
+	carryOut = ((x & y) | ((x | y) &^ sum)) >> 63
+	carryOut |= ((x & carry) | ((x | carry) &^ sum)) >> 63
+	carryOut |= ((y & carry) | ((y | carry) &^ sum)) >> 63
+	return sum, carryOut
+}
+
+// sbb Subtract with borrow.
+func sbb(x, y, borrow uint64) (diff, borrowOut uint64) {
+	diff = x - (y + borrow)
+	borrowOut = ((^x & y) | (^(x ^ y) & diff)) >> 63
+	return diff, borrowOut
+}
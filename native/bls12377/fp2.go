@@ -0,0 +1,325 @@
+package bls12377
+
+import (
+	"io"
+)
+
+// fp2 is a point in p^2 = p / u^2 + 5.
+type fp2 struct {
+	A, B fp
+}
+
+var (
+	// 1 / 2 in montgomery form.
+	twoInv = fp{
+		0x8166ffffffffffb4,
+		0x28a04fc1bfffffd8,
+		0xcfbed9d4c53e9ff9,
+		0x3da74bdbb73e3182,
+		0x267a4adfc01e4274,
+		0x0046b330f17efa4d,
+	}
+	// 1 / \beta = -1 / 5 in montgomery form.
+	betaInv = fp{
+		0x8072266666666685,
+		0x8df55926899999a9,
+		0x7fe4561ad64f34cf,
+		0xb95da6d8b6e4f01b,
+		0x4b747cccfc142743,
+		0x0039c3fa70f49f43,
+	}
+)
+
+// Set copies a into fp2.
+func (f *fp2) Set(a *fp2) *fp2 {
+	f.A.Set(&a.A)
+	f.B.Set(&a.B)
+	return f
+}
+
+// SetZero fp2 = 0.
+func (f *fp2) SetZero() *fp2 {
+	f.A.SetZero()
+	f.B.SetZero()
+	return f
+}
+
+// SetOne fp2 to the multiplicative identity element.
+func (f *fp2) SetOne() *fp2 {
+	f.A.SetOne()
+	f.B.SetZero()
+	return f
+}
+
+// SetFp creates an element from a lower field.
+func (f *fp2) SetFp(a *fp) *fp2 {
+	f.A.Set(a)
+	f.B.SetZero()
+	return f
+}
+
+// Random generates a random field element.
+func (f *fp2) Random(reader io.Reader) (*fp2, error) {
+	a, err := new(fp).Random(reader)
+	if err != nil {
+		return nil, err
+	}
+	b, err := new(fp).Random(reader)
+	if err != nil {
+		return nil, err
+	}
+	f.A = *a
+	f.B = *b
+	return f, nil
+}
+
+// IsZero returns 1 if fp2 == 0, 0 otherwise.
+func (f *fp2) IsZero() int {
+	return f.A.IsZero() & f.B.IsZero()
+}
+
+// IsOne returns 1 if fp2 == 1, 0 otherwise.
+func (f *fp2) IsOne() int {
+	return f.A.IsOne() & f.B.IsZero()
+}
+
+// Equal returns 1 if f == rhs, 0 otherwise.
+func (f *fp2) Equal(rhs *fp2) int {
+	return f.A.Equal(&rhs.A) & f.B.Equal(&rhs.B)
+}
+
+// LexicographicallyLargest returns 1 if
+// this element is strictly lexicographically larger than its negation
+// 0 otherwise.
+func (f *fp2) LexicographicallyLargest() int {
+	// If this element's B coefficient is lexicographically largest
+	// then it is lexicographically largest. Otherwise, in the event
+	// the B coefficient is zero and the A coefficient is
+	// lexicographically largest, then this element is lexicographically
+	// largest.
+
+	return f.B.LexicographicallyLargest() |
+		f.B.IsZero()&f.A.LexicographicallyLargest()
+}
+
+// Sgn0 returns the lowest bit value.
+func (f *fp2) Sgn0() int {
+	// if A = 0 return B.Sgn0  else A.Sgn0
+	a := f.A.IsZero()
+	t := f.B.Sgn0() & a
+	a = -a + 1
+	t |= f.A.Sgn0() & a
+	return t
+}
+
+// FrobeniusMap raises this element to p.
+func (f *fp2) FrobeniusMap(a *fp2) *fp2 {
+	// This is always just a conjugation. If you're curious why, here's
+	// an article about it: https://alicebob.cryptoland.net/the-frobenius-endomorphism-with-finite-fields/
+	return f.Conjugate(a)
+}
+
+// Conjugate computes the conjugation of this element.
+func (f *fp2) Conjugate(a *fp2) *fp2 {
+	f.A.Set(&a.A)
+	f.B.Neg(&a.B)
+	return f
+}
+
+// MulByNonResidue computes the following:
+// multiply a + bu by u, getting
+// au + bu^2
+// and because u^2 = -5, we get
+// -5b + au.
+func (f *fp2) MulByNonResidue(a *fp2) *fp2 {
+	var aa fp
+	aa.mulByBeta(&a.B)
+	f.B.Set(&a.A)
+	f.A.Set(&aa)
+	return f
+}
+
+// Square computes the square of this element.
+func (f *fp2) Square(arg *fp2) *fp2 {
+	var a, b, c fp
+
+	// Complex squaring:
+	//
+	// v0  = a * b
+	// a' = (a + b) * (a + \beta*b) - v0 - \beta * v0
+	// b' = 2 * v0
+	//
+	// In BLS12-377's F_{p^2}, our \beta is -5.
+	c.Mul(&arg.A, &arg.B)
+	a.Add(&arg.A, &arg.B)
+	b.mulByBeta(&arg.B)
+	b.Add(&b, &arg.A)
+	a.Mul(&a, &b)
+	a.Sub(&a, &c)
+	b.mulByBeta(&c)
+	f.A.Sub(&a, &b)
+	f.B.Double(&c)
+	return f
+}
+
+// Add performs field addition.
+func (f *fp2) Add(arg1, arg2 *fp2) *fp2 {
+	f.A.Add(&arg1.A, &arg2.A)
+	f.B.Add(&arg1.B, &arg2.B)
+	return f
+}
+
+// Double doubles specified element.
+func (f *fp2) Double(a *fp2) *fp2 {
+	f.A.Double(&a.A)
+	f.B.Double(&a.B)
+	return f
+}
+
+// Sub performs field subtraction.
+func (f *fp2) Sub(arg1, arg2 *fp2) *fp2 {
+	f.A.Sub(&arg1.A, &arg2.A)
+	f.B.Sub(&arg1.B, &arg2.B)
+	return f
+}
+
+// Mul computes Karatsuba multiplication.
+func (f *fp2) Mul(arg1, arg2 *fp2) *fp2 {
+	var v0, v1, t, a, b fp
+
+	// Karatsuba multiplication:
+	//
+	// v0  = a0 * b0
+	// v1  = a1 * b1
+	// c0 = v0 + \beta * v1
+	// c1 = (a0 + a1) * (b0 + b1) - v0 - v1
+	//
+	// In BLS12-377's F_{p^2}, our \beta is -5.
+	v0.Mul(&arg1.A, &arg2.A)
+	v1.Mul(&arg1.B, &arg2.B)
+
+	a.mulByBeta(&v1)
+	a.Add(&a, &v0)
+	b.Add(&arg1.A, &arg1.B)
+	t.Add(&arg2.A, &arg2.B)
+	b.Mul(&b, &t)
+	b.Sub(&b, &v0)
+	b.Sub(&b, &v1)
+	f.A.Set(&a)
+	f.B.Set(&b)
+	return f
+}
+
+func (f *fp2) Mul0(arg1 *fp2, arg2 *fp) *fp2 {
+	f.A.Mul(&arg1.A, arg2)
+	f.B.Mul(&arg1.B, arg2)
+	return f
+}
+
+// MulBy3b returns arg * 3 / u or 3 * b.
+func (f *fp2) MulBy3b(arg *fp2) *fp2 {
+	return f.Mul(arg, &curveG23B)
+}
+
+// Neg performs field negation.
+func (f *fp2) Neg(a *fp2) *fp2 {
+	f.A.Neg(&a.A)
+	f.B.Neg(&a.B)
+	return f
+}
+
+// Sqrt performs field square root.
+func (f *fp2) Sqrt(a *fp2) (*fp2, int) {
+	// Since p = 1 (mod 4) this uses the norm, x0 + x1 u is a root
+	// when x0^2 = (a0 +- sqrt(a0^2 - \beta * a1^2)) / 2 and
+	// x1 = a1 / (2 * x0). All candidates are computed in constant time.
+	var alpha, delta, x0, x1, t, res, res2 fp
+	var r, c fp2
+
+	// alpha = sqrt(a0^2 - \beta * a1^2)
+	alpha.Square(&a.A)
+	t.Square(&a.B)
+	t.mulByBeta(&t)
+	alpha.Sub(&alpha, &t)
+	_, _ = alpha.Sqrt(&alpha)
+
+	// delta = (a0 + alpha) / 2 if it is a square
+	// otherwise delta = (a0 - alpha) / 2
+	delta.Add(&a.A, &alpha)
+	delta.Mul(&delta, &twoInv)
+	t.Sub(&a.A, &alpha)
+	t.Mul(&t, &twoInv)
+	_, e1 := x0.Sqrt(&delta)
+	_, _ = res.Sqrt(&t)
+	x0.CMove(&res, &x0, e1)
+
+	// x1 = a1 / (2 * x0)
+	t.Double(&x0)
+	_, _ = t.Invert(&t)
+	x1.Mul(&a.B, &t)
+	r.A.Set(&x0)
+	r.B.Set(&x1)
+
+	// In the event a1 = 0, the element is in the subfield fp
+	// and the root is either sqrt(a0) or sqrt(a0 / \beta) * u.
+	_, e2 := res.Sqrt(&a.A)
+	t.Mul(&a.A, &betaInv)
+	_, _ = res2.Sqrt(&t)
+	c.A.CMove(&c.A, &res, e2)
+	c.B.CMove(&res2, &c.B, e2)
+	r.CMove(&r, &c, a.B.IsZero())
+
+	// is the result^2 = a
+	c.Square(&r)
+	e3 := c.Equal(a)
+	f.CMove(f, &r, e3)
+	return f, e3
+}
+
+// Invert computes the multiplicative inverse of this field
+// element, returning the original value of fp2
+// in the case that this element is zero.
+func (f *fp2) Invert(arg *fp2) (*fp2, int) {
+	// We wish to find the multiplicative inverse of a nonzero
+	// element a + bu in fp2. We leverage an identity
+	//
+	// (a + bu)(a - bu) = a^2 + 5b^2
+	//
+	// which holds because u^2 = -5. This can be rewritten as
+	//
+	// (a + bu)(a - bu)/(a^2 + 5b^2) = 1
+	//
+	// because a^2 + 5b^2 = 0 has no nonzero solutions for (a, b)
+	// as -5 is not a square. This gives that (a - bu)/(a^2 + 5b^2)
+	// is the inverse of (a + bu). Importantly, this can be computing
+	// using only a single inversion in fp.
+	var a, b, t fp
+	a.Square(&arg.A)
+	b.Square(&arg.B)
+	b.mulByBeta(&b)
+	a.Sub(&a, &b)
+	_, wasInverted := t.Invert(&a)
+	// a * t
+	a.Mul(&arg.A, &t)
+	// b * -t
+	b.Neg(&t)
+	b.Mul(&b, &arg.B)
+	f.A.CMove(&f.A, &a, wasInverted)
+	f.B.CMove(&f.B, &b, wasInverted)
+	return f, wasInverted
+}
+
+// CMove performs conditional select.
+// selects arg1 if choice == 0 and arg2 if choice == 1.
+func (f *fp2) CMove(arg1, arg2 *fp2, choice int) *fp2 {
+	f.A.CMove(&arg1.A, &arg2.A, choice)
+	f.B.CMove(&arg1.B, &arg2.B, choice)
+	return f
+}
+
+// CNeg conditionally negates a if choice == 1.
+func (f *fp2) CNeg(a *fp2, choice int) *fp2 {
+	var t fp2
+	t.Neg(a)
+	return f.CMove(f, &t, choice)
+}
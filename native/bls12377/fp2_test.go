@@ -0,0 +1,71 @@
+package bls12377
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFp2Arithmetic(t *testing.T) {
+	var a, b, c, d, xi fp2
+	xi.B.SetOne()
+	for i := 0; i < 25; i++ {
+		_, _ = a.Random(crand.Reader)
+		_, _ = b.Random(crand.Reader)
+
+		require.Equal(t, 1, c.Square(&a).Equal(d.Mul(&a, &a)))
+		require.Equal(t, 1, c.MulByNonResidue(&a).Equal(d.Mul(&a, &xi)))
+		require.Equal(t, 1, c.Sub(c.Add(&a, &b), &b).Equal(&a))
+
+		_, wasInverted := c.Invert(&a)
+		require.Equal(t, 1, wasInverted)
+		require.Equal(t, 1, c.Mul(&c, &a).IsOne())
+
+		// The frobenius map is the conjugation
+		require.Equal(t, 1, c.FrobeniusMap(&a).Equal(d.Conjugate(&a)))
+		var e fp2
+		c.Mul(c.FrobeniusMap(&a), e.FrobeniusMap(&b))
+		require.Equal(t, 1, c.Equal(d.FrobeniusMap(d.Mul(&a, &b))))
+
+		c.Square(&a)
+		_, wasSquare := d.Sqrt(&c)
+		require.Equal(t, 1, wasSquare)
+		require.Equal(t, 1, d.Square(&d).Equal(&c))
+	}
+	// u^2 = -5
+	c.Square(&xi)
+	require.Equal(t, 1, c.Equal(new(fp2).SetFp(new(fp).mulByBeta(new(fp).SetOne()))))
+	// xi is not a square
+	_, wasSquare := c.Sqrt(&xi)
+	require.Equal(t, 0, wasSquare)
+}
+
+func TestFp2SqrtSubfield(t *testing.T) {
+	var a, c, d fp2
+	for i := 0; i < 10; i++ {
+		// Every element of fp is a square in fp2
+		_, _ = a.A.Random(crand.Reader)
+		a.B.SetZero()
+		_, wasSquare := c.Sqrt(&a)
+		require.Equal(t, 1, wasSquare)
+		require.Equal(t, 1, d.Square(&c).Equal(&a))
+	}
+	_, wasSquare := c.Sqrt(a.SetZero())
+	require.Equal(t, 1, wasSquare)
+	require.Equal(t, 1, c.IsZero())
+}
+
+func TestFp2MulBy3b(t *testing.T) {
+	var a, e, res, xi fp2
+	xi.B.SetOne()
+	_, _ = a.Random(crand.Reader)
+	e.Mul(&a, &curveG2B)
+	e.Add(&e, new(fp2).Double(&e))
+	res.MulBy3b(&a)
+	require.Equal(t, 1, e.Equal(&res))
+
+	// b' * xi = 1
+	e.Mul(&curveG2B, &xi)
+	require.Equal(t, 1, e.IsOne())
+}
@@ -0,0 +1,89 @@
+package bls12377
+
+import (
+	crand "crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFpModulus(t *testing.T) {
+	expected, _ := new(big.Int).SetString("1ae3a4617c510eac63b05c06ca1493b1a22d9f300f5138f1ef3622fba094800170b5d44300000008508c00000000001", 16)
+	require.Equal(t, 0, expected.Cmp(biModulus))
+	var one fp
+	one.SetOne()
+	require.Equal(t, one, r)
+	require.Equal(t, 0, big.NewInt(1).Cmp(one.BigInt()))
+}
+
+func TestFpArithmetic(t *testing.T) {
+	var fa, fb, res fp
+	for i := 0; i < 25; i++ {
+		a, _ := crand.Int(crand.Reader, biModulus)
+		b, _ := crand.Int(crand.Reader, biModulus)
+		fa.SetBigInt(a)
+		fb.SetBigInt(b)
+
+		sum := new(big.Int).Add(a, b)
+		require.Equal(t, 0, sum.Mod(sum, biModulus).Cmp(res.Add(&fa, &fb).BigInt()))
+		diff := new(big.Int).Sub(a, b)
+		require.Equal(t, 0, diff.Mod(diff, biModulus).Cmp(res.Sub(&fa, &fb).BigInt()))
+		prod := new(big.Int).Mul(a, b)
+		require.Equal(t, 0, prod.Mod(prod, biModulus).Cmp(res.Mul(&fa, &fb).BigInt()))
+		sq := new(big.Int).Mul(a, a)
+		require.Equal(t, 0, sq.Mod(sq, biModulus).Cmp(res.Square(&fa).BigInt()))
+		neg := new(big.Int).Neg(a)
+		require.Equal(t, 0, neg.Mod(neg, biModulus).Cmp(res.Neg(&fa).BigInt()))
+		b3 := new(big.Int).Mul(a, big.NewInt(3))
+		require.Equal(t, 0, b3.Mod(b3, biModulus).Cmp(res.MulBy3b(&fa).BigInt()))
+
+		_, wasInverted := res.Invert(&fa)
+		require.Equal(t, 1, wasInverted)
+		require.Equal(t, 1, res.Mul(&res, &fa).IsOne())
+
+		res.Square(&fa)
+		_, wasSquare := res.Sqrt(&res)
+		require.Equal(t, 1, wasSquare)
+		require.Equal(t, 1, res.Square(&res).Equal(fb.Square(&fa)))
+	}
+	_, wasInverted := res.Invert(new(fp).SetZero())
+	require.Equal(t, 0, wasInverted)
+	// -5 is not a square but -1 is since p = 1 mod 4
+	_, wasSquare := res.Sqrt(new(fp).mulByBeta(new(fp).SetOne()))
+	require.Equal(t, 0, wasSquare)
+	_, wasSquare = res.Sqrt(new(fp).Neg(new(fp).SetOne()))
+	require.Equal(t, 1, wasSquare)
+	require.Equal(t, 1, res.Square(&res).Equal(new(fp).Neg(new(fp).SetOne())))
+}
+
+func TestFpBytes(t *testing.T) {
+	var t1, t2 fp
+	for i := 0; i < 25; i++ {
+		_, _ = t1.Random(crand.Reader)
+		seq := t1.Bytes()
+		_, suc := t2.SetBytes(&seq)
+		require.Equal(t, 1, suc)
+		require.Equal(t, t1, t2)
+	}
+
+	var bad [FieldBytes]byte
+	copy(bad[:], reverse(biModulus.Bytes()))
+	_, suc := t2.SetBytes(&bad)
+	require.Equal(t, 0, suc)
+
+	var wide [WideFieldBytes]byte
+	_, _ = crand.Read(wide[:])
+	expected := new(big.Int).SetBytes(reverse(wide[:]))
+	expected.Mod(expected, biModulus)
+	require.Equal(t, 0, expected.Cmp(t1.SetBytesWide(&wide).BigInt()))
+}
+
+func TestFpLexicographicallyLargest(t *testing.T) {
+	var a fp
+	require.Equal(t, 0, a.SetZero().LexicographicallyLargest())
+	require.Equal(t, 0, a.SetOne().LexicographicallyLargest())
+	require.Equal(t, 1, a.Neg(a.SetOne()).LexicographicallyLargest())
+	require.Equal(t, 0, a.SetBigInt(new(big.Int).Rsh(biModulus, 1)).LexicographicallyLargest())
+	require.Equal(t, 1, a.SetBigInt(new(big.Int).Rsh(biModulus, 1)).Add(&a, new(fp).SetOne()).LexicographicallyLargest())
+}
@@ -0,0 +1,748 @@
+package bls12377
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+)
+
+var (
+	g1x = fp{
+		0x260f33b9772451f4,
+		0xc54dd773169d5658,
+		0x5c1551c469a510dd,
+		0x761662e4425e1698,
+		0xc97d78cc6f065272,
+		0x00a41206b361fd4d,
+	}
+	g1y = fp{
+		0x8193961fb8cb81f3,
+		0x00638d4c5f44adb8,
+		0xfafaf3dad4daf54a,
+		0xc27849e2d655cd18,
+		0x2ec3ddb401d52814,
+		0x007da93326303c71,
+	}
+	curveG1B = fp{
+		0x02cdffffffffff68,
+		0x51409f837fffffb1,
+		0x9f7db3a98a7d3ff2,
+		0x7b4e97b76e7c6305,
+		0x4cf495bf803c84e8,
+		0x008d6661e2fdf49a,
+	}
+	// Shallue-van de Woestijne constants for y^2 = x^3 + 1 with Z = 1
+	svdwZ = fp{
+		0x02cdffffffffff68,
+		0x51409f837fffffb1,
+		0x9f7db3a98a7d3ff2,
+		0x7b4e97b76e7c6305,
+		0x4cf495bf803c84e8,
+		0x008d6661e2fdf49a,
+	}
+	// g(Z)
+	svdwC1 = fp{
+		0x059bfffffffffed0,
+		0xa2813f06ffffff62,
+		0x3efb675314fa7fe4,
+		0xf69d2f6edcf8c60b,
+		0x99e92b7f007909d0,
+		0x011accc3c5fbe934,
+	}
+	// -Z / 2
+	svdwC2 = fp{
+		0x03a1c0000000004d,
+		0xee6b0d8270000028,
+		0x4f34885af4caa806,
+		0xdc7b8e1749b6e20c,
+		0x9fc0bae0ac8306c6,
+		0x016787152646169d,
+	}
+	// sqrt(-g(Z) * 3 * Z^2)
+	svdwC3 = fp{
+		0x1d310ec61bb69c79,
+		0xfedd1500ad6fa28b,
+		0xbf158fb55d2e5c06,
+		0xb72c352759ed109e,
+		0x35fc81c3418e226c,
+		0x002a7002fe17a55d,
+	}
+	// -4 * g(Z) / (3 * Z^2)
+	svdwC4 = fp{
+		0xa9e65555555556ec,
+		0xf0b8285195555628,
+		0xd54aa3d0dc13b579,
+		0x2f5ce35adaa5bcaf,
+		0x906d2301e58aff38,
+		0x00c4920317b6df9d,
+	}
+)
+
+// G1 is a point in g1.
+type G1 struct {
+	x, y, z fp
+}
+
+// Random creates a random point on the curve
+// from the specified reader.
+func (g1 *G1) Random(reader io.Reader) (*G1, error) {
+	var seed [native.WideField4Bytes]byte
+	n, err := reader.Read(seed[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "random could not read from stream")
+	}
+	if n != native.WideField4Bytes {
+		return nil, fmt.Errorf("insufficient bytes read %d when %d are needed", n, WideFieldBytes)
+	}
+	dst := []byte("BLS12377G1_XMD:SHA-256_SVDW_RO_")
+	return g1.Hash(native.EllipticPointHasherSha256(), seed[:], dst), nil
+}
+
+// Hash uses the hasher to map bytes to a valid point.
+// BLS12-377 has no suite with an SSWU map in RFC 9380 so this
+// uses the Shallue-van de Woestijne map from section 6.6.1 with Z = 1.
+func (g1 *G1) Hash(hash *native.EllipticPointHasher, msg, dst []byte) *G1 {
+	var u []byte
+	var u0, u1 fp
+	var q0, q1 G1
+
+	switch hash.Type() {
+	case native.XMD:
+		u = native.ExpandMsgXmd(hash, msg, dst, 2*hashBytes)
+	case native.XOF:
+		u = native.ExpandMsgXof(hash, msg, dst, 2*hashBytes)
+	}
+
+	var buf [WideFieldBytes]byte
+	copy(buf[:hashBytes], internal.ReverseBytes(u[:hashBytes]))
+	u0.SetBytesWide(&buf)
+	copy(buf[:hashBytes], internal.ReverseBytes(u[hashBytes:]))
+	u1.SetBytesWide(&buf)
+
+	q0.svdw(&u0)
+	q1.svdw(&u1)
+	g1.Add(&q0, &q1)
+	return g1.ClearCofactor(g1)
+}
+
+// Identity returns the identity point.
+func (g1 *G1) Identity() *G1 {
+	g1.x.SetZero()
+	g1.y.SetOne()
+	g1.z.SetZero()
+	return g1
+}
+
+// Generator returns the base point.
+func (g1 *G1) Generator() *G1 {
+	g1.x.Set(&g1x)
+	g1.y.Set(&g1y)
+	g1.z.SetOne()
+	return g1
+}
+
+// IsIdentity returns true if this point is at infinity.
+func (g1 *G1) IsIdentity() int {
+	return g1.z.IsZero()
+}
+
+// IsOnCurve determines if this point represents a valid curve point.
+func (g1 *G1) IsOnCurve() int {
+	// Y^2 Z = X^3 + b Z^3
+	var lhs, rhs, t fp
+	lhs.Square(&g1.y)
+	lhs.Mul(&lhs, &g1.z)
+
+	rhs.Square(&g1.x)
+	rhs.Mul(&rhs, &g1.x)
+	t.Square(&g1.z)
+	t.Mul(&t, &g1.z)
+	t.Mul(&t, &curveG1B)
+	rhs.Add(&rhs, &t)
+
+	return lhs.Equal(&rhs)
+}
+
+// InCorrectSubgroup returns 1 if the point is torsion free, 0 otherwise.
+func (g1 *G1) InCorrectSubgroup() int {
+	var t G1
+	t.multiply(g1, &fqModulusBytes)
+	return t.IsIdentity()
+}
+
+// Add adds this point to another point.
+func (g1 *G1) Add(arg1, arg2 *G1) *G1 {
+	// Algorithm 7, https://eprint.iacr.org/2015/1060.pdf
+	var t0, t1, t2, t3, t4, x3, y3, z3 fp
+
+	t0.Mul(&arg1.x, &arg2.x)
+	t1.Mul(&arg1.y, &arg2.y)
+	t2.Mul(&arg1.z, &arg2.z)
+	t3.Add(&arg1.x, &arg1.y)
+	t4.Add(&arg2.x, &arg2.y)
+	t3.Mul(&t3, &t4)
+	t4.Add(&t0, &t1)
+	t3.Sub(&t3, &t4)
+	t4.Add(&arg1.y, &arg1.z)
+	x3.Add(&arg2.y, &arg2.z)
+	t4.Mul(&t4, &x3)
+	x3.Add(&t1, &t2)
+	t4.Sub(&t4, &x3)
+	x3.Add(&arg1.x, &arg1.z)
+	y3.Add(&arg2.x, &arg2.z)
+	x3.Mul(&x3, &y3)
+	y3.Add(&t0, &t2)
+	y3.Sub(&x3, &y3)
+	x3.Double(&t0)
+	t0.Add(&t0, &x3)
+	t2.MulBy3b(&t2)
+	z3.Add(&t1, &t2)
+	t1.Sub(&t1, &t2)
+	y3.MulBy3b(&y3)
+	x3.Mul(&t4, &y3)
+	t2.Mul(&t3, &t1)
+	x3.Sub(&t2, &x3)
+	y3.Mul(&y3, &t0)
+	t1.Mul(&t1, &z3)
+	y3.Add(&t1, &y3)
+	t0.Mul(&t0, &t3)
+	z3.Mul(&z3, &t4)
+	z3.Add(&z3, &t0)
+
+	g1.x.Set(&x3)
+	g1.y.Set(&y3)
+	g1.z.Set(&z3)
+	return g1
+}
+
+// Sub subtracts the two points.
+func (g1 *G1) Sub(arg1, arg2 *G1) *G1 {
+	var t G1
+	t.Neg(arg2)
+	return g1.Add(arg1, &t)
+}
+
+// Double this point.
+func (g1 *G1) Double(a *G1) *G1 {
+	// Algorithm 9, https://eprint.iacr.org/2015/1060.pdf
+	var t0, t1, t2, x3, y3, z3 fp
+
+	t0.Square(&a.y)
+	z3.Double(&t0)
+	z3.Double(&z3)
+	z3.Double(&z3)
+	t1.Mul(&a.y, &a.z)
+	t2.Square(&a.z)
+	t2.MulBy3b(&t2)
+	x3.Mul(&t2, &z3)
+	y3.Add(&t0, &t2)
+	z3.Mul(&t1, &z3)
+	t1.Double(&t2)
+	t2.Add(&t2, &t1)
+	t0.Sub(&t0, &t2)
+	y3.Mul(&t0, &y3)
+	y3.Add(&y3, &x3)
+	t1.Mul(&a.x, &a.y)
+	x3.Mul(&t0, &t1)
+	x3.Double(&x3)
+
+	e := a.IsIdentity()
+	g1.x.CMove(&x3, t0.SetZero(), e)
+	g1.z.CMove(&z3, &t0, e)
+	g1.y.CMove(&y3, t0.SetOne(), e)
+	return g1
+}
+
+// Mul multiplies this point by the input scalar.
+func (g1 *G1) Mul(a *G1, s *native.Field4) *G1 {
+	bytes := s.Bytes()
+	return g1.multiply(a, &bytes)
+}
+
+func (g1 *G1) multiply(a *G1, bytes *[native.Field4Bytes]byte) *G1 {
+	var p G1
+	precomputed := [16]*G1{}
+	precomputed[0] = new(G1).Identity()
+	precomputed[1] = new(G1).Set(a)
+	for i := 2; i < 16; i += 2 {
+		precomputed[i] = new(G1).Double(precomputed[i>>1])
+		precomputed[i+1] = new(G1).Add(precomputed[i], a)
+	}
+	p.Identity()
+	for i := 0; i < 256; i += 4 {
+		// Brouwer / windowing method. window size of 4.
+		for j := 0; j < 4; j++ {
+			p.Double(&p)
+		}
+		window := bytes[32-1-i>>3] >> (4 - i&0x04) & 0x0F
+		p.Add(&p, precomputed[window])
+	}
+	return g1.Set(&p)
+}
+
+// MulByX multiplies by BLS X using double and add.
+func (g1 *G1) MulByX(a *G1) *G1 {
+	var s, t, r G1
+	r.Identity()
+	t.Set(a)
+
+	for x := paramX; x != 0; x >>= 1 {
+		s.Add(&r, &t)
+		r.CMove(&r, &s, int(x&1))
+		t.Double(&t)
+	}
+	return g1.Set(&r)
+}
+
+// ClearCofactor multiplies by (1 - z), where z is the parameter of BLS12-377, which
+// [suffices to clear](https://ia.cr/2019/403) the cofactor and map
+// elliptic curve points to elements of G1.
+func (g1 *G1) ClearCofactor(a *G1) *G1 {
+	var t G1
+	t.MulByX(a)
+	return g1.Sub(a, &t)
+}
+
+// Neg negates this point.
+func (g1 *G1) Neg(a *G1) *G1 {
+	g1.Set(a)
+	g1.y.CNeg(&a.y, -(a.IsIdentity() - 1))
+	return g1
+}
+
+// Set copies a into g1.
+func (g1 *G1) Set(a *G1) *G1 {
+	g1.x.Set(&a.x)
+	g1.y.Set(&a.y)
+	g1.z.Set(&a.z)
+	return g1
+}
+
+// BigInt returns the x and y as big.Ints in affine.
+func (g1 *G1) BigInt() (x, y *big.Int) {
+	var t G1
+	t.ToAffine(g1)
+	x = t.x.BigInt()
+	y = t.y.BigInt()
+	return x, y
+}
+
+// SetBigInt creates a point from affine x, y
+// and returns the point if it is on the curve.
+func (g1 *G1) SetBigInt(x, y *big.Int) (*G1, error) {
+	var xx, yy fp
+	var pp G1
+	pp.x = *(xx.SetBigInt(x))
+	pp.y = *(yy.SetBigInt(y))
+
+	if pp.x.IsZero()&pp.y.IsZero() == 1 {
+		pp.Identity()
+		return g1.Set(&pp), nil
+	}
+
+	pp.z.SetOne()
+
+	// If not the identity point and not on the curve then invalid
+	if (pp.IsOnCurve()&pp.InCorrectSubgroup())|(xx.IsZero()&yy.IsZero()) == 0 {
+		return nil, fmt.Errorf("invalid coordinates")
+	}
+	return g1.Set(&pp), nil
+}
+
+// ToCompressed serializes this element into compressed form.
+func (g1 *G1) ToCompressed() [FieldBytes]byte {
+	var out [FieldBytes]byte
+	var t G1
+	t.ToAffine(g1)
+	xBytes := t.x.Bytes()
+	copy(out[:], internal.ReverseBytes(xBytes[:]))
+	isInfinity := byte(g1.IsIdentity())
+	// Compressed flag
+	out[0] |= 1 << 7
+	// Is infinity
+	out[0] |= (1 << 6) & -isInfinity
+	// Sign of y only set if not infinity
+	out[0] |= (byte(t.y.LexicographicallyLargest()) << 5) & (isInfinity - 1)
+	return out
+}
+
+// FromCompressed deserializes this element from compressed form.
+func (g1 *G1) FromCompressed(input *[FieldBytes]byte) (*G1, error) {
+	var xFp, yFp fp
+	var x [FieldBytes]byte
+	var p G1
+	compressedFlag := int((input[0] >> 7) & 1)
+	infinityFlag := int((input[0] >> 6) & 1)
+	sortFlag := int((input[0] >> 5) & 1)
+
+	if compressedFlag != 1 {
+		return nil, errors.New("compressed flag must be set")
+	}
+
+	if infinityFlag == 1 {
+		return g1.Identity(), nil
+	}
+
+	copy(x[:], internal.ReverseBytes(input[:]))
+	// Mask away the flag bits
+	x[FieldBytes-1] &= 0x1F
+	if _, valid := xFp.SetBytes(&x); valid != 1 {
+		return nil, errors.New("invalid bytes - not in field")
+	}
+
+	yFp.Square(&xFp)
+	yFp.Mul(&yFp, &xFp)
+	yFp.Add(&yFp, &curveG1B)
+
+	if _, wasSquare := yFp.Sqrt(&yFp); wasSquare != 1 {
+		return nil, errors.New("point is not on the curve")
+	}
+
+	yFp.CNeg(&yFp, yFp.LexicographicallyLargest()^sortFlag)
+	p.x.Set(&xFp)
+	p.y.Set(&yFp)
+	p.z.SetOne()
+	if p.InCorrectSubgroup() == 0 {
+		return nil, errors.New("point is not in correct subgroup")
+	}
+	return g1.Set(&p), nil
+}
+
+// ToUncompressed serializes this element into uncompressed form.
+func (g1 *G1) ToUncompressed() [WideFieldBytes]byte {
+	var out [WideFieldBytes]byte
+	var t G1
+	t.ToAffine(g1)
+	xBytes := t.x.Bytes()
+	yBytes := t.y.Bytes()
+	copy(out[:FieldBytes], internal.ReverseBytes(xBytes[:]))
+	copy(out[FieldBytes:], internal.ReverseBytes(yBytes[:]))
+	isInfinity := byte(g1.IsIdentity())
+	out[0] |= (1 << 6) & -isInfinity
+	return out
+}
+
+// FromUncompressed deserializes this element from uncompressed form.
+func (g1 *G1) FromUncompressed(input *[WideFieldBytes]byte) (*G1, error) {
+	var xFp, yFp fp
+	var t [FieldBytes]byte
+	var p G1
+	infinityFlag := int((input[0] >> 6) & 1)
+
+	if infinityFlag == 1 {
+		return g1.Identity(), nil
+	}
+
+	copy(t[:], internal.ReverseBytes(input[:FieldBytes]))
+	// Mask away top bits
+	t[FieldBytes-1] &= 0x1F
+
+	_, valid := xFp.SetBytes(&t)
+	if valid == 0 {
+		return nil, errors.New("invalid bytes - x not in field")
+	}
+	copy(t[:], internal.ReverseBytes(input[FieldBytes:]))
+	_, valid = yFp.SetBytes(&t)
+	if valid == 0 {
+		return nil, errors.New("invalid bytes - y not in field")
+	}
+
+	p.x.Set(&xFp)
+	p.y.Set(&yFp)
+	p.z.SetOne()
+
+	if p.IsOnCurve() == 0 {
+		return nil, errors.New("point is not on the curve")
+	}
+	if p.InCorrectSubgroup() == 0 {
+		return nil, errors.New("point is not in correct subgroup")
+	}
+	return g1.Set(&p), nil
+}
+
+// ToAffine converts the point into affine coordinates.
+// Points that are already affine are copied without an inversion.
+func (g1 *G1) ToAffine(a *G1) *G1 {
+	if a.z.IsOne() == 1 {
+		return g1.Set(a)
+	}
+	var wasInverted int
+	var zero, x, y, z fp
+	_, wasInverted = z.Invert(&a.z)
+	x.Mul(&a.x, &z)
+	y.Mul(&a.y, &z)
+
+	g1.x.CMove(&zero, &x, wasInverted)
+	g1.y.CMove(&zero, &y, wasInverted)
+	g1.z.CMove(&zero, z.SetOne(), wasInverted)
+	return g1
+}
+
+// BatchToAffineG1 returns the points converted to affine coordinates
+// using Montgomery's trick to share a single field inversion between them.
+// The identity is converted the same as ToAffine.
+func BatchToAffineG1(points []*G1) []*G1 {
+	var t, v, inv fp
+	out := make([]*G1, len(points))
+	// acc[i] is the product of the non-zero z coordinates before i
+	acc := make([]fp, len(points))
+	t.SetOne()
+	for i, p := range points {
+		acc[i] = t
+		v.CMove(v.SetOne(), &p.z, p.z.IsZero()^1)
+		t.Mul(&t, &v)
+	}
+	inv.Invert(&t)
+	for i := len(points) - 1; i >= 0; i-- {
+		p := points[i]
+		nonZero := p.z.IsZero() ^ 1
+		v.CMove(v.SetOne(), &p.z, nonZero)
+		t.Mul(&inv, &acc[i])
+		inv.Mul(&inv, &v)
+		t.CMove(new(fp), &t, nonZero)
+
+		// The identity has a zero inverse so x and y become zero
+		out[i] = new(G1)
+		out[i].x.Mul(&p.x, &t)
+		out[i].y.Mul(&p.y, &t)
+		out[i].z.CMove(new(fp), v.SetOne(), nonZero)
+	}
+	return out
+}
+
+// GetX returns the affine X coordinate.
+func (g1 *G1) GetX() *fp {
+	var t G1
+	t.ToAffine(g1)
+	return &t.x
+}
+
+// GetY returns the affine Y coordinate.
+func (g1 *G1) GetY() *fp {
+	var t G1
+	t.ToAffine(g1)
+	return &t.y
+}
+
+// Equal returns 1 if the two points are equal 0 otherwise.
+func (g1 *G1) Equal(rhs *G1) int {
+	var x1, x2, y1, y2 fp
+	var e1, e2 int
+
+	// This technique avoids inversions
+	x1.Mul(&g1.x, &rhs.z)
+	x2.Mul(&rhs.x, &g1.z)
+
+	y1.Mul(&g1.y, &rhs.z)
+	y2.Mul(&rhs.y, &g1.z)
+
+	e1 = g1.z.IsZero()
+	e2 = rhs.z.IsZero()
+
+	// Both at infinity or coordinates are the same
+	return (e1 & e2) | (^e1 & ^e2)&x1.Equal(&x2)&y1.Equal(&y2)
+}
+
+// CMove sets g1 = arg1 if choice == 0 and g1 = arg2 if choice == 1.
+func (g1 *G1) CMove(arg1, arg2 *G1, choice int) *G1 {
+	g1.x.CMove(&arg1.x, &arg2.x, choice)
+	g1.y.CMove(&arg1.y, &arg2.y, choice)
+	g1.z.CMove(&arg1.z, &arg2.z, choice)
+	return g1
+}
+
+// SumOfProducts computes the multi-exponentiation for the specified
+// points and scalars and stores the result in `g1`.
+// Returns an error if the lengths of the arguments is not equal.
+func (g1 *G1) SumOfProducts(points []*G1, scalars []*native.Field4) (*G1, error) {
+	const Upper = 256
+	const W = 4
+	const Windows = Upper / W // careful--use ceiling division in case this doesn't divide evenly
+	var sum G1
+	if len(points) != len(scalars) {
+		return nil, fmt.Errorf("length mismatch")
+	}
+
+	bucketSize := 1 << W
+	windows := make([]G1, Windows)
+	bytes := make([][32]byte, len(scalars))
+	buckets := make([]G1, bucketSize)
+
+	for i := 0; i < len(windows); i++ {
+		windows[i].Identity()
+	}
+
+	for i, scalar := range scalars {
+		bytes[i] = scalar.Bytes()
+	}
+
+	for j := 0; j < len(windows); j++ {
+		for i := 0; i < bucketSize; i++ {
+			buckets[i].Identity()
+		}
+
+		for i := 0; i < len(scalars); i++ {
+			// j*W to get the nibble
+			// >> 3 to convert to byte, / 8
+			// (W * j & W) gets the nibble, mod W
+			// 1 << W - 1 to get the offset
+			index := bytes[i][j*W>>3] >> (W * j & W) & (1<<W - 1) // little-endian
+			buckets[index].Add(&buckets[index], points[i])
+		}
+
+		sum.Identity()
+
+		for i := bucketSize - 1; i > 0; i-- {
+			sum.Add(&sum, &buckets[i])
+			windows[j].Add(&windows[j], &sum)
+		}
+	}
+
+	g1.Identity()
+	for i := len(windows) - 1; i >= 0; i-- {
+		for j := 0; j < W; j++ {
+			g1.Double(g1)
+		}
+
+		g1.Add(g1, &windows[i])
+	}
+	return g1, nil
+}
+
+// SumOfProductsVarTime computes the multi-exponentiation for the specified
+// points and scalars and stores the result in `g1`. Fewer than
+// MsmStrausPoints points use interleaved wNAF and larger inputs use
+// Pippenger's bucket method. It must only be used with public scalars.
+// Returns an error if the lengths of the arguments is not equal.
+func (g1 *G1) SumOfProductsVarTime(points []*G1, scalars []*native.Field4) (*G1, error) {
+	return g1.MsmVarTime(points, scalars, nil)
+}
+
+// MsmVarTime is SumOfProductsVarTime with options to choose the window
+// size and split the work across goroutines. Setting the window always
+// uses Pippenger's bucket method.
+func (g1 *G1) MsmVarTime(points []*G1, scalars []*native.Field4, opts *native.MsmOptions) (*G1, error) {
+	if len(points) != len(scalars) {
+		return nil, fmt.Errorf("length mismatch")
+	}
+	if len(points) < native.MsmStrausPoints && (opts == nil || opts.Window == 0) {
+		return g1.strausVarTime(points, scalars), nil
+	}
+	affine, nScalars := g1MsmInputs(points, scalars)
+	return g1.Set(native.Msm[g1Affine, *G1](g1Msm{}, affine, nScalars, opts)), nil
+}
+
+// strausVarTime computes the sum of points[i] * scalars[i] by interleaving
+// the width WnafWidth NAFs of the scalars so all the points share the doublings.
+func (g1 *G1) strausVarTime(points []*G1, scalars []*native.Field4) *G1 {
+	var p, t G1
+	tables := make([][]G1, 0, len(points))
+	digits := make([][]int8, 0, len(points))
+	for i, point := range points {
+		if point.IsIdentity() == 1 {
+			continue
+		}
+		table := g1WnafTable(point)
+		var k [native.Field4Limbs]uint64
+		scalars[i].Arithmetic.FromMontgomery(&k, &scalars[i].Value)
+		tables = append(tables, table)
+		digits = append(digits, native.Wnaf(k[:], native.WnafWidth))
+	}
+
+	top := 0
+	for _, d := range digits {
+		if len(d) > top {
+			top = len(d)
+		}
+	}
+	p.Identity()
+	for i := top - 1; i >= 0; i-- {
+		p.Double(&p)
+		for j, d := range digits {
+			if i >= len(d) || d[i] == 0 {
+				continue
+			}
+			if d[i] > 0 {
+				p.Add(&p, &tables[j][d[i]>>1])
+			} else {
+				p.Add(&p, t.Neg(&tables[j][-d[i]>>1]))
+			}
+		}
+	}
+	return g1.Set(&p)
+}
+
+// g1WnafTable returns the odd multiples a, 3*a, ... (2^(WnafWidth-1) - 1)*a
+// indexed by the absolute wNAF digit / 2.
+func g1WnafTable(a *G1) []G1 {
+	var double G1
+	table := make([]G1, 1<<(native.WnafWidth-2))
+	table[0].Set(a)
+	double.Double(a)
+	for i := 1; i < len(table); i++ {
+		table[i].Add(&table[i-1], &double)
+	}
+	return table
+}
+
+func (g1 *G1) svdw(u *fp) *G1 {
+	// Straight-line Shallue-van de Woestijne method taken from
+	// section F.1 in <https://www.rfc-editor.org/rfc/rfc9380.html>
+	var tv1, tv2, tv3, tv4, x1, x2, x3, gx1, gx2, gx, x, y, t fp
+
+	// tv1 = u^2 * c1
+	tv1.Square(u)
+	tv1.Mul(&tv1, &svdwC1)
+	// tv2 = 1 + tv1
+	tv2.Add(&r, &tv1)
+	// tv1 = 1 - tv1
+	tv1.Sub(&r, &tv1)
+	// tv3 = inv0(tv1 * tv2)
+	tv3.Mul(&tv1, &tv2)
+	tv3.Invert(&tv3)
+	// tv4 = u * tv1 * tv3 * c3
+	tv4.Mul(u, &tv1)
+	tv4.Mul(&tv4, &tv3)
+	tv4.Mul(&tv4, &svdwC3)
+
+	// x1 = c2 - tv4
+	x1.Sub(&svdwC2, &tv4)
+	gx1.Square(&x1)
+	gx1.Mul(&gx1, &x1)
+	gx1.Add(&gx1, &curveG1B)
+	_, e1 := t.Sqrt(&gx1)
+
+	// x2 = c2 + tv4
+	x2.Add(&svdwC2, &tv4)
+	gx2.Square(&x2)
+	gx2.Mul(&gx2, &x2)
+	gx2.Add(&gx2, &curveG1B)
+	_, e2 := t.Sqrt(&gx2)
+	e2 &= e1 ^ 1
+
+	// x3 = (tv2^2 * tv3)^2 * c4 + Z
+	x3.Square(&tv2)
+	x3.Mul(&x3, &tv3)
+	x3.Square(&x3)
+	x3.Mul(&x3, &svdwC4)
+	x3.Add(&x3, &svdwZ)
+
+	x.CMove(&x3, &x1, e1)
+	x.CMove(&x, &x2, e2)
+	gx.Square(&x)
+	gx.Mul(&gx, &x)
+	gx.Add(&gx, &curveG1B)
+	_, _ = y.Sqrt(&gx)
+
+	y.CNeg(&y, u.Sgn0()^y.Sgn0())
+
+	g1.x.Set(&x)
+	g1.y.Set(&y)
+	g1.z.SetOne()
+	return g1
+}
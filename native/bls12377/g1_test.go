@@ -0,0 +1,209 @@
+package bls12377
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikelodder7/curvey/native"
+)
+
+func TestG1IsOnCurve(t *testing.T) {
+	require.Equal(t, 1, new(G1).Identity().IsOnCurve())
+	require.Equal(t, 1, new(G1).Generator().IsOnCurve())
+	require.Equal(t, 1, new(G1).Generator().InCorrectSubgroup())
+
+	expected := "008848defe740a67c8fc6225bf87ff5485951e2caa9d41bb188282c8bd37cb5cd5481512ffcd394eeab9b16eb21be9ef" +
+		"01914a69c5102eff1f674f5d30afeec4bd7fb348ca3e52d96d182ad44fb82305c2fe3d3634a9591afd82de55559c8ea6"
+	out := new(G1).Generator().ToUncompressed()
+	require.Equal(t, expected, hex.EncodeToString(out[:]))
+
+	_, err := new(G1).SetBigInt(big.NewInt(1), big.NewInt(3))
+	require.Error(t, err)
+}
+
+func TestG1Arithmetic(t *testing.T) {
+	g := new(G1).Generator()
+	a := new(G1).Double(g)
+	b := new(G1).Add(g, g)
+	require.Equal(t, 1, a.Equal(b))
+	b.Mul(g, FqNew().SetUint64(2))
+	require.Equal(t, 1, a.Equal(b))
+	b.Sub(a, g)
+	require.Equal(t, 1, g.Equal(b))
+
+	b.Add(g, new(G1).Neg(g))
+	require.Equal(t, 1, b.IsIdentity())
+	b.Double(b)
+	require.Equal(t, 1, b.IsIdentity())
+
+	// r * G = 0
+	q := FqNew().SetOne()
+	q.Neg(q)
+	b.Mul(g, q)
+	require.Equal(t, 1, b.Equal(new(G1).Neg(g)))
+
+	a.MulByX(g)
+	b.Mul(g, FqNew().SetUint64(paramX))
+	require.Equal(t, 1, a.Equal(b))
+
+	var bytes [64]byte
+	_, _ = crand.Read(bytes[:])
+	s := FqNew().SetBytesWide(&bytes)
+	_, _ = crand.Read(bytes[:])
+	u := FqNew().SetBytesWide(&bytes)
+	a.Mul(g, s)
+	a.Add(a, new(G1).Mul(g, u))
+	b.Mul(g, FqNew().Add(s, u))
+	require.Equal(t, 1, a.Equal(b))
+}
+
+func TestG1ClearCofactor(t *testing.T) {
+	var u fp
+	var p G1
+	for i := 0; i < 5; i++ {
+		_, _ = u.Random(crand.Reader)
+		p.svdw(&u)
+		require.Equal(t, 1, p.IsOnCurve())
+		p.ClearCofactor(&p)
+		require.Equal(t, 1, p.IsOnCurve())
+		require.Equal(t, 1, p.InCorrectSubgroup())
+	}
+}
+
+func TestG1Hash(t *testing.T) {
+	dst := []byte("BLS12377G1_XMD:SHA-256_SVDW_RO_")
+	tests := []struct {
+		input, expected string
+	}{
+		{"", "007d4be84094198d77e6ee7d3bbede93d6dc830665c4ae84c83f71c1e2cbebf6b2a0c72c80d11843842f9c0b4f63477301251ada7327819bb87528427f93eba3d1a08823a50ff44f47878d0728cd8a1ac3c3b51fbc7527d9bb01c83676271351"},
+		{"abc", "003c25cf6bc7b8bce28d3128a49ac38731e69d45251e50a669ab3249feb3e6cb88078d8654f0136441da6713a9baed1d008d3f81d76c770dfed9fa17f9e1b67984fb0093767b97c28beb6715426251af13f22562aeec5c387ba5f1eb883583b3"},
+	}
+
+	pt := new(G1).Identity()
+	for _, tst := range tests {
+		pt.Hash(native.EllipticPointHasherSha256(), []byte(tst.input), dst)
+		require.Equal(t, 1, pt.InCorrectSubgroup())
+		out := pt.ToUncompressed()
+		require.Equal(t, tst.expected, hex.EncodeToString(out[:]))
+	}
+}
+
+func TestG1Serialization(t *testing.T) {
+	a, _ := new(G1).Random(crand.Reader)
+	b, _ := new(G1).Random(crand.Reader)
+
+	aBytes := a.ToCompressed()
+	bBytes := b.ToCompressed()
+	require.Equal(t, byte(0x80), aBytes[0]&0x80)
+
+	aa, err := new(G1).FromCompressed(&aBytes)
+	require.NoError(t, err)
+	require.Equal(t, 1, a.Equal(aa))
+
+	bb, err := new(G1).FromCompressed(&bBytes)
+	require.NoError(t, err)
+	require.Equal(t, 1, b.Equal(bb))
+
+	auBytes := a.ToUncompressed()
+	_, err = aa.FromUncompressed(&auBytes)
+	require.NoError(t, err)
+	require.Equal(t, 1, a.Equal(aa))
+
+	bBytes = a.ToCompressed()
+	a.Neg(a)
+	aBytes = a.ToCompressed()
+	require.NotEqual(t, aBytes[0]&0x20, bBytes[0]&0x20)
+	_, err = aa.FromCompressed(&aBytes)
+	require.NoError(t, err)
+	require.Equal(t, 1, a.Equal(aa))
+
+	id := new(G1).Identity()
+	idBytes := id.ToUncompressed()
+	require.Equal(t, byte(0x40), idBytes[0])
+	_, err = aa.FromUncompressed(&idBytes)
+	require.NoError(t, err)
+	require.Equal(t, 1, aa.IsIdentity())
+	idc := id.ToCompressed()
+	require.Equal(t, byte(0xc0), idc[0])
+	_, err = aa.FromCompressed(&idc)
+	require.NoError(t, err)
+	require.Equal(t, 1, aa.IsIdentity())
+
+	auBytes[WideFieldBytes-1] ^= 1
+	_, err = aa.FromUncompressed(&auBytes)
+	require.Error(t, err)
+
+	// A point on the curve that is not in the r-torsion must be rejected
+	var u fp
+	var p G1
+	for {
+		_, _ = u.Random(crand.Reader)
+		p.svdw(&u)
+		if p.InCorrectSubgroup() == 0 {
+			break
+		}
+	}
+	pBytes := p.ToUncompressed()
+	_, err = aa.FromUncompressed(&pBytes)
+	require.Error(t, err)
+	pc := p.ToCompressed()
+	_, err = aa.FromCompressed(&pc)
+	require.Error(t, err)
+}
+
+func TestG1SumOfProducts(t *testing.T) {
+	var b [64]byte
+	h0, _ := new(G1).Random(crand.Reader)
+	_, _ = crand.Read(b[:])
+	s := FqNew().SetBytesWide(&b)
+	_, _ = crand.Read(b[:])
+	c := FqNew().SetBytesWide(&b)
+
+	lhs := new(G1).Mul(h0, s)
+	lhs.Add(lhs, new(G1).Mul(h0, c))
+	rhs, err := new(G1).SumOfProducts([]*G1{h0, h0}, []*native.Field4{s, c})
+	require.NoError(t, err)
+	require.Equal(t, 1, lhs.Equal(rhs))
+}
+
+func TestG1Msm(t *testing.T) {
+	var b [64]byte
+	points := make([]*G1, 64)
+	scalars := make([]*native.Field4, 64)
+	for i := range points {
+		_, _ = crand.Read(b[:])
+		points[i], _ = new(G1).Random(crand.Reader)
+		scalars[i] = FqNew().SetBytesWide(&b)
+	}
+	// Repeated and opposite points exercise doubling and
+	// cancellation when the buckets are summed in affine
+	points[1] = points[0]
+	points[2] = new(G1).Neg(points[0])
+	points[3] = new(G1).Double(points[4])
+	points[5] = new(G1).Identity()
+	scalars[1] = scalars[0]
+	scalars[2] = scalars[0]
+	scalars[6].SetZero()
+	expected, err := new(G1).SumOfProducts(points, scalars)
+	require.NoError(t, err)
+
+	for _, window := range []int{0, 2, 5, 11} {
+		for _, workers := range []int{0, 3, 100} {
+			actual, err := new(G1).MsmVarTime(points, scalars, &native.MsmOptions{Window: window, Workers: workers})
+			require.NoError(t, err)
+			require.Equal(t, 1, expected.Equal(actual))
+		}
+	}
+	// Fewer than MsmStrausPoints points use interleaved wNAF
+	expected, err = new(G1).SumOfProducts(points[:8], scalars[:8])
+	require.NoError(t, err)
+	actual, err := new(G1).SumOfProductsVarTime(points[:8], scalars[:8])
+	require.NoError(t, err)
+	require.Equal(t, 1, expected.Equal(actual))
+	_, err = new(G1).MsmVarTime(points, scalars[1:], nil)
+	require.Error(t, err)
+}
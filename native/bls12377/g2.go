@@ -0,0 +1,913 @@
+package bls12377
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+)
+
+var (
+	g2x = fp2{
+		A: fp{
+			0x68904082f268725b,
+			0x668f2ea74f45328b,
+			0xebca7a65802be84f,
+			0x1e1850f4c1ada3e6,
+			0x830dc22d588ef1e9,
+			0x01862a81767c0982,
+		},
+		B: fp{
+			0x5f02a915c91c7f39,
+			0xf8c553ba388da2a7,
+			0xd51a416dbd198850,
+			0xe943c6f38ae3073a,
+			0xffe24aa8259a4981,
+			0x011853391e73dfdd,
+		},
+	}
+	g2y = fp2{
+		A: fp{
+			0xd5b19b897881430f,
+			0x05be9118a5b371ed,
+			0x6063f91f86c131ee,
+			0x3244a61be8f4ec19,
+			0xa02e425b9f9a3a12,
+			0x018af8c04f3360d2,
+		},
+		B: fp{
+			0x57601ac71a5b96f5,
+			0xe99acc1714f2440e,
+			0x2339612f10118ea9,
+			0x8321e68a3b1cd722,
+			0x2b543b050cc74917,
+			0x00590182b396c112,
+		},
+	}
+	// 1 / u
+	curveG2B = fp2{
+		A: fp{},
+		B: fp{
+			0x8072266666666685,
+			0x8df55926899999a9,
+			0x7fe4561ad64f34cf,
+			0xb95da6d8b6e4f01b,
+			0x4b747cccfc142743,
+			0x0039c3fa70f49f43,
+		},
+	}
+	// 3 / u
+	curveG23B = fp2{
+		A: fp{},
+		B: fp{
+			0x815673333333338f,
+			0xa9e00b739cccccfc,
+			0x7fad025082ed9e6e,
+			0x2c18f48a24aed052,
+			0xe25d7666f43c75cb,
+			0x00ad4bef52ddddc9,
+		},
+	}
+	// u^((p-1)/3).
+	psiCoeffX = fp2{
+		A: fp{
+			0x5892506da58478da,
+			0x133366940ac2a74b,
+			0x9b64a150cdf726cf,
+			0x5cc426090a9c587e,
+			0x5cf848adfdcd640c,
+			0x004702bf3ac02380,
+		},
+		B: fp{},
+	}
+	// u^((p-1)/2).
+	psiCoeffY = fp2{
+		A: fp{
+			0x982c13d9d084771f,
+			0xfd49de0c6da34a32,
+			0x61a530d183ab0e53,
+			0xdf8fe44106dd9879,
+			0x40f29b58d88472bc,
+			0x0158723199046d5d,
+		},
+		B: fp{},
+	}
+	// u^((p^2-1)/3).
+	psi2CoeffX = fp2{
+		A: fp{
+			0xdacd106da5847973,
+			0xd8fe2454bac2a79a,
+			0x1ada4fd6fd832edc,
+			0xfb9868449d150908,
+			0xd63eb8aeea32285e,
+			0x0167d6a36f873fd0,
+		},
+		B: fp{},
+	}
+	// Shallue-van de Woestijne constants for y^2 = x^3 + 1/u with Z = 2
+	g2SvdwZ = fp2{
+		A: fp{
+			0x059bfffffffffed0,
+			0xa2813f06ffffff62,
+			0x3efb675314fa7fe4,
+			0xf69d2f6edcf8c60b,
+			0x99e92b7f007909d0,
+			0x011accc3c5fbe934,
+		},
+		B: fp{},
+	}
+	// g(Z)
+	g2SvdwC1 = fp2{
+		A: fp{
+			0x0c5e7ffffffffb3e,
+			0x5bee41939ffffd87,
+			0xbe06d8ecdfd76f92,
+			0xa62f09d571f8f10e,
+			0xdb2ea27b28a194cd,
+			0x010ebe82e86582fc,
+		},
+		B: fp{
+			0x8072266666666685,
+			0x8df55926899999a9,
+			0x7fe4561ad64f34cf,
+			0xb95da6d8b6e4f01b,
+			0x4b747cccfc142743,
+			0x0039c3fa70f49f43,
+		},
+	}
+	// -Z / 2
+	g2SvdwC2 = fp2{
+		A: fp{
+			0x823ac00000000099,
+			0xc5cabdc0b000004f,
+			0x7f75ae862f8c080d,
+			0x9ed4423b9278b089,
+			0x79467000ec64c452,
+			0x0120d3e434c71c50,
+		},
+		B: fp{},
+	}
+	// sqrt(-g(Z) * 3 * Z^2)
+	g2SvdwC3 = fp2{
+		A: fp{
+			0xf2e4443443a239a0,
+			0xb1706a328d2d4baa,
+			0xd408869c3d3950ef,
+			0x8c5b740d00dc2848,
+			0x9c5df20787ab65b2,
+			0x011985aa9c872bda,
+		},
+		B: fp{
+			0x977c4ed70661597a,
+			0xed9b151a15f45247,
+			0xf71665a9a86de5ac,
+			0x62b3059de3585834,
+			0x87d05ad0803bd9ea,
+			0x0110a4dcdaecddb4,
+		},
+	}
+	// -4 * g(Z) / (3 * Z^2)
+	g2SvdwC4 = fp2{
+		A: fp{
+			0xa9e65555555556ec,
+			0xf0b8285195555628,
+			0xd54aa3d0dc13b579,
+			0x2f5ce35adaa5bcaf,
+			0x906d2301e58aff38,
+			0x00c4920317b6df9d,
+		},
+		B: fp{
+			0x56dcddddddddddd4,
+			0x2db2015f37777772,
+			0x8a5a595c4be8b110,
+			0x2041bbb36e056126,
+			0x7e422da67ad9b5fd,
+			0x007c276e8cf025e2,
+		},
+	}
+)
+
+// G2 is a point in g2.
+type G2 struct {
+	x, y, z fp2
+}
+
+// Random creates a random point on the curve
+// from the specified reader.
+func (g2 *G2) Random(reader io.Reader) (*G2, error) {
+	var seed [native.WideField4Bytes]byte
+	n, err := reader.Read(seed[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "random could not read from stream")
+	}
+	if n != native.WideField4Bytes {
+		return nil, fmt.Errorf("insufficient bytes read %d when %d are needed", n, WideFieldBytes)
+	}
+	dst := []byte("BLS12377G2_XMD:SHA-256_SVDW_RO_")
+	return g2.Hash(native.EllipticPointHasherSha256(), seed[:], dst), nil
+}
+
+// Hash uses the hasher to map bytes to a valid point.
+// BLS12-377 has no suite with an SSWU map in RFC 9380 so this
+// uses the Shallue-van de Woestijne map from section 6.6.1 with Z = 2.
+func (g2 *G2) Hash(hash *native.EllipticPointHasher, msg, dst []byte) *G2 {
+	var u []byte
+	var u0, u1 fp2
+	var q0, q1 G2
+
+	switch hash.Type() {
+	case native.XMD:
+		u = native.ExpandMsgXmd(hash, msg, dst, 4*hashBytes)
+	case native.XOF:
+		u = native.ExpandMsgXof(hash, msg, dst, 4*hashBytes)
+	}
+
+	var buf [WideFieldBytes]byte
+	copy(buf[:hashBytes], internal.ReverseBytes(u[:hashBytes]))
+	u0.A.SetBytesWide(&buf)
+	copy(buf[:hashBytes], internal.ReverseBytes(u[hashBytes:2*hashBytes]))
+	u0.B.SetBytesWide(&buf)
+	copy(buf[:hashBytes], internal.ReverseBytes(u[2*hashBytes:3*hashBytes]))
+	u1.A.SetBytesWide(&buf)
+	copy(buf[:hashBytes], internal.ReverseBytes(u[3*hashBytes:]))
+	u1.B.SetBytesWide(&buf)
+
+	q0.svdw(&u0)
+	q1.svdw(&u1)
+	g2.Add(&q0, &q1)
+	return g2.ClearCofactor(g2)
+}
+
+// Identity returns the identity point.
+func (g2 *G2) Identity() *G2 {
+	g2.x.SetZero()
+	g2.y.SetOne()
+	g2.z.SetZero()
+	return g2
+}
+
+// Generator returns the base point.
+func (g2 *G2) Generator() *G2 {
+	g2.x.Set(&g2x)
+	g2.y.Set(&g2y)
+	g2.z.SetOne()
+	return g2
+}
+
+// IsIdentity returns true if this point is at infinity.
+func (g2 *G2) IsIdentity() int {
+	return g2.z.IsZero()
+}
+
+// IsOnCurve determines if this point represents a valid curve point.
+func (g2 *G2) IsOnCurve() int {
+	// Y^2 Z = X^3 + b Z^3
+	var lhs, rhs, t fp2
+	lhs.Square(&g2.y)
+	lhs.Mul(&lhs, &g2.z)
+
+	rhs.Square(&g2.x)
+	rhs.Mul(&rhs, &g2.x)
+	t.Square(&g2.z)
+	t.Mul(&t, &g2.z)
+	t.Mul(&t, &curveG2B)
+	rhs.Add(&rhs, &t)
+
+	return lhs.Equal(&rhs)
+}
+
+// InCorrectSubgroup returns 1 if the point is torsion free, 0 otherwise.
+func (g2 *G2) InCorrectSubgroup() int {
+	var t G2
+	t.multiply(g2, &fqModulusBytes)
+	return t.IsIdentity()
+}
+
+// Add adds this point to another point.
+func (g2 *G2) Add(arg1, arg2 *G2) *G2 {
+	// Algorithm 7, https://eprint.iacr.org/2015/1060.pdf
+	var t0, t1, t2, t3, t4, x3, y3, z3 fp2
+
+	t0.Mul(&arg1.x, &arg2.x)
+	t1.Mul(&arg1.y, &arg2.y)
+	t2.Mul(&arg1.z, &arg2.z)
+	t3.Add(&arg1.x, &arg1.y)
+	t4.Add(&arg2.x, &arg2.y)
+	t3.Mul(&t3, &t4)
+	t4.Add(&t0, &t1)
+	t3.Sub(&t3, &t4)
+	t4.Add(&arg1.y, &arg1.z)
+	x3.Add(&arg2.y, &arg2.z)
+	t4.Mul(&t4, &x3)
+	x3.Add(&t1, &t2)
+	t4.Sub(&t4, &x3)
+	x3.Add(&arg1.x, &arg1.z)
+	y3.Add(&arg2.x, &arg2.z)
+	x3.Mul(&x3, &y3)
+	y3.Add(&t0, &t2)
+	y3.Sub(&x3, &y3)
+	x3.Double(&t0)
+	t0.Add(&t0, &x3)
+	t2.MulBy3b(&t2)
+	z3.Add(&t1, &t2)
+	t1.Sub(&t1, &t2)
+	y3.MulBy3b(&y3)
+	x3.Mul(&t4, &y3)
+	t2.Mul(&t3, &t1)
+	x3.Sub(&t2, &x3)
+	y3.Mul(&y3, &t0)
+	t1.Mul(&t1, &z3)
+	y3.Add(&t1, &y3)
+	t0.Mul(&t0, &t3)
+	z3.Mul(&z3, &t4)
+	z3.Add(&z3, &t0)
+
+	g2.x.Set(&x3)
+	g2.y.Set(&y3)
+	g2.z.Set(&z3)
+	return g2
+}
+
+// Sub subtracts the two points.
+func (g2 *G2) Sub(arg1, arg2 *G2) *G2 {
+	var t G2
+	t.Neg(arg2)
+	return g2.Add(arg1, &t)
+}
+
+// Double this point.
+func (g2 *G2) Double(a *G2) *G2 {
+	// Algorithm 9, https://eprint.iacr.org/2015/1060.pdf
+	var t0, t1, t2, x3, y3, z3 fp2
+
+	t0.Square(&a.y)
+	z3.Double(&t0)
+	z3.Double(&z3)
+	z3.Double(&z3)
+	t1.Mul(&a.y, &a.z)
+	t2.Square(&a.z)
+	t2.MulBy3b(&t2)
+	x3.Mul(&t2, &z3)
+	y3.Add(&t0, &t2)
+	z3.Mul(&t1, &z3)
+	t1.Double(&t2)
+	t2.Add(&t2, &t1)
+	t0.Sub(&t0, &t2)
+	y3.Mul(&t0, &y3)
+	y3.Add(&y3, &x3)
+	t1.Mul(&a.x, &a.y)
+	x3.Mul(&t0, &t1)
+	x3.Double(&x3)
+
+	e := a.IsIdentity()
+	g2.x.CMove(&x3, t0.SetZero(), e)
+	g2.z.CMove(&z3, &t0, e)
+	g2.y.CMove(&y3, t0.SetOne(), e)
+	return g2
+}
+
+// Mul multiplies this point by the input scalar.
+func (g2 *G2) Mul(a *G2, s *native.Field4) *G2 {
+	bytes := s.Bytes()
+	return g2.multiply(a, &bytes)
+}
+
+func (g2 *G2) multiply(a *G2, bytes *[native.Field4Bytes]byte) *G2 {
+	var p G2
+	precomputed := [16]*G2{}
+	precomputed[0] = new(G2).Identity()
+	precomputed[1] = new(G2).Set(a)
+	for i := 2; i < 16; i += 2 {
+		precomputed[i] = new(G2).Double(precomputed[i>>1])
+		precomputed[i+1] = new(G2).Add(precomputed[i], a)
+	}
+	p.Identity()
+	for i := 0; i < 256; i += 4 {
+		// Brouwer / windowing method. window size of 4.
+		for j := 0; j < 4; j++ {
+			p.Double(&p)
+		}
+		window := bytes[32-1-i>>3] >> (4 - i&0x04) & 0x0F
+		p.Add(&p, precomputed[window])
+	}
+	return g2.Set(&p)
+}
+
+// MulByX multiplies by BLS X using double and add.
+func (g2 *G2) MulByX(a *G2) *G2 {
+	var s, t, r G2
+	r.Identity()
+	t.Set(a)
+
+	for x := paramX; x != 0; x >>= 1 {
+		s.Add(&r, &t)
+		r.CMove(&r, &s, int(x&1))
+		t.Double(&t)
+	}
+	return g2.Set(&r)
+}
+
+// ClearCofactor using [Budroni-Pintore](https://ia.cr/2017/419).
+// This is equivalent to multiplying by h_{eff} = 3(z^2 - 1) * h_2
+// where h_2 is the cofactor of G_2 and z is the parameter of BLS12-377.
+func (g2 *G2) ClearCofactor(a *G2) *G2 {
+	var t1, t2, t3, pt G2
+
+	t1.MulByX(a)
+	t2.psi(a)
+
+	pt.Double(a)
+	pt.psi2(&pt)
+
+	t3.Add(&t1, &t2)
+	t3.MulByX(&t3)
+
+	pt.Add(&pt, &t3)
+	pt.Sub(&pt, &t1)
+	pt.Sub(&pt, &t2)
+	pt.Sub(&pt, a)
+	return g2.Set(&pt)
+}
+
+// Neg negates this point.
+func (g2 *G2) Neg(a *G2) *G2 {
+	g2.Set(a)
+	g2.y.CNeg(&a.y, -(a.IsIdentity() - 1))
+	return g2
+}
+
+// Set copies a into g2.
+func (g2 *G2) Set(a *G2) *G2 {
+	g2.x.Set(&a.x)
+	g2.y.Set(&a.y)
+	g2.z.Set(&a.z)
+	return g2
+}
+
+// BigInt returns the x and y as big.Ints in affine.
+func (*G2) BigInt() (x, y *big.Int) {
+	var t G2
+	out := t.ToUncompressed()
+	x = new(big.Int).SetBytes(out[:WideFieldBytes])
+	y = new(big.Int).SetBytes(out[WideFieldBytes:])
+	return x, y
+}
+
+// SetBigInt creates a point from affine x, y
+// and returns the point if it is on the curve.
+func (g2 *G2) SetBigInt(x, y *big.Int) (*G2, error) {
+	var tt [DoubleWideFieldBytes]byte
+
+	if len(x.Bytes()) == 0 && len(y.Bytes()) == 0 {
+		return g2.Identity(), nil
+	}
+	x.FillBytes(tt[:WideFieldBytes])
+	y.FillBytes(tt[WideFieldBytes:])
+
+	return g2.FromUncompressed(&tt)
+}
+
+// ToCompressed serializes this element into compressed form.
+func (g2 *G2) ToCompressed() [WideFieldBytes]byte {
+	var out [WideFieldBytes]byte
+	var t G2
+	t.ToAffine(g2)
+	xABytes := t.x.A.Bytes()
+	xBBytes := t.x.B.Bytes()
+	copy(out[:FieldBytes], internal.ReverseBytes(xBBytes[:]))
+	copy(out[FieldBytes:], internal.ReverseBytes(xABytes[:]))
+	isInfinity := byte(g2.IsIdentity())
+	// Compressed flag
+	out[0] |= 1 << 7
+	// Is infinity
+	out[0] |= (1 << 6) & -isInfinity
+	// Sign of y only set if not infinity
+	out[0] |= (byte(t.y.LexicographicallyLargest()) << 5) & (isInfinity - 1)
+	return out
+}
+
+// FromCompressed deserializes this element from compressed form.
+func (g2 *G2) FromCompressed(input *[WideFieldBytes]byte) (*G2, error) {
+	var xFp, yFp fp2
+	var xA, xB [FieldBytes]byte
+	var p G2
+	compressedFlag := int((input[0] >> 7) & 1)
+	infinityFlag := int((input[0] >> 6) & 1)
+	sortFlag := int((input[0] >> 5) & 1)
+
+	if compressedFlag != 1 {
+		return nil, errors.New("compressed flag must be set")
+	}
+
+	if infinityFlag == 1 {
+		return g2.Identity(), nil
+	}
+
+	copy(xB[:], internal.ReverseBytes(input[:FieldBytes]))
+	copy(xA[:], internal.ReverseBytes(input[FieldBytes:]))
+	// Mask away the flag bits
+	xB[FieldBytes-1] &= 0x1F
+	_, validA := xFp.A.SetBytes(&xA)
+	_, validB := xFp.B.SetBytes(&xB)
+
+	if validA&validB != 1 {
+		return nil, errors.New("invalid bytes - not in field")
+	}
+
+	// Recover a y-coordinate given x by y = sqrt(x^3 + b)
+	yFp.Square(&xFp)
+	yFp.Mul(&yFp, &xFp)
+	yFp.Add(&yFp, &curveG2B)
+
+	if _, wasSquare := yFp.Sqrt(&yFp); wasSquare != 1 {
+		return nil, errors.New("point is not on the curve")
+	}
+
+	yFp.CNeg(&yFp, yFp.LexicographicallyLargest()^sortFlag)
+	p.x.Set(&xFp)
+	p.y.Set(&yFp)
+	p.z.SetOne()
+	if p.InCorrectSubgroup() == 0 {
+		return nil, errors.New("point is not in correct subgroup")
+	}
+	return g2.Set(&p), nil
+}
+
+// ToUncompressed serializes this element into uncompressed form.
+func (g2 *G2) ToUncompressed() [DoubleWideFieldBytes]byte {
+	var out [DoubleWideFieldBytes]byte
+	var t G2
+	t.ToAffine(g2)
+	bytes := t.x.B.Bytes()
+	copy(out[:FieldBytes], internal.ReverseBytes(bytes[:]))
+	bytes = t.x.A.Bytes()
+	copy(out[FieldBytes:WideFieldBytes], internal.ReverseBytes(bytes[:]))
+	bytes = t.y.B.Bytes()
+	copy(out[WideFieldBytes:WideFieldBytes+FieldBytes], internal.ReverseBytes(bytes[:]))
+	bytes = t.y.A.Bytes()
+	copy(out[WideFieldBytes+FieldBytes:], internal.ReverseBytes(bytes[:]))
+	isInfinity := byte(g2.IsIdentity())
+	out[0] |= (1 << 6) & -isInfinity
+	return out
+}
+
+// FromUncompressed deserializes this element from uncompressed form.
+func (g2 *G2) FromUncompressed(input *[DoubleWideFieldBytes]byte) (*G2, error) {
+	var a, b fp
+	var t [FieldBytes]byte
+	var p G2
+	infinityFlag := int((input[0] >> 6) & 1)
+
+	if infinityFlag == 1 {
+		return g2.Identity(), nil
+	}
+
+	copy(t[:], internal.ReverseBytes(input[:FieldBytes]))
+	// Mask away top bits
+	t[FieldBytes-1] &= 0x1F
+
+	_, valid := b.SetBytes(&t)
+	if valid == 0 {
+		return nil, errors.New("invalid bytes - x.B not in field")
+	}
+	copy(t[:], internal.ReverseBytes(input[FieldBytes:WideFieldBytes]))
+	_, valid = a.SetBytes(&t)
+	if valid == 0 {
+		return nil, errors.New("invalid bytes - x.A not in field")
+	}
+
+	p.x.B.Set(&b)
+	p.x.A.Set(&a)
+
+	copy(t[:], internal.ReverseBytes(input[WideFieldBytes:WideFieldBytes+FieldBytes]))
+	_, valid = b.SetBytes(&t)
+	if valid == 0 {
+		return nil, errors.New("invalid bytes - y.B not in field")
+	}
+	copy(t[:], internal.ReverseBytes(input[FieldBytes+WideFieldBytes:]))
+	_, valid = a.SetBytes(&t)
+	if valid == 0 {
+		return nil, errors.New("invalid bytes - y.A not in field")
+	}
+
+	p.y.B.Set(&b)
+	p.y.A.Set(&a)
+	p.z.SetOne()
+
+	if p.IsOnCurve() == 0 {
+		return nil, errors.New("point is not on the curve")
+	}
+	if p.InCorrectSubgroup() == 0 {
+		return nil, errors.New("point is not in correct subgroup")
+	}
+	return g2.Set(&p), nil
+}
+
+// ToAffine converts the point into affine coordinates.
+// Points that are already affine are copied without an inversion.
+func (g2 *G2) ToAffine(a *G2) *G2 {
+	if a.z.IsOne() == 1 {
+		return g2.Set(a)
+	}
+	var wasInverted int
+	var zero, x, y, z fp2
+	_, wasInverted = z.Invert(&a.z)
+	x.Mul(&a.x, &z)
+	y.Mul(&a.y, &z)
+
+	g2.x.CMove(&zero, &x, wasInverted)
+	g2.y.CMove(&zero, &y, wasInverted)
+	g2.z.CMove(&zero, z.SetOne(), wasInverted)
+	return g2
+}
+
+// BatchToAffineG2 returns the points converted to affine coordinates
+// using Montgomery's trick to share a single field inversion between them.
+// The identity is converted the same as ToAffine.
+func BatchToAffineG2(points []*G2) []*G2 {
+	var t, v, inv fp2
+	out := make([]*G2, len(points))
+	// acc[i] is the product of the non-zero z coordinates before i
+	acc := make([]fp2, len(points))
+	t.SetOne()
+	for i, p := range points {
+		acc[i] = t
+		v.CMove(v.SetOne(), &p.z, p.z.IsZero()^1)
+		t.Mul(&t, &v)
+	}
+	inv.Invert(&t)
+	for i := len(points) - 1; i >= 0; i-- {
+		p := points[i]
+		nonZero := p.z.IsZero() ^ 1
+		v.CMove(v.SetOne(), &p.z, nonZero)
+		t.Mul(&inv, &acc[i])
+		inv.Mul(&inv, &v)
+		t.CMove(new(fp2), &t, nonZero)
+
+		// The identity has a zero inverse so x and y become zero
+		out[i] = new(G2)
+		out[i].x.Mul(&p.x, &t)
+		out[i].y.Mul(&p.y, &t)
+		out[i].z.CMove(new(fp2), v.SetOne(), nonZero)
+	}
+	return out
+}
+
+// GetX returns the affine X coordinate.
+func (g2 *G2) GetX() *fp2 {
+	var t G2
+	t.ToAffine(g2)
+	return &t.x
+}
+
+// GetY returns the affine Y coordinate.
+func (g2 *G2) GetY() *fp2 {
+	var t G2
+	t.ToAffine(g2)
+	return &t.y
+}
+
+// Equal returns 1 if the two points are equal 0 otherwise.
+func (g2 *G2) Equal(rhs *G2) int {
+	var x1, x2, y1, y2 fp2
+	var e1, e2 int
+
+	// This technique avoids inversions
+	x1.Mul(&g2.x, &rhs.z)
+	x2.Mul(&rhs.x, &g2.z)
+
+	y1.Mul(&g2.y, &rhs.z)
+	y2.Mul(&rhs.y, &g2.z)
+
+	e1 = g2.z.IsZero()
+	e2 = rhs.z.IsZero()
+
+	// Both at infinity or coordinates are the same
+	return (e1 & e2) | (^e1 & ^e2)&x1.Equal(&x2)&y1.Equal(&y2)
+}
+
+// CMove sets g2 = arg1 if choice == 0 and g2 = arg2 if choice == 1.
+func (g2 *G2) CMove(arg1, arg2 *G2, choice int) *G2 {
+	g2.x.CMove(&arg1.x, &arg2.x, choice)
+	g2.y.CMove(&arg1.y, &arg2.y, choice)
+	g2.z.CMove(&arg1.z, &arg2.z, choice)
+	return g2
+}
+
+// SumOfProducts computes the multi-exponentiation for the specified
+// points and scalars and stores the result in `g2`.
+// Returns an error if the lengths of the arguments is not equal.
+func (g2 *G2) SumOfProducts(points []*G2, scalars []*native.Field4) (*G2, error) {
+	const Upper = 256
+	const W = 4
+	const Windows = Upper / W // careful--use ceiling division in case this doesn't divide evenly
+	var sum G2
+	if len(points) != len(scalars) {
+		return nil, fmt.Errorf("length mismatch")
+	}
+
+	bucketSize := 1 << W
+	windows := make([]G2, Windows)
+	bytes := make([][32]byte, len(scalars))
+	buckets := make([]G2, bucketSize)
+	for i := 0; i < len(windows); i++ {
+		windows[i].Identity()
+	}
+
+	for i, scalar := range scalars {
+		bytes[i] = scalar.Bytes()
+	}
+
+	for j := 0; j < len(windows); j++ {
+		for i := 0; i < bucketSize; i++ {
+			buckets[i].Identity()
+		}
+
+		for i := 0; i < len(scalars); i++ {
+			// j*W to get the nibble
+			// >> 3 to convert to byte, / 8
+			// (W * j & W) gets the nibble, mod W
+			// 1 << W - 1 to get the offset
+			index := bytes[i][j*W>>3] >> (W * j & W) & (1<<W - 1) // little-endian
+			buckets[index].Add(&buckets[index], points[i])
+		}
+
+		sum.Identity()
+
+		for i := bucketSize - 1; i > 0; i-- {
+			sum.Add(&sum, &buckets[i])
+			windows[j].Add(&windows[j], &sum)
+		}
+	}
+
+	g2.Identity()
+	for i := len(windows) - 1; i >= 0; i-- {
+		for j := 0; j < W; j++ {
+			g2.Double(g2)
+		}
+
+		g2.Add(g2, &windows[i])
+	}
+	return g2, nil
+}
+
+// SumOfProductsVarTime computes the multi-exponentiation for the specified
+// points and scalars and stores the result in `g2`. Fewer than
+// MsmStrausPoints points use interleaved wNAF and larger inputs use
+// Pippenger's bucket method. It must only be used with public scalars.
+// Returns an error if the lengths of the arguments is not equal.
+func (g2 *G2) SumOfProductsVarTime(points []*G2, scalars []*native.Field4) (*G2, error) {
+	return g2.MsmVarTime(points, scalars, nil)
+}
+
+// MsmVarTime is SumOfProductsVarTime with options to choose the window
+// size and split the work across goroutines. Setting the window always
+// uses Pippenger's bucket method.
+func (g2 *G2) MsmVarTime(points []*G2, scalars []*native.Field4, opts *native.MsmOptions) (*G2, error) {
+	if len(points) != len(scalars) {
+		return nil, fmt.Errorf("length mismatch")
+	}
+	if len(points) < native.MsmStrausPoints && (opts == nil || opts.Window == 0) {
+		return g2.strausVarTime(points, scalars), nil
+	}
+	affine, nScalars := g2MsmInputs(points, scalars)
+	return g2.Set(native.Msm[g2Affine, *G2](g2Msm{}, affine, nScalars, opts)), nil
+}
+
+// strausVarTime computes the sum of points[i] * scalars[i] by interleaving
+// the width WnafWidth NAFs of the scalars so all the points share the doublings.
+func (g2 *G2) strausVarTime(points []*G2, scalars []*native.Field4) *G2 {
+	var p, t G2
+	tables := make([][]G2, 0, len(points))
+	digits := make([][]int8, 0, len(points))
+	for i, point := range points {
+		if point.IsIdentity() == 1 {
+			continue
+		}
+		table := g2WnafTable(point)
+		var k [native.Field4Limbs]uint64
+		scalars[i].Arithmetic.FromMontgomery(&k, &scalars[i].Value)
+		tables = append(tables, table)
+		digits = append(digits, native.Wnaf(k[:], native.WnafWidth))
+	}
+
+	top := 0
+	for _, d := range digits {
+		if len(d) > top {
+			top = len(d)
+		}
+	}
+	p.Identity()
+	for i := top - 1; i >= 0; i-- {
+		p.Double(&p)
+		for j, d := range digits {
+			if i >= len(d) || d[i] == 0 {
+				continue
+			}
+			if d[i] > 0 {
+				p.Add(&p, &tables[j][d[i]>>1])
+			} else {
+				p.Add(&p, t.Neg(&tables[j][-d[i]>>1]))
+			}
+		}
+	}
+	return g2.Set(&p)
+}
+
+// g2WnafTable returns the odd multiples a, 3*a, ... (2^(WnafWidth-1) - 1)*a
+// indexed by the absolute wNAF digit / 2.
+func g2WnafTable(a *G2) []G2 {
+	var double G2
+	table := make([]G2, 1<<(native.WnafWidth-2))
+	table[0].Set(a)
+	double.Double(a)
+	for i := 1; i < len(table); i++ {
+		table[i].Add(&table[i-1], &double)
+	}
+	return table
+}
+
+func (g2 *G2) psi(a *G2) *G2 {
+	g2.x.FrobeniusMap(&a.x)
+	g2.y.FrobeniusMap(&a.y)
+	// z = frobenius(z)
+	g2.z.FrobeniusMap(&a.z)
+
+	// x = frobenius(x) * u^((p-1)/3)
+	g2.x.Mul(&g2.x, &psiCoeffX)
+	// y = frobenius(y) * u^((p-1)/2)
+	g2.y.Mul(&g2.y, &psiCoeffY)
+
+	return g2
+}
+
+func (g2 *G2) psi2(a *G2) *G2 {
+	// x = frobenius^2(x) * u^((p^2-1)/3); note that q^2 is the order of the field.
+	g2.x.Mul(&a.x, &psi2CoeffX)
+	// y = -frobenius^2(y); note that q^2 is the order of the field.
+	g2.y.Neg(&a.y)
+	g2.z.Set(&a.z)
+	return g2
+}
+
+func (g2 *G2) svdw(u *fp2) *G2 {
+	// Straight-line Shallue-van de Woestijne method taken from
+	// section F.1 in <https://www.rfc-editor.org/rfc/rfc9380.html>
+	var tv1, tv2, tv3, tv4, x1, x2, x3, gx1, gx2, gx, x, y, t, one fp2
+	one.SetOne()
+
+	// tv1 = u^2 * c1
+	tv1.Square(u)
+	tv1.Mul(&tv1, &g2SvdwC1)
+	// tv2 = 1 + tv1
+	tv2.Add(&one, &tv1)
+	// tv1 = 1 - tv1
+	tv1.Sub(&one, &tv1)
+	// tv3 = inv0(tv1 * tv2)
+	t.Mul(&tv1, &tv2)
+	tv3.Invert(&t)
+	// tv4 = u * tv1 * tv3 * c3
+	tv4.Mul(u, &tv1)
+	tv4.Mul(&tv4, &tv3)
+	tv4.Mul(&tv4, &g2SvdwC3)
+
+	// x1 = c2 - tv4
+	x1.Sub(&g2SvdwC2, &tv4)
+	gx1.Square(&x1)
+	gx1.Mul(&gx1, &x1)
+	gx1.Add(&gx1, &curveG2B)
+	_, e1 := t.Sqrt(&gx1)
+
+	// x2 = c2 + tv4
+	x2.Add(&g2SvdwC2, &tv4)
+	gx2.Square(&x2)
+	gx2.Mul(&gx2, &x2)
+	gx2.Add(&gx2, &curveG2B)
+	_, e2 := t.Sqrt(&gx2)
+	e2 &= e1 ^ 1
+
+	// x3 = (tv2^2 * tv3)^2 * c4 + Z
+	x3.Square(&tv2)
+	x3.Mul(&x3, &tv3)
+	x3.Square(&x3)
+	x3.Mul(&x3, &g2SvdwC4)
+	x3.Add(&x3, &g2SvdwZ)
+
+	x.CMove(&x3, &x1, e1)
+	x.CMove(&x, &x2, e2)
+	gx.Square(&x)
+	gx.Mul(&gx, &x)
+	gx.Add(&gx, &curveG2B)
+	_, _ = y.Sqrt(&gx)
+
+	y.CNeg(&y, u.Sgn0()^y.Sgn0())
+
+	g2.x.Set(&x)
+	g2.y.Set(&y)
+	g2.z.SetOne()
+	return g2
+}
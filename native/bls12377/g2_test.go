@@ -0,0 +1,196 @@
+package bls12377
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikelodder7/curvey/native"
+)
+
+func TestG2Generator(t *testing.T) {
+	g := new(G2).Generator()
+	require.Equal(t, 1, g.IsOnCurve())
+	require.Equal(t, 1, g.InCorrectSubgroup())
+	require.Equal(t, 1, new(G2).Identity().IsOnCurve())
+
+	expected := "00ea6040e700403170dc5a51b1b140d5532777ee6651cecbe7223ece0799c9de5cf89984bff76fe6b26bfefa6ea16afe" +
+		"018480be71c785fec89630a2a3841d01c565f071203e50317ea501f557db6b9b71889f52bb53540274e3e48f7c005196" +
+		"00f8169fd28355189e549da3151a70aa61ef11ac3d591bf12463b01acee304c24279b83f5e52270bd9a1cdd185eb8f93" +
+		"00690d665d446f7bd960736bcbb2efb4de03ed7274b49a58e458c282f832d204f2cf88886d8c7c2ef094094409fd4ddf"
+	out := g.ToUncompressed()
+	require.Equal(t, expected, hex.EncodeToString(out[:]))
+}
+
+func TestG2Arithmetic(t *testing.T) {
+	g := new(G2).Generator()
+	a := new(G2).Double(g)
+	b := new(G2).Add(g, g)
+	require.Equal(t, 1, a.Equal(b))
+	b.Mul(g, FqNew().SetUint64(2))
+	require.Equal(t, 1, a.Equal(b))
+	b.Sub(a, g)
+	require.Equal(t, 1, g.Equal(b))
+
+	b.Add(g, new(G2).Neg(g))
+	require.Equal(t, 1, b.IsIdentity())
+
+	q := FqNew().SetOne()
+	q.Neg(q)
+	b.Mul(g, q)
+	require.Equal(t, 1, b.Equal(new(G2).Neg(g)))
+
+	a.MulByX(g)
+	b.Mul(g, FqNew().SetUint64(paramX))
+	require.Equal(t, 1, a.Equal(b))
+}
+
+func TestG2Psi(t *testing.T) {
+	// ψ acts on G2 as multiplication by p mod r
+	g := new(G2).Generator()
+	a := new(G2).psi(g)
+	require.Equal(t, 1, a.IsOnCurve())
+	pModR := FqNew().SetBigInt(biModulus)
+	b := new(G2).Mul(g, pModR)
+	require.Equal(t, 1, a.Equal(b))
+
+	a.psi2(g)
+	b.Mul(b, pModR)
+	require.Equal(t, 1, a.Equal(b))
+}
+
+func TestG2ClearCofactor(t *testing.T) {
+	var u fp2
+	var p G2
+	for i := 0; i < 5; i++ {
+		_, _ = u.Random(crand.Reader)
+		p.svdw(&u)
+		require.Equal(t, 1, p.IsOnCurve())
+		p.ClearCofactor(&p)
+		require.Equal(t, 1, p.IsOnCurve())
+		require.Equal(t, 1, p.InCorrectSubgroup())
+	}
+}
+
+func TestG2Hash(t *testing.T) {
+	dst := []byte("BLS12377G2_XMD:SHA-256_SVDW_RO_")
+	tests := []struct {
+		input, expected string
+	}{
+		{"", "003eb2024ae5a726d6b085653e86d94ed500500b3f093c57c0b090b12bba16c1122d921bbf8a3ef241528e4b46cf82580158efbad1914a7e616504f11af2ecdc79ae893bbdcf6d136f1e7faf8585c036cce8402afb95e089676076186731d204007408c50628468c51751674f612f81c087de903a4351d15f3b4b147d5f169590fc75696ed16d8bdc87fa8300f40f13d0016aab20a5b12c9aa642e95dec0ddaf41e8f25bfb82802bdd02d017d6cec95db934424279b581eb25f3c92f2a937fa5"},
+		{"abc", "00a6e14ef187f05c5e950b76771fe8ef89f7773a19602d5d160f8e81c24ecf03b8a7d639bcefdb33d86ac86d750b168700fcb203855d8477e22fb133071fe042fda9657ad147d2b75213031f86c9c85736cd553fde70c6246150a3f4e7b6a2b2007b71385ba856796316c62bd4318f52ae1c995d03551aae29a0ebd9c61b5cd579cb8f3fa160d8872f20f9443076100c0052190dfb3f538d63cb3e16aeea5fef199fa8e566499b5281dd3ae4ca8f326925d2c5e74b3efc4dc2261155695fd9af"},
+	}
+
+	pt := new(G2).Identity()
+	for _, tst := range tests {
+		pt.Hash(native.EllipticPointHasherSha256(), []byte(tst.input), dst)
+		require.Equal(t, 1, pt.InCorrectSubgroup())
+		out := pt.ToUncompressed()
+		require.Equal(t, tst.expected, hex.EncodeToString(out[:]))
+	}
+}
+
+func TestG2Serialization(t *testing.T) {
+	a, _ := new(G2).Random(crand.Reader)
+
+	aBytes := a.ToCompressed()
+	aa, err := new(G2).FromCompressed(&aBytes)
+	require.NoError(t, err)
+	require.Equal(t, 1, a.Equal(aa))
+
+	auBytes := a.ToUncompressed()
+	_, err = aa.FromUncompressed(&auBytes)
+	require.NoError(t, err)
+	require.Equal(t, 1, a.Equal(aa))
+
+	a.Neg(a)
+	bBytes := a.ToCompressed()
+	require.NotEqual(t, aBytes[0], bBytes[0])
+	_, err = aa.FromCompressed(&bBytes)
+	require.NoError(t, err)
+	require.Equal(t, 1, a.Equal(aa))
+
+	id := new(G2).Identity()
+	idBytes := id.ToUncompressed()
+	require.Equal(t, byte(0x40), idBytes[0])
+	_, err = aa.FromUncompressed(&idBytes)
+	require.NoError(t, err)
+	require.Equal(t, 1, aa.IsIdentity())
+	idc := id.ToCompressed()
+	require.Equal(t, byte(0xc0), idc[0])
+	_, err = aa.FromCompressed(&idc)
+	require.NoError(t, err)
+	require.Equal(t, 1, aa.IsIdentity())
+
+	// A point on the twist that is not in the r-torsion must be rejected
+	var u fp2
+	var p G2
+	for {
+		_, _ = u.Random(crand.Reader)
+		p.svdw(&u)
+		if p.InCorrectSubgroup() == 0 {
+			break
+		}
+	}
+	pBytes := p.ToUncompressed()
+	_, err = aa.FromUncompressed(&pBytes)
+	require.Error(t, err)
+	pc := p.ToCompressed()
+	_, err = aa.FromCompressed(&pc)
+	require.Error(t, err)
+}
+
+func TestG2SumOfProducts(t *testing.T) {
+	var b [64]byte
+	h0, _ := new(G2).Random(crand.Reader)
+	_, _ = crand.Read(b[:])
+	s := FqNew().SetBytesWide(&b)
+	_, _ = crand.Read(b[:])
+	c := FqNew().SetBytesWide(&b)
+
+	lhs := new(G2).Mul(h0, s)
+	lhs.Add(lhs, new(G2).Mul(h0, c))
+	rhs, err := new(G2).SumOfProducts([]*G2{h0, h0}, []*native.Field4{s, c})
+	require.NoError(t, err)
+	require.Equal(t, 1, lhs.Equal(rhs))
+}
+
+func TestG2Msm(t *testing.T) {
+	var b [64]byte
+	points := make([]*G2, 64)
+	scalars := make([]*native.Field4, 64)
+	for i := range points {
+		_, _ = crand.Read(b[:])
+		points[i], _ = new(G2).Random(crand.Reader)
+		scalars[i] = FqNew().SetBytesWide(&b)
+	}
+	// Repeated and opposite points exercise doubling and
+	// cancellation when the buckets are summed in affine
+	points[1] = points[0]
+	points[2] = new(G2).Neg(points[0])
+	points[3] = new(G2).Double(points[4])
+	points[5] = new(G2).Identity()
+	scalars[1] = scalars[0]
+	scalars[2] = scalars[0]
+	scalars[6].SetZero()
+	expected, err := new(G2).SumOfProducts(points, scalars)
+	require.NoError(t, err)
+
+	for _, window := range []int{0, 2, 5, 11} {
+		for _, workers := range []int{0, 3, 100} {
+			actual, err := new(G2).MsmVarTime(points, scalars, &native.MsmOptions{Window: window, Workers: workers})
+			require.NoError(t, err)
+			require.Equal(t, 1, expected.Equal(actual))
+		}
+	}
+	// Fewer than MsmStrausPoints points use interleaved wNAF
+	expected, err = new(G2).SumOfProducts(points[:8], scalars[:8])
+	require.NoError(t, err)
+	actual, err := new(G2).SumOfProductsVarTime(points[:8], scalars[:8])
+	require.NoError(t, err)
+	require.Equal(t, 1, expected.Equal(actual))
+	_, err = new(G2).MsmVarTime(points, scalars[1:], nil)
+	require.Error(t, err)
+}
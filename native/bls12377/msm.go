@@ -0,0 +1,219 @@
+package bls12377
+
+import (
+	"github.com/mikelodder7/curvey/native"
+)
+
+// g1Affine is an affine point in G1.
+type g1Affine struct {
+	x, y fp
+}
+
+// g1Msm implements native.MsmCurve for G1.
+type g1Msm struct{}
+
+func (g1Msm) BatchAdd(out, lhs, rhs []g1Affine, ok []bool) {
+	acc := make([]fp, len(lhs))
+	var t, inv, lambda, x, y fp
+
+	// Montgomery's trick, acc[i] is the product of the first i+1 denominators
+	t.SetOne()
+	for i := range lhs {
+		ok[i] = true
+		if lhs[i].x.Equal(&rhs[i].x) == 1 {
+			if lhs[i].y.Equal(&rhs[i].y)&lhs[i].y.IsNonZero() == 0 {
+				// P + -P is the identity, use 1 so the product is invertible
+				ok[i] = false
+				acc[i] = t
+				continue
+			}
+			// The tangent slope has denominator 2y
+			inv.Double(&lhs[i].y)
+		} else {
+			inv.Sub(&rhs[i].x, &lhs[i].x)
+		}
+		t.Mul(&t, &inv)
+		acc[i] = t
+	}
+	inv.Invert(&t)
+
+	for i := len(lhs) - 1; i >= 0; i-- {
+		if !ok[i] {
+			continue
+		}
+		// Remove this denominator from the inverse before using it
+		if i > 0 {
+			t.Mul(&inv, &acc[i-1])
+		} else {
+			t.Set(&inv)
+		}
+		if lhs[i].x.Equal(&rhs[i].x) == 1 {
+			// lambda = 3x^2 / 2y
+			x.Square(&lhs[i].x)
+			y.Double(&x)
+			x.Add(&y, &x)
+			lambda.Mul(&x, &t)
+			y.Double(&lhs[i].y)
+		} else {
+			// lambda = (y2 - y1) / (x2 - x1)
+			y.Sub(&rhs[i].y, &lhs[i].y)
+			lambda.Mul(&y, &t)
+			y.Sub(&rhs[i].x, &lhs[i].x)
+		}
+		inv.Mul(&inv, &y)
+
+		// x3 = lambda^2 - x1 - x2
+		// y3 = lambda * (x1 - x3) - y1
+		x.Square(&lambda)
+		x.Sub(&x, &lhs[i].x)
+		x.Sub(&x, &rhs[i].x)
+		y.Sub(&lhs[i].x, &x)
+		y.Mul(&y, &lambda)
+		y.Sub(&y, &lhs[i].y)
+		out[i] = g1Affine{x, y}
+	}
+}
+
+func (g1Msm) Neg(a g1Affine) g1Affine {
+	a.y.Neg(&a.y)
+	return a
+}
+
+func (g1Msm) Identity() *G1 {
+	return new(G1).Identity()
+}
+
+func (g1Msm) AddAffine(p *G1, a g1Affine) {
+	q := G1{x: a.x, y: a.y}
+	q.z.SetOne()
+	p.Add(p, &q)
+}
+
+func (g1Msm) Add(p, q *G1) {
+	p.Add(p, q)
+}
+
+func (g1Msm) Double(p *G1) {
+	p.Double(p)
+}
+
+// g2Affine is an affine point in G2.
+type g2Affine struct {
+	x, y fp2
+}
+
+// g2Msm implements native.MsmCurve for G2.
+type g2Msm struct{}
+
+func (g2Msm) BatchAdd(out, lhs, rhs []g2Affine, ok []bool) {
+	acc := make([]fp2, len(lhs))
+	var t, inv, lambda, x, y fp2
+
+	// Montgomery's trick, acc[i] is the product of the first i+1 denominators
+	t.SetOne()
+	for i := range lhs {
+		ok[i] = true
+		if lhs[i].x.Equal(&rhs[i].x) == 1 {
+			if lhs[i].y.Equal(&rhs[i].y)&(lhs[i].y.IsZero()^1) == 0 {
+				// P + -P is the identity, use 1 so the product is invertible
+				ok[i] = false
+				acc[i] = t
+				continue
+			}
+			// The tangent slope has denominator 2y
+			inv.Double(&lhs[i].y)
+		} else {
+			inv.Sub(&rhs[i].x, &lhs[i].x)
+		}
+		t.Mul(&t, &inv)
+		acc[i] = t
+	}
+	inv.Invert(&t)
+
+	for i := len(lhs) - 1; i >= 0; i-- {
+		if !ok[i] {
+			continue
+		}
+		// Remove this denominator from the inverse before using it
+		if i > 0 {
+			t.Mul(&inv, &acc[i-1])
+		} else {
+			t.Set(&inv)
+		}
+		if lhs[i].x.Equal(&rhs[i].x) == 1 {
+			// lambda = 3x^2 / 2y
+			x.Square(&lhs[i].x)
+			y.Double(&x)
+			x.Add(&y, &x)
+			lambda.Mul(&x, &t)
+			y.Double(&lhs[i].y)
+		} else {
+			// lambda = (y2 - y1) / (x2 - x1)
+			y.Sub(&rhs[i].y, &lhs[i].y)
+			lambda.Mul(&y, &t)
+			y.Sub(&rhs[i].x, &lhs[i].x)
+		}
+		inv.Mul(&inv, &y)
+
+		// x3 = lambda^2 - x1 - x2
+		// y3 = lambda * (x1 - x3) - y1
+		x.Square(&lambda)
+		x.Sub(&x, &lhs[i].x)
+		x.Sub(&x, &rhs[i].x)
+		y.Sub(&lhs[i].x, &x)
+		y.Mul(&y, &lambda)
+		y.Sub(&y, &lhs[i].y)
+		out[i] = g2Affine{x, y}
+	}
+}
+
+func (g2Msm) Neg(a g2Affine) g2Affine {
+	a.y.Neg(&a.y)
+	return a
+}
+
+func (g2Msm) Identity() *G2 {
+	return new(G2).Identity()
+}
+
+func (g2Msm) AddAffine(p *G2, a g2Affine) {
+	q := G2{x: a.x, y: a.y}
+	q.z.SetOne()
+	p.Add(p, &q)
+}
+
+func (g2Msm) Add(p, q *G2) {
+	p.Add(p, q)
+}
+
+func (g2Msm) Double(p *G2) {
+	p.Double(p)
+}
+
+// g1MsmInputs converts the points to affine and drops any identity points.
+func g1MsmInputs(points []*G1, scalars []*native.Field4) ([]g1Affine, []*native.Field4) {
+	affine := make([]g1Affine, 0, len(points))
+	nScalars := make([]*native.Field4, 0, len(scalars))
+	for i, p := range BatchToAffineG1(points) {
+		if p.IsIdentity() == 1 {
+			continue
+		}
+		affine = append(affine, g1Affine{p.x, p.y})
+		nScalars = append(nScalars, scalars[i])
+	}
+	return affine, nScalars
+}
+
+// g2MsmInputs converts the points to affine and drops any identity points.
+func g2MsmInputs(points []*G2, scalars []*native.Field4) ([]g2Affine, []*native.Field4) {
+	affine := make([]g2Affine, 0, len(points))
+	nScalars := make([]*native.Field4, 0, len(scalars))
+	for i, p := range BatchToAffineG2(points) {
+		if p.IsIdentity() == 1 {
+			continue
+		}
+		affine = append(affine, g2Affine{p.x, p.y})
+		nScalars = append(nScalars, scalars[i])
+	}
+	return affine, nScalars
+}
@@ -0,0 +1,103 @@
+package bls12377
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikelodder7/curvey/native"
+)
+
+func TestSinglePairing(t *testing.T) {
+	g := new(G1).Generator()
+	h := new(G2).Generator()
+
+	e := new(Engine)
+	e.AddPair(g, h)
+	p := e.Result()
+	require.Equal(t, 1, p.Equal(new(Gt).Generator()))
+	p.Neg(p)
+
+	e.Reset()
+	e.AddPairInvG2(g, h)
+	q := e.Result()
+	e.Reset()
+	e.AddPairInvG1(g, h)
+	r := e.Result()
+
+	require.Equal(t, 1, p.Equal(q))
+	require.Equal(t, 1, q.Equal(r))
+}
+
+func TestPairingBilinearity(t *testing.T) {
+	var bytes [64]byte
+	a := FqNew()
+	b := FqNew()
+	_, _ = crand.Read(bytes[:])
+	a.SetBytesWide(&bytes)
+	_, _ = crand.Read(bytes[:])
+	b.SetBytesWide(&bytes)
+
+	g := new(G1).Generator()
+	h := new(G2).Generator()
+	g.Mul(g, a)
+	h.Mul(h, b)
+
+	e := new(Engine)
+	e.AddPair(g, h)
+	lhs := e.Result()
+
+	rhs := new(Gt).Generator()
+	rhs.Mul(rhs, a)
+	rhs.Mul(rhs, b)
+	require.Equal(t, 1, lhs.Equal(rhs))
+
+	// e(aG, bH) * e(-abG, H) == 1
+	ab := FqNew().Mul(a, b)
+	g.Generator().Mul(g, ab)
+	e.AddPairInvG1(g, new(G2).Generator())
+	require.True(t, e.Check())
+}
+
+func TestPairingIdentity(t *testing.T) {
+	e := new(Engine)
+	e.AddPair(new(G1).Identity(), new(G2).Generator())
+	e.AddPair(new(G1).Generator(), new(G2).Identity())
+	require.True(t, e.Check())
+	require.Equal(t, 1, e.Result().IsOne())
+}
+
+func TestMultiPairing(t *testing.T) {
+	const Tests = 10
+	e1 := new(Engine)
+	e2 := new(Engine)
+
+	g1s := make([]*G1, Tests)
+	g2s := make([]*G2, Tests)
+	sc := make([]*native.Field4, Tests)
+	res := make([]*Gt, Tests)
+	expected := new(Gt).SetOne()
+
+	for i := 0; i < Tests; i++ {
+		var bytes [64]byte
+		g1s[i] = new(G1).Generator()
+		g2s[i] = new(G2).Generator()
+		sc[i] = FqNew()
+		_, _ = crand.Read(bytes[:])
+		sc[i].SetBytesWide(&bytes)
+		if i&1 == 0 {
+			g1s[i].Mul(g1s[i], sc[i])
+		} else {
+			g2s[i].Mul(g2s[i], sc[i])
+		}
+		e1.AddPair(g1s[i], g2s[i])
+		e2.AddPair(g1s[i], g2s[i])
+		res[i] = e1.Result()
+		e1.Reset()
+		expected.Add(expected, res[i])
+	}
+
+	actual := e2.Result()
+	require.Equal(t, 1, expected.Equal(actual))
+}
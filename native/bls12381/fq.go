@@ -82,7 +82,7 @@ func (fqArithmetic) Neg(out, arg *[native.Field4Limbs]uint64) {
 
 	// t could be `fqModulus` if `arg`=0. Set mask=0 if self=0
 	// and 0xff..ff if `arg`!=0
-	mask := t[0] | t[1] | t[2] | t[3]
+	mask := arg[0] | arg[1] | arg[2] | arg[3]
 	mask = -((mask | -mask) >> 63)
 	out[0] = t[0] & mask
 	out[1] = t[1] & mask
@@ -161,6 +161,28 @@ func TestFqNeg(t *testing.T) {
 	a.Neg(g)
 	e = FqNew().SetRaw(&[native.Field4Limbs]uint64{0xfffffff000000010, 0x3bda402fffe5bfef, 0x339d80809a1d8055, 0x3eda753299d7d483})
 	require.Equal(t, e, a)
+	a.Neg(FqNew().SetZero())
+	require.Equal(t, 1, a.IsZero())
+	require.Equal(t, [native.Field4Limbs]uint64{}, a.Value)
+}
+
+func TestFqNegAdd(t *testing.T) {
+	values := []*native.Field4{
+		FqNew().SetZero(),
+		FqNew().SetOne(),
+		FqNew().Neg(FqNew().SetOne()),
+		FqNew().SetRaw(&fqGenerator),
+	}
+	var tv [64]byte
+	for i := 0; i < 25; i++ {
+		_, _ = rand.Read(tv[:])
+		values = append(values, FqNew().SetBytesWide(&tv))
+	}
+	for _, x := range values {
+		n := FqNew().Neg(x)
+		require.Equal(t, 1, FqNew().Add(n, x).IsZero())
+		require.Equal(t, 1, FqNew().Neg(n).Equal(x))
+	}
 }
 
 func TestFqExp(t *testing.T) {
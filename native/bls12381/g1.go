@@ -1,6 +1,7 @@
 package bls12381
 
 import (
+	"encoding/binary"
 	"fmt"
 	"io"
 	"math/big"
@@ -28,6 +29,27 @@ var (
 		0x0e1c8c3fad0059c0,
 		0x0bbc3efc5008a26a,
 	}
+	// g1GlvBeta is a cube root of unity in montgomery form
+	// 0x5f19672fdf76ce51ba69c6076a0f77eaddb3a93be6f89688de17d813620a00022e01fffffffefffe
+	g1GlvBeta = fp{
+		0x30f1361b798a64e8,
+		0xf3b8ddab7ece5a2a,
+		0x16a8ca3ac61577f7,
+		0xc26a2ff874fd029b,
+		0x3636b76660701c6e,
+		0x051ba4ab241b6160,
+	}
+	// g1GlvParams split scalars for the endomorphism (x, y) -> (beta * x, y)
+	// which multiplies points in G1 by lambda = -z^2.
+	// The lattice basis is (1, 1 - z^2) and (z^2, 1).
+	g1GlvParams = native.GlvParams{
+		G1:          [native.Field4Limbs]uint64{0x1c5aee5b83f0476a, 0x1aa84a76ff6f1bbe, 0x0000000000000001, 0x0000000000000000},
+		G2:          [native.Field4Limbs]uint64{0xd0d4396b40c5f203, 0x01a75a5c93d6e013, 0xb1fb72917b67f717, 0xbe35f678f00fd56e},
+		Shift:       383,
+		MinusB1:     [native.Field4Limbs]uint64{0x00000000ffffffff, 0xac45a4010001a402, 0x0000000000000000, 0x0000000000000000},
+		MinusB2:     [native.Field4Limbs]uint64{0xffffffff00000000, 0x53bda402fffe5bfe, 0x3339d80809a1d805, 0x73eda753299d7d48},
+		MinusLambda: [native.Field4Limbs]uint64{0x0000000100000000, 0xac45a4010001a402, 0x0000000000000000, 0x0000000000000000},
+	}
 	curveG1B = fp{
 		0xaa270000000cfff3,
 		0x53cc0032fc34000a,
@@ -705,11 +727,21 @@ func (g1 *G1) Double(a *G1) *G1 {
 }
 
 // Mul multiplies this point by the input scalar.
+// It is correct for any point on the curve, including points
+// outside G1. Use MulGlv for points known to be in G1.
 func (g1 *G1) Mul(a *G1, s *native.Field4) *G1 {
 	bytes := s.Bytes()
 	return g1.multiply(a, &bytes)
 }
 
+// MulGlv multiplies a by s using the endomorphism to split the scalar in two.
+// Every table entry is scanned for each window so it is safe for secret scalars.
+// The result is only correct if a is in G1, e.g. it was decoded with
+// FromCompressed or FromUncompressed or is the output of ClearCofactor.
+func (g1 *G1) MulGlv(a *G1, s *native.Field4) *G1 {
+	return g1.glvSumOfProducts([]*G1{a}, []*native.Field4{s})
+}
+
 func (g1 *G1) multiply(a *G1, bytes *[native.Field4Bytes]byte) *G1 {
 	var p G1
 	precomputed := [16]*G1{}
@@ -914,7 +946,11 @@ func (g1 *G1) FromUncompressed(input *[WideFieldBytes]byte) (*G1, error) {
 }
 
 // ToAffine converts the point into affine coordinates.
+// Points that are already affine are copied without an inversion.
 func (g1 *G1) ToAffine(a *G1) *G1 {
+	if a.z.IsOne() == 1 {
+		return g1.Set(a)
+	}
 	var wasInverted int
 	var zero, x, y, z fp
 	_, wasInverted = z.Invert(&a.z)
@@ -927,6 +963,38 @@ func (g1 *G1) ToAffine(a *G1) *G1 {
 	return g1
 }
 
+// BatchToAffineG1 returns the points converted to affine coordinates
+// using Montgomery's trick to share a single field inversion between them.
+// The identity is converted the same as ToAffine.
+func BatchToAffineG1(points []*G1) []*G1 {
+	var t, v, inv fp
+	out := make([]*G1, len(points))
+	// acc[i] is the product of the non-zero z coordinates before i
+	acc := make([]fp, len(points))
+	t.SetOne()
+	for i, p := range points {
+		acc[i] = t
+		v.CMove(v.SetOne(), &p.z, p.z.IsZero()^1)
+		t.Mul(&t, &v)
+	}
+	inv.Invert(&t)
+	for i := len(points) - 1; i >= 0; i-- {
+		p := points[i]
+		nonZero := p.z.IsZero() ^ 1
+		v.CMove(v.SetOne(), &p.z, nonZero)
+		t.Mul(&inv, &acc[i])
+		inv.Mul(&inv, &v)
+		t.CMove(new(fp), &t, nonZero)
+
+		// The identity has a zero inverse so x and y become zero
+		out[i] = new(G1)
+		out[i].x.Mul(&p.x, &t)
+		out[i].y.Mul(&p.y, &t)
+		out[i].z.CMove(new(fp), v.SetOne(), nonZero)
+	}
+	return out
+}
+
 // GetX returns the affine X coordinate.
 func (g1 *G1) GetX() *fp {
 	var t G1
@@ -971,59 +1039,190 @@ func (g1 *G1) CMove(arg1, arg2 *G1, choice int) *G1 {
 // SumOfProducts computes the multi-exponentiation for the specified
 // points and scalars and stores the result in `g1`.
 // Returns an error if the lengths of the arguments is not equal.
+// The windows of all scalars are processed together and each lookup
+// scans the whole table so it is safe for secret scalars.
+// It is correct for any points on the curve, including points outside G1.
+// Use SumOfProductsGlv for points known to be in G1 and
+// SumOfProductsVarTime when all the scalars are public.
 func (g1 *G1) SumOfProducts(points []*G1, scalars []*native.Field4) (*G1, error) {
-	const Upper = 256
-	const W = 4
-	const Windows = Upper / W // careful--use ceiling division in case this doesn't divide evenly
-	var sum G1
 	if len(points) != len(scalars) {
 		return nil, fmt.Errorf("length mismatch")
 	}
+	tables := make([][16]G1, len(points))
+	digits := make([][native.Field4Limbs]uint64, len(points))
+	for i, point := range points {
+		tables[i] = g1Table(point)
+		bytes := scalars[i].Bytes()
+		for j := range digits[i] {
+			digits[i][j] = binary.LittleEndian.Uint64(bytes[8*j:])
+		}
+	}
+	return g1.windowSumOfProducts(tables, digits, native.Field4Bytes*8), nil
+}
 
-	bucketSize := 1 << W
-	windows := make([]G1, Windows)
-	bytes := make([][32]byte, len(scalars))
-	buckets := make([]G1, bucketSize)
-
-	for i := 0; i < len(windows); i++ {
-		windows[i].Identity()
+// SumOfProductsGlv is SumOfProducts with each scalar split in two with
+// the endomorphism. The result is only correct if all the points are in G1.
+func (g1 *G1) SumOfProductsGlv(points []*G1, scalars []*native.Field4) (*G1, error) {
+	if len(points) != len(scalars) {
+		return nil, fmt.Errorf("length mismatch")
 	}
+	return g1.glvSumOfProducts(points, scalars), nil
+}
+
+// SumOfProductsVarTime computes the multi-exponentiation for the specified
+// points and scalars and stores the result in `g1`. Fewer than
+// MsmStrausPoints points use interleaved wNAF and larger inputs use
+// Pippenger's bucket method. It must only be used with public scalars.
+// Returns an error if the lengths of the arguments is not equal.
+func (g1 *G1) SumOfProductsVarTime(points []*G1, scalars []*native.Field4) (*G1, error) {
+	return g1.MsmVarTime(points, scalars, nil)
+}
 
-	for i, scalar := range scalars {
-		bytes[i] = scalar.Bytes()
+// MsmVarTime is SumOfProductsVarTime with options to choose the window
+// size and split the work across goroutines. Setting the window always
+// uses Pippenger's bucket method.
+func (g1 *G1) MsmVarTime(points []*G1, scalars []*native.Field4, opts *native.MsmOptions) (*G1, error) {
+	if len(points) != len(scalars) {
+		return nil, fmt.Errorf("length mismatch")
+	}
+	if len(points) < native.MsmStrausPoints && (opts == nil || opts.Window == 0) {
+		return g1.strausVarTime(points, scalars), nil
 	}
+	affine, nScalars := g1MsmInputs(points, scalars)
+	return g1.Set(native.Msm[g1Affine, *G1](g1Msm{}, affine, nScalars, opts)), nil
+}
+
+// MulVarTime multiplies a by s with the running time depending on s
+// so it must only be used with public values, e.g. when verifying signatures.
+func (g1 *G1) MulVarTime(a *G1, s *native.Field4) *G1 {
+	return g1.strausVarTime([]*G1{a}, []*native.Field4{s})
+}
 
-	for j := 0; j < len(windows); j++ {
-		for i := 0; i < bucketSize; i++ {
-			buckets[i].Identity()
+// strausVarTime computes the sum of points[i] * scalars[i] by interleaving
+// the width WnafWidth NAFs of the scalars so all the points share the
+// doublings. The scalars are not split with the endomorphism so the
+// result is correct for points outside G1.
+func (g1 *G1) strausVarTime(points []*G1, scalars []*native.Field4) *G1 {
+	var p, t G1
+	tables := make([][]G1, 0, len(points))
+	digits := make([][]int8, 0, len(points))
+	for i, point := range points {
+		if point.IsIdentity() == 1 {
+			continue
 		}
+		table := g1WnafTable(point)
+		var k [native.Field4Limbs]uint64
+		scalars[i].Arithmetic.FromMontgomery(&k, &scalars[i].Value)
+		tables = append(tables, table)
+		digits = append(digits, native.Wnaf(k[:], native.WnafWidth))
+	}
 
-		for i := 0; i < len(scalars); i++ {
-			// j*W to get the nibble
-			// >> 3 to convert to byte, / 8
-			// (W * j & W) gets the nibble, mod W
-			// 1 << W - 1 to get the offset
-			index := bytes[i][j*W>>3] >> (W * j & W) & (1<<W - 1) // little-endian
-			buckets[index].Add(&buckets[index], points[i])
+	top := 0
+	for _, d := range digits {
+		if len(d) > top {
+			top = len(d)
+		}
+	}
+	p.Identity()
+	for i := top - 1; i >= 0; i-- {
+		p.Double(&p)
+		for j, d := range digits {
+			if i >= len(d) || d[i] == 0 {
+				continue
+			}
+			if d[i] > 0 {
+				p.Add(&p, &tables[j][d[i]>>1])
+			} else {
+				p.Add(&p, t.Neg(&tables[j][-d[i]>>1]))
+			}
 		}
+	}
+	return g1.Set(&p)
+}
 
-		sum.Identity()
+// g1WnafTable returns the odd multiples a, 3*a, ... (2^(WnafWidth-1) - 1)*a
+// indexed by the absolute wNAF digit / 2.
+func g1WnafTable(a *G1) []G1 {
+	var double G1
+	table := make([]G1, 1<<(native.WnafWidth-2))
+	table[0].Set(a)
+	double.Double(a)
+	for i := 1; i < len(table); i++ {
+		table[i].Add(&table[i-1], &double)
+	}
+	return table
+}
 
-		for i := bucketSize - 1; i > 0; i-- {
-			sum.Add(&sum, &buckets[i])
-			windows[j].Add(&windows[j], &sum)
+// glvSumOfProducts splits every scalar into k1 + k2 * lambda and computes
+// the sum of k1 * P + k2 * phi(P) over half length scalars. The signs of k1
+// and k2 are applied to the points so only the absolute values are used.
+// Points must be in G1 for phi(P) to equal lambda * P.
+func (g1 *G1) glvSumOfProducts(points []*G1, scalars []*native.Field4) *G1 {
+	tables := make([][16]G1, 2*len(points))
+	digits := make([][native.Field4Limbs]uint64, 2*len(points))
+	for i, point := range points {
+		var p1 G1
+		k1, k2, neg1, neg2 := g1GlvParams.Decompose(scalars[i])
+		p1.Set(point)
+		p1.y.CNeg(&p1.y, neg1)
+		tables[2*i] = g1Table(&p1)
+		// phi(j * P) = j * phi(P) so the second table only costs
+		// one multiplication per entry
+		for j := range tables[2*i+1] {
+			tables[2*i+1][j].endomorphism(&tables[2*i][j])
+			tables[2*i+1][j].y.CNeg(&tables[2*i+1][j].y, neg1^neg2)
 		}
+		digits[2*i] = k1
+		digits[2*i+1] = k2
 	}
+	return g1.windowSumOfProducts(tables, digits, native.GlvBits)
+}
 
-	g1.Identity()
-	for i := len(windows) - 1; i >= 0; i-- {
-		for j := 0; j < W; j++ {
-			g1.Double(g1)
+// windowSumOfProducts computes the sum of digits[k] * tables[k][1] where
+// tables[k] holds the multiples 0 through 15 and only the low bits of
+// each digit are used. Each lookup scans the whole table.
+func (g1 *G1) windowSumOfProducts(tables [][16]G1, digits [][native.Field4Limbs]uint64, bits int) *G1 {
+	var p, t G1
+	p.Identity()
+	for i := bits - 4; i >= 0; i -= 4 {
+		for j := 0; j < 4; j++ {
+			p.Double(&p)
 		}
+		for k := range tables {
+			window := int(digits[k][i>>6]>>(i&63)) & 0x0F
+			t.Identity()
+			for j := 1; j < 16; j++ {
+				t.CMove(&t, &tables[k][j], ctEqual(window, j))
+			}
+			p.Add(&p, &t)
+		}
+	}
+	return g1.Set(&p)
+}
+
+// endomorphism computes phi(a) = (beta * x, y) which equals lambda * a for points in G1.
+func (g1 *G1) endomorphism(a *G1) *G1 {
+	g1.x.Mul(&a.x, &g1GlvBeta)
+	g1.y.Set(&a.y)
+	g1.z.Set(&a.z)
+	return g1
+}
 
-		g1.Add(g1, &windows[i])
+// g1Table returns the multiples 0*a through 15*a.
+func g1Table(a *G1) [16]G1 {
+	var precomputed [16]G1
+	precomputed[0].Identity()
+	precomputed[1].Set(a)
+	for i := 2; i < 16; i += 2 {
+		precomputed[i].Double(&precomputed[i>>1])
+		precomputed[i+1].Add(&precomputed[i], a)
 	}
-	return g1, nil
+	return precomputed
+}
+
+// ctEqual returns 1 if a == b and 0 otherwise without branching.
+func ctEqual(a, b int) int {
+	return int((uint64(a^b) - 1) >> 63)
 }
 
 func (g1 *G1) osswu3mod4(u *fp) *G1 {
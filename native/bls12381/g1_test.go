@@ -3,6 +3,7 @@ package bls12381
 import (
 	crand "crypto/rand"
 	"encoding/hex"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -221,9 +222,9 @@ func TestG1Neg(t *testing.T) {
 	require.Equal(t, 1, a.Neg(a).IsIdentity())
 }
 
-func TestG1InCorrectSubgroup(t *testing.T) {
-	// ZCash test vector
-	a := G1{
+// g1NotInSubgroup returns a ZCash test vector that is on the curve but not in G1.
+func g1NotInSubgroup() *G1 {
+	return &G1{
 		x: fp{
 			0x0abaf895b97e43c8,
 			0xba4c6432eb9b61b0,
@@ -242,6 +243,10 @@ func TestG1InCorrectSubgroup(t *testing.T) {
 		},
 		z: *(new(fp).SetOne()),
 	}
+}
+
+func TestG1InCorrectSubgroup(t *testing.T) {
+	a := g1NotInSubgroup()
 	require.Equal(t, 0, a.InCorrectSubgroup())
 
 	require.Equal(t, 1, new(G1).Identity().InCorrectSubgroup())
@@ -410,3 +415,195 @@ func TestSumOfProducts(t *testing.T) {
 	_, _ = rhs.SumOfProducts([]*G1{u, h0}, []*native.Field4{c, sHat})
 	require.Equal(t, 1, uTilde.Equal(rhs))
 }
+
+func TestG1MulGlv(t *testing.T) {
+	var b [64]byte
+	p, _ := new(G1).Random(crand.Reader)
+	// lambda = -z^2 is split into 0 + 1 * lambda
+	lambda := FqNew().SetUint64(paramX)
+	lambda.Square(lambda).Neg(lambda)
+	scalars := []*native.Field4{
+		FqNew().SetZero(),
+		FqNew().SetOne(),
+		FqNew().Neg(FqNew().SetOne()),
+		lambda,
+		FqNew().Neg(lambda),
+	}
+	for i := 0; i < 8; i++ {
+		_, _ = crand.Read(b[:])
+		scalars = append(scalars, FqNew().SetBytesWide(&b))
+	}
+	for _, s := range scalars {
+		bytes := s.Bytes()
+		expected := new(G1).multiply(p, &bytes)
+		require.Equal(t, 1, expected.Equal(new(G1).MulGlv(p, s)))
+	}
+	require.Equal(t, 1, new(G1).endomorphism(p).Equal(new(G1).MulGlv(p, lambda)))
+
+	points := make([]*G1, len(scalars))
+	for i := range points {
+		points[i], _ = new(G1).Random(crand.Reader)
+	}
+	expected, err := new(G1).SumOfProductsVarTime(points, scalars)
+	require.NoError(t, err)
+	actual, err := new(G1).SumOfProductsGlv(points, scalars)
+	require.NoError(t, err)
+	require.Equal(t, 1, expected.Equal(actual))
+	actual, err = new(G1).SumOfProducts(points, scalars)
+	require.NoError(t, err)
+	require.Equal(t, 1, expected.Equal(actual))
+	_, err = new(G1).SumOfProductsGlv(points, scalars[1:])
+	require.Error(t, err)
+}
+
+func TestG1MulOutsideSubgroup(t *testing.T) {
+	var b [64]byte
+	a := g1NotInSubgroup()
+	require.Equal(t, 1, a.IsOnCurve())
+	require.Equal(t, 0, a.InCorrectSubgroup())
+
+	points := []*G1{a, new(G1).Double(a)}
+	scalars := make([]*native.Field4, len(points))
+	sum := new(G1).Identity()
+	for i := range scalars {
+		_, _ = crand.Read(b[:])
+		scalars[i] = FqNew().SetBytesWide(&b)
+		expected := g1DoubleAndAdd(points[i], scalars[i])
+		require.Equal(t, 1, expected.Equal(new(G1).Mul(points[i], scalars[i])))
+		sum.Add(sum, expected)
+	}
+	actual, err := new(G1).SumOfProducts(points, scalars)
+	require.NoError(t, err)
+	require.Equal(t, 1, sum.Equal(actual))
+}
+
+// g1DoubleAndAdd computes s * a one bit at a time.
+func g1DoubleAndAdd(a *G1, s *native.Field4) *G1 {
+	k := s.BigInt()
+	out := new(G1).Identity()
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		out.Double(out)
+		if k.Bit(i) == 1 {
+			out.Add(out, a)
+		}
+	}
+	return out
+}
+
+func BenchmarkG1Mul(b *testing.B) {
+	p, s := benchmarkG1Inputs(1)
+	b.Run("glv", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			new(G1).MulGlv(p[0], s[0])
+		}
+	})
+	b.Run("window", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			new(G1).Mul(p[0], s[0])
+		}
+	})
+}
+
+func BenchmarkG1SumOfProducts(b *testing.B) {
+	p, s := benchmarkG1Inputs(8)
+	b.Run("glv", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = new(G1).SumOfProductsGlv(p, s)
+		}
+	})
+	b.Run("window", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = new(G1).SumOfProducts(p, s)
+		}
+	})
+	b.Run("buckets", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = new(G1).SumOfProductsVarTime(p, s)
+		}
+	})
+}
+
+func benchmarkG1Inputs(n int) ([]*G1, []*native.Field4) {
+	var b [64]byte
+	points := make([]*G1, n)
+	scalars := make([]*native.Field4, n)
+	for i := range points {
+		_, _ = crand.Read(b[:])
+		points[i], _ = new(G1).Random(crand.Reader)
+		scalars[i] = FqNew().SetBytesWide(&b)
+	}
+	return points, scalars
+}
+
+func TestG1Msm(t *testing.T) {
+	points, scalars := benchmarkG1Inputs(64)
+	// Repeated and opposite points exercise doubling and
+	// cancellation when the buckets are summed in affine
+	points[1] = points[0]
+	points[2] = new(G1).Neg(points[0])
+	points[3] = new(G1).Double(points[4])
+	points[5] = new(G1).Identity()
+	scalars[1] = scalars[0]
+	scalars[2] = scalars[0]
+	scalars[6].SetZero()
+	expected, err := new(G1).SumOfProducts(points, scalars)
+	require.NoError(t, err)
+
+	for _, window := range []int{0, 2, 5, 11} {
+		for _, workers := range []int{0, 3, 100} {
+			actual, err := new(G1).MsmVarTime(points, scalars, &native.MsmOptions{Window: window, Workers: workers})
+			require.NoError(t, err)
+			require.Equal(t, 1, expected.Equal(actual))
+		}
+	}
+	_, err = new(G1).MsmVarTime(points, scalars[1:], nil)
+	require.Error(t, err)
+}
+
+func BenchmarkG1Msm(b *testing.B) {
+	for _, n := range []int{1 << 8, 1 << 12} {
+		p, s := benchmarkG1Inputs(n)
+		for _, workers := range []int{1, 4} {
+			b.Run(fmt.Sprintf("%d/workers=%d", n, workers), func(b *testing.B) {
+				opts := &native.MsmOptions{Workers: workers}
+				for i := 0; i < b.N; i++ {
+					_, _ = new(G1).MsmVarTime(p, s, opts)
+				}
+			})
+		}
+	}
+}
+
+func TestG1BatchToAffine(t *testing.T) {
+	points, _ := benchmarkG1Inputs(6)
+	points[2] = new(G1).Identity()
+	points[4] = new(G1).Generator()
+	affine := BatchToAffineG1(points)
+	for i, p := range points {
+		expected := new(G1).ToAffine(p)
+		require.Equal(t, expected.x, affine[i].x)
+		require.Equal(t, expected.y, affine[i].y)
+		require.Equal(t, expected.z, affine[i].z)
+	}
+	require.Empty(t, BatchToAffineG1(nil))
+}
+
+func TestG1MulVarTime(t *testing.T) {
+	points, scalars := benchmarkG1Inputs(4)
+	scalars[1] = FqNew().SetZero()
+	scalars[2] = FqNew().Neg(FqNew().SetOne())
+	points[3] = new(G1).Identity()
+	for i, p := range points {
+		require.Equal(t, 1, new(G1).Mul(p, scalars[i]).Equal(new(G1).MulVarTime(p, scalars[i])))
+	}
+
+	// Points outside G1 give the same result with both methods
+	points[1] = g1NotInSubgroup()
+	scalars[1] = scalars[0]
+	require.Equal(t, 1, new(G1).Mul(points[1], scalars[1]).Equal(new(G1).MulVarTime(points[1], scalars[1])))
+	straus, err := new(G1).SumOfProductsVarTime(points, scalars)
+	require.NoError(t, err)
+	buckets, err := new(G1).MsmVarTime(points, scalars, &native.MsmOptions{Window: 4})
+	require.NoError(t, err)
+	require.Equal(t, 1, straus.Equal(buckets))
+}
@@ -912,7 +912,11 @@ func (g2 *G2) FromUncompressed(input *[DoubleWideFieldBytes]byte) (*G2, error) {
 }
 
 // ToAffine converts the point into affine coordinates.
+// Points that are already affine are copied without an inversion.
 func (g2 *G2) ToAffine(a *G2) *G2 {
+	if a.z.IsOne() == 1 {
+		return g2.Set(a)
+	}
 	var wasInverted int
 	var zero, x, y, z fp2
 	_, wasInverted = z.Invert(&a.z)
@@ -925,6 +929,38 @@ func (g2 *G2) ToAffine(a *G2) *G2 {
 	return g2
 }
 
+// BatchToAffineG2 returns the points converted to affine coordinates
+// using Montgomery's trick to share a single field inversion between them.
+// The identity is converted the same as ToAffine.
+func BatchToAffineG2(points []*G2) []*G2 {
+	var t, v, inv fp2
+	out := make([]*G2, len(points))
+	// acc[i] is the product of the non-zero z coordinates before i
+	acc := make([]fp2, len(points))
+	t.SetOne()
+	for i, p := range points {
+		acc[i] = t
+		v.CMove(v.SetOne(), &p.z, p.z.IsZero()^1)
+		t.Mul(&t, &v)
+	}
+	inv.Invert(&t)
+	for i := len(points) - 1; i >= 0; i-- {
+		p := points[i]
+		nonZero := p.z.IsZero() ^ 1
+		v.CMove(v.SetOne(), &p.z, nonZero)
+		t.Mul(&inv, &acc[i])
+		inv.Mul(&inv, &v)
+		t.CMove(new(fp2), &t, nonZero)
+
+		// The identity has a zero inverse so x and y become zero
+		out[i] = new(G2)
+		out[i].x.Mul(&p.x, &t)
+		out[i].y.Mul(&p.y, &t)
+		out[i].z.CMove(new(fp2), v.SetOne(), nonZero)
+	}
+	return out
+}
+
 // GetX returns the affine X coordinate.
 func (g2 *G2) GetX() *fp2 {
 	var t G2
@@ -969,58 +1005,91 @@ func (g2 *G2) CMove(arg1, arg2 *G2, choice int) *G2 {
 // SumOfProducts computes the multi-exponentiation for the specified
 // points and scalars and stores the result in `g2`.
 // Returns an error if the lengths of the arguments is not equal.
+// This is the same as SumOfProductsVarTime so it must only be used with public scalars.
 func (g2 *G2) SumOfProducts(points []*G2, scalars []*native.Field4) (*G2, error) {
-	const Upper = 256
-	const W = 4
-	const Windows = Upper / W // careful--use ceiling division in case this doesn't divide evenly
-	var sum G2
+	return g2.MsmVarTime(points, scalars, nil)
+}
+
+// SumOfProductsVarTime computes the multi-exponentiation for the specified
+// points and scalars and stores the result in `g2`. Fewer than
+// MsmStrausPoints points use interleaved wNAF and larger inputs use
+// Pippenger's bucket method. It must only be used with public scalars.
+// Returns an error if the lengths of the arguments is not equal.
+func (g2 *G2) SumOfProductsVarTime(points []*G2, scalars []*native.Field4) (*G2, error) {
+	return g2.MsmVarTime(points, scalars, nil)
+}
+
+// MsmVarTime is SumOfProductsVarTime with options to choose the window
+// size and split the work across goroutines. Setting the window always
+// uses Pippenger's bucket method.
+func (g2 *G2) MsmVarTime(points []*G2, scalars []*native.Field4, opts *native.MsmOptions) (*G2, error) {
 	if len(points) != len(scalars) {
 		return nil, fmt.Errorf("length mismatch")
 	}
-
-	bucketSize := 1 << W
-	windows := make([]G2, Windows)
-	bytes := make([][32]byte, len(scalars))
-	buckets := make([]G2, bucketSize)
-	for i := 0; i < len(windows); i++ {
-		windows[i].Identity()
-	}
-
-	for i, scalar := range scalars {
-		bytes[i] = scalar.Bytes()
+	if len(points) < native.MsmStrausPoints && (opts == nil || opts.Window == 0) {
+		return g2.strausVarTime(points, scalars), nil
 	}
+	affine, nScalars := g2MsmInputs(points, scalars)
+	return g2.Set(native.Msm[g2Affine, *G2](g2Msm{}, affine, nScalars, opts)), nil
+}
 
-	for j := 0; j < len(windows); j++ {
-		for i := 0; i < bucketSize; i++ {
-			buckets[i].Identity()
-		}
+// MulVarTime multiplies a by s with the running time depending on s
+// so it must only be used with public values, e.g. when verifying signatures.
+func (g2 *G2) MulVarTime(a *G2, s *native.Field4) *G2 {
+	return g2.strausVarTime([]*G2{a}, []*native.Field4{s})
+}
 
-		for i := 0; i < len(scalars); i++ {
-			// j*W to get the nibble
-			// >> 3 to convert to byte, / 8
-			// (W * j & W) gets the nibble, mod W
-			// 1 << W - 1 to get the offset
-			index := bytes[i][j*W>>3] >> (W * j & W) & (1<<W - 1) // little-endian
-			buckets[index].Add(&buckets[index], points[i])
+// strausVarTime computes the sum of points[i] * scalars[i] by interleaving
+// the width WnafWidth NAFs of the scalars so all the points share the doublings.
+func (g2 *G2) strausVarTime(points []*G2, scalars []*native.Field4) *G2 {
+	var p, t G2
+	tables := make([][]G2, 0, 2*len(points))
+	digits := make([][]int8, 0, 2*len(points))
+	for i, point := range points {
+		if point.IsIdentity() == 1 {
+			continue
 		}
+		table := g2WnafTable(point)
+		var k [native.Field4Limbs]uint64
+		scalars[i].Arithmetic.FromMontgomery(&k, &scalars[i].Value)
+		tables = append(tables, table)
+		digits = append(digits, native.Wnaf(k[:], native.WnafWidth))
+	}
 
-		sum.Identity()
-
-		for i := bucketSize - 1; i > 0; i-- {
-			sum.Add(&sum, &buckets[i])
-			windows[j].Add(&windows[j], &sum)
+	top := 0
+	for _, d := range digits {
+		if len(d) > top {
+			top = len(d)
 		}
 	}
-
-	g2.Identity()
-	for i := len(windows) - 1; i >= 0; i-- {
-		for j := 0; j < W; j++ {
-			g2.Double(g2)
+	p.Identity()
+	for i := top - 1; i >= 0; i-- {
+		p.Double(&p)
+		for j, d := range digits {
+			if i >= len(d) || d[i] == 0 {
+				continue
+			}
+			if d[i] > 0 {
+				p.Add(&p, &tables[j][d[i]>>1])
+			} else {
+				p.Add(&p, t.Neg(&tables[j][-d[i]>>1]))
+			}
 		}
+	}
+	return g2.Set(&p)
+}
 
-		g2.Add(g2, &windows[i])
+// g2WnafTable returns the odd multiples a, 3*a, ... (2^(WnafWidth-1) - 1)*a
+// indexed by the absolute wNAF digit / 2.
+func g2WnafTable(a *G2) []G2 {
+	var double G2
+	table := make([]G2, 1<<(native.WnafWidth-2))
+	table[0].Set(a)
+	double.Double(a)
+	for i := 1; i < len(table); i++ {
+		table[i].Add(&table[i-1], &double)
 	}
-	return g2, nil
+	return table
 }
 
 func (g2 *G2) psi(a *G2) *G2 {
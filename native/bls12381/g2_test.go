@@ -553,3 +553,52 @@ func TestG2SumOfProducts(t *testing.T) {
 	_, _ = rhs.SumOfProducts([]*G2{u, h0}, []*native.Field4{c, sHat})
 	require.Equal(t, 1, uTilde.Equal(rhs))
 }
+
+func TestG2Msm(t *testing.T) {
+	var b [64]byte
+	points := make([]*G2, 32)
+	scalars := make([]*native.Field4, len(points))
+	for i := range points {
+		_, _ = crand.Read(b[:])
+		points[i], _ = new(G2).Random(crand.Reader)
+		scalars[i] = FqNew().SetBytesWide(&b)
+	}
+	// Repeated and opposite points exercise doubling and
+	// cancellation when the buckets are summed in affine
+	points[1] = points[0]
+	points[2] = new(G2).Neg(points[0])
+	points[3] = new(G2).Double(points[4])
+	points[5] = new(G2).Identity()
+	scalars[1] = scalars[0]
+	scalars[2] = scalars[0]
+	scalars[6].SetZero()
+	expected := new(G2).Identity()
+	for i := range points {
+		expected.Add(expected, new(G2).Mul(points[i], scalars[i]))
+	}
+
+	for _, window := range []int{0, 3, 9} {
+		for _, workers := range []int{0, 4} {
+			actual, err := new(G2).MsmVarTime(points, scalars, &native.MsmOptions{Window: window, Workers: workers})
+			require.NoError(t, err)
+			require.Equal(t, 1, expected.Equal(actual))
+		}
+	}
+	actual, err := new(G2).SumOfProducts(points, scalars)
+	require.NoError(t, err)
+	require.Equal(t, 1, expected.Equal(actual))
+}
+
+func TestG2MulVarTime(t *testing.T) {
+	var b [64]byte
+	for i := 0; i < 4; i++ {
+		_, _ = crand.Read(b[:])
+		p, _ := new(G2).Random(crand.Reader)
+		s := FqNew().SetBytesWide(&b)
+		if i == 1 {
+			s.SetZero()
+		}
+		require.Equal(t, 1, new(G2).Mul(p, s).Equal(new(G2).MulVarTime(p, s)))
+	}
+	require.Equal(t, 1, new(G2).MulVarTime(new(G2).Identity(), FqNew().SetOne()).IsIdentity())
+}
@@ -320,11 +320,13 @@ func (gt *Gt) Mul(a *Gt, s *native.Field4) *Gt {
 	bytes := s.Bytes()
 
 	precomputed := [16]fp12{}
+	precomputed[0].SetOne()
 	precomputed[1].Set(&f)
 	for i := 2; i < 16; i += 2 {
 		precomputed[i].Square(&precomputed[i>>1])
 		precomputed[i+1].Mul(&precomputed[i], &f)
 	}
+	p.SetOne()
 	for i := 0; i < 256; i += 4 {
 		// Brouwer / windowing method. window size of 4.
 		for j := 0; j < 4; j++ {
@@ -62,3 +62,21 @@ func TestMultiPairing(t *testing.T) {
 	actual := e2.Result()
 	require.Equal(t, 1, expected.Equal(actual))
 }
+
+func TestGtMul(t *testing.T) {
+	var bytes [64]byte
+	_, _ = crand.Read(bytes[:])
+	sc := FqNew()
+	sc.SetBytesWide(&bytes)
+
+	e := new(Engine)
+	e.AddPair(new(G1).Generator(), new(G2).Generator())
+	gt := e.Result()
+	e.Reset()
+	e.AddPair(new(G1).Mul(new(G1).Generator(), sc), new(G2).Generator())
+	expected := e.Result()
+
+	actual := new(Gt).Mul(gt, sc)
+	require.Equal(t, 1, expected.Equal(actual))
+	require.Equal(t, 1, new(Gt).Mul(gt, FqNew().SetZero()).IsOne())
+}
@@ -0,0 +1,55 @@
+package bn254
+
+import (
+	"math/bits"
+
+	"github.com/mikelodder7/curvey/native"
+)
+
+var fqModulusBytes = [native.Field4Bytes]byte{0x01, 0x00, 0x00, 0xf0, 0x93, 0xf5, 0xe1, 0x43, 0x91, 0x70, 0xb9, 0x79, 0x48, 0xe8, 0x33, 0x28, 0x5d, 0x58, 0x81, 0x81, 0xb6, 0x45, 0x50, 0xb8, 0x29, 0xa0, 0x31, 0xe1, 0x72, 0x4e, 0x64, 0x30}
+
+// sixUPlus2NAF is the non-adjacent form of 6x+2, least significant digit first,
+// which is the loop count for the optimal ate pairing.
+var sixUPlus2NAF = [...]int8{
+	0, 0, 0, 1, 0, 1, 0, -1, 0, 0, -1, 0, 0, 0, 1, 0, 0, -1, 0, -1, 0, 0, 0, 1, 0, -1, 0, 0, 0, 0, -1, 0, 0,
+	1, 0, -1, 0, 0, 1, 0, 0, 0, 0, 0, -1, 0, 0, -1, 0, 1, 0, -1, 0, 0, 0, -1, 0, -1, 0, 0, 0, 1, 0, -1, 0, 1,
+}
+
+const (
+	// The BN parameter x for BN254 is 0x44e992b44a6909f1.
+	paramX               = uint64(0x44e992b44a6909f1)
+	Limbs                = 4
+	FieldBytes           = 32
+	WideFieldBytes       = 64
+	DoubleWideFieldBytes = 128
+)
+
+// mac Multiply and Accumulate - compute a + (b * c) + d, return the result and new carry.
+func mac(a, b, c, d uint64) (lo, hi uint64) {
+	hi, lo = bits.Mul64(b, c)
+	carry2, carry := bits.Add64(a, d, 0)
+	hi, _ = bits.Add64(hi, 0, carry)
+	lo, carry = bits.Add64(lo, carry2, 0)
+	hi, _ = bits.Add64(hi, 0, carry)
+
+	return lo, hi
+}
+
+// adc Add w/Carry.
+func adc(x, y, carry uint64) (sum, carryOut uint64) {
+	sum = x + y + carry
+	// The sum will overflow if both top bits are set (x & y) or if one of them
+	// is (x | y), and a carry from the lower place happened. If such a carry
+	// happens, the top bit will be 1 + 0 + 1 = 0 (&^ sum).
+	carryOut = ((x & y) | ((x | y) &^ sum)) >> 63
+	carryOut |= ((x & carry) | ((x | carry) &^ sum)) >> 63
+	carryOut |= ((y & carry) | ((y | carry) &^ sum)) >> 63
+	return sum, carryOut
+}
+
+// sbb Subtract with borrow.
+func sbb(x, y, borrow uint64) (diff, borrowOut uint64) {
+	diff = x - (y + borrow)
+	borrowOut = ((^x & y) | (^(x ^ y) & diff)) >> 63
+	return diff, borrowOut
+}
@@ -0,0 +1,86 @@
+package bn254
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFp12Arithmetic(t *testing.T) {
+	var a, b, c, d, e fp12
+	for i := 0; i < 10; i++ {
+		_, _ = a.Random(crand.Reader)
+		_, _ = b.Random(crand.Reader)
+
+		require.Equal(t, 1, c.Square(&a).Equal(d.Mul(&a, &a)))
+		require.Equal(t, 1, c.Mul(&a, &b).Equal(d.Mul(&b, &a)))
+		require.Equal(t, 1, c.Sub(c.Add(&a, &b), &b).Equal(&a))
+
+		_, wasInverted := c.Invert(&a)
+		require.Equal(t, 1, wasInverted)
+		require.Equal(t, 1, c.Mul(&c, &a).IsOne())
+
+		// frobenius is a ring homomorphism of order 12
+		c.FrobeniusMap(&a)
+		d.FrobeniusMap(&b)
+		c.Mul(&c, &d)
+		e.Mul(&a, &b)
+		require.Equal(t, 1, c.Equal(d.FrobeniusMap(&e)))
+		c.Set(&a)
+		for j := 0; j < 12; j++ {
+			c.FrobeniusMap(&c)
+		}
+		require.Equal(t, 1, c.Equal(&a))
+		// frobenius^6 is the conjugation
+		c.Set(&a)
+		for j := 0; j < 6; j++ {
+			c.FrobeniusMap(&c)
+		}
+		require.Equal(t, 1, c.Equal(d.Conjugate(&a)))
+	}
+}
+
+func TestFp12MulBy034(t *testing.T) {
+	var a, b, c, d fp12
+	var x, y, z fp2
+	_, _ = a.Random(crand.Reader)
+	_, _ = x.Random(crand.Reader)
+	_, _ = y.Random(crand.Reader)
+	_, _ = z.Random(crand.Reader)
+
+	b.SetZero()
+	b.A.A.Set(&x)
+	b.B.A.Set(&y)
+	b.B.B.Set(&z)
+	c.Mul(&a, &b)
+	d.MulBy034(&a, &x, &y, &z)
+	require.Equal(t, 1, c.Equal(&d))
+}
+
+func TestGtCyclotomic(t *testing.T) {
+	var a, b, c Gt
+	_, _ = a.Random(crand.Reader)
+	a.FinalExponentiation(&a)
+	require.Equal(t, 1, b.Square(&a).Equal((*Gt)(new(fp12).Square((*fp12)(&a)))))
+
+	b.Mul(&a, FqNew().SetUint64(paramX))
+	(*fp12)(&c).cyclotomicExp((*fp12)(&a))
+	require.Equal(t, 1, b.Equal(&c))
+
+	// elements of Gt have order r
+	q := FqNew().SetOne()
+	q.Neg(q)
+	b.Mul(&a, q)
+	c.Add(&b, &a)
+	require.Equal(t, 1, c.IsOne())
+	require.Equal(t, 1, b.Equal(c.Neg(&a)))
+}
+
+func TestGtBytes(t *testing.T) {
+	g := new(Gt).Generator()
+	b := g.Bytes()
+	h, valid := new(Gt).SetBytes(&b)
+	require.Equal(t, 1, valid)
+	require.Equal(t, 1, g.Equal(h))
+}
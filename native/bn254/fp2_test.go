@@ -0,0 +1,62 @@
+package bn254
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFp2Arithmetic(t *testing.T) {
+	var a, b, c, d, xi fp2
+	xi.A.SetUint64(9)
+	xi.B.SetOne()
+	for i := 0; i < 25; i++ {
+		_, _ = a.Random(crand.Reader)
+		_, _ = b.Random(crand.Reader)
+
+		require.Equal(t, 1, c.Square(&a).Equal(d.Mul(&a, &a)))
+		require.Equal(t, 1, c.MulByNonResidue(&a).Equal(d.Mul(&a, &xi)))
+		require.Equal(t, 1, c.Sub(c.Add(&a, &b), &b).Equal(&a))
+
+		_, wasInverted := c.Invert(&a)
+		require.Equal(t, 1, wasInverted)
+		require.Equal(t, 1, c.Mul(&c, &a).IsOne())
+
+		// The frobenius map is the conjugation
+		require.Equal(t, 1, c.FrobeniusMap(&a).Equal(d.Conjugate(&a)))
+		var e fp2
+		c.Mul(c.FrobeniusMap(&a), e.FrobeniusMap(&b))
+		require.Equal(t, 1, c.Equal(d.FrobeniusMap(d.Mul(&a, &b))))
+
+		c.Square(&a)
+		_, wasSquare := d.Sqrt(&c)
+		require.Equal(t, 1, wasSquare)
+		require.Equal(t, 1, d.Square(&d).Equal(&c))
+	}
+	// xi is not a square
+	_, wasSquare := c.Sqrt(&xi)
+	require.Equal(t, 0, wasSquare)
+}
+
+func TestFp2MulBy3b(t *testing.T) {
+	var a, e, res, xi fp2
+	xi.A.SetUint64(9)
+	xi.B.SetOne()
+	_, _ = a.Random(crand.Reader)
+	e.Mul(&a, &curveG2B)
+	e.A.MulBy3b(&e.A)
+	e.B.MulBy3b(&e.B)
+	e.A.MulBy3b(&e.A)
+	e.B.MulBy3b(&e.B)
+	// 9 * 9 * b' * a = 81 * a * 3 / xi, so compare 3b' * a * 27
+	res.MulBy3b(&a)
+	res.A.MulBy3b(&res.A)
+	res.B.MulBy3b(&res.B)
+	res.Mul(&res, new(fp2).SetFp(new(fp).SetUint64(3)))
+	require.Equal(t, 1, e.Equal(&res))
+
+	// b' * xi = 3
+	e.Mul(&curveG2B, &xi)
+	require.Equal(t, 1, e.Equal(new(fp2).SetFp(new(fp).SetUint64(3))))
+}
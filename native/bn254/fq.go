@@ -0,0 +1,124 @@
+package bn254
+
+import (
+	"encoding/binary"
+	"sync"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+)
+
+var (
+	fqInitonce sync.Once
+	fqParams   native.Field4Params
+)
+
+// FqNew returns an element of the bn254 scalar field, the field
+// defined by the order of G1, G2 and Gt.
+func FqNew() *native.Field4 {
+	return &native.Field4{
+		Value:      [native.Field4Limbs]uint64{},
+		Params:     getFqParams(),
+		Arithmetic: fqArithmetic{},
+	}
+}
+
+func fqParamsInit() {
+	params := internal.Bn254FqParams()
+	fqParams = native.Field4Params{
+		BiModulus: params.BiModulus,
+	}
+	copy(fqParams.R[:], params.R)
+	copy(fqParams.R2[:], params.R2)
+	copy(fqParams.R3[:], params.R3)
+	copy(fqParams.Modulus[:], params.Modulus)
+}
+
+func getFqParams() *native.Field4Params {
+	fqInitonce.Do(fqParamsInit)
+	return &fqParams
+}
+
+// fqArithmetic adapts the generic montgomery field arithmetic
+// to the fixed size limbs used by native.Field4.
+type fqArithmetic struct{}
+
+// ToMontgomery converts this field to montgomery form.
+func (fqArithmetic) ToMontgomery(out, arg *[native.Field4Limbs]uint64) {
+	o, a := out[:], arg[:]
+	internal.Bn254FqParams().ToMontgomery(&o, &a)
+}
+
+// FromMontgomery converts this field from montgomery form.
+func (fqArithmetic) FromMontgomery(out, arg *[native.Field4Limbs]uint64) {
+	o, a := out[:], arg[:]
+	internal.Bn254FqParams().FromMontgomery(&o, &a)
+}
+
+// Neg performs modular negation.
+func (fqArithmetic) Neg(out, arg *[native.Field4Limbs]uint64) {
+	o, a := out[:], arg[:]
+	internal.Bn254FqParams().Neg(&o, &a)
+}
+
+// Square performs modular square.
+func (fqArithmetic) Square(out, arg *[native.Field4Limbs]uint64) {
+	o, a := out[:], arg[:]
+	internal.Bn254FqParams().Square(&o, &a)
+}
+
+// Mul performs modular multiplication.
+func (fqArithmetic) Mul(out, arg1, arg2 *[native.Field4Limbs]uint64) {
+	o, a1, a2 := out[:], arg1[:], arg2[:]
+	internal.Bn254FqParams().Mul(&o, &a1, &a2)
+}
+
+// Add performs modular addition.
+func (fqArithmetic) Add(out, arg1, arg2 *[native.Field4Limbs]uint64) {
+	o, a1, a2 := out[:], arg1[:], arg2[:]
+	internal.Bn254FqParams().Add(&o, &a1, &a2)
+}
+
+// Sub performs modular subtraction.
+func (fqArithmetic) Sub(out, arg1, arg2 *[native.Field4Limbs]uint64) {
+	o, a1, a2 := out[:], arg1[:], arg2[:]
+	internal.Bn254FqParams().Sub(&o, &a1, &a2)
+}
+
+// Sqrt performs modular square root.
+func (fqArithmetic) Sqrt(wasSquare *int, out, arg *[native.Field4Limbs]uint64) {
+	o, a := out[:], arg[:]
+	internal.Bn254FqParams().Sqrt(wasSquare, &o, &a)
+}
+
+// Invert performs modular inverse.
+func (fqArithmetic) Invert(wasInverted *int, out, arg *[native.Field4Limbs]uint64) {
+	o, a := out[:], arg[:]
+	internal.Bn254FqParams().Invert(wasInverted, &o, &a)
+}
+
+// FromBytes converts a little endian byte array into a field element.
+func (fqArithmetic) FromBytes(out *[native.Field4Limbs]uint64, arg *[native.Field4Bytes]byte) {
+	out[0] = binary.LittleEndian.Uint64(arg[:8])
+	out[1] = binary.LittleEndian.Uint64(arg[8:16])
+	out[2] = binary.LittleEndian.Uint64(arg[16:24])
+	out[3] = binary.LittleEndian.Uint64(arg[24:])
+}
+
+// ToBytes converts a field element to a little endian byte array.
+func (fqArithmetic) ToBytes(out *[native.Field4Bytes]byte, arg *[native.Field4Limbs]uint64) {
+	binary.LittleEndian.PutUint64(out[:8], arg[0])
+	binary.LittleEndian.PutUint64(out[8:16], arg[1])
+	binary.LittleEndian.PutUint64(out[16:24], arg[2])
+	binary.LittleEndian.PutUint64(out[24:], arg[3])
+}
+
+// Selectznz performs conditional select.
+// selects arg1 if choice == 0 and arg2 if choice == 1.
+func (fqArithmetic) Selectznz(out, arg1, arg2 *[native.Field4Limbs]uint64, choice int) {
+	b := uint64(-choice)
+	out[0] = arg1[0] ^ ((arg1[0] ^ arg2[0]) & b)
+	out[1] = arg1[1] ^ ((arg1[1] ^ arg2[1]) & b)
+	out[2] = arg1[2] ^ ((arg1[2] ^ arg2[2]) & b)
+	out[3] = arg1[3] ^ ((arg1[3] ^ arg2[3]) & b)
+}
@@ -0,0 +1,218 @@
+package bn254
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikelodder7/curvey/native"
+)
+
+func TestG1IsOnCurve(t *testing.T) {
+	require.Equal(t, 1, new(G1).Identity().IsOnCurve())
+	require.Equal(t, 1, new(G1).Generator().IsOnCurve())
+	require.Equal(t, 1, new(G1).Generator().InCorrectSubgroup())
+
+	x, y := new(G1).Generator().BigInt()
+	require.Equal(t, 0, x.Cmp(big.NewInt(1)))
+	require.Equal(t, 0, y.Cmp(big.NewInt(2)))
+
+	_, err := new(G1).SetBigInt(big.NewInt(1), big.NewInt(3))
+	require.Error(t, err)
+}
+
+func TestG1Arithmetic(t *testing.T) {
+	g := new(G1).Generator()
+	a := new(G1).Double(g)
+	b := new(G1).Add(g, g)
+	require.Equal(t, 1, a.Equal(b))
+	b.Mul(g, FqNew().SetUint64(2))
+	require.Equal(t, 1, a.Equal(b))
+	b.Sub(a, g)
+	require.Equal(t, 1, g.Equal(b))
+
+	b.Add(g, new(G1).Neg(g))
+	require.Equal(t, 1, b.IsIdentity())
+	b.Double(b)
+	require.Equal(t, 1, b.IsIdentity())
+
+	// r * G = 0
+	q := FqNew().SetOne()
+	q.Neg(q)
+	b.Mul(g, q)
+	require.Equal(t, 1, b.Equal(new(G1).Neg(g)))
+
+	var bytes [64]byte
+	_, _ = crand.Read(bytes[:])
+	s := FqNew().SetBytesWide(&bytes)
+	_, _ = crand.Read(bytes[:])
+	u := FqNew().SetBytesWide(&bytes)
+	a.Mul(g, s)
+	a.Add(a, new(G1).Mul(g, u))
+	b.Mul(g, FqNew().Add(s, u))
+	require.Equal(t, 1, a.Equal(b))
+}
+
+// TestG1Hash checks the hash to curve vectors gnark-crypto publishes
+// for the BN254G1_XMD:SHA-256_SVDW_RO_ suite.
+func TestG1Hash(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-BN254G1_XMD:SHA-256_SVDW_RO_")
+	tests := []struct {
+		input, expected string
+	}{
+		{"", "0a976ab906170db1f9638d376514dbf8c42aef256a54bbd48521f20749e59e8602925ead66b9e68bfc309b014398640ab55f6619ab59bc1fab2210ad4c4d53d5"},
+		{"abc", "23f717bee89b1003957139f193e6be7da1df5f1374b26a4643b0378b5baf53d104142f826b71ee574452dbc47e05bc3e1a647478403a7ba38b7b93948f4e151d"},
+		{"abcdef0123456789", "187dbf1c3c89aceceef254d6548d7163fdfa43084145f92c4c91c85c21442d4a0abd99d5b0000910b56058f9cc3b0ab0a22d47cf27615f588924fac1e5c63b4d"},
+		{"q128_qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq", "00fe2b0743575324fc452d590d217390ad48e5a16cf051bee5c40a2eba233f5c0794211e0cc72d3cbbdf8e4e5cd6e7d7e78d101ff94862caae8acbe63e9fdc78"},
+		{"a512_aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "01b05dc540bd79fd0fea4fbb07de08e94fc2e7bd171fe025c479dc212a2173ce1bf028afc00c0f843d113758968f580640541728cfc6d32ced9779aa613cd9b0"},
+	}
+
+	pt := new(G1).Identity()
+	for _, tst := range tests {
+		pt.Hash(native.EllipticPointHasherSha256(), []byte(tst.input), dst)
+		require.Equal(t, 1, pt.IsOnCurve())
+		out := pt.ToUncompressed()
+		require.Equal(t, tst.expected, hex.EncodeToString(out[:]))
+	}
+}
+
+// TestG1Eip196 checks ecAdd and ecMul results from the EIP-196
+// precompiles. The points were computed with go-ethereum's
+// crypto/bn256/cloudflare, the implementation behind the precompiles.
+func TestG1Eip196(t *testing.T) {
+	decode := func(s string) *G1 {
+		var input [WideFieldBytes]byte
+		b, err := hex.DecodeString(s)
+		require.NoError(t, err)
+		copy(input[:], b)
+		p, err := new(G1).FromUncompressed(&input)
+		require.NoError(t, err)
+		out := p.ToUncompressed()
+		require.Equal(t, s, hex.EncodeToString(out[:]))
+		return p
+	}
+	a, _ := new(big.Int).SetString("1a2b3c4d5e6f708192a3b4c5d6e7f80123456789abcdef0fedcba9876543210", 16)
+	b, _ := new(big.Int).SetString("2f1e0d0c0b0a09080706050403020100ffeeddccbbaa99887766554433221100", 16)
+	g := decode("00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002")
+	// ecMul(G, a)
+	p := decode("0e4bd3536d35ab52cc4d33b69b87c15cc6b5c93742e2533395a44748a07068600f5663f7dd7dd4e2de332ce5315fa87e461feff3c390eccd77fce9476f7424f1")
+	// ecMul(G, b)
+	s := decode("2e887205aa87ccd739858e9d849e787fa58ba4d1d6951ee039b03aa3e23845f41769e2aa318de1d83d6d2418a21ed6b3e315707a08d7f23a232555e69b4d2326")
+	// ecAdd(ecMul(G, a), ecMul(G, b))
+	sum := decode("0545ef4c3eb39558f9bb3c80bfced3a397eebefc5f21c24148606d718c55820718523c504d7ed588f043e1e24904640ce43386aa769d556e2d5695eac75962f1")
+	// ecMul(ecMul(G, a), b)
+	product := decode("2b8bd8dc5ae0661c5721b9e2c6b8d4bcf8158593527191406ef008a8ed3ef96309015025f784f59a8da54d192326c1872f1b68c5338b192b2b1a08108ab570f4")
+	// ecAdd(G, G) and ecMul(G, 2)
+	double := decode("030644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd315ed738c0e0a7c92e7845f96b2ae9c0a68a6a449e3538fc7ff3ebf7a5a18a2c4")
+
+	require.Equal(t, 1, g.Equal(new(G1).Generator()))
+	require.Equal(t, 1, p.Equal(new(G1).Mul(g, FqNew().SetBigInt(a))))
+	require.Equal(t, 1, s.Equal(new(G1).Mul(g, FqNew().SetBigInt(b))))
+	require.Equal(t, 1, sum.Equal(new(G1).Add(p, s)))
+	require.Equal(t, 1, sum.Equal(new(G1).Mul(g, FqNew().SetBigInt(new(big.Int).Add(a, b)))))
+	require.Equal(t, 1, product.Equal(new(G1).Mul(p, FqNew().SetBigInt(b))))
+	require.Equal(t, 1, double.Equal(new(G1).Add(g, g)))
+	require.Equal(t, 1, double.Equal(new(G1).Mul(g, FqNew().SetUint64(2))))
+}
+
+func TestG1Serialization(t *testing.T) {
+	a, _ := new(G1).Random(crand.Reader)
+	b, _ := new(G1).Random(crand.Reader)
+
+	aBytes := a.ToCompressed()
+	bBytes := b.ToCompressed()
+
+	aa, err := new(G1).FromCompressed(&aBytes)
+	require.NoError(t, err)
+	require.Equal(t, 1, a.Equal(aa))
+
+	bb, err := new(G1).FromCompressed(&bBytes)
+	require.NoError(t, err)
+	require.Equal(t, 1, b.Equal(bb))
+
+	auBytes := a.ToUncompressed()
+	_, err = aa.FromUncompressed(&auBytes)
+	require.NoError(t, err)
+	require.Equal(t, 1, a.Equal(aa))
+
+	bBytes = a.ToCompressed()
+	a.Neg(a)
+	aBytes = a.ToCompressed()
+	require.NotEqual(t, aBytes[0]&g1FlagMask, bBytes[0]&g1FlagMask)
+	_, err = aa.FromCompressed(&aBytes)
+	require.NoError(t, err)
+	require.Equal(t, 1, a.Equal(aa))
+
+	// EIP-196 encodes the point at infinity as all zeros
+	id := new(G1).Identity()
+	idBytes := id.ToUncompressed()
+	require.Equal(t, [WideFieldBytes]byte{}, idBytes)
+	_, err = aa.FromUncompressed(&idBytes)
+	require.NoError(t, err)
+	require.Equal(t, 1, aa.IsIdentity())
+	idc := id.ToCompressed()
+	require.Equal(t, g1CompressedInfinity, idc[0])
+	_, err = aa.FromCompressed(&idc)
+	require.NoError(t, err)
+	require.Equal(t, 1, aa.IsIdentity())
+
+	auBytes[WideFieldBytes-1] ^= 1
+	_, err = aa.FromUncompressed(&auBytes)
+	require.Error(t, err)
+}
+
+func TestG1SumOfProducts(t *testing.T) {
+	var b [64]byte
+	h0, _ := new(G1).Random(crand.Reader)
+	_, _ = crand.Read(b[:])
+	s := FqNew().SetBytesWide(&b)
+	_, _ = crand.Read(b[:])
+	c := FqNew().SetBytesWide(&b)
+
+	lhs := new(G1).Mul(h0, s)
+	lhs.Add(lhs, new(G1).Mul(h0, c))
+	rhs, err := new(G1).SumOfProducts([]*G1{h0, h0}, []*native.Field4{s, c})
+	require.NoError(t, err)
+	require.Equal(t, 1, lhs.Equal(rhs))
+}
+
+func TestG1Msm(t *testing.T) {
+	var b [64]byte
+	points := make([]*G1, 64)
+	scalars := make([]*native.Field4, 64)
+	for i := range points {
+		_, _ = crand.Read(b[:])
+		points[i], _ = new(G1).Random(crand.Reader)
+		scalars[i] = FqNew().SetBytesWide(&b)
+	}
+	// Repeated and opposite points exercise doubling and
+	// cancellation when the buckets are summed in affine
+	points[1] = points[0]
+	points[2] = new(G1).Neg(points[0])
+	points[3] = new(G1).Double(points[4])
+	points[5] = new(G1).Identity()
+	scalars[1] = scalars[0]
+	scalars[2] = scalars[0]
+	scalars[6].SetZero()
+	expected, err := new(G1).SumOfProducts(points, scalars)
+	require.NoError(t, err)
+
+	for _, window := range []int{0, 2, 5, 11} {
+		for _, workers := range []int{0, 3, 100} {
+			actual, err := new(G1).MsmVarTime(points, scalars, &native.MsmOptions{Window: window, Workers: workers})
+			require.NoError(t, err)
+			require.Equal(t, 1, expected.Equal(actual))
+		}
+	}
+	// Fewer than MsmStrausPoints points use interleaved wNAF
+	expected, err = new(G1).SumOfProducts(points[:8], scalars[:8])
+	require.NoError(t, err)
+	actual, err := new(G1).SumOfProductsVarTime(points[:8], scalars[:8])
+	require.NoError(t, err)
+	require.Equal(t, 1, expected.Equal(actual))
+	_, err = new(G1).MsmVarTime(points, scalars[1:], nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,889 @@
+package bn254
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+)
+
+var (
+	g2x = fp2{
+		A: fp{
+			0x8e83b5d102bc2026,
+			0xdceb1935497b0172,
+			0xfbb8264797811adf,
+			0x19573841af96503b,
+		},
+		B: fp{
+			0xafb4737da84c6140,
+			0x6043dd5a5802d8c4,
+			0x09e950fc52a02f86,
+			0x14fef0833aea7b6b,
+		},
+	}
+	g2y = fp2{
+		A: fp{
+			0x619dfa9d886be9f6,
+			0xfe7fd297f59e9b78,
+			0xff9e1a62231b7dfe,
+			0x28fd7eebae9e4206,
+		},
+		B: fp{
+			0x64095b56c71856ee,
+			0xdc57f922327d3cbb,
+			0x55f935be33351076,
+			0x0da4a0e693fd6482,
+		},
+	}
+	// b = 3 / (u + 9)
+	curveG2B = fp2{
+		A: fp{
+			0x3bf938e377b802a8,
+			0x020b1b273633535d,
+			0x26b7edf049755260,
+			0x2514c6324384a86d,
+		},
+		B: fp{
+			0x38e7ecccd1dcff67,
+			0x65f0b37d93ce0d3e,
+			0xd749d0dd22ac00aa,
+			0x0141b9ce4a688d4d,
+		},
+	}
+	// 3b
+	curveG23B = fp2{
+		A: fp{
+			0x3baa927cb62e0d6a,
+			0xd71e7c52d1b664fd,
+			0x03873e63d95d4664,
+			0x0e75b5b1082ab8f4,
+		},
+		B: fp{
+			0xaab7c6667596fe35,
+			0x31d21a78bb6a27ba,
+			0x85dd7297680401ff,
+			0x03c52d6adf39a7e9,
+		},
+	}
+	// (u + 9)^((p - 1) / 3)
+	psiCoeffX = fp2{
+		A: fp{
+			0xb5773b104563ab30,
+			0x347f91c8a9aa6454,
+			0x7a007127242e0991,
+			0x1956bcd8118214ec,
+		},
+		B: fp{
+			0x6e849f1ea0aa4757,
+			0xaa1c7b6d89f89141,
+			0xb6e713cdfae0ca3a,
+			0x26694fbb4e82ebc3,
+		},
+	}
+	// (u + 9)^((p - 1) / 2)
+	psiCoeffY = fp2{
+		A: fp{
+			0xe4bbdd0c2936b629,
+			0xbb30f162e133bacb,
+			0x31a9d1b6f9645366,
+			0x253570bea500f8dd,
+		},
+		B: fp{
+			0xa1d77ce45ffe77c7,
+			0x07affd117826d1db,
+			0x6d16bd27bb7edc6b,
+			0x2c87200285defecc,
+		},
+	}
+	// Shallue-van de Woestijne constants for the twist with Z = 1
+	g2SvdwZ = fp2{
+		A: fp{
+			0xd35d438dc58f0d9d,
+			0x0a78eb28f5c70b3d,
+			0x666ea36f7879462c,
+			0x0e0a77c19a07df2f,
+		},
+		B: fp{
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+		},
+	}
+	// g(Z)
+	g2SvdwC1 = fp2{
+		A: fp{
+			0xd335f05a64ca12fe,
+			0x75029bbec388940d,
+			0xd4d64ba9406d402e,
+			0x02baef80fc5ae772,
+		},
+		B: fp{
+			0x38e7ecccd1dcff67,
+			0x65f0b37d93ce0d3e,
+			0xd749d0dd22ac00aa,
+			0x0141b9ce4a688d4d,
+		},
+	}
+	// -Z / 2
+	g2SvdwC2 = fp2{
+		A: fp{
+			0xb461a4448976f7d5,
+			0xc6843fb439555fa7,
+			0x28f0d12384840918,
+			0x112ceb58a394e07d,
+		},
+		B: fp{
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+		},
+	}
+	// sqrt(-g(Z) * 3 * Z^2)
+	g2SvdwC3 = fp2{
+		A: fp{
+			0xaaad0cab9a24277f,
+			0xf2209f5b7e5b757a,
+			0xc3a46b7e850013a7,
+			0x1f9e7f3768c5c9af,
+		},
+		B: fp{
+			0x412278c8de85d863,
+			0xfe3e4c7f559d375a,
+			0x5e44b9da0a96ad23,
+			0x297d818d387725c8,
+		},
+	}
+	// -4 * g(Z) / (3 * Z^2)
+	g2SvdwC4 = fp2{
+		A: fp{
+			0x63cdc796b49b3a32,
+			0x73a8220d40eb16f6,
+			0xb46d1eed55c49000,
+			0x1c9ef4f5f0528b82,
+		},
+		B: fp{
+			0x9aeb505b1600fe13,
+			0x64eb25e9f8b4638f,
+			0x43edd9e4fdf1577a,
+			0x2eb756b528a63917,
+		},
+	}
+)
+
+// G2 is a point in g2.
+type G2 struct {
+	x, y, z fp2
+}
+
+// Random creates a random point on the curve
+// from the specified reader.
+func (g2 *G2) Random(reader io.Reader) (*G2, error) {
+	var seed [native.WideField4Bytes]byte
+	n, err := reader.Read(seed[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "random could not read from stream")
+	}
+	if n != native.WideField4Bytes {
+		return nil, fmt.Errorf("insufficient bytes read %d when %d are needed", n, WideFieldBytes)
+	}
+	dst := []byte("BN254G2_XMD:SHA-256_SVDW_RO_")
+	return g2.Hash(native.EllipticPointHasherSha256(), seed[:], dst), nil
+}
+
+// Hash uses the hasher to map bytes to a valid point.
+// BN254 has no suite with an SSWU map in RFC 9380 so this
+// uses the Shallue-van de Woestijne map from section 6.6.1 with Z = 1.
+func (g2 *G2) Hash(hash *native.EllipticPointHasher, msg, dst []byte) *G2 {
+	var u []byte
+	var u0, u1 fp2
+	var q0, q1 G2
+
+	switch hash.Type() {
+	case native.XMD:
+		u = native.ExpandMsgXmd(hash, msg, dst, 4*hashBytes)
+	case native.XOF:
+		u = native.ExpandMsgXof(hash, msg, dst, 4*hashBytes)
+	}
+
+	var buf [WideFieldBytes]byte
+	copy(buf[:hashBytes], internal.ReverseBytes(u[:hashBytes]))
+	u0.A.SetBytesWide(&buf)
+	copy(buf[:hashBytes], internal.ReverseBytes(u[hashBytes:2*hashBytes]))
+	u0.B.SetBytesWide(&buf)
+	copy(buf[:hashBytes], internal.ReverseBytes(u[2*hashBytes:3*hashBytes]))
+	u1.A.SetBytesWide(&buf)
+	copy(buf[:hashBytes], internal.ReverseBytes(u[3*hashBytes:]))
+	u1.B.SetBytesWide(&buf)
+
+	q0.svdw(&u0)
+	q1.svdw(&u1)
+	g2.Add(&q0, &q1)
+	return g2.ClearCofactor(g2)
+}
+
+// Identity returns the identity point.
+func (g2 *G2) Identity() *G2 {
+	g2.x.SetZero()
+	g2.y.SetOne()
+	g2.z.SetZero()
+	return g2
+}
+
+// Generator returns the base point.
+func (g2 *G2) Generator() *G2 {
+	g2.x.Set(&g2x)
+	g2.y.Set(&g2y)
+	g2.z.SetOne()
+	return g2
+}
+
+// IsIdentity returns true if this point is at infinity.
+func (g2 *G2) IsIdentity() int {
+	return g2.z.IsZero()
+}
+
+// IsOnCurve determines if this point represents a valid curve point.
+func (g2 *G2) IsOnCurve() int {
+	// Y^2 Z = X^3 + b Z^3
+	var lhs, rhs, t fp2
+	lhs.Square(&g2.y)
+	lhs.Mul(&lhs, &g2.z)
+
+	rhs.Square(&g2.x)
+	rhs.Mul(&rhs, &g2.x)
+	t.Square(&g2.z)
+	t.Mul(&t, &g2.z)
+	t.Mul(&t, &curveG2B)
+	rhs.Add(&rhs, &t)
+
+	return lhs.Equal(&rhs)
+}
+
+// InCorrectSubgroup returns 1 if the point is torsion free, 0 otherwise.
+func (g2 *G2) InCorrectSubgroup() int {
+	var t G2
+	t.multiply(g2, &fqModulusBytes)
+	return t.IsIdentity()
+}
+
+// Add adds this point to another point.
+func (g2 *G2) Add(arg1, arg2 *G2) *G2 {
+	// Algorithm 7, https://eprint.iacr.org/2015/1060.pdf
+	var t0, t1, t2, t3, t4, x3, y3, z3 fp2
+
+	t0.Mul(&arg1.x, &arg2.x)
+	t1.Mul(&arg1.y, &arg2.y)
+	t2.Mul(&arg1.z, &arg2.z)
+	t3.Add(&arg1.x, &arg1.y)
+	t4.Add(&arg2.x, &arg2.y)
+	t3.Mul(&t3, &t4)
+	t4.Add(&t0, &t1)
+	t3.Sub(&t3, &t4)
+	t4.Add(&arg1.y, &arg1.z)
+	x3.Add(&arg2.y, &arg2.z)
+	t4.Mul(&t4, &x3)
+	x3.Add(&t1, &t2)
+	t4.Sub(&t4, &x3)
+	x3.Add(&arg1.x, &arg1.z)
+	y3.Add(&arg2.x, &arg2.z)
+	x3.Mul(&x3, &y3)
+	y3.Add(&t0, &t2)
+	y3.Sub(&x3, &y3)
+	x3.Double(&t0)
+	t0.Add(&t0, &x3)
+	t2.MulBy3b(&t2)
+	z3.Add(&t1, &t2)
+	t1.Sub(&t1, &t2)
+	y3.MulBy3b(&y3)
+	x3.Mul(&t4, &y3)
+	t2.Mul(&t3, &t1)
+	x3.Sub(&t2, &x3)
+	y3.Mul(&y3, &t0)
+	t1.Mul(&t1, &z3)
+	y3.Add(&t1, &y3)
+	t0.Mul(&t0, &t3)
+	z3.Mul(&z3, &t4)
+	z3.Add(&z3, &t0)
+
+	g2.x.Set(&x3)
+	g2.y.Set(&y3)
+	g2.z.Set(&z3)
+	return g2
+}
+
+// Sub subtracts the two points.
+func (g2 *G2) Sub(arg1, arg2 *G2) *G2 {
+	var t G2
+	t.Neg(arg2)
+	return g2.Add(arg1, &t)
+}
+
+// Double this point.
+func (g2 *G2) Double(a *G2) *G2 {
+	// Algorithm 9, https://eprint.iacr.org/2015/1060.pdf
+	var t0, t1, t2, x3, y3, z3 fp2
+
+	t0.Square(&a.y)
+	z3.Double(&t0)
+	z3.Double(&z3)
+	z3.Double(&z3)
+	t1.Mul(&a.y, &a.z)
+	t2.Square(&a.z)
+	t2.MulBy3b(&t2)
+	x3.Mul(&t2, &z3)
+	y3.Add(&t0, &t2)
+	z3.Mul(&t1, &z3)
+	t1.Double(&t2)
+	t2.Add(&t2, &t1)
+	t0.Sub(&t0, &t2)
+	y3.Mul(&t0, &y3)
+	y3.Add(&y3, &x3)
+	t1.Mul(&a.x, &a.y)
+	x3.Mul(&t0, &t1)
+	x3.Double(&x3)
+
+	e := a.IsIdentity()
+	g2.x.CMove(&x3, t0.SetZero(), e)
+	g2.z.CMove(&z3, &t0, e)
+	g2.y.CMove(&y3, t0.SetOne(), e)
+	return g2
+}
+
+// Mul multiplies this point by the input scalar.
+func (g2 *G2) Mul(a *G2, s *native.Field4) *G2 {
+	bytes := s.Bytes()
+	return g2.multiply(a, &bytes)
+}
+
+func (g2 *G2) multiply(a *G2, bytes *[native.Field4Bytes]byte) *G2 {
+	var p G2
+	precomputed := [16]*G2{}
+	precomputed[0] = new(G2).Identity()
+	precomputed[1] = new(G2).Set(a)
+	for i := 2; i < 16; i += 2 {
+		precomputed[i] = new(G2).Double(precomputed[i>>1])
+		precomputed[i+1] = new(G2).Add(precomputed[i], a)
+	}
+	p.Identity()
+	for i := 0; i < 256; i += 4 {
+		// Brouwer / windowing method. window size of 4.
+		for j := 0; j < 4; j++ {
+			p.Double(&p)
+		}
+		window := bytes[32-1-i>>3] >> (4 - i&0x04) & 0x0F
+		p.Add(&p, precomputed[window])
+	}
+	return g2.Set(&p)
+}
+
+// MulByX multiplies by BN X using double and add.
+func (g2 *G2) MulByX(a *G2) *G2 {
+	var s, t, r G2
+	r.Identity()
+	t.Set(a)
+
+	for x := paramX; x != 0; x >>= 1 {
+		s.Add(&r, &t)
+		r.CMove(&r, &s, int(x&1))
+		t.Double(&t)
+	}
+	return g2.Set(&r)
+}
+
+// ClearCofactor using the method from section 6.1 of
+// [Fuentes-Castañeda, Knapp and Rodríguez-Henríquez](https://cacr.uwaterloo.ca/techreports/2011/cacr2011-26.pdf)
+// which computes [x]P + ψ([3x]P) + ψ^2([x]P) + ψ^3(P)
+// where x is the parameter of BN254.
+func (g2 *G2) ClearCofactor(a *G2) *G2 {
+	var t0, t1, t2, t3 G2
+
+	t0.MulByX(a)
+	t1.Double(&t0)
+	t1.Add(&t1, &t0)
+	t1.psi(&t1)
+	t2.psi(&t0)
+	t2.psi(&t2)
+	t3.psi(a)
+	t3.psi(&t3)
+	t3.psi(&t3)
+
+	t0.Add(&t0, &t1)
+	t0.Add(&t0, &t2)
+	t0.Add(&t0, &t3)
+	return g2.Set(&t0)
+}
+
+// Neg negates this point.
+func (g2 *G2) Neg(a *G2) *G2 {
+	g2.Set(a)
+	g2.y.CNeg(&a.y, -(a.IsIdentity() - 1))
+	return g2
+}
+
+// Set copies a into g2.
+func (g2 *G2) Set(a *G2) *G2 {
+	g2.x.Set(&a.x)
+	g2.y.Set(&a.y)
+	g2.z.Set(&a.z)
+	return g2
+}
+
+// BigInt returns the x and y as big.Ints in affine.
+func (g2 *G2) BigInt() (x, y *big.Int) {
+	out := g2.ToUncompressed()
+	x = new(big.Int).SetBytes(out[:WideFieldBytes])
+	y = new(big.Int).SetBytes(out[WideFieldBytes:])
+	return x, y
+}
+
+// SetBigInt creates a point from affine x, y
+// and returns the point if it is on the curve.
+func (g2 *G2) SetBigInt(x, y *big.Int) (*G2, error) {
+	var tt [DoubleWideFieldBytes]byte
+
+	if len(x.Bytes()) == 0 && len(y.Bytes()) == 0 {
+		return g2.Identity(), nil
+	}
+	if x.BitLen() > 8*WideFieldBytes || y.BitLen() > 8*WideFieldBytes {
+		return nil, errors.New("invalid coordinates")
+	}
+	x.FillBytes(tt[:WideFieldBytes])
+	y.FillBytes(tt[WideFieldBytes:])
+
+	return g2.FromUncompressed(&tt)
+}
+
+// ToCompressed serializes this element into compressed form.
+// The x-coordinate is written as x.B || x.A big-endian with the top
+// two bits holding the flags for infinity and the sign of y.
+func (g2 *G2) ToCompressed() [WideFieldBytes]byte {
+	var out [WideFieldBytes]byte
+	var t G2
+	t.ToAffine(g2)
+	xABytes := t.x.A.Bytes()
+	xBBytes := t.x.B.Bytes()
+	copy(out[:FieldBytes], internal.ReverseBytes(xBBytes[:]))
+	copy(out[FieldBytes:], internal.ReverseBytes(xABytes[:]))
+	isInfinity := byte(g2.IsIdentity())
+	largest := byte(t.y.LexicographicallyLargest())
+	out[0] |= g1CompressedInfinity & -isInfinity
+	out[0] |= g1CompressedSmallest & (isInfinity - 1)
+	out[0] |= g1CompressedLargest & -largest & (isInfinity - 1)
+	return out
+}
+
+// FromCompressed deserializes this element from compressed form.
+func (g2 *G2) FromCompressed(input *[WideFieldBytes]byte) (*G2, error) {
+	var xFp, yFp fp2
+	var xA, xB [FieldBytes]byte
+	var p G2
+	flags := input[0] & g1FlagMask
+
+	switch flags {
+	case g1CompressedInfinity:
+		return g2.Identity(), nil
+	case g1CompressedSmallest, g1CompressedLargest:
+	default:
+		return nil, errors.New("compressed flag must be set")
+	}
+
+	copy(xB[:], internal.ReverseBytes(input[:FieldBytes]))
+	copy(xA[:], internal.ReverseBytes(input[FieldBytes:]))
+	// Mask away the flag bits
+	xB[FieldBytes-1] &^= g1FlagMask
+	_, validA := xFp.A.SetBytes(&xA)
+	_, validB := xFp.B.SetBytes(&xB)
+
+	if validA&validB != 1 {
+		return nil, errors.New("invalid bytes - not in field")
+	}
+
+	// Recover a y-coordinate given x by y = sqrt(x^3 + b)
+	yFp.Square(&xFp)
+	yFp.Mul(&yFp, &xFp)
+	yFp.Add(&yFp, &curveG2B)
+
+	if _, wasSquare := yFp.Sqrt(&yFp); wasSquare != 1 {
+		return nil, errors.New("point is not on the curve")
+	}
+
+	sortFlag := 0
+	if flags == g1CompressedLargest {
+		sortFlag = 1
+	}
+	yFp.CNeg(&yFp, yFp.LexicographicallyLargest()^sortFlag)
+	p.x.Set(&xFp)
+	p.y.Set(&yFp)
+	p.z.SetOne()
+	if p.InCorrectSubgroup() == 0 {
+		return nil, errors.New("point is not in correct subgroup")
+	}
+	return g2.Set(&p), nil
+}
+
+// ToUncompressed serializes this element into the EIP-197 form
+// which is x.B || x.A || y.B || y.A big-endian
+// with the point at infinity as all zeros.
+func (g2 *G2) ToUncompressed() [DoubleWideFieldBytes]byte {
+	var out [DoubleWideFieldBytes]byte
+	var t G2
+	t.ToAffine(g2)
+	bytes := t.x.B.Bytes()
+	copy(out[:FieldBytes], internal.ReverseBytes(bytes[:]))
+	bytes = t.x.A.Bytes()
+	copy(out[FieldBytes:WideFieldBytes], internal.ReverseBytes(bytes[:]))
+	bytes = t.y.B.Bytes()
+	copy(out[WideFieldBytes:WideFieldBytes+FieldBytes], internal.ReverseBytes(bytes[:]))
+	bytes = t.y.A.Bytes()
+	copy(out[WideFieldBytes+FieldBytes:], internal.ReverseBytes(bytes[:]))
+	return out
+}
+
+// FromUncompressed deserializes this element from the EIP-197 form.
+func (g2 *G2) FromUncompressed(input *[DoubleWideFieldBytes]byte) (*G2, error) {
+	var a, b fp
+	var t [FieldBytes]byte
+	var p G2
+
+	copy(t[:], internal.ReverseBytes(input[:FieldBytes]))
+	_, valid := b.SetBytes(&t)
+	if valid == 0 {
+		return nil, errors.New("invalid bytes - x.B not in field")
+	}
+	copy(t[:], internal.ReverseBytes(input[FieldBytes:WideFieldBytes]))
+	_, valid = a.SetBytes(&t)
+	if valid == 0 {
+		return nil, errors.New("invalid bytes - x.A not in field")
+	}
+
+	p.x.B.Set(&b)
+	p.x.A.Set(&a)
+
+	copy(t[:], internal.ReverseBytes(input[WideFieldBytes:WideFieldBytes+FieldBytes]))
+	_, valid = b.SetBytes(&t)
+	if valid == 0 {
+		return nil, errors.New("invalid bytes - y.B not in field")
+	}
+	copy(t[:], internal.ReverseBytes(input[FieldBytes+WideFieldBytes:]))
+	_, valid = a.SetBytes(&t)
+	if valid == 0 {
+		return nil, errors.New("invalid bytes - y.A not in field")
+	}
+
+	p.y.B.Set(&b)
+	p.y.A.Set(&a)
+
+	if p.x.IsZero()&p.y.IsZero() == 1 {
+		return g2.Identity(), nil
+	}
+	p.z.SetOne()
+
+	if p.IsOnCurve() == 0 {
+		return nil, errors.New("point is not on the curve")
+	}
+	if p.InCorrectSubgroup() == 0 {
+		return nil, errors.New("point is not in correct subgroup")
+	}
+	return g2.Set(&p), nil
+}
+
+// ToAffine converts the point into affine coordinates.
+// Points that are already affine are copied without an inversion.
+func (g2 *G2) ToAffine(a *G2) *G2 {
+	if a.z.IsOne() == 1 {
+		return g2.Set(a)
+	}
+	var wasInverted int
+	var zero, x, y, z fp2
+	_, wasInverted = z.Invert(&a.z)
+	x.Mul(&a.x, &z)
+	y.Mul(&a.y, &z)
+
+	g2.x.CMove(&zero, &x, wasInverted)
+	g2.y.CMove(&zero, &y, wasInverted)
+	g2.z.CMove(&zero, z.SetOne(), wasInverted)
+	return g2
+}
+
+// BatchToAffineG2 returns the points converted to affine coordinates
+// using Montgomery's trick to share a single field inversion between them.
+// The identity is converted the same as ToAffine.
+func BatchToAffineG2(points []*G2) []*G2 {
+	var t, v, inv fp2
+	out := make([]*G2, len(points))
+	// acc[i] is the product of the non-zero z coordinates before i
+	acc := make([]fp2, len(points))
+	t.SetOne()
+	for i, p := range points {
+		acc[i] = t
+		v.CMove(v.SetOne(), &p.z, p.z.IsZero()^1)
+		t.Mul(&t, &v)
+	}
+	inv.Invert(&t)
+	for i := len(points) - 1; i >= 0; i-- {
+		p := points[i]
+		nonZero := p.z.IsZero() ^ 1
+		v.CMove(v.SetOne(), &p.z, nonZero)
+		t.Mul(&inv, &acc[i])
+		inv.Mul(&inv, &v)
+		t.CMove(new(fp2), &t, nonZero)
+
+		// The identity has a zero inverse so x and y become zero
+		out[i] = new(G2)
+		out[i].x.Mul(&p.x, &t)
+		out[i].y.Mul(&p.y, &t)
+		out[i].z.CMove(new(fp2), v.SetOne(), nonZero)
+	}
+	return out
+}
+
+// GetX returns the affine X coordinate.
+func (g2 *G2) GetX() *fp2 {
+	var t G2
+	t.ToAffine(g2)
+	return &t.x
+}
+
+// GetY returns the affine Y coordinate.
+func (g2 *G2) GetY() *fp2 {
+	var t G2
+	t.ToAffine(g2)
+	return &t.y
+}
+
+// Equal returns 1 if the two points are equal 0 otherwise.
+func (g2 *G2) Equal(rhs *G2) int {
+	var x1, x2, y1, y2 fp2
+	var e1, e2 int
+
+	// This technique avoids inversions
+	x1.Mul(&g2.x, &rhs.z)
+	x2.Mul(&rhs.x, &g2.z)
+
+	y1.Mul(&g2.y, &rhs.z)
+	y2.Mul(&rhs.y, &g2.z)
+
+	e1 = g2.z.IsZero()
+	e2 = rhs.z.IsZero()
+
+	// Both at infinity or coordinates are the same
+	return (e1 & e2) | (^e1 & ^e2)&x1.Equal(&x2)&y1.Equal(&y2)
+}
+
+// CMove sets g2 = arg1 if choice == 0 and g2 = arg2 if choice == 1.
+func (g2 *G2) CMove(arg1, arg2 *G2, choice int) *G2 {
+	g2.x.CMove(&arg1.x, &arg2.x, choice)
+	g2.y.CMove(&arg1.y, &arg2.y, choice)
+	g2.z.CMove(&arg1.z, &arg2.z, choice)
+	return g2
+}
+
+// SumOfProducts computes the multi-exponentiation for the specified
+// points and scalars and stores the result in `g2`.
+// Returns an error if the lengths of the arguments is not equal.
+func (g2 *G2) SumOfProducts(points []*G2, scalars []*native.Field4) (*G2, error) {
+	const Upper = 256
+	const W = 4
+	const Windows = Upper / W // careful--use ceiling division in case this doesn't divide evenly
+	var sum G2
+	if len(points) != len(scalars) {
+		return nil, fmt.Errorf("length mismatch")
+	}
+
+	bucketSize := 1 << W
+	windows := make([]G2, Windows)
+	bytes := make([][32]byte, len(scalars))
+	buckets := make([]G2, bucketSize)
+	for i := 0; i < len(windows); i++ {
+		windows[i].Identity()
+	}
+
+	for i, scalar := range scalars {
+		bytes[i] = scalar.Bytes()
+	}
+
+	for j := 0; j < len(windows); j++ {
+		for i := 0; i < bucketSize; i++ {
+			buckets[i].Identity()
+		}
+
+		for i := 0; i < len(scalars); i++ {
+			// j*W to get the nibble
+			// >> 3 to convert to byte, / 8
+			// (W * j & W) gets the nibble, mod W
+			// 1 << W - 1 to get the offset
+			index := bytes[i][j*W>>3] >> (W * j & W) & (1<<W - 1) // little-endian
+			buckets[index].Add(&buckets[index], points[i])
+		}
+
+		sum.Identity()
+
+		for i := bucketSize - 1; i > 0; i-- {
+			sum.Add(&sum, &buckets[i])
+			windows[j].Add(&windows[j], &sum)
+		}
+	}
+
+	g2.Identity()
+	for i := len(windows) - 1; i >= 0; i-- {
+		for j := 0; j < W; j++ {
+			g2.Double(g2)
+		}
+
+		g2.Add(g2, &windows[i])
+	}
+	return g2, nil
+}
+
+// SumOfProductsVarTime computes the multi-exponentiation for the specified
+// points and scalars and stores the result in `g2`. Fewer than
+// MsmStrausPoints points use interleaved wNAF and larger inputs use
+// Pippenger's bucket method. It must only be used with public scalars.
+// Returns an error if the lengths of the arguments is not equal.
+func (g2 *G2) SumOfProductsVarTime(points []*G2, scalars []*native.Field4) (*G2, error) {
+	return g2.MsmVarTime(points, scalars, nil)
+}
+
+// MsmVarTime is SumOfProductsVarTime with options to choose the window
+// size and split the work across goroutines. Setting the window always
+// uses Pippenger's bucket method.
+func (g2 *G2) MsmVarTime(points []*G2, scalars []*native.Field4, opts *native.MsmOptions) (*G2, error) {
+	if len(points) != len(scalars) {
+		return nil, fmt.Errorf("length mismatch")
+	}
+	if len(points) < native.MsmStrausPoints && (opts == nil || opts.Window == 0) {
+		return g2.strausVarTime(points, scalars), nil
+	}
+	affine, nScalars := g2MsmInputs(points, scalars)
+	return g2.Set(native.Msm[g2Affine, *G2](g2Msm{}, affine, nScalars, opts)), nil
+}
+
+// strausVarTime computes the sum of points[i] * scalars[i] by interleaving
+// the width WnafWidth NAFs of the scalars so all the points share the doublings.
+func (g2 *G2) strausVarTime(points []*G2, scalars []*native.Field4) *G2 {
+	var p, t G2
+	tables := make([][]G2, 0, len(points))
+	digits := make([][]int8, 0, len(points))
+	for i, point := range points {
+		if point.IsIdentity() == 1 {
+			continue
+		}
+		table := g2WnafTable(point)
+		var k [native.Field4Limbs]uint64
+		scalars[i].Arithmetic.FromMontgomery(&k, &scalars[i].Value)
+		tables = append(tables, table)
+		digits = append(digits, native.Wnaf(k[:], native.WnafWidth))
+	}
+
+	top := 0
+	for _, d := range digits {
+		if len(d) > top {
+			top = len(d)
+		}
+	}
+	p.Identity()
+	for i := top - 1; i >= 0; i-- {
+		p.Double(&p)
+		for j, d := range digits {
+			if i >= len(d) || d[i] == 0 {
+				continue
+			}
+			if d[i] > 0 {
+				p.Add(&p, &tables[j][d[i]>>1])
+			} else {
+				p.Add(&p, t.Neg(&tables[j][-d[i]>>1]))
+			}
+		}
+	}
+	return g2.Set(&p)
+}
+
+// g2WnafTable returns the odd multiples a, 3*a, ... (2^(WnafWidth-1) - 1)*a
+// indexed by the absolute wNAF digit / 2.
+func g2WnafTable(a *G2) []G2 {
+	var double G2
+	table := make([]G2, 1<<(native.WnafWidth-2))
+	table[0].Set(a)
+	double.Double(a)
+	for i := 1; i < len(table); i++ {
+		table[i].Add(&table[i-1], &double)
+	}
+	return table
+}
+
+func (g2 *G2) psi(a *G2) *G2 {
+	// x = frobenius(x) * (u+9)^((p-1)/3)
+	g2.x.FrobeniusMap(&a.x)
+	g2.x.Mul(&g2.x, &psiCoeffX)
+	// y = frobenius(y) * (u+9)^((p-1)/2)
+	g2.y.FrobeniusMap(&a.y)
+	g2.y.Mul(&g2.y, &psiCoeffY)
+	// z = frobenius(z)
+	g2.z.FrobeniusMap(&a.z)
+	return g2
+}
+
+func (g2 *G2) svdw(u *fp2) *G2 {
+	// Straight-line Shallue-van de Woestijne method taken from
+	// section F.1 in <https://www.rfc-editor.org/rfc/rfc9380.html>
+	var tv1, tv2, tv3, tv4, x1, x2, x3, gx1, gx2, gx, x, y, t, one fp2
+	one.SetOne()
+
+	// tv1 = u^2 * c1
+	tv1.Square(u)
+	tv1.Mul(&tv1, &g2SvdwC1)
+	// tv2 = 1 + tv1
+	tv2.Add(&one, &tv1)
+	// tv1 = 1 - tv1
+	tv1.Sub(&one, &tv1)
+	// tv3 = inv0(tv1 * tv2)
+	t.Mul(&tv1, &tv2)
+	tv3.Invert(&t)
+	// tv4 = u * tv1 * tv3 * c3
+	tv4.Mul(u, &tv1)
+	tv4.Mul(&tv4, &tv3)
+	tv4.Mul(&tv4, &g2SvdwC3)
+
+	// x1 = c2 - tv4
+	x1.Sub(&g2SvdwC2, &tv4)
+	gx1.Square(&x1)
+	gx1.Mul(&gx1, &x1)
+	gx1.Add(&gx1, &curveG2B)
+	_, e1 := t.Sqrt(&gx1)
+
+	// x2 = c2 + tv4
+	x2.Add(&g2SvdwC2, &tv4)
+	gx2.Square(&x2)
+	gx2.Mul(&gx2, &x2)
+	gx2.Add(&gx2, &curveG2B)
+	_, e2 := t.Sqrt(&gx2)
+	e2 &= e1 ^ 1
+
+	// x3 = (tv2^2 * tv3)^2 * c4 + Z
+	x3.Square(&tv2)
+	x3.Mul(&x3, &tv3)
+	x3.Square(&x3)
+	x3.Mul(&x3, &g2SvdwC4)
+	x3.Add(&x3, &g2SvdwZ)
+
+	x.CMove(&x3, &x1, e1)
+	x.CMove(&x, &x2, e2)
+	gx.Square(&x)
+	gx.Mul(&gx, &x)
+	gx.Add(&gx, &curveG2B)
+	_, _ = y.Sqrt(&gx)
+
+	y.CNeg(&y, u.Sgn0()^y.Sgn0())
+
+	g2.x.Set(&x)
+	g2.y.Set(&y)
+	g2.z.SetOne()
+	return g2
+}
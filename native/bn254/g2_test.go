@@ -0,0 +1,197 @@
+package bn254
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikelodder7/curvey/native"
+)
+
+func TestG2Generator(t *testing.T) {
+	g := new(G2).Generator()
+	require.Equal(t, 1, g.IsOnCurve())
+	require.Equal(t, 1, g.InCorrectSubgroup())
+	require.Equal(t, 1, new(G2).Identity().IsOnCurve())
+
+	// EIP-197 encoding of the generator
+	expected := "198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c2" +
+		"1800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed" +
+		"090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b" +
+		"12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa"
+	out := g.ToUncompressed()
+	require.Equal(t, expected, hex.EncodeToString(out[:]))
+}
+
+func TestG2Arithmetic(t *testing.T) {
+	g := new(G2).Generator()
+	a := new(G2).Double(g)
+	b := new(G2).Add(g, g)
+	require.Equal(t, 1, a.Equal(b))
+	b.Mul(g, FqNew().SetUint64(2))
+	require.Equal(t, 1, a.Equal(b))
+	b.Sub(a, g)
+	require.Equal(t, 1, g.Equal(b))
+
+	b.Add(g, new(G2).Neg(g))
+	require.Equal(t, 1, b.IsIdentity())
+
+	q := FqNew().SetOne()
+	q.Neg(q)
+	b.Mul(g, q)
+	require.Equal(t, 1, b.Equal(new(G2).Neg(g)))
+
+	a.MulByX(g)
+	b.Mul(g, FqNew().SetUint64(paramX))
+	require.Equal(t, 1, a.Equal(b))
+}
+
+func TestG2Psi(t *testing.T) {
+	// ψ acts on G2 as multiplication by p mod r
+	g := new(G2).Generator()
+	a := new(G2).psi(g)
+	require.Equal(t, 1, a.IsOnCurve())
+	pModR := FqNew().SetBigInt(biModulus)
+	b := new(G2).Mul(g, pModR)
+	require.Equal(t, 1, a.Equal(b))
+}
+
+func TestG2ClearCofactor(t *testing.T) {
+	var u fp2
+	var p G2
+	for i := 0; i < 5; i++ {
+		_, _ = u.Random(crand.Reader)
+		p.svdw(&u)
+		require.Equal(t, 1, p.IsOnCurve())
+		p.ClearCofactor(&p)
+		require.Equal(t, 1, p.IsOnCurve())
+		require.Equal(t, 1, p.InCorrectSubgroup())
+	}
+}
+
+// TestG2Hash checks the hash to curve vectors gnark-crypto publishes
+// for the BN254G2_XMD:SHA-256_SVDW_RO_ suite.
+func TestG2Hash(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-BN254G2_XMD:SHA-256_SVDW_RO_")
+	tests := []struct {
+		input, expected string
+	}{
+		{"", "1747d950a6f23c16156e2171bce95d1189b04148ad12628869ed21c96a8c93351192005a0f121921a6d5629946199e4b27ff8ee4d6dd4f9581dc550ade8513002c9755350ca363ef2cf541005437221c5740086c2e909b71d075152484e845f40498f6bb5ac309a07d9a8b88e6ff4b8de0d5f27a075830e1eb0e68ea318201d8"},
+		{"abc", "0b5db3ca7e8ef5edf3a33dfc3242357fbccead98099c3eb564b3d9d13cba4efd16c88b54eec9af86a41569608cd0f60aab43464e52ce7e6e298bf584b94fccd222d02d2da7f288545ff8789e789902245ab08c6b1d253561eec789ec2c1bd6301c42ba524cb74db8e2c680449746c028f7bea923f245e69f89256af2d6c5f3ac"},
+		{"abcdef0123456789", "2a8a360585b6b05996ef69c3c09b2c6fb17afe2b1e944f07559c53178eabf1711435fd84aa43c699230e371f6fea3545ce7e053cbbb06a320296a2b81efddc70142f08e2441ec431defc24621b73cfe0252d19b243cb55b84bdeb85de039207a2820188dcdc13ffdca31694942418afa1d6dfaaf259d012fab4da52b0f592e38"},
+		{"q128_qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq", "2718ef38d1bc4347f0266c774c8ef4ee5fa7056cc27a4bd7ecf7a888efb95b262cffc213fb63d00d923cb22cda5a2904837bb93a2fe6e875c532c517443883412206ec0a9288f31ed78531c37295df3b56c42a1284443ee9893adb1521779001232553f728341afa64ce66d00535764557a052e38657594e10074ad28728c584"},
+		{"a512_aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "17f9f6292998cf18ccc155903c1fe6b6465d40c794a3e1ed644a4182ad639f4a242a0a159f36f87065e7c5170426012087023165ce47a486e53d6e2845ca625a18ef4886c818f01fdf309bc9a46dd904273917f85e74ecd0de62460a681220372dc5b7b65c9c79e6ef4afab8fbe3083c66d4ce31c78f6621ece17ecc892cf4b3"},
+	}
+
+	pt := new(G2).Identity()
+	for _, tst := range tests {
+		pt.Hash(native.EllipticPointHasherSha256(), []byte(tst.input), dst)
+		require.Equal(t, 1, pt.InCorrectSubgroup())
+		out := pt.ToUncompressed()
+		require.Equal(t, tst.expected, hex.EncodeToString(out[:]))
+	}
+}
+
+func TestG2Serialization(t *testing.T) {
+	a, _ := new(G2).Random(crand.Reader)
+
+	aBytes := a.ToCompressed()
+	aa, err := new(G2).FromCompressed(&aBytes)
+	require.NoError(t, err)
+	require.Equal(t, 1, a.Equal(aa))
+
+	auBytes := a.ToUncompressed()
+	_, err = aa.FromUncompressed(&auBytes)
+	require.NoError(t, err)
+	require.Equal(t, 1, a.Equal(aa))
+
+	a.Neg(a)
+	bBytes := a.ToCompressed()
+	require.NotEqual(t, aBytes[0], bBytes[0])
+	_, err = aa.FromCompressed(&bBytes)
+	require.NoError(t, err)
+	require.Equal(t, 1, a.Equal(aa))
+
+	id := new(G2).Identity()
+	idBytes := id.ToUncompressed()
+	require.Equal(t, [DoubleWideFieldBytes]byte{}, idBytes)
+	_, err = aa.FromUncompressed(&idBytes)
+	require.NoError(t, err)
+	require.Equal(t, 1, aa.IsIdentity())
+	idc := id.ToCompressed()
+	_, err = aa.FromCompressed(&idc)
+	require.NoError(t, err)
+	require.Equal(t, 1, aa.IsIdentity())
+
+	// A point on the twist that is not in the r-torsion must be rejected
+	var u fp2
+	var p G2
+	for {
+		_, _ = u.Random(crand.Reader)
+		p.svdw(&u)
+		if p.InCorrectSubgroup() == 0 {
+			break
+		}
+	}
+	pBytes := p.ToUncompressed()
+	_, err = aa.FromUncompressed(&pBytes)
+	require.Error(t, err)
+	pc := p.ToCompressed()
+	_, err = aa.FromCompressed(&pc)
+	require.Error(t, err)
+}
+
+func TestG2SumOfProducts(t *testing.T) {
+	var b [64]byte
+	h0, _ := new(G2).Random(crand.Reader)
+	_, _ = crand.Read(b[:])
+	s := FqNew().SetBytesWide(&b)
+	_, _ = crand.Read(b[:])
+	c := FqNew().SetBytesWide(&b)
+
+	lhs := new(G2).Mul(h0, s)
+	lhs.Add(lhs, new(G2).Mul(h0, c))
+	rhs, err := new(G2).SumOfProducts([]*G2{h0, h0}, []*native.Field4{s, c})
+	require.NoError(t, err)
+	require.Equal(t, 1, lhs.Equal(rhs))
+}
+
+func TestG2Msm(t *testing.T) {
+	var b [64]byte
+	points := make([]*G2, 64)
+	scalars := make([]*native.Field4, 64)
+	for i := range points {
+		_, _ = crand.Read(b[:])
+		points[i], _ = new(G2).Random(crand.Reader)
+		scalars[i] = FqNew().SetBytesWide(&b)
+	}
+	// Repeated and opposite points exercise doubling and
+	// cancellation when the buckets are summed in affine
+	points[1] = points[0]
+	points[2] = new(G2).Neg(points[0])
+	points[3] = new(G2).Double(points[4])
+	points[5] = new(G2).Identity()
+	scalars[1] = scalars[0]
+	scalars[2] = scalars[0]
+	scalars[6].SetZero()
+	expected, err := new(G2).SumOfProducts(points, scalars)
+	require.NoError(t, err)
+
+	for _, window := range []int{0, 2, 5, 11} {
+		for _, workers := range []int{0, 3, 100} {
+			actual, err := new(G2).MsmVarTime(points, scalars, &native.MsmOptions{Window: window, Workers: workers})
+			require.NoError(t, err)
+			require.Equal(t, 1, expected.Equal(actual))
+		}
+	}
+	// Fewer than MsmStrausPoints points use interleaved wNAF
+	expected, err = new(G2).SumOfProducts(points[:8], scalars[:8])
+	require.NoError(t, err)
+	actual, err := new(G2).SumOfProductsVarTime(points[:8], scalars[:8])
+	require.NoError(t, err)
+	require.Equal(t, 1, expected.Equal(actual))
+	_, err = new(G2).MsmVarTime(points, scalars[1:], nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,237 @@
+package bn254
+
+// coefficientsG2 is the number of line coefficients per G2 point
+// which is one per doubling and one per non-zero NAF digit of 6x+2
+// plus the two final frobenius additions.
+const coefficientsG2 = 88
+
+// twoInv is 1/2 in montgomery form.
+var twoInv = fp{0x87bee7d24f060572, 0xd0fd2add2f1c6ae5, 0x8f5f7492fcfd4f44, 0x1f37631a3d9cbfac}
+
+type Engine struct {
+	pairs []pair
+}
+
+type pair struct {
+	g1 G1
+	g2 G2
+}
+
+type g2Prepared struct {
+	identity     int
+	coefficients []coefficients
+}
+
+type coefficients struct {
+	a, b, c fp2
+}
+
+func (c *coefficients) CMove(arg1, arg2 *coefficients, choice int) *coefficients {
+	c.a.CMove(&arg1.a, &arg2.a, choice)
+	c.b.CMove(&arg1.b, &arg2.b, choice)
+	c.c.CMove(&arg1.c, &arg2.c, choice)
+	return c
+}
+
+// AddPair adds a pair of points to be paired.
+func (e *Engine) AddPair(g1 *G1, g2 *G2) *Engine {
+	var p pair
+	p.g1.ToAffine(g1)
+	p.g2.ToAffine(g2)
+	if p.g1.IsIdentity()|p.g2.IsIdentity() == 0 {
+		e.pairs = append(e.pairs, p)
+	}
+	return e
+}
+
+// AddPairInvG1 adds a pair of points to be paired. G1 point is negated.
+func (e *Engine) AddPairInvG1(g1 *G1, g2 *G2) *Engine {
+	var p G1
+	p.Neg(g1)
+	return e.AddPair(&p, g2)
+}
+
+// AddPairInvG2 adds a pair of points to be paired. G2 point is negated.
+func (e *Engine) AddPairInvG2(g1 *G1, g2 *G2) *Engine {
+	var p G2
+	p.Neg(g2)
+	return e.AddPair(g1, &p)
+}
+
+func (e *Engine) Reset() *Engine {
+	e.pairs = []pair{}
+	return e
+}
+
+func (e *Engine) Check() bool {
+	return e.pairing().IsOne() == 1
+}
+
+func (e *Engine) Result() *Gt {
+	return e.pairing()
+}
+
+func (e *Engine) pairing() *Gt {
+	f := new(Gt).SetOne()
+	if len(e.pairs) == 0 {
+		return f
+	}
+	coeffs := e.computeCoeffs()
+	e.millerLoop((*fp12)(f), coeffs)
+	return f.FinalExponentiation(f)
+}
+
+func (e *Engine) millerLoop(f *fp12, coeffs []g2Prepared) {
+	cIdx := 0
+	for i := len(sixUPlus2NAF) - 1; i > 0; i-- {
+		if i != len(sixUPlus2NAF)-1 {
+			f.Square(f)
+		}
+
+		// doubling
+		e.ellAll(f, coeffs, cIdx)
+		cIdx++
+
+		if sixUPlus2NAF[i-1] != 0 {
+			// adding
+			e.ellAll(f, coeffs, cIdx)
+			cIdx++
+		}
+	}
+	// x is positive so no conjugation is needed
+	e.ellAll(f, coeffs, cIdx)
+	cIdx++
+	e.ellAll(f, coeffs, cIdx)
+}
+
+func (e *Engine) ellAll(f *fp12, coeffs []g2Prepared, cIdx int) {
+	var newF fp12
+	for j, terms := range coeffs {
+		identity := e.pairs[j].g1.IsIdentity() | terms.identity
+		newF.Set(f)
+		ell(&newF, &terms.coefficients[cIdx], &e.pairs[j].g1)
+		f.CMove(&newF, f, identity)
+	}
+}
+
+func (e *Engine) computeCoeffs() []g2Prepared {
+	coeffs := make([]g2Prepared, len(e.pairs))
+	for i := 0; i < len(e.pairs); i++ {
+		identity := e.pairs[i].g2.IsIdentity()
+		q := new(G2).Generator()
+		q.CMove(&e.pairs[i].g2, q, identity)
+		c := new(G2).Set(q)
+		negQ := new(G2).Neg(q)
+		cfs := make([]coefficients, coefficientsG2)
+		k := 0
+
+		for j := len(sixUPlus2NAF) - 1; j > 0; j-- {
+			cfs[k] = doublingStep(c)
+			k++
+
+			switch sixUPlus2NAF[j-1] {
+			case 1:
+				cfs[k] = additionStep(c, q)
+				k++
+			case -1:
+				cfs[k] = additionStep(c, negQ)
+				k++
+			}
+		}
+		// q1 = ψ(q), q2 = -ψ^2(q)
+		var q1, q2 G2
+		q1.psi(q)
+		q2.psi(&q1)
+		q2.Neg(&q2)
+		cfs[k] = additionStep(c, &q1)
+		k++
+		cfs[k] = additionStep(c, &q2)
+		coeffs[i] = g2Prepared{
+			coefficients: cfs, identity: identity,
+		}
+	}
+	return coeffs
+}
+
+func ell(f *fp12, coeffs *coefficients, p *G1) {
+	var x, y fp2
+	x.A.Mul(&coeffs.a.A, &p.y)
+	x.B.Mul(&coeffs.a.B, &p.y)
+	y.A.Mul(&coeffs.b.A, &p.x)
+	y.B.Mul(&coeffs.b.B, &p.x)
+	f.MulBy034(f, &x, &y, &coeffs.c)
+}
+
+func doublingStep(r *G2) coefficients {
+	// Adaptation of Algorithm 26, https://eprint.iacr.org/2012/408.pdf
+	// for homogeneous projective coordinates on the D-type twist
+	var a, b, c, e, f, g, h, i, j, t fp2
+	a.Mul(&r.x, &r.y)
+	a.A.Mul(&a.A, &twoInv)
+	a.B.Mul(&a.B, &twoInv)
+	b.Square(&r.y)
+	c.Square(&r.z)
+	e.MulBy3b(&c)
+	f.Double(&e)
+	f.Add(&f, &e)
+	g.Add(&b, &f)
+	g.A.Mul(&g.A, &twoInv)
+	g.B.Mul(&g.B, &twoInv)
+	h.Add(&r.y, &r.z)
+	h.Square(&h)
+	t.Add(&b, &c)
+	h.Sub(&h, &t)
+	i.Sub(&e, &b)
+	j.Square(&r.x)
+
+	t.Sub(&b, &f)
+	r.x.Mul(&a, &t)
+	t.Square(&e)
+	r.y.Square(&g)
+	r.y.Sub(&r.y, &t)
+	r.y.Sub(&r.y, &t)
+	r.y.Sub(&r.y, &t)
+	r.z.Mul(&b, &h)
+
+	h.Neg(&h)
+	t.Double(&j)
+	j.Add(&j, &t)
+
+	return coefficients{
+		a: h, b: j, c: i,
+	}
+}
+
+func additionStep(r, q *G2) coefficients {
+	// Adaptation of Algorithm 12, https://eprint.iacr.org/2012/408.pdf
+	// for homogeneous projective coordinates on the D-type twist
+	// where q is in affine coordinates
+	var theta, lambda, c, d, e, f, g, h, j, t fp2
+	theta.Mul(&q.y, &r.z)
+	theta.Sub(&r.y, &theta)
+	lambda.Mul(&q.x, &r.z)
+	lambda.Sub(&r.x, &lambda)
+	c.Square(&theta)
+	d.Square(&lambda)
+	e.Mul(&lambda, &d)
+	f.Mul(&r.z, &c)
+	g.Mul(&r.x, &d)
+	h.Add(&e, &f)
+	t.Double(&g)
+	h.Sub(&h, &t)
+	r.x.Mul(&lambda, &h)
+	t.Sub(&g, &h)
+	r.y.Mul(&r.y, &e)
+	t.Mul(&t, &theta)
+	r.y.Sub(&t, &r.y)
+	r.z.Mul(&r.z, &e)
+	j.Mul(&theta, &q.x)
+	t.Mul(&lambda, &q.y)
+	j.Sub(&j, &t)
+
+	theta.Neg(&theta)
+
+	return coefficients{
+		a: lambda, b: theta, c: j,
+	}
+}
@@ -0,0 +1,164 @@
+package bn254
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikelodder7/curvey/native"
+)
+
+func TestSinglePairing(t *testing.T) {
+	g := new(G1).Generator()
+	h := new(G2).Generator()
+
+	e := new(Engine)
+	e.AddPair(g, h)
+	p := e.Result()
+	require.Equal(t, 1, p.Equal(new(Gt).Generator()))
+	p.Neg(p)
+
+	e.Reset()
+	e.AddPairInvG2(g, h)
+	q := e.Result()
+	e.Reset()
+	e.AddPairInvG1(g, h)
+	r := e.Result()
+
+	require.Equal(t, 1, p.Equal(q))
+	require.Equal(t, 1, q.Equal(r))
+}
+
+func TestPairingBilinearity(t *testing.T) {
+	var bytes [64]byte
+	a := FqNew()
+	b := FqNew()
+	_, _ = crand.Read(bytes[:])
+	a.SetBytesWide(&bytes)
+	_, _ = crand.Read(bytes[:])
+	b.SetBytesWide(&bytes)
+
+	g := new(G1).Generator()
+	h := new(G2).Generator()
+	g.Mul(g, a)
+	h.Mul(h, b)
+
+	e := new(Engine)
+	e.AddPair(g, h)
+	lhs := e.Result()
+
+	rhs := new(Gt).Generator()
+	rhs.Mul(rhs, a)
+	rhs.Mul(rhs, b)
+	require.Equal(t, 1, lhs.Equal(rhs))
+
+	// e(aG, bH) * e(-abG, H) == 1
+	ab := FqNew().Mul(a, b)
+	g.Generator().Mul(g, ab)
+	e.AddPairInvG1(g, new(G2).Generator())
+	require.True(t, e.Check())
+}
+
+func TestPairingIdentity(t *testing.T) {
+	e := new(Engine)
+	e.AddPair(new(G1).Identity(), new(G2).Generator())
+	e.AddPair(new(G1).Generator(), new(G2).Identity())
+	require.True(t, e.Check())
+	require.Equal(t, 1, e.Result().IsOne())
+}
+
+func TestMultiPairing(t *testing.T) {
+	const Tests = 10
+	e1 := new(Engine)
+	e2 := new(Engine)
+
+	g1s := make([]*G1, Tests)
+	g2s := make([]*G2, Tests)
+	sc := make([]*native.Field4, Tests)
+	res := make([]*Gt, Tests)
+	expected := new(Gt).SetOne()
+
+	for i := 0; i < Tests; i++ {
+		var bytes [64]byte
+		g1s[i] = new(G1).Generator()
+		g2s[i] = new(G2).Generator()
+		sc[i] = FqNew()
+		_, _ = crand.Read(bytes[:])
+		sc[i].SetBytesWide(&bytes)
+		if i&1 == 0 {
+			g1s[i].Mul(g1s[i], sc[i])
+		} else {
+			g2s[i].Mul(g2s[i], sc[i])
+		}
+		e1.AddPair(g1s[i], g2s[i])
+		e2.AddPair(g1s[i], g2s[i])
+		res[i] = e1.Result()
+		e1.Reset()
+		expected.Add(expected, res[i])
+	}
+
+	actual := e2.Result()
+	require.Equal(t, 1, expected.Equal(actual))
+}
+
+// TestPairingEip197 checks inputs to the EIP-197 ecPairing precompile
+// whose result is 1 (true) or 0 (false). The points were computed with
+// go-ethereum's crypto/bn256/cloudflare, the implementation behind the
+// precompile, using the scalars
+// a = 0x1a2b3c4d5e6f708192a3b4c5d6e7f80123456789abcdef0fedcba9876543210
+// b = 0x2f1e0d0c0b0a09080706050403020100ffeeddccbbaa99887766554433221100
+func TestPairingEip197(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{"empty", "", true},
+		// e(G1, G2)
+		{"single", "00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002" +
+			"198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed" +
+			"090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa", false},
+		// e(G1, G2) * e(-G1, G2)
+		{"inverse", "00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002" +
+			"198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed" +
+			"090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa" +
+			"000000000000000000000000000000000000000000000000000000000000000130644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd45" +
+			"198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed" +
+			"090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa", true},
+		// e(a * G1, b * G2) * e(-(a * b) * G1, G2)
+		{"bilinear", "0e4bd3536d35ab52cc4d33b69b87c15cc6b5c93742e2533395a44748a07068600f5663f7dd7dd4e2de332ce5315fa87e461feff3c390eccd77fce9476f7424f1" +
+			"17ffc191dacaf196305a3787fa4d6ae3af0d5dc663b61c92d287c0384f57b2072b26b61e8dd34700cf05b60f3aab209d9964e46c3d40e60bdfa1398ba59d8e21" +
+			"1d390f32fe16b4810cac21a4a0bf84cf5c4b5a7ae6780f7dd414f33761a3e98b1f0c3c077898a1c5264ca1a1cd48f4fc1cd9824e9948981884d88401d6db9e42" +
+			"2b8bd8dc5ae0661c5721b9e2c6b8d4bcf8158593527191406ef008a8ed3ef9632762fe4ce9acaa8f2aaaf89d5e5a96d6686601cc34e6b162110684064dc78c53" +
+			"198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed" +
+			"090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa", true},
+		// e(a * G1, b * G2) * e(-(a * b + 1) * G1, G2)
+		{"bilinear off by one", "0e4bd3536d35ab52cc4d33b69b87c15cc6b5c93742e2533395a44748a07068600f5663f7dd7dd4e2de332ce5315fa87e461feff3c390eccd77fce9476f7424f1" +
+			"17ffc191dacaf196305a3787fa4d6ae3af0d5dc663b61c92d287c0384f57b2072b26b61e8dd34700cf05b60f3aab209d9964e46c3d40e60bdfa1398ba59d8e21" +
+			"1d390f32fe16b4810cac21a4a0bf84cf5c4b5a7ae6780f7dd414f33761a3e98b1f0c3c077898a1c5264ca1a1cd48f4fc1cd9824e9948981884d88401d6db9e42" +
+			"24a84de30ea90c25dd8f568f2c063060d9f041406c234c515c7bc17cb2091620132ace33115c2126c8b0411806299486dca2b72754f26c8eb3194d7d5091491b" +
+			"198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed" +
+			"090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa", false},
+	}
+	for _, tst := range tests {
+		input, err := hex.DecodeString(tst.input)
+		require.NoError(t, err, tst.name)
+		require.Equal(t, 0, len(input)%(WideFieldBytes+DoubleWideFieldBytes), tst.name)
+		e := new(Engine)
+		for len(input) > 0 {
+			var g1Bytes [WideFieldBytes]byte
+			var g2Bytes [DoubleWideFieldBytes]byte
+			copy(g1Bytes[:], input)
+			copy(g2Bytes[:], input[WideFieldBytes:])
+			input = input[WideFieldBytes+DoubleWideFieldBytes:]
+			g1, err := new(G1).FromUncompressed(&g1Bytes)
+			require.NoError(t, err, tst.name)
+			g2, err := new(G2).FromUncompressed(&g2Bytes)
+			require.NoError(t, err, tst.name)
+			e.AddPair(g1, g2)
+		}
+		require.Equal(t, tst.expected, e.Check(), tst.name)
+	}
+}
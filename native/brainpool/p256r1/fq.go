@@ -0,0 +1,120 @@
+package p256r1
+
+import (
+	"encoding/binary"
+	"sync"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+)
+
+var (
+	fqInitonce sync.Once
+	fqParams   native.Field4Params
+)
+
+// FqNew returns an element of the brainpoolP256r1 scalar field, the field defined by the group order.
+func FqNew() *native.Field4 {
+	return &native.Field4{
+		Value:      [native.Field4Limbs]uint64{},
+		Params:     getFqParams(),
+		Arithmetic: fqArithmetic{},
+	}
+}
+
+func fqParamsInit() {
+	params := internal.BrainpoolP256r1FqParams()
+	fqParams = native.Field4Params{
+		BiModulus: params.BiModulus,
+	}
+	copy(fqParams.R[:], params.R)
+	copy(fqParams.R2[:], params.R2)
+	copy(fqParams.R3[:], params.R3)
+	copy(fqParams.Modulus[:], params.Modulus)
+}
+
+func getFqParams() *native.Field4Params {
+	fqInitonce.Do(fqParamsInit)
+	return &fqParams
+}
+
+// fqArithmetic adapts the generic montgomery field arithmetic
+// to the fixed size limbs used by native.Field4.
+type fqArithmetic struct{}
+
+// ToMontgomery converts this field to montgomery form.
+func (fqArithmetic) ToMontgomery(out, arg *[native.Field4Limbs]uint64) {
+	o, a := out[:], arg[:]
+	internal.BrainpoolP256r1FqParams().ToMontgomery(&o, &a)
+}
+
+// FromMontgomery converts this field from montgomery form.
+func (fqArithmetic) FromMontgomery(out, arg *[native.Field4Limbs]uint64) {
+	o, a := out[:], arg[:]
+	internal.BrainpoolP256r1FqParams().FromMontgomery(&o, &a)
+}
+
+// Neg performs modular negation.
+func (fqArithmetic) Neg(out, arg *[native.Field4Limbs]uint64) {
+	o, a := out[:], arg[:]
+	internal.BrainpoolP256r1FqParams().Neg(&o, &a)
+}
+
+// Square performs modular square.
+func (fqArithmetic) Square(out, arg *[native.Field4Limbs]uint64) {
+	o, a := out[:], arg[:]
+	internal.BrainpoolP256r1FqParams().Square(&o, &a)
+}
+
+// Mul performs modular multiplication.
+func (fqArithmetic) Mul(out, arg1, arg2 *[native.Field4Limbs]uint64) {
+	o, a1, a2 := out[:], arg1[:], arg2[:]
+	internal.BrainpoolP256r1FqParams().Mul(&o, &a1, &a2)
+}
+
+// Add performs modular addition.
+func (fqArithmetic) Add(out, arg1, arg2 *[native.Field4Limbs]uint64) {
+	o, a1, a2 := out[:], arg1[:], arg2[:]
+	internal.BrainpoolP256r1FqParams().Add(&o, &a1, &a2)
+}
+
+// Sub performs modular subtraction.
+func (fqArithmetic) Sub(out, arg1, arg2 *[native.Field4Limbs]uint64) {
+	o, a1, a2 := out[:], arg1[:], arg2[:]
+	internal.BrainpoolP256r1FqParams().Sub(&o, &a1, &a2)
+}
+
+// Sqrt performs modular square root.
+func (fqArithmetic) Sqrt(wasSquare *int, out, arg *[native.Field4Limbs]uint64) {
+	o, a := out[:], arg[:]
+	internal.BrainpoolP256r1FqParams().Sqrt(wasSquare, &o, &a)
+}
+
+// Invert performs modular inverse.
+func (fqArithmetic) Invert(wasInverted *int, out, arg *[native.Field4Limbs]uint64) {
+	o, a := out[:], arg[:]
+	internal.BrainpoolP256r1FqParams().Invert(wasInverted, &o, &a)
+}
+
+// FromBytes converts a little endian byte array into a field element.
+func (fqArithmetic) FromBytes(out *[native.Field4Limbs]uint64, arg *[native.Field4Bytes]byte) {
+	for i := range out {
+		out[i] = binary.LittleEndian.Uint64(arg[i*8 : (i+1)*8])
+	}
+}
+
+// ToBytes converts a field element to a little endian byte array.
+func (fqArithmetic) ToBytes(out *[native.Field4Bytes]byte, arg *[native.Field4Limbs]uint64) {
+	for i, a := range arg {
+		binary.LittleEndian.PutUint64(out[i*8:(i+1)*8], a)
+	}
+}
+
+// Selectznz performs conditional select.
+// selects arg1 if choice == 0 and arg2 if choice == 1.
+func (fqArithmetic) Selectznz(out, arg1, arg2 *[native.Field4Limbs]uint64, choice int) {
+	b := uint64(-choice)
+	for i := range out {
+		out[i] = arg1[i] ^ ((arg1[i] ^ arg2[i]) & b)
+	}
+}
@@ -0,0 +1,272 @@
+package p256r1
+
+import (
+	"sync"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+)
+
+var (
+	p256r1PointInitonce     sync.Once
+	p256r1PointParams       native.EllipticPoint4Params
+	p256r1PointSswuInitOnce sync.Once
+	p256r1PointSswuParams   native.Sswu4Params
+)
+
+func PointNew() *native.EllipticPoint4 {
+	return &native.EllipticPoint4{
+		X:          FpNew(),
+		Y:          FpNew(),
+		Z:          FpNew(),
+		Params:     getPointParams(),
+		Arithmetic: &pointArithmetic{},
+	}
+}
+
+func pointParamsInit() {
+	// How these values were derived
+	// left for informational purposes
+	// capA := FpNew().SetBigInt(a)
+	// capB := FpNew().SetBigInt(b)
+	// gx := FpNew().SetBigInt(gx)
+	// gy := FpNew().SetBigInt(gy)
+	// where a, b, gx and gy are from RFC 5639, Section 3
+
+	p256r1PointParams = native.EllipticPoint4Params{
+		A:       FpNew().SetRaw(&[native.Field4Limbs]uint64{0xd5d18edf69696261, 0xa68123f1c1d20c64, 0x95ec1e5e6398556e, 0x1e4676abd666bc17}),
+		B:       FpNew().SetRaw(&[native.Field4Limbs]uint64{0x05d24d72c0c0f36f, 0x0ac34a49cc51bf59, 0x64ca989357f2e9d9, 0x1634f57646a3c93e}),
+		Gx:      FpNew().SetRaw(&[native.Field4Limbs]uint64{0x27c0d92d351fd10c, 0x80de4d9ab97cf30a, 0x704c311d6b892ad3, 0x8e1f767a9e119bdf}),
+		Gy:      FpNew().SetRaw(&[native.Field4Limbs]uint64{0x9a4fe948a0917a17, 0xa618f259cd950162, 0x16fdf6e8dfbd8b03, 0x14eb78c6026eb0a2}),
+		BitSize: 256,
+		Name:    "brainpoolP256r1",
+	}
+}
+
+func getPointParams() *native.EllipticPoint4Params {
+	p256r1PointInitonce.Do(pointParamsInit)
+	return &p256r1PointParams
+}
+
+func getPointSswuParams() *native.Sswu4Params {
+	p256r1PointSswuInitOnce.Do(pointSswuParamsInit)
+	return &p256r1PointSswuParams
+}
+
+func pointSswuParamsInit() {
+	// How these values were derived
+	// left for informational purposes
+	// p := internal.BrainpoolP256r1FpParams().BiModulus
+	//
+	// // c1 = (p - 3) / 4
+	// c1 := new(big.Int).Sub(p, big.NewInt(3))
+	// c1.Rsh(c1, 2)
+	//
+	// z := big.NewInt(-2)
+	// z.Mod(z, p)
+	// // sqrt(-Z^3)
+	// zTmp := new(big.Int).Exp(z, big.NewInt(3), nil)
+	// zTmp = zTmp.Neg(zTmp)
+	// zTmp.Mod(zTmp, p)
+	// c2 := new(big.Int).ModSqrt(zTmp, p)
+	//
+	// capC1 is c1 as little endian limbs, not in montgomery form
+	// capC2 := FpNew().SetBigInt(c2)
+	// capZ := FpNew().SetBigInt(z)
+
+	p256r1PointSswuParams = native.Sswu4Params{
+		C1: [native.Field4Limbs]uint64{0x0804d20747db94dd, 0x9b8efd88f549880a, 0x0f9982a42760e35c, 0x2a7ed5f6e87baa6f},
+		C2: [native.Field4Limbs]uint64{0x7c1d6077044bf63a, 0xfdcb6455a64b8512, 0x28ec76fa82391007, 0x90e17995acf9444d},
+		A:  [native.Field4Limbs]uint64{0xd5d18edf69696261, 0xa68123f1c1d20c64, 0x95ec1e5e6398556e, 0x1e4676abd666bc17},
+		B:  [native.Field4Limbs]uint64{0x05d24d72c0c0f36f, 0x0ac34a49cc51bf59, 0x64ca989357f2e9d9, 0x1634f57646a3c93e},
+		Z:  [native.Field4Limbs]uint64{0x804d20747db94ddc, 0xb8efd88f549880a0, 0xf9982a42760e35c9, 0xa7ed5f6e87baa6f0},
+	}
+}
+
+type pointArithmetic struct{}
+
+func (k pointArithmetic) Hash(out *native.EllipticPoint4, hash *native.EllipticPointHasher, msg, dst []byte) error {
+	var u []byte
+	sswuParams := getPointSswuParams()
+
+	switch hash.Type() {
+	case native.XMD:
+		u = native.ExpandMsgXmd(hash, msg, dst, 96)
+	case native.XOF:
+		u = native.ExpandMsgXof(hash, msg, dst, 96)
+	}
+	var buf [native.WideField4Bytes]byte
+	copy(buf[:48], internal.ReverseBytes(u[:48]))
+	u0 := FpNew().SetBytesWide(&buf)
+	copy(buf[:48], internal.ReverseBytes(u[48:]))
+	u1 := FpNew().SetBytesWide(&buf)
+
+	q0x, q0y := sswuParams.Osswu3mod4(u0)
+	q1x, q1y := sswuParams.Osswu3mod4(u1)
+	out.X = q0x
+	out.Y = q0y
+	out.Z.SetOne()
+	tv := &native.EllipticPoint4{
+		X: q1x,
+		Y: q1y,
+		Z: FpNew().SetOne(),
+	}
+	k.Add(out, out, tv)
+	return nil
+}
+
+func (pointArithmetic) Double(out, arg *native.EllipticPoint4) {
+	// Doubling formula for any a from Renes-Costello-Batina 2015
+	// (https://eprint.iacr.org/2015/1060 Algorithm 3)
+	var t0, t1, t2, t3, b3, x, y, z [native.Field4Limbs]uint64
+	a := getPointParams().A.Value
+	b := getPointParams().B.Value
+	f := arg.X.Arithmetic
+
+	f.Add(&b3, &b, &b)
+	f.Add(&b3, &b3, &b)
+
+	f.Square(&t0, &arg.X.Value)
+	f.Square(&t1, &arg.Y.Value)
+	f.Square(&t2, &arg.Z.Value)
+	f.Mul(&t3, &arg.X.Value, &arg.Y.Value)
+	f.Add(&t3, &t3, &t3)
+	f.Mul(&z, &arg.X.Value, &arg.Z.Value)
+	f.Add(&z, &z, &z)
+	f.Mul(&x, &a, &z)
+	f.Mul(&y, &b3, &t2)
+	f.Add(&y, &x, &y)
+	f.Sub(&x, &t1, &y)
+	f.Add(&y, &t1, &y)
+	f.Mul(&y, &x, &y)
+	f.Mul(&x, &t3, &x)
+	f.Mul(&z, &b3, &z)
+	f.Mul(&t2, &a, &t2)
+	f.Sub(&t3, &t0, &t2)
+	f.Mul(&t3, &a, &t3)
+	f.Add(&t3, &t3, &z)
+	f.Add(&z, &t0, &t0)
+	f.Add(&t0, &z, &t0)
+	f.Add(&t0, &t0, &t2)
+	f.Mul(&t0, &t0, &t3)
+	f.Add(&y, &y, &t0)
+	f.Mul(&t2, &arg.Y.Value, &arg.Z.Value)
+	f.Add(&t2, &t2, &t2)
+	f.Mul(&t0, &t2, &t3)
+	f.Sub(&x, &x, &t0)
+	f.Mul(&z, &t2, &t1)
+	f.Add(&z, &z, &z)
+	f.Add(&z, &z, &z)
+
+	out.X.Value = x
+	out.Y.Value = y
+	out.Z.Value = z
+}
+
+func (pointArithmetic) Add(out, arg1, arg2 *native.EllipticPoint4) {
+	// Addition formula for any a from Renes-Costello-Batina 2015
+	// (https://eprint.iacr.org/2015/1060 Algorithm 1).
+	var t0, t1, t2, t3, t4, t5, b3, x, y, z [native.Field4Limbs]uint64
+	a := getPointParams().A.Value
+	b := getPointParams().B.Value
+	f := arg1.X.Arithmetic
+
+	f.Add(&b3, &b, &b)
+	f.Add(&b3, &b3, &b)
+
+	f.Mul(&t0, &arg1.X.Value, &arg2.X.Value)
+	f.Mul(&t1, &arg1.Y.Value, &arg2.Y.Value)
+	f.Mul(&t2, &arg1.Z.Value, &arg2.Z.Value)
+	f.Add(&t3, &arg1.X.Value, &arg1.Y.Value)
+	f.Add(&t4, &arg2.X.Value, &arg2.Y.Value)
+	f.Mul(&t3, &t3, &t4)
+	f.Add(&t4, &t0, &t1)
+	f.Sub(&t3, &t3, &t4)
+	f.Add(&t4, &arg1.X.Value, &arg1.Z.Value)
+	f.Add(&t5, &arg2.X.Value, &arg2.Z.Value)
+	f.Mul(&t4, &t4, &t5)
+	f.Add(&t5, &t0, &t2)
+	f.Sub(&t4, &t4, &t5)
+	f.Add(&t5, &arg1.Y.Value, &arg1.Z.Value)
+	f.Add(&x, &arg2.Y.Value, &arg2.Z.Value)
+	f.Mul(&t5, &t5, &x)
+	f.Add(&x, &t1, &t2)
+	f.Sub(&t5, &t5, &x)
+	f.Mul(&z, &a, &t4)
+	f.Mul(&x, &b3, &t2)
+	f.Add(&z, &x, &z)
+	f.Sub(&x, &t1, &z)
+	f.Add(&z, &t1, &z)
+	f.Mul(&y, &x, &z)
+	f.Add(&t1, &t0, &t0)
+	f.Add(&t1, &t1, &t0)
+	f.Mul(&t2, &a, &t2)
+	f.Mul(&t4, &b3, &t4)
+	f.Add(&t1, &t1, &t2)
+	f.Sub(&t2, &t0, &t2)
+	f.Mul(&t2, &a, &t2)
+	f.Add(&t4, &t4, &t2)
+	f.Mul(&t0, &t1, &t4)
+	f.Add(&y, &y, &t0)
+	f.Mul(&t0, &t5, &t4)
+	f.Mul(&x, &t3, &x)
+	f.Sub(&x, &x, &t0)
+	f.Mul(&t0, &t3, &t1)
+	f.Mul(&z, &t5, &z)
+	f.Add(&z, &z, &t0)
+
+	e1 := arg1.Z.IsZero()
+	e2 := arg2.Z.IsZero()
+
+	// If arg1 is identity set it to arg2
+	f.Selectznz(&z, &z, &arg2.Z.Value, e1)
+	f.Selectznz(&y, &y, &arg2.Y.Value, e1)
+	f.Selectznz(&x, &x, &arg2.X.Value, e1)
+	// If arg2 is identity set it to arg1
+	f.Selectznz(&z, &z, &arg1.Z.Value, e2)
+	f.Selectznz(&y, &y, &arg1.Y.Value, e2)
+	f.Selectznz(&x, &x, &arg1.X.Value, e2)
+
+	out.X.Value = x
+	out.Y.Value = y
+	out.Z.Value = z
+}
+
+func (k pointArithmetic) IsOnCurve(arg *native.EllipticPoint4) bool {
+	affine := PointNew()
+	k.ToAffine(affine, arg)
+	lhs := FpNew().Square(affine.Y)
+	rhs := FpNew()
+	k.RhsEquation(rhs, affine.X)
+	return lhs.Equal(rhs) == 1
+}
+
+func (pointArithmetic) ToAffine(out, arg *native.EllipticPoint4) {
+	var wasInverted int
+	var zero, x, y, z [native.Field4Limbs]uint64
+	f := arg.X.Arithmetic
+
+	f.Invert(&wasInverted, &z, &arg.Z.Value)
+	f.Mul(&x, &arg.X.Value, &z)
+	f.Mul(&y, &arg.Y.Value, &z)
+
+	out.Z.SetOne()
+	// If point at infinity this does nothing
+	f.Selectznz(&x, &zero, &x, wasInverted)
+	f.Selectznz(&y, &zero, &y, wasInverted)
+	f.Selectznz(&z, &zero, &out.Z.Value, wasInverted)
+
+	out.X.Value = x
+	out.Y.Value = y
+	out.Z.Value = z
+	out.Params = arg.Params
+	out.Arithmetic = arg.Arithmetic
+}
+
+func (pointArithmetic) RhsEquation(out, x *native.Field4) {
+	// Elliptic curve equation for brainpoolP256r1 is: y^2 = x^3 + ax + b
+	out.Square(x)
+	out.Mul(out, x)
+	out.Add(out, getPointParams().B)
+	out.Add(out, FpNew().Mul(getPointParams().A, x))
+}
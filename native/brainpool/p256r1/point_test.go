@@ -0,0 +1,69 @@
+package p256r1_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikelodder7/curvey/native"
+	"github.com/mikelodder7/curvey/native/brainpool/p256r1"
+)
+
+func bhex(s string) *big.Int {
+	r, _ := new(big.Int).SetString(s, 16)
+	return r
+}
+
+// The expected points were computed with OpenSSL 3.0 (k*G) and an
+// independent RFC 9380 implementation (hash to curve).
+
+func TestBrainpoolP256r1PointArithmetic_Double(t *testing.T) {
+	g := p256r1.PointNew().Generator()
+	require.True(t, g.IsOnCurve())
+	pt1 := p256r1.PointNew().Double(g)
+	pt2 := p256r1.PointNew().Add(g, g)
+	pt3 := p256r1.PointNew().Mul(g, p256r1.FqNew().SetUint64(2))
+
+	require.Equal(t, 1, pt1.Equal(pt2))
+	require.Equal(t, 1, pt1.Equal(pt3))
+	require.Equal(t, 1, pt2.Equal(pt3))
+
+	x, y := pt1.BigInt()
+	require.Equal(t, 0, x.Cmp(bhex("743cf1b8b5cd4f2eb55f8aa369593ac436ef044166699e37d51a14c2ce13ea0e")))
+	require.Equal(t, 0, y.Cmp(bhex("36ed163337deba9c946fe0bb776529da38df059f69249406892ada097eeb7cd4")))
+}
+
+func TestBrainpoolP256r1PointArithmetic_Mul(t *testing.T) {
+	g := p256r1.PointNew().Generator()
+	pt := p256r1.PointNew().Mul(g, p256r1.FqNew().SetUint64(0x1234567890abcdef))
+	require.True(t, pt.IsOnCurve())
+	x, y := pt.BigInt()
+	require.Equal(t, 0, x.Cmp(bhex("668adacf4ed5f35980db33ee865581f20198bc9364bdd94dc8aaa9731cb7bb17")))
+	require.Equal(t, 0, y.Cmp(bhex("4c51e8d475b04dbb59639f222f130d92d03e72e0f7a8e92af582ef674c3ce64")))
+
+	// n * G = 0
+	n := p256r1.FqNew().SetOne()
+	n.Neg(n)
+	pt.Mul(g, n)
+	pt.Add(pt, g)
+	require.True(t, pt.IsIdentity())
+}
+
+func TestBrainpoolP256r1PointArithmetic_Hash(t *testing.T) {
+	tests := []struct {
+		msg  string
+		x, y string
+	}{
+		{"", "6e11d57dacd08a0222398e80436fa21266a6f8888d8742bcf11c8eeb14ab7c98", "2b5731bad407151941e0b70bbae9cc60ed78ae4a933f63921b37723ba5888fe6"},
+		{"abc", "a95cff074e2ad2ab2927f450936a3acd83a02dc080a012a8eb30285b8c97c1c2", "432d4f7711afb9c9223bba10c481ad63c45329533b2ca009bfe630f73dc857a3"},
+	}
+	for _, tst := range tests {
+		pt, err := p256r1.PointNew().Hash([]byte(tst.msg), native.EllipticPointHasherSha256())
+		require.NoError(t, err)
+		require.True(t, pt.IsOnCurve())
+		x, y := pt.BigInt()
+		require.Equal(t, 0, x.Cmp(bhex(tst.x)))
+		require.Equal(t, 0, y.Cmp(bhex(tst.y)))
+	}
+}
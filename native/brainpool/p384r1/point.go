@@ -0,0 +1,335 @@
+package p384r1
+
+import (
+	"sync"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+)
+
+var (
+	p384r1PointInitonce     sync.Once
+	p384r1PointParams       native.EllipticPoint6Params
+	p384r1PointSswuInitOnce sync.Once
+	p384r1PointSswuParams   native.Sswu6Params
+)
+
+func PointNew() *native.EllipticPoint6 {
+	return &native.EllipticPoint6{
+		X:          FpNew(),
+		Y:          FpNew(),
+		Z:          FpNew(),
+		Params:     getPointParams(),
+		Arithmetic: &pointArithmetic{},
+	}
+}
+
+func pointParamsInit() {
+	// How these values were derived
+	// left for informational purposes
+	// capA := FpNew().SetBigInt(a)
+	// capB := FpNew().SetBigInt(b)
+	// gx := FpNew().SetBigInt(gx)
+	// gy := FpNew().SetBigInt(gy)
+	// where a, b, gx and gy are from RFC 5639, Section 3
+
+	p384r1PointParams = native.EllipticPoint6Params{
+		A: FpNew().SetRaw(&[native.Field6Limbs]uint64{
+			0xdb26b895466c3c99,
+			0x75d7f3fef157b07b,
+			0x936771b9d7f10db4,
+			0xe7ffe9e535529374,
+			0x400a8fdf42b00c60,
+			0x7c338021a2e8c0d1,
+		}),
+		B: FpNew().SetRaw(&[native.Field6Limbs]uint64{
+			0x1f05fdea00c8e16d,
+			0x362ef7c8205a0fe3,
+			0xcdb456c3f7216eda,
+			0x17413827fe77fed8,
+			0x2b335681d1cd255d,
+			0x453dcefae84686aa,
+		}),
+		Gx: FpNew().SetRaw(&[native.Field6Limbs]uint64{
+			0xa189deebd438fbc1,
+			0x66fc80e8d5a886bf,
+			0x94c378e99d202f23,
+			0x068b264ef95c2164,
+			0x9cdd0dcfbacd0099,
+			0x8500753388f53fc1,
+		}),
+		Gy: FpNew().SetRaw(&[native.Field6Limbs]uint64{
+			0xe738b3310de140a5,
+			0xf5e0d246c7996f55,
+			0xf88309a38f0737fc,
+			0xa180acd4d5719217,
+			0xc61625664f21ddb6,
+			0x2cf4a062458968b5,
+		}),
+		BitSize: 384,
+		Name:    "brainpoolP384r1",
+	}
+}
+
+func getPointParams() *native.EllipticPoint6Params {
+	p384r1PointInitonce.Do(pointParamsInit)
+	return &p384r1PointParams
+}
+
+func getPointSswuParams() *native.Sswu6Params {
+	p384r1PointSswuInitOnce.Do(pointSswuParamsInit)
+	return &p384r1PointSswuParams
+}
+
+func pointSswuParamsInit() {
+	// How these values were derived
+	// left for informational purposes
+	// p := internal.BrainpoolP384r1FpParams().BiModulus
+	//
+	// // c1 = (p - 3) / 4
+	// c1 := new(big.Int).Sub(p, big.NewInt(3))
+	// c1.Rsh(c1, 2)
+	//
+	// z := big.NewInt(-5)
+	// z.Mod(z, p)
+	// // sqrt(-Z^3)
+	// zTmp := new(big.Int).Exp(z, big.NewInt(3), nil)
+	// zTmp = zTmp.Neg(zTmp)
+	// zTmp.Mod(zTmp, p)
+	// c2 := new(big.Int).ModSqrt(zTmp, p)
+	//
+	// capC1 is c1 as little endian limbs, not in montgomery form
+	// capC2 := FpNew().SetBigInt(c2)
+	// capZ := FpNew().SetBigInt(z)
+
+	p384r1PointSswuParams = native.Sswu6Params{
+		C1: [native.Field6Limbs]uint64{
+			0x61d1c004cc41fb14,
+			0xeb34e9ca6407469c,
+			0x04ac76865fedc448,
+			0xc54bdc427b5515ad,
+			0x03d75bdf94399077,
+			0x232e47a0a8ce1b4a,
+		},
+		C2: [native.Field6Limbs]uint64{
+			0xd21ce27780cbf475,
+			0xa03de569d30d3b30,
+			0x8f9e079bc4dbcff6,
+			0x065e3f7d8752a867,
+			0xffb659fc0f172883,
+			0x7e6d7611ad0b2b39,
+		},
+		A: [native.Field6Limbs]uint64{
+			0xdb26b895466c3c99,
+			0x75d7f3fef157b07b,
+			0x936771b9d7f10db4,
+			0xe7ffe9e535529374,
+			0x400a8fdf42b00c60,
+			0x7c338021a2e8c0d1,
+		},
+		B: [native.Field6Limbs]uint64{
+			0x1f05fdea00c8e16d,
+			0x362ef7c8205a0fe3,
+			0xcdb456c3f7216eda,
+			0x17413827fe77fed8,
+			0x2b335681d1cd255d,
+			0x453dcefae84686aa,
+		},
+		Z: [native.Field6Limbs]uint64{
+			0x48c600bfea4f3b3e,
+			0xc044879fa123086f,
+			0xbaf284fefd26ab64,
+			0xd3da6a63454b6308,
+			0x99a65aef28fe92b6,
+			0x7f3b311a60344390,
+		},
+	}
+}
+
+type pointArithmetic struct{}
+
+func (k pointArithmetic) Hash(out *native.EllipticPoint6, hash *native.EllipticPointHasher, msg, dst []byte) error {
+	var u []byte
+	sswuParams := getPointSswuParams()
+
+	switch hash.Type() {
+	case native.XMD:
+		u = native.ExpandMsgXmd(hash, msg, dst, 144)
+	case native.XOF:
+		u = native.ExpandMsgXof(hash, msg, dst, 144)
+	}
+	var buf [native.WideField6Bytes]byte
+	copy(buf[:72], internal.ReverseBytes(u[:72]))
+	u0 := FpNew().SetBytesWide(&buf)
+	copy(buf[:72], internal.ReverseBytes(u[72:]))
+	u1 := FpNew().SetBytesWide(&buf)
+
+	q0x, q0y := sswuParams.Osswu3mod4(u0)
+	q1x, q1y := sswuParams.Osswu3mod4(u1)
+	out.X = q0x
+	out.Y = q0y
+	out.Z.SetOne()
+	tv := &native.EllipticPoint6{
+		X: q1x,
+		Y: q1y,
+		Z: FpNew().SetOne(),
+	}
+	k.Add(out, out, tv)
+	return nil
+}
+
+func (pointArithmetic) Double(out, arg *native.EllipticPoint6) {
+	// Doubling formula for any a from Renes-Costello-Batina 2015
+	// (https://eprint.iacr.org/2015/1060 Algorithm 3)
+	var t0, t1, t2, t3, b3, x, y, z [native.Field6Limbs]uint64
+	a := getPointParams().A.Value
+	b := getPointParams().B.Value
+	f := arg.X.Arithmetic
+
+	f.Add(&b3, &b, &b)
+	f.Add(&b3, &b3, &b)
+
+	f.Square(&t0, &arg.X.Value)
+	f.Square(&t1, &arg.Y.Value)
+	f.Square(&t2, &arg.Z.Value)
+	f.Mul(&t3, &arg.X.Value, &arg.Y.Value)
+	f.Add(&t3, &t3, &t3)
+	f.Mul(&z, &arg.X.Value, &arg.Z.Value)
+	f.Add(&z, &z, &z)
+	f.Mul(&x, &a, &z)
+	f.Mul(&y, &b3, &t2)
+	f.Add(&y, &x, &y)
+	f.Sub(&x, &t1, &y)
+	f.Add(&y, &t1, &y)
+	f.Mul(&y, &x, &y)
+	f.Mul(&x, &t3, &x)
+	f.Mul(&z, &b3, &z)
+	f.Mul(&t2, &a, &t2)
+	f.Sub(&t3, &t0, &t2)
+	f.Mul(&t3, &a, &t3)
+	f.Add(&t3, &t3, &z)
+	f.Add(&z, &t0, &t0)
+	f.Add(&t0, &z, &t0)
+	f.Add(&t0, &t0, &t2)
+	f.Mul(&t0, &t0, &t3)
+	f.Add(&y, &y, &t0)
+	f.Mul(&t2, &arg.Y.Value, &arg.Z.Value)
+	f.Add(&t2, &t2, &t2)
+	f.Mul(&t0, &t2, &t3)
+	f.Sub(&x, &x, &t0)
+	f.Mul(&z, &t2, &t1)
+	f.Add(&z, &z, &z)
+	f.Add(&z, &z, &z)
+
+	out.X.Value = x
+	out.Y.Value = y
+	out.Z.Value = z
+}
+
+func (pointArithmetic) Add(out, arg1, arg2 *native.EllipticPoint6) {
+	// Addition formula for any a from Renes-Costello-Batina 2015
+	// (https://eprint.iacr.org/2015/1060 Algorithm 1).
+	var t0, t1, t2, t3, t4, t5, b3, x, y, z [native.Field6Limbs]uint64
+	a := getPointParams().A.Value
+	b := getPointParams().B.Value
+	f := arg1.X.Arithmetic
+
+	f.Add(&b3, &b, &b)
+	f.Add(&b3, &b3, &b)
+
+	f.Mul(&t0, &arg1.X.Value, &arg2.X.Value)
+	f.Mul(&t1, &arg1.Y.Value, &arg2.Y.Value)
+	f.Mul(&t2, &arg1.Z.Value, &arg2.Z.Value)
+	f.Add(&t3, &arg1.X.Value, &arg1.Y.Value)
+	f.Add(&t4, &arg2.X.Value, &arg2.Y.Value)
+	f.Mul(&t3, &t3, &t4)
+	f.Add(&t4, &t0, &t1)
+	f.Sub(&t3, &t3, &t4)
+	f.Add(&t4, &arg1.X.Value, &arg1.Z.Value)
+	f.Add(&t5, &arg2.X.Value, &arg2.Z.Value)
+	f.Mul(&t4, &t4, &t5)
+	f.Add(&t5, &t0, &t2)
+	f.Sub(&t4, &t4, &t5)
+	f.Add(&t5, &arg1.Y.Value, &arg1.Z.Value)
+	f.Add(&x, &arg2.Y.Value, &arg2.Z.Value)
+	f.Mul(&t5, &t5, &x)
+	f.Add(&x, &t1, &t2)
+	f.Sub(&t5, &t5, &x)
+	f.Mul(&z, &a, &t4)
+	f.Mul(&x, &b3, &t2)
+	f.Add(&z, &x, &z)
+	f.Sub(&x, &t1, &z)
+	f.Add(&z, &t1, &z)
+	f.Mul(&y, &x, &z)
+	f.Add(&t1, &t0, &t0)
+	f.Add(&t1, &t1, &t0)
+	f.Mul(&t2, &a, &t2)
+	f.Mul(&t4, &b3, &t4)
+	f.Add(&t1, &t1, &t2)
+	f.Sub(&t2, &t0, &t2)
+	f.Mul(&t2, &a, &t2)
+	f.Add(&t4, &t4, &t2)
+	f.Mul(&t0, &t1, &t4)
+	f.Add(&y, &y, &t0)
+	f.Mul(&t0, &t5, &t4)
+	f.Mul(&x, &t3, &x)
+	f.Sub(&x, &x, &t0)
+	f.Mul(&t0, &t3, &t1)
+	f.Mul(&z, &t5, &z)
+	f.Add(&z, &z, &t0)
+
+	e1 := arg1.Z.IsZero()
+	e2 := arg2.Z.IsZero()
+
+	// If arg1 is identity set it to arg2
+	f.Selectznz(&z, &z, &arg2.Z.Value, e1)
+	f.Selectznz(&y, &y, &arg2.Y.Value, e1)
+	f.Selectznz(&x, &x, &arg2.X.Value, e1)
+	// If arg2 is identity set it to arg1
+	f.Selectznz(&z, &z, &arg1.Z.Value, e2)
+	f.Selectznz(&y, &y, &arg1.Y.Value, e2)
+	f.Selectznz(&x, &x, &arg1.X.Value, e2)
+
+	out.X.Value = x
+	out.Y.Value = y
+	out.Z.Value = z
+}
+
+func (k pointArithmetic) IsOnCurve(arg *native.EllipticPoint6) bool {
+	affine := PointNew()
+	k.ToAffine(affine, arg)
+	lhs := FpNew().Square(affine.Y)
+	rhs := FpNew()
+	k.RhsEquation(rhs, affine.X)
+	return lhs.Equal(rhs) == 1
+}
+
+func (pointArithmetic) ToAffine(out, arg *native.EllipticPoint6) {
+	var wasInverted int
+	var zero, x, y, z [native.Field6Limbs]uint64
+	f := arg.X.Arithmetic
+
+	f.Invert(&wasInverted, &z, &arg.Z.Value)
+	f.Mul(&x, &arg.X.Value, &z)
+	f.Mul(&y, &arg.Y.Value, &z)
+
+	out.Z.SetOne()
+	// If point at infinity this does nothing
+	f.Selectznz(&x, &zero, &x, wasInverted)
+	f.Selectznz(&y, &zero, &y, wasInverted)
+	f.Selectznz(&z, &zero, &out.Z.Value, wasInverted)
+
+	out.X.Value = x
+	out.Y.Value = y
+	out.Z.Value = z
+	out.Params = arg.Params
+	out.Arithmetic = arg.Arithmetic
+}
+
+func (pointArithmetic) RhsEquation(out, x *native.Field6) {
+	// Elliptic curve equation for brainpoolP384r1 is: y^2 = x^3 + ax + b
+	out.Square(x)
+	out.Mul(out, x)
+	out.Add(out, getPointParams().B)
+	out.Add(out, FpNew().Mul(getPointParams().A, x))
+}
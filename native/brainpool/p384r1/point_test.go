@@ -0,0 +1,69 @@
+package p384r1_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikelodder7/curvey/native"
+	"github.com/mikelodder7/curvey/native/brainpool/p384r1"
+)
+
+func bhex(s string) *big.Int {
+	r, _ := new(big.Int).SetString(s, 16)
+	return r
+}
+
+// The expected points were computed with OpenSSL 3.0 (k*G) and an
+// independent RFC 9380 implementation (hash to curve).
+
+func TestBrainpoolP384r1PointArithmetic_Double(t *testing.T) {
+	g := p384r1.PointNew().Generator()
+	require.True(t, g.IsOnCurve())
+	pt1 := p384r1.PointNew().Double(g)
+	pt2 := p384r1.PointNew().Add(g, g)
+	pt3 := p384r1.PointNew().Mul(g, p384r1.FqNew().SetUint64(2))
+
+	require.Equal(t, 1, pt1.Equal(pt2))
+	require.Equal(t, 1, pt1.Equal(pt3))
+	require.Equal(t, 1, pt2.Equal(pt3))
+
+	x, y := pt1.BigInt()
+	require.Equal(t, 0, x.Cmp(bhex("2282bc382a2f4dfcb95c3495d7b4fd590ad520b3eb6be4d6ec2f80c4e0f70df87c4ba74a09b553ebb427b58df9d59fca")))
+	require.Equal(t, 0, y.Cmp(bhex("edda83773ac68735768d14a24f37a57ce9bedbc170921ce4d89dd051728fc3eb4b4ea69ab64fc288f1b29502b6e1d30")))
+}
+
+func TestBrainpoolP384r1PointArithmetic_Mul(t *testing.T) {
+	g := p384r1.PointNew().Generator()
+	pt := p384r1.PointNew().Mul(g, p384r1.FqNew().SetUint64(0x1234567890abcdef))
+	require.True(t, pt.IsOnCurve())
+	x, y := pt.BigInt()
+	require.Equal(t, 0, x.Cmp(bhex("740874ac9542812458e7d25afb0d0c286f153f826fc80b9af19d268a9c0359287db398f601eda0d247af90ea69004dc1")))
+	require.Equal(t, 0, y.Cmp(bhex("4a666a4c852f3c23d10a15f316e884c9a5fb8c291870336add0c932e56dc4d8a74c0b152d43e82f263b6550825455438")))
+
+	// n * G = 0
+	n := p384r1.FqNew().SetOne()
+	n.Neg(n)
+	pt.Mul(g, n)
+	pt.Add(pt, g)
+	require.True(t, pt.IsIdentity())
+}
+
+func TestBrainpoolP384r1PointArithmetic_Hash(t *testing.T) {
+	tests := []struct {
+		msg  string
+		x, y string
+	}{
+		{"", "4f90cdb1ccdcddb5e1229bf989645196e98177cc022822d2bf68162a3ac2331da8b8b16d7fde375cba5c284caed04c71", "4cf9a7f6996af6645670c9347fdc49c7883e87bf7bebce8268ec50a739a424e2769977166925f0ed82691fdea27e9fb2"},
+		{"abc", "6d6963ff5af59043abc9b05ab4a00fdb439d3138e5756376c85abae1779b229529da8b8788304bdf3134ff7cfa62720b", "7fd3354dd8a4c210765e848b83b73c2df70be956e0cf635d532e35c51e7ea1f7377bd88f839e840bf3c41be74310da95"},
+	}
+	for _, tst := range tests {
+		pt, err := p384r1.PointNew().Hash([]byte(tst.msg), native.EllipticPointHasherSha384())
+		require.NoError(t, err)
+		require.True(t, pt.IsOnCurve())
+		x, y := pt.BigInt()
+		require.Equal(t, 0, x.Cmp(bhex(tst.x)))
+		require.Equal(t, 0, y.Cmp(bhex(tst.y)))
+	}
+}
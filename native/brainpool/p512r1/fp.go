@@ -0,0 +1,120 @@
+package p512r1
+
+import (
+	"encoding/binary"
+	"sync"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+)
+
+var (
+	fpInitonce sync.Once
+	fpParams   native.Field8Params
+)
+
+// FpNew returns an element of the brainpoolP512r1 base field.
+func FpNew() *native.Field8 {
+	return &native.Field8{
+		Value:      [native.Field8Limbs]uint64{},
+		Params:     getFpParams(),
+		Arithmetic: fpArithmetic{},
+	}
+}
+
+func fpParamsInit() {
+	params := internal.BrainpoolP512r1FpParams()
+	fpParams = native.Field8Params{
+		BiModulus: params.BiModulus,
+	}
+	copy(fpParams.R[:], params.R)
+	copy(fpParams.R2[:], params.R2)
+	copy(fpParams.R3[:], params.R3)
+	copy(fpParams.Modulus[:], params.Modulus)
+}
+
+func getFpParams() *native.Field8Params {
+	fpInitonce.Do(fpParamsInit)
+	return &fpParams
+}
+
+// fpArithmetic adapts the generic montgomery field arithmetic
+// to the fixed size limbs used by native.Field8.
+type fpArithmetic struct{}
+
+// ToMontgomery converts this field to montgomery form.
+func (fpArithmetic) ToMontgomery(out, arg *[native.Field8Limbs]uint64) {
+	o, a := out[:], arg[:]
+	internal.BrainpoolP512r1FpParams().ToMontgomery(&o, &a)
+}
+
+// FromMontgomery converts this field from montgomery form.
+func (fpArithmetic) FromMontgomery(out, arg *[native.Field8Limbs]uint64) {
+	o, a := out[:], arg[:]
+	internal.BrainpoolP512r1FpParams().FromMontgomery(&o, &a)
+}
+
+// Neg performs modular negation.
+func (fpArithmetic) Neg(out, arg *[native.Field8Limbs]uint64) {
+	o, a := out[:], arg[:]
+	internal.BrainpoolP512r1FpParams().Neg(&o, &a)
+}
+
+// Square performs modular square.
+func (fpArithmetic) Square(out, arg *[native.Field8Limbs]uint64) {
+	o, a := out[:], arg[:]
+	internal.BrainpoolP512r1FpParams().Square(&o, &a)
+}
+
+// Mul performs modular multiplication.
+func (fpArithmetic) Mul(out, arg1, arg2 *[native.Field8Limbs]uint64) {
+	o, a1, a2 := out[:], arg1[:], arg2[:]
+	internal.BrainpoolP512r1FpParams().Mul(&o, &a1, &a2)
+}
+
+// Add performs modular addition.
+func (fpArithmetic) Add(out, arg1, arg2 *[native.Field8Limbs]uint64) {
+	o, a1, a2 := out[:], arg1[:], arg2[:]
+	internal.BrainpoolP512r1FpParams().Add(&o, &a1, &a2)
+}
+
+// Sub performs modular subtraction.
+func (fpArithmetic) Sub(out, arg1, arg2 *[native.Field8Limbs]uint64) {
+	o, a1, a2 := out[:], arg1[:], arg2[:]
+	internal.BrainpoolP512r1FpParams().Sub(&o, &a1, &a2)
+}
+
+// Sqrt performs modular square root.
+func (fpArithmetic) Sqrt(wasSquare *int, out, arg *[native.Field8Limbs]uint64) {
+	o, a := out[:], arg[:]
+	internal.BrainpoolP512r1FpParams().Sqrt(wasSquare, &o, &a)
+}
+
+// Invert performs modular inverse.
+func (fpArithmetic) Invert(wasInverted *int, out, arg *[native.Field8Limbs]uint64) {
+	o, a := out[:], arg[:]
+	internal.BrainpoolP512r1FpParams().Invert(wasInverted, &o, &a)
+}
+
+// FromBytes converts a little endian byte array into a field element.
+func (fpArithmetic) FromBytes(out *[native.Field8Limbs]uint64, arg *[native.Field8Bytes]byte) {
+	for i := range out {
+		out[i] = binary.LittleEndian.Uint64(arg[i*8 : (i+1)*8])
+	}
+}
+
+// ToBytes converts a field element to a little endian byte array.
+func (fpArithmetic) ToBytes(out *[native.Field8Bytes]byte, arg *[native.Field8Limbs]uint64) {
+	for i, a := range arg {
+		binary.LittleEndian.PutUint64(out[i*8:(i+1)*8], a)
+	}
+}
+
+// Selectznz performs conditional select.
+// selects arg1 if choice == 0 and arg2 if choice == 1.
+func (fpArithmetic) Selectznz(out, arg1, arg2 *[native.Field8Limbs]uint64, choice int) {
+	b := uint64(-choice)
+	for i := range out {
+		out[i] = arg1[i] ^ ((arg1[i] ^ arg2[i]) & b)
+	}
+}
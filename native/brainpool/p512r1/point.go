@@ -0,0 +1,353 @@
+package p512r1
+
+import (
+	"sync"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+)
+
+var (
+	p512r1PointInitonce     sync.Once
+	p512r1PointParams       native.EllipticPoint8Params
+	p512r1PointSswuInitOnce sync.Once
+	p512r1PointSswuParams   native.Sswu8Params
+)
+
+func PointNew() *native.EllipticPoint8 {
+	return &native.EllipticPoint8{
+		X:          FpNew(),
+		Y:          FpNew(),
+		Z:          FpNew(),
+		Params:     getPointParams(),
+		Arithmetic: &pointArithmetic{},
+	}
+}
+
+func pointParamsInit() {
+	// How these values were derived
+	// left for informational purposes
+	// capA := FpNew().SetBigInt(a)
+	// capB := FpNew().SetBigInt(b)
+	// gx := FpNew().SetBigInt(gx)
+	// gy := FpNew().SetBigInt(gy)
+	// where a, b, gx and gy are from RFC 5639, Section 3
+
+	p512r1PointParams = native.EllipticPoint8Params{
+		A: FpNew().SetRaw(&[native.Field8Limbs]uint64{
+			0xda1f8a34ea10c446,
+			0x14e4957dafa7d283,
+			0x40b04b724675bbab,
+			0xcf8f01119e6e87ff,
+			0xa5ec30c83f80d1c7,
+			0x182d0f59f41e8778,
+			0xb83b84fae2d0850c,
+			0x5ec4f187227d2a83,
+		}),
+		B: FpNew().SetRaw(&[native.Field8Limbs]uint64{
+			0x507e839620e92a34,
+			0x009b63c7e58e5a34,
+			0xe16ba4562d8724aa,
+			0xc73e30e89877be02,
+			0x97e00c63fe222433,
+			0xcbda57ac6d17d81d,
+			0x642312a50bb5aaa2,
+			0x6a4aabb4471e8ea7,
+		}),
+		Gx: FpNew().SetRaw(&[native.Field8Limbs]uint64{
+			0xc4ce96095161d9d3,
+			0x683e4d64272c02a4,
+			0x34ab04146df55e8f,
+			0x8550539514c01fc8,
+			0x2433d76f905c8737,
+			0xb2b6ea37f36d3cf7,
+			0x871cb5ca006d4573,
+			0x5a2ba14c0994e981,
+		}),
+		Gy: FpNew().SetRaw(&[native.Field8Limbs]uint64{
+			0x2f90662925042a6d,
+			0x7518df6f4742f325,
+			0xbf8455534c859490,
+			0x360ec775598ecc3e,
+			0x7c170b888fe62fdc,
+			0x585d2b77cd9d3f8c,
+			0x9a5ed7da870f3f9b,
+			0x8c50c9d12acb7281,
+		}),
+		BitSize: 512,
+		Name:    "brainpoolP512r1",
+	}
+}
+
+func getPointParams() *native.EllipticPoint8Params {
+	p512r1PointInitonce.Do(pointParamsInit)
+	return &p512r1PointParams
+}
+
+func getPointSswuParams() *native.Sswu8Params {
+	p512r1PointSswuInitOnce.Do(pointSswuParamsInit)
+	return &p512r1PointSswuParams
+}
+
+func pointSswuParamsInit() {
+	// How these values were derived
+	// left for informational purposes
+	// p := internal.BrainpoolP512r1FpParams().BiModulus
+	//
+	// // c1 = (p - 3) / 4
+	// c1 := new(big.Int).Sub(p, big.NewInt(3))
+	// c1.Rsh(c1, 2)
+	//
+	// z := big.NewInt(7)
+	// z.Mod(z, p)
+	// // sqrt(-Z^3)
+	// zTmp := new(big.Int).Exp(z, big.NewInt(3), nil)
+	// zTmp = zTmp.Neg(zTmp)
+	// zTmp.Mod(zTmp, p)
+	// c2 := new(big.Int).ModSqrt(zTmp, p)
+	//
+	// capC1 is c1 as little endian limbs, not in montgomery form
+	// capC2 := FpNew().SetBigInt(c2)
+	// capZ := FpNew().SetBigInt(z)
+
+	p512r1PointSswuParams = native.Sswu8Params{
+		C1: [native.Field8Limbs]uint64{
+			0x4a2a9815960e923c,
+			0x8a207fcbcb60b1a1,
+			0xabb3684ab9a8e039,
+			0x5f5366c026f19a10,
+			0xb598e7329c0cc21c,
+			0xf2cc236cecf27483,
+			0xcff539ab8cf27f01,
+			0x2ab7676e36fa7122,
+		},
+		C2: [native.Field8Limbs]uint64{
+			0x3793bc3a9c8d3536,
+			0x6a5b2fb68253af37,
+			0x353cfacc92289747,
+			0x185ecf8a3154c204,
+			0x7fe10f7e5eda8362,
+			0xe73e8163da7dd6da,
+			0x8bf91f3328a8be91,
+			0x4afcdd41f190ba43,
+		},
+		A: [native.Field8Limbs]uint64{
+			0xda1f8a34ea10c446,
+			0x14e4957dafa7d283,
+			0x40b04b724675bbab,
+			0xcf8f01119e6e87ff,
+			0xa5ec30c83f80d1c7,
+			0x182d0f59f41e8778,
+			0xb83b84fae2d0850c,
+			0x5ec4f187227d2a83,
+		},
+		B: [native.Field8Limbs]uint64{
+			0x507e839620e92a34,
+			0x009b63c7e58e5a34,
+			0xe16ba4562d8724aa,
+			0xc73e30e89877be02,
+			0x97e00c63fe222433,
+			0xcbda57ac6d17d81d,
+			0x642312a50bb5aaa2,
+			0x6a4aabb4471e8ea7,
+		},
+		Z: [native.Field8Limbs]uint64{
+			0x69583ca08db92682,
+			0x6aec082838e43ecc,
+			0x2bf7b452fd9cf702,
+			0x1af7f1f9ea3fed65,
+			0xa01be0179e01ab91,
+			0x101a76fafa1dcb6b,
+			0x81aefd31fa1c27b2,
+			0x5357d6c768de528f,
+		},
+	}
+}
+
+type pointArithmetic struct{}
+
+func (k pointArithmetic) Hash(out *native.EllipticPoint8, hash *native.EllipticPointHasher, msg, dst []byte) error {
+	var u []byte
+	sswuParams := getPointSswuParams()
+
+	switch hash.Type() {
+	case native.XMD:
+		u = native.ExpandMsgXmd(hash, msg, dst, 192)
+	case native.XOF:
+		u = native.ExpandMsgXof(hash, msg, dst, 192)
+	}
+	var buf [native.WideField8Bytes]byte
+	copy(buf[:96], internal.ReverseBytes(u[:96]))
+	u0 := FpNew().SetBytesWide(&buf)
+	copy(buf[:96], internal.ReverseBytes(u[96:]))
+	u1 := FpNew().SetBytesWide(&buf)
+
+	q0x, q0y := sswuParams.Osswu3mod4(u0)
+	q1x, q1y := sswuParams.Osswu3mod4(u1)
+	out.X = q0x
+	out.Y = q0y
+	out.Z.SetOne()
+	tv := &native.EllipticPoint8{
+		X: q1x,
+		Y: q1y,
+		Z: FpNew().SetOne(),
+	}
+	k.Add(out, out, tv)
+	return nil
+}
+
+func (pointArithmetic) Double(out, arg *native.EllipticPoint8) {
+	// Doubling formula for any a from Renes-Costello-Batina 2015
+	// (https://eprint.iacr.org/2015/1060 Algorithm 3)
+	var t0, t1, t2, t3, b3, x, y, z [native.Field8Limbs]uint64
+	a := getPointParams().A.Value
+	b := getPointParams().B.Value
+	f := arg.X.Arithmetic
+
+	f.Add(&b3, &b, &b)
+	f.Add(&b3, &b3, &b)
+
+	f.Square(&t0, &arg.X.Value)
+	f.Square(&t1, &arg.Y.Value)
+	f.Square(&t2, &arg.Z.Value)
+	f.Mul(&t3, &arg.X.Value, &arg.Y.Value)
+	f.Add(&t3, &t3, &t3)
+	f.Mul(&z, &arg.X.Value, &arg.Z.Value)
+	f.Add(&z, &z, &z)
+	f.Mul(&x, &a, &z)
+	f.Mul(&y, &b3, &t2)
+	f.Add(&y, &x, &y)
+	f.Sub(&x, &t1, &y)
+	f.Add(&y, &t1, &y)
+	f.Mul(&y, &x, &y)
+	f.Mul(&x, &t3, &x)
+	f.Mul(&z, &b3, &z)
+	f.Mul(&t2, &a, &t2)
+	f.Sub(&t3, &t0, &t2)
+	f.Mul(&t3, &a, &t3)
+	f.Add(&t3, &t3, &z)
+	f.Add(&z, &t0, &t0)
+	f.Add(&t0, &z, &t0)
+	f.Add(&t0, &t0, &t2)
+	f.Mul(&t0, &t0, &t3)
+	f.Add(&y, &y, &t0)
+	f.Mul(&t2, &arg.Y.Value, &arg.Z.Value)
+	f.Add(&t2, &t2, &t2)
+	f.Mul(&t0, &t2, &t3)
+	f.Sub(&x, &x, &t0)
+	f.Mul(&z, &t2, &t1)
+	f.Add(&z, &z, &z)
+	f.Add(&z, &z, &z)
+
+	out.X.Value = x
+	out.Y.Value = y
+	out.Z.Value = z
+}
+
+func (pointArithmetic) Add(out, arg1, arg2 *native.EllipticPoint8) {
+	// Addition formula for any a from Renes-Costello-Batina 2015
+	// (https://eprint.iacr.org/2015/1060 Algorithm 1).
+	var t0, t1, t2, t3, t4, t5, b3, x, y, z [native.Field8Limbs]uint64
+	a := getPointParams().A.Value
+	b := getPointParams().B.Value
+	f := arg1.X.Arithmetic
+
+	f.Add(&b3, &b, &b)
+	f.Add(&b3, &b3, &b)
+
+	f.Mul(&t0, &arg1.X.Value, &arg2.X.Value)
+	f.Mul(&t1, &arg1.Y.Value, &arg2.Y.Value)
+	f.Mul(&t2, &arg1.Z.Value, &arg2.Z.Value)
+	f.Add(&t3, &arg1.X.Value, &arg1.Y.Value)
+	f.Add(&t4, &arg2.X.Value, &arg2.Y.Value)
+	f.Mul(&t3, &t3, &t4)
+	f.Add(&t4, &t0, &t1)
+	f.Sub(&t3, &t3, &t4)
+	f.Add(&t4, &arg1.X.Value, &arg1.Z.Value)
+	f.Add(&t5, &arg2.X.Value, &arg2.Z.Value)
+	f.Mul(&t4, &t4, &t5)
+	f.Add(&t5, &t0, &t2)
+	f.Sub(&t4, &t4, &t5)
+	f.Add(&t5, &arg1.Y.Value, &arg1.Z.Value)
+	f.Add(&x, &arg2.Y.Value, &arg2.Z.Value)
+	f.Mul(&t5, &t5, &x)
+	f.Add(&x, &t1, &t2)
+	f.Sub(&t5, &t5, &x)
+	f.Mul(&z, &a, &t4)
+	f.Mul(&x, &b3, &t2)
+	f.Add(&z, &x, &z)
+	f.Sub(&x, &t1, &z)
+	f.Add(&z, &t1, &z)
+	f.Mul(&y, &x, &z)
+	f.Add(&t1, &t0, &t0)
+	f.Add(&t1, &t1, &t0)
+	f.Mul(&t2, &a, &t2)
+	f.Mul(&t4, &b3, &t4)
+	f.Add(&t1, &t1, &t2)
+	f.Sub(&t2, &t0, &t2)
+	f.Mul(&t2, &a, &t2)
+	f.Add(&t4, &t4, &t2)
+	f.Mul(&t0, &t1, &t4)
+	f.Add(&y, &y, &t0)
+	f.Mul(&t0, &t5, &t4)
+	f.Mul(&x, &t3, &x)
+	f.Sub(&x, &x, &t0)
+	f.Mul(&t0, &t3, &t1)
+	f.Mul(&z, &t5, &z)
+	f.Add(&z, &z, &t0)
+
+	e1 := arg1.Z.IsZero()
+	e2 := arg2.Z.IsZero()
+
+	// If arg1 is identity set it to arg2
+	f.Selectznz(&z, &z, &arg2.Z.Value, e1)
+	f.Selectznz(&y, &y, &arg2.Y.Value, e1)
+	f.Selectznz(&x, &x, &arg2.X.Value, e1)
+	// If arg2 is identity set it to arg1
+	f.Selectznz(&z, &z, &arg1.Z.Value, e2)
+	f.Selectznz(&y, &y, &arg1.Y.Value, e2)
+	f.Selectznz(&x, &x, &arg1.X.Value, e2)
+
+	out.X.Value = x
+	out.Y.Value = y
+	out.Z.Value = z
+}
+
+func (k pointArithmetic) IsOnCurve(arg *native.EllipticPoint8) bool {
+	affine := PointNew()
+	k.ToAffine(affine, arg)
+	lhs := FpNew().Square(affine.Y)
+	rhs := FpNew()
+	k.RhsEquation(rhs, affine.X)
+	return lhs.Equal(rhs) == 1
+}
+
+func (pointArithmetic) ToAffine(out, arg *native.EllipticPoint8) {
+	var wasInverted int
+	var zero, x, y, z [native.Field8Limbs]uint64
+	f := arg.X.Arithmetic
+
+	f.Invert(&wasInverted, &z, &arg.Z.Value)
+	f.Mul(&x, &arg.X.Value, &z)
+	f.Mul(&y, &arg.Y.Value, &z)
+
+	out.Z.SetOne()
+	// If point at infinity this does nothing
+	f.Selectznz(&x, &zero, &x, wasInverted)
+	f.Selectznz(&y, &zero, &y, wasInverted)
+	f.Selectznz(&z, &zero, &out.Z.Value, wasInverted)
+
+	out.X.Value = x
+	out.Y.Value = y
+	out.Z.Value = z
+	out.Params = arg.Params
+	out.Arithmetic = arg.Arithmetic
+}
+
+func (pointArithmetic) RhsEquation(out, x *native.Field8) {
+	// Elliptic curve equation for brainpoolP512r1 is: y^2 = x^3 + ax + b
+	out.Square(x)
+	out.Mul(out, x)
+	out.Add(out, getPointParams().B)
+	out.Add(out, FpNew().Mul(getPointParams().A, x))
+}
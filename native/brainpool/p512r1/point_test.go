@@ -0,0 +1,93 @@
+package p512r1_test
+
+import (
+	crand "crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikelodder7/curvey/native"
+	"github.com/mikelodder7/curvey/native/brainpool/p512r1"
+)
+
+func bhex(s string) *big.Int {
+	r, _ := new(big.Int).SetString(s, 16)
+	return r
+}
+
+// The expected points were computed with OpenSSL 3.0 (k*G) and an
+// independent RFC 9380 implementation (hash to curve).
+
+func TestBrainpoolP512r1PointArithmetic_Double(t *testing.T) {
+	g := p512r1.PointNew().Generator()
+	require.True(t, g.IsOnCurve())
+	pt1 := p512r1.PointNew().Double(g)
+	pt2 := p512r1.PointNew().Add(g, g)
+	pt3 := p512r1.PointNew().Mul(g, p512r1.FqNew().SetUint64(2))
+
+	require.Equal(t, 1, pt1.Equal(pt2))
+	require.Equal(t, 1, pt1.Equal(pt3))
+	require.Equal(t, 1, pt2.Equal(pt3))
+
+	x, y := pt1.BigInt()
+	require.Equal(t, 0, x.Cmp(bhex("9f4945f680edf9800a63285758f399b3d18d8141b8a18064a30d3035f4cb6581957877f3a8f0f72597116e702915a4f4f698f404089a4cc5080447def02f4850")))
+	require.Equal(t, 0, y.Cmp(bhex("6d6b4b188b699c5649826b716292f29d149ce1238d3f1e0f5a2c366b03e5d1b2fdf99bb1709c700fa5c3b602b0960cbf63a42e4181fd929ce269ad21be592e71")))
+}
+
+func TestBrainpoolP512r1PointArithmetic_Mul(t *testing.T) {
+	g := p512r1.PointNew().Generator()
+	pt := p512r1.PointNew().Mul(g, p512r1.FqNew().SetUint64(0x1234567890abcdef))
+	require.True(t, pt.IsOnCurve())
+	x, y := pt.BigInt()
+	require.Equal(t, 0, x.Cmp(bhex("6beff7b4920fb8b049324a3092a9b8c30fe937d3cd3ac533814a46115ddf6ac356598ef6342f0b4dc91748dd0b31e6de50e63699e31972ce0ee0be0afe49ca49")))
+	require.Equal(t, 0, y.Cmp(bhex("8fc68d70a886eaa8bf5b666fa7d53cfff8bf7bab56c54f033dc035175c43e27591d1b62b8d9639a0da7d30936f43e1638082803b859ba916961c3b5a8af0fcf7")))
+
+	// n * G = 0
+	n := p512r1.FqNew().SetOne()
+	n.Neg(n)
+	pt.Mul(g, n)
+	pt.Add(pt, g)
+	require.True(t, pt.IsIdentity())
+}
+
+func TestBrainpoolP512r1PointArithmetic_Hash(t *testing.T) {
+	tests := []struct {
+		msg  string
+		x, y string
+	}{
+		{"", "21ae39c8180841bc6e9486b03c4b4f8989ae91e50f932570cce6f7cc14273ca0b19dbf10cbe97fe8519bccf66457eafa6b917bfbe0fbad1aa1340289e4bccb9", "36818ed34c99ca9aadde80f9578b1e90780b7c6ed3f910794d2901f95fc18109df35da84e0605d9f51235f52969d49f88cfe6d90edf4e3493f28b882d101e048"},
+		{"abc", "1c096e4ce48ba242109f50c109d83942c8bfd4b9374c72f9a2c97879469f0d918e0bcb82613f9ec8eacc45eb48b6989cb60de0438a07d5e328f7d8fa56eb70f1", "54a5bfc595abdd34de829b438fc441b2d0534803e382aa1ac3817d9a9717e9ec930ffc4303d83d49330edf36fc3eca983b2b90e8cefdc2732aacf1807fada9b6"},
+	}
+	for _, tst := range tests {
+		pt, err := p512r1.PointNew().Hash([]byte(tst.msg), native.EllipticPointHasherSha512())
+		require.NoError(t, err)
+		require.True(t, pt.IsOnCurve())
+		x, y := pt.BigInt()
+		require.Equal(t, 0, x.Cmp(bhex(tst.x)))
+		require.Equal(t, 0, y.Cmp(bhex(tst.y)))
+	}
+}
+
+func TestBrainpoolP512r1PointArithmetic_SumOfProducts(t *testing.T) {
+	points := make([]*native.EllipticPoint8, 4)
+	scalars := make([]*native.Field8, 4)
+	expected := p512r1.PointNew().Identity()
+	for i := range points {
+		var b [native.WideField8Bytes]byte
+		_, _ = crand.Read(b[:])
+		points[i], _ = p512r1.PointNew().Random(crand.Reader)
+		scalars[i] = p512r1.FqNew().SetBytesWide(&b)
+		expected.Add(expected, p512r1.PointNew().Mul(points[i], scalars[i]))
+	}
+
+	actual, err := p512r1.PointNew().SumOfProducts(points, scalars)
+	require.NoError(t, err)
+	require.Equal(t, 1, expected.Equal(actual))
+	actual, err = p512r1.PointNew().SumOfProductsVarTime(points, scalars)
+	require.NoError(t, err)
+	require.Equal(t, 1, expected.Equal(actual))
+
+	_, err = p512r1.PointNew().SumOfProducts(points, scalars[1:])
+	require.Error(t, err)
+}
@@ -0,0 +1,219 @@
+package ed448
+
+import (
+	"fmt"
+	"github.com/mikelodder7/curvey/native"
+)
+
+const DecafBytes = 56
+
+// DecafEncoding is the canonical decaf448 encoding described in RFC 9496 §5.3.2
+type DecafEncoding = [DecafBytes]byte
+
+var (
+	// sqrtMinusD is the square root of -d, the negation of decafFactor
+	sqrtMinusD    = FpNew().Neg(decafFactor)
+	invSqrtMinusD = FpNew().SetLimbs(&[7]uint64{
+		0xac5044a1478797d3,
+		0x1044db860e616b0c,
+		0x418f811d3de045ea,
+		0x2945a90dd759ade5,
+		0xa56f6af3c5a4d858,
+		0x6fd41ca5f43537f8,
+		0x910bf9ad1ddd3fa8,
+	})
+	oneMinusD    = FpNew().SetUint64(39082)
+	oneMinusTwoD = FpNew().SetUint64(78163)
+)
+
+// DecafDecode returns a representative of the decaf448 element encoded in
+// input as described in RFC 9496 §5.3.1. Non-canonical encodings are rejected.
+func DecafDecode(input *DecafEncoding) (*EdwardsPoint, error) {
+	s, err := FpNew().SetCanonicalBytes(input)
+	if err != nil {
+		return nil, err
+	}
+	if s.Sgn0I() == 1 {
+		return nil, fmt.Errorf("invalid point")
+	}
+
+	ss := FpNew().Square(s)
+	u1 := FpNew().Add(one, ss)
+	u1u1 := FpNew().Square(u1)
+	u2 := FpNew().Double(ss)
+	u2.Double(u2)
+	u2.Mul(u2, edwardsD)
+	u2.Sub(u1u1, u2)
+
+	invSqrt, wasSquare := FpNew().SqrtRatio(one, FpNew().Mul(u2, u1u1))
+
+	u3 := FpNew().Double(s)
+	u3.Mul(u3, invSqrt)
+	u3.Mul(u3, u1)
+	u3.Mul(u3, sqrtMinusD)
+	u3.CNeg(u3, u3.Sgn0I())
+
+	x := FpNew().Mul(u3, invSqrt)
+	x.Mul(x, u2)
+	x.Mul(x, invSqrtMinusD)
+
+	y := FpNew().Sub(one, ss)
+	y.Mul(y, invSqrt)
+	y.Mul(y, u1)
+
+	if wasSquare != 1 {
+		return nil, fmt.Errorf("invalid point")
+	}
+	return &EdwardsPoint{
+		X: x,
+		Y: y,
+		Z: FpNew().SetOne(),
+		T: FpNew().Mul(x, y),
+	}, nil
+}
+
+// DecafEncode returns the canonical decaf448 encoding of the point
+// as described in RFC 9496 §5.3.2
+func (e *EdwardsPoint) DecafEncode() *DecafEncoding {
+	u1 := FpNew().Add(e.X, e.T)
+	u1.Mul(u1, FpNew().Sub(e.X, e.T))
+
+	t := FpNew().Square(e.X)
+	t.Mul(t, u1)
+	t.Mul(t, oneMinusD)
+	invSqrt, _ := FpNew().SqrtRatio(one, t)
+
+	ratio := FpNew().Mul(invSqrt, u1)
+	ratio.Mul(ratio, sqrtMinusD)
+	ratio.CNeg(ratio, ratio.Sgn0I())
+
+	u2 := FpNew().Mul(invSqrtMinusD, ratio)
+	u2.Mul(u2, e.Z)
+	u2.Sub(u2, e.T)
+
+	s := FpNew().Mul(oneMinusD, invSqrt)
+	s.Mul(s, e.X)
+	s.Mul(s, u2)
+	s.CNeg(s, s.Sgn0I())
+
+	out := s.Bytes()
+	return &out
+}
+
+// DecafEqualI returns 1 if both points represent the same decaf448 element
+func (e *EdwardsPoint) DecafEqualI(rhs *EdwardsPoint) int {
+	xy := FpNew().Mul(e.X, rhs.Y)
+	yx := FpNew().Mul(e.Y, rhs.X)
+	return xy.EqualI(yx)
+}
+
+// IsDecafIdentityI returns 1 if the point represents the decaf448 identity
+func (e *EdwardsPoint) IsDecafIdentityI() int {
+	return e.X.IsZero()
+}
+
+// IsDecafValidI returns 1 if the point can represent a decaf448 element,
+// i.e. it is in the prime order subgroup up to a 2-torsion component
+func (e *EdwardsPoint) IsDecafValidI() int {
+	t := EdwardsPointNew().Double(e)
+	return e.IsOnCurve() & t.IsTorsionFree()
+}
+
+// SetDecafGenerator sets the point to the decaf448 generator from RFC 9496 §5.4
+func (e *EdwardsPoint) SetDecafGenerator() *EdwardsPoint {
+	e.X.SetLimbs(&[PointLimbs]uint64{
+		0x5555555555555555,
+		0x5555555555555555,
+		0x5555555555555555,
+		0xaaaaaaa955555555,
+		0xaaaaaaaaaaaaaaaa,
+		0xaaaaaaaaaaaaaaaa,
+		0xaaaaaaaaaaaaaaaa,
+	})
+	e.Y.SetLimbs(&[PointLimbs]uint64{
+		0x25150432156c7912,
+		0x44434d412e325f94,
+		0xf29a9a7cc5d5cf67,
+		0x481c928c75273b47,
+		0xfc91285fca77b228,
+		0x4ca629dfaf793d4f,
+		0x51fa169cb528fb72,
+	})
+	e.Z.SetOne()
+	e.T.SetLimbs(&[PointLimbs]uint64{
+		0x8561dff5d7111bfd,
+		0xa1c59b8b11b004f1,
+		0x2d6d8bdd642dd3e2,
+		0x8458d3c445bbaf5a,
+		0xe258b2d2a3ca1726,
+		0x628f67c5ed5562b9,
+		0x96927b9bcc8b4531,
+	})
+	return e
+}
+
+// DecafFromUniformBytes derives a decaf448 element from 112 uniformly
+// random bytes as described in RFC 9496 §5.3.4
+func (e *EdwardsPoint) DecafFromUniformBytes(input *[2 * DecafBytes]byte) *EdwardsPoint {
+	var buf [112]byte
+	copy(buf[:DecafBytes], input[:DecafBytes])
+	p1 := decafMap(FpNew().SetBytesWide(&buf))
+	copy(buf[:DecafBytes], input[DecafBytes:])
+	p2 := decafMap(FpNew().SetBytesWide(&buf))
+	return e.Add(p1, p2)
+}
+
+func (e *EdwardsPoint) DecafHashWithDefaults(msg []byte) *EdwardsPoint {
+	return e.DecafHash(native.EllipticPointHasherShake256(), msg, []byte("decaf448_XOF:SHAKE256_D448MAP_RO_"))
+}
+
+// DecafHash computes the hash to group function from RFC 9380 Appendix B
+// using the element derivation function from RFC 9496 §5.3.4
+func (e *EdwardsPoint) DecafHash(hash *native.EllipticPointHasher, msg, dst []byte) *EdwardsPoint {
+	var u []byte
+	switch hash.Type() {
+	case native.XMD:
+		u = native.ExpandMsgXmd(hash, msg, dst, 2*DecafBytes)
+	case native.XOF:
+		u = native.ExpandMsgXof(hash, msg, dst, 2*DecafBytes)
+	}
+	var buf [2 * DecafBytes]byte
+	copy(buf[:], u)
+	return e.DecafFromUniformBytes(&buf)
+}
+
+// decafMap is the MAP function from RFC 9496 §5.3.4
+func decafMap(t *Fp) *EdwardsPoint {
+	r := FpNew().Square(t)
+	r.Neg(r)
+
+	u0 := FpNew().Sub(r, one)
+	u0.Mul(u0, edwardsD)
+
+	u1 := FpNew().Add(u0, one)
+	u1.Mul(u1, FpNew().Sub(u0, r))
+
+	rPlusOne := FpNew().Add(r, one)
+	v, wasSquare := FpNew().SqrtRatio(oneMinusTwoD, FpNew().Mul(rPlusOne, u1))
+	vPrime := FpNew().CMove(FpNew().Mul(t, v), v, wasSquare)
+	sgn := FpNew().CMove(minusOne, one, wasSquare)
+
+	s := FpNew().Mul(vPrime, rPlusOne)
+	ss := FpNew().Square(s)
+
+	w0 := FpNew().Double(s)
+	w1 := FpNew().Add(ss, one)
+	w2 := FpNew().Sub(one, ss)
+	w3 := FpNew().Sub(r, one)
+	w3.Mul(w3, vPrime)
+	w3.Mul(w3, s)
+	w3.Mul(w3, oneMinusTwoD)
+	w3.Add(w3, sgn)
+
+	return &EdwardsPoint{
+		X: FpNew().Mul(w0, w3),
+		Y: FpNew().Mul(w2, w1),
+		Z: FpNew().Mul(w1, w3),
+		T: FpNew().Mul(w0, w2),
+	}
+}
@@ -0,0 +1,87 @@
+package ed448
+
+import (
+	"encoding/hex"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDecaf_GeneratorMultiples(t *testing.T) {
+	// RFC 9496 Appendix B.1
+	multiples := []string{
+		"0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		"6666666666666666666666666666666666666666666666666666666633333333333333333333333333333333333333333333333333333333",
+		"c898eb4f87f97c564c6fd61fc7e49689314a1f818ec85eeb3bd5514ac816d38778f69ef347a89fca817e66defdedce178c7cc709b2116e75",
+		"a0c09bf2ba7208fda0f4bfe3d0f5b29a543012306d43831b5adc6fe7f8596fa308763db15468323b11cf6e4aeb8c18fe44678f44545a69bc",
+		"b46f1836aa287c0a5a5653f0ec5ef9e903f436e21c1570c29ad9e5f596da97eeaf17150ae30bcb3174d04bc2d712c8c7789d7cb4fda138f4",
+		"1c5bbecf4741dfaae79db72dface00eaaac502c2060934b6eaaeca6a20bd3da9e0be8777f7d02033d1b15884232281a41fc7f80eed04af5e",
+		"86ff0182d40f7f9edb7862515821bd67bfd6165a3c44de95d7df79b8779ccf6460e3c68b70c16aaa280f2d7b3f22d745b97a89906cfc476c",
+		"502bcb6842eb06f0e49032bae87c554c031d6d4d2d7694efbf9c468d48220c50f8ca28843364d70cee92d6fe246e61448f9db9808b3b2408",
+	}
+	g := EdwardsPointNew().SetDecafGenerator()
+	require.Equal(t, 1, g.IsOnCurve())
+	require.Equal(t, 1, g.IsDecafValidI())
+	pt := EdwardsPointNew().SetIdentity()
+	for i, m := range multiples {
+		enc := pt.DecafEncode()
+		require.Equal(t, m, hex.EncodeToString(enc[:]), "multiple %d", i)
+
+		decoded, err := DecafDecode(enc)
+		require.NoError(t, err)
+		require.Equal(t, 1, decoded.DecafEqualI(pt))
+		require.Equal(t, *enc, *decoded.DecafEncode())
+
+		pt.Add(pt, g)
+	}
+	require.Equal(t, 1, EdwardsPointNew().SetIdentity().IsDecafIdentityI())
+	require.Equal(t, 0, g.IsDecafIdentityI())
+}
+
+func TestDecaf_DecodeInvalid(t *testing.T) {
+	invalid := []string{
+		// non-canonical field element p
+		"fffffffffffffffffffffffffffffffffffffffffffffffffffffffffeffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		// negative field element
+		"0100000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		// not a valid element
+		"0400000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+	}
+	for _, tc := range invalid {
+		b, _ := hex.DecodeString(tc)
+		var enc DecafEncoding
+		copy(enc[:], b)
+		_, err := DecafDecode(&enc)
+		require.Error(t, err, tc)
+	}
+}
+
+func TestDecaf_TorsionEquality(t *testing.T) {
+	g := EdwardsPointNew().SetDecafGenerator()
+	torqued := EdwardsPointNew().Torque(g)
+	require.Equal(t, 0, torqued.EqualI(g))
+	require.Equal(t, 1, torqued.DecafEqualI(g))
+	require.Equal(t, *g.DecafEncode(), *torqued.DecafEncode())
+}
+
+func TestDecaf_FromUniformBytes(t *testing.T) {
+	// RFC 9496 Appendix B.2
+	input, _ := hex.DecodeString("cbb8c991fd2f0b7e1913462d6463e4fd2ce4ccdd28274dc2ca1f4165d5ee6cdccea57be3416e166fd06718a31af45a2f8e987e301be59ae6673e963001dbbda80df47014a21a26d6c7eb4ebe0312aa6fffb8d1b26bc62ca40ed51f8057a635a02c2b8c83f48fa6a2d70f58a1185902c0")
+	var buf [2 * DecafBytes]byte
+	copy(buf[:], input)
+	pt := EdwardsPointNew().DecafFromUniformBytes(&buf)
+	require.Equal(t, 1, pt.IsOnCurve())
+	require.Equal(t, 1, pt.IsDecafValidI())
+	enc := pt.DecafEncode()
+	require.Equal(t, "0c709c9607dbb01c94513358745b7c23953d03b33e39c7234e268d1d6e24f34014ccbc2216b965dd231d5327e591dc3c0e8844ccfd568848", hex.EncodeToString(enc[:]))
+}
+
+func TestDecaf_Hash(t *testing.T) {
+	tests := []struct{ msg, expected string }{
+		{"", "5869873a621d62fb54c2b3e1adbb0adb699bc06a4694f54b51fa712832c425bd25f5be998862c545d5de5c851f6c354c20705e52f7674b25"},
+		{"abc", "424de9fbcf0e7cfa90ee52bd0f82eafb3245dfa287730ab3b9322a710a62e0a9e55a41ea5e1ac2b6f4b2d9791b7978a0021e7fe94a16a0dc"},
+	}
+	for _, tc := range tests {
+		enc := EdwardsPointNew().DecafHashWithDefaults([]byte(tc.msg)).DecafEncode()
+		require.Equal(t, tc.expected, hex.EncodeToString(enc[:]))
+	}
+}
@@ -109,13 +109,15 @@ var (
 		0xffffffffffffffff,
 		0x3fffffffffffffff,
 	}
-	basePointOrder = FqNew().SetLimbs(&[7]uint64{
-		0x2378c292ab5844f3,
-		0x216cc2728dc58f55,
-		0xc44edb49aed63690,
-		0xffffffff7cca23e9,
-		0xffffffffffffffff,
-		0xffffffffffffffff,
-		0x3fffffffffffffff,
-	})
+	// basePointOrderBytes is the little endian encoding of the prime subgroup order
+	basePointOrderBytes = [PointBytes]byte{
+		0xf3, 0x44, 0x58, 0xab, 0x92, 0xc2, 0x78, 0x23,
+		0x55, 0x8f, 0xc5, 0x8d, 0x72, 0xc2, 0x6c, 0x21,
+		0x90, 0x36, 0xd6, 0xae, 0x49, 0xdb, 0x4e, 0xc4,
+		0xe9, 0x23, 0xca, 0x7c, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x3f,
+		0x00,
+	}
 )
@@ -16,7 +16,7 @@ type CompressedEdwardsY = PointBytesSeq
 
 func (c *CompressedEdwardsY) CMove(arg1, arg2 *CompressedEdwardsY, choice int) *CompressedEdwardsY {
 	mask := byte(-choice)
-	for i := 0; i < PointLimbs; i++ {
+	for i := 0; i < PointBytes; i++ {
 		(*c)[i] = (*arg1)[i] ^ (((*arg1)[i] ^ (*arg2)[i]) & mask)
 	}
 	return c
@@ -26,10 +26,15 @@ func (c *CompressedEdwardsY) EqualI(rhs *CompressedEdwardsY) int {
 	return subtle.ConstantTimeCompare((*c)[:], (*rhs)[:])
 }
 
+// Decompress returns the point encoded as described in RFC 8032 §5.2.3.
+// Only points in the prime order subgroup are accepted.
 func (c *CompressedEdwardsY) Decompress() (*EdwardsPoint, error) {
 	var yBytes [56]byte
 	copy(yBytes[:], c[:56])
-	sign := int(c[56])
+	// Only the sign bit is allowed in the last byte
+	if c[56]&0x7f != 0 {
+		return nil, fmt.Errorf("invalid point")
+	}
 	y, err := FpNew().SetCanonicalBytes(&yBytes)
 	if err != nil {
 		return nil, err
@@ -44,13 +49,15 @@ func (c *CompressedEdwardsY) Decompress() (*EdwardsPoint, error) {
 	denominator.Sub(denominator, dyy)
 	x, isRes := FpNew().SqrtRatio(numerator, denominator)
 
-	signBit := sign >> 7
+	signBit := int(c[56] >> 7)
 	isNegative := x.Sgn0I()
-	x.CNeg(x, isNegative|signBit)
+	// x = 0 has no negative representation
+	invalidSign := x.IsZero() & signBit
+	x.CNeg(x, isNegative^signBit)
 
 	pt := (&AffinePoint{X: x, Y: y}).ToEdwards()
 
-	if isRes&pt.IsTorsionFree()&pt.IsOnCurve() == 1 {
+	if isRes&(invalidSign^1)&pt.IsTorsionFree()&pt.IsOnCurve() == 1 {
 		return pt, nil
 	} else {
 		return nil, fmt.Errorf("invalid point")
@@ -78,61 +85,63 @@ func (e *EdwardsPoint) SetIdentity() *EdwardsPoint {
 	return e
 }
 
+// SetGenerator sets the point to the RFC 8032 base point.
 func (e *EdwardsPoint) SetGenerator() *EdwardsPoint {
-	e.X.SetRaw(&[PointLimbs]uint64{
-		0x6d35bf93b17aa383,
-		0x65fab7bc2914f8fe,
-		0x7e9b28e44cd37ab7,
-		0x9be886a7f2ed152a,
-		0xc7295e6eb444d6fb,
-		0x6ef0905d88b9ee96,
-		0x420685f0ea8836d1,
+	e.X.SetLimbs(&[PointLimbs]uint64{
+		0x2626a82bc70cc05e,
+		0x433b80e18b00938e,
+		0x12ae1af72ab66511,
+		0xea6de324a3d3a464,
+		0x9e146570470f1767,
+		0x221d15a622bf36da,
+		0x4f1970c66bed0ded,
 	})
-	e.Y.SetRaw(&[PointLimbs]uint64{
-		0x04ac119c79a99632,
-		0x5486da8e9ac23c21,
-		0xa98abb416ef259fc,
-		0x012232506ee00948,
-		0xe6acaf94714fa9dd,
-		0xf7687a33ab50a1f0,
-		0xd81f4fba18417765,
+	e.Y.SetLimbs(&[PointLimbs]uint64{
+		0x9808795bf230fa14,
+		0xfdbd132c4ed7c8ad,
+		0x3ad3ff1ce67c39c4,
+		0x87789c1e05a0c2d7,
+		0x4bea73736ca39840,
+		0x8876203756c9c762,
+		0x693f46716eb6bc24,
 	})
 	e.Z.SetOne()
-	e.T.SetRaw(&[PointLimbs]uint64{
-		0x2a8ab420e386ac5c,
-		0x481d32474a6b9736,
-		0xdf9bfafd84761acf,
-		0x445dc2c4a99422e3,
-		0x0f71014e6a0f05f3,
-		0x5339b7fc98aac411,
-		0x70f2d86ecdbe176a,
+	e.T.SetLimbs(&[PointLimbs]uint64{
+		0xeb06624e82af95f3,
+		0xf78fa07d85662d1d,
+		0xf179de90b5b27da1,
+		0x60d71667e2356d58,
+		0xc5056a183f8451d2,
+		0xcec39d2d508d91c9,
+		0xc75eb58aee221c6c,
 	})
 	return e
 }
 
 func (e *EdwardsPoint) IsIdentityI() int {
-	return e.X.IsZero() & e.Y.IsOne() & e.Z.IsOne() & e.T.IsZero()
+	return e.X.IsZero() & e.Y.EqualI(e.Z)
 }
 
 func (e *EdwardsPoint) IsOnCurve() int {
 	xy := FpNew().Mul(e.X, e.Y)
 	zt := FpNew().Mul(e.Z, e.T)
 
-	// Y^2 + X^2 == Z^2 - T^2 * D
+	// Y^2 + X^2 == Z^2 + T^2 * D
 
 	yy := FpNew().Square(e.Y)
 	xx := FpNew().Square(e.X)
 	zz := FpNew().Square(e.Z)
 	tt := FpNew().Square(e.T)
 	lhs := FpNew().Add(yy, xx)
-	rhs := FpNew().Add(zz, tt)
-	rhs.Mul(rhs, edwardsD)
+	rhs := FpNew().Mul(tt, edwardsD)
+	rhs.Add(rhs, zz)
 
-	return xy.EqualI(zt) & lhs.EqualI(rhs)
+	return xy.EqualI(zt) & lhs.EqualI(rhs) & e.Z.IsNonZero()
 }
 
+// IsTorsionFree returns 1 if the point is in the prime order subgroup
 func (e *EdwardsPoint) IsTorsionFree() int {
-	return new(EdwardsPoint).Mul(e, basePointOrder).IsIdentityI()
+	return EdwardsPointNew().mulBytes(e, &basePointOrderBytes).IsIdentityI()
 }
 
 func (e *EdwardsPoint) Set(rhs *EdwardsPoint) *EdwardsPoint {
@@ -153,6 +162,8 @@ func (e *EdwardsPoint) EqualI(rhs *EdwardsPoint) int {
 	return xz.EqualI(zx) & yz.EqualI(zy)
 }
 
+// Add computes arg1 + arg2 using the complete
+// extended coordinate formulas from Hisil–Wong–Carter–Dawson 2008.
 func (e *EdwardsPoint) Add(arg1, arg2 *EdwardsPoint) *EdwardsPoint {
 	tmp := FpNew().Mul(arg1.Y, arg2.X)
 	xyXY := FpNew().Mul(arg1.X, arg2.Y)
@@ -178,7 +189,17 @@ func (e *EdwardsPoint) Add(arg1, arg2 *EdwardsPoint) *EdwardsPoint {
 	tmp.Add(zz, dTT)
 	z := FpNew().Sub(zz, dTT)
 	z.Mul(z, tmp)
-	return &EdwardsPoint{x, y, z, t}
+
+	e.X.Set(x)
+	e.Y.Set(y)
+	e.Z.Set(z)
+	e.T.Set(t)
+	return e
+}
+
+// Sub computes arg1 - arg2
+func (e *EdwardsPoint) Sub(arg1, arg2 *EdwardsPoint) *EdwardsPoint {
+	return e.Add(arg1, EdwardsPointNew().Negate(arg2))
 }
 
 func (e *EdwardsPoint) Double(arg *EdwardsPoint) *EdwardsPoint {
@@ -186,67 +207,150 @@ func (e *EdwardsPoint) Double(arg *EdwardsPoint) *EdwardsPoint {
 }
 
 func (e *EdwardsPoint) Negate(arg *EdwardsPoint) *EdwardsPoint {
-	return &EdwardsPoint{
-		X: FpNew().Neg(arg.X),
-		Y: FpNew().Set(arg.Y),
-		Z: FpNew().Set(arg.Z),
-		T: FpNew().Neg(arg.T),
-	}
+	e.X.Neg(arg.X)
+	e.Y.Set(arg.Y)
+	e.Z.Set(arg.Z)
+	e.T.Neg(arg.T)
+	return e
 }
 
+// Mul computes arg * s in constant time
 func (e *EdwardsPoint) Mul(arg *EdwardsPoint, s *Fq) *EdwardsPoint {
-	ss := FqNew().Div4(s)
-	e.VariableBase(arg.ToTwisted(), ss).ToUntwisted()
-	return e.Add(e, arg).scalarMod4(e, s)
+	bytes := s.Bytes()
+	return e.mulBytes(arg, &bytes)
+}
+
+// mulBytes multiplies arg by the little endian integer in s
+// using a fixed 4-bit window with constant time table lookups
+func (e *EdwardsPoint) mulBytes(arg *EdwardsPoint, s *[PointBytes]byte) *EdwardsPoint {
+	precomputed := edwardsTable(arg)
+	r := EdwardsPointNew().SetIdentity()
+	t := EdwardsPointNew()
+	for i := PointBytes*2 - 1; i >= 0; i-- {
+		r.Double(r)
+		r.Double(r)
+		r.Double(r)
+		r.Double(r)
+
+		window := int(s[i>>1]>>((i&1)<<2)) & 0xf
+		lookupEdwards(t, &precomputed, window)
+		r.Add(r, t)
+	}
+	return e.Set(r)
 }
 
-func (e *EdwardsPoint) scalarMod4(arg *EdwardsPoint, s *Fq) *EdwardsPoint {
-	ss := FqNew().fromMontgomery(s)
-	sMod4 := ss.Value.Value[0] & 3
+// edwardsTable returns the multiples 0*arg through 15*arg.
+func edwardsTable(arg *EdwardsPoint) [16]*EdwardsPoint {
+	var precomputed [16]*EdwardsPoint
+	precomputed[0] = EdwardsPointNew().SetIdentity()
+	precomputed[1] = EdwardsPointNew().Set(arg)
+	for i := 2; i < 16; i += 2 {
+		precomputed[i] = EdwardsPointNew().Double(precomputed[i>>1])
+		precomputed[i+1] = EdwardsPointNew().Add(precomputed[i], arg)
+	}
+	return precomputed
+}
 
-	zeroP := EdwardsPointNew().SetIdentity()
-	twoP := EdwardsPointNew().Double(arg)
-	threeP := EdwardsPointNew().Add(twoP, arg)
+// lookupEdwards sets out to table[window] in constant time
+// by conditionally moving every entry of the table.
+func lookupEdwards(out *EdwardsPoint, table *[16]*EdwardsPoint, window int) {
+	out.SetIdentity()
+	for j := 1; j < 16; j++ {
+		out.CMove(out, table[j], internal.IsZeroI(j-window))
+	}
+}
 
-	isZero := internal.IsZeroUint64I(sMod4)
-	isOne := internal.IsZeroUint64I(sMod4 - 1)
-	isTwo := internal.IsZeroUint64I(sMod4 - 2)
-	isThree := internal.IsZeroUint64I(sMod4 - 3)
+// SumOfProducts computes the multi-exponentiation for the specified
+// points and scalars and stores the result in `e`.
+// Returns an error if the lengths of the arguments is not equal.
+// The windows of all scalars are processed together and each lookup
+// scans the whole table so it is safe for secret scalars.
+// Use SumOfProductsVarTime when all the scalars are public.
+func (e *EdwardsPoint) SumOfProducts(points []*EdwardsPoint, scalars []*Fq) (*EdwardsPoint, error) {
+	if len(points) != len(scalars) {
+		return nil, fmt.Errorf("length mismatch")
+	}
 
-	e.CMove(e, zeroP, isZero)
-	e.CMove(e, arg, isOne)
-	e.CMove(e, twoP, isTwo)
-	e.CMove(e, threeP, isThree)
-	return e
-}
+	tables := make([][16]*EdwardsPoint, len(points))
+	bytes := make([][PointBytes]byte, len(scalars))
+	for i, scalar := range scalars {
+		tables[i] = edwardsTable(points[i])
+		bytes[i] = scalar.Bytes()
+	}
+
+	r := EdwardsPointNew().SetIdentity()
+	t := EdwardsPointNew()
+	for i := PointBytes*2 - 1; i >= 0; i-- {
+		r.Double(r)
+		r.Double(r)
+		r.Double(r)
+		r.Double(r)
+
+		for k := range tables {
+			window := int(bytes[k][i>>1]>>((i&1)<<2)) & 0xf
+			lookupEdwards(t, &tables[k], window)
+			r.Add(r, t)
+		}
+	}
+	return e.Set(r), nil
+}
+
+// SumOfProductsVarTime computes the multi-exponentiation for the specified
+// points and scalars and stores the result in `e` using buckets indexed by
+// the scalar windows. It must only be used with public scalars.
+// Returns an error if the lengths of the arguments is not equal.
+func (e *EdwardsPoint) SumOfProductsVarTime(points []*EdwardsPoint, scalars []*Fq) (*EdwardsPoint, error) {
+	const Upper = 456
+	const W = 4
+	const Windows = Upper / W
+	if len(points) != len(scalars) {
+		return nil, fmt.Errorf("length mismatch")
+	}
 
-func (e *EdwardsPoint) VariableBase(arg *TwistedExtendedPoint, s *Fq) *TwistedExtendedPoint {
-	result := TwistedExtensiblePointNew().SetIdentity()
+	bucketSize := 1 << W
+	windows := make([]*EdwardsPoint, Windows)
+	bytes := make([][PointBytes]byte, len(scalars))
+	buckets := make([]*EdwardsPoint, bucketSize)
 
-	// Recode Scalar
-	scalar := s.ToRadix16()
+	for i, scalar := range scalars {
+		bytes[i] = scalar.Bytes()
+	}
+	for i := range windows {
+		windows[i] = EdwardsPointNew().SetIdentity()
+	}
+	for i := 0; i < bucketSize; i++ {
+		buckets[i] = EdwardsPointNew().SetIdentity()
+	}
 
-	lookup := FromTwistedExtendedPoint(arg)
+	sum := EdwardsPointNew()
 
-	for i := 113; i >= 0; i-- {
-		result.Double(result)
-		result.Double(result)
-		result.Double(result)
-		result.Double(result)
+	for j := 0; j < len(windows); j++ {
+		for i := 0; i < bucketSize; i++ {
+			buckets[i].SetIdentity()
+		}
 
-		// The mask is the top bit, will be 1 for negative numbers, 0 for positive numbers
-		mask := scalar[i] >> 7
-		sign := mask & 0x1
-		// Use the mask to get the absolute value of scalar
-		absValue := uint32((scalar[i] + mask) ^ mask)
+		for i := 0; i < len(scalars); i++ {
+			index := bytes[i][j*W>>3] >> (W * j & W) & (1<<W - 1) // little-endian
+			buckets[index].Add(buckets[index], points[i])
+		}
 
-		negP := lookup.Select(absValue)
-		negP.CNeg(negP, int(sign))
+		sum.SetIdentity()
 
-		result.AddProjectiveNiels(result, negP)
+		for i := bucketSize - 1; i > 0; i-- {
+			sum.Add(sum, buckets[i])
+			windows[j].Add(windows[j], sum)
+		}
 	}
 
-	return result.ToExtended()
+	e.SetIdentity()
+	for i := len(windows) - 1; i >= 0; i-- {
+		for j := 0; j < W; j++ {
+			e.Double(e)
+		}
+
+		e.Add(e, windows[i])
+	}
+	return e, nil
 }
 
 func (e *EdwardsPoint) Torque(arg *EdwardsPoint) *EdwardsPoint {
@@ -296,12 +400,45 @@ func (e *EdwardsPoint) Isogeny(a *Fp) *TwistedExtendedPoint {
 }
 
 func (e *EdwardsPoint) ToAffine() *AffinePoint {
+	// Points from BatchNormalize need no inversion
+	if e.Z.IsOne() == 1 {
+		return &AffinePoint{FpNew().Set(e.X), FpNew().Set(e.Y)}
+	}
 	z, _ := FpNew().Invert(e.Z)
 	x := FpNew().Mul(e.X, z)
 	y := FpNew().Mul(e.Y, z)
 	return &AffinePoint{x, y}
 }
 
+// BatchNormalize returns the points scaled so Z = 1 using Montgomery's
+// trick to share a single field inversion between them.
+func BatchNormalize(points []*EdwardsPoint) []*EdwardsPoint {
+	out := make([]*EdwardsPoint, len(points))
+	if len(points) == 0 {
+		return out
+	}
+	// acc[i] is the product of the z coordinates before i
+	acc := make([]*Fp, len(points))
+	t := FpNew().SetOne()
+	for i, p := range points {
+		acc[i] = FpNew().Set(t)
+		t.Mul(t, p.Z)
+	}
+	inv, _ := FpNew().Invert(t)
+	for i := len(points) - 1; i >= 0; i-- {
+		p := points[i]
+		zInv := FpNew().Mul(inv, acc[i])
+		inv.Mul(inv, p.Z)
+
+		out[i] = EdwardsPointNew()
+		out[i].X.Mul(p.X, zInv)
+		out[i].Y.Mul(p.Y, zInv)
+		out[i].Z.SetOne()
+		out[i].T.Mul(out[i].X, out[i].Y)
+	}
+	return out
+}
+
 func (e *EdwardsPoint) ToMontgomery() *MontgomeryPoint {
 	// u = y^2 * [(1-dy^2)/(1-y^2)]
 
@@ -398,7 +535,7 @@ func (a *AffinePoint) isogeny() *AffinePoint {
 
 	t4 := FpNew().Mul(t0, a.Y) // y(x^2-1)
 	t4.Double(t4)              // 2y(x^2-1)
-	xNum := FpNew().Double(t3) // xNum = 4y(x^2-1)
+	xNum := FpNew().Double(t4) // xNum = 4y(x^2-1)
 
 	t5 := FpNew().Square(t0)    // x^4-2x^2+1
 	t4.Add(t5, t2)              // x^4-2x^2+1+2y^2
@@ -411,10 +548,14 @@ func (a *AffinePoint) isogeny() *AffinePoint {
 	t4.Mul(t1, t2)              // 2x^2y^2+2y^2
 	yDen := FpNew().Sub(t5, t4) // yDen = x^5-2x^3+x-2x^2y^2-2y^2
 
+	// The exceptional case maps to the identity
+	isIdentity := FpNew().Mul(xDen, yDen).IsZero()
 	_, _ = xDen.Invert(xDen)
 	_, _ = yDen.Invert(yDen)
 	a.X.Mul(xNum, xDen)
 	a.Y.Mul(yNum, yDen)
+	a.X.CMove(a.X, zero, isIdentity)
+	a.Y.CMove(a.Y, one, isIdentity)
 	return a
 }
 
@@ -500,34 +641,49 @@ func (m *MontgomeryPoint) IsLowOrder() int {
 		subtle.ConstantTimeCompare(m[:], MontgomeryPointLowC[:])
 }
 
+// Mul multiplies this point by a reduced scalar.
 func (m *MontgomeryPoint) Mul(scalar *Fq) *MontgomeryPoint {
+	var k [56]byte
+	b := scalar.Bytes()
+	copy(k[:], b[:])
+	return m.MulBytes(&k)
+}
+
+// MulBytes multiplies this point by the little endian scalar k
+// without reducing it. This is the ladder used by X448 in RFC 7748.
+func (m *MontgomeryPoint) MulBytes(k *[56]byte) *MontgomeryPoint {
 	// Algorithm 8 of Costello-Smith 2017
-	affineU, err := FpNew().SetCanonicalBytes((*[56]byte)(m))
-	if err != nil {
-		return nil
-	}
+	affineU := m.toFp()
 
 	x0 := NewProjectiveMontgomeryPoint().SetIdentity()
 	x1 := &ProjectiveMontgomeryPoint{
-		U: affineU,
+		U: FpNew().Set(affineU),
 		W: FpNew().SetOne(),
 	}
 
-	bits := internal.ReverseBytes(scalar.Value.Bytes())
 	swap := 0
-	for _, s := range bits {
-		for i := 7; i >= 0; i-- {
-			bit := int((s >> i) & 1)
+	for i := len(k) - 1; i >= 0; i-- {
+		for j := 7; j >= 0; j-- {
+			bit := int((k[i] >> j) & 1)
 			choice := swap ^ bit
 			(&ProjectiveMontgomeryPoint{}).CSwap(x0, x1, choice)
 			(&ProjectiveMontgomeryPoint{}).DifferentialAddAndDouble(x0, x1, affineU)
 			swap = bit
 		}
 	}
+	(&ProjectiveMontgomeryPoint{}).CSwap(x0, x1, swap)
 
 	return x0.ToAffine()
 }
 
+// toFp decodes the u-coordinate, reducing values that are not canonical
+// as required by RFC 7748.
+func (m *MontgomeryPoint) toFp() *Fp {
+	var wide [112]byte
+	copy(wide[:], m[:])
+	return FpNew().SetBytesWide(&wide)
+}
+
 func (m *MontgomeryPoint) Bytes() []byte {
 	out := make([]byte, len(*m))
 	copy(out, m[:])
@@ -535,8 +691,7 @@ func (m *MontgomeryPoint) Bytes() []byte {
 }
 
 func (m *MontgomeryPoint) ToProjective() *ProjectiveMontgomeryPoint {
-	u, _ := FpNew().SetCanonicalBytes((*[56]byte)(m))
-	return &ProjectiveMontgomeryPoint{U: u, W: FpNew().SetOne()}
+	return &ProjectiveMontgomeryPoint{U: m.toFp(), W: FpNew().SetOne()}
 }
 
 type ProjectiveMontgomeryPoint struct {
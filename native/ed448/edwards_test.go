@@ -1,9 +1,13 @@
 package ed448
 
 import (
+	crand "crypto/rand"
 	"encoding/hex"
 	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+	"math/big"
 	"testing"
 )
 
@@ -16,4 +20,127 @@ func TestEdwardsPoint_IsOnCurve(t *testing.T) {
 
 	gen := (&AffinePoint{x, y}).ToEdwards()
 	require.Equal(t, 1, gen.IsOnCurve())
+	require.Equal(t, 1, EdwardsPointNew().SetGenerator().IsOnCurve())
+	require.Equal(t, 1, EdwardsPointNew().SetGenerator().IsTorsionFree())
+}
+
+func TestEdwardsPoint_Arithmetic(t *testing.T) {
+	g := EdwardsPointNew().SetGenerator()
+	two := EdwardsPointNew().Double(g)
+	three := EdwardsPointNew().Add(two, g)
+	require.Equal(t, 1, three.IsOnCurve())
+	require.Equal(t, 1, EdwardsPointNew().Mul(g, FqNew().SetUint64(3)).EqualI(three))
+	require.Equal(t, 1, EdwardsPointNew().Sub(three, two).EqualI(g))
+	require.Equal(t, 1, EdwardsPointNew().Add(g, EdwardsPointNew().Negate(g)).IsIdentityI())
+	require.Equal(t, 1, EdwardsPointNew().Mul(g, FqNew().SetZero()).IsIdentityI())
+	minusOneQ := FqNew().Neg(FqNew().SetOne())
+	require.Equal(t, 1, EdwardsPointNew().Mul(g, minusOneQ).EqualI(EdwardsPointNew().Negate(g)))
+}
+
+func TestEdwardsPoint_SumOfProducts(t *testing.T) {
+	points := make([]*EdwardsPoint, 4)
+	scalars := make([]*Fq, 4)
+	expected := EdwardsPointNew().SetIdentity()
+	for i := range points {
+		s, err := FqNew().Random(crand.Reader)
+		require.NoError(t, err)
+		scalars[i] = s
+		points[i] = EdwardsPointNew().Mul(EdwardsPointNew().SetGenerator(), FqNew().SetUint64(uint64(i+2)))
+		expected.Add(expected, EdwardsPointNew().Mul(points[i], scalars[i]))
+	}
+
+	actual, err := EdwardsPointNew().SumOfProducts(points, scalars)
+	require.NoError(t, err)
+	require.Equal(t, 1, expected.EqualI(actual))
+	actual, err = EdwardsPointNew().SumOfProductsVarTime(points, scalars)
+	require.NoError(t, err)
+	require.Equal(t, 1, expected.EqualI(actual))
+
+	_, err = EdwardsPointNew().SumOfProducts(points, scalars[1:])
+	require.Error(t, err)
+}
+
+func TestEdwardsPoint_Rfc8032PublicKey(t *testing.T) {
+	// RFC 8032 §7.4 test vectors
+	tests := []struct{ sk, pk string }{
+		{
+			"6c82a562cb808d10d632be89c8513ebf6c929f34ddfa8c9f63c9960ef6e348a3528c8a3fcc2f044e39a3fc5b94492f8f032e7549a20098f95b",
+			"5fd7449b59b461fd2ce787ec616ad46a1da1342485a70e1f8a0ea75d80e96778edf124769b46c7061bd6783df1e50f6cd1fa1abeafe8256180",
+		},
+		{
+			"c4eab05d357007c632f3dbb48489924d552b08fe0c353a0d4a1f00acda2c463afbea67c5e8d2877c5e3bc397a659949ef8021e954e0a12274e",
+			"43ba28f430cdff456ae531545f7ecd0ac834a55d9358c0372bfa0c6c6798c0866aea01eb00742802b8438ea4cb82169c235160627b4c3a9480",
+		},
+	}
+	for _, tc := range tests {
+		sk, _ := hex.DecodeString(tc.sk)
+		var h [114]byte
+		sha3.ShakeSum256(h[:], sk)
+		h[0] &= 0xfc
+		h[56] = 0
+		h[55] |= 0x80
+		s := new(big.Int).SetBytes(internal.ReverseBytes(h[:57]))
+		pk := EdwardsPointNew().Mul(EdwardsPointNew().SetGenerator(), FqNew().SetBigInt(s)).Compress()
+		require.Equal(t, tc.pk, hex.EncodeToString(pk[:]))
+
+		pt, err := pk.Decompress()
+		require.NoError(t, err)
+		require.Equal(t, pk, pt.Compress())
+	}
+}
+
+func TestEdwardsPoint_DecompressInvalid(t *testing.T) {
+	var c CompressedEdwardsY
+	// y = 1, x = 0 with the sign bit set
+	c[0] = 1
+	c[56] = 0x80
+	_, err := c.Decompress()
+	require.Error(t, err)
+	c[56] = 0
+	pt, err := c.Decompress()
+	require.NoError(t, err)
+	require.Equal(t, 1, pt.IsIdentityI())
+	// y = p is not canonical
+	for i := range c {
+		c[i] = 0xff
+	}
+	c[28] = 0xfe
+	c[56] = 0
+	_, err = c.Decompress()
+	require.Error(t, err)
+}
+
+func TestEdwardsPoint_Hash(t *testing.T) {
+	// RFC 9380 J.5.1 edwards448_XOF:SHAKE256_ELL2_RO_
+	dst := []byte("QUUX-V01-CS02-with-edwards448_XOF:SHAKE256_ELL2_RO_")
+	tests := []struct{ msg, x, y string }{
+		{
+			"",
+			"73036d4a88949c032f01507005c133884e2f0d81f9a950826245dda9e844fc78186c39daaa7147ead3e462cff60e9c6340b58134480b4d17",
+			"94c1d61b43728e5d784ef4fcb1f38e1075f3aef5e99866911de5a234f1aafdc26b554344742e6ba0420b71b298671bbeb2b7736618634610",
+		},
+		{
+			"abc",
+			"4e0158acacffa545adb818a6ed8e0b870e6abc24dfc1dc45cf9a052e98469275d9ff0c168d6a5ac7ec05b742412ee090581f12aa398f9f8c",
+			"894d3fa437b2d2e28cdc3bfaade035430f350ec5239b6b406b5501da6f6d6210ff26719cad83b63e97ab26a12df6dec851d6bf38e294af9a",
+		},
+	}
+	for _, tc := range tests {
+		pt := EdwardsPointNew().Hash(native.EllipticPointHasherShake256(), []byte(tc.msg), dst)
+		require.Equal(t, 1, pt.IsOnCurve())
+		affine := pt.ToAffine()
+		require.Equal(t, tc.x, affine.X.BigInt().Text(16))
+		require.Equal(t, tc.y, affine.Y.BigInt().Text(16))
+	}
+}
+
+func TestMontgomeryPoint_Mul(t *testing.T) {
+	g := EdwardsPointNew().SetGenerator()
+	u := new(MontgomeryPoint).SetGenerator()
+	require.Equal(t, 1, g.ToMontgomery().EqualI(u))
+	for _, k := range []uint64{1, 2, 3, 7, 1000, 1001} {
+		s := FqNew().SetUint64(k)
+		expected := EdwardsPointNew().Mul(g, s).ToMontgomery()
+		require.Equal(t, 1, u.Mul(s).EqualI(expected))
+	}
 }
@@ -1,6 +1,7 @@
 package ed448
 
 import (
+	"crypto/subtle"
 	"fmt"
 	"github.com/mikelodder7/curvey/internal"
 	"github.com/mikelodder7/curvey/native"
@@ -140,9 +141,9 @@ func (f *Fp) Random(reader io.Reader) (*Fp, error) {
 
 func (f *Fp) Hash(input []byte) *Fp {
 	dst := []byte("edwards448_XOF:SHAKE256_RO_")
-	xof := native.ExpandMsgXof(native.EllipticPointHasherShake256(), input, dst, 88)
+	xof := native.ExpandMsgXof(native.EllipticPointHasherShake256(), input, dst, 84)
 	var t [112]byte
-	copy(t[:], xof[:])
+	copy(t[:], internal.ReverseBytes(xof))
 	return f.SetBytesWide(&t)
 }
 
@@ -206,8 +207,8 @@ func (f *Fp) Sqrt(a *Fp) (*Fp, int) {
 // Invert performs modular inverse.
 func (f *Fp) Invert(a *Fp) (*Fp, int) {
 	// Exponentiate by p - 2
-	exp := new(internal.Field).Init(f.Value.Params, f.Value.Arithmetic)
-	copy(exp.Value, []uint64{
+	t := new(internal.Field).Init(f.Value.Params, f.Value.Arithmetic)
+	internal.Pow(&t.Value, a.Value.Value, []uint64{
 		0xfffffffffffffffd,
 		0xffffffffffffffff,
 		0xffffffffffffffff,
@@ -215,22 +216,26 @@ func (f *Fp) Invert(a *Fp) (*Fp, int) {
 		0xffffffffffffffff,
 		0xffffffffffffffff,
 		0xffffffffffffffff,
-	})
-	f.Value.Exp(a.Value, exp)
+	}, a.Value.Params, a.Value.Arithmetic)
 	wasInverted := a.IsNonZero()
-	f.Value.CMove(a.Value, f.Value, wasInverted)
+	f.Value.CMove(a.Value, t, wasInverted)
 	return f, wasInverted
 }
 
 // SetCanonicalBytes converts a little endian byte array into a field element
 // returns nil if the bytes are not in the field
 func (f *Fp) SetCanonicalBytes(arg *[56]byte) (*Fp, error) {
-	_, err := f.Value.SetBytes(arg[:])
-
+	t := FpNew()
+	_, err := t.Value.SetBytes(arg[:])
 	if err != nil {
 		return nil, err
 	}
-	return f, nil
+	// Values >= p are reduced so won't round trip
+	out := t.Bytes()
+	if subtle.ConstantTimeCompare(out[:], arg[:]) != 1 {
+		return nil, fmt.Errorf("invalid field element")
+	}
+	return f.Set(t), nil
 }
 
 // SetBytes converts a little endian byte array into a field element
@@ -297,7 +302,7 @@ func (f *Fp) BigInt() *big.Int {
 
 // Raw converts this element into the a []uint64.
 func (f *Fp) Raw() []uint64 {
-	var t []uint64
+	t := make([]uint64, f.Value.Params.Limbs)
 	f.Value.Arithmetic.FromMontgomery(&t, &f.Value.Value)
 	return t
 }
@@ -311,9 +316,8 @@ func (f *Fp) CMove(arg1, arg2 *Fp, choice int) *Fp {
 
 // CNeg conditionally negates a if choice == 1.
 func (f *Fp) CNeg(a *Fp, choice int) *Fp {
-	var t Fp
-	t.Neg(a)
-	return f.CMove(f, &t, choice)
+	t := FpNew().Neg(a)
+	return f.CMove(a, t, choice)
 }
 
 // CSwap conditionally swaps this with a if choice == 1
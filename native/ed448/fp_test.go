@@ -0,0 +1,53 @@
+package ed448
+
+import (
+	crand "crypto/rand"
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/stretchr/testify/require"
+	"math/big"
+	"testing"
+)
+
+func TestFp_Arithmetic(t *testing.T) {
+	p := fpParams.BiModulus
+	pm1 := new(big.Int).Sub(p, big.NewInt(1))
+	for i := 0; i < 100; i++ {
+		a, _ := crand.Int(crand.Reader, p)
+		b, _ := crand.Int(crand.Reader, p)
+		if i == 0 {
+			a.Set(pm1)
+			b.Set(pm1)
+		}
+		fa := FpNew().SetBigInt(a)
+		fb := FpNew().SetBigInt(b)
+
+		require.Equal(t, 0, new(big.Int).Mod(new(big.Int).Add(a, b), p).Cmp(FpNew().Add(fa, fb).BigInt()))
+		require.Equal(t, 0, new(big.Int).Mod(new(big.Int).Sub(a, b), p).Cmp(FpNew().Sub(fa, fb).BigInt()))
+		require.Equal(t, 0, new(big.Int).Mod(new(big.Int).Mul(a, b), p).Cmp(FpNew().Mul(fa, fb).BigInt()))
+		require.Equal(t, 0, new(big.Int).Mod(new(big.Int).Mul(a, a), p).Cmp(FpNew().Square(fa).BigInt()))
+		require.Equal(t, 0, new(big.Int).Mod(new(big.Int).Neg(a), p).Cmp(FpNew().Neg(fa).BigInt()))
+		inv, wasInverted := FpNew().Invert(fa)
+		require.Equal(t, 1, wasInverted)
+		require.Equal(t, 0, new(big.Int).ModInverse(a, p).Cmp(inv.BigInt()))
+		aa := FpNew().Square(fa)
+		s, wasSquare := FpNew().Sqrt(aa)
+		require.Equal(t, 1, wasSquare)
+		require.Equal(t, 1, FpNew().Square(s).EqualI(aa))
+
+		var wide [112]byte
+		_, _ = crand.Read(wide[:])
+		w := new(big.Int).SetBytes(internal.ReverseBytes(wide[:]))
+		require.Equal(t, 0, w.Mod(w, p).Cmp(FpNew().SetBytesWide(&wide).BigInt()))
+	}
+}
+
+func TestFp_SetCanonicalBytes(t *testing.T) {
+	var b [56]byte
+	copy(b[:], internal.ReverseBytes(fpParams.BiModulus.Bytes()))
+	_, err := FpNew().SetCanonicalBytes(&b)
+	require.Error(t, err)
+	b[0]--
+	f, err := FpNew().SetCanonicalBytes(&b)
+	require.NoError(t, err)
+	require.Equal(t, 1, f.EqualI(minusOne))
+}
@@ -1,6 +1,7 @@
 package ed448
 
 import (
+	"crypto/subtle"
 	"fmt"
 	"github.com/mikelodder7/curvey/internal"
 	"github.com/mikelodder7/curvey/native"
@@ -77,26 +78,26 @@ var (
 		Params:     &fqParams,
 		Arithmetic: &fqParams,
 		Value: []uint64{
-			0xcfc4ab375748160e,
-			0x94a6ad0ec8bca90e,
-			0xad3ee487a7fad19e,
-			0xd563c394eff143eb,
-			0x3bc2a3bd7cad3c2a,
-			0x141729a49275b4ac,
-			0x3656fbc6dba69c9d,
+			0xdc873d6d54a7bb0d,
+			0xde933d8d723a70aa,
+			0x3bb124b65129c96f,
+			0x000000008335dc16,
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
 		},
 	}
 	oneHalf = internal.Field{
 		Params:     &fqParams,
 		Arithmetic: &fqParams,
 		Value: []uint64{
-			0x2ca820fc26068e5d,
-			0xcf326895c48ce9e6,
-			0x635992a860009b38,
-			0x5a3dcaf93803b1d9,
-			0x72dfa400d114a36b,
-			0x63429040b4ceb5bc,
-			0x346bc81445a1b1ed,
+			0xb90e7adaa94f761a,
+			0xbd267b1ae474e155,
+			0x7762496ca25392df,
+			0x00000001066bb82c,
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
 		},
 	}
 )
@@ -172,7 +173,7 @@ func (f *Fq) Random(reader io.Reader) (*Fq, error) {
 
 func (f *Fq) Hash(input []byte) *Fq {
 	dst := []byte("edwards448_XOF:SHAKE256_RO_")
-	xof := native.ExpandMsgXof(native.EllipticPointHasherShake256(), input, dst, 88)
+	xof := native.ExpandMsgXof(native.EllipticPointHasherShake256(), input, dst, 114)
 	var t [114]byte
 	copy(t[:], xof[:])
 	return f.SetBytesWide(&t)
@@ -227,8 +228,7 @@ func (f *Fq) Sqrt(a *Fq) (*Fq, int) {
 	// so check the result at the end.
 	z := new(internal.Field).Init(f.Value.Params, f.Value.Arithmetic)
 	c := new(internal.Field).Init(f.Value.Params, f.Value.Arithmetic)
-	exp := new(internal.Field).Init(f.Value.Params, f.Value.Arithmetic)
-	copy(exp.Value, []uint64{
+	internal.Pow(&z.Value, a.Value.Value, []uint64{
 		0x48de30a4aad6113d,
 		0x085b309ca37163d5,
 		0x7113b6d26bb58da4,
@@ -236,8 +236,7 @@ func (f *Fq) Sqrt(a *Fq) (*Fq, int) {
 		0xffffffffffffffff,
 		0xffffffffffffffff,
 		0x0fffffffffffffff,
-	})
-	z.Exp(a.Value, exp)
+	}, a.Value.Params, a.Value.Arithmetic)
 
 	c.Square(z)
 	wasSquare := c.EqualI(a.Value)
@@ -247,20 +246,19 @@ func (f *Fq) Sqrt(a *Fq) (*Fq, int) {
 
 // Invert performs modular inverse.
 func (f *Fq) Invert(a *Fq) (*Fq, int) {
-	// Exponentiate by p - 2
-	exp := new(internal.Field).Init(f.Value.Params, f.Value.Arithmetic)
-	copy(exp.Value, []uint64{
-		0xfffffffffffffffd,
+	// Exponentiate by q - 2
+	t := new(internal.Field).Init(f.Value.Params, f.Value.Arithmetic)
+	internal.Pow(&t.Value, a.Value.Value, []uint64{
+		0x2378c292ab5844f1,
+		0x216cc2728dc58f55,
+		0xc44edb49aed63690,
+		0xffffffff7cca23e9,
 		0xffffffffffffffff,
 		0xffffffffffffffff,
-		0xfffffffeffffffff,
-		0xffffffffffffffff,
-		0xffffffffffffffff,
-		0xffffffffffffffff,
-	})
-	f.Value.Exp(a.Value, exp)
+		0x3fffffffffffffff,
+	}, a.Value.Params, a.Value.Arithmetic)
 	wasInverted := a.IsNonZero()
-	f.Value.CMove(a.Value, f.Value, wasInverted)
+	f.Value.CMove(a.Value, t, wasInverted)
 	return f, wasInverted
 }
 
@@ -304,13 +302,17 @@ func (f *Fq) ToRadix16() []byte {
 // SetBytes converts a little endian byte array into a field element
 // return 0 if the bytes are not in the field, 1 if they are.
 func (f *Fq) SetBytes(arg *[57]byte) (*Fq, error) {
-	_, err := f.Value.SetBytes(arg[:56])
-
-	// Check that the 10 high bits are not set
-	if err != nil || arg[56] != 0 || (arg[55]>>6 != 0) {
+	t := FqNew()
+	_, err := t.Value.SetBytes(arg[:56])
+	if err != nil {
 		return nil, err
 	}
-	return f, nil
+	// Values >= q are reduced so won't round trip
+	out := t.Bytes()
+	if subtle.ConstantTimeCompare(out[:], arg[:]) != 1 {
+		return nil, fmt.Errorf("invalid scalar")
+	}
+	return f.Set(t), nil
 }
 
 // SetBytesWide takes 112 bytes as input and treats them as a 896-bit number.
@@ -401,7 +403,7 @@ func (f *Fq) BigInt() *big.Int {
 
 // Raw converts this element into the a []uint64.
 func (f *Fq) Raw() []uint64 {
-	var t []uint64
+	t := make([]uint64, f.Value.Params.Limbs)
 	f.Value.Arithmetic.FromMontgomery(&t, &f.Value.Value)
 	return t
 }
@@ -415,9 +417,8 @@ func (f *Fq) CMove(arg1, arg2 *Fq, choice int) *Fq {
 
 // CNeg conditionally negates a if choice == 1.
 func (f *Fq) CNeg(a *Fq, choice int) *Fq {
-	var t Fq
-	t.Neg(a)
-	return f.CMove(f, &t, choice)
+	t := FqNew().Neg(a)
+	return f.CMove(a, t, choice)
 }
 
 // Exp raises base^exp.
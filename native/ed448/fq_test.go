@@ -1,8 +1,10 @@
 package ed448
 
 import (
-	"fmt"
+	crand "crypto/rand"
+	"github.com/mikelodder7/curvey/internal"
 	"github.com/stretchr/testify/require"
+	"math/big"
 	"testing"
 )
 
@@ -12,5 +14,39 @@ func TestFq_Add(t *testing.T) {
 	})
 	_, wasInverted := onehalf.Invert(onehalf)
 	require.Equal(t, wasInverted, 1)
-	fmt.Printf("%v", onehalf)
+	require.Equal(t, 1, FqNew().Halve(FqNew().SetOne()).EqualI(onehalf))
+}
+
+func TestFq_Arithmetic(t *testing.T) {
+	q := fqParams.BiModulus
+	for i := 0; i < 100; i++ {
+		a, _ := crand.Int(crand.Reader, q)
+		b, _ := crand.Int(crand.Reader, q)
+		fa := FqNew().SetBigInt(a)
+		fb := FqNew().SetBigInt(b)
+
+		require.Equal(t, 0, new(big.Int).Mod(new(big.Int).Add(a, b), q).Cmp(FqNew().Add(fa, fb).BigInt()))
+		require.Equal(t, 0, new(big.Int).Mod(new(big.Int).Sub(a, b), q).Cmp(FqNew().Sub(fa, fb).BigInt()))
+		require.Equal(t, 0, new(big.Int).Mod(new(big.Int).Mul(a, b), q).Cmp(FqNew().Mul(fa, fb).BigInt()))
+		inv, wasInverted := FqNew().Invert(fa)
+		require.Equal(t, 1, wasInverted)
+		require.Equal(t, 0, new(big.Int).ModInverse(a, q).Cmp(inv.BigInt()))
+		require.Equal(t, 1, FqNew().Double(FqNew().Double(FqNew().Div4(fa))).EqualI(fa))
+
+		var wide [114]byte
+		_, _ = crand.Read(wide[:])
+		w := new(big.Int).SetBytes(internal.ReverseBytes(wide[:]))
+		require.Equal(t, 0, w.Mod(w, q).Cmp(FqNew().SetBytesWide(&wide).BigInt()))
+	}
+}
+
+func TestFq_SetBytes(t *testing.T) {
+	var b [57]byte
+	copy(b[:], internal.ReverseBytes(fqParams.BiModulus.Bytes()))
+	_, err := FqNew().SetBytes(&b)
+	require.Error(t, err)
+	b[0]--
+	f, err := FqNew().SetBytes(&b)
+	require.NoError(t, err)
+	require.Equal(t, 1, f.EqualI(FqNew().Neg(FqNew().SetOne())))
 }
@@ -390,6 +390,37 @@ func (f *Field6) CMove(lhs, rhs *Field6, choice int) *Field6 {
 	return f
 }
 
+// BatchInvert6 replaces every element of values with its multiplicative
+// inverse using Montgomery's trick so only one field inversion is needed.
+// Zero elements are left as zero. The running time only depends on the
+// number of values.
+func BatchInvert6(values []*Field6) {
+	if len(values) == 0 {
+		return
+	}
+	var t, v, inv [Field6Limbs]uint64
+	var wasInverted int
+	arithmetic := values[0].Arithmetic
+	one := values[0].Params.R
+	// acc[i] is the product of the non-zero values before i
+	acc := make([][Field6Limbs]uint64, len(values))
+	t = one
+	for i, f := range values {
+		acc[i] = t
+		arithmetic.Selectznz(&v, &one, &f.Value, f.IsNonZero())
+		arithmetic.Mul(&t, &t, &v)
+	}
+	arithmetic.Invert(&wasInverted, &inv, &t)
+	for i := len(values) - 1; i >= 0; i-- {
+		f := values[i]
+		nonZero := f.IsNonZero()
+		arithmetic.Selectznz(&v, &one, &f.Value, nonZero)
+		arithmetic.Mul(&t, &inv, &acc[i])
+		arithmetic.Mul(&inv, &inv, &v)
+		arithmetic.Selectznz(&f.Value, &f.Value, &t, nonZero)
+	}
+}
+
 // Pow6 raises base^exp. The result is written to out.
 // Public only for convenience for some internal implementations.
 func Pow6(out, base, exp *[Field6Limbs]uint64, params *Field6Params, arithmetic Field6Arithmetic) {
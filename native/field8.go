@@ -0,0 +1,385 @@
+package native
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/mikelodder7/curvey/internal"
+)
+
+// Field8Limbs is the number of limbs needed to represent this field.
+const Field8Limbs = 8
+
+// Field8Bytes is the number of bytes needed to represent this field.
+const Field8Bytes = 64
+
+// WideField8Bytes is the number of bytes needed for safe conversion
+// to this field to avoid bias when reduced.
+const WideField8Bytes = 128
+
+// Field8 represents a field element.
+type Field8 struct {
+	// Value is the field elements value
+	Value [Field8Limbs]uint64
+	// Params are the field parameters
+	Params *Field8Params
+	// Arithmetic are the field methods
+	Arithmetic Field8Arithmetic
+}
+
+// Field8Params are the field parameters.
+type Field8Params struct {
+	// R is 2^512 mod Modulus
+	R [Field8Limbs]uint64
+	// R2 is 2^1024 mod Modulus
+	R2 [Field8Limbs]uint64
+	// R3 is 2^1536 mod Modulus
+	R3 [Field8Limbs]uint64
+	// Modulus of the field
+	Modulus [Field8Limbs]uint64
+	// Modulus as big.Int
+	BiModulus *big.Int
+}
+
+// Field8Arithmetic are the methods that can be done on a field.
+type Field8Arithmetic interface {
+	// ToMontgomery converts this field to montgomery form
+	ToMontgomery(out, arg *[Field8Limbs]uint64)
+	// FromMontgomery converts this field from montgomery form
+	FromMontgomery(out, arg *[Field8Limbs]uint64)
+	// Neg performs modular negation
+	Neg(out, arg *[Field8Limbs]uint64)
+	// Square performs modular square
+	Square(out, arg *[Field8Limbs]uint64)
+	// Mul performs modular multiplication
+	Mul(out, arg1, arg2 *[Field8Limbs]uint64)
+	// Add performs modular addition
+	Add(out, arg1, arg2 *[Field8Limbs]uint64)
+	// Sub performs modular subtraction
+	Sub(out, arg1, arg2 *[Field8Limbs]uint64)
+	// Sqrt performs modular square root
+	Sqrt(wasSquare *int, out, arg *[Field8Limbs]uint64)
+	// Invert performs modular inverse
+	Invert(wasInverted *int, out, arg *[Field8Limbs]uint64)
+	// FromBytes converts a little endian byte array into a field element
+	FromBytes(out *[Field8Limbs]uint64, arg *[Field8Bytes]byte)
+	// ToBytes converts a field element to a little endian byte array
+	ToBytes(out *[Field8Bytes]byte, arg *[Field8Limbs]uint64)
+	// Selectznz performs conditional select.
+	// selects arg1 if choice == 0 and arg2 if choice == 1
+	Selectznz(out, arg1, arg2 *[Field8Limbs]uint64, choice int)
+}
+
+// Cmp returns -1 if f < rhs
+// 0 if f == rhs
+// 1 if f > rhs.
+func (f *Field8) Cmp(rhs *Field8) int {
+	return cmp8Helper(&f.Value, &rhs.Value)
+}
+
+// cmp8Helper returns -1 if lhs < rhs
+// 0 if lhs == rhs
+// 1 if lhs > rhs.
+func cmp8Helper(lhs, rhs *[Field8Limbs]uint64) int {
+	gt := uint64(0)
+	lt := uint64(0)
+	for i := Field8Limbs - 1; i >= 0; i-- {
+		// convert to two 64-bit numbers where
+		// the leading bits are zeros and hold no meaning
+		//  so rhs - fp actually means gt
+		// and fp - rhs actually means lt.
+		rhsH := rhs[i] >> 32
+		rhsL := rhs[i] & 0xffffffff
+		lhsH := lhs[i] >> 32
+		lhsL := lhs[i] & 0xffffffff
+
+		// Check the leading bit
+		// if negative then fp > rhs
+		// if positive then fp < rhs
+		gt |= (rhsH - lhsH) >> 32 & 1 &^ lt
+		lt |= (lhsH - rhsH) >> 32 & 1 &^ gt
+		gt |= (rhsL - lhsL) >> 32 & 1 &^ lt
+		lt |= (lhsL - rhsL) >> 32 & 1 &^ gt
+	}
+	// Make the result -1 for <, 0 for =, 1 for >
+	return int(gt) - int(lt)
+}
+
+// Equal returns 1 if f == rhs, 0 otherwise.
+func (f *Field8) Equal(rhs *Field8) int {
+	return equal8Helper(&f.Value, &rhs.Value)
+}
+
+func equal8Helper(lhs, rhs *[Field8Limbs]uint64) int {
+	t := uint64(0)
+	for i := 0; i < Field8Limbs; i++ {
+		t |= lhs[i] ^ rhs[i]
+	}
+	return int(((int64(t) | int64(-t)) >> 63) + 1)
+}
+
+// New returns a brand new field
+func (f *Field8) New() *Field8 {
+	return &Field8{
+		Value:      [Field8Limbs]uint64{},
+		Params:     f.Params,
+		Arithmetic: f.Arithmetic,
+	}
+}
+
+// IsZero returns 1 if f == 0, 0 otherwise.
+func (f *Field8) IsZero() int {
+	t := uint64(0)
+	for i := 0; i < Field8Limbs; i++ {
+		t |= f.Value[i]
+	}
+	return int(((int64(t) | int64(-t)) >> 63) + 1)
+}
+
+// IsNonZero returns 1 if f != 0, 0 otherwise.
+func (f *Field8) IsNonZero() int {
+	t := uint64(0)
+	for i := 0; i < Field8Limbs; i++ {
+		t |= f.Value[i]
+	}
+	return int(-((int64(t) | int64(-t)) >> 63))
+}
+
+// IsOne returns 1 if f == 1, 0 otherwise.
+func (f *Field8) IsOne() int {
+	return equal8Helper(&f.Value, &f.Params.R)
+}
+
+// Set f = rhs.
+func (f *Field8) Set(rhs *Field8) *Field8 {
+	f.Value = rhs.Value
+	f.Params = rhs.Params
+	f.Arithmetic = rhs.Arithmetic
+	return f
+}
+
+// SetUint64 f = rhs.
+func (f *Field8) SetUint64(rhs uint64) *Field8 {
+	t := &[Field8Limbs]uint64{rhs}
+	f.Arithmetic.ToMontgomery(&f.Value, t)
+	return f
+}
+
+// SetOne f = r.
+func (f *Field8) SetOne() *Field8 {
+	f.Value = f.Params.R
+	return f
+}
+
+// SetZero f = 0.
+func (f *Field8) SetZero() *Field8 {
+	f.Value = [Field8Limbs]uint64{}
+	return f
+}
+
+// SetBytesWide takes 128 bytes as input and treats them as a 1024-bit number.
+// The number is decomposed into two 512-bit digits with the higher bits
+// multiplied by 2^512. The lower bits are multiplied by r^2 and the upper
+// bits by r^3 = r^2 * 2^512 which places both in Montgomery form. This is
+// safe since (2^512 - 1) * c is an acceptable product for the reduction
+// for any c smaller than the modulus.
+func (f *Field8) SetBytesWide(input *[WideField8Bytes]byte) *Field8 {
+	var d0, d1 [Field8Limbs]uint64
+	for i := 0; i < Field8Limbs; i++ {
+		d0[i] = binary.LittleEndian.Uint64(input[i*8 : (i+1)*8])
+		d1[i] = binary.LittleEndian.Uint64(input[(i+Field8Limbs)*8 : (i+Field8Limbs+1)*8])
+	}
+	// Convert to Montgomery form
+	tv1 := &[Field8Limbs]uint64{}
+	tv2 := &[Field8Limbs]uint64{}
+	// d0*r2 + d1*r3
+	f.Arithmetic.Mul(tv1, &d0, &f.Params.R2)
+	f.Arithmetic.Mul(tv2, &d1, &f.Params.R3)
+	f.Arithmetic.Add(&f.Value, tv1, tv2)
+	return f
+}
+
+// SetBytes attempts to convert a little endian byte representation
+// of a scalar into a `Fp`, failing if input is not canonical.
+func (f *Field8) SetBytes(input *[Field8Bytes]byte) (*Field8, error) {
+	d0 := [Field8Limbs]uint64{}
+	f.Arithmetic.FromBytes(&d0, input)
+
+	if cmp8Helper(&d0, &f.Params.Modulus) != -1 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	return f.SetLimbs(&d0), nil
+}
+
+// SetBigInt initializes an element from big.Int
+// The value is reduced by the modulus.
+func (f *Field8) SetBigInt(bi *big.Int) *Field8 {
+	var buffer [Field8Bytes]byte
+	t := new(big.Int).Set(bi)
+	t.Mod(t, f.Params.BiModulus)
+	t.FillBytes(buffer[:])
+	copy(buffer[:], internal.ReverseBytes(buffer[:]))
+	_, _ = f.SetBytes(&buffer)
+	return f
+}
+
+// SetRaw converts a raw array into a field element
+// Assumes input is already in montgomery form.
+func (f *Field8) SetRaw(input *[Field8Limbs]uint64) *Field8 {
+	f.Value = *input
+	return f
+}
+
+// SetLimbs converts an array into a field element
+// by converting to montgomery form.
+func (f *Field8) SetLimbs(input *[Field8Limbs]uint64) *Field8 {
+	f.Arithmetic.ToMontgomery(&f.Value, input)
+	return f
+}
+
+// Bytes converts this element into a byte representation
+// in little endian byte order.
+func (f *Field8) Bytes() [Field8Bytes]byte {
+	var output [Field8Bytes]byte
+	tv := &[Field8Limbs]uint64{}
+	f.Arithmetic.FromMontgomery(tv, &f.Value)
+	f.Arithmetic.ToBytes(&output, tv)
+	return output
+}
+
+// BigInt converts this element into the big.Int struct.
+func (f *Field8) BigInt() *big.Int {
+	buffer := f.Bytes()
+	return new(big.Int).SetBytes(internal.ReverseBytes(buffer[:]))
+}
+
+// Raw converts this element into the a [Field8Limbs]uint64.
+func (f *Field8) Raw() [Field8Limbs]uint64 {
+	res := &[Field8Limbs]uint64{}
+	f.Arithmetic.FromMontgomery(res, &f.Value)
+	return *res
+}
+
+// Double this element.
+func (f *Field8) Double(a *Field8) *Field8 {
+	f.Arithmetic.Add(&f.Value, &a.Value, &a.Value)
+	return f
+}
+
+// Square this element.
+func (f *Field8) Square(a *Field8) *Field8 {
+	f.Arithmetic.Square(&f.Value, &a.Value)
+	return f
+}
+
+// Sqrt this element, if it exists. If true, then value
+// is a square root. If false, value is a QNR.
+func (f *Field8) Sqrt(a *Field8) (*Field8, bool) {
+	wasSquare := 0
+	f.Arithmetic.Sqrt(&wasSquare, &f.Value, &a.Value)
+	return f, wasSquare == 1
+}
+
+// Invert this element i.e. compute the multiplicative inverse
+// return false, zero if this element is zero.
+func (f *Field8) Invert(a *Field8) (*Field8, bool) {
+	wasInverted := 0
+	f.Arithmetic.Invert(&wasInverted, &f.Value, &a.Value)
+	return f, wasInverted == 1
+}
+
+// Mul returns the result from multiplying this element by rhs.
+func (f *Field8) Mul(lhs, rhs *Field8) *Field8 {
+	f.Arithmetic.Mul(&f.Value, &lhs.Value, &rhs.Value)
+	return f
+}
+
+// Sub returns the result from subtracting rhs from this element.
+func (f *Field8) Sub(lhs, rhs *Field8) *Field8 {
+	f.Arithmetic.Sub(&f.Value, &lhs.Value, &rhs.Value)
+	return f
+}
+
+// Add returns the result from adding rhs to this element.
+func (f *Field8) Add(lhs, rhs *Field8) *Field8 {
+	f.Arithmetic.Add(&f.Value, &lhs.Value, &rhs.Value)
+	return f
+}
+
+// Neg returns negation of this element.
+func (f *Field8) Neg(input *Field8) *Field8 {
+	f.Arithmetic.Neg(&f.Value, &input.Value)
+	return f
+}
+
+// Exp raises base^exp.
+func (f *Field8) Exp(base, exp *Field8) *Field8 {
+	e := [Field8Limbs]uint64{}
+	f.Arithmetic.FromMontgomery(&e, &exp.Value)
+	Pow8(&f.Value, &base.Value, &e, f.Params, f.Arithmetic)
+	return f
+}
+
+// CMove sets f = lhs if choice == 0 and f = rhs if choice == 1.
+func (f *Field8) CMove(lhs, rhs *Field8, choice int) *Field8 {
+	f.Arithmetic.Selectznz(&f.Value, &lhs.Value, &rhs.Value, choice)
+	return f
+}
+
+// BatchInvert8 replaces every element of values with its multiplicative
+// inverse using Montgomery's trick so only one field inversion is needed.
+// Zero elements are left as zero. The running time only depends on the
+// number of values.
+func BatchInvert8(values []*Field8) {
+	if len(values) == 0 {
+		return
+	}
+	var t, v, inv [Field8Limbs]uint64
+	var wasInverted int
+	arithmetic := values[0].Arithmetic
+	one := values[0].Params.R
+	// acc[i] is the product of the non-zero values before i
+	acc := make([][Field8Limbs]uint64, len(values))
+	t = one
+	for i, f := range values {
+		acc[i] = t
+		arithmetic.Selectznz(&v, &one, &f.Value, f.IsNonZero())
+		arithmetic.Mul(&t, &t, &v)
+	}
+	arithmetic.Invert(&wasInverted, &inv, &t)
+	for i := len(values) - 1; i >= 0; i-- {
+		f := values[i]
+		nonZero := f.IsNonZero()
+		arithmetic.Selectznz(&v, &one, &f.Value, nonZero)
+		arithmetic.Mul(&t, &inv, &acc[i])
+		arithmetic.Mul(&inv, &inv, &v)
+		arithmetic.Selectznz(&f.Value, &f.Value, &t, nonZero)
+	}
+}
+
+// Pow8 raises base^exp. The result is written to out.
+// Public only for convenience for some internal implementations.
+func Pow8(out, base, exp *[Field8Limbs]uint64, params *Field8Params, arithmetic Field8Arithmetic) {
+	res := params.R
+	tmp := [Field8Limbs]uint64{}
+
+	for i := len(exp) - 1; i >= 0; i-- {
+		for j := 63; j >= 0; j-- {
+			arithmetic.Square(&res, &res)
+			arithmetic.Mul(&tmp, &res, base)
+			arithmetic.Selectznz(&res, &res, &tmp, int(exp[i]>>j)&1)
+		}
+	}
+	*out = res
+}
+
+// Pow2k8 raises arg to the power `2^k`. This result is written to out.
+// Public only for convenience for some internal implementations.
+func Pow2k8(out, arg *[Field8Limbs]uint64, k int, arithmetic Field8Arithmetic) {
+	t := *arg
+	for i := 0; i < k; i++ {
+		arithmetic.Square(&t, &t)
+	}
+	*out = t
+}
@@ -0,0 +1,523 @@
+package native
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	"github.com/mikelodder7/curvey/internal"
+)
+
+// Field9Limbs is the number of limbs needed to represent this field.
+const Field9Limbs = 9
+
+// Field9Bytes is the number of bytes needed to represent this field.
+const Field9Bytes = 66
+
+// WideField9Bytes is the number of bytes needed for safe conversion
+// to this field to avoid bias when reduced.
+const WideField9Bytes = 144
+
+// Field9 represents a field element.
+type Field9 struct {
+	// Value is the field elements value
+	Value [Field9Limbs]uint64
+	// Params are the field parameters
+	Params *Field9Params
+	// Arithmetic are the field methods
+	Arithmetic Field9Arithmetic
+}
+
+// Field9Params are the field parameters.
+type Field9Params struct {
+	// R is 2^576 mod Modulus
+	R [Field9Limbs]uint64
+	// R2 is 2^1152 mod Modulus
+	R2 [Field9Limbs]uint64
+	// R3 is 2^1728 mod Modulus
+	R3 [Field9Limbs]uint64
+	// Modulus of the field
+	Modulus [Field9Limbs]uint64
+	// Modulus as big.Int
+	BiModulus *big.Int
+}
+
+// Field9Arithmetic are the methods that can be done on a field.
+type Field9Arithmetic interface {
+	// ToMontgomery converts this field to montgomery form
+	ToMontgomery(out, arg *[Field9Limbs]uint64)
+	// FromMontgomery converts this field from montgomery form
+	FromMontgomery(out, arg *[Field9Limbs]uint64)
+	// Neg performs modular negation
+	Neg(out, arg *[Field9Limbs]uint64)
+	// Square performs modular square
+	Square(out, arg *[Field9Limbs]uint64)
+	// Mul performs modular multiplication
+	Mul(out, arg1, arg2 *[Field9Limbs]uint64)
+	// Add performs modular addition
+	Add(out, arg1, arg2 *[Field9Limbs]uint64)
+	// Sub performs modular subtraction
+	Sub(out, arg1, arg2 *[Field9Limbs]uint64)
+	// Sqrt performs modular square root
+	Sqrt(wasSquare *int, out, arg *[Field9Limbs]uint64)
+	// Invert performs modular inverse
+	Invert(wasInverted *int, out, arg *[Field9Limbs]uint64)
+	// FromBytes converts a little endian byte array into a field element
+	FromBytes(out *[Field9Limbs]uint64, arg *[Field9Bytes]byte)
+	// ToBytes converts a field element to a little endian byte array
+	ToBytes(out *[Field9Bytes]byte, arg *[Field9Limbs]uint64)
+	// Selectznz performs conditional select.
+	// selects arg1 if choice == 0 and arg2 if choice == 1
+	Selectznz(out, arg1, arg2 *[Field9Limbs]uint64, choice int)
+}
+
+// Cmp returns -1 if f < rhs
+// 0 if f == rhs
+// 1 if f > rhs.
+func (f *Field9) Cmp(rhs *Field9) int {
+	return cmp9Helper(&f.Value, &rhs.Value)
+}
+
+// cmp9Helper returns -1 if lhs < rhs
+// 0 if lhs == rhs
+// 1 if lhs > rhs.
+func cmp9Helper(lhs, rhs *[Field9Limbs]uint64) int {
+	gt := uint64(0)
+	lt := uint64(0)
+	for i := Field9Limbs - 1; i >= 0; i-- {
+		// convert to two 64-bit numbers where
+		// the leading bits are zeros and hold no meaning
+		//  so rhs - fp actually means gt
+		// and fp - rhs actually means lt.
+		rhsH := rhs[i] >> 32
+		rhsL := rhs[i] & 0xffffffff
+		lhsH := lhs[i] >> 32
+		lhsL := lhs[i] & 0xffffffff
+
+		// Check the leading bit
+		// if negative then fp > rhs
+		// if positive then fp < rhs
+		gt |= (rhsH - lhsH) >> 32 & 1 &^ lt
+		lt |= (lhsH - rhsH) >> 32 & 1 &^ gt
+		gt |= (rhsL - lhsL) >> 32 & 1 &^ lt
+		lt |= (lhsL - rhsL) >> 32 & 1 &^ gt
+	}
+	// Make the result -1 for <, 0 for =, 1 for >
+	return int(gt) - int(lt)
+}
+
+// Equal returns 1 if f == rhs, 0 otherwise.
+func (f *Field9) Equal(rhs *Field9) int {
+	return equal9Helper(&f.Value, &rhs.Value)
+}
+
+func equal9Helper(lhs, rhs *[Field9Limbs]uint64) int {
+	t := uint64(0)
+	for i := 0; i < Field9Limbs; i++ {
+		t |= lhs[i] ^ rhs[i]
+	}
+	return int(((int64(t) | int64(-t)) >> 63) + 1)
+}
+
+// New returns a brand new field
+func (f *Field9) New() *Field9 {
+	return &Field9{
+		Value:      [Field9Limbs]uint64{},
+		Params:     f.Params,
+		Arithmetic: f.Arithmetic,
+	}
+}
+
+// IsZero returns 1 if f == 0, 0 otherwise.
+func (f *Field9) IsZero() int {
+	t := uint64(0)
+	for i := 0; i < Field9Limbs; i++ {
+		t |= f.Value[i]
+	}
+	return int(((int64(t) | int64(-t)) >> 63) + 1)
+}
+
+// IsNonZero returns 1 if f != 0, 0 otherwise.
+func (f *Field9) IsNonZero() int {
+	t := uint64(0)
+	for i := 0; i < Field9Limbs; i++ {
+		t |= f.Value[i]
+	}
+	return int(-((int64(t) | int64(-t)) >> 63))
+}
+
+// IsOne returns 1 if f == 1, 0 otherwise.
+func (f *Field9) IsOne() int {
+	return equal9Helper(&f.Value, &f.Params.R)
+}
+
+// Set f = rhs.
+func (f *Field9) Set(rhs *Field9) *Field9 {
+	f.Value = rhs.Value
+	f.Params = rhs.Params
+	f.Arithmetic = rhs.Arithmetic
+	return f
+}
+
+// SetUint64 f = rhs.
+func (f *Field9) SetUint64(rhs uint64) *Field9 {
+	t := &[Field9Limbs]uint64{rhs}
+	f.Arithmetic.ToMontgomery(&f.Value, t)
+	return f
+}
+
+// SetOne f = r.
+func (f *Field9) SetOne() *Field9 {
+	f.Value = f.Params.R
+	return f
+}
+
+// SetZero f = 0.
+func (f *Field9) SetZero() *Field9 {
+	f.Value = [Field9Limbs]uint64{}
+	return f
+}
+
+// SetBytesWide takes 144 bytes as input and treats them as a 1152-bit number.
+// The number is decomposed into two 576-bit digits with the higher bits
+// multiplied by 2^576. The lower bits are multiplied by r^2 and the upper
+// bits by r^3 = r^2 * 2^576 which places both in Montgomery form. This is
+// safe since (2^576 - 1) * c is an acceptable product for the reduction
+// for any c smaller than the modulus.
+func (f *Field9) SetBytesWide(input *[WideField9Bytes]byte) *Field9 {
+	var d0, d1 [Field9Limbs]uint64
+	for i := 0; i < Field9Limbs; i++ {
+		d0[i] = binary.LittleEndian.Uint64(input[i*8 : (i+1)*8])
+		d1[i] = binary.LittleEndian.Uint64(input[(i+Field9Limbs)*8 : (i+Field9Limbs+1)*8])
+	}
+	// Convert to Montgomery form
+	tv1 := &[Field9Limbs]uint64{}
+	tv2 := &[Field9Limbs]uint64{}
+	// d0*r2 + d1*r3
+	f.Arithmetic.Mul(tv1, &d0, &f.Params.R2)
+	f.Arithmetic.Mul(tv2, &d1, &f.Params.R3)
+	f.Arithmetic.Add(&f.Value, tv1, tv2)
+	return f
+}
+
+// SetBytes attempts to convert a little endian byte representation
+// of a scalar into a `Fp`, failing if input is not canonical.
+func (f *Field9) SetBytes(input *[Field9Bytes]byte) (*Field9, error) {
+	d0 := [Field9Limbs]uint64{}
+	f.Arithmetic.FromBytes(&d0, input)
+
+	if cmp9Helper(&d0, &f.Params.Modulus) != -1 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	return f.SetLimbs(&d0), nil
+}
+
+// SetBigInt initializes an element from big.Int
+// The value is reduced by the modulus.
+func (f *Field9) SetBigInt(bi *big.Int) *Field9 {
+	var buffer [Field9Bytes]byte
+	t := new(big.Int).Set(bi)
+	t.Mod(t, f.Params.BiModulus)
+	t.FillBytes(buffer[:])
+	copy(buffer[:], internal.ReverseBytes(buffer[:]))
+	_, _ = f.SetBytes(&buffer)
+	return f
+}
+
+// SetRaw converts a raw array into a field element
+// Assumes input is already in montgomery form.
+func (f *Field9) SetRaw(input *[Field9Limbs]uint64) *Field9 {
+	f.Value = *input
+	return f
+}
+
+// SetLimbs converts an array into a field element
+// by converting to montgomery form.
+func (f *Field9) SetLimbs(input *[Field9Limbs]uint64) *Field9 {
+	f.Arithmetic.ToMontgomery(&f.Value, input)
+	return f
+}
+
+// Bytes converts this element into a byte representation
+// in little endian byte order.
+func (f *Field9) Bytes() [Field9Bytes]byte {
+	var output [Field9Bytes]byte
+	tv := &[Field9Limbs]uint64{}
+	f.Arithmetic.FromMontgomery(tv, &f.Value)
+	f.Arithmetic.ToBytes(&output, tv)
+	return output
+}
+
+// BigInt converts this element into the big.Int struct.
+func (f *Field9) BigInt() *big.Int {
+	buffer := f.Bytes()
+	return new(big.Int).SetBytes(internal.ReverseBytes(buffer[:]))
+}
+
+// Raw converts this element into the a [Field9Limbs]uint64.
+func (f *Field9) Raw() [Field9Limbs]uint64 {
+	res := &[Field9Limbs]uint64{}
+	f.Arithmetic.FromMontgomery(res, &f.Value)
+	return *res
+}
+
+// Double this element.
+func (f *Field9) Double(a *Field9) *Field9 {
+	f.Arithmetic.Add(&f.Value, &a.Value, &a.Value)
+	return f
+}
+
+// Square this element.
+func (f *Field9) Square(a *Field9) *Field9 {
+	f.Arithmetic.Square(&f.Value, &a.Value)
+	return f
+}
+
+// Sqrt this element, if it exists. If true, then value
+// is a square root. If false, value is a QNR.
+func (f *Field9) Sqrt(a *Field9) (*Field9, bool) {
+	wasSquare := 0
+	f.Arithmetic.Sqrt(&wasSquare, &f.Value, &a.Value)
+	return f, wasSquare == 1
+}
+
+// Invert this element i.e. compute the multiplicative inverse
+// return false, zero if this element is zero.
+func (f *Field9) Invert(a *Field9) (*Field9, bool) {
+	wasInverted := 0
+	f.Arithmetic.Invert(&wasInverted, &f.Value, &a.Value)
+	return f, wasInverted == 1
+}
+
+// Mul returns the result from multiplying this element by rhs.
+func (f *Field9) Mul(lhs, rhs *Field9) *Field9 {
+	f.Arithmetic.Mul(&f.Value, &lhs.Value, &rhs.Value)
+	return f
+}
+
+// Sub returns the result from subtracting rhs from this element.
+func (f *Field9) Sub(lhs, rhs *Field9) *Field9 {
+	f.Arithmetic.Sub(&f.Value, &lhs.Value, &rhs.Value)
+	return f
+}
+
+// Add returns the result from adding rhs to this element.
+func (f *Field9) Add(lhs, rhs *Field9) *Field9 {
+	f.Arithmetic.Add(&f.Value, &lhs.Value, &rhs.Value)
+	return f
+}
+
+// Neg returns negation of this element.
+func (f *Field9) Neg(input *Field9) *Field9 {
+	f.Arithmetic.Neg(&f.Value, &input.Value)
+	return f
+}
+
+// Exp raises base^exp.
+func (f *Field9) Exp(base, exp *Field9) *Field9 {
+	e := [Field9Limbs]uint64{}
+	f.Arithmetic.FromMontgomery(&e, &exp.Value)
+	Pow9(&f.Value, &base.Value, &e, f.Params, f.Arithmetic)
+	return f
+}
+
+// CMove sets f = lhs if choice == 0 and f = rhs if choice == 1.
+func (f *Field9) CMove(lhs, rhs *Field9, choice int) *Field9 {
+	f.Arithmetic.Selectznz(&f.Value, &lhs.Value, &rhs.Value, choice)
+	return f
+}
+
+// BatchInvert9 replaces every element of values with its multiplicative
+// inverse using Montgomery's trick so only one field inversion is needed.
+// Zero elements are left as zero. The running time only depends on the
+// number of values.
+func BatchInvert9(values []*Field9) {
+	if len(values) == 0 {
+		return
+	}
+	var t, v, inv [Field9Limbs]uint64
+	var wasInverted int
+	arithmetic := values[0].Arithmetic
+	one := values[0].Params.R
+	// acc[i] is the product of the non-zero values before i
+	acc := make([][Field9Limbs]uint64, len(values))
+	t = one
+	for i, f := range values {
+		acc[i] = t
+		arithmetic.Selectznz(&v, &one, &f.Value, f.IsNonZero())
+		arithmetic.Mul(&t, &t, &v)
+	}
+	arithmetic.Invert(&wasInverted, &inv, &t)
+	for i := len(values) - 1; i >= 0; i-- {
+		f := values[i]
+		nonZero := f.IsNonZero()
+		arithmetic.Selectznz(&v, &one, &f.Value, nonZero)
+		arithmetic.Mul(&t, &inv, &acc[i])
+		arithmetic.Mul(&inv, &inv, &v)
+		arithmetic.Selectznz(&f.Value, &f.Value, &t, nonZero)
+	}
+}
+
+// Pow9 raises base^exp. The result is written to out.
+// Public only for convenience for some internal implementations.
+func Pow9(out, base, exp *[Field9Limbs]uint64, params *Field9Params, arithmetic Field9Arithmetic) {
+	res := params.R
+	tmp := [Field9Limbs]uint64{}
+
+	for i := len(exp) - 1; i >= 0; i-- {
+		for j := 63; j >= 0; j-- {
+			arithmetic.Square(&res, &res)
+			arithmetic.Mul(&tmp, &res, base)
+			arithmetic.Selectznz(&res, &res, &tmp, int(exp[i]>>j)&1)
+		}
+	}
+	*out = res
+}
+
+// Pow2k9 raises arg to the power `2^k`. This result is written to out.
+// Public only for convenience for some internal implementations.
+func Pow2k9(out, arg *[Field9Limbs]uint64, k int, arithmetic Field9Arithmetic) {
+	t := *arg
+	for i := 0; i < k; i++ {
+		arithmetic.Square(&t, &t)
+	}
+	*out = t
+}
+
+// Montgomery9 is a constant time word-by-word Montgomery implementation
+// of the arithmetic for any odd modulus that fits in Field9Bytes. Fields that are
+// represented with 9 limbs can use it for everything except their
+// square root and inversion routines which depend on the modulus.
+type Montgomery9 struct {
+	// Modulus of the field
+	Modulus [Field9Limbs]uint64
+	// R2 is 2^1152 mod Modulus
+	R2 [Field9Limbs]uint64
+	// Inv is -Modulus^-1 mod 2^64
+	Inv uint64
+}
+
+// ToMontgomery converts arg to montgomery form.
+func (m *Montgomery9) ToMontgomery(out, arg *[Field9Limbs]uint64) {
+	m.Mul(out, arg, &m.R2)
+}
+
+// FromMontgomery converts arg from montgomery form.
+func (m *Montgomery9) FromMontgomery(out, arg *[Field9Limbs]uint64) {
+	m.Mul(out, arg, &[Field9Limbs]uint64{1})
+}
+
+// Neg performs modular negation.
+func (m *Montgomery9) Neg(out, arg *[Field9Limbs]uint64) {
+	m.Sub(out, &[Field9Limbs]uint64{}, arg)
+}
+
+// Square performs modular square.
+func (m *Montgomery9) Square(out, arg *[Field9Limbs]uint64) {
+	m.Mul(out, arg, arg)
+}
+
+// Mul performs modular multiplication using the
+// coarsely integrated operand scanning method.
+func (m *Montgomery9) Mul(out, arg1, arg2 *[Field9Limbs]uint64) {
+	var t [Field9Limbs + 2]uint64
+	var hi, lo, c, carry uint64
+
+	for i := 0; i < Field9Limbs; i++ {
+		// t += arg1 * arg2[i]
+		c = 0
+		for j := 0; j < Field9Limbs; j++ {
+			hi, lo = bits.Mul64(arg1[j], arg2[i])
+			lo, carry = bits.Add64(lo, t[j], 0)
+			hi += carry
+			lo, carry = bits.Add64(lo, c, 0)
+			hi += carry
+			t[j] = lo
+			c = hi
+		}
+		t[Field9Limbs], carry = bits.Add64(t[Field9Limbs], c, 0)
+		t[Field9Limbs+1] = carry
+
+		// t = (t + k * modulus) / 2^64
+		k := t[0] * m.Inv
+		hi, lo = bits.Mul64(k, m.Modulus[0])
+		_, carry = bits.Add64(lo, t[0], 0)
+		c = hi + carry
+		for j := 1; j < Field9Limbs; j++ {
+			hi, lo = bits.Mul64(k, m.Modulus[j])
+			lo, carry = bits.Add64(lo, t[j], 0)
+			hi += carry
+			lo, carry = bits.Add64(lo, c, 0)
+			hi += carry
+			t[j-1] = lo
+			c = hi
+		}
+		t[Field9Limbs-1], carry = bits.Add64(t[Field9Limbs], c, 0)
+		t[Field9Limbs] = t[Field9Limbs+1] + carry
+	}
+
+	// t < 2 * modulus so one subtraction is enough
+	var res [Field9Limbs]uint64
+	var borrow uint64
+	for j := 0; j < Field9Limbs; j++ {
+		res[j], borrow = bits.Sub64(t[j], m.Modulus[j], borrow)
+	}
+	_, borrow = bits.Sub64(t[Field9Limbs], 0, borrow)
+	m.Selectznz(out, &res, (*[Field9Limbs]uint64)(t[:Field9Limbs]), int(borrow))
+}
+
+// Add performs modular addition.
+func (m *Montgomery9) Add(out, arg1, arg2 *[Field9Limbs]uint64) {
+	var sum, res [Field9Limbs]uint64
+	var carry, borrow uint64
+	for j := 0; j < Field9Limbs; j++ {
+		sum[j], carry = bits.Add64(arg1[j], arg2[j], carry)
+	}
+	for j := 0; j < Field9Limbs; j++ {
+		res[j], borrow = bits.Sub64(sum[j], m.Modulus[j], borrow)
+	}
+	_, borrow = bits.Sub64(carry, 0, borrow)
+	m.Selectznz(out, &res, &sum, int(borrow))
+}
+
+// Sub performs modular subtraction.
+func (m *Montgomery9) Sub(out, arg1, arg2 *[Field9Limbs]uint64) {
+	var res [Field9Limbs]uint64
+	var carry, borrow uint64
+	for j := 0; j < Field9Limbs; j++ {
+		res[j], borrow = bits.Sub64(arg1[j], arg2[j], borrow)
+	}
+	// Add the modulus back if the subtraction underflowed
+	mask := -borrow
+	for j := 0; j < Field9Limbs; j++ {
+		out[j], carry = bits.Add64(res[j], m.Modulus[j]&mask, carry)
+	}
+}
+
+// FromBytes converts a little endian byte array into a field element.
+func (*Montgomery9) FromBytes(out *[Field9Limbs]uint64, arg *[Field9Bytes]byte) {
+	var buffer [Field9Limbs * 8]byte
+	copy(buffer[:], arg[:])
+	for i := 0; i < Field9Limbs; i++ {
+		out[i] = binary.LittleEndian.Uint64(buffer[i*8 : (i+1)*8])
+	}
+}
+
+// ToBytes converts a field element to a little endian byte array.
+func (*Montgomery9) ToBytes(out *[Field9Bytes]byte, arg *[Field9Limbs]uint64) {
+	var buffer [Field9Limbs * 8]byte
+	for i := 0; i < Field9Limbs; i++ {
+		binary.LittleEndian.PutUint64(buffer[i*8:(i+1)*8], arg[i])
+	}
+	copy(out[:], buffer[:Field9Bytes])
+}
+
+// Selectznz performs conditional select.
+// selects arg1 if choice == 0 and arg2 if choice == 1.
+func (*Montgomery9) Selectznz(out, arg1, arg2 *[Field9Limbs]uint64, choice int) {
+	mask := -uint64(choice & 1)
+	for i := 0; i < Field9Limbs; i++ {
+		out[i] = arg1[i] ^ ((arg1[i] ^ arg2[i]) & mask)
+	}
+}
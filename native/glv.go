@@ -0,0 +1,145 @@
+package native
+
+import (
+	"math/bits"
+)
+
+// GlvBits is the number of bits processed for each half of a scalar
+// split by GlvParams.Decompose. The halves are at most 128 bits for all
+// the supported curves, the extra bits round up to a whole 4-bit window.
+const GlvBits = 132
+
+// GlvParams are the constants for splitting a scalar k into
+// k1 + k2 * lambda mod n where k1 and k2 are about half the size of n,
+// https://www.iacr.org/archive/crypto2001/21390189.pdf.
+// The lattice basis (a1, b1), (a2, b2) of the kernel of
+// (i, j) -> i + j * lambda mod n is chosen by the extended euclidean
+// algorithm and the decomposition follows libsecp256k1 so it
+// runs in constant time.
+// All values are in canonical (non-montgomery) form.
+type GlvParams struct {
+	// G1 is round(2^Shift * b2 / n)
+	G1 [Field4Limbs]uint64
+	// G2 is round(2^Shift * -b1 / n)
+	G2 [Field4Limbs]uint64
+	// Shift is the largest power of two keeping G1 and G2 below 2^256
+	Shift int
+	// MinusB1 is -b1 mod n
+	MinusB1 [Field4Limbs]uint64
+	// MinusB2 is -b2 mod n
+	MinusB2 [Field4Limbs]uint64
+	// MinusLambda is -lambda mod n
+	MinusLambda [Field4Limbs]uint64
+}
+
+// EllipticPoint4Endomorphism is implemented by the point arithmetic of curves
+// with an efficiently computable endomorphism phi(P) = lambda * P.
+// Mul, MulVarTime and SumOfProducts use it to halve the number of doublings.
+type EllipticPoint4Endomorphism interface {
+	// Endomorphism computes phi(arg) and stores the result in out
+	Endomorphism(out, arg *EllipticPoint4)
+	// GlvParams returns the scalar decomposition constants
+	GlvParams() *GlvParams
+}
+
+// Decompose splits k into k1 + k2 * lambda mod n.
+// The absolute values of k1 and k2 are returned with
+// neg1 and neg2 set to 1 when the value is negative.
+func (g *GlvParams) Decompose(k *Field4) (k1, k2 [Field4Limbs]uint64, neg1, neg2 int) {
+	var c1, c2, r1, r2, t [Field4Limbs]uint64
+	a := k.Arithmetic
+
+	a.FromMontgomery(&t, &k.Value)
+	c1 = mulShiftRound(&t, &g.G1, g.Shift)
+	c2 = mulShiftRound(&t, &g.G2, g.Shift)
+
+	// r2 = c1 * -b1 + c2 * -b2
+	a.ToMontgomery(&c1, &c1)
+	a.ToMontgomery(&c2, &c2)
+	a.ToMontgomery(&t, &g.MinusB1)
+	a.Mul(&c1, &c1, &t)
+	a.ToMontgomery(&t, &g.MinusB2)
+	a.Mul(&c2, &c2, &t)
+	a.Add(&r2, &c1, &c2)
+	// r1 = k + r2 * -lambda
+	a.ToMontgomery(&t, &g.MinusLambda)
+	a.Mul(&r1, &r2, &t)
+	a.Add(&r1, &r1, &k.Value)
+
+	k1, neg1 = glvAbs(&r1, k)
+	k2, neg2 = glvAbs(&r2, k)
+	return k1, k2, neg1, neg2
+}
+
+// glvAbs returns the canonical form of arg or of -arg if arg is above n/2.
+func glvAbs(arg *[Field4Limbs]uint64, k *Field4) ([Field4Limbs]uint64, int) {
+	var value, neg, half [Field4Limbs]uint64
+	var borrow uint64
+	a := k.Arithmetic
+
+	a.Neg(&neg, arg)
+	a.FromMontgomery(&neg, &neg)
+	a.FromMontgomery(&value, arg)
+
+	// n is odd so n / 2 = (n - 1) / 2
+	for i := 0; i < Field4Limbs-1; i++ {
+		half[i] = k.Params.Modulus[i]>>1 | k.Params.Modulus[i+1]<<63
+	}
+	half[Field4Limbs-1] = k.Params.Modulus[Field4Limbs-1] >> 1
+	// value > half when half - value borrows
+	for i := 0; i < Field4Limbs; i++ {
+		_, borrow = bits.Sub64(half[i], value[i], borrow)
+	}
+	isNeg := int(borrow)
+	a.Selectznz(&value, &value, &neg, isNeg)
+	return value, isNeg
+}
+
+// mulShiftRound returns round(a * b / 2^shift) for shift >= 256.
+func mulShiftRound(a, b *[Field4Limbs]uint64, shift int) [Field4Limbs]uint64 {
+	var wide [2 * Field4Limbs]uint64
+	var out [Field4Limbs]uint64
+	var carry, hi, lo uint64
+
+	for i := 0; i < Field4Limbs; i++ {
+		carry = 0
+		for j := 0; j < Field4Limbs; j++ {
+			hi, lo = bits.Mul64(a[i], b[j])
+			var c uint64
+			lo, c = bits.Add64(lo, wide[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			wide[i+j] = lo
+			carry = hi
+		}
+		wide[i+Field4Limbs] = carry
+	}
+
+	// Add 2^(shift-1) to round to the nearest integer
+	carry = 0
+	for i := 0; i < 2*Field4Limbs; i++ {
+		var bit uint64
+		if i == (shift-1)>>6 {
+			bit = 1 << ((shift - 1) & 63)
+		}
+		wide[i], carry = bits.Add64(wide[i], bit, carry)
+	}
+
+	limb := shift >> 6
+	offset := uint(shift & 63)
+	for i := range out {
+		if limb+i < len(wide) {
+			out[i] = wide[limb+i] >> offset
+		}
+		if offset != 0 && limb+i+1 < len(wide) {
+			out[i] |= wide[limb+i+1] << (64 - offset)
+		}
+	}
+	return out
+}
+
+// glvWindow returns the 4-bit window of k starting at bit i.
+func glvWindow(k *[Field4Limbs]uint64, i int) byte {
+	return byte(k[i>>6]>>(i&63)) & 0x0F
+}
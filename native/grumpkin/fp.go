@@ -0,0 +1,11 @@
+package grumpkin
+
+import (
+	"github.com/mikelodder7/curvey/native"
+	"github.com/mikelodder7/curvey/native/bn254"
+)
+
+// FpNew returns an element of the Grumpkin base field which is the BN254 scalar field.
+func FpNew() *native.Field4 {
+	return bn254.FqNew()
+}
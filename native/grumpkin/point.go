@@ -0,0 +1,274 @@
+package grumpkin
+
+import (
+	"sync"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+)
+
+var (
+	grumpkinPointInitonce     sync.Once
+	grumpkinPointParams       native.EllipticPoint4Params
+	grumpkinPointSvdwInitOnce sync.Once
+	grumpkinPointSvdwParams   native.Svdw4Params
+)
+
+// PointNew returns a point on Grumpkin, y^2 = x^3 - 17 over
+// the BN254 scalar field.
+func PointNew() *native.EllipticPoint4 {
+	return &native.EllipticPoint4{
+		X:          FpNew(),
+		Y:          FpNew(),
+		Z:          FpNew(),
+		Params:     getPointParams(),
+		Arithmetic: &pointArithmetic{},
+	}
+}
+
+func pointParamsInit() {
+	grumpkinPointParams = native.EllipticPoint4Params{
+		A:  FpNew(),
+		B:  FpNew().Neg(FpNew().SetUint64(17)),
+		Gx: FpNew().SetOne(),
+		Gy: FpNew().SetLimbs(&[native.Field4Limbs]uint64{
+			0x833fc48d823f272c,
+			0x2d270d45f1181294,
+			0xcf135e7506a45d63,
+			0x0000000000000002,
+		}),
+		BitSize: 254,
+		Name:    "grumpkin",
+	}
+}
+
+func getPointParams() *native.EllipticPoint4Params {
+	grumpkinPointInitonce.Do(pointParamsInit)
+	return &grumpkinPointParams
+}
+
+func getPointSvdwParams() *native.Svdw4Params {
+	grumpkinPointSvdwInitOnce.Do(pointSvdwParamsInit)
+	return &grumpkinPointSvdwParams
+}
+
+func pointSvdwParamsInit() {
+	// Grumpkin has A = 0 and no known isogeny so the
+	// Shallue-van de Woestijne map is used with Z = 1
+	grumpkinPointSvdwParams = native.Svdw4Params{
+		// g(Z) = -16
+		C1: [native.Field4Limbs]uint64{0x8a068a1eb0000055, 0x593c20106a92603b, 0x32a72598fff256ee, 0x114e0c24c57a2dda},
+		// -Z / 2
+		C2: [native.Field4Limbs]uint64{0xcba5e0bbd0000003, 0x789bb8d96d2c51b3, 0x28f0d12384840917, 0x112ceb58a394e07d},
+		// sqrt(-g(Z) * 3 * Z^2)
+		C3: [native.Field4Limbs]uint64{0xbfa71a737a9c53fc, 0xffd511382118d9c5, 0x4cb5552fe51ee203, 0x16e163d8915cc57e},
+		// -4 * g(Z) / (3 * Z^2)
+		C4: [native.Field4Limbs]uint64{0x8bd93d6b055554e5, 0x068e6832964b9aec, 0xca1c13952c3e39ca, 0x195193972f396306},
+		// -17
+		B: [native.Field4Limbs]uint64{0xdd7056026000005a, 0x223fa97acb319311, 0xcc388229877910c0, 0x034394632b724eaa},
+		// 1
+		Z: [native.Field4Limbs]uint64{0xac96341c4ffffffb, 0x36fc76959f60cd29, 0x666ea36f7879462e, 0x0e0a77c19a07df2f},
+	}
+}
+
+type pointArithmetic struct{}
+
+func (k pointArithmetic) Hash(out *native.EllipticPoint4, hash *native.EllipticPointHasher, msg, dst []byte) error {
+	var u []byte
+	svdwParams := getPointSvdwParams()
+
+	switch hash.Type() {
+	case native.XMD:
+		u = native.ExpandMsgXmd(hash, msg, dst, 96)
+	case native.XOF:
+		u = native.ExpandMsgXof(hash, msg, dst, 96)
+	}
+	var buf [64]byte
+	copy(buf[:48], internal.ReverseBytes(u[:48]))
+	u0 := FpNew().SetBytesWide(&buf)
+	copy(buf[:48], internal.ReverseBytes(u[48:]))
+	u1 := FpNew().SetBytesWide(&buf)
+
+	q0x, q0y := svdwParams.Map(u0)
+	q1x, q1y := svdwParams.Map(u1)
+	out.X = q0x
+	out.Y = q0y
+	out.Z.SetOne()
+	tv := &native.EllipticPoint4{
+		X: q1x,
+		Y: q1y,
+		Z: FpNew().SetOne(),
+	}
+	// The cofactor is 1 so no clearing is needed
+	k.Add(out, out, tv)
+	return nil
+}
+
+func (pointArithmetic) Double(out, arg *native.EllipticPoint4) {
+	// Addition formula from Renes-Costello-Batina 2015
+	// (https://eprint.iacr.org/2015/1060 Algorithm 9)
+	var yy, zz, xy2, bzz, bzz3, bzz9 [native.Field4Limbs]uint64
+	var yyMBzz9, yyPBzz3, yyzz, yyzz8, t [native.Field4Limbs]uint64
+	var x, y, z [native.Field4Limbs]uint64
+	f := arg.X.Arithmetic
+
+	f.Square(&yy, &arg.Y.Value)
+	f.Square(&zz, &arg.Z.Value)
+	f.Mul(&xy2, &arg.X.Value, &arg.Y.Value)
+	f.Add(&xy2, &xy2, &xy2)
+	f.Mul(&bzz, &zz, &arg.Params.B.Value)
+	f.Add(&bzz3, &bzz, &bzz)
+	f.Add(&bzz3, &bzz3, &bzz)
+	f.Add(&bzz9, &bzz3, &bzz3)
+	f.Add(&bzz9, &bzz9, &bzz3)
+	f.Neg(&yyMBzz9, &bzz9)
+	f.Add(&yyMBzz9, &yyMBzz9, &yy)
+	f.Add(&yyPBzz3, &yy, &bzz3)
+	f.Mul(&yyzz, &yy, &zz)
+	f.Add(&yyzz8, &yyzz, &yyzz)
+	f.Add(&yyzz8, &yyzz8, &yyzz8)
+	f.Add(&yyzz8, &yyzz8, &yyzz8)
+	f.Add(&t, &yyzz8, &yyzz8)
+	f.Add(&t, &t, &yyzz8)
+	f.Mul(&t, &t, &arg.Params.B.Value)
+
+	f.Mul(&x, &xy2, &yyMBzz9)
+
+	f.Mul(&y, &yyMBzz9, &yyPBzz3)
+	f.Add(&y, &y, &t)
+
+	f.Mul(&z, &yy, &arg.Y.Value)
+	f.Mul(&z, &z, &arg.Z.Value)
+	f.Add(&z, &z, &z)
+	f.Add(&z, &z, &z)
+	f.Add(&z, &z, &z)
+
+	out.X.Value = x
+	out.Y.Value = y
+	out.Z.Value = z
+}
+
+func (pointArithmetic) Add(out, arg1, arg2 *native.EllipticPoint4) {
+	// Addition formula from Renes-Costello-Batina 2015
+	// (https://eprint.iacr.org/2015/1060 Algorithm 7).
+	var xx, yy, zz, nXxYy, nYyZz, nXxZz [native.Field4Limbs]uint64
+	var tv1, tv2, xyPairs, yzPairs, xzPairs [native.Field4Limbs]uint64
+	var bzz, bzz3, yyMBzz3, yyPBzz3, byz [native.Field4Limbs]uint64
+	var byz3, xx3, bxx9, x, y, z [native.Field4Limbs]uint64
+	f := arg1.X.Arithmetic
+
+	f.Mul(&xx, &arg1.X.Value, &arg2.X.Value)
+	f.Mul(&yy, &arg1.Y.Value, &arg2.Y.Value)
+	f.Mul(&zz, &arg1.Z.Value, &arg2.Z.Value)
+
+	f.Add(&nXxYy, &xx, &yy)
+	f.Neg(&nXxYy, &nXxYy)
+
+	f.Add(&nYyZz, &yy, &zz)
+	f.Neg(&nYyZz, &nYyZz)
+
+	f.Add(&nXxZz, &xx, &zz)
+	f.Neg(&nXxZz, &nXxZz)
+
+	f.Add(&tv1, &arg1.X.Value, &arg1.Y.Value)
+	f.Add(&tv2, &arg2.X.Value, &arg2.Y.Value)
+	f.Mul(&xyPairs, &tv1, &tv2)
+	f.Add(&xyPairs, &xyPairs, &nXxYy)
+
+	f.Add(&tv1, &arg1.Y.Value, &arg1.Z.Value)
+	f.Add(&tv2, &arg2.Y.Value, &arg2.Z.Value)
+	f.Mul(&yzPairs, &tv1, &tv2)
+	f.Add(&yzPairs, &yzPairs, &nYyZz)
+
+	f.Add(&tv1, &arg1.X.Value, &arg1.Z.Value)
+	f.Add(&tv2, &arg2.X.Value, &arg2.Z.Value)
+	f.Mul(&xzPairs, &tv1, &tv2)
+	f.Add(&xzPairs, &xzPairs, &nXxZz)
+
+	f.Mul(&bzz, &zz, &arg1.Params.B.Value)
+	f.Add(&bzz3, &bzz, &bzz)
+	f.Add(&bzz3, &bzz3, &bzz)
+
+	f.Neg(&yyMBzz3, &bzz3)
+	f.Add(&yyMBzz3, &yyMBzz3, &yy)
+
+	f.Add(&yyPBzz3, &yy, &bzz3)
+
+	f.Mul(&byz, &yzPairs, &arg1.Params.B.Value)
+	f.Add(&byz3, &byz, &byz)
+	f.Add(&byz3, &byz3, &byz)
+
+	f.Add(&xx3, &xx, &xx)
+	f.Add(&xx3, &xx3, &xx)
+
+	f.Add(&bxx9, &xx3, &xx3)
+	f.Add(&bxx9, &bxx9, &xx3)
+	f.Mul(&bxx9, &bxx9, &arg1.Params.B.Value)
+
+	f.Mul(&tv1, &xyPairs, &yyMBzz3)
+	f.Mul(&tv2, &byz3, &xzPairs)
+	f.Neg(&tv2, &tv2)
+	f.Add(&x, &tv1, &tv2)
+
+	f.Mul(&tv1, &yyPBzz3, &yyMBzz3)
+	f.Mul(&tv2, &bxx9, &xzPairs)
+	f.Add(&y, &tv1, &tv2)
+
+	f.Mul(&tv1, &yzPairs, &yyPBzz3)
+	f.Mul(&tv2, &xx3, &xyPairs)
+	f.Add(&z, &tv1, &tv2)
+
+	e1 := arg1.Z.IsZero()
+	e2 := arg2.Z.IsZero()
+
+	// If arg1 is identity set it to arg2
+	f.Selectznz(&z, &z, &arg2.Z.Value, e1)
+	f.Selectznz(&y, &y, &arg2.Y.Value, e1)
+	f.Selectznz(&x, &x, &arg2.X.Value, e1)
+	// If arg2 is identity set it to arg1
+	f.Selectznz(&z, &z, &arg1.Z.Value, e2)
+	f.Selectznz(&y, &y, &arg1.Y.Value, e2)
+	f.Selectznz(&x, &x, &arg1.X.Value, e2)
+
+	out.X.Value = x
+	out.Y.Value = y
+	out.Z.Value = z
+}
+
+func (k pointArithmetic) IsOnCurve(arg *native.EllipticPoint4) bool {
+	affine := PointNew()
+	k.ToAffine(affine, arg)
+	lhs := FpNew().Square(affine.Y)
+	rhs := FpNew()
+	k.RhsEquation(rhs, affine.X)
+	return lhs.Equal(rhs) == 1
+}
+
+func (pointArithmetic) ToAffine(out, arg *native.EllipticPoint4) {
+	var wasInverted int
+	var zero, x, y, z [native.Field4Limbs]uint64
+	f := arg.X.Arithmetic
+
+	f.Invert(&wasInverted, &z, &arg.Z.Value)
+	f.Mul(&x, &arg.X.Value, &z)
+	f.Mul(&y, &arg.Y.Value, &z)
+
+	out.Z.SetOne()
+	// If point at infinity this does nothing
+	f.Selectznz(&x, &zero, &x, wasInverted)
+	f.Selectznz(&y, &zero, &y, wasInverted)
+	f.Selectznz(&z, &zero, &out.Z.Value, wasInverted)
+
+	out.X.Value = x
+	out.Y.Value = y
+	out.Z.Value = z
+	out.Params = arg.Params
+	out.Arithmetic = arg.Arithmetic
+}
+
+func (pointArithmetic) RhsEquation(out, x *native.Field4) {
+	// Elliptic curve equation for Grumpkin is: y^2 = x^3 - 17
+	out.Square(x)
+	out.Mul(out, x)
+	out.Add(out, getPointParams().B)
+}
@@ -0,0 +1,67 @@
+package grumpkin_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikelodder7/curvey/native"
+	"github.com/mikelodder7/curvey/native/grumpkin"
+)
+
+func bhex(s string) *big.Int {
+	r, _ := new(big.Int).SetString(s, 16)
+	return r
+}
+
+func TestGrumpkinPointArithmetic_Double(t *testing.T) {
+	g := grumpkin.PointNew().Generator()
+	require.True(t, g.IsOnCurve())
+	pt1 := grumpkin.PointNew().Double(g)
+	pt2 := grumpkin.PointNew().Add(g, g)
+	pt3 := grumpkin.PointNew().Mul(g, grumpkin.FqNew().SetUint64(2))
+
+	require.Equal(t, 1, pt1.Equal(pt2))
+	require.Equal(t, 1, pt1.Equal(pt3))
+	require.Equal(t, 1, pt2.Equal(pt3))
+
+	x, y := pt1.BigInt()
+	require.Equal(t, 0, x.Cmp(bhex("06ce1b0827aafa85ddeb49cdaa36306d19a74caa311e13d46d8bc688cdbffffe")))
+	require.Equal(t, 0, y.Cmp(bhex("1c122f81a3a14964909ede0ba2a6855fc93faf6fa1a788bf467be7e7a43f80ac")))
+}
+
+func TestGrumpkinPointArithmetic_Mul(t *testing.T) {
+	g := grumpkin.PointNew().Generator()
+	pt := grumpkin.PointNew().Mul(g, grumpkin.FqNew().SetUint64(0x1234567890abcdef))
+	require.True(t, pt.IsOnCurve())
+	x, _ := pt.BigInt()
+	require.Equal(t, 0, x.Cmp(bhex("08e5bc23e059847cce37e1a0f084b7b63b2348112c64171a0850df1148fc688a")))
+
+	// The group order is the BN254 base field modulus
+	n := grumpkin.FqNew().SetOne()
+	n.Neg(n)
+	pt.Mul(g, n)
+	pt.Add(pt, g)
+	require.True(t, pt.IsIdentity())
+}
+
+func TestGrumpkinPointArithmetic_Hash(t *testing.T) {
+	dst := []byte("grumpkin_XMD:SHA-256_SVDW_RO_")
+	tests := []struct {
+		msg  string
+		x, y string
+	}{
+		{"", "24994c0773919a627170dee25bed1c810e5193fa967f2ff8ca3334824723deb9", "26bbf6f557119be6d44eb44bdff47fa0941e7fa83e522cc785b483be32c3dff9"},
+		{"abc", "164738e5f11a67c436955f48393ef574a26e607c4e46147c22b1b5778bf4da87", "0b9ac75ae6b222720383b5cf3f15e9c50232b38c6cd49b1f6c9f3ee4a70dec6f"},
+	}
+	for _, tst := range tests {
+		pt := grumpkin.PointNew()
+		err := pt.Arithmetic.Hash(pt, native.EllipticPointHasherSha256(), []byte(tst.msg), dst)
+		require.NoError(t, err)
+		require.True(t, pt.IsOnCurve())
+		x, y := pt.BigInt()
+		require.Equal(t, 0, x.Cmp(bhex(tst.x)))
+		require.Equal(t, 0, y.Cmp(bhex(tst.y)))
+	}
+}
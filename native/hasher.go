@@ -3,6 +3,7 @@ package native
 import (
 	"crypto/sha256"
 	"crypto/sha512"
+	"github.com/mikelodder7/curvey/native/sm3"
 	"golang.org/x/crypto/blake2b"
 	"golang.org/x/crypto/sha3"
 	"hash"
@@ -33,6 +34,7 @@ const (
 	BLAKE2B
 	SHAKE128
 	SHAKE256
+	SM3
 )
 
 // EllipticPointHasher is the type of hashing methods for
@@ -145,6 +147,15 @@ func EllipticPointHasherShake256() *EllipticPointHasher {
 	}
 }
 
+// EllipticPointHasherSm3 creates a point hasher that uses SM3.
+func EllipticPointHasherSm3() *EllipticPointHasher {
+	return &EllipticPointHasher{
+		name:     SM3,
+		hashType: XMD,
+		xmd:      sm3.New(),
+	}
+}
+
 func (t EllipticPointHashType) String() string {
 	switch t {
 	case XMD:
@@ -173,6 +184,8 @@ func (n EllipticPointHashName) String() string {
 		return "SHAKE-128"
 	case SHAKE256:
 		return "SHAKE-256"
+	case SM3:
+		return "SM3"
 	}
 	return "unknown"
 }
@@ -0,0 +1,77 @@
+package jubjub
+
+import (
+	crand "crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFq_Modulus(t *testing.T) {
+	expected, _ := new(big.Int).SetString("0e7db4ea6533afa906673b0101343b00a6682093ccc81082d0970e5ed6f72cb7", 16)
+	require.Equal(t, 0, expected.Cmp(FqNew().Params.BiModulus))
+	require.Equal(t, 1, FqNew().SetOne().IsOne())
+	require.Equal(t, 1, FqNew().Neg(FqNew().SetOne()).Add(FqNew().Neg(FqNew().SetOne()), FqNew().SetUint64(1)).IsZero())
+}
+
+func TestFq_Arithmetic(t *testing.T) {
+	modulus := FqNew().Params.BiModulus
+	for i := 0; i < 25; i++ {
+		a, _ := crand.Int(crand.Reader, modulus)
+		b, _ := crand.Int(crand.Reader, modulus)
+		fa := FqNew().SetBigInt(a)
+		fb := FqNew().SetBigInt(b)
+
+		sum := new(big.Int).Add(a, b)
+		require.Equal(t, 0, sum.Mod(sum, modulus).Cmp(FqNew().Add(fa, fb).BigInt()))
+		diff := new(big.Int).Sub(a, b)
+		require.Equal(t, 0, diff.Mod(diff, modulus).Cmp(FqNew().Sub(fa, fb).BigInt()))
+		prod := new(big.Int).Mul(a, b)
+		require.Equal(t, 0, prod.Mod(prod, modulus).Cmp(FqNew().Mul(fa, fb).BigInt()))
+		sq := new(big.Int).Mul(a, a)
+		require.Equal(t, 0, sq.Mod(sq, modulus).Cmp(FqNew().Square(fa).BigInt()))
+
+		inv, wasInverted := FqNew().Invert(fa)
+		require.True(t, wasInverted)
+		require.Equal(t, 1, FqNew().Mul(inv, fa).IsOne())
+
+		root, wasSquare := FqNew().Sqrt(FqNew().Square(fa))
+		require.True(t, wasSquare)
+		require.Equal(t, 1, FqNew().Square(root).Equal(FqNew().Square(fa)))
+	}
+	_, wasInverted := FqNew().Invert(FqNew())
+	require.False(t, wasInverted)
+	// 7 is not a square
+	_, wasSquare := FqNew().Sqrt(FqNew().SetUint64(7))
+	require.False(t, wasSquare)
+}
+
+func TestFq_Bytes(t *testing.T) {
+	modulus := FqNew().Params.BiModulus
+	a, _ := crand.Int(crand.Reader, modulus)
+	fa := FqNew().SetBigInt(a)
+	b := fa.Bytes()
+	fb, err := FqNew().SetBytes(&b)
+	require.NoError(t, err)
+	require.Equal(t, 1, fa.Equal(fb))
+
+	var wide [64]byte
+	_, _ = crand.Read(wide[:])
+	expected := new(big.Int).SetBytes(reverse(wide[:]))
+	expected.Mod(expected, modulus)
+	require.Equal(t, 0, expected.Cmp(FqNew().SetBytesWide(&wide).BigInt()))
+
+	var bad [32]byte
+	copy(bad[:], reverse(modulus.Bytes()))
+	_, err = FqNew().SetBytes(&bad)
+	require.Error(t, err)
+}
+
+func reverse(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i := range b {
+		out[len(b)-1-i] = b[i]
+	}
+	return out
+}
@@ -0,0 +1,506 @@
+package jubjub
+
+import (
+	"fmt"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+	"github.com/mikelodder7/curvey/native/bls12381"
+)
+
+// PointBytes is the length of the ZIP-216 point encoding
+const PointBytes = 32
+
+// CompressedPoint is the little endian encoding of the v coordinate
+// with the sign of the u coordinate stored in the top bit
+type CompressedPoint = [PointBytes]byte
+
+var (
+	// edwardsD = -(10240/10241)
+	edwardsD = FpNew().SetLimbs(&[native.Field4Limbs]uint64{
+		0x01065fd6d6343eb1,
+		0x292d7f6d37579d26,
+		0xf5fd9207e6bd7fd4,
+		0x2a9318e74bfa2b48,
+	})
+	edwardsD2 = FpNew().Double(edwardsD)
+	one       = FpNew().SetOne()
+	minusOne  = FpNew().Neg(one)
+	// The birationally equivalent montgomery curve is
+	// K*t^2 = s^3 + J*s^2 + s with J = 40962 and K = -40964
+	montgomeryK = FpNew().Neg(FpNew().SetUint64(40964))
+	// montgomeryJK = J / K
+	montgomeryJK = FpNew().SetLimbs(&[native.Field4Limbs]uint64{
+		0x00832feb6b1a1f58,
+		0x1496bfb69babce93,
+		0x7afec903f35ebfea,
+		0x15498c73a5fd15a4,
+	})
+	// montgomeryKK = 1 / K^2
+	montgomeryKK = FpNew().SetLimbs(&[native.Field4Limbs]uint64{
+		0xb1dec13d57ee22ee,
+		0xc36c50191c27f784,
+		0xc984b83f5d049e6b,
+		0x2b806e727d7b6e67,
+	})
+	// elligatorZ is the non-square used by elligator 2
+	elligatorZ = FpNew().SetUint64(5)
+	// subgroupOrderBytes is the little endian order of the prime order subgroup
+	subgroupOrderBytes = [PointBytes]byte{
+		0xb7, 0x2c, 0xf7, 0xd6, 0x5e, 0x0e, 0x97, 0xd0, 0x82, 0x10, 0xc8, 0xcc, 0x93, 0x20, 0x68, 0xa6,
+		0x00, 0x3b, 0x34, 0x01, 0x01, 0x3b, 0x67, 0x06, 0xa9, 0xaf, 0x33, 0x65, 0xea, 0xb4, 0x7d, 0x0e,
+	}
+)
+
+// FpNew returns an element of the jubjub base field. This is the scalar
+// field of BLS12-381 which is what makes jubjub efficient to use inside
+// circuits defined over BLS12-381.
+func FpNew() *native.Field4 {
+	return bls12381.FqNew()
+}
+
+// Decompress returns the point encoded as described in ZIP-216.
+// Any point on the curve is accepted, use IsTorsionFreeI to check if
+// the point is in the prime order subgroup.
+func Decompress(c *CompressedPoint) (*ExtendedPoint, error) {
+	var vBytes [PointBytes]byte
+	copy(vBytes[:], c[:])
+	signBit := int(vBytes[PointBytes-1] >> 7)
+	vBytes[PointBytes-1] &= 0x7f
+
+	v, err := FpNew().SetBytes(&vBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid point")
+	}
+	// u^2 = (v^2 - 1) / (d * v^2 + 1)
+	vv := FpNew().Square(v)
+	numerator := FpNew().Sub(vv, one)
+	denominator := FpNew().Mul(edwardsD, vv)
+	denominator.Add(denominator, one)
+	denominator, _ = FpNew().Invert(denominator)
+	uu := FpNew().Mul(numerator, denominator)
+	u, wasSquare := sqrtI(uu)
+
+	// ZIP-216 forbids the sign bit for u = 0
+	invalidSign := u.IsZero() & signBit
+	u.CMove(u, FpNew().Neg(u), sgn0(u)^signBit)
+
+	pt := (&AffinePoint{X: u, Y: v}).ToExtended()
+	if wasSquare&(invalidSign^1)&pt.IsOnCurve() == 1 {
+		return pt, nil
+	}
+	return nil, fmt.Errorf("invalid point")
+}
+
+// ExtendedPoint is a jubjub point in extended twisted edwards coordinates
+// where X/Z = u, Y/Z = v and T/Z = uv
+type ExtendedPoint struct {
+	X, Y, Z, T *native.Field4
+}
+
+func PointNew() *ExtendedPoint {
+	return &ExtendedPoint{
+		X: FpNew(),
+		Y: FpNew(),
+		Z: FpNew(),
+		T: FpNew(),
+	}
+}
+
+func (e *ExtendedPoint) SetIdentity() *ExtendedPoint {
+	e.X.SetZero()
+	e.Y.SetOne()
+	e.Z.SetOne()
+	e.T.SetZero()
+	return e
+}
+
+// SetGenerator sets the point to the generator of the prime order subgroup
+// which is [8] of the full group generator with v = 11
+func (e *ExtendedPoint) SetGenerator() *ExtendedPoint {
+	e.X.SetLimbs(&[native.Field4Limbs]uint64{
+		0x512dfea318d56fe5,
+		0x04315c657fbe375f,
+		0x5ed37ee3b172f5ee,
+		0x3ea5c4673a121ca3,
+	})
+	e.Y.SetLimbs(&[native.Field4Limbs]uint64{
+		0xc10cea38d50c55cb,
+		0xb9aa6e8e40808413,
+		0x78f7d30d3f616cb3,
+		0x57137b83ea6edb4f,
+	})
+	e.Z.SetOne()
+	e.T.Mul(e.X, e.Y)
+	return e
+}
+
+func (e *ExtendedPoint) IsIdentityI() int {
+	return e.X.IsZero() & e.Y.Equal(e.Z)
+}
+
+func (e *ExtendedPoint) IsOnCurve() int {
+	xy := FpNew().Mul(e.X, e.Y)
+	zt := FpNew().Mul(e.Z, e.T)
+
+	// Y^2 - X^2 == Z^2 + T^2 * D
+	yy := FpNew().Square(e.Y)
+	xx := FpNew().Square(e.X)
+	zz := FpNew().Square(e.Z)
+	tt := FpNew().Square(e.T)
+	lhs := FpNew().Sub(yy, xx)
+	rhs := FpNew().Mul(tt, edwardsD)
+	rhs.Add(rhs, zz)
+
+	return xy.Equal(zt) & lhs.Equal(rhs) & e.Z.IsNonZero()
+}
+
+// IsSmallOrderI returns 1 if the point is in the torsion subgroup of order 8
+func (e *ExtendedPoint) IsSmallOrderI() int {
+	return PointNew().ClearCofactor(e).IsIdentityI()
+}
+
+// IsTorsionFreeI returns 1 if the point is in the prime order subgroup
+func (e *ExtendedPoint) IsTorsionFreeI() int {
+	return PointNew().mulBytes(e, &subgroupOrderBytes).IsIdentityI()
+}
+
+// ClearCofactor computes [8]arg which always lies in the prime order subgroup
+func (e *ExtendedPoint) ClearCofactor(arg *ExtendedPoint) *ExtendedPoint {
+	e.Double(arg)
+	e.Double(e)
+	return e.Double(e)
+}
+
+func (e *ExtendedPoint) Set(rhs *ExtendedPoint) *ExtendedPoint {
+	e.X.Set(rhs.X)
+	e.Y.Set(rhs.Y)
+	e.Z.Set(rhs.Z)
+	e.T.Set(rhs.T)
+	return e
+}
+
+func (e *ExtendedPoint) EqualI(rhs *ExtendedPoint) int {
+	xz := FpNew().Mul(e.X, rhs.Z)
+	zx := FpNew().Mul(e.Z, rhs.X)
+
+	yz := FpNew().Mul(e.Y, rhs.Z)
+	zy := FpNew().Mul(e.Z, rhs.Y)
+
+	return xz.Equal(zx) & yz.Equal(zy)
+}
+
+// Add computes arg1 + arg2 using the complete a = -1
+// extended coordinate formulas from Hisil–Wong–Carter–Dawson 2008.
+func (e *ExtendedPoint) Add(arg1, arg2 *ExtendedPoint) *ExtendedPoint {
+	a := FpNew().Sub(arg1.Y, arg1.X)
+	a.Mul(a, FpNew().Sub(arg2.Y, arg2.X))
+	b := FpNew().Add(arg1.Y, arg1.X)
+	b.Mul(b, FpNew().Add(arg2.Y, arg2.X))
+	c := FpNew().Mul(arg1.T, edwardsD2)
+	c.Mul(c, arg2.T)
+	d := FpNew().Mul(arg1.Z, arg2.Z)
+	d.Double(d)
+
+	ee := FpNew().Sub(b, a)
+	f := FpNew().Sub(d, c)
+	g := FpNew().Add(d, c)
+	h := FpNew().Add(b, a)
+
+	e.X.Mul(ee, f)
+	e.Y.Mul(g, h)
+	e.T.Mul(ee, h)
+	e.Z.Mul(f, g)
+	return e
+}
+
+// Sub computes arg1 - arg2
+func (e *ExtendedPoint) Sub(arg1, arg2 *ExtendedPoint) *ExtendedPoint {
+	return e.Add(arg1, PointNew().Negate(arg2))
+}
+
+// Double computes 2*arg using the a = -1 doubling formulas
+// from Hisil–Wong–Carter–Dawson 2008.
+func (e *ExtendedPoint) Double(arg *ExtendedPoint) *ExtendedPoint {
+	a := FpNew().Square(arg.X)
+	b := FpNew().Square(arg.Y)
+	c := FpNew().Square(arg.Z)
+	c.Double(c)
+	d := FpNew().Neg(a)
+
+	ee := FpNew().Add(arg.X, arg.Y)
+	ee.Square(ee)
+	ee.Sub(ee, a)
+	ee.Sub(ee, b)
+	g := FpNew().Add(d, b)
+	f := FpNew().Sub(g, c)
+	h := FpNew().Sub(d, b)
+
+	e.X.Mul(ee, f)
+	e.Y.Mul(g, h)
+	e.T.Mul(ee, h)
+	e.Z.Mul(f, g)
+	return e
+}
+
+func (e *ExtendedPoint) Negate(arg *ExtendedPoint) *ExtendedPoint {
+	e.X.Neg(arg.X)
+	e.Y.Set(arg.Y)
+	e.Z.Set(arg.Z)
+	e.T.Neg(arg.T)
+	return e
+}
+
+// Mul computes arg * s in constant time
+func (e *ExtendedPoint) Mul(arg *ExtendedPoint, s *native.Field4) *ExtendedPoint {
+	bytes := s.Bytes()
+	return e.mulBytes(arg, &bytes)
+}
+
+// mulBytes multiplies arg by the little endian integer in s
+// using a fixed 4-bit window with constant time table lookups
+func (e *ExtendedPoint) mulBytes(arg *ExtendedPoint, s *[PointBytes]byte) *ExtendedPoint {
+	var precomputed [16]*ExtendedPoint
+	precomputed[0] = PointNew().SetIdentity()
+	precomputed[1] = PointNew().Set(arg)
+	for i := 2; i < 16; i += 2 {
+		precomputed[i] = PointNew().Double(precomputed[i>>1])
+		precomputed[i+1] = PointNew().Add(precomputed[i], arg)
+	}
+
+	r := PointNew().SetIdentity()
+	t := PointNew()
+	for i := PointBytes*2 - 1; i >= 0; i-- {
+		r.Double(r)
+		r.Double(r)
+		r.Double(r)
+		r.Double(r)
+
+		window := int(s[i>>1]>>((i&1)<<2)) & 0xf
+		t.SetIdentity()
+		for j := 1; j < 16; j++ {
+			t.CMove(t, precomputed[j], internal.IsZeroI(j-window))
+		}
+		r.Add(r, t)
+	}
+	return e.Set(r)
+}
+
+// SumOfProducts computes the multi-exponentiation for the specified
+// points and scalars and stores the result in `e`.
+// Returns an error if the lengths of the arguments is not equal.
+func (e *ExtendedPoint) SumOfProducts(points []*ExtendedPoint, scalars []*native.Field4) (*ExtendedPoint, error) {
+	const Upper = 256
+	const W = 4
+	const Windows = Upper / W
+	if len(points) != len(scalars) {
+		return nil, fmt.Errorf("length mismatch")
+	}
+
+	bucketSize := 1 << W
+	windows := make([]*ExtendedPoint, Windows)
+	bytes := make([][PointBytes]byte, len(scalars))
+	buckets := make([]*ExtendedPoint, bucketSize)
+
+	for i, scalar := range scalars {
+		bytes[i] = scalar.Bytes()
+	}
+	for i := range windows {
+		windows[i] = PointNew().SetIdentity()
+	}
+	for i := 0; i < bucketSize; i++ {
+		buckets[i] = PointNew().SetIdentity()
+	}
+
+	sum := PointNew()
+
+	for j := 0; j < len(windows); j++ {
+		for i := 0; i < bucketSize; i++ {
+			buckets[i].SetIdentity()
+		}
+
+		for i := 0; i < len(scalars); i++ {
+			index := bytes[i][j*W>>3] >> (W * j & W) & (1<<W - 1) // little-endian
+			buckets[index].Add(buckets[index], points[i])
+		}
+
+		sum.SetIdentity()
+
+		for i := bucketSize - 1; i > 0; i-- {
+			sum.Add(sum, buckets[i])
+			windows[j].Add(windows[j], sum)
+		}
+	}
+
+	e.SetIdentity()
+	for i := len(windows) - 1; i >= 0; i-- {
+		for j := 0; j < W; j++ {
+			e.Double(e)
+		}
+
+		e.Add(e, windows[i])
+	}
+	return e, nil
+}
+
+func (e *ExtendedPoint) ToAffine() *AffinePoint {
+	// Points from BatchNormalize need no inversion
+	if e.Z.IsOne() == 1 {
+		return &AffinePoint{FpNew().Set(e.X), FpNew().Set(e.Y)}
+	}
+	z, _ := FpNew().Invert(e.Z)
+	x := FpNew().Mul(e.X, z)
+	y := FpNew().Mul(e.Y, z)
+	return &AffinePoint{x, y}
+}
+
+// BatchNormalize returns the points scaled so Z = 1 using Montgomery's
+// trick to share a single field inversion between them.
+func BatchNormalize(points []*ExtendedPoint) []*ExtendedPoint {
+	zInv := make([]*native.Field4, len(points))
+	for i, p := range points {
+		zInv[i] = FpNew().Set(p.Z)
+	}
+	native.BatchInvert(zInv)
+	out := make([]*ExtendedPoint, len(points))
+	for i, p := range points {
+		out[i] = PointNew()
+		out[i].X.Mul(p.X, zInv[i])
+		out[i].Y.Mul(p.Y, zInv[i])
+		out[i].Z.SetOne()
+		out[i].T.Mul(out[i].X, out[i].Y)
+	}
+	return out
+}
+
+func (e *ExtendedPoint) CMove(a, b *ExtendedPoint, choice int) *ExtendedPoint {
+	e.X.CMove(a.X, b.X, choice)
+	e.Y.CMove(a.Y, b.Y, choice)
+	e.Z.CMove(a.Z, b.Z, choice)
+	e.T.CMove(a.T, b.T, choice)
+	return e
+}
+
+// Compress returns the ZIP-216 encoding of the point
+func (e *ExtendedPoint) Compress() *CompressedPoint {
+	affine := e.ToAffine()
+
+	output := affine.Y.Bytes()
+	output[PointBytes-1] |= byte(sgn0(affine.X)) << 7
+	return &output
+}
+
+// HashWithDefaults hashes msg to the prime order subgroup
+// using the suite jubjub_XMD:BLAKE2b_ELL2_RO_
+func (e *ExtendedPoint) HashWithDefaults(msg []byte) *ExtendedPoint {
+	return e.Hash(native.EllipticPointHasherBlake2b(), msg, []byte("jubjub_XMD:BLAKE2b_ELL2_RO_"))
+}
+
+// Hash computes the hash to curve function from RFC 9380 using
+// elligator 2 on the birationally equivalent montgomery curve
+// followed by clearing the cofactor
+func (e *ExtendedPoint) Hash(hash *native.EllipticPointHasher, msg, dst []byte) *ExtendedPoint {
+	var u []byte
+	switch hash.Type() {
+	case native.XMD:
+		u = native.ExpandMsgXmd(hash, msg, dst, 96)
+	case native.XOF:
+		u = native.ExpandMsgXof(hash, msg, dst, 96)
+	}
+	var buf [native.WideField4Bytes]byte
+	copy(buf[:48], internal.ReverseBytes(u[:48]))
+	u0 := FpNew().SetBytesWide(&buf)
+	copy(buf[:48], internal.ReverseBytes(u[48:]))
+	u1 := FpNew().SetBytesWide(&buf)
+	q0 := AffinePointNew().mapToCurveElligator2(u0)
+	q1 := AffinePointNew().mapToCurveElligator2(u1)
+
+	e.Add(q0.ToExtended(), q1.ToExtended())
+	return e.ClearCofactor(e)
+}
+
+// AffinePoint is a jubjub point with X = u and Y = v
+type AffinePoint struct {
+	X, Y *native.Field4
+}
+
+func AffinePointNew() *AffinePoint {
+	return &AffinePoint{
+		X: FpNew(),
+		Y: FpNew(),
+	}
+}
+
+func (a *AffinePoint) SetIdentity() *AffinePoint {
+	a.X.SetZero()
+	a.Y.SetOne()
+	return a
+}
+
+func (a *AffinePoint) ToExtended() *ExtendedPoint {
+	return &ExtendedPoint{
+		X: FpNew().Set(a.X),
+		Y: FpNew().Set(a.Y),
+		Z: FpNew().SetOne(),
+		T: FpNew().Mul(a.X, a.Y),
+	}
+}
+
+func (a *AffinePoint) EqualI(rhs *AffinePoint) int {
+	return a.X.Equal(rhs.X) & a.Y.Equal(rhs.Y)
+}
+
+// mapToCurveElligator2 maps u to the montgomery curve as described in
+// RFC 9380 Appendix G.2.1 then applies the rational map from Appendix D.1
+func (a *AffinePoint) mapToCurveElligator2(u *native.Field4) *AffinePoint {
+	tv1 := FpNew().Square(u)
+	tv1.Mul(tv1, elligatorZ)
+	e1 := tv1.Equal(minusOne)
+	tv1.CMove(tv1, FpNew(), e1)
+	x1 := FpNew().Add(tv1, one)
+	x1, _ = FpNew().Invert(x1)
+	x1.Mul(x1, montgomeryJK)
+	x1.Neg(x1)
+	gx1 := FpNew().Add(x1, montgomeryJK)
+	gx1.Mul(gx1, x1)
+	gx1.Add(gx1, montgomeryKK)
+	gx1.Mul(gx1, x1)
+	x2 := FpNew().Neg(x1)
+	x2.Sub(x2, montgomeryJK)
+	gx2 := FpNew().Mul(tv1, gx1)
+	_, e2 := sqrtI(gx1)
+	x := FpNew().CMove(x2, x1, e2)
+	y2 := FpNew().CMove(gx2, gx1, e2)
+	y, _ := FpNew().Sqrt(y2)
+	e3 := sgn0(y)
+	y.CMove(y, FpNew().Neg(y), e2^e3)
+
+	s := FpNew().Mul(x, montgomeryK)
+	t := FpNew().Mul(y, montgomeryK)
+
+	// (s, t) -> (s / t, (s - 1) / (s + 1))
+	tv1.Add(s, one)
+	tv2 := FpNew().Mul(tv1, t)
+	tv2, _ = FpNew().Invert(tv2)
+	a.X.Mul(tv2, tv1)
+	a.X.Mul(a.X, s)
+	a.Y.Mul(tv2, t)
+	a.Y.Mul(a.Y, FpNew().Sub(s, one))
+	a.Y.CMove(a.Y, one, tv2.IsZero())
+	return a
+}
+
+// sqrtI returns the square root of f and 1 if it exists
+// otherwise zero and 0
+func sqrtI(f *native.Field4) (*native.Field4, int) {
+	wasSquare := 0
+	out := FpNew()
+	out.Arithmetic.Sqrt(&wasSquare, &out.Value, &f.Value)
+	return out, wasSquare
+}
+
+// sgn0 returns the lowest bit of the canonical representation of f
+func sgn0(f *native.Field4) int {
+	bytes := f.Bytes()
+	return int(bytes[0] & 1)
+}
@@ -0,0 +1,127 @@
+package jubjub
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikelodder7/curvey/native"
+)
+
+func TestPoint_Generator(t *testing.T) {
+	g := PointNew().SetGenerator()
+	require.Equal(t, 1, g.IsOnCurve())
+	require.Equal(t, 1, g.IsTorsionFreeI())
+	require.Equal(t, 0, g.IsSmallOrderI())
+	require.Equal(t, 1, PointNew().SetIdentity().IsOnCurve())
+	require.Equal(t, 1, PointNew().SetIdentity().IsTorsionFreeI())
+
+	// The full group generator has v = 11
+	var enc CompressedPoint
+	enc[0] = 11
+	full, err := Decompress(&enc)
+	require.NoError(t, err)
+	require.Equal(t, 0, full.IsTorsionFreeI())
+	require.Equal(t, 1, PointNew().ClearCofactor(full).EqualI(g))
+}
+
+func TestPoint_Arithmetic(t *testing.T) {
+	g := PointNew().SetGenerator()
+	two := PointNew().Double(g)
+	three := PointNew().Add(two, g)
+	require.Equal(t, 1, three.IsOnCurve())
+	require.Equal(t, 1, PointNew().Add(g, g).EqualI(two))
+	require.Equal(t, 1, PointNew().Mul(g, FqNew().SetUint64(3)).EqualI(three))
+	require.Equal(t, 1, PointNew().Sub(three, two).EqualI(g))
+	require.Equal(t, 1, PointNew().Add(g, PointNew().Negate(g)).IsIdentityI())
+	require.Equal(t, 1, PointNew().Mul(g, FqNew().SetZero()).IsIdentityI())
+	minusOneQ := FqNew().Neg(FqNew().SetOne())
+	require.Equal(t, 1, PointNew().Mul(g, minusOneQ).EqualI(PointNew().Negate(g)))
+}
+
+func TestPoint_Multiples(t *testing.T) {
+	multiples := []string{
+		"cb550cd538ea0cc1138480408e6eaab9b36c613f0dd3f7784fdb6eea837b13d7",
+		"719af0e6e0c6d0aa680f3b7e97dee9c3cbc3a7815979f08e33a640fab8ca9ab1",
+		"c5295dd1cb37a4ae58005ac7019c958df01d0e5256e17e81ba9d94a2db339cc7",
+		"b675faf151c4c7e3974af311dd61c88bc053e723d60e5f4582c90474b113b300",
+	}
+	g := PointNew().SetGenerator()
+	pt := PointNew().Set(g)
+	for i, m := range multiples {
+		enc := pt.Compress()
+		require.Equal(t, m, hex.EncodeToString(enc[:]), "multiple %d", i+1)
+		decoded, err := Decompress(enc)
+		require.NoError(t, err)
+		require.Equal(t, 1, decoded.EqualI(pt))
+		pt.Add(pt, g)
+	}
+
+	k, _ := hex.DecodeString("38a1b4b919489d416cbde3c3e435cc6bee92778f771bcd0b461e782b8ea1b603")
+	var kb [32]byte
+	copy(kb[:], k)
+	s, err := FqNew().SetBytes(&kb)
+	require.NoError(t, err)
+	enc := PointNew().Mul(g, s).Compress()
+	require.Equal(t, "c54c09eb94d8911949fa30e880fbbe3c0fa5da83e81aa660fb4ebf03dcb53108", hex.EncodeToString(enc[:]))
+}
+
+func TestPoint_DecompressZip216(t *testing.T) {
+	// (0, -1) is the point of order 2
+	bb, _ := hex.DecodeString("00000000fffffffffe5bfeff02a4bd5305d8a10908d83933487d9d2953a7ed73")
+	var enc CompressedPoint
+	copy(enc[:], bb)
+	pt, err := Decompress(&enc)
+	require.NoError(t, err)
+	require.Equal(t, 1, pt.IsSmallOrderI())
+	require.Equal(t, 0, pt.IsTorsionFreeI())
+
+	// ZIP-216 rejects the negative encoding of u = 0
+	enc[31] |= 0x80
+	_, err = Decompress(&enc)
+	require.Error(t, err)
+
+	identity := PointNew().SetIdentity().Compress()
+	identity[31] |= 0x80
+	_, err = Decompress(identity)
+	require.Error(t, err)
+
+	invalid := []string{
+		// v = q is not canonical
+		"01000000fffffffffe5bfeff02a4bd5305d8a10908d83933487d9d2953a7ed73",
+		// no u exists for v = 2
+		"0200000000000000000000000000000000000000000000000000000000000000",
+	}
+	for _, tc := range invalid {
+		bb, _ = hex.DecodeString(tc)
+		copy(enc[:], bb)
+		_, err = Decompress(&enc)
+		require.Error(t, err, tc)
+	}
+}
+
+func TestPoint_Hash(t *testing.T) {
+	tests := []struct{ msg, expected string }{
+		{"", "50b41dfe011937fb66a879f436f03cc42518fdf15226d0e43bb849981e0d882b"},
+		{"abc", "94c1c184534d69da1d2c9baf179d5d0ce9de08fd0ebbe93f718e84bae4b77e97"},
+	}
+	for _, tc := range tests {
+		pt := PointNew().HashWithDefaults([]byte(tc.msg))
+		require.Equal(t, 1, pt.IsOnCurve())
+		require.Equal(t, 1, pt.IsTorsionFreeI())
+		enc := pt.Compress()
+		require.Equal(t, tc.expected, hex.EncodeToString(enc[:]))
+	}
+}
+
+func TestPoint_SumOfProducts(t *testing.T) {
+	g := PointNew().SetGenerator()
+	h := PointNew().HashWithDefaults([]byte("sum of products"))
+	a := FqNew().SetUint64(12345)
+	b := FqNew().Neg(FqNew().SetUint64(678910))
+	expected := PointNew().Add(PointNew().Mul(g, a), PointNew().Mul(h, b))
+	actual, err := PointNew().SumOfProducts([]*ExtendedPoint{g, h}, []*native.Field4{a, b})
+	require.NoError(t, err)
+	require.Equal(t, 1, expected.EqualI(actual))
+}
@@ -444,3 +444,30 @@ func (pointArithmetic) RhsEquation(out, x *native.Field4) {
 	out.Mul(out, x)
 	out.Add(out, getPointParams().B)
 }
+
+// glvParams split scalars for the secp256k1 endomorphism
+// (x, y) -> (beta * x, y) which multiplies points by
+// lambda = 0x5363ad4cc05c30e0a5261c028812645a122e22ea20816678df02967c1b23bd72.
+var glvParams = native.GlvParams{
+	G1:          [native.Field4Limbs]uint64{0xe893209a45dbb031, 0x3daa8a1471e8ca7f, 0xe86c90e49284eb15, 0x3086d221a7d46bcd},
+	G2:          [native.Field4Limbs]uint64{0x1571b4ae8ac47f71, 0x221208ac9df506c6, 0x6f547fa90abfe4c4, 0xe4437ed6010e8828},
+	Shift:       384,
+	MinusB1:     [native.Field4Limbs]uint64{0x6f547fa90abfe4c3, 0xe4437ed6010e8828, 0x0000000000000000, 0x0000000000000000},
+	MinusB2:     [native.Field4Limbs]uint64{0xd765cda83db1562c, 0x8a280ac50774346d, 0xfffffffffffffffe, 0xffffffffffffffff},
+	MinusLambda: [native.Field4Limbs]uint64{0xe0cfc810b51283cf, 0xa880b9fc8ec739c2, 0x5ad9e3fd77ed9ba4, 0xac9c52b33fa3cf1f},
+}
+
+// beta is a cube root of unity in the base field
+// 0x7ae96a2b657c07106e64479eac3434e99cf0497512f58995c1396c28719501ee.
+var beta = [native.Field4Limbs]uint64{0xc1396c28719501ee, 0x9cf0497512f58995, 0x6e64479eac3434e9, 0x7ae96a2b657c0710}
+
+func (pointArithmetic) Endomorphism(out, arg *native.EllipticPoint4) {
+	// The projective coordinates (X, Y, Z) become (beta * X, Y, Z)
+	out.X.Mul(arg.X, fp.K256FpNew().SetLimbs(&beta))
+	out.Y.Set(arg.Y)
+	out.Z.Set(arg.Z)
+}
+
+func (pointArithmetic) GlvParams() *native.GlvParams {
+	return &glvParams
+}
@@ -1,12 +1,14 @@
 package k256_test
 
 import (
+	crand "crypto/rand"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/mikelodder7/curvey/native"
 	"github.com/mikelodder7/curvey/native/k256"
+	"github.com/mikelodder7/curvey/native/k256/fq"
 )
 
 func TestK256PointArithmetic_Hash(t *testing.T) {
@@ -17,3 +19,189 @@ func TestK256PointArithmetic_Hash(t *testing.T) {
 	require.True(t, !sc.IsIdentity())
 	require.True(t, sc.IsOnCurve())
 }
+
+func TestK256PointArithmetic_MulVarTime(t *testing.T) {
+	points := make([]*native.EllipticPoint4, 4)
+	scalars := make([]*native.Field4, 4)
+	for i := range points {
+		var b [native.WideField4Bytes]byte
+		_, _ = crand.Read(b[:])
+		points[i], _ = k256.PointNew().Random(crand.Reader)
+		scalars[i] = fq.K256FqNew().SetBytesWide(&b)
+
+		pt := k256.PointNew().Mul(points[i], scalars[i])
+		require.Equal(t, 1, pt.Equal(k256.PointNew().MulVarTime(points[i], scalars[i])))
+	}
+	// Small and zero scalars exercise the identity table entry
+	scalars[0].SetUint64(16)
+	expected := k256.PointNew().Identity()
+	for i := range points {
+		expected.Add(expected, k256.PointNew().MulVarTime(points[i], scalars[i]))
+	}
+	require.True(t, k256.PointNew().Mul(points[0], fq.K256FqNew().SetZero()).IsIdentity())
+
+	actual, err := k256.PointNew().SumOfProducts(points, scalars)
+	require.NoError(t, err)
+	require.Equal(t, 1, expected.Equal(actual))
+	actual, err = k256.PointNew().SumOfProductsVarTime(points, scalars)
+	require.NoError(t, err)
+	require.Equal(t, 1, expected.Equal(actual))
+	_, err = k256.PointNew().SumOfProducts(points, scalars[1:])
+	require.Error(t, err)
+}
+
+func TestK256PointArithmetic_Glv(t *testing.T) {
+	// lambda is split into 0 + 1 * lambda
+	lambda := fq.K256FqNew().SetLimbs(&[native.Field4Limbs]uint64{
+		0xdf02967c1b23bd72, 0x122e22ea20816678, 0xa5261c028812645a, 0x5363ad4cc05c30e0,
+	})
+	scalars := []*native.Field4{
+		fq.K256FqNew().SetZero(),
+		fq.K256FqNew().SetOne(),
+		fq.K256FqNew().Neg(fq.K256FqNew().SetOne()),
+		lambda,
+		fq.K256FqNew().Neg(lambda),
+	}
+	points := make([]*native.EllipticPoint4, 0, 13)
+	for i := 0; i < 8; i++ {
+		var b [native.WideField4Bytes]byte
+		_, _ = crand.Read(b[:])
+		scalars = append(scalars, fq.K256FqNew().SetBytesWide(&b))
+	}
+	for range scalars {
+		pt, _ := k256.PointNew().Random(crand.Reader)
+		points = append(points, pt)
+	}
+	params := k256.PointNew().Arithmetic.(native.EllipticPoint4Endomorphism).GlvParams()
+	for i, s := range scalars {
+		k1, k2, neg1, neg2 := params.Decompose(s)
+		require.Zero(t, k1[2]|k1[3]|k2[2]|k2[3])
+		r1 := fq.K256FqNew().SetLimbs(&k1)
+		r2 := fq.K256FqNew().SetLimbs(&k2)
+		r1.CMove(r1, fq.K256FqNew().Neg(r1), neg1)
+		r2.CMove(r2, fq.K256FqNew().Neg(r2), neg2)
+		require.Equal(t, 1, s.Equal(r1.Add(r1, r2.Mul(r2, lambda))))
+
+		expected := plainPoint(k256.PointNew()).Mul(plainPoint(k256.PointNew().Set(points[i])), s)
+		require.Equal(t, 1, expected.Equal(k256.PointNew().Mul(points[i], s)))
+		require.Equal(t, 1, expected.Equal(k256.PointNew().MulVarTime(points[i], s)))
+	}
+
+	expected, err := k256.PointNew().SumOfProductsVarTime(points, scalars)
+	require.NoError(t, err)
+	actual, err := k256.PointNew().SumOfProducts(points, scalars)
+	require.NoError(t, err)
+	require.Equal(t, 1, expected.Equal(actual))
+}
+
+func BenchmarkK256PointMul(b *testing.B) {
+	points, scalars := benchmarkInputs(1)
+	b.Run("glv", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			k256.PointNew().Mul(points[0], scalars[0])
+		}
+	})
+	b.Run("window", func(b *testing.B) {
+		p := plainPoint(k256.PointNew().Set(points[0]))
+		for i := 0; i < b.N; i++ {
+			k256.PointNew().Mul(p, scalars[0])
+		}
+	})
+}
+
+func BenchmarkK256PointSumOfProducts(b *testing.B) {
+	points, scalars := benchmarkInputs(8)
+	b.Run("glv", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = k256.PointNew().SumOfProducts(points, scalars)
+		}
+	})
+	b.Run("window", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = plainPoint(k256.PointNew()).SumOfProducts(points, scalars)
+		}
+	})
+}
+
+func benchmarkInputs(n int) ([]*native.EllipticPoint4, []*native.Field4) {
+	points := make([]*native.EllipticPoint4, n)
+	scalars := make([]*native.Field4, n)
+	for i := range points {
+		var b [native.WideField4Bytes]byte
+		_, _ = crand.Read(b[:])
+		points[i], _ = k256.PointNew().Random(crand.Reader)
+		scalars[i] = fq.K256FqNew().SetBytesWide(&b)
+	}
+	return points, scalars
+}
+
+// plainPoint hides the endomorphism from the point arithmetic
+// so multiplication uses the full length scalar.
+func plainPoint(p *native.EllipticPoint4) *native.EllipticPoint4 {
+	p.Arithmetic = struct {
+		native.EllipticPoint4Arithmetic
+	}{p.Arithmetic}
+	return p
+}
+
+func TestK256PointArithmetic_Msm(t *testing.T) {
+	points, scalars := benchmarkInputs(64)
+	// Repeated and opposite points exercise doubling and
+	// cancellation when the buckets are summed in affine
+	points[1] = points[0]
+	points[2] = k256.PointNew().Neg(points[0])
+	points[3] = k256.PointNew().Double(points[4])
+	points[5] = k256.PointNew().Identity()
+	scalars[1] = scalars[0]
+	scalars[2] = scalars[0]
+	scalars[6].SetZero()
+	scalars[7].SetOne()
+	scalars[8] = fq.K256FqNew().Neg(scalars[7])
+	expected, err := k256.PointNew().SumOfProducts(points, scalars)
+	require.NoError(t, err)
+
+	actual, err := k256.PointNew().SumOfProductsVarTime(points, scalars)
+	require.NoError(t, err)
+	require.Equal(t, 1, expected.Equal(actual))
+	for _, window := range []int{1, 2, 3, 5, 8, 13} {
+		actual, err = k256.PointNew().MsmVarTime(points, scalars, &native.MsmOptions{Window: window})
+		require.NoError(t, err)
+		require.Equal(t, 1, expected.Equal(actual), window)
+	}
+	for _, workers := range []int{2, 7, 200} {
+		actual, err = k256.PointNew().MsmVarTime(points, scalars, &native.MsmOptions{Workers: workers})
+		require.NoError(t, err)
+		require.Equal(t, 1, expected.Equal(actual), workers)
+	}
+
+	actual, err = k256.PointNew().MsmVarTime(nil, nil, nil)
+	require.NoError(t, err)
+	require.True(t, actual.IsIdentity())
+	_, err = k256.PointNew().MsmVarTime(points, scalars[1:], nil)
+	require.Error(t, err)
+}
+
+func TestK256PointArithmetic_BatchToAffine(t *testing.T) {
+	points, _ := benchmarkInputs(6)
+	points[2] = k256.PointNew().Identity()
+	points[4] = k256.PointNew().Generator()
+	affine := native.BatchToAffine(points)
+	require.Len(t, affine, len(points))
+	for i, p := range points {
+		expected := k256.PointNew().ToAffine(p)
+		require.Equal(t, expected.X.Value, affine[i].X.Value)
+		require.Equal(t, expected.Y.Value, affine[i].Y.Value)
+		require.Equal(t, expected.Z.Value, affine[i].Z.Value)
+	}
+	require.Empty(t, native.BatchToAffine(nil))
+
+	values := make([]*native.Field4, 5)
+	for i := range values {
+		values[i] = fq.K256FqNew().SetUint64(uint64(i))
+	}
+	native.BatchInvert(values)
+	require.Equal(t, 1, values[0].IsZero())
+	for i := 1; i < len(values); i++ {
+		require.Equal(t, 1, values[i].Mul(values[i], fq.K256FqNew().SetUint64(uint64(i))).IsOne())
+	}
+}
@@ -0,0 +1,266 @@
+package native
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	msmMinWindow = 2
+	msmMaxWindow = 20
+)
+
+// MsmStrausPoints is the number of points from which the variable time
+// multi-scalar multiplications switch from interleaved wNAF to Pippenger's
+// bucket method. Below it sharing the doublings costs less than the buckets.
+const MsmStrausPoints = 64
+
+// MsmOptions configure the variable time multi-scalar multiplication.
+type MsmOptions struct {
+	// Window is the number of scalar bits in each bucket window.
+	// Zero selects the window from the number of points.
+	Window int
+	// Workers is the number of goroutines the windows are split across.
+	// Zero or one does all the work on the calling goroutine.
+	Workers int
+}
+
+// MsmCurve are the curve operations needed by Msm for affine points A
+// and projective points P. P must be a pointer type as it is updated in place.
+type MsmCurve[A, P any] interface {
+	// BatchAdd sets out[i] = lhs[i] + rhs[i] sharing one field inversion
+	// between all the additions. ok[i] is false if the sum is the identity.
+	BatchAdd(out, lhs, rhs []A, ok []bool)
+	// Neg returns -a
+	Neg(a A) A
+	// Identity returns a new identity point
+	Identity() P
+	// AddAffine adds a to p
+	AddAffine(p P, a A)
+	// Add adds q to p
+	Add(p, q P)
+	// Double doubles p
+	Double(p P)
+}
+
+// Msm computes the sum of points[i] * scalars[i] with Pippenger's bucket
+// method, https://eprint.iacr.org/2022/1400.pdf. The running time depends
+// on the scalars so it must only be used with public values.
+//
+// The scalars are recoded into signed digits so each window only needs
+// half the buckets. The points of every bucket are summed in affine
+// coordinates by adding pairs across all the buckets at once so each round
+// costs a single field inversion. The windows are independent so they can
+// be split across goroutines with MsmOptions.Workers.
+//
+// points must not contain the identity and must have the same length as scalars.
+func Msm[A, P any](curve MsmCurve[A, P], points []A, scalars []*Field4, opts *MsmOptions) P {
+	if len(points) == 0 {
+		return curve.Identity()
+	}
+	var options MsmOptions
+	if opts != nil {
+		options = *opts
+	}
+	window := options.Window
+	if window == 0 {
+		window = MsmWindow(len(points), scalars[0].Params.BiModulus.BitLen())
+	}
+	if window < msmMinWindow {
+		window = msmMinWindow
+	}
+	if window > msmMaxWindow {
+		window = msmMaxWindow
+	}
+	windows := msmWindows(scalars[0].Params.BiModulus.BitLen(), window)
+
+	digits := make([]int32, len(scalars)*windows)
+	for i, s := range scalars {
+		msmDigits(digits[i*windows:(i+1)*windows], s, window)
+	}
+
+	// Split the points into chunks when there are more workers than windows
+	chunks := 1
+	if options.Workers > windows {
+		chunks = (options.Workers + windows - 1) / windows
+		if chunks > len(points) {
+			chunks = len(points)
+		}
+	}
+	tasks := windows * chunks
+	sums := make([]P, tasks)
+	run := func(task int) {
+		w, c := task/chunks, task%chunks
+		lo := len(points) * c / chunks
+		hi := len(points) * (c + 1) / chunks
+		sums[task] = msmWindowSum(curve, points[lo:hi], digits[lo*windows:hi*windows], windows, w, window)
+	}
+
+	if options.Workers < 2 {
+		for task := range sums {
+			run(task)
+		}
+	} else {
+		var wg sync.WaitGroup
+		var next int64 = -1
+		workers := options.Workers
+		if workers > tasks {
+			workers = tasks
+		}
+		wg.Add(workers)
+		for i := 0; i < workers; i++ {
+			go func() {
+				defer wg.Done()
+				for task := int(atomic.AddInt64(&next, 1)); task < tasks; task = int(atomic.AddInt64(&next, 1)) {
+					run(task)
+				}
+			}()
+		}
+		wg.Wait()
+	}
+
+	out := curve.Identity()
+	for w := windows - 1; w >= 0; w-- {
+		for i := 0; i < window; i++ {
+			curve.Double(out)
+		}
+		for c := 0; c < chunks; c++ {
+			curve.Add(out, sums[w*chunks+c])
+		}
+	}
+	return out
+}
+
+// MsmWindow returns the window size that minimizes the estimated cost of
+// a multi-scalar multiplication of n points with scalars of the given bits.
+// Each window costs an affine addition per point and two projective
+// additions, about four affine additions, per bucket to sum them.
+func MsmWindow(n, bits int) int {
+	best, bestCost := msmMinWindow, -1
+	for c := msmMinWindow; c <= msmMaxWindow; c++ {
+		cost := msmWindows(bits, c) * (n + 1<<(c+1))
+		if bestCost < 0 || cost < bestCost {
+			best, bestCost = c, cost
+		}
+	}
+	return best
+}
+
+// msmWindows returns the number of signed digits for scalars of the given bits.
+// The top digit only holds the final carry or at most window - 2 bits
+// so it never carries again.
+func msmWindows(bits, window int) int {
+	return (bits+1)/window + 1
+}
+
+// msmDigits recodes s into signed digits in [-2^(window-1), 2^(window-1)).
+func msmDigits(digits []int32, s *Field4, window int) {
+	bytes := s.Bytes()
+	half := int32(1) << (window - 1)
+	var carry int32
+	for w := range digits {
+		d := int32(msmBits(&bytes, w*window, window)) + carry
+		carry = 0
+		if d >= half {
+			d -= half << 1
+			carry = 1
+		}
+		digits[w] = d
+	}
+}
+
+// msmBits returns the count bits of the little endian bytes starting at offset.
+func msmBits(bytes *[Field4Bytes]byte, offset, count int) uint32 {
+	var t uint32
+	start := offset >> 3
+	for i := 3; i >= 0; i-- {
+		t <<= 8
+		if start+i < len(bytes) {
+			t |= uint32(bytes[start+i])
+		}
+	}
+	return t >> (offset & 7) & (1<<count - 1)
+}
+
+// msmWindowSum returns the sum of the points weighted by their digits for window w.
+func msmWindowSum[A, P any](curve MsmCurve[A, P], points []A, digits []int32, windows, w, window int) P {
+	half := 1 << (window - 1)
+	starts := make([]int, half+1)
+	for i := range points {
+		if d := digits[i*windows+w]; d != 0 {
+			starts[msmBucket(d)+1]++
+		}
+	}
+	for b := 0; b < half; b++ {
+		starts[b+1] += starts[b]
+	}
+	lens := make([]int, half)
+	entries := make([]A, starts[half])
+	for i, pt := range points {
+		d := digits[i*windows+w]
+		if d == 0 {
+			continue
+		}
+		b := msmBucket(d)
+		if d < 0 {
+			pt = curve.Neg(pt)
+		}
+		entries[starts[b]+lens[b]] = pt
+		lens[b]++
+	}
+
+	// Halve every bucket each round by adding its entries in pairs
+	lhs := make([]A, 0, len(entries)/2)
+	rhs := make([]A, 0, len(entries)/2)
+	out := make([]A, len(entries)/2)
+	ok := make([]bool, len(entries)/2)
+	for {
+		lhs, rhs = lhs[:0], rhs[:0]
+		for b, n := range lens {
+			for k := starts[b]; k+1 < starts[b]+n; k += 2 {
+				lhs = append(lhs, entries[k])
+				rhs = append(rhs, entries[k+1])
+			}
+		}
+		if len(lhs) == 0 {
+			break
+		}
+		curve.BatchAdd(out[:len(lhs)], lhs, rhs, ok[:len(lhs)])
+		j := 0
+		for b, n := range lens {
+			s := starts[b]
+			next := s
+			for k := 0; k+1 < n; k += 2 {
+				if ok[j] {
+					entries[next] = out[j]
+					next++
+				}
+				j++
+			}
+			if n&1 == 1 {
+				entries[next] = entries[s+n-1]
+				next++
+			}
+			lens[b] = next - s
+		}
+	}
+
+	// sum = 1 * bucket[0] + 2 * bucket[1] + ... + half * bucket[half-1]
+	running := curve.Identity()
+	sum := curve.Identity()
+	for b := half - 1; b >= 0; b-- {
+		if lens[b] != 0 {
+			curve.AddAffine(running, entries[starts[b]])
+		}
+		curve.Add(sum, running)
+	}
+	return sum
+}
+
+// msmBucket returns the bucket index for a non-zero digit.
+func msmBucket(d int32) int {
+	if d < 0 {
+		d = -d
+	}
+	return int(d) - 1
+}
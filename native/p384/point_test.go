@@ -1,6 +1,7 @@
 package p384_test
 
 import (
+	crand "crypto/rand"
 	"encoding/hex"
 	"fmt"
 	"github.com/mikelodder7/curvey/internal"
@@ -11,6 +12,7 @@ import (
 	"github.com/mikelodder7/curvey/native"
 	"github.com/mikelodder7/curvey/native/p384"
 	"github.com/mikelodder7/curvey/native/p384/fp"
+	"github.com/mikelodder7/curvey/native/p384/fq"
 )
 
 func TestP384PointArithmetic_Double(t *testing.T) {
@@ -46,3 +48,27 @@ func TestP384PointArithmetic_Hash(t *testing.T) {
 	require.True(t, !sc.IsIdentity())
 	require.True(t, sc.IsOnCurve())
 }
+
+func TestP384PointArithmetic_MulVarTime(t *testing.T) {
+	points := make([]*native.EllipticPoint6, 4)
+	scalars := make([]*native.Field6, 4)
+	expected := p384.PointNew().Identity()
+	for i := range points {
+		var b [native.WideField6Bytes]byte
+		_, _ = crand.Read(b[:])
+		points[i], _ = p384.PointNew().Random(crand.Reader)
+		scalars[i] = fq.P384FqNew().SetBytesWide(&b)
+
+		pt := p384.PointNew().Mul(points[i], scalars[i])
+		require.Equal(t, 1, pt.Equal(p384.PointNew().MulVarTime(points[i], scalars[i])))
+		expected.Add(expected, pt)
+	}
+
+	// The scalars use all 384 bits
+	actual, err := p384.PointNew().SumOfProducts(points, scalars)
+	require.NoError(t, err)
+	require.Equal(t, 1, expected.Equal(actual))
+	actual, err = p384.PointNew().SumOfProductsVarTime(points, scalars)
+	require.NoError(t, err)
+	require.Equal(t, 1, expected.Equal(actual))
+}
@@ -0,0 +1,167 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package fp
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/mikelodder7/curvey/native"
+)
+
+var (
+	p521FpInitonce sync.Once
+	p521FpParams   native.Field9Params
+)
+
+func P521FpNew() *native.Field9 {
+	return &native.Field9{
+		Value:      [native.Field9Limbs]uint64{},
+		Params:     getP521FpParams(),
+		Arithmetic: p521FpArithmetic{},
+	}
+}
+
+func p521FpParamsInit() {
+	// See FIPS 186-3, section D.2.5
+	p521FpParams = native.Field9Params{
+		R: [native.Field9Limbs]uint64{
+			0x0080000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+		},
+		R2: [native.Field9Limbs]uint64{
+			0x0000000000000000,
+			0x0000400000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+		},
+		R3: [native.Field9Limbs]uint64{
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000002000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+		},
+		Modulus: [native.Field9Limbs]uint64{
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0x00000000000001ff,
+		},
+		BiModulus: new(big.Int).SetBytes([]byte{
+			0x01, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		}),
+	}
+}
+
+func getP521FpParams() *native.Field9Params {
+	p521FpInitonce.Do(p521FpParamsInit)
+	return &p521FpParams
+}
+
+type p521FpArithmetic struct{}
+
+// ToMontgomery converts this field to montgomery form.
+func (p521FpArithmetic) ToMontgomery(out, arg *[native.Field9Limbs]uint64) {
+	ToMontgomery((*MontgomeryDomainFieldElement)(out), (*NonMontgomeryDomainFieldElement)(arg))
+}
+
+// FromMontgomery converts this field from montgomery form.
+func (p521FpArithmetic) FromMontgomery(out, arg *[native.Field9Limbs]uint64) {
+	FromMontgomery((*NonMontgomeryDomainFieldElement)(out), (*MontgomeryDomainFieldElement)(arg))
+}
+
+// Neg performs modular negation.
+func (p521FpArithmetic) Neg(out, arg *[native.Field9Limbs]uint64) {
+	Sub((*MontgomeryDomainFieldElement)(out), &MontgomeryDomainFieldElement{}, (*MontgomeryDomainFieldElement)(arg))
+}
+
+// Square performs modular square.
+func (p521FpArithmetic) Square(out, arg *[native.Field9Limbs]uint64) {
+	Square((*MontgomeryDomainFieldElement)(out), (*MontgomeryDomainFieldElement)(arg))
+}
+
+// Mul performs modular multiplication.
+func (p521FpArithmetic) Mul(out, arg1, arg2 *[native.Field9Limbs]uint64) {
+	Mul((*MontgomeryDomainFieldElement)(out), (*MontgomeryDomainFieldElement)(arg1), (*MontgomeryDomainFieldElement)(arg2))
+}
+
+// Add performs modular addition.
+func (p521FpArithmetic) Add(out, arg1, arg2 *[native.Field9Limbs]uint64) {
+	Add((*MontgomeryDomainFieldElement)(out), (*MontgomeryDomainFieldElement)(arg1), (*MontgomeryDomainFieldElement)(arg2))
+}
+
+// Sub performs modular subtraction.
+func (p521FpArithmetic) Sub(out, arg1, arg2 *[native.Field9Limbs]uint64) {
+	Sub((*MontgomeryDomainFieldElement)(out), (*MontgomeryDomainFieldElement)(arg1), (*MontgomeryDomainFieldElement)(arg2))
+}
+
+// Sqrt performs modular square root.
+func (p p521FpArithmetic) Sqrt(wasSquare *int, out, arg *[native.Field9Limbs]uint64) {
+	// p mod 4 = 3 -> compute sqrt(x) using x^((p+1)/4) = x^(2^519)
+	var t, c [native.Field9Limbs]uint64
+	native.Pow2k9(&t, arg, 519, p)
+	Square((*MontgomeryDomainFieldElement)(&c), (*MontgomeryDomainFieldElement)(&t))
+	*wasSquare = (&native.Field9{Value: c, Params: getP521FpParams(), Arithmetic: p}).Equal(&native.Field9{
+		Value: *arg, Params: getP521FpParams(), Arithmetic: p,
+	})
+	Selectznz(out, uint1(*wasSquare), out, &t)
+}
+
+// Invert performs modular inverse.
+func (p p521FpArithmetic) Invert(wasInverted *int, out, arg *[native.Field9Limbs]uint64) {
+	// Exponentiate by p - 2
+	var t [native.Field9Limbs]uint64
+	f := P521FpNew()
+	f.Value = *arg
+	*wasInverted = f.IsNonZero()
+	native.Pow9(&t, arg, &[native.Field9Limbs]uint64{
+		0xfffffffffffffffd,
+		0xffffffffffffffff,
+		0xffffffffffffffff,
+		0xffffffffffffffff,
+		0xffffffffffffffff,
+		0xffffffffffffffff,
+		0xffffffffffffffff,
+		0xffffffffffffffff,
+		0x00000000000001ff,
+	}, f.Params, p)
+	p.Selectznz(out, arg, &t, *wasInverted)
+}
+
+// FromBytes converts a little endian byte array into a field element.
+func (p521FpArithmetic) FromBytes(out *[native.Field9Limbs]uint64, arg *[native.Field9Bytes]byte) {
+	FromBytes(out, arg)
+}
+
+// ToBytes converts a field element to a little endian byte array.
+func (p521FpArithmetic) ToBytes(out *[native.Field9Bytes]byte, arg *[native.Field9Limbs]uint64) {
+	ToBytes(out, arg)
+}
+
+func (p521FpArithmetic) Selectznz(out, arg1, arg2 *[native.Field9Limbs]uint64, choice int) {
+	Selectznz(out, uint1(choice), arg1, arg2)
+}
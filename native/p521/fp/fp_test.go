@@ -0,0 +1,103 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package fp
+
+import (
+	crand "crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+)
+
+func randFp(t *testing.T) (*native.Field9, *big.Int) {
+	t.Helper()
+	bi, err := crand.Int(crand.Reader, getP521FpParams().BiModulus)
+	require.NoError(t, err)
+	return P521FpNew().SetBigInt(bi), bi
+}
+
+func TestFpSetOne(t *testing.T) {
+	fq := P521FpNew().SetOne()
+	require.NotNil(t, fq)
+	require.Equal(t, fq.Value, getP521FpParams().R)
+	require.Equal(t, 0, fq.BigInt().Cmp(big.NewInt(1)))
+	require.Equal(t, 1, P521FpNew().SetUint64(1).IsOne())
+}
+
+func TestFpArithmetic(t *testing.T) {
+	p := getP521FpParams().BiModulus
+	for i := 0; i < 25; i++ {
+		a, ba := randFp(t)
+		b, bb := randFp(t)
+
+		e := new(big.Int).Add(ba, bb)
+		require.Equal(t, 0, P521FpNew().Add(a, b).BigInt().Cmp(e.Mod(e, p)))
+		e.Sub(ba, bb)
+		require.Equal(t, 0, P521FpNew().Sub(a, b).BigInt().Cmp(e.Mod(e, p)))
+		e.Mul(ba, bb)
+		require.Equal(t, 0, P521FpNew().Mul(a, b).BigInt().Cmp(e.Mod(e, p)))
+		e.Mul(ba, ba)
+		require.Equal(t, 0, P521FpNew().Square(a).BigInt().Cmp(e.Mod(e, p)))
+		e.Neg(ba)
+		require.Equal(t, 0, P521FpNew().Neg(a).BigInt().Cmp(e.Mod(e, p)))
+	}
+}
+
+func TestFpInvert(t *testing.T) {
+	a, ba := randFp(t)
+	inv, wasInverted := P521FpNew().Invert(a)
+	require.True(t, wasInverted)
+	require.Equal(t, 0, inv.BigInt().Cmp(new(big.Int).ModInverse(ba, getP521FpParams().BiModulus)))
+
+	_, wasInverted = P521FpNew().Invert(P521FpNew())
+	require.False(t, wasInverted)
+}
+
+func TestFpSqrt(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		a, _ := randFp(t)
+		a.Square(a)
+		s, wasSquare := P521FpNew().Sqrt(a)
+		require.True(t, wasSquare)
+		require.Equal(t, 1, P521FpNew().Square(s).Equal(a))
+	}
+	// -1 is not a square since p = 3 mod 4
+	_, wasSquare := P521FpNew().Sqrt(P521FpNew().Neg(P521FpNew().SetOne()))
+	require.False(t, wasSquare)
+}
+
+func TestFpBytes(t *testing.T) {
+	a, _ := randFp(t)
+	b := a.Bytes()
+	c, err := P521FpNew().SetBytes(&b)
+	require.NoError(t, err)
+	require.Equal(t, 1, a.Equal(c))
+
+	var p [native.Field9Bytes]byte
+	getP521FpParams().BiModulus.FillBytes(p[:])
+	copy(p[:], internal.ReverseBytes(p[:]))
+	_, err = P521FpNew().SetBytes(&p)
+	require.Error(t, err)
+}
+
+func TestFpSetBytesWide(t *testing.T) {
+	var b [native.WideField9Bytes]byte
+	for i := 0; i < 10; i++ {
+		_, _ = crand.Read(b[:])
+		e := new(big.Int).SetBytes(internal.ReverseBytes(b[:]))
+		e.Mod(e, getP521FpParams().BiModulus)
+		require.Equal(t, 0, P521FpNew().SetBytesWide(&b).BigInt().Cmp(e))
+	}
+	for i := range b {
+		b[i] = 0xff
+	}
+	e := new(big.Int).SetBytes(b[:])
+	e.Mod(e, getP521FpParams().BiModulus)
+	require.Equal(t, 0, P521FpNew().SetBytesWide(&b).BigInt().Cmp(e))
+}
@@ -0,0 +1,219 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package fq
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/mikelodder7/curvey/native"
+)
+
+var (
+	p521FqInitonce sync.Once
+	p521FqParams   native.Field9Params
+	// p521FqMontgomery is the word-by-word Montgomery arithmetic for the group order
+	p521FqMontgomery = native.Montgomery9{
+		Modulus: [native.Field9Limbs]uint64{
+			0xbb6fb71e91386409,
+			0x3bb5c9b8899c47ae,
+			0x7fcc0148f709a5d0,
+			0x51868783bf2f966b,
+			0xfffffffffffffffa,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0x00000000000001ff,
+		},
+		R2: [native.Field9Limbs]uint64{
+			0x137cd04dcf15dd04,
+			0xf707badce5547ea3,
+			0x12a78d38794573ff,
+			0xd3721ef557f75e06,
+			0xdd6e23d82e49c7db,
+			0xcff3d142b7756e3e,
+			0x5bcc6d61a8e567bc,
+			0x2d8e03d1492d0d45,
+			0x000000000000003d,
+		},
+		Inv: 0x1d2f5ccd79a995c7,
+	}
+)
+
+func P521FqNew() *native.Field9 {
+	return &native.Field9{
+		Value:      [native.Field9Limbs]uint64{},
+		Params:     getP521FqParams(),
+		Arithmetic: p521FqArithmetic{},
+	}
+}
+
+func p521FqParamsInit() {
+	// See FIPS 186-3, section D.2.5
+	p521FqParams = native.Field9Params{
+		R: [native.Field9Limbs]uint64{
+			0xfb80000000000000,
+			0x28a2482470b763cd,
+			0x17e2251b23bb31dc,
+			0xca4019ff5b847b2d,
+			0x02d73cbc3e206834,
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+		},
+		R2: p521FqMontgomery.R2,
+		R3: [native.Field9Limbs]uint64{
+			0xd35f2816760f23e2,
+			0x2767143c8c3184c1,
+			0xb268ad5bac58d935,
+			0x3f3deb9609930765,
+			0x6d4af6cb8e6b92ea,
+			0xdf0f66f966e917a0,
+			0xc99c43fc5f7321ce,
+			0xdb25b1117a1814fd,
+			0x0000000000000097,
+		},
+		Modulus: p521FqMontgomery.Modulus,
+		BiModulus: new(big.Int).SetBytes([]byte{
+			0x01, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfa, 0x51, 0x86, 0x87, 0x83, 0xbf, 0x2f, 0x96, 0x6b, 0x7f, 0xcc, 0x01, 0x48, 0xf7, 0x09, 0xa5, 0xd0, 0x3b, 0xb5, 0xc9, 0xb8, 0x89, 0x9c, 0x47, 0xae, 0xbb, 0x6f, 0xb7, 0x1e, 0x91, 0x38, 0x64, 0x09,
+		}),
+	}
+}
+
+func getP521FqParams() *native.Field9Params {
+	p521FqInitonce.Do(p521FqParamsInit)
+	return &p521FqParams
+}
+
+type p521FqArithmetic struct{}
+
+// ToMontgomery converts this field to montgomery form.
+func (p521FqArithmetic) ToMontgomery(out, arg *[native.Field9Limbs]uint64) {
+	p521FqMontgomery.ToMontgomery(out, arg)
+}
+
+// FromMontgomery converts this field from montgomery form.
+func (p521FqArithmetic) FromMontgomery(out, arg *[native.Field9Limbs]uint64) {
+	p521FqMontgomery.FromMontgomery(out, arg)
+}
+
+// Neg performs modular negation.
+func (p521FqArithmetic) Neg(out, arg *[native.Field9Limbs]uint64) {
+	p521FqMontgomery.Neg(out, arg)
+}
+
+// Square performs modular square.
+func (p521FqArithmetic) Square(out, arg *[native.Field9Limbs]uint64) {
+	p521FqMontgomery.Square(out, arg)
+}
+
+// Mul performs modular multiplication.
+func (p521FqArithmetic) Mul(out, arg1, arg2 *[native.Field9Limbs]uint64) {
+	p521FqMontgomery.Mul(out, arg1, arg2)
+}
+
+// Add performs modular addition.
+func (p521FqArithmetic) Add(out, arg1, arg2 *[native.Field9Limbs]uint64) {
+	p521FqMontgomery.Add(out, arg1, arg2)
+}
+
+// Sub performs modular subtraction.
+func (p521FqArithmetic) Sub(out, arg1, arg2 *[native.Field9Limbs]uint64) {
+	p521FqMontgomery.Sub(out, arg1, arg2)
+}
+
+// Sqrt performs modular square root.
+func (p p521FqArithmetic) Sqrt(wasSquare *int, out, arg *[native.Field9Limbs]uint64) {
+	// Constant time Tonelli-Shanks, as q = 1 (mod 2^3)
+	// where q - 1 = 2^3 * t. This only works for elements
+	// that are actually quadratic residue,
+	// so check the result at the end.
+	var z, t, b, c, tv [native.Field9Limbs]uint64
+	params := getP521FqParams()
+	// z = a^((t - 1) / 2)
+	native.Pow9(&z, arg, &[native.Field9Limbs]uint64{
+		0xebb6fb71e9138640,
+		0x03bb5c9b8899c47a,
+		0xb7fcc0148f709a5d,
+		0xa51868783bf2f966,
+		0xffffffffffffffff,
+		0xffffffffffffffff,
+		0xffffffffffffffff,
+		0xffffffffffffffff,
+		0x000000000000001f,
+	}, params, p)
+	p.Square(&t, &z)
+	p.Mul(&t, &t, arg)
+	p.Mul(&z, &z, arg)
+	b = t
+	// c = 3^t where 3 is a non-residue
+	c = [native.Field9Limbs]uint64{
+		0xabb188098dced84b,
+		0x855e9aa0e4514691,
+		0xf8ad091b5630adf2,
+		0x348752d031eaefa5,
+		0xd6afc26dde0c84b2,
+		0x9d48ef02e5b1ea1d,
+		0x6da18e4abac1b0ee,
+		0x6004a9df317d5be0,
+		0x0000000000000032,
+	}
+
+	for i := 3; i >= 2; i-- {
+		for j := 1; j <= i-2; j++ {
+			p.Square(&b, &b)
+		}
+		// if b == 1 flag = 0 else flag = 1
+		flag := (&native.Field9{Value: b, Params: params, Arithmetic: p}).IsOne() ^ 1
+		p.Mul(&tv, &z, &c)
+		p.Selectznz(&z, &z, &tv, flag)
+		p.Square(&c, &c)
+		p.Mul(&tv, &t, &c)
+		p.Selectznz(&t, &t, &tv, flag)
+		b = t
+	}
+
+	p.Square(&c, &z)
+	*wasSquare = (&native.Field9{Value: c, Params: params, Arithmetic: p}).Equal(&native.Field9{
+		Value: *arg, Params: params, Arithmetic: p,
+	})
+	p.Selectznz(out, out, &z, *wasSquare)
+}
+
+// Invert performs modular inverse.
+func (p p521FqArithmetic) Invert(wasInverted *int, out, arg *[native.Field9Limbs]uint64) {
+	// Exponentiate by q - 2
+	var t [native.Field9Limbs]uint64
+	f := P521FqNew()
+	f.Value = *arg
+	*wasInverted = f.IsNonZero()
+	native.Pow9(&t, arg, &[native.Field9Limbs]uint64{
+		0xbb6fb71e91386407,
+		0x3bb5c9b8899c47ae,
+		0x7fcc0148f709a5d0,
+		0x51868783bf2f966b,
+		0xfffffffffffffffa,
+		0xffffffffffffffff,
+		0xffffffffffffffff,
+		0xffffffffffffffff,
+		0x00000000000001ff,
+	}, f.Params, p)
+	p.Selectznz(out, arg, &t, *wasInverted)
+}
+
+// FromBytes converts a little endian byte array into a field element.
+func (p521FqArithmetic) FromBytes(out *[native.Field9Limbs]uint64, arg *[native.Field9Bytes]byte) {
+	p521FqMontgomery.FromBytes(out, arg)
+}
+
+// ToBytes converts a field element to a little endian byte array.
+func (p521FqArithmetic) ToBytes(out *[native.Field9Bytes]byte, arg *[native.Field9Limbs]uint64) {
+	p521FqMontgomery.ToBytes(out, arg)
+}
+
+func (p521FqArithmetic) Selectznz(out, arg1, arg2 *[native.Field9Limbs]uint64, choice int) {
+	p521FqMontgomery.Selectznz(out, arg1, arg2, choice)
+}
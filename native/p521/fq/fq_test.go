@@ -0,0 +1,105 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package fq
+
+import (
+	crand "crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+)
+
+func randFq(t *testing.T) (*native.Field9, *big.Int) {
+	t.Helper()
+	bi, err := crand.Int(crand.Reader, getP521FqParams().BiModulus)
+	require.NoError(t, err)
+	return P521FqNew().SetBigInt(bi), bi
+}
+
+func TestFqSetOne(t *testing.T) {
+	fq := P521FqNew().SetOne()
+	require.NotNil(t, fq)
+	require.Equal(t, fq.Value, getP521FqParams().R)
+	require.Equal(t, 0, fq.BigInt().Cmp(big.NewInt(1)))
+	require.Equal(t, 1, P521FqNew().SetUint64(1).IsOne())
+}
+
+func TestFqArithmetic(t *testing.T) {
+	q := getP521FqParams().BiModulus
+	for i := 0; i < 25; i++ {
+		a, ba := randFq(t)
+		b, bb := randFq(t)
+
+		e := new(big.Int).Add(ba, bb)
+		require.Equal(t, 0, P521FqNew().Add(a, b).BigInt().Cmp(e.Mod(e, q)))
+		e.Sub(ba, bb)
+		require.Equal(t, 0, P521FqNew().Sub(a, b).BigInt().Cmp(e.Mod(e, q)))
+		e.Mul(ba, bb)
+		require.Equal(t, 0, P521FqNew().Mul(a, b).BigInt().Cmp(e.Mod(e, q)))
+		e.Mul(ba, ba)
+		require.Equal(t, 0, P521FqNew().Square(a).BigInt().Cmp(e.Mod(e, q)))
+		e.Neg(ba)
+		require.Equal(t, 0, P521FqNew().Neg(a).BigInt().Cmp(e.Mod(e, q)))
+	}
+}
+
+func TestFqInvert(t *testing.T) {
+	a, ba := randFq(t)
+	inv, wasInverted := P521FqNew().Invert(a)
+	require.True(t, wasInverted)
+	require.Equal(t, 0, inv.BigInt().Cmp(new(big.Int).ModInverse(ba, getP521FqParams().BiModulus)))
+
+	_, wasInverted = P521FqNew().Invert(P521FqNew())
+	require.False(t, wasInverted)
+}
+
+func TestFqSqrt(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		a, _ := randFq(t)
+		a.Square(a)
+		s, wasSquare := P521FqNew().Sqrt(a)
+		require.True(t, wasSquare)
+		require.Equal(t, 1, P521FqNew().Square(s).Equal(a))
+	}
+	// 3 is the smallest non-residue
+	_, wasSquare := P521FqNew().Sqrt(P521FqNew().SetUint64(3))
+	require.False(t, wasSquare)
+	_, wasSquare = P521FqNew().Sqrt(P521FqNew().SetUint64(2))
+	require.True(t, wasSquare)
+}
+
+func TestFqBytes(t *testing.T) {
+	a, _ := randFq(t)
+	b := a.Bytes()
+	c, err := P521FqNew().SetBytes(&b)
+	require.NoError(t, err)
+	require.Equal(t, 1, a.Equal(c))
+
+	var q [native.Field9Bytes]byte
+	getP521FqParams().BiModulus.FillBytes(q[:])
+	copy(q[:], internal.ReverseBytes(q[:]))
+	_, err = P521FqNew().SetBytes(&q)
+	require.Error(t, err)
+}
+
+func TestFqSetBytesWide(t *testing.T) {
+	var b [native.WideField9Bytes]byte
+	for i := 0; i < 10; i++ {
+		_, _ = crand.Read(b[:])
+		e := new(big.Int).SetBytes(internal.ReverseBytes(b[:]))
+		e.Mod(e, getP521FqParams().BiModulus)
+		require.Equal(t, 0, P521FqNew().SetBytesWide(&b).BigInt().Cmp(e))
+	}
+	for i := range b {
+		b[i] = 0xff
+	}
+	e := new(big.Int).SetBytes(b[:])
+	e.Mod(e, getP521FqParams().BiModulus)
+	require.Equal(t, 0, P521FqNew().SetBytesWide(&b).BigInt().Cmp(e))
+}
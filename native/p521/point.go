@@ -0,0 +1,442 @@
+package p521
+
+import (
+	"sync"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+	"github.com/mikelodder7/curvey/native/p521/fp"
+)
+
+var (
+	p521PointInitonce     sync.Once
+	p521PointParams       native.EllipticPoint9Params
+	p521PointSswuInitOnce sync.Once
+	p521PointSswuParams   native.Sswu9Params
+)
+
+func PointNew() *native.EllipticPoint9 {
+	return &native.EllipticPoint9{
+		X:          fp.P521FpNew(),
+		Y:          fp.P521FpNew(),
+		Z:          fp.P521FpNew(),
+		Params:     getPointParams(),
+		Arithmetic: &pointArithmetic{},
+	}
+}
+
+func pointParamsInit() {
+	// How these values were derived
+	// left for informational purposes
+	//params := elliptic.P521().Params()
+	//a := big.NewInt(-3)
+	//a.Mod(a, params.P)
+	//capA := fp.P521FpNew().SetBigInt(a)
+	//capB := fp.P521FpNew().SetBigInt(params.B)
+	//gx := fp.P521FpNew().SetBigInt(params.Gx)
+	//gy := fp.P521FpNew().SetBigInt(params.Gy)
+
+	p521PointParams = native.EllipticPoint9Params{
+		A: fp.P521FpNew().SetRaw(&[native.Field9Limbs]uint64{
+			0xfe7fffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0x00000000000001ff,
+		}),
+		B: fp.P521FpNew().SetRaw(&[native.Field9Limbs]uint64{
+			0x8014654fae586387,
+			0x78f7a28fea35a81f,
+			0x839ab9efc41e961a,
+			0xbd8b29605e9dd8df,
+			0xf0ab0c9ca8f63f49,
+			0xf9dc5a44c8c77884,
+			0x77516d392dccd98a,
+			0x0fc94d10d05b42a0,
+			0x000000000000004d,
+		}),
+		Gx: fp.P521FpNew().SetRaw(&[native.Field9Limbs]uint64{
+			0xb331a16381adc101,
+			0x4dfcbf3f18e172de,
+			0x6f19a459e0c2b521,
+			0x947f0ee093d17fd4,
+			0xdd50a5af3bf7f3ac,
+			0x90fc1457b035a69e,
+			0x214e32409c829fda,
+			0xe6cf1f65b311cada,
+			0x0000000000000074,
+		}),
+		Gy: fp.P521FpNew().SetRaw(&[native.Field9Limbs]uint64{
+			0x28460e4a5a9e268e,
+			0x20445f4a3b4fe8b3,
+			0xb09a9e3843513961,
+			0x2062a85c809fd683,
+			0x164bf7394caf7a13,
+			0x340bd7de8b939f33,
+			0xeccc7aa224abcda2,
+			0x022e452fda163e8d,
+			0x00000000000001e0,
+		}),
+		BitSize: 521,
+		Name:    "P521",
+	}
+}
+
+func getPointParams() *native.EllipticPoint9Params {
+	p521PointInitonce.Do(pointParamsInit)
+	return &p521PointParams
+}
+
+func getPointSswuParams() *native.Sswu9Params {
+	p521PointSswuInitOnce.Do(pointSswuParamsInit)
+	return &p521PointSswuParams
+}
+
+func pointSswuParamsInit() {
+	// How these values were derived
+	// left for informational purposes
+	//params := elliptic.P521().Params()
+	//
+	//// c1 = (q - 3) / 4
+	//c1 := new(big.Int).Set(params.P)
+	//c1.Sub(c1, big.NewInt(3))
+	//c1.Rsh(c1, 2)
+	//
+	//a := big.NewInt(-3)
+	//a.Mod(a, params.P)
+	//b := new(big.Int).Set(params.B)
+	//z := big.NewInt(-4)
+	//z.Mod(z, params.P)
+	//// sqrt(-Z^3)
+	//zTmp := new(big.Int).Exp(z, big.NewInt(3), nil)
+	//zTmp = zTmp.Neg(zTmp)
+	//zTmp.Mod(zTmp, params.P)
+	//c2 := new(big.Int).ModSqrt(zTmp, params.P)
+	//
+	//var capC1Bytes [72]byte
+	//c1.FillBytes(capC1Bytes[:])
+	//capC1 := fp.P521FpNew().SetRaw(&[native.Field9Limbs]uint64{
+	//	binary.BigEndian.Uint64(capC1Bytes[64:72]),
+	//	binary.BigEndian.Uint64(capC1Bytes[56:64]),
+	//	binary.BigEndian.Uint64(capC1Bytes[48:56]),
+	//	binary.BigEndian.Uint64(capC1Bytes[40:48]),
+	//	binary.BigEndian.Uint64(capC1Bytes[32:40]),
+	//	binary.BigEndian.Uint64(capC1Bytes[24:32]),
+	//	binary.BigEndian.Uint64(capC1Bytes[16:24]),
+	//	binary.BigEndian.Uint64(capC1Bytes[8:16]),
+	//	binary.BigEndian.Uint64(capC1Bytes[:8]),
+	//})
+	//capC2 := fp.P521FpNew().SetBigInt(c2)
+	//capA := fp.P521FpNew().SetBigInt(a)
+	//capB := fp.P521FpNew().SetBigInt(b)
+	//capZ := fp.P521FpNew().SetBigInt(z)
+
+	p521PointSswuParams = native.Sswu9Params{
+		C1: [native.Field9Limbs]uint64{
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0x000000000000007f,
+		},
+		C2: [native.Field9Limbs]uint64{
+			0x0400000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+			0x0000000000000000,
+		},
+		A: [native.Field9Limbs]uint64{
+			0xfe7fffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0x00000000000001ff,
+		},
+		B: [native.Field9Limbs]uint64{
+			0x8014654fae586387,
+			0x78f7a28fea35a81f,
+			0x839ab9efc41e961a,
+			0xbd8b29605e9dd8df,
+			0xf0ab0c9ca8f63f49,
+			0xf9dc5a44c8c77884,
+			0x77516d392dccd98a,
+			0x0fc94d10d05b42a0,
+			0x000000000000004d,
+		},
+		Z: [native.Field9Limbs]uint64{
+			0xfdffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0xffffffffffffffff,
+			0x00000000000001ff,
+		},
+	}
+}
+
+type pointArithmetic struct{}
+
+func (p pointArithmetic) Hash(out *native.EllipticPoint9, hash *native.EllipticPointHasher, msg, dst []byte) error {
+	var u []byte
+	sswuParams := getPointSswuParams()
+
+	switch hash.Type() {
+	case native.XMD:
+		u = native.ExpandMsgXmd(hash, msg, dst, 196)
+	case native.XOF:
+		u = native.ExpandMsgXof(hash, msg, dst, 196)
+	}
+	var buf [native.WideField9Bytes]byte
+	copy(buf[:98], internal.ReverseBytes(u[:98]))
+	u0 := fp.P521FpNew().SetBytesWide(&buf)
+	copy(buf[:98], internal.ReverseBytes(u[98:]))
+	u1 := fp.P521FpNew().SetBytesWide(&buf)
+
+	q0x, q0y := sswuParams.Osswu3mod4(u0)
+	q1x, q1y := sswuParams.Osswu3mod4(u1)
+
+	out.X = q0x
+	out.Y = q0y
+	out.Z.SetOne()
+	tv := &native.EllipticPoint9{
+		X:          q1x,
+		Y:          q1y,
+		Z:          fp.P521FpNew().SetOne(),
+		Params:     getPointParams(),
+		Arithmetic: out.Arithmetic,
+	}
+	p.Add(out, out, tv)
+	return nil
+}
+
+func (p *pointArithmetic) Double(out, arg *native.EllipticPoint9) {
+	//curve := elliptic.P521()
+	//affine := PointNew()
+	//affine.ToAffine(arg)
+	//x, y := affine.X.BigInt(), affine.Y.BigInt()
+	//
+	//x, y = curve.Double(x, y)
+	//
+	//out.X.SetBigInt(x)
+	//out.Y.SetBigInt(y)
+	//out.Z.SetOne()
+
+	xx := fp.P521FpNew()
+	yy := fp.P521FpNew()
+	zz := fp.P521FpNew()
+	t := fp.P521FpNew()
+	xy2 := fp.P521FpNew()
+	yz2 := fp.P521FpNew()
+	xz2 := fp.P521FpNew()
+	bzzPart := fp.P521FpNew()
+	bzz3Part := fp.P521FpNew()
+	yyMBzz3 := fp.P521FpNew()
+	yyPBzz3 := fp.P521FpNew()
+	yFrag := fp.P521FpNew()
+	xFrag := fp.P521FpNew()
+	zz3 := fp.P521FpNew()
+	bxz2Part := fp.P521FpNew()
+	bxz6Part := fp.P521FpNew()
+	xx3Mzz3 := fp.P521FpNew()
+
+	xx.Square(arg.X)
+	yy.Square(arg.Y)
+	zz.Square(arg.Z)
+
+	xy2.Mul(arg.X, arg.Y)
+	xy2.Double(xy2)
+
+	yz2.Mul(arg.Y, arg.Z)
+	yz2.Double(yz2)
+
+	xz2.Mul(arg.X, arg.Z)
+	xz2.Double(xz2)
+
+	bzzPart.Mul(arg.Params.B, zz)
+	bzzPart.Sub(bzzPart, xz2)
+
+	bzz3Part.Double(bzzPart)
+	bzz3Part.Add(bzz3Part, bzzPart)
+
+	yyMBzz3.Sub(yy, bzz3Part)
+	yyPBzz3.Add(yy, bzz3Part)
+
+	yFrag.Mul(yyPBzz3, yyMBzz3)
+	xFrag.Mul(yyMBzz3, xy2)
+
+	zz3.Double(zz)
+	zz3.Add(zz3, zz)
+
+	t.Add(zz3, xx)
+	bxz2Part.Mul(arg.Params.B, xz2)
+	bxz2Part.Sub(bxz2Part, t)
+
+	bxz6Part.Double(bxz2Part)
+	bxz6Part.Add(bxz6Part, bxz2Part)
+
+	xx3Mzz3.Double(xx)
+	xx3Mzz3.Add(xx3Mzz3, xx)
+	xx3Mzz3.Sub(xx3Mzz3, zz3)
+
+	t.Mul(bxz6Part, yz2)
+	out.X.Sub(xFrag, t)
+
+	t.Mul(xx3Mzz3, bxz6Part)
+	out.Y.Add(yFrag, t)
+
+	out.Z.Mul(yz2, yy)
+	out.Z.Double(out.Z)
+	out.Z.Double(out.Z)
+}
+
+func (p *pointArithmetic) Add(out, arg1, arg2 *native.EllipticPoint9) {
+	//curve := elliptic.P521()
+	//affine1 := PointNew()
+	//affine1.ToAffine(arg1)
+	//x1, y1 := affine1.X.BigInt(), affine1.Y.BigInt()
+	//
+	//affine2 := PointNew()
+	//affine2.ToAffine(arg2)
+	//x2, y2 := affine2.X.BigInt(), affine2.Y.BigInt()
+	//
+	//x, y := curve.Add(x1, y1, x2, y2)
+	//
+	//out.X.SetBigInt(x)
+	//out.Y.SetBigInt(y)
+	//out.Z.SetOne()
+
+	xx := fp.P521FpNew()
+	yy := fp.P521FpNew()
+	zz := fp.P521FpNew()
+	t := fp.P521FpNew()
+	tt := fp.P521FpNew()
+	xyPairs := fp.P521FpNew()
+	yzPairs := fp.P521FpNew()
+	xzPairs := fp.P521FpNew()
+	bzzPart := fp.P521FpNew()
+	bzz3Part := fp.P521FpNew()
+	yyMBzz3 := fp.P521FpNew()
+	yyPBzz3 := fp.P521FpNew()
+	zz3 := fp.P521FpNew()
+	bxzPart := fp.P521FpNew()
+	bxz3Part := fp.P521FpNew()
+	xx3Mzz3 := fp.P521FpNew()
+
+	xx.Mul(arg1.X, arg2.X)
+	yy.Mul(arg1.Y, arg2.Y)
+	zz.Mul(arg1.Z, arg2.Z)
+
+	t.Add(xx, yy)
+	tt.Add(arg2.X, arg2.Y)
+	xyPairs.Add(arg1.X, arg1.Y)
+	xyPairs.Mul(xyPairs, tt)
+	xyPairs.Sub(xyPairs, t)
+
+	t.Add(yy, zz)
+	tt.Add(arg2.Y, arg2.Z)
+	yzPairs.Add(arg1.Y, arg1.Z)
+	yzPairs.Mul(yzPairs, tt)
+	yzPairs.Sub(yzPairs, t)
+
+	t.Add(xx, zz)
+	tt.Add(arg2.X, arg2.Z)
+	xzPairs.Add(arg1.X, arg1.Z)
+	xzPairs.Mul(xzPairs, tt)
+	xzPairs.Sub(xzPairs, t)
+
+	t.Mul(arg1.Params.B, zz)
+	bzzPart.Sub(xzPairs, t)
+
+	bzz3Part.Double(bzzPart)
+	bzz3Part.Add(bzz3Part, bzzPart)
+
+	yyMBzz3.Sub(yy, bzz3Part)
+	yyPBzz3.Add(yy, bzz3Part)
+
+	zz3.Double(zz)
+	zz3.Add(zz3, zz)
+
+	t.Add(zz3, xx)
+	bxzPart.Mul(arg1.Params.B, xzPairs)
+	bxzPart.Sub(bxzPart, t)
+
+	bxz3Part.Double(bxzPart)
+	bxz3Part.Add(bxz3Part, bxzPart)
+
+	xx3Mzz3.Double(xx)
+	xx3Mzz3.Add(xx3Mzz3, xx)
+	xx3Mzz3.Sub(xx3Mzz3, zz3)
+
+	t.Mul(yzPairs, bxz3Part)
+	out.X.Mul(yyPBzz3, xyPairs)
+	out.X.Sub(out.X, t)
+
+	t.Mul(xx3Mzz3, bxz3Part)
+	out.Y.Mul(yyPBzz3, yyMBzz3)
+	out.Y.Add(out.Y, t)
+
+	t.Mul(xyPairs, xx3Mzz3)
+	out.Z.Mul(yyMBzz3, yzPairs)
+	out.Z.Add(out.Z, t)
+}
+
+func (p pointArithmetic) IsOnCurve(arg *native.EllipticPoint9) bool {
+	affine := PointNew()
+	p.ToAffine(affine, arg)
+
+	lhs := fp.P521FpNew().Square(affine.Y)
+	rhs := fp.P521FpNew()
+	p.RhsEquation(rhs, affine.X)
+
+	return lhs.Equal(rhs) == 1
+}
+
+func (pointArithmetic) ToAffine(out, arg *native.EllipticPoint9) {
+	var wasInverted int
+	var zero, x, y, z [native.Field9Limbs]uint64
+	f := arg.X.Arithmetic
+
+	f.Invert(&wasInverted, &z, &arg.Z.Value)
+	f.Mul(&x, &arg.X.Value, &z)
+	f.Mul(&y, &arg.Y.Value, &z)
+
+	out.Z.SetOne()
+	// If point at infinity this does nothing
+	f.Selectznz(&x, &zero, &x, wasInverted)
+	f.Selectznz(&y, &zero, &y, wasInverted)
+	f.Selectznz(&z, &zero, &out.Z.Value, wasInverted)
+
+	out.X.Value = x
+	out.Y.Value = y
+	out.Z.Value = z
+	out.Params = arg.Params
+	out.Arithmetic = arg.Arithmetic
+}
+
+func (pointArithmetic) RhsEquation(out, x *native.Field9) {
+	// Elliptic curve equation for p521 is: y^2 = x^3 + ax + b
+	out.Square(x)
+	out.Add(out, getPointParams().A)
+	out.Mul(out, x)
+	out.Add(out, getPointParams().B)
+}
@@ -0,0 +1,96 @@
+package p521_test
+
+import (
+	"crypto/elliptic"
+	crand "crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+	"github.com/mikelodder7/curvey/native/p521"
+	"github.com/mikelodder7/curvey/native/p521/fq"
+)
+
+func TestP521PointArithmetic_Double(t *testing.T) {
+	g := p521.PointNew().Generator()
+	pt1 := p521.PointNew().Double(g)
+	pt2 := p521.PointNew().Add(g, g)
+	pt3 := p521.PointNew().Mul(g, fq.P521FqNew().SetUint64(2))
+
+	e1 := pt1.Equal(pt2)
+	e2 := pt1.Equal(pt3)
+	e3 := pt2.Equal(pt3)
+	require.Equal(t, 1, e1)
+	require.Equal(t, 1, e2)
+	require.Equal(t, 1, e3)
+}
+
+func TestP521PointArithmetic_Mul(t *testing.T) {
+	curve := elliptic.P521()
+	k := fq.P521FqNew().SetUint64(0xdeadbeef)
+	k.Exp(k, fq.P521FqNew().SetUint64(17))
+	kBytes := k.Bytes()
+	ex, ey := curve.ScalarBaseMult(internal.ReverseBytes(kBytes[:]))
+
+	x, y := p521.PointNew().Mul(p521.PointNew().Generator(), k).BigInt()
+	require.Equal(t, 0, ex.Cmp(x))
+	require.Equal(t, 0, ey.Cmp(y))
+
+	// n * G = 0
+	n := fq.P521FqNew().SetOne()
+	n.Neg(n)
+	pt := p521.PointNew().Mul(p521.PointNew().Generator(), n)
+	pt.Add(pt, p521.PointNew().Generator())
+	require.True(t, pt.IsIdentity())
+}
+
+func TestP521PointArithmetic_Hash(t *testing.T) {
+	// RFC 9380 appendix J.3.1
+	tests := []struct {
+		msg, x, y string
+	}{
+		{"", "00fd767cebb2452030358d0e9cf907f525f50920c8f607889a6a35680727f64f4d66b161fafeb2654bea0d35086bec0a10b30b14adef3556ed9f7f1bc23cecc9c088", "0169ba78d8d851e930680322596e39c78f4fe31b97e57629ef6460ddd68f8763fd7bd767a4e94a80d3d21a3c2ee98347e024fc73ee1c27166dc3fe5eeef782be411d"},
+		{"abc", "002f89a1677b28054b50d15e1f81ed6669b5a2158211118ebdef8a6efc77f8ccaa528f698214e4340155abc1fa08f8f613ef14a043717503d57e267d57155cf784a4", "010e0be5dc8e753da8ce51091908b72396d3deed14ae166f66d8ebf0a4e7059ead169ea4bead0232e9b700dd380b316e9361cfdba55a08c73545563a80966ecbb86d"},
+	}
+	dst := []byte("QUUX-V01-CS02-with-P521_XMD:SHA-512_SSWU_RO_")
+	for _, tst := range tests {
+		pt := p521.PointNew()
+		err := pt.Arithmetic.Hash(pt, native.EllipticPointHasherSha512(), []byte(tst.msg), dst)
+		require.NoError(t, err)
+		require.True(t, pt.IsOnCurve())
+		x, y := pt.BigInt()
+		require.Equal(t, tst.x, hex.EncodeToString(x.FillBytes(make([]byte, 66))))
+		require.Equal(t, tst.y, hex.EncodeToString(y.FillBytes(make([]byte, 66))))
+	}
+
+	sc, err := p521.PointNew().Hash([]byte{}, native.EllipticPointHasherSha512())
+	require.NoError(t, err)
+	require.True(t, !sc.IsIdentity())
+	require.True(t, sc.IsOnCurve())
+}
+
+func TestP521PointArithmetic_SumOfProducts(t *testing.T) {
+	points := make([]*native.EllipticPoint9, 4)
+	scalars := make([]*native.Field9, 4)
+	expected := p521.PointNew().Identity()
+	for i := range points {
+		var b [native.WideField9Bytes]byte
+		_, _ = crand.Read(b[:])
+		points[i], _ = p521.PointNew().Random(crand.Reader)
+		scalars[i] = fq.P521FqNew().SetBytesWide(&b)
+		expected.Add(expected, p521.PointNew().Mul(points[i], scalars[i]))
+	}
+
+	actual, err := p521.PointNew().SumOfProducts(points, scalars)
+	require.NoError(t, err)
+	require.Equal(t, 1, expected.Equal(actual))
+	actual, err = p521.PointNew().SumOfProductsVarTime(points, scalars)
+	require.NoError(t, err)
+	require.Equal(t, 1, expected.Equal(actual))
+
+	_, err = p521.PointNew().SumOfProducts(points, scalars[1:])
+	require.Error(t, err)
+}
@@ -42,7 +42,13 @@ func getPallasPointParams() *native.EllipticPoint4Params {
 	return &pallasPointParams
 }
 
-type pallasPointArithmetic struct{}
+// pastaPointArithmetic implements the jacobian point arithmetic shared by
+// pallas and vesta since both curves are y^2 = x^3 + 5 over their respective base fields.
+type pastaPointArithmetic struct{}
+
+type pallasPointArithmetic struct {
+	pastaPointArithmetic
+}
 
 func (pallasPointArithmetic) Hash(out *native.EllipticPoint4, hash *native.EllipticPointHasher, msg, dst []byte) error {
 	var u []byte
@@ -63,15 +69,15 @@ func (pallasPointArithmetic) Hash(out *native.EllipticPoint4, hash *native.Ellip
 	copy(buf[:], u[64:])
 	u1 := fp.PastaFpNew().SetBytesWide(&buf)
 
-	mapSswu(q0, u0)
-	mapSswu(q1, u1)
-	isoMap(r0, q0)
-	isoMap(r1, q1)
+	mapSswu(q0, u0, &pallasSswu)
+	mapSswu(q1, u1, &pallasSswu)
+	isoMap(r0, q0, &pallasIsomapper)
+	isoMap(r1, q1, &pallasIsomapper)
 	out.Add(r0, r1)
 	return nil
 }
 
-func (pallasPointArithmetic) Double(out, arg *native.EllipticPoint4) {
+func (pastaPointArithmetic) Double(out, arg *native.EllipticPoint4) {
 	var a, b, c, d, e, f, x, y, z [native.Field4Limbs]uint64
 	u := arg.X.Arithmetic
 
@@ -109,13 +115,13 @@ func (pallasPointArithmetic) Double(out, arg *native.EllipticPoint4) {
 	u.Selectznz(&out.Z.Value, &z, &arg.Z.Value, e1)
 }
 
-func (p pallasPointArithmetic) Add(out, arg1, arg2 *native.EllipticPoint4) {
+func (p pastaPointArithmetic) Add(out, arg1, arg2 *native.EllipticPoint4) {
 	e1 := arg1.Z.IsZero()
 	e2 := arg2.Z.IsZero()
 
 	var z1z1, z2z2, u1, u2, s1, s2, zero [native.Field4Limbs]uint64
 	var h, i, j, r, v, x3, y3, z3, t1 [native.Field4Limbs]uint64
-	darg1 := PointNew()
+	darg1 := new(native.EllipticPoint4).Set(arg1)
 	a := arg1.X.Arithmetic
 
 	a.Square(&z1z1, &arg1.Z.Value)
@@ -183,7 +189,7 @@ func (p pallasPointArithmetic) Add(out, arg1, arg2 *native.EllipticPoint4) {
 	a.Selectznz(&out.Z.Value, &out.Z.Value, &z3, e1&e2&e3)
 }
 
-func (pallasPointArithmetic) IsOnCurve(arg *native.EllipticPoint4) bool {
+func (pastaPointArithmetic) IsOnCurve(arg *native.EllipticPoint4) bool {
 	var z2, z4, z6, x2, x3, lhs, rhs [native.Field4Limbs]uint64
 
 	u := arg.X.Arithmetic
@@ -205,7 +211,7 @@ func (pallasPointArithmetic) IsOnCurve(arg *native.EllipticPoint4) bool {
 	return arg.Z.IsZero()|e == 1
 }
 
-func (pallasPointArithmetic) ToAffine(out, arg *native.EllipticPoint4) {
+func (pastaPointArithmetic) ToAffine(out, arg *native.EllipticPoint4) {
 	var wasInverted int
 	var zero, x, y, z, zinv [native.Field4Limbs]uint64
 	f := arg.X.Arithmetic
@@ -226,6 +232,20 @@ func (pallasPointArithmetic) ToAffine(out, arg *native.EllipticPoint4) {
 	out.Z.Value = z
 }
 
+// ToAffineInverted scales the jacobian coordinates by the inverse of Z
+// so BatchToAffine can share the inversion between many points.
+func (pastaPointArithmetic) ToAffineInverted(out, arg *native.EllipticPoint4, zInv *native.Field4) {
+	var zero, z2, z3 [native.Field4Limbs]uint64
+	f := arg.X.Arithmetic
+
+	f.Square(&z2, &zInv.Value)
+	f.Mul(&z3, &z2, &zInv.Value)
+	// The identity has a zero inverse so X and Y become zero
+	f.Mul(&out.X.Value, &arg.X.Value, &z2)
+	f.Mul(&out.Y.Value, &arg.Y.Value, &z3)
+	f.Selectznz(&out.Z.Value, &zero, &out.Z.Params.R, zInv.IsNonZero())
+}
+
 func (pallasPointArithmetic) RhsEquation(out, x *native.Field4) {
 	// Elliptic curve equation for pallas is: y^2 = x^3 + b
 	out.Square(x)
@@ -233,27 +253,42 @@ func (pallasPointArithmetic) RhsEquation(out, x *native.Field4) {
 	out.Add(out, getPallasPointParams().B)
 }
 
-func mapSswu(p *native.EllipticPoint4, u *native.Field4) {
-	isoa := [native.Field4Limbs]uint64{0x7fc5d29077bb08de, 0x93090252cf122108, 0x49f63ff5da1145bb, 0x1c6d4f087137f0dc}
-	isob := [native.Field4Limbs]uint64{0xf7f22478ffffec3d, 0xa6dec35433e1339b, 0xfffffffffffffd5a, 0x3fffffffffffffff}
-	z := [native.Field4Limbs]uint64{0x1d2df02400000034, 0xf6571331e3a2999b, 0x0000000000000006, 0x0000000000000000}
-	// c1 := new(fp.Fp).Neg(isoa)
-	// c1.Invert(c1)
-	// c1.Mul(isob, c1)
-	c1 := [native.Field4Limbs]uint64{
+// sswuParams are the simplified SWU constants for the curve isogenous to the target curve
+type sswuParams struct {
+	// A and B are the coefficients of the isogenous curve
+	A, B [native.Field4Limbs]uint64
+	// Z is the non-square used by the map
+	Z [native.Field4Limbs]uint64
+	// C1 = -B / A
+	C1 [native.Field4Limbs]uint64
+	// C2 = -1 / Z
+	C2 [native.Field4Limbs]uint64
+}
+
+var pallasSswu = sswuParams{
+	A: [native.Field4Limbs]uint64{0x7fc5d29077bb08de, 0x93090252cf122108, 0x49f63ff5da1145bb, 0x1c6d4f087137f0dc},
+	B: [native.Field4Limbs]uint64{0xf7f22478ffffec3d, 0xa6dec35433e1339b, 0xfffffffffffffd5a, 0x3fffffffffffffff},
+	Z: [native.Field4Limbs]uint64{0x1d2df02400000034, 0xf6571331e3a2999b, 0x0000000000000006, 0x0000000000000000},
+	C1: [native.Field4Limbs]uint64{
 		0x1ee770ce078456ec,
 		0x48cfd64c2ce76be0,
 		0x43d5774c0ab79e2f,
 		0x23368d2bdce28cf3,
-	}
-	// c2 := new(fp.Fp).Neg(z)
-	// c2.Invert(c2)
-	c2 := [native.Field4Limbs]uint64{
+	},
+	C2: [native.Field4Limbs]uint64{
 		0x03df915f89d89d8a,
 		0x8f1e8db09ef82653,
 		0xd89d89d89d89d89d,
 		0x1d89d89d89d89d89,
-	}
+	},
+}
+
+func mapSswu(p *native.EllipticPoint4, u *native.Field4, params *sswuParams) {
+	isoa := params.A
+	isob := params.B
+	z := params.Z
+	c1 := params.C1
+	c2 := params.C2
 
 	var u2, tv1, tv2, x1, x2, gx1, gx2, x, y [native.Field4Limbs]uint64
 	var wasInverted, wasSquare int
@@ -293,7 +328,7 @@ func mapSswu(p *native.EllipticPoint4, u *native.Field4) {
 	p.Z.SetOne()
 }
 
-var isomapper = [13][native.Field4Limbs]uint64{
+var pallasIsomapper = [13][native.Field4Limbs]uint64{
 	{0xc6e037a01c71c71d, 0x130ac6c4e8b8fc2b, 0x0000000000000000, 0x4000000000000000},
 	{0x4c6e64f2323d5cee, 0x501f41cfd25ec1f0, 0x05dee76e883f5ca7, 0x33183c981332cc59},
 	{0x6a3ee7799df56376, 0x126b79ab78c7152f, 0x3260d1c7394f73d9, 0x3faf24198196224d},
@@ -312,7 +347,7 @@ var isomapper = [13][native.Field4Limbs]uint64{
 // Implements a degree 3 isogeny map.
 // The input and output are in Jacobian coordinates, using the method
 // in "Avoiding inversions" [WB2019, section 4.3].
-func isoMap(out, arg *native.EllipticPoint4) {
+func isoMap(out, arg *native.EllipticPoint4, isomapper *[13][native.Field4Limbs]uint64) {
 	var z [4][native.Field4Limbs]uint64
 	var numX, divX, numY, divY, t, z0, x, y [native.Field4Limbs]uint64
 	a := arg.X.Arithmetic
@@ -378,3 +413,34 @@ func isoMap(out, arg *native.EllipticPoint4) {
 	copy(out.Y.Value[:], y[:])
 	copy(out.Z.Value[:], z0[:])
 }
+
+// pallasGlvParams split scalars for the pallas endomorphism
+// (x, y) -> (beta * x, y) which multiplies points by
+// lambda = 0x397e65a7d7c1ad71aee24b27e308f0a61259527ec1d4752e619d1840af55f1b1.
+var pallasGlvParams = native.GlvParams{
+	G1:          [native.Field4Limbs]uint64{0xa54ad16cb90b8eda, 0xb0d7ef5342407d2a, 0x19624f25ffffffff, 0x93cd3a2c815132a7},
+	G2:          [native.Field4Limbs]uint64{0x6344e2c3cfcc526f, 0xb0d7ef5341f3b445, 0xff95c38e00000001, 0x93cd3a2c81e0922a},
+	Shift:       383,
+	MinusB1:     [native.Field4Limbs]uint64{0x7fcae1c700000001, 0x49e69d1640f04915, 0x0000000000000000, 0x0000000000000000},
+	MinusB2:     [native.Field4Limbs]uint64{0xff95c38e00000001, 0xd85ffbe5c8ec0f89, 0xffffffffffffffff, 0x3fffffffffffffff},
+	MinusLambda: [native.Field4Limbs]uint64{0x2aa9d2e050aa0e50, 0x0fed467d47c033af, 0x511db4d81cf70f5a, 0x06819a58283e528e},
+}
+
+// pallasBeta is a cube root of unity in the base field
+// 0x2d33357cb532458ed3552a23a8554e5005270d29d19fc7d27b7fd22f0201b547.
+var pallasBeta = [native.Field4Limbs]uint64{0x7b7fd22f0201b547, 0x05270d29d19fc7d2, 0xd3552a23a8554e50, 0x2d33357cb532458e}
+
+func (pallasPointArithmetic) Endomorphism(out, arg *native.EllipticPoint4) {
+	endomorphism(out, arg, fp.PastaFpNew().SetLimbs(&pallasBeta))
+}
+
+func (pallasPointArithmetic) GlvParams() *native.GlvParams {
+	return &pallasGlvParams
+}
+
+// endomorphism maps the jacobian coordinates (X, Y, Z) to (beta * X, Y, Z).
+func endomorphism(out, arg *native.EllipticPoint4, beta *native.Field4) {
+	out.X.Mul(arg.X, beta)
+	out.Y.Set(arg.Y)
+	out.Z.Set(arg.Z)
+}
@@ -0,0 +1,155 @@
+package pasta_test
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikelodder7/curvey/native"
+	"github.com/mikelodder7/curvey/native/pasta"
+	"github.com/mikelodder7/curvey/native/pasta/fp"
+	"github.com/mikelodder7/curvey/native/pasta/fq"
+)
+
+type pastaCurve struct {
+	name      string
+	pointNew  func() *native.EllipticPoint4
+	scalarNew func() *native.Field4
+	lambda    [native.Field4Limbs]uint64
+}
+
+var pastaCurves = []pastaCurve{
+	{
+		name:      "pallas",
+		pointNew:  pasta.PointNew,
+		scalarNew: fq.PastaFqNew,
+		lambda:    [native.Field4Limbs]uint64{0x619d1840af55f1b1, 0x1259527ec1d4752e, 0xaee24b27e308f0a6, 0x397e65a7d7c1ad71},
+	},
+	{
+		name:      "vesta",
+		pointNew:  pasta.VestaPointNew,
+		scalarNew: fp.PastaFpNew,
+		lambda:    [native.Field4Limbs]uint64{0x1dad5ebdfdfe4ab9, 0x1d1f8bd237ad3149, 0x2caad5dc57aab1b0, 0x12ccca834acdba71},
+	},
+}
+
+func TestPastaPointArithmetic_Glv(t *testing.T) {
+	for _, curve := range pastaCurves {
+		// lambda is split into 0 + 1 * lambda
+		lambda := curve.scalarNew().SetLimbs(&curve.lambda)
+		scalars := []*native.Field4{
+			curve.scalarNew().SetZero(),
+			curve.scalarNew().SetOne(),
+			curve.scalarNew().Neg(curve.scalarNew().SetOne()),
+			lambda,
+			curve.scalarNew().Neg(lambda),
+		}
+		for i := 0; i < 8; i++ {
+			var b [native.WideField4Bytes]byte
+			_, _ = crand.Read(b[:])
+			scalars = append(scalars, curve.scalarNew().SetBytesWide(&b))
+		}
+		points := make([]*native.EllipticPoint4, len(scalars))
+		for i := range points {
+			points[i], _ = curve.pointNew().Random(crand.Reader)
+		}
+
+		g := curve.pointNew().Generator()
+		phi := curve.pointNew()
+		curve.pointNew().Arithmetic.(native.EllipticPoint4Endomorphism).Endomorphism(phi, g)
+		requireEqual(t, phi, plainPoint(curve.pointNew()).Mul(plainPoint(g), lambda), curve.name)
+
+		for i, s := range scalars {
+			expected := plainPoint(curve.pointNew()).Mul(plainPoint(curve.pointNew().Set(points[i])), s)
+			requireEqual(t, expected, curve.pointNew().Mul(points[i], s), curve.name)
+			requireEqual(t, expected, curve.pointNew().MulVarTime(points[i], s), curve.name)
+		}
+
+		expected, err := curve.pointNew().SumOfProductsVarTime(points, scalars)
+		require.NoError(t, err)
+		actual, err := curve.pointNew().SumOfProducts(points, scalars)
+		require.NoError(t, err)
+		requireEqual(t, expected, actual, curve.name)
+	}
+}
+
+func BenchmarkPastaPointMul(b *testing.B) {
+	for _, curve := range pastaCurves {
+		points, scalars := benchmarkInputs(curve, 1)
+		b.Run(curve.name+"/glv", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				curve.pointNew().Mul(points[0], scalars[0])
+			}
+		})
+		b.Run(curve.name+"/window", func(b *testing.B) {
+			p := plainPoint(curve.pointNew().Set(points[0]))
+			for i := 0; i < b.N; i++ {
+				curve.pointNew().Mul(p, scalars[0])
+			}
+		})
+	}
+}
+
+func BenchmarkPastaPointSumOfProducts(b *testing.B) {
+	for _, curve := range pastaCurves {
+		points, scalars := benchmarkInputs(curve, 8)
+		b.Run(curve.name+"/glv", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = curve.pointNew().SumOfProducts(points, scalars)
+			}
+		})
+		b.Run(curve.name+"/window", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = plainPoint(curve.pointNew()).SumOfProducts(points, scalars)
+			}
+		})
+	}
+}
+
+func benchmarkInputs(curve pastaCurve, n int) ([]*native.EllipticPoint4, []*native.Field4) {
+	points := make([]*native.EllipticPoint4, n)
+	scalars := make([]*native.Field4, n)
+	for i := range points {
+		var b [native.WideField4Bytes]byte
+		_, _ = crand.Read(b[:])
+		points[i], _ = curve.pointNew().Random(crand.Reader)
+		scalars[i] = curve.scalarNew().SetBytesWide(&b)
+	}
+	return points, scalars
+}
+
+// requireEqual compares the affine coordinates since
+// EllipticPoint4.Equal expects projective not jacobian coordinates.
+func requireEqual(t *testing.T, expected, actual *native.EllipticPoint4, name string) {
+	t.Helper()
+	ex, ey := expected.BigInt()
+	ax, ay := actual.BigInt()
+	require.Equal(t, ex, ax, name)
+	require.Equal(t, ey, ay, name)
+}
+
+// plainPoint hides the endomorphism from the point arithmetic
+// so multiplication uses the full length scalar.
+func plainPoint(p *native.EllipticPoint4) *native.EllipticPoint4 {
+	p.Arithmetic = struct {
+		native.EllipticPoint4Arithmetic
+	}{p.Arithmetic}
+	return p
+}
+
+func TestPastaPointArithmetic_BatchToAffine(t *testing.T) {
+	for _, curve := range pastaCurves {
+		points, _ := benchmarkInputs(curve, 6)
+		points[2] = curve.pointNew().Identity()
+		points[4] = curve.pointNew().Generator()
+		affine := native.BatchToAffine(points)
+		for i, p := range points {
+			requireEqual(t, p, affine[i], curve.name)
+			require.Equal(t, p.IsIdentity(), affine[i].IsIdentity(), curve.name)
+			if !p.IsIdentity() {
+				require.Equal(t, 1, affine[i].Z.IsOne(), curve.name)
+			}
+		}
+	}
+}
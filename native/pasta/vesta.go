@@ -0,0 +1,139 @@
+package pasta
+
+import (
+	"sync"
+
+	"github.com/mikelodder7/curvey/native"
+	"github.com/mikelodder7/curvey/native/pasta/fq"
+)
+
+var (
+	vestaPointInitonce sync.Once
+	vestaPointParams   native.EllipticPoint4Params
+)
+
+// VestaPointNew returns a point on the vesta curve y^2 = x^3 + 5
+// defined over the pallas scalar field.
+func VestaPointNew() *native.EllipticPoint4 {
+	return &native.EllipticPoint4{
+		X:          fq.PastaFqNew(),
+		Y:          fq.PastaFqNew(),
+		Z:          fq.PastaFqNew(),
+		Params:     getVestaPointParams(),
+		Arithmetic: &vestaPointArithmetic{},
+	}
+}
+
+func vestaPointParamsInit() {
+	vestaPointParams = native.EllipticPoint4Params{
+		A:       fq.PastaFqNew().SetZero(),
+		B:       fq.PastaFqNew().SetUint64(5),
+		Gx:      fq.PastaFqNew().SetOne(),
+		Gy:      fq.PastaFqNew().SetRaw(&[native.Field4Limbs]uint64{0x9aae9ab8f909fe12, 0x4ef425ddfec978ab, 0x80532e1caba65bb9, 0x1104486c25ae2958}),
+		BitSize: 255,
+		Name:    "vesta",
+	}
+}
+
+func getVestaPointParams() *native.EllipticPoint4Params {
+	vestaPointInitonce.Do(vestaPointParamsInit)
+	return &vestaPointParams
+}
+
+type vestaPointArithmetic struct {
+	pastaPointArithmetic
+}
+
+func (vestaPointArithmetic) Hash(out *native.EllipticPoint4, hash *native.EllipticPointHasher, msg, dst []byte) error {
+	var u []byte
+
+	switch hash.Type() {
+	case native.XMD:
+		u = native.ExpandMsgXmd(hash, msg, dst, 128)
+	case native.XOF:
+		u = native.ExpandMsgXof(hash, msg, dst, 128)
+	}
+	q0 := VestaPointNew()
+	q1 := VestaPointNew()
+	r0 := VestaPointNew()
+	r1 := VestaPointNew()
+	var buf [64]byte
+	copy(buf[:], u[:64])
+	u0 := fq.PastaFqNew().SetBytesWide(&buf)
+	copy(buf[:], u[64:])
+	u1 := fq.PastaFqNew().SetBytesWide(&buf)
+
+	mapSswu(q0, u0, &vestaSswu)
+	mapSswu(q1, u1, &vestaSswu)
+	isoMap(r0, q0, &vestaIsomapper)
+	isoMap(r1, q1, &vestaIsomapper)
+	out.Add(r0, r1)
+	return nil
+}
+
+func (vestaPointArithmetic) RhsEquation(out, x *native.Field4) {
+	// Elliptic curve equation for vesta is: y^2 = x^3 + b
+	out.Square(x)
+	out.Mul(out, x)
+	out.Add(out, getVestaPointParams().B)
+}
+
+// vestaSswu maps to iso-vesta y^2 = x^3 + A'x + 1265 with Z = -13
+var vestaSswu = sswuParams{
+	A: [native.Field4Limbs]uint64{0xe39dd73ce5fa2060, 0xa67a4eac41bd984a, 0x4e9334381c85040e, 0x287658b7203524b5},
+	B: [native.Field4Limbs]uint64{0xe28772dcffffec3d, 0xa6dec34eab3aedd4, 0xfffffffffffffd5a, 0x3fffffffffffffff},
+	Z: [native.Field4Limbs]uint64{0x7e67c2b400000034, 0xf6571331f2324d00, 0x0000000000000006, 0x0000000000000000},
+	C1: [native.Field4Limbs]uint64{
+		0x9b6f47ad227aa1f9,
+		0xe05bdf5a01f54a5d,
+		0x7e27b2ae7a9b09aa,
+		0x05bd49d4c1123eb2,
+	},
+	C2: [native.Field4Limbs]uint64{
+		0x39302a9876276276,
+		0xd35799b1d72434ca,
+		0x9d89d89d89d89d89,
+		0x09d89d89d89d89d8,
+	},
+}
+
+// vestaIsomapper is the degree 3 isogeny from iso-vesta to vesta
+var vestaIsomapper = [13][native.Field4Limbs]uint64{
+	{0xad6517ce71c71c72, 0xb24893c63b04974d, 0xaaaaaaaaaaaaaaaa, 0x2aaaaaaaaaaaaaaa},
+	{0x46361151a9354d4b, 0x681b8597f431b790, 0xa27b0945498c318f, 0x3aa4c7434d930578},
+	{0x33de750af21121c7, 0xf71496fffd0bf228, 0x5cfe5d49550435ff, 0x3cb4babf63329c09},
+	{0xbff8f6d3e38e383b, 0xc3f6292a22e5b459, 0x555555555555553e, 0x1555555555555555},
+	{0x15af42d6f2dfb79b, 0x96c2ea77491a2d26, 0xb653536f95edbe09, 0x0fcb015dba2b313d},
+	{0xc58961ee87ce8fe4, 0xb646fc62c0be0c9a, 0xced3d6bbf771b1fb, 0x270fb22f765674fc},
+	{0x41fba4b025ed097c, 0xfcf1ec94c4b9f858, 0x8e38e38e38e38e38, 0x38e38e38e38e38e3},
+	{0xe93e7e39549aa6a6, 0xc5310f49fee33036, 0x513d84a2a4c618c7, 0x3d5263a1a6c982bc},
+	{0x9d728f7acce97bd7, 0x2e8f3c5fdb9f6044, 0x54fa00b78d0f70aa, 0x33ec021316643c22},
+	{0xcf9720f8bda12ff4, 0x263ae4ccee9b8f6b, 0xc71c71c71c71c72f, 0x1c71c71c71c71c71},
+	{0xe6aa59d2ec4f9369, 0x7347ac30f2719827, 0x917cfd2760e49d0e, 0x37b0820c9740c9dc},
+	{0xc4553aaa976bafab, 0x008e5c2c38a57cf2, 0x6c7b8433e65515f3, 0x352f168e63035ef6},
+	{0x965fe67000000870, 0x33aace90d650cd4f, 0x0000000000000121, 0x0000000000000000},
+}
+
+// vestaGlvParams split scalars for the vesta endomorphism
+// (x, y) -> (beta * x, y) which multiplies points by
+// lambda = 0x12ccca834acdba712caad5dc57aab1b01d1f8bd237ad31491dad5ebdfdfe4ab9.
+var vestaGlvParams = native.GlvParams{
+	G1:          [native.Field4Limbs]uint64{0xa10763588a5b8559, 0xb0d7ef5342bfa649, 0x0c7c095a00000000, 0x93cd3a2c8198e269},
+	G2:          [native.Field4Limbs]uint64{0x2105053092fe66a1, 0xd86bf7a9a173055e, 0x8cb1279300000000, 0x49e69d1640a89953},
+	Shift:       382,
+	MinusB1:     [native.Field4Limbs]uint64{0x8cb1279300000001, 0x49e69d1640a89953, 0x0000000000000000, 0x0000000000000000},
+	MinusB2:     [native.Field4Limbs]uint64{0x8cb1279300000000, 0x8e795ecf87b416b2, 0xffffffffffffffff, 0x3fffffffffffffff},
+	MinusLambda: [native.Field4Limbs]uint64{0x7b7fd22f0201b548, 0x05270d29d19fc7d2, 0xd3552a23a8554e50, 0x2d33357cb532458e},
+}
+
+// vestaBeta is a cube root of unity in the base field
+// 0x6819a58283e528e511db4d81cf70f5a0fed467d47c033af2aa9d2e050aa0e4f.
+var vestaBeta = [native.Field4Limbs]uint64{0x2aa9d2e050aa0e4f, 0x0fed467d47c033af, 0x511db4d81cf70f5a, 0x06819a58283e528e}
+
+func (vestaPointArithmetic) Endomorphism(out, arg *native.EllipticPoint4) {
+	endomorphism(out, arg, fq.PastaFqNew().SetLimbs(&vestaBeta))
+}
+
+func (vestaPointArithmetic) GlvParams() *native.GlvParams {
+	return &vestaGlvParams
+}
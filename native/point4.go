@@ -47,6 +47,15 @@ type EllipticPoint4Arithmetic interface {
 	RhsEquation(out, x *Field4)
 }
 
+// EllipticPoint4Jacobian is implemented by the point arithmetic of curves
+// using jacobian coordinates (X/Z^2, Y/Z^3) instead of the homogeneous
+// projective coordinates (X/Z, Y/Z) BatchToAffine assumes by default.
+type EllipticPoint4Jacobian interface {
+	// ToAffineInverted converts arg to affine coordinates given
+	// zInv = 1/Z and stores the result in out
+	ToAffineInverted(out, arg *EllipticPoint4, zInv *Field4)
+}
+
 // Random creates a random point on the curve
 // from the specified reader.
 func (p *EllipticPoint4) Random(reader io.Reader) (*EllipticPoint4, error) {
@@ -126,15 +135,16 @@ func (p *EllipticPoint4) Sub(lhs, rhs *EllipticPoint4) *EllipticPoint4 {
 }
 
 // Mul multiplies this point by the input scalar.
+// Every table entry is scanned for each window so the memory access
+// pattern does not depend on the scalar. Use MulVarTime for public scalars.
+// Curves with an endomorphism split the scalar into two halves.
 func (p *EllipticPoint4) Mul(point *EllipticPoint4, scalar *Field4) *EllipticPoint4 {
-	bytes := scalar.Bytes()
-	precomputed := [16]*EllipticPoint4{}
-	precomputed[0] = new(EllipticPoint4).Set(point).Identity()
-	precomputed[1] = new(EllipticPoint4).Set(point)
-	for i := 2; i < 16; i += 2 {
-		precomputed[i] = new(EllipticPoint4).Set(point).Double(precomputed[i>>1])
-		precomputed[i+1] = new(EllipticPoint4).Set(point).Add(precomputed[i], point)
+	if endo, ok := point.Arithmetic.(EllipticPoint4Endomorphism); ok {
+		return p.glvSumOfProducts([]*EllipticPoint4{point}, []*Field4{scalar}, endo, false)
 	}
+	bytes := scalar.Bytes()
+	precomputed := point4Table(point)
+	t := new(EllipticPoint4).Set(point)
 	p.Identity()
 	for i := 0; i < 256; i += 4 {
 		// Brouwer / windowing method. window size of 4.
@@ -142,11 +152,20 @@ func (p *EllipticPoint4) Mul(point *EllipticPoint4, scalar *Field4) *EllipticPoi
 			p.Double(p)
 		}
 		window := bytes[32-1-i>>3] >> (4 - i&0x04) & 0x0F
-		p.Add(p, precomputed[window])
+		lookupPoint4(t, precomputed[:], window)
+		p.Add(p, t)
 	}
 	return p
 }
 
+// MulVarTime multiplies this point by the input scalar.
+// The running time and memory access pattern depend on the scalar
+// so it must only be used with public values, e.g. when verifying signatures.
+func (p *EllipticPoint4) MulVarTime(point *EllipticPoint4, scalar *Field4) *EllipticPoint4 {
+	endo, _ := point.Arithmetic.(EllipticPoint4Endomorphism)
+	return p.strausVarTime([]*EllipticPoint4{point}, []*Field4{scalar}, endo)
+}
+
 // Equal returns 1 if the two points are equal 0 otherwise.
 func (p *EllipticPoint4) Equal(rhs *EllipticPoint4) int {
 	var x1, x2, y1, y2 Field4
@@ -235,71 +254,200 @@ func (p *EllipticPoint4) IsOnCurve() bool {
 }
 
 // ToAffine converts the point into affine coordinates.
+// Points that are already affine are copied without an inversion.
 func (p *EllipticPoint4) ToAffine(clone *EllipticPoint4) *EllipticPoint4 {
+	if clone.Z.IsOne() == 1 {
+		return p.Set(clone)
+	}
 	p.Arithmetic.ToAffine(p, clone)
 	return p
 }
 
+// BatchToAffine returns the points converted to affine coordinates
+// sharing a single field inversion between them. The identity is
+// converted the same as ToAffine. All points must be on the same curve.
+func BatchToAffine(points []*EllipticPoint4) []*EllipticPoint4 {
+	out := make([]*EllipticPoint4, len(points))
+	zInv := make([]*Field4, len(points))
+	for i, p := range points {
+		zInv[i] = new(Field4).Set(p.Z)
+	}
+	BatchInvert(zInv)
+	for i, p := range points {
+		out[i] = new(EllipticPoint4).Set(p)
+		if jacobian, ok := p.Arithmetic.(EllipticPoint4Jacobian); ok {
+			jacobian.ToAffineInverted(out[i], p, zInv[i])
+			continue
+		}
+		// The identity has a zero inverse so X and Y become zero
+		out[i].X.Mul(p.X, zInv[i])
+		out[i].Y.Mul(p.Y, zInv[i])
+		out[i].Z.CMove(out[i].Z.SetZero(), new(Field4).Set(p.Z).SetOne(), zInv[i].IsNonZero())
+	}
+	return out
+}
+
 // SumOfProducts computes the multi-exponentiation for the specified
 // points and scalars and stores the result in `p`.
 // Returns an error if the lengths of the arguments is not equal.
+// The windows of all scalars are processed together and each lookup
+// scans the whole table so it is safe for secret scalars.
+// Use SumOfProductsVarTime when all the scalars are public.
 func (p *EllipticPoint4) SumOfProducts(points []*EllipticPoint4, scalars []*Field4) (*EllipticPoint4, error) {
-	const Upper = 256
-	const W = 4
-	const Windows = Upper / W // careful--use ceiling division in case this doesn't divide evenly
 	if len(points) != len(scalars) {
 		return nil, fmt.Errorf("length mismatch")
 	}
+	if endo, ok := p.Arithmetic.(EllipticPoint4Endomorphism); ok {
+		return p.glvSumOfProducts(points, scalars, endo, false), nil
+	}
 
-	bucketSize := 1 << W
-	windows := make([]*EllipticPoint4, Windows)
+	tables := make([][16]*EllipticPoint4, len(points))
 	bytes := make([][32]byte, len(scalars))
-	buckets := make([]*EllipticPoint4, bucketSize)
-
 	for i, scalar := range scalars {
+		tables[i] = point4Table(points[i])
 		bytes[i] = scalar.Bytes()
 	}
-	for i := range windows {
-		windows[i] = new(EllipticPoint4).Set(p).Identity()
-	}
 
-	for i := 0; i < bucketSize; i++ {
-		buckets[i] = new(EllipticPoint4).Set(p).Identity()
+	t := new(EllipticPoint4).Set(p)
+	p.Identity()
+	for i := 0; i < 256; i += 4 {
+		for j := 0; j < 4; j++ {
+			p.Double(p)
+		}
+		for k := range tables {
+			window := bytes[k][32-1-i>>3] >> (4 - i&0x04) & 0x0F
+			lookupPoint4(t, tables[k][:], window)
+			p.Add(p, t)
+		}
 	}
+	return p, nil
+}
 
-	sum := new(EllipticPoint4).Set(p)
+// SumOfProductsVarTime computes the multi-exponentiation for the specified
+// points and scalars and stores the result in `p`. Fewer than
+// MsmStrausPoints points use interleaved wNAF and larger inputs use
+// Pippenger's bucket method. It must only be used with public scalars.
+// Returns an error if the lengths of the arguments is not equal.
+func (p *EllipticPoint4) SumOfProductsVarTime(points []*EllipticPoint4, scalars []*Field4) (*EllipticPoint4, error) {
+	return p.MsmVarTime(points, scalars, nil)
+}
 
-	for j := 0; j < len(windows); j++ {
-		for i := 0; i < bucketSize; i++ {
-			buckets[i].Identity()
+// MsmVarTime is SumOfProductsVarTime with options to choose the window
+// size and split the work across goroutines. Setting the window always
+// uses Pippenger's bucket method.
+func (p *EllipticPoint4) MsmVarTime(points []*EllipticPoint4, scalars []*Field4, opts *MsmOptions) (*EllipticPoint4, error) {
+	if len(points) != len(scalars) {
+		return nil, fmt.Errorf("length mismatch")
+	}
+	if len(points) < MsmStrausPoints && (opts == nil || opts.Window == 0) {
+		endo, _ := p.Arithmetic.(EllipticPoint4Endomorphism)
+		return p.strausVarTime(points, scalars, endo), nil
+	}
+	affine := make([]point4Affine, 0, len(points))
+	nScalars := make([]*Field4, 0, len(scalars))
+	for i, point := range BatchToAffine(points) {
+		if point.IsIdentity() {
+			continue
 		}
+		affine = append(affine, point4Affine{point.X.Value, point.Y.Value})
+		nScalars = append(nScalars, scalars[i])
+	}
+	return p.Set(Msm[point4Affine, *EllipticPoint4](point4Msm{p}, affine, nScalars, opts)), nil
+}
 
-		for i := 0; i < len(scalars); i++ {
-			// j*W to get the nibble
-			// >> 3 to convert to byte, / 8
-			// (W * j & W) gets the nibble, mod W
-			// 1 << W - 1 to get the offset
-			index := bytes[i][j*W>>3] >> (W * j & W) & (1<<W - 1) // little-endian
-			buckets[index].Add(buckets[index], points[i])
+// strausVarTime computes the sum of points[i] * scalars[i] by interleaving
+// the width WnafWidth NAFs of the scalars so all the points share the
+// doublings. When endo is not nil every scalar is split into two halves
+// with the second half multiplying phi(P), which halves the doublings.
+func (p *EllipticPoint4) strausVarTime(points []*EllipticPoint4, scalars []*Field4, endo EllipticPoint4Endomorphism) *EllipticPoint4 {
+	tables := make([][]*EllipticPoint4, 0, 2*len(points))
+	digits := make([][]int8, 0, 2*len(points))
+	for i, point := range points {
+		if point.IsIdentity() {
+			continue
+		}
+		table := point4WnafTable(point)
+		if endo == nil {
+			var k [Field4Limbs]uint64
+			scalars[i].Arithmetic.FromMontgomery(&k, &scalars[i].Value)
+			tables = append(tables, table)
+			digits = append(digits, Wnaf(k[:], WnafWidth))
+			continue
 		}
+		k1, k2, neg1, neg2 := endo.GlvParams().Decompose(scalars[i])
+		phi := make([]*EllipticPoint4, len(table))
+		for j, entry := range table {
+			phi[j] = new(EllipticPoint4).Set(entry)
+			endo.Endomorphism(phi[j], entry)
+		}
+		tables = append(tables, table, phi)
+		digits = append(digits, wnafNeg(Wnaf(k1[:], WnafWidth), neg1), wnafNeg(Wnaf(k2[:], WnafWidth), neg2))
+	}
 
-		sum.Identity()
+	top := 0
+	for _, d := range digits {
+		if len(d) > top {
+			top = len(d)
+		}
+	}
+	p.Identity()
+	for i := top - 1; i >= 0; i-- {
+		p.Double(p)
+		for j, d := range digits {
+			if i >= len(d) || d[i] == 0 {
+				continue
+			}
+			if d[i] > 0 {
+				p.Add(p, tables[j][d[i]>>1])
+			} else {
+				p.Sub(p, tables[j][-d[i]>>1])
+			}
+		}
+	}
+	return p
+}
 
-		for i := bucketSize - 1; i > 0; i-- {
-			sum.Add(sum, buckets[i])
-			windows[j].Add(windows[j], sum)
+// glvSumOfProducts splits every scalar into k1 + k2 * lambda and computes
+// the sum of k1 * P + k2 * phi(P) over half length scalars. The signs of k1
+// and k2 are applied to the points so only the absolute values are used.
+func (p *EllipticPoint4) glvSumOfProducts(points []*EllipticPoint4, scalars []*Field4, endo EllipticPoint4Endomorphism, varTime bool) *EllipticPoint4 {
+	params := endo.GlvParams()
+	tables := make([][16]*EllipticPoint4, 2*len(points))
+	digits := make([][Field4Limbs]uint64, 2*len(points))
+	for i, point := range points {
+		k1, k2, neg1, neg2 := params.Decompose(scalars[i])
+		p1 := new(EllipticPoint4).Set(point)
+		p1.Y.CMove(p1.Y, new(Field4).Set(p1.Y).Neg(p1.Y), neg1)
+		tables[2*i] = point4Table(p1)
+		// phi(j * P) = j * phi(P) so the second table only costs
+		// one multiplication per entry
+		for j, entry := range tables[2*i] {
+			p2 := new(EllipticPoint4).Set(entry)
+			endo.Endomorphism(p2, entry)
+			p2.Y.CMove(p2.Y, new(Field4).Set(p2.Y).Neg(p2.Y), neg1^neg2)
+			tables[2*i+1][j] = p2
 		}
+		digits[2*i] = k1
+		digits[2*i+1] = k2
 	}
 
+	t := new(EllipticPoint4).Set(p)
 	p.Identity()
-	for i := len(windows) - 1; i >= 0; i-- {
-		for j := 0; j < W; j++ {
+	for i := GlvBits - 4; i >= 0; i -= 4 {
+		for j := 0; j < 4; j++ {
 			p.Double(p)
 		}
-
-		p.Add(p, windows[i])
+		for k := range tables {
+			window := glvWindow(&digits[k], i)
+			if !varTime {
+				lookupPoint4(t, tables[k][:], window)
+				p.Add(p, t)
+			} else if window != 0 {
+				p.Add(p, tables[k][window])
+			}
+		}
 	}
-	return p, nil
+	return p
 }
 
 // CMove returns arg1 if choice == 0, otherwise returns arg2.
@@ -309,3 +457,144 @@ func (*EllipticPoint4) CMove(pt1, pt2 *EllipticPoint4, choice int) *EllipticPoin
 	pt1.Z.CMove(pt1.Z, pt2.Z, choice)
 	return pt1
 }
+
+// point4WnafTable returns the odd multiples point, 3*point, ...
+// (2^(WnafWidth-1) - 1)*point indexed by the absolute wNAF digit / 2.
+func point4WnafTable(point *EllipticPoint4) []*EllipticPoint4 {
+	table := make([]*EllipticPoint4, 1<<(WnafWidth-2))
+	table[0] = new(EllipticPoint4).Set(point)
+	double := new(EllipticPoint4).Double(point)
+	for i := 1; i < len(table); i++ {
+		table[i] = new(EllipticPoint4).Add(table[i-1], double)
+	}
+	return table
+}
+
+// point4Table returns the multiples 0*point through 15*point.
+func point4Table(point *EllipticPoint4) [16]*EllipticPoint4 {
+	var precomputed [16]*EllipticPoint4
+	precomputed[0] = new(EllipticPoint4).Set(point).Identity()
+	precomputed[1] = new(EllipticPoint4).Set(point)
+	for i := 2; i < 16; i += 2 {
+		precomputed[i] = new(EllipticPoint4).Set(point).Double(precomputed[i>>1])
+		precomputed[i+1] = new(EllipticPoint4).Set(point).Add(precomputed[i], point)
+	}
+	return precomputed
+}
+
+// lookupPoint4 sets out to table[index] in constant time
+// by conditionally moving every entry of the table.
+func lookupPoint4(out *EllipticPoint4, table []*EllipticPoint4, index byte) {
+	out.Identity()
+	for i := 1; i < len(table); i++ {
+		out.CMove(out, table[i], ctEqual(index, i))
+	}
+}
+
+// ctEqual returns 1 if a == b and 0 otherwise without branching.
+// b must be less than 256.
+func ctEqual(a byte, b int) int {
+	return int(((uint64(a) ^ uint64(b)) - 1) >> 63)
+}
+
+// point4Affine is an affine point in montgomery form.
+type point4Affine struct {
+	x, y [Field4Limbs]uint64
+}
+
+// point4Msm implements MsmCurve using the params and arithmetic of the template.
+type point4Msm struct {
+	template *EllipticPoint4
+}
+
+func (m point4Msm) BatchAdd(out, lhs, rhs []point4Affine, ok []bool) {
+	f := m.template.X.Arithmetic
+	one := &m.template.X.Params.R
+	a := &m.template.Params.A.Value
+	acc := make([][Field4Limbs]uint64, len(lhs))
+	var t, inv, lambda, x, y [Field4Limbs]uint64
+	var wasInverted int
+
+	// Montgomery's trick, acc[i] is the product of the first i+1 denominators
+	t = *one
+	for i := range lhs {
+		ok[i] = true
+		if lhs[i].x == rhs[i].x {
+			if lhs[i].y != rhs[i].y || lhs[i].y == [Field4Limbs]uint64{} {
+				// P + -P is the identity, use 1 so the product is invertible
+				ok[i] = false
+				acc[i] = t
+				continue
+			}
+			// The tangent slope has denominator 2y
+			f.Add(&inv, &lhs[i].y, &lhs[i].y)
+		} else {
+			f.Sub(&inv, &rhs[i].x, &lhs[i].x)
+		}
+		f.Mul(&t, &t, &inv)
+		acc[i] = t
+	}
+	f.Invert(&wasInverted, &inv, &t)
+
+	for i := len(lhs) - 1; i >= 0; i-- {
+		if !ok[i] {
+			continue
+		}
+		// Remove this denominator from the inverse before using it
+		if i > 0 {
+			f.Mul(&t, &inv, &acc[i-1])
+		} else {
+			t = inv
+		}
+		if lhs[i].x == rhs[i].x {
+			// lambda = (3x^2 + a) / 2y
+			f.Square(&x, &lhs[i].x)
+			f.Add(&y, &x, &x)
+			f.Add(&x, &y, &x)
+			f.Add(&x, &x, a)
+			f.Mul(&lambda, &x, &t)
+			f.Add(&y, &lhs[i].y, &lhs[i].y)
+		} else {
+			// lambda = (y2 - y1) / (x2 - x1)
+			f.Sub(&y, &rhs[i].y, &lhs[i].y)
+			f.Mul(&lambda, &y, &t)
+			f.Sub(&y, &rhs[i].x, &lhs[i].x)
+		}
+		f.Mul(&inv, &inv, &y)
+
+		// x3 = lambda^2 - x1 - x2
+		// y3 = lambda * (x1 - x3) - y1
+		f.Square(&x, &lambda)
+		f.Sub(&x, &x, &lhs[i].x)
+		f.Sub(&x, &x, &rhs[i].x)
+		f.Sub(&y, &lhs[i].x, &x)
+		f.Mul(&y, &y, &lambda)
+		f.Sub(&y, &y, &lhs[i].y)
+		out[i] = point4Affine{x, y}
+	}
+}
+
+func (m point4Msm) Neg(a point4Affine) point4Affine {
+	m.template.X.Arithmetic.Neg(&a.y, &a.y)
+	return a
+}
+
+func (m point4Msm) Identity() *EllipticPoint4 {
+	return new(EllipticPoint4).Set(m.template).Identity()
+}
+
+func (m point4Msm) AddAffine(p *EllipticPoint4, a point4Affine) {
+	q := new(EllipticPoint4).Set(m.template)
+	q.X.Value = a.x
+	q.Y.Value = a.y
+	q.Z.SetOne()
+	p.Add(p, q)
+}
+
+func (point4Msm) Add(p, q *EllipticPoint4) {
+	p.Add(p, q)
+}
+
+func (point4Msm) Double(p *EllipticPoint4) {
+	p.Double(p)
+}
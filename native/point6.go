@@ -126,15 +126,11 @@ func (p *EllipticPoint6) Sub(lhs, rhs *EllipticPoint6) *EllipticPoint6 {
 }
 
 // Mul multiplies this point by the input scalar.
+// Every table entry is scanned for each window so the memory access
+// pattern does not depend on the scalar. Use MulVarTime for public scalars.
 func (p *EllipticPoint6) Mul(point *EllipticPoint6, scalar *Field6) *EllipticPoint6 {
 	bytes := scalar.Bytes()
-	precomputed := [16]*EllipticPoint6{}
-	precomputed[0] = new(EllipticPoint6).Set(point).Identity()
-	precomputed[1] = new(EllipticPoint6).Set(point)
-	for i := 2; i < 16; i += 2 {
-		precomputed[i] = new(EllipticPoint6).Set(point).Double(precomputed[i>>1])
-		precomputed[i+1] = new(EllipticPoint6).Set(point).Add(precomputed[i], point)
-	}
+	precomputed := point6Table(point)
 	pos := p.Params.BitSize - 4
 	p.Identity()
 	t := new(EllipticPoint6).Set(point)
@@ -143,18 +139,34 @@ func (p *EllipticPoint6) Mul(point *EllipticPoint6, scalar *Field6) *EllipticPoi
 			p.Double(p)
 		}
 		slot := (bytes[pos>>3] >> (pos & 7)) & 0xf
-		t.Identity()
-		for i := 1; i < 16; i++ {
-			choice := int(((uint64(slot)^uint64(i))-1)>>8) & 1
-			t.CMove(t, precomputed[i], choice)
-		}
-
+		lookupPoint6(t, precomputed[:], slot)
 		p.Add(p, t)
 	}
 
 	return p
 }
 
+// MulVarTime multiplies this point by the input scalar.
+// The running time and memory access pattern depend on the scalar
+// so it must only be used with public values, e.g. when verifying signatures.
+func (p *EllipticPoint6) MulVarTime(point *EllipticPoint6, scalar *Field6) *EllipticPoint6 {
+	bytes := scalar.Bytes()
+	precomputed := point6Table(point)
+	pos := p.Params.BitSize - 4
+	p.Identity()
+	for ; pos >= 0; pos -= 4 {
+		for i := 0; i < 4; i++ {
+			p.Double(p)
+		}
+		slot := (bytes[pos>>3] >> (pos & 7)) & 0xf
+		if slot != 0 {
+			p.Add(p, precomputed[slot])
+		}
+	}
+
+	return p
+}
+
 // Equal returns 1 if the two points are equal 0 otherwise.
 func (p *EllipticPoint6) Equal(rhs *EllipticPoint6) int {
 	var x1, x2, y1, y2 Field6
@@ -243,16 +255,74 @@ func (p *EllipticPoint6) IsOnCurve() bool {
 }
 
 // ToAffine converts the point into affine coordinates.
+// Points that are already affine are copied without an inversion.
 func (p *EllipticPoint6) ToAffine(clone *EllipticPoint6) *EllipticPoint6 {
+	if clone.Z.IsOne() == 1 {
+		return p.Set(clone)
+	}
 	p.Arithmetic.ToAffine(p, clone)
 	return p
 }
 
+// BatchToAffine6 returns the points converted to affine coordinates
+// sharing a single field inversion between them. The identity is
+// converted the same as ToAffine. All points must be on the same curve.
+func BatchToAffine6(points []*EllipticPoint6) []*EllipticPoint6 {
+	out := make([]*EllipticPoint6, len(points))
+	zInv := make([]*Field6, len(points))
+	for i, p := range points {
+		zInv[i] = new(Field6).Set(p.Z)
+	}
+	BatchInvert6(zInv)
+	for i, p := range points {
+		// The identity has a zero inverse so X and Y become zero
+		out[i] = new(EllipticPoint6).Set(p)
+		out[i].X.Mul(p.X, zInv[i])
+		out[i].Y.Mul(p.Y, zInv[i])
+		out[i].Z.CMove(out[i].Z.SetZero(), new(Field6).Set(p.Z).SetOne(), zInv[i].IsNonZero())
+	}
+	return out
+}
+
 // SumOfProducts computes the multi-exponentiation for the specified
 // points and scalars and stores the result in `p`.
 // Returns an error if the lengths of the arguments is not equal.
+// The windows of all scalars are processed together and each lookup
+// scans the whole table so it is safe for secret scalars.
+// Use SumOfProductsVarTime when all the scalars are public.
 func (p *EllipticPoint6) SumOfProducts(points []*EllipticPoint6, scalars []*Field6) (*EllipticPoint6, error) {
-	const Upper = 256
+	if len(points) != len(scalars) {
+		return nil, fmt.Errorf("length mismatch")
+	}
+
+	tables := make([][16]*EllipticPoint6, len(points))
+	bytes := make([][48]byte, len(scalars))
+	for i, scalar := range scalars {
+		tables[i] = point6Table(points[i])
+		bytes[i] = scalar.Bytes()
+	}
+
+	t := new(EllipticPoint6).Set(p)
+	p.Identity()
+	for i := 0; i < 384; i += 4 {
+		for j := 0; j < 4; j++ {
+			p.Double(p)
+		}
+		for k := range tables {
+			window := bytes[k][48-1-i>>3] >> (4 - i&0x04) & 0x0F
+			lookupPoint6(t, tables[k][:], window)
+			p.Add(p, t)
+		}
+	}
+	return p, nil
+}
+
+// SumOfProductsVarTime computes the multi-exponentiation for the specified
+// points and scalars and stores the result in `p` using buckets indexed by
+// the scalar windows. It must only be used with public scalars.
+// Returns an error if the lengths of the arguments is not equal.
+func (p *EllipticPoint6) SumOfProductsVarTime(points []*EllipticPoint6, scalars []*Field6) (*EllipticPoint6, error) {
+	const Upper = 384
 	const W = 4
 	const Windows = Upper / W // careful--use ceiling division in case this doesn't divide evenly
 	if len(points) != len(scalars) {
@@ -317,3 +387,24 @@ func (*EllipticPoint6) CMove(pt1, pt2 *EllipticPoint6, choice int) *EllipticPoin
 	pt1.Z.CMove(pt1.Z, pt2.Z, choice)
 	return pt1
 }
+
+// point6Table returns the multiples 0*point through 15*point.
+func point6Table(point *EllipticPoint6) [16]*EllipticPoint6 {
+	var precomputed [16]*EllipticPoint6
+	precomputed[0] = new(EllipticPoint6).Set(point).Identity()
+	precomputed[1] = new(EllipticPoint6).Set(point)
+	for i := 2; i < 16; i += 2 {
+		precomputed[i] = new(EllipticPoint6).Set(point).Double(precomputed[i>>1])
+		precomputed[i+1] = new(EllipticPoint6).Set(point).Add(precomputed[i], point)
+	}
+	return precomputed
+}
+
+// lookupPoint6 sets out to table[index] in constant time
+// by conditionally moving every entry of the table.
+func lookupPoint6(out *EllipticPoint6, table []*EllipticPoint6, index byte) {
+	out.Identity()
+	for i := 1; i < len(table); i++ {
+		out.CMove(out, table[i], ctEqual(index, i))
+	}
+}
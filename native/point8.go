@@ -0,0 +1,392 @@
+package native
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// EllipticPoint8 represents a Weierstrauss elliptic curve point.
+type EllipticPoint8 struct {
+	X          *Field8
+	Y          *Field8
+	Z          *Field8
+	Params     *EllipticPoint8Params
+	Arithmetic EllipticPoint8Arithmetic
+}
+
+// EllipticPoint8Params are the Weierstrauss curve parameters
+// such as the name, the coefficients the generator point,
+// and the prime bit size.
+type EllipticPoint8Params struct {
+	Name    string
+	A       *Field8
+	B       *Field8
+	Gx      *Field8
+	Gy      *Field8
+	BitSize int
+}
+
+// EllipticPoint8Arithmetic are the methods that specific curves
+// need to implement for higher abstractions to wrap the point.
+type EllipticPoint8Arithmetic interface {
+	// Hash a byte sequence to the curve using the specified hasher
+	// and dst and store the result in out
+	Hash(out *EllipticPoint8, hasher *EllipticPointHasher, bytes, dst []byte) error
+	// Double arg and store the result in out
+	Double(out, arg *EllipticPoint8)
+	// Add arg1 with arg2 and store the result in out
+	Add(out, arg1, arg2 *EllipticPoint8)
+	// IsOnCurve tests arg if it represents a valid point on the curve
+	IsOnCurve(arg *EllipticPoint8) bool
+	// ToAffine converts arg to affine coordinates storing the result in out
+	ToAffine(out, arg *EllipticPoint8)
+	// RhsEquation computes the right-hand side of the ecc equation
+	RhsEquation(out, x *Field8)
+}
+
+// Random creates a random point on the curve
+// from the specified reader.
+func (p *EllipticPoint8) Random(reader io.Reader) (*EllipticPoint8, error) {
+	var seed [WideField8Bytes]byte
+	n, err := reader.Read(seed[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "random could not read from stream")
+	}
+	if n != WideField8Bytes {
+		return nil, fmt.Errorf("insufficient bytes read %d when %d are needed", n, WideField8Bytes)
+	}
+	dst := []byte(fmt.Sprintf("%s_XMD:SHA-256_SSWU_RO_", p.Params.Name))
+	err = p.Arithmetic.Hash(p, EllipticPointHasherSha256(), seed[:], dst)
+	if err != nil {
+		return nil, errors.Wrap(err, "ecc hash failed")
+	}
+	return p, nil
+}
+
+// Hash uses the hasher to map bytes to a valid point.
+func (p *EllipticPoint8) Hash(bytes []byte, hasher *EllipticPointHasher) (*EllipticPoint8, error) {
+	dst := []byte(fmt.Sprintf("%s_%s:%s_SSWU_RO_", p.Params.Name, hasher.hashType, hasher.name))
+	err := p.Arithmetic.Hash(p, hasher, bytes, dst)
+	if err != nil {
+		return nil, errors.Wrap(err, "hash failed")
+	}
+	return p, nil
+}
+
+// Identity returns the identity point.
+func (p *EllipticPoint8) Identity() *EllipticPoint8 {
+	p.X.SetZero()
+	p.Y.SetOne()
+	p.Z.SetZero()
+	return p
+}
+
+// Generator returns the base point for the curve.
+func (p *EllipticPoint8) Generator() *EllipticPoint8 {
+	p.X.Set(p.Params.Gx)
+	p.Y.Set(p.Params.Gy)
+	p.Z.SetOne()
+	return p
+}
+
+// IsIdentity returns true if this point is at infinity.
+func (p *EllipticPoint8) IsIdentity() bool {
+	return p.Z.IsZero() == 1
+}
+
+// Double this point.
+func (p *EllipticPoint8) Double(point *EllipticPoint8) *EllipticPoint8 {
+	p.Set(point)
+	p.Arithmetic.Double(p, point)
+	return p
+}
+
+// Neg negates this point.
+func (p *EllipticPoint8) Neg(point *EllipticPoint8) *EllipticPoint8 {
+	p.Set(point)
+	p.Y.Neg(p.Y)
+	return p
+}
+
+// Add adds the two points.
+func (p *EllipticPoint8) Add(lhs, rhs *EllipticPoint8) *EllipticPoint8 {
+	p.Set(lhs)
+	p.Arithmetic.Add(p, lhs, rhs)
+	return p
+}
+
+// Sub subtracts the two points.
+func (p *EllipticPoint8) Sub(lhs, rhs *EllipticPoint8) *EllipticPoint8 {
+	p.Set(lhs)
+	p.Arithmetic.Add(p, lhs, new(EllipticPoint8).Neg(rhs))
+	return p
+}
+
+// Mul multiplies this point by the input scalar.
+// Every table entry is scanned for each window so the memory access
+// pattern does not depend on the scalar.
+func (p *EllipticPoint8) Mul(point *EllipticPoint8, scalar *Field8) *EllipticPoint8 {
+	bytes := scalar.Bytes()
+	precomputed := point8Table(point)
+	// Round up so the lowest window starts at bit 0
+	pos := (p.Params.BitSize+3)&^3 - 4
+	p.Identity()
+	t := new(EllipticPoint8).Set(point)
+	for ; pos >= 0; pos -= 4 {
+		for i := 0; i < 4; i++ {
+			p.Double(p)
+		}
+		slot := (bytes[pos>>3] >> (pos & 7)) & 0xf
+		lookupPoint8(t, precomputed[:], slot)
+		p.Add(p, t)
+	}
+
+	return p
+}
+
+// Equal returns 1 if the two points are equal 0 otherwise.
+func (p *EllipticPoint8) Equal(rhs *EllipticPoint8) int {
+	var x1, x2, y1, y2 Field8
+
+	x1.Arithmetic = p.X.Arithmetic
+	x2.Arithmetic = p.X.Arithmetic
+	y1.Arithmetic = p.Y.Arithmetic
+	y2.Arithmetic = p.Y.Arithmetic
+
+	x1.Mul(p.X, rhs.Z)
+	x2.Mul(rhs.X, p.Z)
+
+	y1.Mul(p.Y, rhs.Z)
+	y2.Mul(rhs.Y, p.Z)
+
+	e1 := p.Z.IsZero()
+	e2 := rhs.Z.IsZero()
+
+	// Both at infinity or coordinates are the same
+	return (e1 & e2) | (^e1 & ^e2)&x1.Equal(&x2)&y1.Equal(&y2)
+}
+
+// Set copies clone into p.
+func (p *EllipticPoint8) Set(clone *EllipticPoint8) *EllipticPoint8 {
+	p.X = new(Field8).Set(clone.X)
+	p.Y = new(Field8).Set(clone.Y)
+	p.Z = new(Field8).Set(clone.Z)
+	p.Params = clone.Params
+	p.Arithmetic = clone.Arithmetic
+	return p
+}
+
+// BigInt returns the x and y as big.Ints in affine.
+func (p *EllipticPoint8) BigInt() (x, y *big.Int) {
+	t := new(EllipticPoint8).Set(p)
+	p.Arithmetic.ToAffine(t, p)
+	x = t.X.BigInt()
+	y = t.Y.BigInt()
+	return x, y
+}
+
+// SetBigInt creates a point from affine x, y
+// and returns the point if it is on the curve.
+func (p *EllipticPoint8) SetBigInt(x, y *big.Int) (*EllipticPoint8, error) {
+	xx := &Field8{
+		Params:     p.Params.Gx.Params,
+		Arithmetic: p.Params.Gx.Arithmetic,
+	}
+	xx.SetBigInt(x)
+	yy := &Field8{
+		Params:     p.Params.Gx.Params,
+		Arithmetic: p.Params.Gx.Arithmetic,
+	}
+	yy.SetBigInt(y)
+	pp := new(EllipticPoint8).Set(p)
+
+	zero := new(Field8).Set(xx).SetZero()
+	one := new(Field8).Set(xx).SetOne()
+	isIdentity := xx.IsZero() & yy.IsZero()
+	pp.X = xx.CMove(xx, zero, isIdentity)
+	pp.Y = yy.CMove(yy, zero, isIdentity)
+	pp.Z = one.CMove(one, zero, isIdentity)
+	if !p.Arithmetic.IsOnCurve(pp) && isIdentity == 0 {
+		return nil, fmt.Errorf("invalid coordinates")
+	}
+	return p.Set(pp), nil
+}
+
+// GetX returns the affine X coordinate.
+func (p *EllipticPoint8) GetX() *Field8 {
+	t := new(EllipticPoint8).Set(p)
+	p.Arithmetic.ToAffine(t, p)
+	return t.X
+}
+
+// GetY returns the affine Y coordinate.
+func (p *EllipticPoint8) GetY() *Field8 {
+	t := new(EllipticPoint8).Set(p)
+	p.Arithmetic.ToAffine(t, p)
+	return t.Y
+}
+
+// IsOnCurve determines if this point represents a valid curve point.
+func (p *EllipticPoint8) IsOnCurve() bool {
+	return p.Arithmetic.IsOnCurve(p)
+}
+
+// ToAffine converts the point into affine coordinates.
+// Points that are already affine are copied without an inversion.
+func (p *EllipticPoint8) ToAffine(clone *EllipticPoint8) *EllipticPoint8 {
+	if clone.Z.IsOne() == 1 {
+		return p.Set(clone)
+	}
+	p.Arithmetic.ToAffine(p, clone)
+	return p
+}
+
+// BatchToAffine8 returns the points converted to affine coordinates
+// sharing a single field inversion between them. The identity is
+// converted the same as ToAffine. All points must be on the same curve.
+func BatchToAffine8(points []*EllipticPoint8) []*EllipticPoint8 {
+	out := make([]*EllipticPoint8, len(points))
+	zInv := make([]*Field8, len(points))
+	for i, p := range points {
+		zInv[i] = new(Field8).Set(p.Z)
+	}
+	BatchInvert8(zInv)
+	for i, p := range points {
+		// The identity has a zero inverse so X and Y become zero
+		out[i] = new(EllipticPoint8).Set(p)
+		out[i].X.Mul(p.X, zInv[i])
+		out[i].Y.Mul(p.Y, zInv[i])
+		out[i].Z.CMove(out[i].Z.SetZero(), new(Field8).Set(p.Z).SetOne(), zInv[i].IsNonZero())
+	}
+	return out
+}
+
+// SumOfProducts computes the multi-exponentiation for the specified
+// points and scalars and stores the result in `p`.
+// Returns an error if the lengths of the arguments is not equal.
+// The windows of all scalars are processed together and each lookup
+// scans the whole table so it is safe for secret scalars.
+// Use SumOfProductsVarTime when all the scalars are public.
+func (p *EllipticPoint8) SumOfProducts(points []*EllipticPoint8, scalars []*Field8) (*EllipticPoint8, error) {
+	if len(points) != len(scalars) {
+		return nil, fmt.Errorf("length mismatch")
+	}
+
+	tables := make([][16]*EllipticPoint8, len(points))
+	bytes := make([][Field8Bytes]byte, len(scalars))
+	for i, scalar := range scalars {
+		tables[i] = point8Table(points[i])
+		bytes[i] = scalar.Bytes()
+	}
+
+	// Round up so the lowest window starts at bit 0
+	pos := (p.Params.BitSize+3)&^3 - 4
+	t := new(EllipticPoint8).Set(p)
+	p.Identity()
+	for ; pos >= 0; pos -= 4 {
+		for j := 0; j < 4; j++ {
+			p.Double(p)
+		}
+		for k := range tables {
+			window := (bytes[k][pos>>3] >> (pos & 7)) & 0xf
+			lookupPoint8(t, tables[k][:], window)
+			p.Add(p, t)
+		}
+	}
+	return p, nil
+}
+
+// SumOfProductsVarTime computes the multi-exponentiation for the specified
+// points and scalars and stores the result in `p` using buckets indexed by
+// the scalar windows. It must only be used with public scalars.
+// Returns an error if the lengths of the arguments is not equal.
+func (p *EllipticPoint8) SumOfProductsVarTime(points []*EllipticPoint8, scalars []*Field8) (*EllipticPoint8, error) {
+	const Upper = Field8Bytes * 8
+	const W = 4
+	const Windows = Upper / W // careful--use ceiling division in case this doesn't divide evenly
+	if len(points) != len(scalars) {
+		return nil, fmt.Errorf("length mismatch")
+	}
+
+	bucketSize := 1 << W
+	windows := make([]*EllipticPoint8, Windows)
+	bytes := make([][Field8Bytes]byte, len(scalars))
+	buckets := make([]*EllipticPoint8, bucketSize)
+
+	for i, scalar := range scalars {
+		bytes[i] = scalar.Bytes()
+	}
+	for i := range windows {
+		windows[i] = new(EllipticPoint8).Set(p).Identity()
+	}
+
+	for i := 0; i < bucketSize; i++ {
+		buckets[i] = new(EllipticPoint8).Set(p).Identity()
+	}
+
+	sum := new(EllipticPoint8).Set(p)
+
+	for j := 0; j < len(windows); j++ {
+		for i := 0; i < bucketSize; i++ {
+			buckets[i].Identity()
+		}
+
+		for i := 0; i < len(scalars); i++ {
+			// j*W to get the nibble
+			// >> 3 to convert to byte, / 8
+			// (W * j & W) gets the nibble, mod W
+			// 1 << W - 1 to get the offset
+			index := bytes[i][j*W>>3] >> (W * j & W) & (1<<W - 1) // little-endian
+			buckets[index].Add(buckets[index], points[i])
+		}
+
+		sum.Identity()
+
+		for i := bucketSize - 1; i > 0; i-- {
+			sum.Add(sum, buckets[i])
+			windows[j].Add(windows[j], sum)
+		}
+	}
+
+	p.Identity()
+	for i := len(windows) - 1; i >= 0; i-- {
+		for j := 0; j < W; j++ {
+			p.Double(p)
+		}
+
+		p.Add(p, windows[i])
+	}
+	return p, nil
+}
+
+// CMove returns arg1 if choice == 0, otherwise returns arg2.
+func (*EllipticPoint8) CMove(pt1, pt2 *EllipticPoint8, choice int) *EllipticPoint8 {
+	pt1.X.CMove(pt1.X, pt2.X, choice)
+	pt1.Y.CMove(pt1.Y, pt2.Y, choice)
+	pt1.Z.CMove(pt1.Z, pt2.Z, choice)
+	return pt1
+}
+
+// point8Table returns the multiples 0*point through 15*point.
+func point8Table(point *EllipticPoint8) [16]*EllipticPoint8 {
+	var precomputed [16]*EllipticPoint8
+	precomputed[0] = new(EllipticPoint8).Set(point).Identity()
+	precomputed[1] = new(EllipticPoint8).Set(point)
+	for i := 2; i < 16; i += 2 {
+		precomputed[i] = new(EllipticPoint8).Set(point).Double(precomputed[i>>1])
+		precomputed[i+1] = new(EllipticPoint8).Set(point).Add(precomputed[i], point)
+	}
+	return precomputed
+}
+
+// lookupPoint8 sets out to table[index] in constant time
+// by conditionally moving every entry of the table.
+func lookupPoint8(out *EllipticPoint8, table []*EllipticPoint8, index byte) {
+	out.Identity()
+	for i := 1; i < len(table); i++ {
+		out.CMove(out, table[i], ctEqual(index, i))
+	}
+}
@@ -0,0 +1,280 @@
+package secq256k1
+
+import (
+	"sync"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+	"github.com/mikelodder7/curvey/native/k256/fq"
+)
+
+var (
+	secq256k1PointInitonce     sync.Once
+	secq256k1PointParams       native.EllipticPoint4Params
+	secq256k1PointSvdwInitOnce sync.Once
+	secq256k1PointSvdwParams   native.Svdw4Params
+)
+
+// PointNew returns a point on secq256k1, y^2 = x^3 + 7 over
+// the secp256k1 scalar field.
+func PointNew() *native.EllipticPoint4 {
+	return &native.EllipticPoint4{
+		X:          fq.K256FqNew(),
+		Y:          fq.K256FqNew(),
+		Z:          fq.K256FqNew(),
+		Params:     getPointParams(),
+		Arithmetic: &pointArithmetic{},
+	}
+}
+
+func pointParamsInit() {
+	secq256k1PointParams = native.EllipticPoint4Params{
+		A: fq.K256FqNew(),
+		B: fq.K256FqNew().SetUint64(7),
+		Gx: fq.K256FqNew().SetLimbs(&[native.Field4Limbs]uint64{
+			0xa24288e37702eda6,
+			0x3134e45a097781a6,
+			0xb6b06c87a2ce32e2,
+			0x76c39f5585cb160e,
+		}),
+		Gy: fq.K256FqNew().SetLimbs(&[native.Field4Limbs]uint64{
+			0xa4120ddad952677f,
+			0xd18983d26e8dc055,
+			0xdc2d265a8e82a7f7,
+			0x3ffc646c7b2918b5,
+		}),
+		BitSize: 256,
+		Name:    "secq256k1",
+	}
+}
+
+func getPointParams() *native.EllipticPoint4Params {
+	secq256k1PointInitonce.Do(pointParamsInit)
+	return &secq256k1PointParams
+}
+
+func getPointSvdwParams() *native.Svdw4Params {
+	secq256k1PointSvdwInitOnce.Do(pointSvdwParamsInit)
+	return &secq256k1PointSvdwParams
+}
+
+func pointSvdwParamsInit() {
+	// secq256k1 has A = 0 and no known isogeny so the
+	// Shallue-van de Woestijne map is used with Z = 1
+	secq256k1PointSvdwParams = native.Svdw4Params{
+		// g(Z) = 8
+		C1: [native.Field4Limbs]uint64{0x016d0b997e4df5f8, 0x2a8918ca85bafe22, 0x000000000000000a, 0x0000000000000000},
+		// -Z / 2
+		C2: [native.Field4Limbs]uint64{0xbfd25e8cd0364141, 0xbaaedce6af48a03b, 0xfffffffffffffffe, 0x7fffffffffffffff},
+		// sqrt(-g(Z) * 3 * Z^2)
+		C3: [native.Field4Limbs]uint64{0xf8879640e5c346ac, 0xc5dd6ac7cdbeeda5, 0x6b02582f900855ee, 0x164d0942b44e134b},
+		// -4 * g(Z) / (3 * Z^2)
+		C4: [native.Field4Limbs]uint64{0x1340f9c027ce4ea1, 0x2ca2bbd8a7a4a2b9, 0xfffffffffffffff1, 0xffffffffffffffff},
+		// 7
+		B: [native.Field4Limbs]uint64{0xc13f6a264e843739, 0xe537f5b135039e5d, 0x0000000000000008, 0x0000000000000000},
+		// 1
+		Z: [native.Field4Limbs]uint64{0x402da1732fc9bebf, 0x4551231950b75fc4, 0x0000000000000001, 0x0000000000000000},
+	}
+}
+
+type pointArithmetic struct{}
+
+func (k pointArithmetic) Hash(out *native.EllipticPoint4, hash *native.EllipticPointHasher, msg, dst []byte) error {
+	var u []byte
+	svdwParams := getPointSvdwParams()
+
+	switch hash.Type() {
+	case native.XMD:
+		u = native.ExpandMsgXmd(hash, msg, dst, 96)
+	case native.XOF:
+		u = native.ExpandMsgXof(hash, msg, dst, 96)
+	}
+	var buf [64]byte
+	copy(buf[:48], internal.ReverseBytes(u[:48]))
+	u0 := fq.K256FqNew().SetBytesWide(&buf)
+	copy(buf[:48], internal.ReverseBytes(u[48:]))
+	u1 := fq.K256FqNew().SetBytesWide(&buf)
+
+	q0x, q0y := svdwParams.Map(u0)
+	q1x, q1y := svdwParams.Map(u1)
+	out.X = q0x
+	out.Y = q0y
+	out.Z.SetOne()
+	tv := &native.EllipticPoint4{
+		X: q1x,
+		Y: q1y,
+		Z: fq.K256FqNew().SetOne(),
+	}
+	// The cofactor is 1 so no clearing is needed
+	k.Add(out, out, tv)
+	return nil
+}
+
+func (pointArithmetic) Double(out, arg *native.EllipticPoint4) {
+	// Addition formula from Renes-Costello-Batina 2015
+	// (https://eprint.iacr.org/2015/1060 Algorithm 9)
+	var yy, zz, xy2, bzz, bzz3, bzz9 [native.Field4Limbs]uint64
+	var yyMBzz9, yyPBzz3, yyzz, yyzz8, t [native.Field4Limbs]uint64
+	var x, y, z [native.Field4Limbs]uint64
+	f := arg.X.Arithmetic
+
+	f.Square(&yy, &arg.Y.Value)
+	f.Square(&zz, &arg.Z.Value)
+	f.Mul(&xy2, &arg.X.Value, &arg.Y.Value)
+	f.Add(&xy2, &xy2, &xy2)
+	f.Mul(&bzz, &zz, &arg.Params.B.Value)
+	f.Add(&bzz3, &bzz, &bzz)
+	f.Add(&bzz3, &bzz3, &bzz)
+	f.Add(&bzz9, &bzz3, &bzz3)
+	f.Add(&bzz9, &bzz9, &bzz3)
+	f.Neg(&yyMBzz9, &bzz9)
+	f.Add(&yyMBzz9, &yyMBzz9, &yy)
+	f.Add(&yyPBzz3, &yy, &bzz3)
+	f.Mul(&yyzz, &yy, &zz)
+	f.Add(&yyzz8, &yyzz, &yyzz)
+	f.Add(&yyzz8, &yyzz8, &yyzz8)
+	f.Add(&yyzz8, &yyzz8, &yyzz8)
+	f.Add(&t, &yyzz8, &yyzz8)
+	f.Add(&t, &t, &yyzz8)
+	f.Mul(&t, &t, &arg.Params.B.Value)
+
+	f.Mul(&x, &xy2, &yyMBzz9)
+
+	f.Mul(&y, &yyMBzz9, &yyPBzz3)
+	f.Add(&y, &y, &t)
+
+	f.Mul(&z, &yy, &arg.Y.Value)
+	f.Mul(&z, &z, &arg.Z.Value)
+	f.Add(&z, &z, &z)
+	f.Add(&z, &z, &z)
+	f.Add(&z, &z, &z)
+
+	out.X.Value = x
+	out.Y.Value = y
+	out.Z.Value = z
+}
+
+func (pointArithmetic) Add(out, arg1, arg2 *native.EllipticPoint4) {
+	// Addition formula from Renes-Costello-Batina 2015
+	// (https://eprint.iacr.org/2015/1060 Algorithm 7).
+	var xx, yy, zz, nXxYy, nYyZz, nXxZz [native.Field4Limbs]uint64
+	var tv1, tv2, xyPairs, yzPairs, xzPairs [native.Field4Limbs]uint64
+	var bzz, bzz3, yyMBzz3, yyPBzz3, byz [native.Field4Limbs]uint64
+	var byz3, xx3, bxx9, x, y, z [native.Field4Limbs]uint64
+	f := arg1.X.Arithmetic
+
+	f.Mul(&xx, &arg1.X.Value, &arg2.X.Value)
+	f.Mul(&yy, &arg1.Y.Value, &arg2.Y.Value)
+	f.Mul(&zz, &arg1.Z.Value, &arg2.Z.Value)
+
+	f.Add(&nXxYy, &xx, &yy)
+	f.Neg(&nXxYy, &nXxYy)
+
+	f.Add(&nYyZz, &yy, &zz)
+	f.Neg(&nYyZz, &nYyZz)
+
+	f.Add(&nXxZz, &xx, &zz)
+	f.Neg(&nXxZz, &nXxZz)
+
+	f.Add(&tv1, &arg1.X.Value, &arg1.Y.Value)
+	f.Add(&tv2, &arg2.X.Value, &arg2.Y.Value)
+	f.Mul(&xyPairs, &tv1, &tv2)
+	f.Add(&xyPairs, &xyPairs, &nXxYy)
+
+	f.Add(&tv1, &arg1.Y.Value, &arg1.Z.Value)
+	f.Add(&tv2, &arg2.Y.Value, &arg2.Z.Value)
+	f.Mul(&yzPairs, &tv1, &tv2)
+	f.Add(&yzPairs, &yzPairs, &nYyZz)
+
+	f.Add(&tv1, &arg1.X.Value, &arg1.Z.Value)
+	f.Add(&tv2, &arg2.X.Value, &arg2.Z.Value)
+	f.Mul(&xzPairs, &tv1, &tv2)
+	f.Add(&xzPairs, &xzPairs, &nXxZz)
+
+	f.Mul(&bzz, &zz, &arg1.Params.B.Value)
+	f.Add(&bzz3, &bzz, &bzz)
+	f.Add(&bzz3, &bzz3, &bzz)
+
+	f.Neg(&yyMBzz3, &bzz3)
+	f.Add(&yyMBzz3, &yyMBzz3, &yy)
+
+	f.Add(&yyPBzz3, &yy, &bzz3)
+
+	f.Mul(&byz, &yzPairs, &arg1.Params.B.Value)
+	f.Add(&byz3, &byz, &byz)
+	f.Add(&byz3, &byz3, &byz)
+
+	f.Add(&xx3, &xx, &xx)
+	f.Add(&xx3, &xx3, &xx)
+
+	f.Add(&bxx9, &xx3, &xx3)
+	f.Add(&bxx9, &bxx9, &xx3)
+	f.Mul(&bxx9, &bxx9, &arg1.Params.B.Value)
+
+	f.Mul(&tv1, &xyPairs, &yyMBzz3)
+	f.Mul(&tv2, &byz3, &xzPairs)
+	f.Neg(&tv2, &tv2)
+	f.Add(&x, &tv1, &tv2)
+
+	f.Mul(&tv1, &yyPBzz3, &yyMBzz3)
+	f.Mul(&tv2, &bxx9, &xzPairs)
+	f.Add(&y, &tv1, &tv2)
+
+	f.Mul(&tv1, &yzPairs, &yyPBzz3)
+	f.Mul(&tv2, &xx3, &xyPairs)
+	f.Add(&z, &tv1, &tv2)
+
+	e1 := arg1.Z.IsZero()
+	e2 := arg2.Z.IsZero()
+
+	// If arg1 is identity set it to arg2
+	f.Selectznz(&z, &z, &arg2.Z.Value, e1)
+	f.Selectznz(&y, &y, &arg2.Y.Value, e1)
+	f.Selectznz(&x, &x, &arg2.X.Value, e1)
+	// If arg2 is identity set it to arg1
+	f.Selectznz(&z, &z, &arg1.Z.Value, e2)
+	f.Selectznz(&y, &y, &arg1.Y.Value, e2)
+	f.Selectznz(&x, &x, &arg1.X.Value, e2)
+
+	out.X.Value = x
+	out.Y.Value = y
+	out.Z.Value = z
+}
+
+func (k pointArithmetic) IsOnCurve(arg *native.EllipticPoint4) bool {
+	affine := PointNew()
+	k.ToAffine(affine, arg)
+	lhs := fq.K256FqNew().Square(affine.Y)
+	rhs := fq.K256FqNew()
+	k.RhsEquation(rhs, affine.X)
+	return lhs.Equal(rhs) == 1
+}
+
+func (pointArithmetic) ToAffine(out, arg *native.EllipticPoint4) {
+	var wasInverted int
+	var zero, x, y, z [native.Field4Limbs]uint64
+	f := arg.X.Arithmetic
+
+	f.Invert(&wasInverted, &z, &arg.Z.Value)
+	f.Mul(&x, &arg.X.Value, &z)
+	f.Mul(&y, &arg.Y.Value, &z)
+
+	out.Z.SetOne()
+	// If point at infinity this does nothing
+	f.Selectznz(&x, &zero, &x, wasInverted)
+	f.Selectznz(&y, &zero, &y, wasInverted)
+	f.Selectznz(&z, &zero, &out.Z.Value, wasInverted)
+
+	out.X.Value = x
+	out.Y.Value = y
+	out.Z.Value = z
+	out.Params = arg.Params
+	out.Arithmetic = arg.Arithmetic
+}
+
+func (pointArithmetic) RhsEquation(out, x *native.Field4) {
+	// Elliptic curve equation for secq256k1 is: y^2 = x^3 + 7
+	out.Square(x)
+	out.Mul(out, x)
+	out.Add(out, getPointParams().B)
+}
@@ -0,0 +1,68 @@
+package secq256k1_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikelodder7/curvey/native"
+	"github.com/mikelodder7/curvey/native/k256/fp"
+	"github.com/mikelodder7/curvey/native/secq256k1"
+)
+
+func bhex(s string) *big.Int {
+	r, _ := new(big.Int).SetString(s, 16)
+	return r
+}
+
+func TestSecq256k1PointArithmetic_Double(t *testing.T) {
+	g := secq256k1.PointNew().Generator()
+	require.True(t, g.IsOnCurve())
+	pt1 := secq256k1.PointNew().Double(g)
+	pt2 := secq256k1.PointNew().Add(g, g)
+	pt3 := secq256k1.PointNew().Mul(g, fp.K256FpNew().SetUint64(2))
+
+	require.Equal(t, 1, pt1.Equal(pt2))
+	require.Equal(t, 1, pt1.Equal(pt3))
+	require.Equal(t, 1, pt2.Equal(pt3))
+
+	x, y := pt1.BigInt()
+	require.Equal(t, 0, x.Cmp(bhex("c17340f13398a692e5f455d1275059e44c085f188a12ab0aae2fa282098c29c0")))
+	require.Equal(t, 0, y.Cmp(bhex("18b1dfccd9b774dd05e4f53a15cab27b781f77ffbf92d7617935bf32d9288812")))
+}
+
+func TestSecq256k1PointArithmetic_Mul(t *testing.T) {
+	g := secq256k1.PointNew().Generator()
+	pt := secq256k1.PointNew().Mul(g, fp.K256FpNew().SetUint64(0x1234567890abcdef))
+	require.True(t, pt.IsOnCurve())
+	x, _ := pt.BigInt()
+	require.Equal(t, 0, x.Cmp(bhex("5ad3e68a38543a0ff22040f07e74e02db4c217e5740fd06d8571125cee0eeb15")))
+
+	// The group order is the secp256k1 base field modulus
+	n := fp.K256FpNew().SetOne()
+	n.Neg(n)
+	pt.Mul(g, n)
+	pt.Add(pt, g)
+	require.True(t, pt.IsIdentity())
+}
+
+func TestSecq256k1PointArithmetic_Hash(t *testing.T) {
+	dst := []byte("secq256k1_XMD:SHA-256_SVDW_RO_")
+	tests := []struct {
+		msg  string
+		x, y string
+	}{
+		{"", "c7ec21a85f000f2a29fe0321f12087ca5de9526335776442d87bb926e220299b", "bf4b89645465b9c6c779dcf0963792d7d6f433c60a7b4734477696c0fe934574"},
+		{"abc", "566a4f0842311576f724b422c704aa298b0e2e6fbfe4343e40f9c7223c612a55", "27c0dba01b225e5df5ebbfc836fc53a5cdba901952c27f3c4f8b8d16c04d05f4"},
+	}
+	for _, tst := range tests {
+		pt := secq256k1.PointNew()
+		err := pt.Arithmetic.Hash(pt, native.EllipticPointHasherSha256(), []byte(tst.msg), dst)
+		require.NoError(t, err)
+		require.True(t, pt.IsOnCurve())
+		x, y := pt.BigInt()
+		require.Equal(t, 0, x.Cmp(bhex(tst.x)))
+		require.Equal(t, 0, y.Cmp(bhex(tst.y)))
+	}
+}
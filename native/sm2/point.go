@@ -0,0 +1,303 @@
+package sm2
+
+import (
+	"sync"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+)
+
+var (
+	sm2PointInitonce     sync.Once
+	sm2PointParams       native.EllipticPoint4Params
+	sm2PointSswuInitOnce sync.Once
+	sm2PointSswuParams   native.Sswu4Params
+)
+
+func PointNew() *native.EllipticPoint4 {
+	return &native.EllipticPoint4{
+		X:          FpNew(),
+		Y:          FpNew(),
+		Z:          FpNew(),
+		Params:     getPointParams(),
+		Arithmetic: &pointArithmetic{},
+	}
+}
+
+func pointParamsInit() {
+	// How these values were derived
+	// left for informational purposes
+	// capA := FpNew().SetBigInt(a)
+	// capB := FpNew().SetBigInt(b)
+	// gx := FpNew().SetBigInt(gx)
+	// gy := FpNew().SetBigInt(gy)
+	// where a, b, gx and gy are from GB/T 32918.5-2017, Section 4
+
+	sm2PointParams = native.EllipticPoint4Params{
+		A:       FpNew().SetRaw(&[native.Field4Limbs]uint64{0xfffffffffffffffc, 0xfffffffc00000003, 0xffffffffffffffff, 0xfffffffbffffffff}),
+		B:       FpNew().SetRaw(&[native.Field4Limbs]uint64{0x90d230632bc0dd42, 0x71cf379ae9b537ab, 0x527981505ea51c3c, 0x240fe188ba20e2c8}),
+		Gx:      FpNew().SetRaw(&[native.Field4Limbs]uint64{0x61328990f418029e, 0x3e7981eddca6c050, 0xd6a1ed99ac24c3c3, 0x91167a5ee1c13b05}),
+		Gy:      FpNew().SetRaw(&[native.Field4Limbs]uint64{0xc1354e593c2d0ddd, 0xc1f5e5788d3295fa, 0x8d4cfb066e2a48f8, 0x63cd65d481d735bd}),
+		BitSize: 256,
+		Name:    "SM2",
+	}
+}
+
+func getPointParams() *native.EllipticPoint4Params {
+	sm2PointInitonce.Do(pointParamsInit)
+	return &sm2PointParams
+}
+
+func getPointSswuParams() *native.Sswu4Params {
+	sm2PointSswuInitOnce.Do(pointSswuParamsInit)
+	return &sm2PointSswuParams
+}
+
+func pointSswuParamsInit() {
+	// How these values were derived
+	// left for informational purposes
+	// p := internal.Sm2FpParams().BiModulus
+	//
+	// // c1 = (p - 3) / 4
+	// c1 := new(big.Int).Sub(p, big.NewInt(3))
+	// c1.Rsh(c1, 2)
+	//
+	// z := big.NewInt(-9)
+	// z.Mod(z, p)
+	// // sqrt(-Z^3)
+	// zTmp := new(big.Int).Exp(z, big.NewInt(3), nil)
+	// zTmp = zTmp.Neg(zTmp)
+	// zTmp.Mod(zTmp, p)
+	// c2 := new(big.Int).ModSqrt(zTmp, p)
+	//
+	// capC1 is c1 as little endian limbs, not in montgomery form
+	// capC2 := FpNew().SetBigInt(c2)
+	// capA := FpNew().SetBigInt(a)
+	// capB := FpNew().SetBigInt(b)
+	// capZ := FpNew().SetBigInt(z)
+
+	sm2PointSswuParams = native.Sswu4Params{
+		C1: [native.Field4Limbs]uint64{0x3fffffffffffffff, 0xffffffffc0000000, 0xffffffffffffffff, 0x3fffffffbfffffff},
+		C2: [native.Field4Limbs]uint64{0x000000000000001b, 0x0000001affffffe5, 0x0000000000000000, 0x0000001b00000000},
+		A:  [native.Field4Limbs]uint64{0xfffffffffffffffc, 0xfffffffc00000003, 0xffffffffffffffff, 0xfffffffbffffffff},
+		B:  [native.Field4Limbs]uint64{0x90d230632bc0dd42, 0x71cf379ae9b537ab, 0x527981505ea51c3c, 0x240fe188ba20e2c8},
+		Z:  [native.Field4Limbs]uint64{0xfffffffffffffff6, 0xfffffff600000009, 0xffffffffffffffff, 0xfffffff5ffffffff},
+	}
+}
+
+type pointArithmetic struct{}
+
+func (k pointArithmetic) Hash(out *native.EllipticPoint4, hash *native.EllipticPointHasher, msg, dst []byte) error {
+	var u []byte
+	sswuParams := getPointSswuParams()
+
+	switch hash.Type() {
+	case native.XMD:
+		u = native.ExpandMsgXmd(hash, msg, dst, 96)
+	case native.XOF:
+		u = native.ExpandMsgXof(hash, msg, dst, 96)
+	}
+	var buf [64]byte
+	copy(buf[:48], internal.ReverseBytes(u[:48]))
+	u0 := FpNew().SetBytesWide(&buf)
+	copy(buf[:48], internal.ReverseBytes(u[48:]))
+	u1 := FpNew().SetBytesWide(&buf)
+
+	q0x, q0y := sswuParams.Osswu3mod4(u0)
+	q1x, q1y := sswuParams.Osswu3mod4(u1)
+	out.X = q0x
+	out.Y = q0y
+	out.Z.SetOne()
+	tv := &native.EllipticPoint4{
+		X: q1x,
+		Y: q1y,
+		Z: FpNew().SetOne(),
+	}
+	k.Add(out, out, tv)
+	return nil
+}
+
+func (pointArithmetic) Double(out, arg *native.EllipticPoint4) {
+	// Addition formula from Renes-Costello-Batina 2015
+	// (https://eprint.iacr.org/2015/1060 Algorithm 6)
+	var xx, yy, zz, xy2, yz2, xz2, bzz, bzz3 [native.Field4Limbs]uint64
+	var yyMBzz3, yyPBzz3, yFrag, xFrag, zz3 [native.Field4Limbs]uint64
+	var bxz2, bxz6, xx3Mzz3, x, y, z [native.Field4Limbs]uint64
+	b := getPointParams().B.Value
+	f := arg.X.Arithmetic
+
+	f.Square(&xx, &arg.X.Value)
+	f.Square(&yy, &arg.Y.Value)
+	f.Square(&zz, &arg.Z.Value)
+
+	f.Mul(&xy2, &arg.X.Value, &arg.Y.Value)
+	f.Add(&xy2, &xy2, &xy2)
+
+	f.Mul(&yz2, &arg.Y.Value, &arg.Z.Value)
+	f.Add(&yz2, &yz2, &yz2)
+
+	f.Mul(&xz2, &arg.X.Value, &arg.Z.Value)
+	f.Add(&xz2, &xz2, &xz2)
+
+	f.Mul(&bzz, &b, &zz)
+	f.Sub(&bzz, &bzz, &xz2)
+
+	f.Add(&bzz3, &bzz, &bzz)
+	f.Add(&bzz3, &bzz3, &bzz)
+
+	f.Sub(&yyMBzz3, &yy, &bzz3)
+	f.Add(&yyPBzz3, &yy, &bzz3)
+	f.Mul(&yFrag, &yyPBzz3, &yyMBzz3)
+	f.Mul(&xFrag, &yyMBzz3, &xy2)
+
+	f.Add(&zz3, &zz, &zz)
+	f.Add(&zz3, &zz3, &zz)
+
+	f.Mul(&bxz2, &b, &xz2)
+	f.Sub(&bxz2, &bxz2, &zz3)
+	f.Sub(&bxz2, &bxz2, &xx)
+
+	f.Add(&bxz6, &bxz2, &bxz2)
+	f.Add(&bxz6, &bxz6, &bxz2)
+
+	f.Add(&xx3Mzz3, &xx, &xx)
+	f.Add(&xx3Mzz3, &xx3Mzz3, &xx)
+	f.Sub(&xx3Mzz3, &xx3Mzz3, &zz3)
+
+	f.Mul(&x, &bxz6, &yz2)
+	f.Sub(&x, &xFrag, &x)
+
+	f.Mul(&y, &xx3Mzz3, &bxz6)
+	f.Add(&y, &yFrag, &y)
+
+	f.Mul(&z, &yz2, &yy)
+	f.Add(&z, &z, &z)
+	f.Add(&z, &z, &z)
+
+	out.X.Value = x
+	out.Y.Value = y
+	out.Z.Value = z
+}
+
+func (pointArithmetic) Add(out, arg1, arg2 *native.EllipticPoint4) {
+	// Addition formula from Renes-Costello-Batina 2015
+	// (https://eprint.iacr.org/2015/1060 Algorithm 4).
+	var xx, yy, zz, zz3, bxz, bxz3 [native.Field4Limbs]uint64
+	var tv1, xyPairs, yzPairs, xzPairs [native.Field4Limbs]uint64
+	var bzz, bzz3, yyMBzz3, yyPBzz3 [native.Field4Limbs]uint64
+	var xx3Mzz3, x, y, z [native.Field4Limbs]uint64
+	f := arg1.X.Arithmetic
+	b := getPointParams().B.Value
+
+	f.Mul(&xx, &arg1.X.Value, &arg2.X.Value)
+	f.Mul(&yy, &arg1.Y.Value, &arg2.Y.Value)
+	f.Mul(&zz, &arg1.Z.Value, &arg2.Z.Value)
+
+	f.Add(&tv1, &arg2.X.Value, &arg2.Y.Value)
+	f.Add(&xyPairs, &arg1.X.Value, &arg1.Y.Value)
+	f.Mul(&xyPairs, &xyPairs, &tv1)
+	f.Sub(&xyPairs, &xyPairs, &xx)
+	f.Sub(&xyPairs, &xyPairs, &yy)
+
+	f.Add(&tv1, &arg2.Y.Value, &arg2.Z.Value)
+	f.Add(&yzPairs, &arg1.Y.Value, &arg1.Z.Value)
+	f.Mul(&yzPairs, &yzPairs, &tv1)
+	f.Sub(&yzPairs, &yzPairs, &yy)
+	f.Sub(&yzPairs, &yzPairs, &zz)
+
+	f.Add(&tv1, &arg2.X.Value, &arg2.Z.Value)
+	f.Add(&xzPairs, &arg1.X.Value, &arg1.Z.Value)
+	f.Mul(&xzPairs, &xzPairs, &tv1)
+	f.Sub(&xzPairs, &xzPairs, &xx)
+	f.Sub(&xzPairs, &xzPairs, &zz)
+
+	f.Mul(&bzz, &b, &zz)
+	f.Sub(&bzz, &xzPairs, &bzz)
+
+	f.Add(&bzz3, &bzz, &bzz)
+	f.Add(&bzz3, &bzz3, &bzz)
+
+	f.Sub(&yyMBzz3, &yy, &bzz3)
+	f.Add(&yyPBzz3, &yy, &bzz3)
+
+	f.Add(&zz3, &zz, &zz)
+	f.Add(&zz3, &zz3, &zz)
+
+	f.Mul(&bxz, &b, &xzPairs)
+	f.Sub(&bxz, &bxz, &zz3)
+	f.Sub(&bxz, &bxz, &xx)
+
+	f.Add(&bxz3, &bxz, &bxz)
+	f.Add(&bxz3, &bxz3, &bxz)
+
+	f.Add(&xx3Mzz3, &xx, &xx)
+	f.Add(&xx3Mzz3, &xx3Mzz3, &xx)
+	f.Sub(&xx3Mzz3, &xx3Mzz3, &zz3)
+
+	f.Mul(&tv1, &yzPairs, &bxz3)
+	f.Mul(&x, &yyPBzz3, &xyPairs)
+	f.Sub(&x, &x, &tv1)
+
+	f.Mul(&tv1, &xx3Mzz3, &bxz3)
+	f.Mul(&y, &yyPBzz3, &yyMBzz3)
+	f.Add(&y, &y, &tv1)
+
+	f.Mul(&tv1, &xyPairs, &xx3Mzz3)
+	f.Mul(&z, &yyMBzz3, &yzPairs)
+	f.Add(&z, &z, &tv1)
+
+	e1 := arg1.Z.IsZero()
+	e2 := arg2.Z.IsZero()
+
+	// If arg1 is identity set it to arg2
+	f.Selectznz(&z, &z, &arg2.Z.Value, e1)
+	f.Selectznz(&y, &y, &arg2.Y.Value, e1)
+	f.Selectznz(&x, &x, &arg2.X.Value, e1)
+	// If arg2 is identity set it to arg1
+	f.Selectznz(&z, &z, &arg1.Z.Value, e2)
+	f.Selectznz(&y, &y, &arg1.Y.Value, e2)
+	f.Selectznz(&x, &x, &arg1.X.Value, e2)
+
+	out.X.Value = x
+	out.Y.Value = y
+	out.Z.Value = z
+}
+
+func (k pointArithmetic) IsOnCurve(arg *native.EllipticPoint4) bool {
+	affine := PointNew()
+	k.ToAffine(affine, arg)
+	lhs := FpNew().Square(affine.Y)
+	rhs := FpNew()
+	k.RhsEquation(rhs, affine.X)
+	return lhs.Equal(rhs) == 1
+}
+
+func (pointArithmetic) ToAffine(out, arg *native.EllipticPoint4) {
+	var wasInverted int
+	var zero, x, y, z [native.Field4Limbs]uint64
+	f := arg.X.Arithmetic
+
+	f.Invert(&wasInverted, &z, &arg.Z.Value)
+	f.Mul(&x, &arg.X.Value, &z)
+	f.Mul(&y, &arg.Y.Value, &z)
+
+	out.Z.SetOne()
+	// If point at infinity this does nothing
+	f.Selectznz(&x, &zero, &x, wasInverted)
+	f.Selectznz(&y, &zero, &y, wasInverted)
+	f.Selectznz(&z, &zero, &out.Z.Value, wasInverted)
+
+	out.X.Value = x
+	out.Y.Value = y
+	out.Z.Value = z
+	out.Params = arg.Params
+	out.Arithmetic = arg.Arithmetic
+}
+
+func (pointArithmetic) RhsEquation(out, x *native.Field4) {
+	// Elliptic curve equation for SM2 is: y^2 = x^3 + ax + b
+	out.Square(x)
+	out.Mul(out, x)
+	out.Add(out, getPointParams().B)
+	out.Add(out, FpNew().Mul(getPointParams().A, x))
+}
@@ -0,0 +1,69 @@
+package sm2_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikelodder7/curvey/native"
+	"github.com/mikelodder7/curvey/native/sm2"
+)
+
+func bhex(s string) *big.Int {
+	r, _ := new(big.Int).SetString(s, 16)
+	return r
+}
+
+// The expected points were computed with OpenSSL 3.0 (k*G) and an
+// independent RFC 9380 implementation (hash to curve).
+
+func TestSm2PointArithmetic_Double(t *testing.T) {
+	g := sm2.PointNew().Generator()
+	require.True(t, g.IsOnCurve())
+	pt1 := sm2.PointNew().Double(g)
+	pt2 := sm2.PointNew().Add(g, g)
+	pt3 := sm2.PointNew().Mul(g, sm2.FqNew().SetUint64(2))
+
+	require.Equal(t, 1, pt1.Equal(pt2))
+	require.Equal(t, 1, pt1.Equal(pt3))
+	require.Equal(t, 1, pt2.Equal(pt3))
+
+	x, y := pt1.BigInt()
+	require.Equal(t, 0, x.Cmp(bhex("56cefd60d7c87c000d58ef57fa73ba4d9c0dfa08c08a7331495c2e1da3f2bd52")))
+	require.Equal(t, 0, y.Cmp(bhex("31b7e7e6cc8189f668535ce0f8eaf1bd6de84c182f6c8e716f780d3a970a23c3")))
+}
+
+func TestSm2PointArithmetic_Mul(t *testing.T) {
+	g := sm2.PointNew().Generator()
+	pt := sm2.PointNew().Mul(g, sm2.FqNew().SetUint64(0x1234567890abcdef))
+	require.True(t, pt.IsOnCurve())
+	x, y := pt.BigInt()
+	require.Equal(t, 0, x.Cmp(bhex("cb79bea697a5f90c00fd7d6bb8eb74f78c0b94542f9eb082f626d07b3cb7c2ce")))
+	require.Equal(t, 0, y.Cmp(bhex("cbb70aded5cb7124695bf72de3e85c7587bcfc9db97852dcfd514ef252001f5e")))
+
+	// n * G = 0
+	n := sm2.FqNew().SetOne()
+	n.Neg(n)
+	pt.Mul(g, n)
+	pt.Add(pt, g)
+	require.True(t, pt.IsIdentity())
+}
+
+func TestSm2PointArithmetic_Hash(t *testing.T) {
+	tests := []struct {
+		msg  string
+		x, y string
+	}{
+		{"", "d61e55bd0ef336027690eb5c55f86228b8fb5fa852a236524c7e10ab89a0a7b9", "9c1c07e517239929f874295797b41f83d0215395d07d80b34aca036e4df546cd"},
+		{"abc", "27e15df2b00b3fc255c3ca7f4f2d598ee43f12801dedc64ca850c4a02cbd56", "8d26c0ac885869116b62b961d04d77c09d793cc54f39f5110f728afb854de508"},
+	}
+	for _, tst := range tests {
+		pt, err := sm2.PointNew().Hash([]byte(tst.msg), native.EllipticPointHasherSm3())
+		require.NoError(t, err)
+		require.True(t, pt.IsOnCurve())
+		x, y := pt.BigInt()
+		require.Equal(t, 0, x.Cmp(bhex(tst.x)))
+		require.Equal(t, 0, y.Cmp(bhex(tst.y)))
+	}
+}
@@ -0,0 +1,52 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package sm3
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSum(t *testing.T) {
+	tests := []struct {
+		msg, digest string
+	}{
+		// GB/T 32905-2016, Appendix A
+		{"abc", "66c7f0f462eeedd9d1f2d46bdc10e4e24167c4875cf2f7a2297da02b8f4ba8e0"},
+		{strings.Repeat("abcd", 16), "debe9ff92275b8a138604889c18e5a4d6fdb70e5387e5765293dcba39c0c5732"},
+		{"", "1ab21d8355cfa17f8e61194831e81a8f22bec8c728fefb747ed035eb5082aa2b"},
+		{strings.Repeat("a", 55), "288337eef51eec62e7544d7270424c8dbe656254c99852870a73b2453a6a7fb1"},
+		{strings.Repeat("a", 56), "ba00ebedaab54065a5fd4f9f56326016203166bcee3eed44ea868d59d67aa3c8"},
+	}
+	for _, tst := range tests {
+		expected, _ := hex.DecodeString(tst.digest)
+		actual := Sum([]byte(tst.msg))
+		require.Equal(t, expected, actual[:])
+
+		h := New()
+		_, _ = h.Write([]byte(tst.msg))
+		require.Equal(t, expected, h.Sum(nil))
+	}
+}
+
+func TestWriteChunks(t *testing.T) {
+	expected, _ := hex.DecodeString("c8aaf89429554029e231941a2acc0ad61ff2a5acd8fadd25847a3a732b3b02c3")
+	h := New()
+	chunk := []byte(strings.Repeat("a", 1000))
+	for i := 0; i < 1000; i++ {
+		_, _ = h.Write(chunk[:i%7+1])
+		_, _ = h.Write(chunk[i%7+1:])
+	}
+	require.Equal(t, expected, h.Sum(nil))
+	// Sum does not change the state
+	require.Equal(t, expected, h.Sum(nil))
+
+	h.Reset()
+	_, _ = h.Write([]byte("abc"))
+	require.Equal(t, "66c7f0f462eeedd9d1f2d46bdc10e4e24167c4875cf2f7a2297da02b8f4ba8e0", hex.EncodeToString(h.Sum(nil)))
+}
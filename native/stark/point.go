@@ -0,0 +1,273 @@
+package stark
+
+import (
+	"sync"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+)
+
+var (
+	starkPointInitonce     sync.Once
+	starkPointParams       native.EllipticPoint4Params
+	starkPointSvdwInitOnce sync.Once
+	starkPointSvdwParams   native.Svdw4Params
+)
+
+// PointNew returns a point on the STARK curve, y^2 = x^3 + x + b
+// over the prime field of order 2^251 + 17 * 2^192 + 1.
+func PointNew() *native.EllipticPoint4 {
+	return &native.EllipticPoint4{
+		X:          FpNew(),
+		Y:          FpNew(),
+		Z:          FpNew(),
+		Params:     getPointParams(),
+		Arithmetic: &pointArithmetic{},
+	}
+}
+
+func pointParamsInit() {
+	starkPointParams = native.EllipticPoint4Params{
+		A: FpNew().SetOne(),
+		B: FpNew().SetLimbs(&[native.Field4Limbs]uint64{
+			0xf4cdfcb99cee9e89,
+			0x609ad26c15c915c1,
+			0x150e596d72f7a8c5,
+			0x06f21413efbe40de,
+		}),
+		Gx: FpNew().SetLimbs(&[native.Field4Limbs]uint64{
+			0x3d723d8bc943cfca,
+			0xdeacfd9b0d1819e0,
+			0x7beced415a40f0c7,
+			0x01ef15c18599971b,
+		}),
+		Gy: FpNew().SetLimbs(&[native.Field4Limbs]uint64{
+			0x2873000c36e8dc1f,
+			0xde53ecd11abe43a3,
+			0xb7be4801df46ec62,
+			0x005668060aa49730,
+		}),
+		BitSize: 252,
+		Name:    "stark-curve",
+	}
+}
+
+func getPointParams() *native.EllipticPoint4Params {
+	starkPointInitonce.Do(pointParamsInit)
+	return &starkPointParams
+}
+
+func getPointSvdwParams() *native.Svdw4Params {
+	starkPointSvdwInitOnce.Do(pointSvdwParamsInit)
+	return &starkPointSvdwParams
+}
+
+func pointSvdwParamsInit() {
+	// The field has 2-adicity 192 and no suitable isogeny is known
+	// so the Shallue-van de Woestijne map is used with Z = 1
+	starkPointSvdwParams = native.Svdw4Params{
+		// g(Z) = 2 + b
+		C1: [native.Field4Limbs]uint64{0x359ddd67b59a218a, 0x6725f2237aab9006, 0xab8a1e002a41f947, 0x013931651774203f},
+		// -Z / 2
+		C2: [native.Field4Limbs]uint64{0x0000000000000010, 0x0000000000000000, 0x0000000000000000, 0x0000000000000110},
+		// sqrt(-g(Z) * (3 * Z^2 + 4 * A))
+		C3: [native.Field4Limbs]uint64{0x89a4b864cf3e5d43, 0xf331bd6c65a79ca5, 0xd434e2adb6b6a2fb, 0x0714e13a1dfec1ff},
+		// -4 * g(Z) / (3 * Z^2 + 4 * A)
+		C4: [native.Field4Limbs]uint64{0xbcca5ce94f15a3b2, 0xa07c9a34de79648e, 0x0bb1136d9eb5bab2, 0x0628760f604feda1},
+		// 1
+		A: [native.Field4Limbs]uint64{0xffffffffffffffe1, 0xffffffffffffffff, 0xffffffffffffffff, 0x07fffffffffffdf0},
+		// b
+		B: [native.Field4Limbs]uint64{0x359ddd67b59a21ca, 0x6725f2237aab9006, 0xab8a1e002a41f947, 0x013931651774247f},
+		// 1
+		Z: [native.Field4Limbs]uint64{0xffffffffffffffe1, 0xffffffffffffffff, 0xffffffffffffffff, 0x07fffffffffffdf0},
+	}
+}
+
+type pointArithmetic struct{}
+
+func (k pointArithmetic) Hash(out *native.EllipticPoint4, hash *native.EllipticPointHasher, msg, dst []byte) error {
+	var u []byte
+	svdwParams := getPointSvdwParams()
+
+	switch hash.Type() {
+	case native.XMD:
+		u = native.ExpandMsgXmd(hash, msg, dst, 96)
+	case native.XOF:
+		u = native.ExpandMsgXof(hash, msg, dst, 96)
+	}
+	var buf [native.WideField4Bytes]byte
+	copy(buf[:48], internal.ReverseBytes(u[:48]))
+	u0 := FpNew().SetBytesWide(&buf)
+	copy(buf[:48], internal.ReverseBytes(u[48:]))
+	u1 := FpNew().SetBytesWide(&buf)
+
+	q0x, q0y := svdwParams.Map(u0)
+	q1x, q1y := svdwParams.Map(u1)
+	out.X = q0x
+	out.Y = q0y
+	out.Z.SetOne()
+	tv := &native.EllipticPoint4{
+		X: q1x,
+		Y: q1y,
+		Z: FpNew().SetOne(),
+	}
+	// The cofactor is 1 so no clearing is needed
+	k.Add(out, out, tv)
+	return nil
+}
+
+func (pointArithmetic) Double(out, arg *native.EllipticPoint4) {
+	// Doubling formula for any a from Renes-Costello-Batina 2015
+	// (https://eprint.iacr.org/2015/1060 Algorithm 3)
+	var t0, t1, t2, t3, b3, x, y, z [native.Field4Limbs]uint64
+	a := getPointParams().A.Value
+	b := getPointParams().B.Value
+	f := arg.X.Arithmetic
+
+	f.Add(&b3, &b, &b)
+	f.Add(&b3, &b3, &b)
+
+	f.Square(&t0, &arg.X.Value)
+	f.Square(&t1, &arg.Y.Value)
+	f.Square(&t2, &arg.Z.Value)
+	f.Mul(&t3, &arg.X.Value, &arg.Y.Value)
+	f.Add(&t3, &t3, &t3)
+	f.Mul(&z, &arg.X.Value, &arg.Z.Value)
+	f.Add(&z, &z, &z)
+	f.Mul(&x, &a, &z)
+	f.Mul(&y, &b3, &t2)
+	f.Add(&y, &x, &y)
+	f.Sub(&x, &t1, &y)
+	f.Add(&y, &t1, &y)
+	f.Mul(&y, &x, &y)
+	f.Mul(&x, &t3, &x)
+	f.Mul(&z, &b3, &z)
+	f.Mul(&t2, &a, &t2)
+	f.Sub(&t3, &t0, &t2)
+	f.Mul(&t3, &a, &t3)
+	f.Add(&t3, &t3, &z)
+	f.Add(&z, &t0, &t0)
+	f.Add(&t0, &z, &t0)
+	f.Add(&t0, &t0, &t2)
+	f.Mul(&t0, &t0, &t3)
+	f.Add(&y, &y, &t0)
+	f.Mul(&t2, &arg.Y.Value, &arg.Z.Value)
+	f.Add(&t2, &t2, &t2)
+	f.Mul(&t0, &t2, &t3)
+	f.Sub(&x, &x, &t0)
+	f.Mul(&z, &t2, &t1)
+	f.Add(&z, &z, &z)
+	f.Add(&z, &z, &z)
+
+	out.X.Value = x
+	out.Y.Value = y
+	out.Z.Value = z
+}
+
+func (pointArithmetic) Add(out, arg1, arg2 *native.EllipticPoint4) {
+	// Addition formula for any a from Renes-Costello-Batina 2015
+	// (https://eprint.iacr.org/2015/1060 Algorithm 1).
+	var t0, t1, t2, t3, t4, t5, b3, x, y, z [native.Field4Limbs]uint64
+	a := getPointParams().A.Value
+	b := getPointParams().B.Value
+	f := arg1.X.Arithmetic
+
+	f.Add(&b3, &b, &b)
+	f.Add(&b3, &b3, &b)
+
+	f.Mul(&t0, &arg1.X.Value, &arg2.X.Value)
+	f.Mul(&t1, &arg1.Y.Value, &arg2.Y.Value)
+	f.Mul(&t2, &arg1.Z.Value, &arg2.Z.Value)
+	f.Add(&t3, &arg1.X.Value, &arg1.Y.Value)
+	f.Add(&t4, &arg2.X.Value, &arg2.Y.Value)
+	f.Mul(&t3, &t3, &t4)
+	f.Add(&t4, &t0, &t1)
+	f.Sub(&t3, &t3, &t4)
+	f.Add(&t4, &arg1.X.Value, &arg1.Z.Value)
+	f.Add(&t5, &arg2.X.Value, &arg2.Z.Value)
+	f.Mul(&t4, &t4, &t5)
+	f.Add(&t5, &t0, &t2)
+	f.Sub(&t4, &t4, &t5)
+	f.Add(&t5, &arg1.Y.Value, &arg1.Z.Value)
+	f.Add(&x, &arg2.Y.Value, &arg2.Z.Value)
+	f.Mul(&t5, &t5, &x)
+	f.Add(&x, &t1, &t2)
+	f.Sub(&t5, &t5, &x)
+	f.Mul(&z, &a, &t4)
+	f.Mul(&x, &b3, &t2)
+	f.Add(&z, &x, &z)
+	f.Sub(&x, &t1, &z)
+	f.Add(&z, &t1, &z)
+	f.Mul(&y, &x, &z)
+	f.Add(&t1, &t0, &t0)
+	f.Add(&t1, &t1, &t0)
+	f.Mul(&t2, &a, &t2)
+	f.Mul(&t4, &b3, &t4)
+	f.Add(&t1, &t1, &t2)
+	f.Sub(&t2, &t0, &t2)
+	f.Mul(&t2, &a, &t2)
+	f.Add(&t4, &t4, &t2)
+	f.Mul(&t0, &t1, &t4)
+	f.Add(&y, &y, &t0)
+	f.Mul(&t0, &t5, &t4)
+	f.Mul(&x, &t3, &x)
+	f.Sub(&x, &x, &t0)
+	f.Mul(&t0, &t3, &t1)
+	f.Mul(&z, &t5, &z)
+	f.Add(&z, &z, &t0)
+
+	e1 := arg1.Z.IsZero()
+	e2 := arg2.Z.IsZero()
+
+	// If arg1 is identity set it to arg2
+	f.Selectznz(&z, &z, &arg2.Z.Value, e1)
+	f.Selectznz(&y, &y, &arg2.Y.Value, e1)
+	f.Selectznz(&x, &x, &arg2.X.Value, e1)
+	// If arg2 is identity set it to arg1
+	f.Selectznz(&z, &z, &arg1.Z.Value, e2)
+	f.Selectznz(&y, &y, &arg1.Y.Value, e2)
+	f.Selectznz(&x, &x, &arg1.X.Value, e2)
+
+	out.X.Value = x
+	out.Y.Value = y
+	out.Z.Value = z
+}
+
+func (k pointArithmetic) IsOnCurve(arg *native.EllipticPoint4) bool {
+	affine := PointNew()
+	k.ToAffine(affine, arg)
+	lhs := FpNew().Square(affine.Y)
+	rhs := FpNew()
+	k.RhsEquation(rhs, affine.X)
+	return lhs.Equal(rhs) == 1
+}
+
+func (pointArithmetic) ToAffine(out, arg *native.EllipticPoint4) {
+	var wasInverted int
+	var zero, x, y, z [native.Field4Limbs]uint64
+	f := arg.X.Arithmetic
+
+	f.Invert(&wasInverted, &z, &arg.Z.Value)
+	f.Mul(&x, &arg.X.Value, &z)
+	f.Mul(&y, &arg.Y.Value, &z)
+
+	out.Z.SetOne()
+	// If point at infinity this does nothing
+	f.Selectznz(&x, &zero, &x, wasInverted)
+	f.Selectznz(&y, &zero, &y, wasInverted)
+	f.Selectznz(&z, &zero, &out.Z.Value, wasInverted)
+
+	out.X.Value = x
+	out.Y.Value = y
+	out.Z.Value = z
+	out.Params = arg.Params
+	out.Arithmetic = arg.Arithmetic
+}
+
+func (pointArithmetic) RhsEquation(out, x *native.Field4) {
+	// Elliptic curve equation for the STARK curve is: y^2 = x^3 + x + b
+	out.Square(x)
+	out.Mul(out, x)
+	out.Add(out, getPointParams().B)
+	out.Add(out, FpNew().Mul(getPointParams().A, x))
+}
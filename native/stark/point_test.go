@@ -0,0 +1,92 @@
+package stark_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikelodder7/curvey/native"
+	"github.com/mikelodder7/curvey/native/stark"
+)
+
+func bhex(s string) *big.Int {
+	r, _ := new(big.Int).SetString(s, 16)
+	return r
+}
+
+func TestStarkPointArithmetic_Double(t *testing.T) {
+	g := stark.PointNew().Generator()
+	require.True(t, g.IsOnCurve())
+	pt1 := stark.PointNew().Double(g)
+	pt2 := stark.PointNew().Add(g, g)
+	pt3 := stark.PointNew().Mul(g, stark.FqNew().SetUint64(2))
+
+	require.Equal(t, 1, pt1.Equal(pt2))
+	require.Equal(t, 1, pt1.Equal(pt3))
+	require.Equal(t, 1, pt2.Equal(pt3))
+
+	x, y := pt1.BigInt()
+	require.Equal(t, 0, x.Cmp(bhex("759ca09377679ecd535a81e83039658bf40959283187c654c5416f439403cf5")))
+	require.Equal(t, 0, y.Cmp(bhex("6f524a3400e7708d5c01a28598ad272e7455aa88778b19f93b562d7a9646c41")))
+}
+
+func TestStarkPointArithmetic_Mul(t *testing.T) {
+	g := stark.PointNew().Generator()
+	pt := stark.PointNew().Mul(g, stark.FqNew().SetUint64(0x1234567890abcdef))
+	require.True(t, pt.IsOnCurve())
+	x, _ := pt.BigInt()
+	require.Equal(t, 0, x.Cmp(bhex("2abbefdcbf731195ee2acd186441eb536e86f888327b3655cffbd07b57dbf26")))
+
+	// n * G = 0
+	n := stark.FqNew().SetOne()
+	n.Neg(n)
+	pt.Mul(g, n)
+	pt.Add(pt, g)
+	require.True(t, pt.IsIdentity())
+}
+
+func TestStarkPointArithmetic_Hash(t *testing.T) {
+	dst := []byte("stark-curve_XMD:SHA-256_SVDW_RO_")
+	tests := []struct {
+		msg  string
+		x, y string
+	}{
+		{"", "654b8a24f0ab6f4d6f2cdfd4535b665c5945b23b78c6d31007daf93f5035d83", "554770bc28b654cd39e85e456ad90cd848b8d4c3105dd24abafb52b6a5df4e"},
+		{"abc", "4c8b193665b0f921fd773b061228beb9851b9362e5902dd346abd1d06cd92e9", "422f77a545dd001d3d5337548649129c2c921f393a3222165c34720d914c5ff"},
+	}
+	for _, tst := range tests {
+		pt := stark.PointNew()
+		err := pt.Arithmetic.Hash(pt, native.EllipticPointHasherSha256(), []byte(tst.msg), dst)
+		require.NoError(t, err)
+		require.True(t, pt.IsOnCurve())
+		x, y := pt.BigInt()
+		require.Equal(t, 0, x.Cmp(bhex(tst.x)))
+		require.Equal(t, 0, y.Cmp(bhex(tst.y)))
+	}
+}
+
+func TestStarkPedersenHash(t *testing.T) {
+	tests := []struct {
+		a, b, expected string
+	}{
+		{"0", "0", "49ee3eba8c1600700ee1b87eb599f16716b0b1022947733551fde4050ca6804"},
+		{"1", "2", "5bb9440e27889a364bcb678b1f679ecd1347acdedcbf36e83494f857cc58026"},
+		{
+			"3d937c035c878245caf64531a5756109c53068da139362728feb561405371cb",
+			"208a0a10250e382e1e4bbe2880906c2791bf6275695e02fbbc6aeff9cd8b31a",
+			"30e480bed5fe53fa909cc0f8c4d99b8f9f2c016be4c41e13a4848797979c662",
+		},
+		{
+			"800000000000011000000000000000000000000000000000000000000000000",
+			"100000000000000000000000000000000000000000000000000000000000005",
+			"34ad53780d04c56644834b511f339ad5dd4523be33d7979b81c8fd300566cd8",
+		},
+	}
+	for _, tst := range tests {
+		a := stark.FpNew().SetBigInt(bhex(tst.a))
+		b := stark.FpNew().SetBigInt(bhex(tst.b))
+		h := stark.PedersenHash(a, b)
+		require.Equal(t, 0, h.BigInt().Cmp(bhex(tst.expected)))
+	}
+}
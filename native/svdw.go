@@ -0,0 +1,77 @@
+package native
+
+// Svdw4Params for computing the Shallue-van de Woestijne mapping
+// for hash to curve implementations on curves where
+// the simplified SWU map does not apply i.e. A = 0 and
+// no suitable isogeny is available.
+type Svdw4Params struct {
+	// C1 = g(Z)
+	// C2 = -Z / 2
+	// C3 = sqrt(-g(Z) * (3 * Z^2 + 4 * A)) where sgn0(C3) == 0
+	// C4 = -4 * g(Z) / (3 * Z^2 + 4 * A)
+	C1, C2, C3, C4, A, B, Z [Field4Limbs]uint64
+}
+
+// Map computes the Shallue-van de Woestijne map from
+// section F.1 in <https://www.rfc-editor.org/rfc/rfc9380.html>
+func (p *Svdw4Params) Map(u *Field4) (x, y *Field4) {
+	var tv1, tv2, tv3, tv4, x1, x2, x3, gx, t [Field4Limbs]uint64
+	var wasInverted, wasSquare, e1, e2 int
+	f := u.Arithmetic
+
+	f.Square(&tv1, &u.Value)       // tv1 = u^2
+	f.Mul(&tv1, &tv1, &p.C1)       // tv1 = tv1 * c1
+	f.Add(&tv2, &u.Params.R, &tv1) // tv2 = 1 + tv1
+	f.Sub(&tv1, &u.Params.R, &tv1) // tv1 = 1 - tv1
+	f.Mul(&tv3, &tv1, &tv2)        // tv3 = tv1 * tv2
+	f.Invert(&wasInverted, &tv3, &tv3)
+	f.Selectznz(&tv3, &t, &tv3, wasInverted) // tv3 = inv0(tv3)
+	f.Mul(&tv4, &u.Value, &tv1)              // tv4 = u * tv1
+	f.Mul(&tv4, &tv4, &tv3)                  // tv4 = tv4 * tv3
+	f.Mul(&tv4, &tv4, &p.C3)                 // tv4 = tv4 * c3
+
+	f.Sub(&x1, &p.C2, &tv4) // x1 = c2 - tv4
+	p.rhs(f, &gx, &x1)      // gx1 = x1^3 + A * x1 + B
+	f.Sqrt(&e1, &t, &gx)    // e1 = is_square(gx1)
+
+	f.Add(&x2, &p.C2, &tv4) // x2 = c2 + tv4
+	p.rhs(f, &gx, &x2)      // gx2 = x2^3 + A * x2 + B
+	f.Sqrt(&e2, &t, &gx)    // e2 = is_square(gx2) AND NOT e1
+	e2 &= e1 ^ 1
+
+	f.Square(&x3, &tv2)    // x3 = tv2^2
+	f.Mul(&x3, &x3, &tv3)  // x3 = x3 * tv3
+	f.Square(&x3, &x3)     // x3 = x3^2
+	f.Mul(&x3, &x3, &p.C4) // x3 = x3 * c4
+	f.Add(&x3, &x3, &p.Z)  // x3 = x3 + Z
+
+	x = new(Field4).Set(u)
+	y = new(Field4).Set(u)
+
+	f.Selectznz(&x.Value, &x3, &x1, e1)      // x = CMOV(x3, x1, e1)
+	f.Selectznz(&x.Value, &x.Value, &x2, e2) // x = CMOV(x, x2, e2)
+	p.rhs(f, &gx, &x.Value)                  // gx = x^3 + A * x + B
+	f.Sqrt(&wasSquare, &y.Value, &gx)        // y = sqrt(gx)
+
+	uBytes := u.Bytes()
+	yBytes := y.Bytes()
+
+	usign := uBytes[0] & 1
+	ysign := yBytes[0] & 1
+
+	// Fix sign of y
+	if usign != ysign {
+		y.Neg(y)
+	}
+
+	return x, y
+}
+
+// rhs computes x^3 + A * x + B.
+func (p *Svdw4Params) rhs(f Field4Arithmetic, out, x *[Field4Limbs]uint64) {
+	var tv [Field4Limbs]uint64
+	f.Square(&tv, x)
+	f.Add(&tv, &tv, &p.A)
+	f.Mul(&tv, &tv, x)
+	f.Add(out, &tv, &p.B)
+}
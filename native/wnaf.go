@@ -0,0 +1,60 @@
+package native
+
+// WnafWidth is the window width used by the interleaved wNAF
+// multiplications. Each point needs a table of its 2^(WnafWidth-2)
+// odd multiples and on average one addition every WnafWidth+1 bits.
+const WnafWidth = 5
+
+// Wnaf recodes the little endian limbs of k into width w non-adjacent form.
+// digits[i] is the coefficient of 2^i and is either zero or odd with an
+// absolute value below 2^(w-1). At most one of any w consecutive digits is
+// non-zero. The digits end at the highest non-zero digit so the result is
+// empty when k is zero. The running time depends on k.
+func Wnaf(k []uint64, w int) []int8 {
+	bits := 64 * len(k)
+	digits := make([]int8, bits+1)
+	width := uint64(1) << w
+	var carry uint64
+	for pos := 0; pos <= bits; {
+		window := carry + wnafBits(k, pos, w)
+		if window&1 == 0 {
+			pos++
+			continue
+		}
+		if window < width/2 {
+			carry = 0
+			digits[pos] = int8(window)
+		} else {
+			carry = 1
+			digits[pos] = int8(int64(window) - int64(width))
+		}
+		pos += w
+	}
+	for len(digits) > 0 && digits[len(digits)-1] == 0 {
+		digits = digits[:len(digits)-1]
+	}
+	return digits
+}
+
+// wnafBits returns the w bits of k starting at pos, bits past the end are zero.
+func wnafBits(k []uint64, pos, w int) uint64 {
+	limb, offset := pos>>6, uint(pos&63)
+	var t uint64
+	if limb < len(k) {
+		t = k[limb] >> offset
+	}
+	if offset != 0 && limb+1 < len(k) {
+		t |= k[limb+1] << (64 - offset)
+	}
+	return t & (1<<w - 1)
+}
+
+// wnafNeg negates the digits in place when neg is 1.
+func wnafNeg(digits []int8, neg int) []int8 {
+	if neg == 1 {
+		for i := range digits {
+			digits[i] = -digits[i]
+		}
+	}
+	return digits
+}
@@ -0,0 +1,59 @@
+package native
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWnaf(t *testing.T) {
+	inputs := [][]uint64{
+		{},
+		{0},
+		{1},
+		{15},
+		{16},
+		{0xFFFFFFFFFFFFFFFF},
+		{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF},
+	}
+	for i := 0; i < 16; i++ {
+		var b [32]byte
+		_, _ = crand.Read(b[:])
+		k := make([]uint64, 4)
+		for j := range k {
+			k[j] = binary.LittleEndian.Uint64(b[8*j:])
+		}
+		inputs = append(inputs, k)
+	}
+	for _, k := range inputs {
+		for _, w := range []int{2, 4, WnafWidth, 8} {
+			digits := Wnaf(k, w)
+			expected := new(big.Int)
+			for j := len(k) - 1; j >= 0; j-- {
+				expected.Lsh(expected, 64).Or(expected, new(big.Int).SetUint64(k[j]))
+			}
+			actual := new(big.Int)
+			last := -w
+			for j := len(digits) - 1; j >= 0; j-- {
+				actual.Lsh(actual, 1).Add(actual, big.NewInt(int64(digits[j])))
+			}
+			for j, d := range digits {
+				if d == 0 {
+					continue
+				}
+				require.Equal(t, int8(1), d&1)
+				require.Less(t, int(d), 1<<(w-1))
+				require.Greater(t, int(d), -(1 << (w - 1)))
+				require.GreaterOrEqual(t, j-last, w)
+				last = j
+			}
+			require.Equal(t, expected, actual)
+			if len(digits) > 0 {
+				require.NotZero(t, digits[len(digits)-1])
+			}
+		}
+	}
+}
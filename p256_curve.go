@@ -479,6 +479,14 @@ func (p *PointP256) Mul(rhs Scalar) Point {
 	}
 }
 
+func (*PointP256) selectPoint(row []Point, index int) Point {
+	value := p256n.PointNew().Identity()
+	for i, pt := range row {
+		value.CMove(value, pt.(*PointP256).value, ctEqual(i+1, index))
+	}
+	return &PointP256{value}
+}
+
 func (p *PointP256) Equal(rhs Point) bool {
 	r, ok := rhs.(*PointP256)
 	if ok {
@@ -611,6 +619,55 @@ func (*PointP256) SumOfProducts(points []Point, scalars []Scalar) Point {
 	return &PointP256{value}
 }
 
+func (*PointP256) sumOfProductsVarTime(points []Point, scalars []Scalar, opts *MsmOptions) Point {
+	nPoints := make([]*native.EllipticPoint4, len(points))
+	nScalars := make([]*native.Field4, len(scalars))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointP256)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarP256)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.value
+	}
+	value := p256n.PointNew()
+	_, err := value.MsmVarTime(nPoints, nScalars, opts)
+	if err != nil {
+		return nil
+	}
+	return &PointP256{value}
+}
+
+func (p *PointP256) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point {
+	return p.VarTimeMultiScalarMult([]Scalar{a, b}, []Point{capA, p.Generator()})
+}
+
+func (p *PointP256) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	return p.sumOfProductsVarTime(points, scalars, nil)
+}
+
+func (*PointP256) batchNormalize(points []Point) []Point {
+	nPoints := make([]*native.EllipticPoint4, len(points))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointP256)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	out := make([]Point, len(points))
+	for i, value := range native.BatchToAffine(nPoints) {
+		out[i] = &PointP256{value}
+	}
+	return out
+}
+
 func (p *PointP256) X() *native.Field4 {
 	return p.value.GetX()
 }
@@ -479,6 +479,14 @@ func (p *PointP384) Mul(rhs Scalar) Point {
 	}
 }
 
+func (*PointP384) selectPoint(row []Point, index int) Point {
+	value := p384n.PointNew().Identity()
+	for i, pt := range row {
+		value.CMove(value, pt.(*PointP384).value, ctEqual(i+1, index))
+	}
+	return &PointP384{value}
+}
+
 func (p *PointP384) Equal(rhs Point) bool {
 	r, ok := rhs.(*PointP384)
 	if ok {
@@ -611,6 +619,30 @@ func (*PointP384) SumOfProducts(points []Point, scalars []Scalar) Point {
 	return &PointP384{value}
 }
 
+func (p *PointP384) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point {
+	return p.VarTimeMultiScalarMult([]Scalar{a, b}, []Point{capA, p.Generator()})
+}
+
+func (p *PointP384) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	return varTimeMultiScalarMult(p, scalars, points)
+}
+
+func (*PointP384) batchNormalize(points []Point) []Point {
+	nPoints := make([]*native.EllipticPoint6, len(points))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointP384)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	out := make([]Point, len(points))
+	for i, value := range native.BatchToAffine6(nPoints) {
+		out[i] = &PointP384{value}
+	}
+	return out
+}
+
 func (p *PointP384) X() *native.Field6 {
 	return p.value.GetX()
 }
@@ -0,0 +1,707 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"crypto/elliptic"
+	"fmt"
+	"github.com/mikelodder7/curvey/internal"
+	"io"
+	"math/big"
+	"sync"
+
+	"github.com/mikelodder7/curvey/native"
+	p521n "github.com/mikelodder7/curvey/native/p521"
+	"github.com/mikelodder7/curvey/native/p521/fp"
+	"github.com/mikelodder7/curvey/native/p521/fq"
+)
+
+var (
+	oldP521InitOnce sync.Once
+	oldP521         NistP521
+)
+
+type NistP521 struct {
+	*elliptic.CurveParams
+}
+
+func oldP521InitAll() {
+	curve := elliptic.P521()
+	oldP521.CurveParams = curve.Params()
+	oldP521.P = curve.Params().P
+	oldP521.N = curve.Params().N
+	oldP521.Gx = curve.Params().Gx
+	oldP521.Gy = curve.Params().Gy
+	oldP521.B = curve.Params().B
+	oldP521.BitSize = curve.Params().BitSize
+	oldP521.Name = curve.Params().Name
+}
+
+func NistP521Curve() *NistP521 {
+	oldP521InitOnce.Do(oldP521InitAll)
+	return &oldP521
+}
+
+func (curve *NistP521) Params() *elliptic.CurveParams {
+	return curve.CurveParams
+}
+
+func (*NistP521) IsOnCurve(x, y *big.Int) bool {
+	_, err := p521n.PointNew().SetBigInt(x, y)
+	return err == nil
+}
+
+func (*NistP521) Add(x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int) {
+	p1, err := p521n.PointNew().SetBigInt(x1, y1)
+	if err != nil {
+		return nil, nil
+	}
+	p2, err := p521n.PointNew().SetBigInt(x2, y2)
+	if err != nil {
+		return nil, nil
+	}
+	return p1.Add(p1, p2).BigInt()
+}
+
+func (*NistP521) Double(x1, y1 *big.Int) (*big.Int, *big.Int) {
+	p1, err := p521n.PointNew().SetBigInt(x1, y1)
+	if err != nil {
+		return nil, nil
+	}
+	return p1.Double(p1).BigInt()
+}
+
+func (*NistP521) ScalarMul(capBx, capBy *big.Int, k []byte) (*big.Int, *big.Int) {
+	p1, err := p521n.PointNew().SetBigInt(capBx, capBy)
+	if err != nil {
+		return nil, nil
+	}
+	var bytes [66]byte
+	copy(bytes[:], internal.ReverseBytes(k))
+	s, err := fq.P521FqNew().SetBytes(&bytes)
+	if err != nil {
+		return nil, nil
+	}
+	return p1.Mul(p1, s).BigInt()
+}
+
+func (*NistP521) ScalarBaseMult(k []byte) (*big.Int, *big.Int) {
+	var bytes [66]byte
+	copy(bytes[:], internal.ReverseBytes(k))
+	s, err := fq.P521FqNew().SetBytes(&bytes)
+	if err != nil {
+		return nil, nil
+	}
+	p1 := p521n.PointNew().Generator()
+	return p1.Mul(p1, s).BigInt()
+}
+
+type ScalarP521 struct {
+	value *native.Field9
+}
+
+type PointP521 struct {
+	value *native.EllipticPoint9
+}
+
+func (s *ScalarP521) Random(reader io.Reader) Scalar {
+	if reader == nil {
+		return nil
+	}
+	var seed [144]byte
+	_, _ = reader.Read(seed[:])
+	return s.Hash(seed[:])
+}
+
+func (*ScalarP521) Hash(bytes []byte) Scalar {
+	dst := []byte("P521_XMD:SHA-512_SSWU_RO_")
+	xmd := native.ExpandMsgXmd(native.EllipticPointHasherSha512(), bytes, dst, 98)
+	var t [144]byte
+	copy(t[:98], internal.ReverseBytes(xmd))
+
+	return &ScalarP521{
+		value: fq.P521FqNew().SetBytesWide(&t),
+	}
+}
+
+func (*ScalarP521) Zero() Scalar {
+	return &ScalarP521{
+		value: fq.P521FqNew().SetZero(),
+	}
+}
+
+func (*ScalarP521) One() Scalar {
+	return &ScalarP521{
+		value: fq.P521FqNew().SetOne(),
+	}
+}
+
+func (s *ScalarP521) IsZero() bool {
+	return s.value.IsZero() == 1
+}
+
+func (s *ScalarP521) IsOne() bool {
+	return s.value.IsOne() == 1
+}
+
+func (s *ScalarP521) IsOdd() bool {
+	return s.value.Bytes()[0]&1 == 1
+}
+
+func (s *ScalarP521) IsEven() bool {
+	return s.value.Bytes()[0]&1 == 0
+}
+
+func (*ScalarP521) New(value int) Scalar {
+	t := fq.P521FqNew()
+	v := big.NewInt(int64(value))
+	if value < 0 {
+		v.Mod(v, t.Params.BiModulus)
+	}
+	return &ScalarP521{
+		value: t.SetBigInt(v),
+	}
+}
+
+func (s *ScalarP521) Cmp(rhs Scalar) int {
+	r, ok := rhs.(*ScalarP521)
+	if ok {
+		return s.value.Cmp(r.value)
+	} else {
+		return -2
+	}
+}
+
+func (s *ScalarP521) Square() Scalar {
+	return &ScalarP521{
+		value: fq.P521FqNew().Square(s.value),
+	}
+}
+
+func (s *ScalarP521) Pow(exp uint64) Scalar {
+	expFieldLimb := [native.Field9Limbs]uint64{exp}
+	out := ScalarP521{value: fq.P521FqNew()}
+	native.Pow9(&out.value.Value, &s.value.Value, &expFieldLimb, s.value.Params, s.value.Arithmetic)
+	return &ScalarP521{
+		value: out.value,
+	}
+}
+
+func (s *ScalarP521) Double() Scalar {
+	return &ScalarP521{
+		value: fq.P521FqNew().Double(s.value),
+	}
+}
+
+func (s *ScalarP521) Invert() (Scalar, error) {
+	value, wasInverted := fq.P521FqNew().Invert(s.value)
+	if !wasInverted {
+		return nil, fmt.Errorf("inverse doesn't exist")
+	}
+	return &ScalarP521{
+		value,
+	}, nil
+}
+
+func (s *ScalarP521) Sqrt() (Scalar, error) {
+	value, wasSquare := fq.P521FqNew().Sqrt(s.value)
+	if !wasSquare {
+		return nil, fmt.Errorf("not a square")
+	}
+	return &ScalarP521{
+		value,
+	}, nil
+}
+
+func (s *ScalarP521) Cube() Scalar {
+	value := fq.P521FqNew().Square(s.value)
+	value.Mul(value, s.value)
+	return &ScalarP521{
+		value,
+	}
+}
+
+func (s *ScalarP521) Add(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarP521)
+	if ok {
+		return &ScalarP521{
+			value: fq.P521FqNew().Add(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarP521) Sub(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarP521)
+	if ok {
+		return &ScalarP521{
+			value: fq.P521FqNew().Sub(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarP521) Mul(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarP521)
+	if ok {
+		return &ScalarP521{
+			value: fq.P521FqNew().Mul(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarP521) MulAdd(y, z Scalar) Scalar {
+	return s.Mul(y).Add(z)
+}
+
+func (s *ScalarP521) Div(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarP521)
+	if ok {
+		v, wasInverted := fq.P521FqNew().Invert(r.value)
+		if !wasInverted {
+			return nil
+		}
+		v.Mul(v, s.value)
+		return &ScalarP521{value: v}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarP521) Neg() Scalar {
+	return &ScalarP521{
+		value: fq.P521FqNew().Neg(s.value),
+	}
+}
+
+func (*ScalarP521) SetBigInt(v *big.Int) (Scalar, error) {
+	if v == nil {
+		return nil, fmt.Errorf("'v' cannot be nil")
+	}
+	value := fq.P521FqNew().SetBigInt(v)
+	return &ScalarP521{
+		value,
+	}, nil
+}
+
+func (s *ScalarP521) BigInt() *big.Int {
+	return s.value.BigInt()
+}
+
+func (s *ScalarP521) Bytes() []byte {
+	t := s.value.Bytes()
+	return internal.ReverseBytes(t[:])
+}
+
+func (*ScalarP521) SetBytes(bytes []byte) (Scalar, error) {
+	if len(bytes) != 66 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [66]byte
+	copy(seq[:], internal.ReverseBytes(bytes))
+	value, err := fq.P521FqNew().SetBytes(&seq)
+	if err != nil {
+		return nil, err
+	}
+	return &ScalarP521{
+		value,
+	}, nil
+}
+
+func (*ScalarP521) SetBytesWide(bytes []byte) (Scalar, error) {
+	if len(bytes) != 144 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [144]byte
+	copy(seq[:], bytes)
+	return &ScalarP521{
+		value: fq.P521FqNew().SetBytesWide(&seq),
+	}, nil
+}
+
+func (*ScalarP521) Point() Point {
+	return new(PointP521).Identity()
+}
+
+func (s *ScalarP521) Clone() Scalar {
+	return &ScalarP521{
+		value: fq.P521FqNew().Set(s.value),
+	}
+}
+
+func (s *ScalarP521) MarshalBinary() ([]byte, error) {
+	return ScalarMarshalBinary(s)
+}
+
+func (s *ScalarP521) UnmarshalBinary(input []byte) error {
+	sc, err := ScalarUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarP521)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarP521) MarshalText() ([]byte, error) {
+	return ScalarMarshalText(s)
+}
+
+func (s *ScalarP521) UnmarshalText(input []byte) error {
+	sc, err := ScalarUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarP521)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarP521) MarshalJSON() ([]byte, error) {
+	return ScalarMarshalJSON(s)
+}
+
+func (s *ScalarP521) UnmarshalJSON(input []byte) error {
+	sc, err := ScalarUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	S, ok := sc.(*ScalarP521)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	s.value = S.value
+	return nil
+}
+
+func (p *PointP521) Random(reader io.Reader) Point {
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return p.Hash(seed[:])
+}
+
+func (*PointP521) Hash(bytes []byte) Point {
+	value, err := p521n.PointNew().Hash(bytes, native.EllipticPointHasherSha512())
+	// TODO: change hash to return an error also
+	if err != nil {
+		return nil
+	}
+
+	return &PointP521{value}
+}
+
+func (*PointP521) Identity() Point {
+	return &PointP521{
+		value: p521n.PointNew().Identity(),
+	}
+}
+
+func (*PointP521) Generator() Point {
+	return &PointP521{
+		value: p521n.PointNew().Generator(),
+	}
+}
+
+func (p *PointP521) IsIdentity() bool {
+	return p.value.IsIdentity()
+}
+
+func (p *PointP521) IsNegative() bool {
+	return p.value.GetY().Value[0]&1 == 1
+}
+
+func (p *PointP521) IsOnCurve() bool {
+	return p.value.IsOnCurve()
+}
+
+func (p *PointP521) Double() Point {
+	value := p521n.PointNew().Double(p.value)
+	return &PointP521{value}
+}
+
+func (*PointP521) Scalar() Scalar {
+	return new(ScalarP521).Zero()
+}
+
+func (p *PointP521) Neg() Point {
+	value := p521n.PointNew().Neg(p.value)
+	return &PointP521{value}
+}
+
+func (p *PointP521) Add(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointP521)
+	if ok {
+		value := p521n.PointNew().Add(p.value, r.value)
+		return &PointP521{value}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointP521) Sub(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointP521)
+	if ok {
+		value := p521n.PointNew().Sub(p.value, r.value)
+		return &PointP521{value}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointP521) Mul(rhs Scalar) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*ScalarP521)
+	if ok {
+		value := p521n.PointNew().Mul(p.value, r.value)
+		return &PointP521{value}
+	} else {
+		return nil
+	}
+}
+
+func (*PointP521) selectPoint(row []Point, index int) Point {
+	value := p521n.PointNew().Identity()
+	for i, pt := range row {
+		value.CMove(value, pt.(*PointP521).value, ctEqual(i+1, index))
+	}
+	return &PointP521{value}
+}
+
+func (p *PointP521) Equal(rhs Point) bool {
+	r, ok := rhs.(*PointP521)
+	if ok {
+		return p.value.Equal(r.value) == 1
+	} else {
+		return false
+	}
+}
+
+func (*PointP521) Set(x, y *big.Int) (Point, error) {
+	value, err := p521n.PointNew().SetBigInt(x, y)
+	if err != nil {
+		return nil, err
+	}
+	return &PointP521{value}, nil
+}
+
+func (p *PointP521) ToAffineCompressed() []byte {
+	var x [67]byte
+	x[0] = byte(2)
+
+	t := p521n.PointNew().ToAffine(p.value)
+
+	x[0] |= t.Y.Bytes()[0] & 1
+
+	xBytes := t.X.Bytes()
+	copy(x[1:], internal.ReverseBytes(xBytes[:]))
+	return x[:]
+}
+
+func (p *PointP521) ToAffineUncompressed() []byte {
+	var out [133]byte
+	out[0] = byte(4)
+	t := p521n.PointNew().ToAffine(p.value)
+	arr := t.X.Bytes()
+	copy(out[1:67], internal.ReverseBytes(arr[:]))
+	arr = t.Y.Bytes()
+	copy(out[67:], internal.ReverseBytes(arr[:]))
+	return out[:]
+}
+
+func (p *PointP521) FromAffineCompressed(bytes []byte) (Point, error) {
+	var raw [native.Field9Bytes]byte
+	if len(bytes) != 67 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	sign := int(bytes[0])
+	if sign != 2 && sign != 3 {
+		return nil, fmt.Errorf("invalid sign byte")
+	}
+	sign &= 0x1
+
+	copy(raw[:], internal.ReverseBytes(bytes[1:]))
+	x, err := fp.P521FpNew().SetBytes(&raw)
+	if err != nil {
+		return nil, err
+	}
+
+	value := p521n.PointNew().Identity()
+	rhs := fp.P521FpNew()
+	p.value.Arithmetic.RhsEquation(rhs, x)
+	// test that rhs is quadratic residue
+	// if not, then this Point is at infinity
+	y, wasQr := fp.P521FpNew().Sqrt(rhs)
+	if wasQr {
+		// fix the sign
+		sigY := int(y.Bytes()[0] & 1)
+		if sigY != sign {
+			y.Neg(y)
+		}
+		value.X = x
+		value.Y = y
+		value.Z.SetOne()
+	}
+	return &PointP521{value}, nil
+}
+
+func (*PointP521) FromAffineUncompressed(bytes []byte) (Point, error) {
+	var arr [native.Field9Bytes]byte
+	if len(bytes) != 133 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	if bytes[0] != 4 {
+		return nil, fmt.Errorf("invalid sign byte")
+	}
+
+	copy(arr[:], internal.ReverseBytes(bytes[1:67]))
+	x, err := fp.P521FpNew().SetBytes(&arr)
+	if err != nil {
+		return nil, err
+	}
+	copy(arr[:], internal.ReverseBytes(bytes[67:]))
+	y, err := fp.P521FpNew().SetBytes(&arr)
+	if err != nil {
+		return nil, err
+	}
+	value := p521n.PointNew()
+	value.X = x
+	value.Y = y
+	value.Z.SetOne()
+	return &PointP521{value}, nil
+}
+
+func (*PointP521) CurveName() string {
+	return elliptic.P521().Params().Name
+}
+
+func (*PointP521) SumOfProducts(points []Point, scalars []Scalar) Point {
+	nPoints := make([]*native.EllipticPoint9, len(points))
+	nScalars := make([]*native.Field9, len(scalars))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointP521)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarP521)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.value
+	}
+	value := p521n.PointNew()
+	_, err := value.SumOfProducts(nPoints, nScalars)
+	if err != nil {
+		return nil
+	}
+	return &PointP521{value}
+}
+
+func (p *PointP521) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point {
+	return p.VarTimeMultiScalarMult([]Scalar{a, b}, []Point{capA, p.Generator()})
+}
+
+func (p *PointP521) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	return varTimeMultiScalarMult(p, scalars, points)
+}
+
+func (*PointP521) batchNormalize(points []Point) []Point {
+	nPoints := make([]*native.EllipticPoint9, len(points))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointP521)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	out := make([]Point, len(points))
+	for i, value := range native.BatchToAffine9(nPoints) {
+		out[i] = &PointP521{value}
+	}
+	return out
+}
+
+func (p *PointP521) X() *native.Field9 {
+	return p.value.GetX()
+}
+
+func (p *PointP521) Y() *native.Field9 {
+	return p.value.GetY()
+}
+
+func (*PointP521) Params() *elliptic.CurveParams {
+	return elliptic.P521().Params()
+}
+
+func (p *PointP521) MarshalBinary() ([]byte, error) {
+	return PointMarshalBinary(p)
+}
+
+func (p *PointP521) UnmarshalBinary(input []byte) error {
+	pt, err := PointUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointP521)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointP521) MarshalText() ([]byte, error) {
+	return PointMarshalText(p)
+}
+
+func (p *PointP521) UnmarshalText(input []byte) error {
+	pt, err := PointUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointP521)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointP521) MarshalJSON() ([]byte, error) {
+	return PointMarshalJSON(p)
+}
+
+func (p *PointP521) UnmarshalJSON(input []byte) error {
+	pt, err := PointUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	P, ok := pt.(*PointP521)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	p.value = P.value
+	return nil
+}
@@ -0,0 +1,379 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"crypto/elliptic"
+	crand "crypto/rand"
+	"encoding/hex"
+	"github.com/mikelodder7/curvey/internal"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScalarP521Random(t *testing.T) {
+	curve := P521()
+	sc := curve.Scalar.Random(testRng())
+	s, ok := sc.(*ScalarP521)
+	require.True(t, ok)
+	expected := bhex("1ed0cb573724362f55afc88050e786409d504decc10da0ab4ea7784e6aa92d5ece8385b818c03e6d1e602f6866a88f8ca73eb0cf13cad4a52fc59a511ee577b086e")
+	require.Equal(t, s.value.BigInt(), expected)
+	// Try 10 random values
+	for i := 0; i < 10; i++ {
+		sc := p521.Scalar.Random(crand.Reader)
+		_, ok := sc.(*ScalarP521)
+		require.True(t, ok)
+		require.True(t, !sc.IsZero())
+	}
+}
+
+func TestScalarP521Hash(t *testing.T) {
+	var b [32]byte
+	p521 := P521()
+	sc := p521.Scalar.Hash(b[:])
+	s, ok := sc.(*ScalarP521)
+	require.True(t, ok)
+	expected := bhex("15b07fa07e37e48ef12cabc480ac4ffa362b99a35d3675f52922dc05db640ae7757f15d6cb2fbb6f086415db2ae82a3f106485f5634c58bea64ecda32f38c81445b")
+	require.Equal(t, s.value.BigInt(), expected)
+}
+
+func TestScalarP521Zero(t *testing.T) {
+	p521 := P521()
+	sc := p521.Scalar.Zero()
+	require.True(t, sc.IsZero())
+	require.True(t, sc.IsEven())
+}
+
+func TestScalarP521One(t *testing.T) {
+	p521 := P521()
+	sc := p521.Scalar.One()
+	require.True(t, sc.IsOne())
+	require.True(t, sc.IsOdd())
+}
+
+func TestScalarP521New(t *testing.T) {
+	p521 := P521()
+	three := p521.Scalar.New(3)
+	require.True(t, three.IsOdd())
+	four := p521.Scalar.New(4)
+	require.True(t, four.IsEven())
+	neg1 := p521.Scalar.New(-1)
+	require.True(t, neg1.IsEven())
+	neg2 := p521.Scalar.New(-2)
+	require.True(t, neg2.IsOdd())
+}
+
+func TestScalarP521Square(t *testing.T) {
+	p521 := P521()
+	three := p521.Scalar.New(3)
+	nine := p521.Scalar.New(9)
+	require.Equal(t, three.Square().Cmp(nine), 0)
+}
+
+func TestScalarP521Cube(t *testing.T) {
+	p521 := P521()
+	three := p521.Scalar.New(3)
+	twentySeven := p521.Scalar.New(27)
+	require.Equal(t, three.Cube().Cmp(twentySeven), 0)
+}
+
+func TestScalarP521Double(t *testing.T) {
+	p521 := P521()
+	three := p521.Scalar.New(3)
+	six := p521.Scalar.New(6)
+	require.Equal(t, three.Double().Cmp(six), 0)
+}
+
+func TestScalarP521Neg(t *testing.T) {
+	p521 := P521()
+	one := p521.Scalar.One()
+	neg1 := p521.Scalar.New(-1)
+	require.Equal(t, one.Neg().Cmp(neg1), 0)
+	lotsOfThrees := p521.Scalar.New(333333)
+	expected := p521.Scalar.New(-333333)
+	require.Equal(t, lotsOfThrees.Neg().Cmp(expected), 0)
+}
+
+func TestScalarP521Invert(t *testing.T) {
+	p521 := P521()
+	nine := p521.Scalar.New(9)
+	actual, _ := nine.Invert()
+	sa, _ := actual.(*ScalarP521)
+	curve := elliptic.P521()
+
+	bn := new(big.Int).SetInt64(9)
+	bn.ModInverse(bn, curve.Params().N)
+
+	expected, err := p521.Scalar.SetBigInt(bn)
+	require.NoError(t, err)
+	require.Equal(t, sa.Cmp(expected), 0)
+}
+
+func TestScalarP521Sqrt(t *testing.T) {
+	p521 := P521()
+	nine := p521.Scalar.New(9)
+	actual, err := nine.Sqrt()
+	require.NoError(t, err)
+	three := p521.Scalar.New(3)
+	require.True(t, actual.Cmp(three) == 0 || actual.Cmp(three.Neg()) == 0)
+	require.Equal(t, actual.Square().Cmp(nine), 0)
+}
+
+func TestScalarP521Add(t *testing.T) {
+	p521 := P521()
+	nine := p521.Scalar.New(9)
+	six := p521.Scalar.New(6)
+	fifteen := nine.Add(six)
+	require.NotNil(t, fifteen)
+	expected := p521.Scalar.New(15)
+	require.Equal(t, expected.Cmp(fifteen), 0)
+	n := new(big.Int).Set(elliptic.P521().Params().N)
+	n.Sub(n, big.NewInt(3))
+
+	upper, err := p521.Scalar.SetBigInt(n)
+	require.NoError(t, err)
+	actual := upper.Add(nine)
+	require.NotNil(t, actual)
+	require.Equal(t, actual.Cmp(six), 0)
+}
+
+func TestScalarP521Sub(t *testing.T) {
+	p521 := P521()
+	nine := p521.Scalar.New(9)
+	six := p521.Scalar.New(6)
+	n := new(big.Int).Set(elliptic.P521().Params().N)
+	n.Sub(n, big.NewInt(3))
+
+	expected, err := p521.Scalar.SetBigInt(n)
+	require.NoError(t, err)
+	actual := six.Sub(nine)
+	require.Equal(t, expected.Cmp(actual), 0)
+
+	actual = nine.Sub(six)
+	require.Equal(t, actual.Cmp(p521.Scalar.New(3)), 0)
+}
+
+func TestScalarP521Mul(t *testing.T) {
+	p521 := P521()
+	nine := p521.Scalar.New(9)
+	six := p521.Scalar.New(6)
+	actual := nine.Mul(six)
+	require.Equal(t, actual.Cmp(p521.Scalar.New(54)), 0)
+	n := new(big.Int).Set(elliptic.P521().Params().N)
+	n.Sub(n, big.NewInt(1))
+	upper, err := p521.Scalar.SetBigInt(n)
+	require.NoError(t, err)
+	require.Equal(t, upper.Mul(upper).Cmp(p521.Scalar.New(1)), 0)
+}
+
+func TestScalarP521Div(t *testing.T) {
+	p521 := P521()
+	nine := p521.Scalar.New(9)
+	actual := nine.Div(nine)
+	require.Equal(t, actual.Cmp(p521.Scalar.New(1)), 0)
+	require.Equal(t, p521.Scalar.New(54).Div(nine).Cmp(p521.Scalar.New(6)), 0)
+}
+
+func TestScalarP521Serialize(t *testing.T) {
+	p521 := P521()
+	sc := p521.Scalar.New(255)
+	sequence := sc.Bytes()
+	require.Equal(t, len(sequence), 66)
+	require.Equal(t, sequence, []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xff})
+	ret, err := p521.Scalar.SetBytes(sequence)
+	require.NoError(t, err)
+	require.Equal(t, ret.Cmp(sc), 0)
+
+	// Try 10 random values
+	for i := 0; i < 10; i++ {
+		sc = p521.Scalar.Random(crand.Reader)
+		sequence = sc.Bytes()
+		require.Equal(t, len(sequence), 66)
+		ret, err = p521.Scalar.SetBytes(sequence)
+		require.NoError(t, err)
+		require.Equal(t, ret.Cmp(sc), 0)
+	}
+}
+
+func TestScalarP521Nil(t *testing.T) {
+	p521 := P521()
+	one := p521.Scalar.New(1)
+	require.Nil(t, one.Add(nil))
+	require.Nil(t, one.Sub(nil))
+	require.Nil(t, one.Mul(nil))
+	require.Nil(t, one.Div(nil))
+	require.Nil(t, p521.Scalar.Random(nil))
+	require.Equal(t, one.Cmp(nil), -2)
+	_, err := p521.Scalar.SetBigInt(nil)
+	require.Error(t, err)
+}
+
+func TestPointP521Random(t *testing.T) {
+	p521 := P521()
+	sc := p521.Point.Random(testRng())
+	s, ok := sc.(*PointP521)
+	require.True(t, ok)
+	expectedX, _ := new(big.Int).SetString("c8f1e710265a7a67e8d4c4ac22f42826747bee028d157073f53448c90eca16f20f4274b3b2a7c904989190aed846142dfdec7843f66f0ea6ace7ce68f653a1eaf5", 16)
+	expectedY, _ := new(big.Int).SetString("f7fb16b3ed2c66b141a85430c97823c5a76fdc20bba9c8df56cdf8f2b787b504aa789b94c5f7823f965bcdd70a9c692c09e2608df3710e833586328cb2fe347f60", 16)
+	require.Equal(t, s.X().BigInt(), expectedX)
+	require.Equal(t, s.Y().BigInt(), expectedY)
+	// Try 10 random values
+	for i := 0; i < 10; i++ {
+		sc := p521.Point.Random(crand.Reader)
+		_, ok := sc.(*PointP521)
+		require.True(t, ok)
+		require.True(t, !sc.IsIdentity())
+	}
+}
+
+func TestPointP521Hash(t *testing.T) {
+	var b [32]byte
+	p521 := P521()
+	sc := p521.Point.Hash(b[:])
+	s, ok := sc.(*PointP521)
+	require.True(t, ok)
+
+	expectedX, _ := new(big.Int).SetString("babdaa886b9a9d5f8545f6c722e3a1df886a835d9c40d12d96f45fb2aefdaf40dcb153ce0eb8980e1848242a5972be4eaddd6175425b59fe521d1da0fa5f60eaa5", 16)
+	expectedY, _ := new(big.Int).SetString("1604d3e841239709e51dcf8670f9262dc0c46b99c7755125ef79715cd44dc6be54ff486e0e2f15de9d15c4bd542ae250bc4f76a5c477b4c5894f07eafe41312341d", 16)
+	require.Equal(t, s.X().BigInt(), expectedX)
+	require.Equal(t, s.Y().BigInt(), expectedY)
+}
+
+func TestPointP521Identity(t *testing.T) {
+	p521 := P521()
+	sc := p521.Point.Identity()
+	require.True(t, sc.IsIdentity())
+	require.Equal(t, sc.ToAffineCompressed(), []byte{0x2, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0})
+}
+
+func TestPointP521Generator(t *testing.T) {
+	p521 := P521()
+	sc := p521.Point.Generator()
+	s, ok := sc.(*PointP521)
+	require.True(t, ok)
+	require.Equal(t, s.X().BigInt(), elliptic.P521().Params().Gx)
+	require.Equal(t, s.Y().BigInt(), elliptic.P521().Params().Gy)
+}
+
+func TestPointP521Set(t *testing.T) {
+	p521 := P521()
+	iden, err := p521.Point.Set(big.NewInt(0), big.NewInt(0))
+	require.NoError(t, err)
+	require.True(t, iden.IsIdentity())
+	_, err = p521.Point.Set(elliptic.P521().Params().Gx, elliptic.P521().Params().Gy)
+	require.NoError(t, err)
+}
+
+func TestPointP521Double(t *testing.T) {
+	p521 := P521()
+	g := p521.Point.Generator()
+	g2 := g.Double()
+	require.True(t, g2.Equal(g.Mul(p521.Scalar.New(2))))
+	i := p521.Point.Identity()
+	require.True(t, i.Double().Equal(i))
+}
+
+func TestPointP521Neg(t *testing.T) {
+	p521 := P521()
+	g := p521.Point.Generator().Neg()
+	require.True(t, g.Neg().Equal(p521.Point.Generator()))
+	require.True(t, p521.Point.Identity().Neg().Equal(p521.Point.Identity()))
+}
+
+func TestPointP521Add(t *testing.T) {
+	p521 := P521()
+	pt := p521.Point.Generator()
+	require.True(t, pt.Add(pt).Equal(pt.Double()))
+	require.True(t, pt.Mul(p521.Scalar.New(3)).Equal(pt.Add(pt).Add(pt)))
+}
+
+func TestPointP521Sub(t *testing.T) {
+	p521 := P521()
+	g := p521.Point.Generator()
+	pt := p521.Point.Generator().Mul(p521.Scalar.New(4))
+	require.True(t, pt.Sub(g).Sub(g).Sub(g).Equal(g))
+	require.True(t, pt.Sub(g).Sub(g).Sub(g).Sub(g).IsIdentity())
+}
+
+func TestPointP521Mul(t *testing.T) {
+	p521 := P521()
+	g := p521.Point.Generator()
+	pt := p521.Point.Generator().Mul(p521.Scalar.New(4))
+	require.True(t, g.Double().Double().Equal(pt))
+
+	u1Bytes, _ := hex.DecodeString("01a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3")
+	u1, _ := p521.NewScalar().SetBytes(u1Bytes)
+	expectedX, _ := hex.DecodeString("00ab06ba98daf05c46366d8abe19a021c8667c3b4c90794bd6f32c63381e8c8245df015c73e138ceef96448ad7c0367700c5d4843d0a42596b9d3757856a6858296c")
+	lhs := p521.ScalarBaseMult(u1)
+	p := lhs.(*PointP521)
+	x := p.X().Bytes()
+	require.Equal(t, x[:], internal.ReverseBytes(expectedX))
+}
+
+func TestPointP521Serialize(t *testing.T) {
+	p521 := P521()
+	ss := p521.Scalar.Random(testRng())
+	g := p521.Point.Generator()
+
+	ppt := g.Mul(ss)
+
+	require.Equal(t, ppt.ToAffineCompressed(), []byte{0x2, 0x0, 0x4, 0xb1, 0x30, 0x6d, 0x5e, 0xea, 0x75, 0xd, 0x45, 0xa8, 0x6, 0xfe, 0x1c, 0x1b, 0x3d, 0x83, 0x30, 0xb8, 0xc0, 0x83, 0xe7, 0x70, 0xff, 0xa3, 0x9, 0x9b, 0xd6, 0xc5, 0x69, 0xba, 0x4c, 0xcd, 0xa5, 0xbb, 0x3, 0x88, 0x3b, 0x96, 0xc8, 0x9d, 0xbd, 0xbd, 0x53, 0x64, 0x12, 0x50, 0x82, 0x29, 0xd1, 0x58, 0x13, 0x5, 0x68, 0xea, 0xaa, 0x4c, 0x27, 0xb1, 0x49, 0x70, 0xb8, 0x92, 0xa9, 0x51, 0xa4})
+	require.Equal(t, ppt.ToAffineUncompressed(), []byte{0x4, 0x0, 0x4, 0xb1, 0x30, 0x6d, 0x5e, 0xea, 0x75, 0xd, 0x45, 0xa8, 0x6, 0xfe, 0x1c, 0x1b, 0x3d, 0x83, 0x30, 0xb8, 0xc0, 0x83, 0xe7, 0x70, 0xff, 0xa3, 0x9, 0x9b, 0xd6, 0xc5, 0x69, 0xba, 0x4c, 0xcd, 0xa5, 0xbb, 0x3, 0x88, 0x3b, 0x96, 0xc8, 0x9d, 0xbd, 0xbd, 0x53, 0x64, 0x12, 0x50, 0x82, 0x29, 0xd1, 0x58, 0x13, 0x5, 0x68, 0xea, 0xaa, 0x4c, 0x27, 0xb1, 0x49, 0x70, 0xb8, 0x92, 0xa9, 0x51, 0xa4, 0x1, 0xeb, 0xa6, 0x23, 0x94, 0xe2, 0x87, 0x4d, 0x50, 0x50, 0xc7, 0x5, 0x43, 0x13, 0x62, 0xed, 0xcb, 0xd, 0xcf, 0x33, 0xa6, 0xdf, 0x63, 0xea, 0x55, 0x6f, 0xa9, 0xa6, 0x26, 0x6b, 0x9e, 0x3e, 0x20, 0x4b, 0x17, 0x3, 0xee, 0x95, 0x50, 0x11, 0xde, 0x20, 0x7a, 0xd7, 0x56, 0x21, 0x73, 0x49, 0x51, 0x26, 0x8c, 0xeb, 0xbe, 0x37, 0xdd, 0x80, 0x66, 0xb5, 0xcd, 0x32, 0xa4, 0xfc, 0xd3, 0xb9, 0x1e, 0x30})
+	retP, err := ppt.FromAffineCompressed(ppt.ToAffineCompressed())
+	require.NoError(t, err)
+	require.True(t, ppt.Equal(retP))
+	retP, err = ppt.FromAffineUncompressed(ppt.ToAffineUncompressed())
+	require.NoError(t, err)
+	require.True(t, ppt.Equal(retP))
+
+	// smoke test
+	for i := 0; i < 25; i++ {
+		s := p521.Scalar.Random(crand.Reader)
+		pt := g.Mul(s)
+		cmprs := pt.ToAffineCompressed()
+		require.Equal(t, len(cmprs), 67)
+		retC, err := pt.FromAffineCompressed(cmprs)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(retC))
+
+		un := pt.ToAffineUncompressed()
+		require.Equal(t, len(un), 133)
+		retU, err := pt.FromAffineUncompressed(un)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(retU))
+	}
+}
+
+func TestPointP521Nil(t *testing.T) {
+	p521 := P521()
+	one := p521.Point.Generator()
+	require.Nil(t, one.Add(nil))
+	require.Nil(t, one.Sub(nil))
+	require.Nil(t, one.Mul(nil))
+	require.Nil(t, p521.Scalar.Random(nil))
+	require.False(t, one.Equal(nil))
+	_, err := p521.Scalar.SetBigInt(nil)
+	require.Error(t, err)
+}
+
+func TestPointP521SumOfProducts(t *testing.T) {
+	lhs := new(PointP521).Generator().Mul(new(ScalarP521).New(50))
+	points := make([]Point, 5)
+	for i := range points {
+		points[i] = new(PointP521).Generator()
+	}
+	scalars := []Scalar{
+		new(ScalarP521).New(8),
+		new(ScalarP521).New(9),
+		new(ScalarP521).New(10),
+		new(ScalarP521).New(11),
+		new(ScalarP521).New(12),
+	}
+	rhs := lhs.SumOfProducts(points, scalars)
+	require.NotNil(t, rhs)
+	require.True(t, lhs.Equal(rhs))
+}
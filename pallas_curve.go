@@ -521,6 +521,14 @@ func (p *PointPallas) Mul(rhs Scalar) Point {
 	return &PointPallas{pasta.PointNew().Mul(p.EllipticPoint4, s.Value)}
 }
 
+func (*PointPallas) selectPoint(row []Point, index int) Point {
+	value := pasta.PointNew().Identity()
+	for i, pt := range row {
+		value.CMove(value, pt.(*PointPallas).EllipticPoint4, ctEqual(i+1, index))
+	}
+	return &PointPallas{value}
+}
+
 func (p *PointPallas) Equal(rhs Point) bool {
 	r, ok := rhs.(*PointPallas)
 	if !ok {
@@ -664,6 +672,54 @@ func (p *PointPallas) SumOfProducts(points []Point, scalars []Scalar) Point {
 	return &PointPallas{value}
 }
 
+func (*PointPallas) sumOfProductsVarTime(points []Point, scalars []Scalar, opts *MsmOptions) Point {
+	eps := make([]*native.EllipticPoint4, len(points))
+	for i, pt := range points {
+		ps, ok := pt.(*PointPallas)
+		if !ok {
+			return nil
+		}
+		eps[i] = ps.EllipticPoint4
+	}
+	scs := make([]*native.Field4, len(scalars))
+	for i, sc := range scalars {
+		ss, ok := sc.(*ScalarPallas)
+		if !ok {
+			return nil
+		}
+		scs[i] = ss.Value
+	}
+	value, err := pasta.PointNew().MsmVarTime(eps, scs, opts)
+	if err != nil {
+		return nil
+	}
+	return &PointPallas{value}
+}
+
+func (p *PointPallas) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point {
+	return p.VarTimeMultiScalarMult([]Scalar{a, b}, []Point{capA, p.Generator()})
+}
+
+func (p *PointPallas) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	return p.sumOfProductsVarTime(points, scalars, nil)
+}
+
+func (*PointPallas) batchNormalize(points []Point) []Point {
+	nPoints := make([]*native.EllipticPoint4, len(points))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointPallas)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.EllipticPoint4
+	}
+	out := make([]Point, len(points))
+	for i, value := range native.BatchToAffine(nPoints) {
+		out[i] = &PointPallas{value}
+	}
+	return out
+}
+
 func (p *PointPallas) MarshalBinary() ([]byte, error) {
 	return PointMarshalBinary(p)
 }
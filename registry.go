@@ -0,0 +1,296 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	registryInitonce sync.Once
+	registryLock     sync.RWMutex
+	// curveRegistry maps names and aliases to curve constructors
+	curveRegistry map[string]func() *Curve
+	// curveNames holds only the canonical names
+	curveNames map[string]struct{}
+	// pairingCurveRegistry maps names and aliases to pairing curve constructors
+	pairingCurveRegistry map[string]func() *PairingCurve
+	// pairingCurveNames holds only the canonical names
+	pairingCurveNames map[string]struct{}
+	// curveIDs maps wire format identifiers to canonical names
+	curveIDs map[CurveID]string
+	// curveIDsByName maps canonical names to wire format identifiers
+	curveIDsByName map[string]CurveID
+)
+
+func registryInit() {
+	curveRegistry = make(map[string]func() *Curve)
+	curveNames = make(map[string]struct{})
+	pairingCurveRegistry = make(map[string]func() *PairingCurve)
+	pairingCurveNames = make(map[string]struct{})
+	curveIDs = make(map[CurveID]string)
+	curveIDsByName = make(map[string]CurveID)
+
+	builtins := []struct {
+		id      CurveID
+		name    string
+		curve   func() *Curve
+		aliases []string
+	}{
+		{K256ID, K256Name, K256, nil},
+		{BLS12381G1ID, BLS12381G1Name, BLS12381G1, []string{BLS12381Name, BLS12831Name}},
+		{BLS12381G2ID, BLS12381G2Name, BLS12381G2, nil},
+		{P256ID, P256Name, P256, nil},
+		{P384ID, P384Name, P384, nil},
+		{P521ID, P521Name, P521, nil},
+		{ED25519ID, ED25519Name, ED25519, nil},
+		{ED448ID, ED448Name, ED448, nil},
+		{PallasID, PallasName, PALLAS, nil},
+		{VestaID, VestaName, VESTA, nil},
+		{Ristretto25519ID, Ristretto25519Name, Ristretto25519, nil},
+		{Decaf448ID, Decaf448Name, Decaf448, nil},
+		{JubjubID, JubjubName, Jubjub, nil},
+		{BN254G1ID, BN254G1Name, BN254G1, []string{BN254Name}},
+		{BN254G2ID, BN254G2Name, BN254G2, nil},
+		{BLS12377G1ID, BLS12377G1Name, BLS12377G1, []string{BLS12377Name}},
+		{BLS12377G2ID, BLS12377G2Name, BLS12377G2, nil},
+		{BrainpoolP256r1ID, BrainpoolP256r1Name, BrainpoolP256r1, nil},
+		{BrainpoolP384r1ID, BrainpoolP384r1Name, BrainpoolP384r1, nil},
+		{BrainpoolP512r1ID, BrainpoolP512r1Name, BrainpoolP512r1, nil},
+		{Sm2ID, Sm2Name, SM2, nil},
+		{Secq256k1ID, Secq256k1Name, Secq256k1, nil},
+		{GrumpkinID, GrumpkinName, Grumpkin, nil},
+		{BandersnatchID, BandersnatchName, Bandersnatch, nil},
+		{BabyJubjubID, BabyJubjubName, BabyJubjub, nil},
+		{StarkID, StarkName, Stark, nil},
+	}
+	for _, b := range builtins {
+		if err := registerCurve(b.name, b.curve, b.aliases); err != nil {
+			panic(err)
+		}
+		if err := registerCurveID(b.id, b.name); err != nil {
+			panic(err)
+		}
+	}
+
+	pairingBuiltins := []struct {
+		name    string
+		curve   func() *PairingCurve
+		aliases []string
+	}{
+		{BLS12381G1Name, func() *PairingCurve {
+			return BLS12381(BLS12381G1().NewIdentityPoint())
+		}, []string{BLS12381Name, BLS12831Name}},
+		{BLS12381G2Name, func() *PairingCurve {
+			return BLS12381(BLS12381G2().NewIdentityPoint())
+		}, nil},
+		{BN254G1Name, func() *PairingCurve {
+			return BN254(BN254G1().NewIdentityPoint())
+		}, []string{BN254Name}},
+		{BN254G2Name, func() *PairingCurve {
+			return BN254(BN254G2().NewIdentityPoint())
+		}, nil},
+		{BLS12377G1Name, func() *PairingCurve {
+			return BLS12377(BLS12377G1().NewIdentityPoint())
+		}, []string{BLS12377Name}},
+		{BLS12377G2Name, func() *PairingCurve {
+			return BLS12377(BLS12377G2().NewIdentityPoint())
+		}, nil},
+	}
+	for _, b := range pairingBuiltins {
+		if err := registerPairingCurve(b.name, b.curve, b.aliases); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterCurve makes a curve available to GetCurveByName and the generic
+// marshal and unmarshal helpers under name and any aliases.
+// name must be the value returned by CurveName for the curve's points
+// so they can be decoded again. The constructor is called on each lookup
+// so it should return a shared instance, typically created with sync.Once.
+// An error is returned if any of the names is already registered.
+func RegisterCurve(name string, curve func() *Curve, aliases ...string) error {
+	registryInitonce.Do(registryInit)
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	return registerCurve(name, curve, aliases)
+}
+
+// RegisterCurveID assigns the wire format identifier id to the curve
+// registered as name so its values use the compact binary encoding.
+// Curves without an identifier are encoded with their name instead.
+// An error is returned if either id or name already has an assignment.
+func RegisterCurveID(id CurveID, name string) error {
+	registryInitonce.Do(registryInit)
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	return registerCurveID(id, name)
+}
+
+// RegisterPairingCurve makes a pairing curve available to GetPairingCurveByName
+// under name and any aliases.
+// An error is returned if any of the names is already registered.
+func RegisterPairingCurve(name string, curve func() *PairingCurve, aliases ...string) error {
+	registryInitonce.Do(registryInit)
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	return registerPairingCurve(name, curve, aliases)
+}
+
+// RegisteredCurves returns the sorted canonical names of all registered curves.
+// Aliases are not included.
+func RegisteredCurves() []string {
+	registryInitonce.Do(registryInit)
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	return sortedNames(curveNames)
+}
+
+// RegisteredPairingCurves returns the sorted canonical names of all
+// registered pairing curves. Aliases are not included.
+func RegisteredPairingCurves() []string {
+	registryInitonce.Do(registryInit)
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	return sortedNames(pairingCurveNames)
+}
+
+func lookupCurve(name string) *Curve {
+	registryInitonce.Do(registryInit)
+	registryLock.RLock()
+	curve, ok := curveRegistry[name]
+	registryLock.RUnlock()
+	if !ok {
+		return nil
+	}
+	return curve()
+}
+
+// GetCurveByID returns the registered `Curve` with the given
+// wire format identifier or nil if no such curve is registered.
+func GetCurveByID(id CurveID) *Curve {
+	registryInitonce.Do(registryInit)
+	registryLock.RLock()
+	name, ok := curveIDs[id]
+	registryLock.RUnlock()
+	if !ok {
+		return nil
+	}
+	return lookupCurve(name)
+}
+
+// GetCurveID returns the wire format identifier assigned to the
+// curve with the canonical name and whether one exists.
+func GetCurveID(name string) (CurveID, bool) {
+	registryInitonce.Do(registryInit)
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	id, ok := curveIDsByName[name]
+	return id, ok
+}
+
+func lookupPairingCurve(name string) *PairingCurve {
+	registryInitonce.Do(registryInit)
+	registryLock.RLock()
+	curve, ok := pairingCurveRegistry[name]
+	registryLock.RUnlock()
+	if !ok {
+		return nil
+	}
+	return curve()
+}
+
+func registerCurve(name string, curve func() *Curve, aliases []string) error {
+	if curve == nil {
+		return fmt.Errorf("curve cannot be nil")
+	}
+	names, err := checkNames(name, aliases, func(n string) bool {
+		_, ok := curveRegistry[n]
+		return ok
+	})
+	if err != nil {
+		return err
+	}
+	for _, n := range names {
+		curveRegistry[n] = curve
+	}
+	curveNames[name] = struct{}{}
+	return nil
+}
+
+func registerCurveID(id CurveID, name string) error {
+	if id == 0 || id > MaxCurveID {
+		return fmt.Errorf("invalid curve id %d", id)
+	}
+	if _, ok := curveNames[name]; !ok {
+		return fmt.Errorf("curve '%s' is not registered", name)
+	}
+	if other, ok := curveIDs[id]; ok {
+		return fmt.Errorf("curve id %d is already assigned to '%s'", id, other)
+	}
+	if other, ok := curveIDsByName[name]; ok {
+		return fmt.Errorf("curve '%s' already has id %d", name, other)
+	}
+	curveIDs[id] = name
+	curveIDsByName[name] = id
+	return nil
+}
+
+func registerPairingCurve(name string, curve func() *PairingCurve, aliases []string) error {
+	if curve == nil {
+		return fmt.Errorf("curve cannot be nil")
+	}
+	names, err := checkNames(name, aliases, func(n string) bool {
+		_, ok := pairingCurveRegistry[n]
+		return ok
+	})
+	if err != nil {
+		return err
+	}
+	for _, n := range names {
+		pairingCurveRegistry[n] = curve
+	}
+	pairingCurveNames[name] = struct{}{}
+	return nil
+}
+
+// checkNames validates that name and aliases are non-empty, printable,
+// do not contain the ':' separator used by the binary and text encodings
+// and are not already registered, including duplicates within the list itself.
+func checkNames(name string, aliases []string, exists func(string) bool) ([]string, error) {
+	names := append([]string{name}, aliases...)
+	seen := make(map[string]struct{}, len(names))
+	for _, n := range names {
+		if n == "" {
+			return nil, fmt.Errorf("curve name cannot be empty")
+		}
+		for i := 0; i < len(n); i++ {
+			if n[i] == ':' {
+				return nil, fmt.Errorf("curve name '%s' cannot contain ':'", n)
+			}
+			// The binary envelope relies on names never starting with a control byte
+			if n[i] < 0x20 || n[i] == 0x7f {
+				return nil, fmt.Errorf("curve name '%s' must be printable", n)
+			}
+		}
+		if _, ok := seen[n]; ok || exists(n) {
+			return nil, fmt.Errorf("curve '%s' is already registered", n)
+		}
+		seen[n] = struct{}{}
+	}
+	return names, nil
+}
+
+func sortedNames(set map[string]struct{}) []string {
+	out := make([]string, 0, len(set))
+	for n := range set {
+		out = append(out, n)
+	}
+	sort.Strings(out)
+	return out
+}
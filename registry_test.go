@@ -0,0 +1,151 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testRegistryName = "test-k256"
+
+var testRegistryInitonce sync.Once
+
+// testRegistryPoint stands in for a third-party curve by
+// wrapping secp256k1 under a different name.
+type testRegistryPoint struct {
+	*PointK256
+}
+
+type testRegistryScalar struct {
+	*ScalarK256
+}
+
+func (testRegistryPoint) CurveName() string {
+	return testRegistryName
+}
+
+func (p testRegistryPoint) Equal(rhs Point) bool {
+	r, ok := rhs.(testRegistryPoint)
+	return ok && bytes.Equal(p.ToAffineCompressed(), r.ToAffineCompressed())
+}
+
+func (p testRegistryPoint) FromAffineCompressed(input []byte) (Point, error) {
+	pt, err := p.PointK256.FromAffineCompressed(input)
+	if err != nil {
+		return nil, err
+	}
+	return testRegistryPoint{pt.(*PointK256)}, nil
+}
+
+func (testRegistryScalar) Point() Point {
+	return testRegistryPoint{new(PointK256).Identity().(*PointK256)}
+}
+
+func (s testRegistryScalar) SetBytes(input []byte) (Scalar, error) {
+	sc, err := s.ScalarK256.SetBytes(input)
+	if err != nil {
+		return nil, err
+	}
+	return testRegistryScalar{sc.(*ScalarK256)}, nil
+}
+
+func testRegistryCurve() *Curve {
+	return &Curve{
+		Scalar: testRegistryScalar{new(ScalarK256).Zero().(*ScalarK256)},
+		Point:  testRegistryPoint{new(PointK256).Identity().(*PointK256)},
+		Name:   testRegistryName,
+	}
+}
+
+func registerTestRegistryCurve(t *testing.T) {
+	t.Helper()
+	testRegistryInitonce.Do(func() {
+		require.NoError(t, RegisterCurve(testRegistryName, testRegistryCurve, "test-k256-alias"))
+	})
+}
+
+func TestRegistryBuiltins(t *testing.T) {
+	names := RegisteredCurves()
+	for _, name := range []string{K256Name, BLS12381G1Name, Sm2Name, StarkName} {
+		require.Contains(t, names, name)
+		require.Equal(t, name, GetCurveByName(name).Name)
+	}
+	// Aliases resolve but are not enumerated
+	require.NotContains(t, names, BLS12831Name)
+	require.Equal(t, BLS12381G1Name, GetCurveByName(BLS12831Name).Name)
+	require.Equal(t, BLS12381G1Name, GetCurveByName(BLS12381Name).Name)
+	require.Equal(t, BN254G1Name, GetCurveByName(BN254Name).Name)
+	require.Nil(t, GetCurveByName("unknown"))
+
+	pairingNames := RegisteredPairingCurves()
+	require.Contains(t, pairingNames, BLS12381G2Name)
+	require.NotContains(t, pairingNames, BLS12381Name)
+	require.NotNil(t, GetPairingCurveByName(BLS12381Name))
+	require.NotNil(t, GetPairingCurveByName(BLS12377G2Name))
+	require.Nil(t, GetPairingCurveByName(K256Name))
+}
+
+func TestRegistryDuplicates(t *testing.T) {
+	require.Error(t, RegisterCurve(K256Name, K256))
+	require.Error(t, RegisterCurve("test-duplicate", K256, BLS12831Name))
+	require.Error(t, RegisterCurve("test-duplicate", K256, "test-duplicate"))
+	require.Error(t, RegisterCurve("", K256))
+	require.Error(t, RegisterCurve("test:colon", K256))
+	require.Error(t, RegisterCurve("test-nil", nil))
+	require.Error(t, RegisterPairingCurve(BLS12381G1Name, func() *PairingCurve {
+		return GetPairingCurveByName(BLS12381G1Name)
+	}))
+	// A failed registration must not leave any of its names behind
+	require.Nil(t, GetCurveByName("test-duplicate"))
+}
+
+func TestRegistryThirdPartyCurve(t *testing.T) {
+	registerTestRegistryCurve(t)
+	require.Error(t, RegisterCurve(testRegistryName, testRegistryCurve))
+	require.Contains(t, RegisteredCurves(), testRegistryName)
+	require.NotContains(t, RegisteredCurves(), "test-k256-alias")
+	require.Equal(t, testRegistryName, GetCurveByName("test-k256-alias").Name)
+
+	pt := testRegistryPoint{new(PointK256).Generator().Mul(new(ScalarK256).New(7)).(*PointK256)}
+	for _, codec := range []struct {
+		marshal   func(Point) ([]byte, error)
+		unmarshal func([]byte) (Point, error)
+	}{
+		{PointMarshalBinary, PointUnmarshalBinary},
+		{PointMarshalText, PointUnmarshalText},
+		{PointMarshalJSON, PointUnmarshalJSON},
+	} {
+		b, err := codec.marshal(pt)
+		require.NoError(t, err)
+		pt2, err := codec.unmarshal(b)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(pt2))
+	}
+
+	sc := testRegistryScalar{new(ScalarK256).New(42).(*ScalarK256)}
+	for _, codec := range []struct {
+		marshal   func(Scalar) ([]byte, error)
+		unmarshal func([]byte) (Scalar, error)
+	}{
+		{ScalarMarshalBinary, ScalarUnmarshalBinary},
+		{ScalarMarshalText, ScalarUnmarshalText},
+		{ScalarMarshalJSON, ScalarUnmarshalJSON},
+	} {
+		b, err := codec.marshal(sc)
+		require.NoError(t, err)
+		sc2, err := codec.unmarshal(b)
+		require.NoError(t, err)
+		_, ok := sc2.(testRegistryScalar)
+		require.True(t, ok)
+		require.Equal(t, 0, sc.ScalarK256.Cmp(sc2.(testRegistryScalar).ScalarK256))
+	}
+
+	_, err := PointUnmarshalBinary([]byte("no separator"))
+	require.Error(t, err)
+}
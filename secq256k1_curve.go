@@ -0,0 +1,650 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+	"github.com/mikelodder7/curvey/native/k256/fp"
+	"github.com/mikelodder7/curvey/native/k256/fq"
+	secq256k1n "github.com/mikelodder7/curvey/native/secq256k1"
+)
+
+type ScalarSecq256k1 struct {
+	value *native.Field4
+}
+
+type PointSecq256k1 struct {
+	value *native.EllipticPoint4
+}
+
+func (s *ScalarSecq256k1) Random(reader io.Reader) Scalar {
+	if reader == nil {
+		return nil
+	}
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return s.Hash(seed[:])
+}
+
+func (*ScalarSecq256k1) Hash(bytes []byte) Scalar {
+	dst := []byte("secq256k1_XMD:SHA-256_SVDW_RO_")
+	xmd := native.ExpandMsgXmd(native.EllipticPointHasherSha256(), bytes, dst, 48)
+	var t [64]byte
+	copy(t[:48], internal.ReverseBytes(xmd))
+
+	return &ScalarSecq256k1{
+		value: fp.K256FpNew().SetBytesWide(&t),
+	}
+}
+
+func (*ScalarSecq256k1) Zero() Scalar {
+	return &ScalarSecq256k1{
+		value: fp.K256FpNew().SetZero(),
+	}
+}
+
+func (*ScalarSecq256k1) One() Scalar {
+	return &ScalarSecq256k1{
+		value: fp.K256FpNew().SetOne(),
+	}
+}
+
+func (s *ScalarSecq256k1) IsZero() bool {
+	return s.value.IsZero() == 1
+}
+
+func (s *ScalarSecq256k1) IsOne() bool {
+	return s.value.IsOne() == 1
+}
+
+func (s *ScalarSecq256k1) IsOdd() bool {
+	return s.value.Bytes()[0]&1 == 1
+}
+
+func (s *ScalarSecq256k1) IsEven() bool {
+	return s.value.Bytes()[0]&1 == 0
+}
+
+func (*ScalarSecq256k1) New(value int) Scalar {
+	t := fp.K256FpNew()
+	v := big.NewInt(int64(value))
+	if value < 0 {
+		v.Mod(v, t.Params.BiModulus)
+	}
+	return &ScalarSecq256k1{
+		value: t.SetBigInt(v),
+	}
+}
+
+func (s *ScalarSecq256k1) Cmp(rhs Scalar) int {
+	r, ok := rhs.(*ScalarSecq256k1)
+	if ok {
+		return s.value.Cmp(r.value)
+	} else {
+		return -2
+	}
+}
+
+func (s *ScalarSecq256k1) Square() Scalar {
+	return &ScalarSecq256k1{
+		value: fp.K256FpNew().Square(s.value),
+	}
+}
+
+func (s *ScalarSecq256k1) Pow(exp uint64) Scalar {
+	expFieldLimb := [native.Field4Limbs]uint64{exp, 0, 0, 0}
+	out := ScalarSecq256k1{value: fp.K256FpNew()}
+	native.Pow(&out.value.Value, &s.value.Value, &expFieldLimb, s.value.Params, s.value.Arithmetic)
+	return &ScalarSecq256k1{
+		value: out.value,
+	}
+}
+
+func (s *ScalarSecq256k1) Double() Scalar {
+	return &ScalarSecq256k1{
+		value: fp.K256FpNew().Double(s.value),
+	}
+}
+
+func (s *ScalarSecq256k1) Invert() (Scalar, error) {
+	value, wasInverted := fp.K256FpNew().Invert(s.value)
+	if !wasInverted {
+		return nil, fmt.Errorf("inverse doesn't exist")
+	}
+	return &ScalarSecq256k1{
+		value,
+	}, nil
+}
+
+func (s *ScalarSecq256k1) Sqrt() (Scalar, error) {
+	value, wasSquare := fp.K256FpNew().Sqrt(s.value)
+	if !wasSquare {
+		return nil, fmt.Errorf("not a square")
+	}
+	return &ScalarSecq256k1{
+		value,
+	}, nil
+}
+
+func (s *ScalarSecq256k1) Cube() Scalar {
+	value := fp.K256FpNew().Square(s.value)
+	value.Mul(value, s.value)
+	return &ScalarSecq256k1{
+		value,
+	}
+}
+
+func (s *ScalarSecq256k1) Add(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarSecq256k1)
+	if ok {
+		return &ScalarSecq256k1{
+			value: fp.K256FpNew().Add(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarSecq256k1) Sub(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarSecq256k1)
+	if ok {
+		return &ScalarSecq256k1{
+			value: fp.K256FpNew().Sub(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarSecq256k1) Mul(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarSecq256k1)
+	if ok {
+		return &ScalarSecq256k1{
+			value: fp.K256FpNew().Mul(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarSecq256k1) MulAdd(y, z Scalar) Scalar {
+	return s.Mul(y).Add(z)
+}
+
+func (s *ScalarSecq256k1) Div(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarSecq256k1)
+	if ok {
+		v, wasInverted := fp.K256FpNew().Invert(r.value)
+		if !wasInverted {
+			return nil
+		}
+		v.Mul(v, s.value)
+		return &ScalarSecq256k1{value: v}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarSecq256k1) Neg() Scalar {
+	return &ScalarSecq256k1{
+		value: fp.K256FpNew().Neg(s.value),
+	}
+}
+
+func (*ScalarSecq256k1) SetBigInt(v *big.Int) (Scalar, error) {
+	if v == nil {
+		return nil, fmt.Errorf("'v' cannot be nil")
+	}
+	value := fp.K256FpNew().SetBigInt(v)
+	return &ScalarSecq256k1{
+		value,
+	}, nil
+}
+
+func (s *ScalarSecq256k1) BigInt() *big.Int {
+	return s.value.BigInt()
+}
+
+func (s *ScalarSecq256k1) Bytes() []byte {
+	t := s.value.Bytes()
+	return internal.ReverseBytes(t[:])
+}
+
+func (*ScalarSecq256k1) SetBytes(bytes []byte) (Scalar, error) {
+	if len(bytes) != 32 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [32]byte
+	copy(seq[:], internal.ReverseBytes(bytes))
+	value, err := fp.K256FpNew().SetBytes(&seq)
+	if err != nil {
+		return nil, err
+	}
+	return &ScalarSecq256k1{
+		value,
+	}, nil
+}
+
+func (*ScalarSecq256k1) SetBytesWide(bytes []byte) (Scalar, error) {
+	if len(bytes) != 64 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [64]byte
+	copy(seq[:], bytes)
+	return &ScalarSecq256k1{
+		value: fp.K256FpNew().SetBytesWide(&seq),
+	}, nil
+}
+
+func (*ScalarSecq256k1) Point() Point {
+	return new(PointSecq256k1).Identity()
+}
+
+func (s *ScalarSecq256k1) Clone() Scalar {
+	return &ScalarSecq256k1{
+		value: fp.K256FpNew().Set(s.value),
+	}
+}
+
+func (s *ScalarSecq256k1) MarshalBinary() ([]byte, error) {
+	return ScalarMarshalBinary(s)
+}
+
+func (s *ScalarSecq256k1) UnmarshalBinary(input []byte) error {
+	sc, err := ScalarUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarSecq256k1)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarSecq256k1) MarshalText() ([]byte, error) {
+	return ScalarMarshalText(s)
+}
+
+func (s *ScalarSecq256k1) UnmarshalText(input []byte) error {
+	sc, err := ScalarUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarSecq256k1)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarSecq256k1) MarshalJSON() ([]byte, error) {
+	return ScalarMarshalJSON(s)
+}
+
+func (s *ScalarSecq256k1) UnmarshalJSON(input []byte) error {
+	sc, err := ScalarUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	S, ok := sc.(*ScalarSecq256k1)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	s.value = S.value
+	return nil
+}
+
+func (p *PointSecq256k1) Random(reader io.Reader) Point {
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return p.Hash(seed[:])
+}
+
+func (*PointSecq256k1) Hash(bytes []byte) Point {
+	// secq256k1 has no SSWU suite so the dst names the
+	// Shallue-van de Woestijne map used by the native point
+	dst := []byte("secq256k1_XMD:SHA-256_SVDW_RO_")
+	value := secq256k1n.PointNew()
+	err := value.Arithmetic.Hash(value, native.EllipticPointHasherSha256(), bytes, dst)
+	// TODO: change hash to return an error also
+	if err != nil {
+		return nil
+	}
+
+	return &PointSecq256k1{value}
+}
+
+func (*PointSecq256k1) Identity() Point {
+	return &PointSecq256k1{
+		value: secq256k1n.PointNew().Identity(),
+	}
+}
+
+func (*PointSecq256k1) Generator() Point {
+	return &PointSecq256k1{
+		value: secq256k1n.PointNew().Generator(),
+	}
+}
+
+func (p *PointSecq256k1) IsIdentity() bool {
+	return p.value.IsIdentity()
+}
+
+func (p *PointSecq256k1) IsNegative() bool {
+	return p.value.GetY().Value[0]&1 == 1
+}
+
+func (p *PointSecq256k1) IsOnCurve() bool {
+	return p.value.IsOnCurve()
+}
+
+func (p *PointSecq256k1) Double() Point {
+	value := secq256k1n.PointNew().Double(p.value)
+	return &PointSecq256k1{value}
+}
+
+func (*PointSecq256k1) Scalar() Scalar {
+	return new(ScalarSecq256k1).Zero()
+}
+
+func (p *PointSecq256k1) Neg() Point {
+	value := secq256k1n.PointNew().Neg(p.value)
+	return &PointSecq256k1{value}
+}
+
+func (p *PointSecq256k1) Add(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointSecq256k1)
+	if ok {
+		value := secq256k1n.PointNew().Add(p.value, r.value)
+		return &PointSecq256k1{value}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointSecq256k1) Sub(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointSecq256k1)
+	if ok {
+		value := secq256k1n.PointNew().Sub(p.value, r.value)
+		return &PointSecq256k1{value}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointSecq256k1) Mul(rhs Scalar) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*ScalarSecq256k1)
+	if ok {
+		value := secq256k1n.PointNew().Mul(p.value, r.value)
+		return &PointSecq256k1{value}
+	} else {
+		return nil
+	}
+}
+
+func (*PointSecq256k1) selectPoint(row []Point, index int) Point {
+	value := secq256k1n.PointNew().Identity()
+	for i, pt := range row {
+		value.CMove(value, pt.(*PointSecq256k1).value, ctEqual(i+1, index))
+	}
+	return &PointSecq256k1{value}
+}
+
+func (p *PointSecq256k1) Equal(rhs Point) bool {
+	r, ok := rhs.(*PointSecq256k1)
+	if ok {
+		return p.value.Equal(r.value) == 1
+	} else {
+		return false
+	}
+}
+
+func (*PointSecq256k1) Set(x, y *big.Int) (Point, error) {
+	value, err := secq256k1n.PointNew().SetBigInt(x, y)
+	if err != nil {
+		return nil, err
+	}
+	return &PointSecq256k1{value}, nil
+}
+
+func (p *PointSecq256k1) ToAffineCompressed() []byte {
+	var x [33]byte
+	x[0] = byte(2)
+
+	t := secq256k1n.PointNew().ToAffine(p.value)
+
+	x[0] |= t.Y.Bytes()[0] & 1
+
+	xBytes := t.X.Bytes()
+	copy(x[1:], internal.ReverseBytes(xBytes[:]))
+	return x[:]
+}
+
+func (p *PointSecq256k1) ToAffineUncompressed() []byte {
+	var out [65]byte
+	out[0] = byte(4)
+	t := secq256k1n.PointNew().ToAffine(p.value)
+	arr := t.X.Bytes()
+	copy(out[1:33], internal.ReverseBytes(arr[:]))
+	arr = t.Y.Bytes()
+	copy(out[33:], internal.ReverseBytes(arr[:]))
+	return out[:]
+}
+
+func (p *PointSecq256k1) FromAffineCompressed(bytes []byte) (Point, error) {
+	var raw [native.Field4Bytes]byte
+	if len(bytes) != 33 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	sign := int(bytes[0])
+	if sign != 2 && sign != 3 {
+		return nil, fmt.Errorf("invalid sign byte")
+	}
+	sign &= 0x1
+
+	copy(raw[:], internal.ReverseBytes(bytes[1:]))
+	x, err := fq.K256FqNew().SetBytes(&raw)
+	if err != nil {
+		return nil, err
+	}
+
+	value := secq256k1n.PointNew().Identity()
+	rhs := fq.K256FqNew()
+	p.value.Arithmetic.RhsEquation(rhs, x)
+	// test that rhs is quadratic residue
+	// if not, then this Point is at infinity
+	y, wasQr := fq.K256FqNew().Sqrt(rhs)
+	if wasQr {
+		// fix the sign
+		sigY := int(y.Bytes()[0] & 1)
+		if sigY != sign {
+			y.Neg(y)
+		}
+		value.X = x
+		value.Y = y
+		value.Z.SetOne()
+	}
+	return &PointSecq256k1{value}, nil
+}
+
+func (*PointSecq256k1) FromAffineUncompressed(bytes []byte) (Point, error) {
+	var arr [native.Field4Bytes]byte
+	if len(bytes) != 65 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	if bytes[0] != 4 {
+		return nil, fmt.Errorf("invalid sign byte")
+	}
+
+	copy(arr[:], internal.ReverseBytes(bytes[1:33]))
+	x, err := fq.K256FqNew().SetBytes(&arr)
+	if err != nil {
+		return nil, err
+	}
+	copy(arr[:], internal.ReverseBytes(bytes[33:]))
+	y, err := fq.K256FqNew().SetBytes(&arr)
+	if err != nil {
+		return nil, err
+	}
+	value := secq256k1n.PointNew()
+	value.X = x
+	value.Y = y
+	value.Z.SetOne()
+	return &PointSecq256k1{value}, nil
+}
+
+func (*PointSecq256k1) CurveName() string {
+	return Secq256k1Name
+}
+
+func (*PointSecq256k1) SumOfProducts(points []Point, scalars []Scalar) Point {
+	nPoints := make([]*native.EllipticPoint4, len(points))
+	nScalars := make([]*native.Field4, len(scalars))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointSecq256k1)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarSecq256k1)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.value
+	}
+	value := secq256k1n.PointNew()
+	_, err := value.SumOfProducts(nPoints, nScalars)
+	if err != nil {
+		return nil
+	}
+	return &PointSecq256k1{value}
+}
+
+func (*PointSecq256k1) sumOfProductsVarTime(points []Point, scalars []Scalar, opts *MsmOptions) Point {
+	nPoints := make([]*native.EllipticPoint4, len(points))
+	nScalars := make([]*native.Field4, len(scalars))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointSecq256k1)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarSecq256k1)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.value
+	}
+	value := secq256k1n.PointNew()
+	_, err := value.MsmVarTime(nPoints, nScalars, opts)
+	if err != nil {
+		return nil
+	}
+	return &PointSecq256k1{value}
+}
+
+func (p *PointSecq256k1) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point {
+	return p.VarTimeMultiScalarMult([]Scalar{a, b}, []Point{capA, p.Generator()})
+}
+
+func (p *PointSecq256k1) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	return p.sumOfProductsVarTime(points, scalars, nil)
+}
+
+func (*PointSecq256k1) batchNormalize(points []Point) []Point {
+	nPoints := make([]*native.EllipticPoint4, len(points))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointSecq256k1)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	out := make([]Point, len(points))
+	for i, value := range native.BatchToAffine(nPoints) {
+		out[i] = &PointSecq256k1{value}
+	}
+	return out
+}
+
+func (p *PointSecq256k1) X() *native.Field4 {
+	return p.value.GetX()
+}
+
+func (p *PointSecq256k1) Y() *native.Field4 {
+	return p.value.GetY()
+}
+
+func (p *PointSecq256k1) MarshalBinary() ([]byte, error) {
+	return PointMarshalBinary(p)
+}
+
+func (p *PointSecq256k1) UnmarshalBinary(input []byte) error {
+	pt, err := PointUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointSecq256k1)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointSecq256k1) MarshalText() ([]byte, error) {
+	return PointMarshalText(p)
+}
+
+func (p *PointSecq256k1) UnmarshalText(input []byte) error {
+	pt, err := PointUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointSecq256k1)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointSecq256k1) MarshalJSON() ([]byte, error) {
+	return PointMarshalJSON(p)
+}
+
+func (p *PointSecq256k1) UnmarshalJSON(input []byte) error {
+	pt, err := PointUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	P, ok := pt.(*PointSecq256k1)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	p.value = P.value
+	return nil
+}
@@ -0,0 +1,207 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	crand "crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// There is no widely used secq256k1 library to take vectors from, so the
+// known answers in this file come from a textbook affine double-and-add and
+// a direct transcription of the RFC 9380 Shallue-van de Woestijne map
+// written with Python integers, independently of this package.
+
+// TestSecq256k1K256Cycle checks that secq256k1 and secp256k1 form a cycle:
+// the secq256k1 base field is the secp256k1 scalar field and the secq256k1
+// scalar field is the secp256k1 base field.
+func TestSecq256k1K256Cycle(t *testing.T) {
+	secq256k1 := Secq256k1()
+	k256 := K256()
+
+	// The secq256k1 group order is the secp256k1 field prime
+	p := bhex("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2e")
+	pm1, err := secq256k1.Scalar.SetBigInt(p)
+	require.NoError(t, err)
+	require.Equal(t, 0, pm1.Add(secq256k1.Scalar.One()).Cmp(secq256k1.Scalar.Zero()))
+	g := secq256k1.Point.Generator()
+	require.True(t, g.Mul(pm1).Equal(g.Neg()))
+
+	// And the secp256k1 group order is the secq256k1 field prime
+	n := bhex("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364140")
+	nm1, err := k256.Scalar.SetBigInt(n)
+	require.NoError(t, err)
+	require.Equal(t, 0, nm1.Add(k256.Scalar.One()).Cmp(k256.Scalar.Zero()))
+
+	// secq256k1 coordinates are secp256k1 scalars: y^2 = x^3 + 7
+	for i := 0; i < 10; i++ {
+		pt := secq256k1.Point.Random(crand.Reader).(*PointSecq256k1)
+		x, err := k256.Scalar.SetBigInt(pt.X().BigInt())
+		require.NoError(t, err)
+		y, err := k256.Scalar.SetBigInt(pt.Y().BigInt())
+		require.NoError(t, err)
+		require.Equal(t, 0, y.Square().Cmp(x.Cube().Add(k256.Scalar.New(7))))
+	}
+
+	// secp256k1 coordinates are secq256k1 scalars: y^2 = x^3 + 7
+	for i := 0; i < 10; i++ {
+		pt := k256.Point.Random(crand.Reader).(*PointK256)
+		x, err := secq256k1.Scalar.SetBigInt(pt.X().BigInt())
+		require.NoError(t, err)
+		y, err := secq256k1.Scalar.SetBigInt(pt.Y().BigInt())
+		require.NoError(t, err)
+		require.Equal(t, 0, y.Square().Cmp(x.Cube().Add(secq256k1.Scalar.New(7))))
+	}
+}
+
+func TestPointSecq256k1Generator(t *testing.T) {
+	secq256k1 := Secq256k1()
+	g, ok := secq256k1.Point.Generator().(*PointSecq256k1)
+	require.True(t, ok)
+	require.True(t, g.IsOnCurve())
+	require.Equal(t, g.X().BigInt(), bhex("76c39f5585cb160eb6b06c87a2ce32e23134e45a097781a6a24288e37702eda6"))
+	require.Equal(t, g.Y().BigInt(), bhex("3ffc646c7b2918b5dc2d265a8e82a7f7d18983d26e8dc055a4120ddad952677f"))
+}
+
+func TestPointSecq256k1Mul(t *testing.T) {
+	tests := []struct {
+		k, x, y string
+	}{
+		{
+			k: "2",
+			x: "c17340f13398a692e5f455d1275059e44c085f188a12ab0aae2fa282098c29c0",
+			y: "18b1dfccd9b774dd05e4f53a15cab27b781f77ffbf92d7617935bf32d9288812",
+		},
+		{
+			k: "3",
+			x: "bfee81e7fa8480a8ee0a13d4bf21fdcf37beaa125d921fe088f339b1549819fa",
+			y: "eb9e37d68bf1f60965432b007419ae9c7875dd9929dde2536c905934144c5ee9",
+		},
+		{
+			k: "1234567890abcdef",
+			x: "5ad3e68a38543a0ff22040f07e74e02db4c217e5740fd06d8571125cee0eeb15",
+			y: "a595db7e59df14cda42640fb733e5b6790109469be79fd8a64834104f5b13bed",
+		},
+		{
+			k: "1234567890abcdef1234567890abcdef",
+			x: "794412aa009e0d03abee1eead84c733fa2433a1861d463d4bea0527e5ecb6040",
+			y: "29963e10b8fbb66e22166e2cbbdbdbcfa5d53f64b35a01ff4be9c6ea1a338999",
+		},
+	}
+	secq256k1 := Secq256k1()
+	for _, tt := range tests {
+		k, err := secq256k1.Scalar.SetBigInt(bhex(tt.k))
+		require.NoError(t, err)
+		pt := secq256k1.ScalarBaseMult(k).(*PointSecq256k1)
+		require.Equal(t, pt.X().BigInt(), bhex(tt.x))
+		require.Equal(t, pt.Y().BigInt(), bhex(tt.y))
+		require.True(t, secq256k1.Point.Generator().Mul(k).Equal(pt))
+	}
+}
+
+func TestPointSecq256k1Hash(t *testing.T) {
+	tests := []struct {
+		msg  []byte
+		x, y string
+	}{
+		{
+			msg: []byte{},
+			x:   "c7ec21a85f000f2a29fe0321f12087ca5de9526335776442d87bb926e220299b",
+			y:   "bf4b89645465b9c6c779dcf0963792d7d6f433c60a7b4734477696c0fe934574",
+		},
+		{
+			msg: []byte("abc"),
+			x:   "566a4f0842311576f724b422c704aa298b0e2e6fbfe4343e40f9c7223c612a55",
+			y:   "27c0dba01b225e5df5ebbfc836fc53a5cdba901952c27f3c4f8b8d16c04d05f4",
+		},
+		{
+			msg: make([]byte, 32),
+			x:   "b1834a1ad397cc55214b6eb9e4f67d5fdd8008a46d0ba2274b950b3c8727af67",
+			y:   "55c38770a399aca6159034fc776f14a8aea3ac0c631cdc21b104fc2af5dcdedf",
+		},
+	}
+	secq256k1 := Secq256k1()
+	for _, tt := range tests {
+		pt, ok := secq256k1.Point.Hash(tt.msg).(*PointSecq256k1)
+		require.True(t, ok)
+		require.Equal(t, pt.X().BigInt(), bhex(tt.x))
+		require.Equal(t, pt.Y().BigInt(), bhex(tt.y))
+	}
+
+	// Random hashes 64 bytes read from the reader
+	pt, ok := secq256k1.Point.Random(testRng()).(*PointSecq256k1)
+	require.True(t, ok)
+	require.Equal(t, pt.X().BigInt(), bhex("5c578e0d68cb12260d633c5e3780813b6f438a7bd0bc65df206d42a8f17e1843"))
+	require.Equal(t, pt.Y().BigInt(), bhex("a8ddcbc09da0aaa314ac10d7a3341ec11b41bc2235458e8552c0af30c6d36f74"))
+}
+
+func TestScalarSecq256k1Hash(t *testing.T) {
+	tests := []struct {
+		msg      []byte
+		expected string
+	}{
+		{[]byte{}, "a42fc3d2acdf9d1c010e396ee41e9918300db2711db460beb388537a382f8e3f"},
+		{[]byte("abc"), "8b424bde656cfecd379826fc244cabed589619f6a142864026d15c0cfd6b25e9"},
+		{make([]byte, 32), "91a02bc121cc47ab093ab8ce5a5d7e089a51d756a8e01d2455f6b75e50a7b1de"},
+	}
+	secq256k1 := Secq256k1()
+	for _, tt := range tests {
+		s, ok := secq256k1.Scalar.Hash(tt.msg).(*ScalarSecq256k1)
+		require.True(t, ok)
+		require.Equal(t, s.value.BigInt(), bhex(tt.expected))
+	}
+
+	s, ok := secq256k1.Scalar.Random(testRng()).(*ScalarSecq256k1)
+	require.True(t, ok)
+	require.Equal(t, s.value.BigInt(), bhex("f35f6dd74350652bd01574c8c57c0427420429b31ae0795c71fffa19e19e392a"))
+	require.Nil(t, secq256k1.Scalar.Random(nil))
+}
+
+func TestPointSecq256k1Serialize(t *testing.T) {
+	secq256k1 := Secq256k1()
+	g := secq256k1.Point.Generator()
+
+	// SEC1: a parity tag then the big endian coordinates
+	cmprs := g.ToAffineCompressed()
+	require.Equal(t, 33, len(cmprs))
+	require.Equal(t, byte(0x03), cmprs[0])
+	require.Equal(t, bhex("76c39f5585cb160eb6b06c87a2ce32e23134e45a097781a6a24288e37702eda6"), new(big.Int).SetBytes(cmprs[1:]))
+	require.Equal(t, byte(0x02), g.Neg().ToAffineCompressed()[0])
+	un := g.ToAffineUncompressed()
+	require.Equal(t, 65, len(un))
+	require.Equal(t, byte(0x04), un[0])
+	require.Equal(t, bhex("76c39f5585cb160eb6b06c87a2ce32e23134e45a097781a6a24288e37702eda6"), new(big.Int).SetBytes(un[1:33]))
+	require.Equal(t, bhex("3ffc646c7b2918b5dc2d265a8e82a7f7d18983d26e8dc055a4120ddad952677f"), new(big.Int).SetBytes(un[33:]))
+
+	two := g.Double()
+	cmprs = two.ToAffineCompressed()
+	require.Equal(t, byte(0x02), cmprs[0])
+	require.Equal(t, bhex("c17340f13398a692e5f455d1275059e44c085f188a12ab0aae2fa282098c29c0"), new(big.Int).SetBytes(cmprs[1:]))
+	retP, err := two.FromAffineCompressed(cmprs)
+	require.NoError(t, err)
+	require.True(t, two.Equal(retP))
+	retP, err = two.FromAffineUncompressed(two.ToAffineUncompressed())
+	require.NoError(t, err)
+	require.True(t, two.Equal(retP))
+
+	_, err = two.FromAffineCompressed(append([]byte{0x04}, cmprs[1:]...))
+	require.Error(t, err)
+	_, err = two.FromAffineUncompressed(append([]byte{0x02}, two.ToAffineUncompressed()[1:]...))
+	require.Error(t, err)
+}
+
+func TestPointSecq256k1GetCurveByName(t *testing.T) {
+	curve := GetCurveByName(Secq256k1Name)
+	require.NotNil(t, curve)
+	g := curve.Point.Generator()
+	bin, err := PointMarshalBinary(g)
+	require.NoError(t, err)
+	pt, err := PointUnmarshalBinary(bin)
+	require.NoError(t, err)
+	require.True(t, g.Equal(pt))
+}
@@ -0,0 +1,324 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/mikelodder7/curvey/native/sm3"
+)
+
+// Sm2DefaultUserID is the user identity used when none is agreed upon,
+// as specified in GB/T 35276-2017.
+var Sm2DefaultUserID = []byte("1234567812345678")
+
+// Sm2PrivateKey is an SM2 private key d in [1, n-2].
+type Sm2PrivateKey struct {
+	d         *ScalarSm2
+	publicKey *Sm2PublicKey
+}
+
+// Sm2PublicKey is an SM2 public key P = d*G.
+type Sm2PublicKey struct {
+	point *PointSm2
+}
+
+// Sm2Signature is an SM2 signature (r, s) as defined in GB/T 32918.2-2016.
+type Sm2Signature struct {
+	R, S *ScalarSm2
+}
+
+// GenerateSm2Key creates a new private key using reader.
+func GenerateSm2Key(reader io.Reader) (*Sm2PrivateKey, error) {
+	if reader == nil {
+		return nil, fmt.Errorf("invalid reader")
+	}
+	for {
+		d, err := sm2RandomScalar(reader)
+		if err != nil {
+			return nil, err
+		}
+		key, err := NewSm2PrivateKey(d)
+		if err == nil {
+			return key, nil
+		}
+	}
+}
+
+// NewSm2PrivateKey creates a private key from the scalar d.
+// d must not be 0 or n-1 as 1+d must be invertible when signing.
+func NewSm2PrivateKey(d *ScalarSm2) (*Sm2PrivateKey, error) {
+	if d == nil {
+		return nil, fmt.Errorf("invalid private key")
+	}
+	if d.IsZero() || d.Add(new(ScalarSm2).One()).IsZero() {
+		return nil, fmt.Errorf("invalid private key")
+	}
+	point, _ := new(PointSm2).Generator().Mul(d).(*PointSm2)
+	return &Sm2PrivateKey{
+		d:         d.Clone().(*ScalarSm2),
+		publicKey: &Sm2PublicKey{point},
+	}, nil
+}
+
+// Scalar returns the private scalar d.
+func (k *Sm2PrivateKey) Scalar() *ScalarSm2 {
+	return k.d.Clone().(*ScalarSm2)
+}
+
+// PublicKey returns the public key for this private key.
+func (k *Sm2PrivateKey) PublicKey() *Sm2PublicKey {
+	return k.publicKey
+}
+
+// NewSm2PublicKey creates a public key from a point which must be
+// on the curve and not the identity.
+func NewSm2PublicKey(point *PointSm2) (*Sm2PublicKey, error) {
+	if point == nil || point.IsIdentity() || !point.IsOnCurve() {
+		return nil, fmt.Errorf("invalid public key")
+	}
+	return &Sm2PublicKey{point}, nil
+}
+
+// Point returns the public point.
+func (k *Sm2PublicKey) Point() *PointSm2 {
+	return k.point
+}
+
+func (k *Sm2PublicKey) Equal(rhs *Sm2PublicKey) bool {
+	return rhs != nil && k.point.Equal(rhs.point)
+}
+
+// Sm2ZA computes the user identity preamble
+// ZA = SM3(ENTLA || ID || a || b || xG || yG || xA || yA).
+func Sm2ZA(id []byte, publicKey *Sm2PublicKey) ([]byte, error) {
+	if publicKey == nil {
+		return nil, fmt.Errorf("invalid public key")
+	}
+	if len(id) > 0x1fff {
+		return nil, fmt.Errorf("user identity is too long")
+	}
+	var entla [2]byte
+	binary.BigEndian.PutUint16(entla[:], uint16(len(id)*8))
+
+	var a, b [32]byte
+	params := new(PointSm2).Generator().(*PointSm2).value.Params
+	params.A.BigInt().FillBytes(a[:])
+	params.B.BigInt().FillBytes(b[:])
+
+	h := sm3.New()
+	_, _ = h.Write(entla[:])
+	_, _ = h.Write(id)
+	_, _ = h.Write(a[:])
+	_, _ = h.Write(b[:])
+	_, _ = h.Write(new(PointSm2).Generator().ToAffineUncompressed()[1:])
+	_, _ = h.Write(publicKey.point.ToAffineUncompressed()[1:])
+	return h.Sum(nil), nil
+}
+
+// Sign computes the SM2 signature of msg for the user identity id.
+// The message is hashed together with the identity preamble ZA.
+func (k *Sm2PrivateKey) Sign(reader io.Reader, id, msg []byte) (*Sm2Signature, error) {
+	if reader == nil {
+		return nil, fmt.Errorf("invalid reader")
+	}
+	e, err := sm2Digest(id, msg, k.publicKey)
+	if err != nil {
+		return nil, err
+	}
+	dInv, err := k.d.Add(new(ScalarSm2).One()).Invert()
+	if err != nil {
+		return nil, err
+	}
+	g := new(PointSm2).Generator()
+	for {
+		kk, err := sm2RandomScalar(reader)
+		if err != nil {
+			return nil, err
+		}
+		if kk.IsZero() {
+			continue
+		}
+		x1, _ := new(ScalarSm2).SetBigInt(g.Mul(kk).(*PointSm2).X().BigInt())
+		r := e.Add(x1)
+		if r.IsZero() || r.Add(kk).IsZero() {
+			continue
+		}
+		// s = (1 + d)^-1 * (k - r*d)
+		s := dInv.Mul(kk.Sub(r.Mul(k.d)))
+		if s.IsZero() {
+			continue
+		}
+		return &Sm2Signature{
+			R: r.(*ScalarSm2),
+			S: s.(*ScalarSm2),
+		}, nil
+	}
+}
+
+// Verify checks the SM2 signature of msg for the user identity id.
+func (k *Sm2PublicKey) Verify(id, msg []byte, sig *Sm2Signature) bool {
+	if sig == nil || sig.R == nil || sig.S == nil || sig.R.IsZero() || sig.S.IsZero() {
+		return false
+	}
+	e, err := sm2Digest(id, msg, k)
+	if err != nil {
+		return false
+	}
+	t := sig.R.Add(sig.S)
+	if t.IsZero() {
+		return false
+	}
+	pt := new(PointSm2).Generator().(*PointSm2).mulVarTime(sig.S).Add(k.point.mulVarTime(t.(*ScalarSm2)))
+	if pt.IsIdentity() {
+		return false
+	}
+	x1, _ := new(ScalarSm2).SetBigInt(pt.(*PointSm2).X().BigInt())
+	return e.Add(x1).Cmp(sig.R) == 0
+}
+
+// Bytes returns the signature as r || s, each 32 bytes big endian.
+func (s *Sm2Signature) Bytes() []byte {
+	out := make([]byte, 0, 64)
+	out = append(out, s.R.Bytes()...)
+	return append(out, s.S.Bytes()...)
+}
+
+// SetBytes decodes a signature in the form r || s.
+func (*Sm2Signature) SetBytes(input []byte) (*Sm2Signature, error) {
+	if len(input) != 64 {
+		return nil, fmt.Errorf("invalid signature length")
+	}
+	r, err := new(ScalarSm2).SetBytes(input[:32])
+	if err != nil {
+		return nil, err
+	}
+	s, err := new(ScalarSm2).SetBytes(input[32:])
+	if err != nil {
+		return nil, err
+	}
+	return &Sm2Signature{
+		R: r.(*ScalarSm2),
+		S: s.(*ScalarSm2),
+	}, nil
+}
+
+// Encrypt encrypts msg to this public key as specified in GB/T 32918.4-2016.
+// The ciphertext is returned as C1 || C3 || C2 where C1 is the
+// uncompressed ephemeral point and C3 is the SM3 check value.
+func (k *Sm2PublicKey) Encrypt(reader io.Reader, msg []byte) ([]byte, error) {
+	if reader == nil {
+		return nil, fmt.Errorf("invalid reader")
+	}
+	if len(msg) == 0 {
+		return nil, fmt.Errorf("message cannot be empty")
+	}
+	g := new(PointSm2).Generator()
+	for {
+		kk, err := sm2RandomScalar(reader)
+		if err != nil {
+			return nil, err
+		}
+		if kk.IsZero() {
+			continue
+		}
+		c1 := g.Mul(kk).ToAffineUncompressed()
+		xy := k.point.Mul(kk).ToAffineUncompressed()[1:]
+		t := sm2Kdf(xy, len(msg))
+		if subtle.ConstantTimeCompare(t, make([]byte, len(t))) == 1 {
+			continue
+		}
+		for i := range t {
+			t[i] ^= msg[i]
+		}
+		out := make([]byte, 0, len(c1)+sm3.Size+len(t))
+		out = append(out, c1...)
+		out = append(out, sm2CheckValue(xy, msg)...)
+		return append(out, t...), nil
+	}
+}
+
+// Decrypt decrypts a ciphertext in the form C1 || C3 || C2.
+func (k *Sm2PrivateKey) Decrypt(ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) <= 65+sm3.Size {
+		return nil, fmt.Errorf("invalid ciphertext length")
+	}
+	c1, err := new(PointSm2).FromAffineUncompressed(ciphertext[:65])
+	if err != nil {
+		return nil, err
+	}
+	if c1.IsIdentity() || !c1.IsOnCurve() {
+		return nil, fmt.Errorf("invalid ciphertext")
+	}
+	c3 := ciphertext[65 : 65+sm3.Size]
+	c2 := ciphertext[65+sm3.Size:]
+
+	xy := c1.Mul(k.d).ToAffineUncompressed()[1:]
+	msg := sm2Kdf(xy, len(c2))
+	if subtle.ConstantTimeCompare(msg, make([]byte, len(msg))) == 1 {
+		return nil, fmt.Errorf("invalid ciphertext")
+	}
+	for i := range msg {
+		msg[i] ^= c2[i]
+	}
+	if subtle.ConstantTimeCompare(sm2CheckValue(xy, msg), c3) != 1 {
+		return nil, fmt.Errorf("invalid ciphertext")
+	}
+	return msg, nil
+}
+
+// sm2Digest computes e = SM3(ZA || msg) reduced modulo n.
+func sm2Digest(id, msg []byte, publicKey *Sm2PublicKey) (*ScalarSm2, error) {
+	za, err := Sm2ZA(id, publicKey)
+	if err != nil {
+		return nil, err
+	}
+	h := sm3.New()
+	_, _ = h.Write(za)
+	_, _ = h.Write(msg)
+	e, _ := new(ScalarSm2).SetBigInt(new(big.Int).SetBytes(h.Sum(nil)))
+	return e.(*ScalarSm2), nil
+}
+
+// sm2CheckValue computes C3 = SM3(x2 || msg || y2).
+func sm2CheckValue(xy, msg []byte) []byte {
+	h := sm3.New()
+	_, _ = h.Write(xy[:32])
+	_, _ = h.Write(msg)
+	_, _ = h.Write(xy[32:])
+	return h.Sum(nil)
+}
+
+// sm2Kdf is the key derivation function from GB/T 32918.4-2016, Section 5.4.3.
+func sm2Kdf(z []byte, length int) []byte {
+	out := make([]byte, 0, length+sm3.Size)
+	var ct [4]byte
+	h := sm3.New()
+	for i := uint32(1); len(out) < length; i++ {
+		binary.BigEndian.PutUint32(ct[:], i)
+		h.Reset()
+		_, _ = h.Write(z)
+		_, _ = h.Write(ct[:])
+		out = h.Sum(out)
+	}
+	return out[:length]
+}
+
+// sm2RandomScalar reads 64 bytes from reader and reduces them modulo n.
+func sm2RandomScalar(reader io.Reader) (*ScalarSm2, error) {
+	var seed [64]byte
+	if _, err := io.ReadFull(reader, seed[:]); err != nil {
+		return nil, err
+	}
+	s, err := new(ScalarSm2).SetBytesWide(seed[:])
+	if err != nil {
+		return nil, err
+	}
+	return s.(*ScalarSm2), nil
+}
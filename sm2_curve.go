@@ -0,0 +1,649 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+	sm2n "github.com/mikelodder7/curvey/native/sm2"
+)
+
+type ScalarSm2 struct {
+	value *native.Field4
+}
+
+type PointSm2 struct {
+	value *native.EllipticPoint4
+}
+
+func (s *ScalarSm2) Random(reader io.Reader) Scalar {
+	if reader == nil {
+		return nil
+	}
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return s.Hash(seed[:])
+}
+
+func (*ScalarSm2) Hash(bytes []byte) Scalar {
+	dst := []byte("SM2_XMD:SM3_SSWU_RO_")
+	xmd := native.ExpandMsgXmd(native.EllipticPointHasherSm3(), bytes, dst, 48)
+	var t [64]byte
+	copy(t[:48], internal.ReverseBytes(xmd))
+
+	return &ScalarSm2{
+		value: sm2n.FqNew().SetBytesWide(&t),
+	}
+}
+
+func (*ScalarSm2) Zero() Scalar {
+	return &ScalarSm2{
+		value: sm2n.FqNew().SetZero(),
+	}
+}
+
+func (*ScalarSm2) One() Scalar {
+	return &ScalarSm2{
+		value: sm2n.FqNew().SetOne(),
+	}
+}
+
+func (s *ScalarSm2) IsZero() bool {
+	return s.value.IsZero() == 1
+}
+
+func (s *ScalarSm2) IsOne() bool {
+	return s.value.IsOne() == 1
+}
+
+func (s *ScalarSm2) IsOdd() bool {
+	return s.value.Bytes()[0]&1 == 1
+}
+
+func (s *ScalarSm2) IsEven() bool {
+	return s.value.Bytes()[0]&1 == 0
+}
+
+func (*ScalarSm2) New(value int) Scalar {
+	t := sm2n.FqNew()
+	v := big.NewInt(int64(value))
+	if value < 0 {
+		v.Mod(v, t.Params.BiModulus)
+	}
+	return &ScalarSm2{
+		value: t.SetBigInt(v),
+	}
+}
+
+func (s *ScalarSm2) Cmp(rhs Scalar) int {
+	r, ok := rhs.(*ScalarSm2)
+	if ok {
+		return s.value.Cmp(r.value)
+	} else {
+		return -2
+	}
+}
+
+func (s *ScalarSm2) Square() Scalar {
+	return &ScalarSm2{
+		value: sm2n.FqNew().Square(s.value),
+	}
+}
+
+func (s *ScalarSm2) Pow(exp uint64) Scalar {
+	expFieldLimb := [native.Field4Limbs]uint64{exp, 0, 0, 0}
+	out := ScalarSm2{value: sm2n.FqNew()}
+	native.Pow(&out.value.Value, &s.value.Value, &expFieldLimb, s.value.Params, s.value.Arithmetic)
+	return &ScalarSm2{
+		value: out.value,
+	}
+}
+
+func (s *ScalarSm2) Double() Scalar {
+	return &ScalarSm2{
+		value: sm2n.FqNew().Double(s.value),
+	}
+}
+
+func (s *ScalarSm2) Invert() (Scalar, error) {
+	value, wasInverted := sm2n.FqNew().Invert(s.value)
+	if !wasInverted {
+		return nil, fmt.Errorf("inverse doesn't exist")
+	}
+	return &ScalarSm2{
+		value,
+	}, nil
+}
+
+func (s *ScalarSm2) Sqrt() (Scalar, error) {
+	value, wasSquare := sm2n.FqNew().Sqrt(s.value)
+	if !wasSquare {
+		return nil, fmt.Errorf("not a square")
+	}
+	return &ScalarSm2{
+		value,
+	}, nil
+}
+
+func (s *ScalarSm2) Cube() Scalar {
+	value := sm2n.FqNew().Square(s.value)
+	value.Mul(value, s.value)
+	return &ScalarSm2{
+		value,
+	}
+}
+
+func (s *ScalarSm2) Add(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarSm2)
+	if ok {
+		return &ScalarSm2{
+			value: sm2n.FqNew().Add(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarSm2) Sub(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarSm2)
+	if ok {
+		return &ScalarSm2{
+			value: sm2n.FqNew().Sub(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarSm2) Mul(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarSm2)
+	if ok {
+		return &ScalarSm2{
+			value: sm2n.FqNew().Mul(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarSm2) MulAdd(y, z Scalar) Scalar {
+	return s.Mul(y).Add(z)
+}
+
+func (s *ScalarSm2) Div(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarSm2)
+	if ok {
+		v, wasInverted := sm2n.FqNew().Invert(r.value)
+		if !wasInverted {
+			return nil
+		}
+		v.Mul(v, s.value)
+		return &ScalarSm2{value: v}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarSm2) Neg() Scalar {
+	return &ScalarSm2{
+		value: sm2n.FqNew().Neg(s.value),
+	}
+}
+
+func (*ScalarSm2) SetBigInt(v *big.Int) (Scalar, error) {
+	if v == nil {
+		return nil, fmt.Errorf("'v' cannot be nil")
+	}
+	value := sm2n.FqNew().SetBigInt(v)
+	return &ScalarSm2{
+		value,
+	}, nil
+}
+
+func (s *ScalarSm2) BigInt() *big.Int {
+	return s.value.BigInt()
+}
+
+func (s *ScalarSm2) Bytes() []byte {
+	t := s.value.Bytes()
+	return internal.ReverseBytes(t[:])
+}
+
+func (*ScalarSm2) SetBytes(bytes []byte) (Scalar, error) {
+	if len(bytes) != 32 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [32]byte
+	copy(seq[:], internal.ReverseBytes(bytes))
+	value, err := sm2n.FqNew().SetBytes(&seq)
+	if err != nil {
+		return nil, err
+	}
+	return &ScalarSm2{
+		value,
+	}, nil
+}
+
+func (*ScalarSm2) SetBytesWide(bytes []byte) (Scalar, error) {
+	if len(bytes) != 64 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [64]byte
+	copy(seq[:], bytes)
+	return &ScalarSm2{
+		value: sm2n.FqNew().SetBytesWide(&seq),
+	}, nil
+}
+
+func (*ScalarSm2) Point() Point {
+	return new(PointSm2).Identity()
+}
+
+func (s *ScalarSm2) Clone() Scalar {
+	return &ScalarSm2{
+		value: sm2n.FqNew().Set(s.value),
+	}
+}
+
+func (s *ScalarSm2) MarshalBinary() ([]byte, error) {
+	return ScalarMarshalBinary(s)
+}
+
+func (s *ScalarSm2) UnmarshalBinary(input []byte) error {
+	sc, err := ScalarUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarSm2)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarSm2) MarshalText() ([]byte, error) {
+	return ScalarMarshalText(s)
+}
+
+func (s *ScalarSm2) UnmarshalText(input []byte) error {
+	sc, err := ScalarUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarSm2)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarSm2) MarshalJSON() ([]byte, error) {
+	return ScalarMarshalJSON(s)
+}
+
+func (s *ScalarSm2) UnmarshalJSON(input []byte) error {
+	sc, err := ScalarUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	S, ok := sc.(*ScalarSm2)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	s.value = S.value
+	return nil
+}
+
+func (p *PointSm2) Random(reader io.Reader) Point {
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return p.Hash(seed[:])
+}
+
+func (*PointSm2) Hash(bytes []byte) Point {
+	value, err := sm2n.PointNew().Hash(bytes, native.EllipticPointHasherSm3())
+	// TODO: change hash to return an error also
+	if err != nil {
+		return nil
+	}
+
+	return &PointSm2{value}
+}
+
+func (*PointSm2) Identity() Point {
+	return &PointSm2{
+		value: sm2n.PointNew().Identity(),
+	}
+}
+
+func (*PointSm2) Generator() Point {
+	return &PointSm2{
+		value: sm2n.PointNew().Generator(),
+	}
+}
+
+func (p *PointSm2) IsIdentity() bool {
+	return p.value.IsIdentity()
+}
+
+func (p *PointSm2) IsNegative() bool {
+	return p.value.GetY().Value[0]&1 == 1
+}
+
+func (p *PointSm2) IsOnCurve() bool {
+	return p.value.IsOnCurve()
+}
+
+func (p *PointSm2) Double() Point {
+	value := sm2n.PointNew().Double(p.value)
+	return &PointSm2{value}
+}
+
+func (*PointSm2) Scalar() Scalar {
+	return new(ScalarSm2).Zero()
+}
+
+func (p *PointSm2) Neg() Point {
+	value := sm2n.PointNew().Neg(p.value)
+	return &PointSm2{value}
+}
+
+func (p *PointSm2) Add(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointSm2)
+	if ok {
+		value := sm2n.PointNew().Add(p.value, r.value)
+		return &PointSm2{value}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointSm2) Sub(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointSm2)
+	if ok {
+		value := sm2n.PointNew().Sub(p.value, r.value)
+		return &PointSm2{value}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointSm2) Mul(rhs Scalar) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*ScalarSm2)
+	if ok {
+		value := sm2n.PointNew().Mul(p.value, r.value)
+		return &PointSm2{value}
+	} else {
+		return nil
+	}
+}
+
+func (*PointSm2) selectPoint(row []Point, index int) Point {
+	value := sm2n.PointNew().Identity()
+	for i, pt := range row {
+		value.CMove(value, pt.(*PointSm2).value, ctEqual(i+1, index))
+	}
+	return &PointSm2{value}
+}
+
+// mulVarTime is Mul for public scalars such as those used to verify signatures.
+func (p *PointSm2) mulVarTime(rhs *ScalarSm2) *PointSm2 {
+	return &PointSm2{sm2n.PointNew().MulVarTime(p.value, rhs.value)}
+}
+
+func (p *PointSm2) Equal(rhs Point) bool {
+	r, ok := rhs.(*PointSm2)
+	if ok {
+		return p.value.Equal(r.value) == 1
+	} else {
+		return false
+	}
+}
+
+func (*PointSm2) Set(x, y *big.Int) (Point, error) {
+	value, err := sm2n.PointNew().SetBigInt(x, y)
+	if err != nil {
+		return nil, err
+	}
+	return &PointSm2{value}, nil
+}
+
+func (p *PointSm2) ToAffineCompressed() []byte {
+	var x [33]byte
+	x[0] = byte(2)
+
+	t := sm2n.PointNew().ToAffine(p.value)
+
+	x[0] |= t.Y.Bytes()[0] & 1
+
+	xBytes := t.X.Bytes()
+	copy(x[1:], internal.ReverseBytes(xBytes[:]))
+	return x[:]
+}
+
+func (p *PointSm2) ToAffineUncompressed() []byte {
+	var out [65]byte
+	out[0] = byte(4)
+	t := sm2n.PointNew().ToAffine(p.value)
+	arr := t.X.Bytes()
+	copy(out[1:33], internal.ReverseBytes(arr[:]))
+	arr = t.Y.Bytes()
+	copy(out[33:], internal.ReverseBytes(arr[:]))
+	return out[:]
+}
+
+func (p *PointSm2) FromAffineCompressed(bytes []byte) (Point, error) {
+	var raw [native.Field4Bytes]byte
+	if len(bytes) != 33 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	sign := int(bytes[0])
+	if sign != 2 && sign != 3 {
+		return nil, fmt.Errorf("invalid sign byte")
+	}
+	sign &= 0x1
+
+	copy(raw[:], internal.ReverseBytes(bytes[1:]))
+	x, err := sm2n.FpNew().SetBytes(&raw)
+	if err != nil {
+		return nil, err
+	}
+
+	value := sm2n.PointNew().Identity()
+	rhs := sm2n.FpNew()
+	p.value.Arithmetic.RhsEquation(rhs, x)
+	// test that rhs is quadratic residue
+	// if not, then this Point is at infinity
+	y, wasQr := sm2n.FpNew().Sqrt(rhs)
+	if wasQr {
+		// fix the sign
+		sigY := int(y.Bytes()[0] & 1)
+		if sigY != sign {
+			y.Neg(y)
+		}
+		value.X = x
+		value.Y = y
+		value.Z.SetOne()
+	}
+	return &PointSm2{value}, nil
+}
+
+func (*PointSm2) FromAffineUncompressed(bytes []byte) (Point, error) {
+	var arr [native.Field4Bytes]byte
+	if len(bytes) != 65 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	if bytes[0] != 4 {
+		return nil, fmt.Errorf("invalid sign byte")
+	}
+
+	copy(arr[:], internal.ReverseBytes(bytes[1:33]))
+	x, err := sm2n.FpNew().SetBytes(&arr)
+	if err != nil {
+		return nil, err
+	}
+	copy(arr[:], internal.ReverseBytes(bytes[33:]))
+	y, err := sm2n.FpNew().SetBytes(&arr)
+	if err != nil {
+		return nil, err
+	}
+	value := sm2n.PointNew()
+	value.X = x
+	value.Y = y
+	value.Z.SetOne()
+	return &PointSm2{value}, nil
+}
+
+func (*PointSm2) CurveName() string {
+	return Sm2Name
+}
+
+func (*PointSm2) SumOfProducts(points []Point, scalars []Scalar) Point {
+	nPoints := make([]*native.EllipticPoint4, len(points))
+	nScalars := make([]*native.Field4, len(scalars))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointSm2)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarSm2)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.value
+	}
+	value := sm2n.PointNew()
+	_, err := value.SumOfProducts(nPoints, nScalars)
+	if err != nil {
+		return nil
+	}
+	return &PointSm2{value}
+}
+
+func (*PointSm2) sumOfProductsVarTime(points []Point, scalars []Scalar, opts *MsmOptions) Point {
+	nPoints := make([]*native.EllipticPoint4, len(points))
+	nScalars := make([]*native.Field4, len(scalars))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointSm2)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarSm2)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.value
+	}
+	value := sm2n.PointNew()
+	_, err := value.MsmVarTime(nPoints, nScalars, opts)
+	if err != nil {
+		return nil
+	}
+	return &PointSm2{value}
+}
+
+func (p *PointSm2) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point {
+	return p.VarTimeMultiScalarMult([]Scalar{a, b}, []Point{capA, p.Generator()})
+}
+
+func (p *PointSm2) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	return p.sumOfProductsVarTime(points, scalars, nil)
+}
+
+func (*PointSm2) batchNormalize(points []Point) []Point {
+	nPoints := make([]*native.EllipticPoint4, len(points))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointSm2)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	out := make([]Point, len(points))
+	for i, value := range native.BatchToAffine(nPoints) {
+		out[i] = &PointSm2{value}
+	}
+	return out
+}
+
+func (p *PointSm2) X() *native.Field4 {
+	return p.value.GetX()
+}
+
+func (p *PointSm2) Y() *native.Field4 {
+	return p.value.GetY()
+}
+
+func (p *PointSm2) MarshalBinary() ([]byte, error) {
+	return PointMarshalBinary(p)
+}
+
+func (p *PointSm2) UnmarshalBinary(input []byte) error {
+	pt, err := PointUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointSm2)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointSm2) MarshalText() ([]byte, error) {
+	return PointMarshalText(p)
+}
+
+func (p *PointSm2) UnmarshalText(input []byte) error {
+	pt, err := PointUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointSm2)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointSm2) MarshalJSON() ([]byte, error) {
+	return PointMarshalJSON(p)
+}
+
+func (p *PointSm2) UnmarshalJSON(input []byte) error {
+	pt, err := PointUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	P, ok := pt.(*PointSm2)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	p.value = P.value
+	return nil
+}
@@ -0,0 +1,332 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	crand "crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// The k*G vectors in this file were computed with OpenSSL 3.0 and the hash
+// vectors with a direct transcription of RFC 9380 expand_message_xmd and the
+// simplified SWU map written with Python integers, independently of this
+// package. SM2 has no standard hash to curve suite, so the DST follows the
+// RFC 9380 naming with SM3.
+
+func TestScalarSm2Random(t *testing.T) {
+	sm2 := SM2()
+	sc := sm2.Scalar.Random(testRng())
+	s, ok := sc.(*ScalarSm2)
+	require.True(t, ok)
+	expected := bhex("395799f247937567b78cb0101d3ff69fbfedcea2fe91fa40cc38d8f2528d3331")
+	require.Equal(t, s.value.BigInt(), expected)
+	// Try 10 random values
+	for i := 0; i < 10; i++ {
+		sc := sm2.Scalar.Random(crand.Reader)
+		_, ok := sc.(*ScalarSm2)
+		require.True(t, ok)
+		require.True(t, !sc.IsZero())
+	}
+}
+
+func TestScalarSm2Hash(t *testing.T) {
+	tests := []struct {
+		msg      []byte
+		expected string
+	}{
+		{[]byte{}, "a0873224161396db885be52d0bed1cc353d0b87d0ca36a05589af037dfe11eca"},
+		{[]byte("abc"), "8210523721be374f3ddca4264dcf17ac2ac14b2aabd14ff45dde2ebf34eafb91"},
+		{make([]byte, 32), "58a2bc8fc88dc8078528db8ea737d36c5cf5bd93a5c6e99655db16e79257abfe"},
+	}
+	sm2 := SM2()
+	for _, tt := range tests {
+		s, ok := sm2.Scalar.Hash(tt.msg).(*ScalarSm2)
+		require.True(t, ok)
+		require.Equal(t, s.value.BigInt(), bhex(tt.expected))
+	}
+}
+
+func TestScalarSm2New(t *testing.T) {
+	sm2 := SM2()
+	three := sm2.Scalar.New(3)
+	require.True(t, three.IsOdd())
+	four := sm2.Scalar.New(4)
+	require.True(t, four.IsEven())
+	neg1 := sm2.Scalar.New(-1)
+	require.True(t, neg1.IsEven())
+	neg2 := sm2.Scalar.New(-2)
+	require.True(t, neg2.IsOdd())
+	require.Equal(t, three.Square().Cmp(sm2.Scalar.New(9)), 0)
+	require.Equal(t, three.Cube().Cmp(sm2.Scalar.New(27)), 0)
+	require.Equal(t, three.Double().Cmp(sm2.Scalar.New(6)), 0)
+	require.Equal(t, sm2.Scalar.One().Neg().Cmp(neg1), 0)
+}
+
+func TestScalarSm2Invert(t *testing.T) {
+	sm2 := SM2()
+	nine := sm2.Scalar.New(9)
+	actual, err := nine.Invert()
+	require.NoError(t, err)
+
+	bn := new(big.Int).SetInt64(9)
+	bn.ModInverse(bn, bhex("fffffffeffffffffffffffffffffffff7203df6b21c6052b53bbf40939d54123"))
+
+	expected, err := sm2.Scalar.SetBigInt(bn)
+	require.NoError(t, err)
+	require.Equal(t, actual.Cmp(expected), 0)
+}
+
+func TestScalarSm2Sqrt(t *testing.T) {
+	sm2 := SM2()
+	nine := sm2.Scalar.New(9)
+	actual, err := nine.Sqrt()
+	require.NoError(t, err)
+	three := sm2.Scalar.New(3)
+	require.True(t, actual.Cmp(three) == 0 || actual.Cmp(three.Neg()) == 0)
+}
+
+func TestScalarSm2Add(t *testing.T) {
+	sm2 := SM2()
+	nine := sm2.Scalar.New(9)
+	six := sm2.Scalar.New(6)
+	require.Equal(t, nine.Add(six).Cmp(sm2.Scalar.New(15)), 0)
+	n := bhex("fffffffeffffffffffffffffffffffff7203df6b21c6052b53bbf40939d54123")
+	n.Sub(n, big.NewInt(3))
+
+	upper, err := sm2.Scalar.SetBigInt(n)
+	require.NoError(t, err)
+	require.Equal(t, upper.Add(nine).Cmp(six), 0)
+	require.Equal(t, six.Sub(nine).Cmp(upper), 0)
+	require.Equal(t, nine.Mul(six).Cmp(sm2.Scalar.New(54)), 0)
+	require.Equal(t, sm2.Scalar.New(54).Div(nine).Cmp(six), 0)
+}
+
+func TestScalarSm2Serialize(t *testing.T) {
+	sm2 := SM2()
+	sc := sm2.Scalar.New(255)
+	sequence := sc.Bytes()
+	require.Equal(t, len(sequence), 32)
+	require.Equal(t, sequence[31], byte(0xff))
+	ret, err := sm2.Scalar.SetBytes(sequence)
+	require.NoError(t, err)
+	require.Equal(t, ret.Cmp(sc), 0)
+
+	// Try 10 random values
+	for i := 0; i < 10; i++ {
+		sc = sm2.Scalar.Random(crand.Reader)
+		sequence = sc.Bytes()
+		require.Equal(t, len(sequence), 32)
+		ret, err = sm2.Scalar.SetBytes(sequence)
+		require.NoError(t, err)
+		require.Equal(t, ret.Cmp(sc), 0)
+	}
+}
+
+func TestScalarSm2Nil(t *testing.T) {
+	sm2 := SM2()
+	one := sm2.Scalar.New(1)
+	require.Nil(t, one.Add(nil))
+	require.Nil(t, one.Sub(nil))
+	require.Nil(t, one.Mul(nil))
+	require.Nil(t, one.Div(nil))
+	require.Nil(t, sm2.Scalar.Random(nil))
+	require.Equal(t, one.Cmp(nil), -2)
+	_, err := sm2.Scalar.SetBigInt(nil)
+	require.Error(t, err)
+}
+
+func TestPointSm2Random(t *testing.T) {
+	sm2 := SM2()
+	sc := sm2.Point.Random(testRng())
+	s, ok := sc.(*PointSm2)
+	require.True(t, ok)
+	require.Equal(t, s.X().BigInt(), bhex("a409ba29e86a0b8a63fcee652216c3074b1249e4832f234447579fda8bd63aca"))
+	require.Equal(t, s.Y().BigInt(), bhex("b883dba869df486bcd4f0f7cb48ee5bef33733eca5f6963a2c41cf71e678969f"))
+	// Try 10 random values
+	for i := 0; i < 10; i++ {
+		sc := sm2.Point.Random(crand.Reader)
+		_, ok := sc.(*PointSm2)
+		require.True(t, ok)
+		require.True(t, !sc.IsIdentity())
+		require.True(t, sc.IsOnCurve())
+	}
+}
+
+func TestPointSm2Hash(t *testing.T) {
+	tests := []struct {
+		msg  []byte
+		x, y string
+	}{
+		{
+			msg: []byte{},
+			x:   "d61e55bd0ef336027690eb5c55f86228b8fb5fa852a236524c7e10ab89a0a7b9",
+			y:   "9c1c07e517239929f874295797b41f83d0215395d07d80b34aca036e4df546cd",
+		},
+		{
+			msg: []byte("abc"),
+			x:   "27e15df2b00b3fc255c3ca7f4f2d598ee43f12801dedc64ca850c4a02cbd56",
+			y:   "8d26c0ac885869116b62b961d04d77c09d793cc54f39f5110f728afb854de508",
+		},
+		{
+			msg: make([]byte, 32),
+			x:   "b5a072263287e98d6a5a27b22e28c30dcde1591068eaf49eb9416f8c21b1d16e",
+			y:   "6e43506a49798124e2d53316224afaa68a3304150230d75e0cef5938d339f460",
+		},
+	}
+	sm2 := SM2()
+	for _, tt := range tests {
+		pt, ok := sm2.Point.Hash(tt.msg).(*PointSm2)
+		require.True(t, ok)
+		require.Equal(t, pt.X().BigInt(), bhex(tt.x))
+		require.Equal(t, pt.Y().BigInt(), bhex(tt.y))
+	}
+}
+
+func TestPointSm2Generator(t *testing.T) {
+	sm2 := SM2()
+	sc := sm2.Point.Generator()
+	s, ok := sc.(*PointSm2)
+	require.True(t, ok)
+	require.True(t, s.IsOnCurve())
+	require.Equal(t, s.X().BigInt(), bhex("32c4ae2c1f1981195f9904466a39c9948fe30bbff2660be1715a4589334c74c7"))
+	require.Equal(t, s.Y().BigInt(), bhex("bc3736a2f4f6779c59bdcee36b692153d0a9877cc62a474002df32e52139f0a0"))
+	_, err := sm2.Point.Set(s.X().BigInt(), s.Y().BigInt())
+	require.NoError(t, err)
+	iden, err := sm2.Point.Set(big.NewInt(0), big.NewInt(0))
+	require.NoError(t, err)
+	require.True(t, iden.IsIdentity())
+}
+
+func TestPointSm2Arithmetic(t *testing.T) {
+	sm2 := SM2()
+	g := sm2.Point.Generator()
+	require.True(t, g.Double().Equal(g.Mul(sm2.Scalar.New(2))))
+	require.True(t, g.Add(g).Add(g).Equal(g.Mul(sm2.Scalar.New(3))))
+	require.True(t, g.Neg().Neg().Equal(g))
+	i := sm2.Point.Identity()
+	require.True(t, i.Double().Equal(i))
+	require.True(t, i.Neg().Equal(i))
+	pt := g.Mul(sm2.Scalar.New(4))
+	require.True(t, pt.Sub(g).Sub(g).Sub(g).Equal(g))
+	require.True(t, pt.Sub(g).Sub(g).Sub(g).Sub(g).IsIdentity())
+	require.True(t, g.Mul(sm2.Scalar.New(-1)).Add(g).IsIdentity())
+}
+
+func TestPointSm2Mul(t *testing.T) {
+	tests := []struct {
+		k, x, y string
+	}{
+		{
+			k: "2",
+			x: "56cefd60d7c87c000d58ef57fa73ba4d9c0dfa08c08a7331495c2e1da3f2bd52",
+			y: "31b7e7e6cc8189f668535ce0f8eaf1bd6de84c182f6c8e716f780d3a970a23c3",
+		},
+		{
+			k: "3",
+			x: "a97f7cd4b3c993b4be2daa8cdb41e24ca13f6bd945302244e26918f1d0509ebf",
+			y: "530b5dd88c688ef5ccc5cec08a72150f7c400ee5cd045292aaacdd037458f6e6",
+		},
+		{
+			k: "1234567890abcdef",
+			x: "cb79bea697a5f90c00fd7d6bb8eb74f78c0b94542f9eb082f626d07b3cb7c2ce",
+			y: "cbb70aded5cb7124695bf72de3e85c7587bcfc9db97852dcfd514ef252001f5e",
+		},
+		{
+			k: "1234567890abcdef1234567890abcdef",
+			x: "c8dd58918b34bfed15c42115093d1241fa9dbc6202e024e3e0852f49d9fc71fe",
+			y: "38ece450fff6520d7622b1fbf7a57cb5b755d5e4f25bbdc724a62a293b025011",
+		},
+		// example key pair dA, PA of GB/T 32918
+		{
+			k: "3945208f7b2144b13f36e38ac6d39f95889393692860b51a42fb81ef4df7c5b8",
+			x: "9f9df311e5421a150dd7d161e4bc5c672179fad1833fc076bb08ff356f35020",
+			y: "ccea490ce26775a52dc6ea718cc1aa600aed05fbf35e084a6632f6072da9ad13",
+		},
+	}
+	sm2 := SM2()
+	for _, tt := range tests {
+		k, err := sm2.Scalar.SetBigInt(bhex(tt.k))
+		require.NoError(t, err)
+		pt := sm2.ScalarBaseMult(k).(*PointSm2)
+		require.Equal(t, pt.X().BigInt(), bhex(tt.x))
+		require.Equal(t, pt.Y().BigInt(), bhex(tt.y))
+		require.True(t, sm2.Point.Generator().Mul(k).Equal(pt))
+	}
+}
+
+func TestPointSm2Serialize(t *testing.T) {
+	sm2 := SM2()
+	ss := sm2.Scalar.Random(testRng())
+	g := sm2.Point.Generator()
+
+	ppt := g.Mul(ss)
+
+	require.Equal(t, ppt.ToAffineCompressed(), []byte{0x2, 0x42, 0x2b, 0xe, 0x62, 0x9, 0xcd, 0x31, 0x86, 0x43, 0x51, 0x12, 0x9c, 0x8f, 0xb1, 0xa4, 0xcd, 0x16, 0xe5, 0x1b, 0x49, 0xc1, 0x5d, 0x7b, 0xc9, 0xad, 0xab, 0x14, 0xb4, 0x14, 0xec, 0xe7, 0xeb})
+	require.Equal(t, ppt.ToAffineUncompressed(), []byte{0x4, 0x42, 0x2b, 0xe, 0x62, 0x9, 0xcd, 0x31, 0x86, 0x43, 0x51, 0x12, 0x9c, 0x8f, 0xb1, 0xa4, 0xcd, 0x16, 0xe5, 0x1b, 0x49, 0xc1, 0x5d, 0x7b, 0xc9, 0xad, 0xab, 0x14, 0xb4, 0x14, 0xec, 0xe7, 0xeb, 0x6e, 0x22, 0xc3, 0xfb, 0x7, 0x8d, 0x2c, 0xed, 0x1c, 0x8e, 0x1, 0xdd, 0xd8, 0x46, 0x14, 0x39, 0xb1, 0x45, 0x5b, 0x3d, 0x84, 0x94, 0xb9, 0x80, 0x14, 0xf5, 0x95, 0xa6, 0x97, 0x24, 0x81, 0x12})
+	retP, err := ppt.FromAffineCompressed(ppt.ToAffineCompressed())
+	require.NoError(t, err)
+	require.True(t, ppt.Equal(retP))
+	retP, err = ppt.FromAffineUncompressed(ppt.ToAffineUncompressed())
+	require.NoError(t, err)
+	require.True(t, ppt.Equal(retP))
+
+	// smoke test
+	for i := 0; i < 25; i++ {
+		s := sm2.Scalar.Random(crand.Reader)
+		pt := g.Mul(s)
+		cmprs := pt.ToAffineCompressed()
+		require.Equal(t, len(cmprs), 33)
+		retC, err := pt.FromAffineCompressed(cmprs)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(retC))
+
+		un := pt.ToAffineUncompressed()
+		require.Equal(t, len(un), 65)
+		retU, err := pt.FromAffineUncompressed(un)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(retU))
+	}
+}
+
+func TestPointSm2Nil(t *testing.T) {
+	sm2 := SM2()
+	one := sm2.Point.Generator()
+	require.Nil(t, one.Add(nil))
+	require.Nil(t, one.Sub(nil))
+	require.Nil(t, one.Mul(nil))
+	require.False(t, one.Equal(nil))
+}
+
+func TestPointSm2SumOfProducts(t *testing.T) {
+	lhs := new(PointSm2).Generator().Mul(new(ScalarSm2).New(50))
+	points := make([]Point, 5)
+	for i := range points {
+		points[i] = new(PointSm2).Generator()
+	}
+	scalars := []Scalar{
+		new(ScalarSm2).New(8),
+		new(ScalarSm2).New(9),
+		new(ScalarSm2).New(10),
+		new(ScalarSm2).New(11),
+		new(ScalarSm2).New(12),
+	}
+	rhs := lhs.SumOfProducts(points, scalars)
+	require.NotNil(t, rhs)
+	require.True(t, lhs.Equal(rhs))
+}
+
+func TestPointSm2GetCurveByName(t *testing.T) {
+	curve := GetCurveByName(Sm2Name)
+	require.NotNil(t, curve)
+	g := curve.Point.Generator()
+	bin, err := PointMarshalBinary(g)
+	require.NoError(t, err)
+	pt, err := PointUnmarshalBinary(bin)
+	require.NoError(t, err)
+	require.True(t, g.Equal(pt))
+}
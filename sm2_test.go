@@ -0,0 +1,145 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Keys, signatures and ciphertexts generated with OpenSSL 3.0
+const (
+	sm2TestPrivateKey = "4dfcc3f8cd7357474d280342a010603c18cdcfd5c65a505760ab91ed59967263"
+	sm2TestPublicKey  = "048035ad76b1808c039cdfe1fb9dff49f0ea1ba3be0f321b77234d7cfa4302d47d7db9ef6f2e049554b57d19d58514a009f4dfa65cf344512a41c5308dd927ae70"
+)
+
+func sm2TestKey(t *testing.T) *Sm2PrivateKey {
+	t.Helper()
+	d, err := new(ScalarSm2).SetBytes(mustDecodeHex(t, sm2TestPrivateKey))
+	require.NoError(t, err)
+	key, err := NewSm2PrivateKey(d.(*ScalarSm2))
+	require.NoError(t, err)
+	return key
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func TestSm2PublicKey(t *testing.T) {
+	key := sm2TestKey(t)
+	require.Equal(t, mustDecodeHex(t, sm2TestPublicKey), key.PublicKey().Point().ToAffineUncompressed())
+
+	pt, err := new(PointSm2).FromAffineUncompressed(mustDecodeHex(t, sm2TestPublicKey))
+	require.NoError(t, err)
+	pub, err := NewSm2PublicKey(pt.(*PointSm2))
+	require.NoError(t, err)
+	require.True(t, pub.Equal(key.PublicKey()))
+
+	_, err = NewSm2PublicKey(new(PointSm2).Identity().(*PointSm2))
+	require.Error(t, err)
+	_, err = NewSm2PrivateKey(new(ScalarSm2).Zero().(*ScalarSm2))
+	require.Error(t, err)
+	_, err = NewSm2PrivateKey(new(ScalarSm2).New(-1).(*ScalarSm2))
+	require.Error(t, err)
+}
+
+func TestSm2VerifyOpenSSL(t *testing.T) {
+	tests := []struct {
+		id   string
+		r, s string
+	}{
+		{
+			string(Sm2DefaultUserID),
+			"207a963b6fa2c69a0a5727ff99153b2fe431913ac234c331c2d3cd43bb3528e5",
+			"975f74c5e444f406c940ed852201fa383bf91e0dea6765e7b298e1c4765f2b09",
+		},
+		{
+			"ALICE123@YAHOO.COM",
+			"28fd87f214ab7df7ca3d80070fbb886cb50971c4e101db677c8b9672299e0875",
+			"c26ac8ced6a64cf7db8cd84926fe7de2dde1e7f27ff58d5ce5d944901ee1c830",
+		},
+	}
+	pub := sm2TestKey(t).PublicKey()
+	msg := []byte("message digest")
+	for _, tst := range tests {
+		sig, err := new(Sm2Signature).SetBytes(mustDecodeHex(t, tst.r+tst.s))
+		require.NoError(t, err)
+		require.True(t, pub.Verify([]byte(tst.id), msg, sig))
+		require.False(t, pub.Verify([]byte(tst.id), []byte("message digesT"), sig))
+		require.False(t, pub.Verify([]byte("1234567812345679"), msg, sig))
+	}
+}
+
+func TestSm2SignVerify(t *testing.T) {
+	key, err := GenerateSm2Key(crand.Reader)
+	require.NoError(t, err)
+	msg := []byte("message digest")
+
+	sig, err := key.Sign(crand.Reader, Sm2DefaultUserID, msg)
+	require.NoError(t, err)
+	require.True(t, key.PublicKey().Verify(Sm2DefaultUserID, msg, sig))
+
+	sig2, err := new(Sm2Signature).SetBytes(sig.Bytes())
+	require.NoError(t, err)
+	require.True(t, key.PublicKey().Verify(Sm2DefaultUserID, msg, sig2))
+
+	other, err := GenerateSm2Key(crand.Reader)
+	require.NoError(t, err)
+	require.False(t, other.PublicKey().Verify(Sm2DefaultUserID, msg, sig))
+	require.False(t, key.PublicKey().Verify(Sm2DefaultUserID, msg, &Sm2Signature{sig.R, sig.R}))
+	require.False(t, key.PublicKey().Verify(Sm2DefaultUserID, msg, nil))
+	require.False(t, key.PublicKey().Verify(Sm2DefaultUserID, msg, &Sm2Signature{
+		R: new(ScalarSm2).Zero().(*ScalarSm2),
+		S: sig.S,
+	}))
+}
+
+func TestSm2DecryptOpenSSL(t *testing.T) {
+	key := sm2TestKey(t)
+	// C1 || C3 || C2 from the DER encoded OpenSSL ciphertext
+	ciphertext := mustDecodeHex(t, "04"+
+		"f1fe90c218280f6822282e847eb0a10fe47041296a73b4b6bb678a3c086990b2"+
+		"a7d827bd6a53a2a294d946a1aec7d6687abce054d5e4abacee68c17fd7f4bd6f"+
+		"97619aa9cae12c9f5885b8e21b3b46f629744f742896f6ea02defed835a02dc3"+
+		"f93d581bb1f77699d28067b1c725")
+	msg, err := key.Decrypt(ciphertext)
+	require.NoError(t, err)
+	require.Equal(t, []byte("message digest"), msg)
+
+	ciphertext[len(ciphertext)-1] ^= 1
+	_, err = key.Decrypt(ciphertext)
+	require.Error(t, err)
+}
+
+func TestSm2EncryptDecrypt(t *testing.T) {
+	key, err := GenerateSm2Key(crand.Reader)
+	require.NoError(t, err)
+	for _, l := range []int{1, 31, 32, 33, 100} {
+		msg := make([]byte, l)
+		_, _ = crand.Read(msg)
+		ciphertext, err := key.PublicKey().Encrypt(crand.Reader, msg)
+		require.NoError(t, err)
+		require.Len(t, ciphertext, 65+32+l)
+		actual, err := key.Decrypt(ciphertext)
+		require.NoError(t, err)
+		require.Equal(t, msg, actual)
+
+		other, err := GenerateSm2Key(crand.Reader)
+		require.NoError(t, err)
+		_, err = other.Decrypt(ciphertext)
+		require.Error(t, err)
+	}
+	_, err = key.PublicKey().Encrypt(crand.Reader, nil)
+	require.Error(t, err)
+	_, err = key.Decrypt(make([]byte, 97))
+	require.Error(t, err)
+}
@@ -0,0 +1,273 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"math/big"
+
+	starkn "github.com/mikelodder7/curvey/native/stark"
+)
+
+// starkEcdsaBound is the exclusive upper bound 2^251 that StarkNet
+// places on message hashes, r and s^-1 so they fit in a felt.
+var starkEcdsaBound = new(big.Int).Lsh(big.NewInt(1), 251)
+
+// StarkPrivateKey is a StarkNet private key d in [1, n-1].
+type StarkPrivateKey struct {
+	d         *ScalarStark
+	publicKey *StarkPublicKey
+}
+
+// StarkPublicKey is a StarkNet public key Q = d*G.
+type StarkPublicKey struct {
+	point *PointStark
+}
+
+// StarkSignature is a StarkNet ECDSA signature (r, s).
+type StarkSignature struct {
+	R, S *ScalarStark
+}
+
+// StarkPedersenHash computes the StarkNet Pedersen hash of two felts.
+// Both inputs must be in [0, p).
+func StarkPedersenHash(a, b *big.Int) (*big.Int, error) {
+	modulus := starkn.FpNew().Params.BiModulus
+	if a == nil || a.Sign() < 0 || a.Cmp(modulus) >= 0 {
+		return nil, fmt.Errorf("invalid felt")
+	}
+	if b == nil || b.Sign() < 0 || b.Cmp(modulus) >= 0 {
+		return nil, fmt.Errorf("invalid felt")
+	}
+	h := starkn.PedersenHash(starkn.FpNew().SetBigInt(a), starkn.FpNew().SetBigInt(b))
+	return h.BigInt(), nil
+}
+
+// GenerateStarkKey creates a new private key using reader.
+func GenerateStarkKey(reader io.Reader) (*StarkPrivateKey, error) {
+	if reader == nil {
+		return nil, fmt.Errorf("invalid reader")
+	}
+	for {
+		var seed [64]byte
+		if _, err := io.ReadFull(reader, seed[:]); err != nil {
+			return nil, err
+		}
+		d, err := new(ScalarStark).SetBytesWide(seed[:])
+		if err != nil {
+			return nil, err
+		}
+		key, err := NewStarkPrivateKey(d.(*ScalarStark))
+		if err == nil {
+			return key, nil
+		}
+	}
+}
+
+// NewStarkPrivateKey creates a private key from the scalar d.
+func NewStarkPrivateKey(d *ScalarStark) (*StarkPrivateKey, error) {
+	if d == nil || d.IsZero() {
+		return nil, fmt.Errorf("invalid private key")
+	}
+	point, _ := new(PointStark).Generator().Mul(d).(*PointStark)
+	return &StarkPrivateKey{
+		d:         d.Clone().(*ScalarStark),
+		publicKey: &StarkPublicKey{point},
+	}, nil
+}
+
+// Scalar returns the private scalar d.
+func (k *StarkPrivateKey) Scalar() *ScalarStark {
+	return k.d.Clone().(*ScalarStark)
+}
+
+// PublicKey returns the public key for this private key.
+func (k *StarkPrivateKey) PublicKey() *StarkPublicKey {
+	return k.publicKey
+}
+
+// NewStarkPublicKey creates a public key from a point which must be
+// on the curve and not the identity.
+func NewStarkPublicKey(point *PointStark) (*StarkPublicKey, error) {
+	if point == nil || point.IsIdentity() || !point.IsOnCurve() {
+		return nil, fmt.Errorf("invalid public key")
+	}
+	return &StarkPublicKey{point}, nil
+}
+
+// NewStarkPublicKeyFromX creates a public key from its x-coordinate
+// which is how StarkNet accounts store them. Either y may be chosen
+// since Verify accepts signatures for both Q and -Q.
+func NewStarkPublicKeyFromX(x *big.Int) (*StarkPublicKey, error) {
+	if x == nil || x.Sign() < 0 || x.Cmp(starkn.FpNew().Params.BiModulus) >= 0 {
+		return nil, fmt.Errorf("invalid public key")
+	}
+	var compressed [33]byte
+	compressed[0] = 2
+	x.FillBytes(compressed[1:])
+	point, err := new(PointStark).Identity().FromAffineCompressed(compressed[:])
+	if err != nil {
+		return nil, err
+	}
+	return NewStarkPublicKey(point.(*PointStark))
+}
+
+// Point returns the public point.
+func (k *StarkPublicKey) Point() *PointStark {
+	return k.point
+}
+
+func (k *StarkPublicKey) Equal(rhs *StarkPublicKey) bool {
+	return rhs != nil && k.point.Equal(rhs.point)
+}
+
+// Sign computes the StarkNet ECDSA signature of msgHash which must be
+// less than 2^251. The nonce is derived deterministically as in RFC 6979
+// with the seed used as extra entropy when a candidate is rejected,
+// which matches the StarkNet reference implementation.
+func (k *StarkPrivateKey) Sign(msgHash *big.Int) (*StarkSignature, error) {
+	if msgHash == nil || msgHash.Sign() < 0 || msgHash.Cmp(starkEcdsaBound) >= 0 {
+		return nil, fmt.Errorf("invalid message hash")
+	}
+	for seed := uint64(0); ; seed++ {
+		kk := starkGenerateK(k.d, msgHash, seed)
+		if sig := k.signWithK(msgHash, kk); sig != nil {
+			return sig, nil
+		}
+	}
+}
+
+// signWithK computes the signature for the nonce kk or
+// returns nil if the resulting r or s is out of range.
+func (k *StarkPrivateKey) signWithK(msgHash *big.Int, kk *ScalarStark) *StarkSignature {
+	x := new(PointStark).Generator().Mul(kk).(*PointStark).X().BigInt()
+	if x.Sign() == 0 || x.Cmp(starkEcdsaBound) >= 0 {
+		return nil
+	}
+	r, _ := new(ScalarStark).SetBigInt(x)
+	z, _ := new(ScalarStark).SetBigInt(msgHash)
+	// s = (z + r*d) / k
+	t := z.Add(r.Mul(k.d))
+	if t.IsZero() {
+		return nil
+	}
+	s := t.Div(kk)
+	w, _ := s.Invert()
+	if w.BigInt().Cmp(starkEcdsaBound) >= 0 {
+		return nil
+	}
+	return &StarkSignature{
+		R: r.(*ScalarStark),
+		S: s.(*ScalarStark),
+	}
+}
+
+// Verify checks the StarkNet ECDSA signature of msgHash.
+func (k *StarkPublicKey) Verify(msgHash *big.Int, sig *StarkSignature) bool {
+	if msgHash == nil || msgHash.Sign() < 0 || msgHash.Cmp(starkEcdsaBound) >= 0 {
+		return false
+	}
+	if sig == nil || sig.R == nil || sig.S == nil || sig.R.IsZero() || sig.S.IsZero() {
+		return false
+	}
+	r := sig.R.BigInt()
+	if r.Cmp(starkEcdsaBound) >= 0 {
+		return false
+	}
+	w, err := sig.S.Invert()
+	if err != nil || w.BigInt().Cmp(starkEcdsaBound) >= 0 {
+		return false
+	}
+	z, _ := new(ScalarStark).SetBigInt(msgHash)
+	a := new(PointStark).Generator().(*PointStark).mulVarTime(z.Mul(w).(*ScalarStark))
+	b := k.point.mulVarTime(sig.R.Mul(w).(*ScalarStark))
+	// StarkNet only commits to the x-coordinate of the public key
+	// so the signature is valid for either Q or -Q
+	for _, pt := range []Point{a.Add(b), a.Sub(b)} {
+		if !pt.IsIdentity() && pt.(*PointStark).X().BigInt().Cmp(r) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Bytes returns the signature as r || s, each 32 bytes big endian.
+func (s *StarkSignature) Bytes() []byte {
+	out := make([]byte, 0, 64)
+	out = append(out, s.R.Bytes()...)
+	return append(out, s.S.Bytes()...)
+}
+
+// SetBytes decodes a signature in the form r || s.
+func (*StarkSignature) SetBytes(input []byte) (*StarkSignature, error) {
+	if len(input) != 64 {
+		return nil, fmt.Errorf("invalid signature length")
+	}
+	r, err := new(ScalarStark).SetBytes(input[:32])
+	if err != nil {
+		return nil, err
+	}
+	s, err := new(ScalarStark).SetBytes(input[32:])
+	if err != nil {
+		return nil, err
+	}
+	return &StarkSignature{
+		R: r.(*ScalarStark),
+		S: s.(*ScalarStark),
+	}, nil
+}
+
+// starkGenerateK derives the nonce from RFC 6979, Section 3.2 using
+// HMAC-SHA256. The seed is appended as extra entropy in its minimal
+// big endian form so a seed of 0 is the plain RFC 6979 nonce.
+func starkGenerateK(d *ScalarStark, msgHash *big.Int, seed uint64) *ScalarStark {
+	// msgHash < 2^251 < n so bits2octets is the identity
+	var h [32]byte
+	msgHash.FillBytes(h[:])
+	bx := make([]byte, 0, 72)
+	bx = append(bx, d.Bytes()...)
+	bx = append(bx, h[:]...)
+	bx = append(bx, new(big.Int).SetUint64(seed).Bytes()...)
+
+	v := make([]byte, sha256.Size)
+	kk := make([]byte, sha256.Size)
+	for i := range v {
+		v[i] = 1
+	}
+	for _, b := range []byte{0, 1} {
+		mac := hmac.New(sha256.New, kk)
+		_, _ = mac.Write(v)
+		_, _ = mac.Write([]byte{b})
+		_, _ = mac.Write(bx)
+		kk = mac.Sum(kk[:0])
+		mac = hmac.New(sha256.New, kk)
+		_, _ = mac.Write(v)
+		v = mac.Sum(v[:0])
+	}
+
+	n := starkn.FqNew().Params.BiModulus
+	for {
+		mac := hmac.New(sha256.New, kk)
+		_, _ = mac.Write(v)
+		v = mac.Sum(v[:0])
+		// bits2int keeps the leftmost 252 bits
+		t := new(big.Int).SetBytes(v)
+		t.Rsh(t, 4)
+		if t.Sign() > 0 && t.Cmp(n) < 0 {
+			out, _ := new(ScalarStark).SetBigInt(t)
+			return out.(*ScalarStark)
+		}
+		mac = hmac.New(sha256.New, kk)
+		_, _ = mac.Write(v)
+		_, _ = mac.Write([]byte{0})
+		kk = mac.Sum(kk[:0])
+		mac = hmac.New(sha256.New, kk)
+		_, _ = mac.Write(v)
+		v = mac.Sum(v[:0])
+	}
+}
@@ -0,0 +1,653 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/mikelodder7/curvey/internal"
+	"github.com/mikelodder7/curvey/native"
+	starkn "github.com/mikelodder7/curvey/native/stark"
+)
+
+type ScalarStark struct {
+	value *native.Field4
+}
+
+type PointStark struct {
+	value *native.EllipticPoint4
+}
+
+func (s *ScalarStark) Random(reader io.Reader) Scalar {
+	if reader == nil {
+		return nil
+	}
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return s.Hash(seed[:])
+}
+
+func (*ScalarStark) Hash(bytes []byte) Scalar {
+	dst := []byte("stark-curve_XMD:SHA-256_SVDW_RO_")
+	xmd := native.ExpandMsgXmd(native.EllipticPointHasherSha256(), bytes, dst, 48)
+	var t [64]byte
+	copy(t[:48], internal.ReverseBytes(xmd))
+
+	return &ScalarStark{
+		value: starkn.FqNew().SetBytesWide(&t),
+	}
+}
+
+func (*ScalarStark) Zero() Scalar {
+	return &ScalarStark{
+		value: starkn.FqNew().SetZero(),
+	}
+}
+
+func (*ScalarStark) One() Scalar {
+	return &ScalarStark{
+		value: starkn.FqNew().SetOne(),
+	}
+}
+
+func (s *ScalarStark) IsZero() bool {
+	return s.value.IsZero() == 1
+}
+
+func (s *ScalarStark) IsOne() bool {
+	return s.value.IsOne() == 1
+}
+
+func (s *ScalarStark) IsOdd() bool {
+	return s.value.Bytes()[0]&1 == 1
+}
+
+func (s *ScalarStark) IsEven() bool {
+	return s.value.Bytes()[0]&1 == 0
+}
+
+func (*ScalarStark) New(value int) Scalar {
+	t := starkn.FqNew()
+	v := big.NewInt(int64(value))
+	if value < 0 {
+		v.Mod(v, t.Params.BiModulus)
+	}
+	return &ScalarStark{
+		value: t.SetBigInt(v),
+	}
+}
+
+func (s *ScalarStark) Cmp(rhs Scalar) int {
+	r, ok := rhs.(*ScalarStark)
+	if ok {
+		return s.value.Cmp(r.value)
+	} else {
+		return -2
+	}
+}
+
+func (s *ScalarStark) Square() Scalar {
+	return &ScalarStark{
+		value: starkn.FqNew().Square(s.value),
+	}
+}
+
+func (s *ScalarStark) Pow(exp uint64) Scalar {
+	expFieldLimb := [native.Field4Limbs]uint64{exp, 0, 0, 0}
+	out := ScalarStark{value: starkn.FqNew()}
+	native.Pow(&out.value.Value, &s.value.Value, &expFieldLimb, s.value.Params, s.value.Arithmetic)
+	return &ScalarStark{
+		value: out.value,
+	}
+}
+
+func (s *ScalarStark) Double() Scalar {
+	return &ScalarStark{
+		value: starkn.FqNew().Double(s.value),
+	}
+}
+
+func (s *ScalarStark) Invert() (Scalar, error) {
+	value, wasInverted := starkn.FqNew().Invert(s.value)
+	if !wasInverted {
+		return nil, fmt.Errorf("inverse doesn't exist")
+	}
+	return &ScalarStark{
+		value,
+	}, nil
+}
+
+func (s *ScalarStark) Sqrt() (Scalar, error) {
+	value, wasSquare := starkn.FqNew().Sqrt(s.value)
+	if !wasSquare {
+		return nil, fmt.Errorf("not a square")
+	}
+	return &ScalarStark{
+		value,
+	}, nil
+}
+
+func (s *ScalarStark) Cube() Scalar {
+	value := starkn.FqNew().Square(s.value)
+	value.Mul(value, s.value)
+	return &ScalarStark{
+		value,
+	}
+}
+
+func (s *ScalarStark) Add(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarStark)
+	if ok {
+		return &ScalarStark{
+			value: starkn.FqNew().Add(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarStark) Sub(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarStark)
+	if ok {
+		return &ScalarStark{
+			value: starkn.FqNew().Sub(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarStark) Mul(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarStark)
+	if ok {
+		return &ScalarStark{
+			value: starkn.FqNew().Mul(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarStark) MulAdd(y, z Scalar) Scalar {
+	return s.Mul(y).Add(z)
+}
+
+func (s *ScalarStark) Div(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarStark)
+	if ok {
+		v, wasInverted := starkn.FqNew().Invert(r.value)
+		if !wasInverted {
+			return nil
+		}
+		v.Mul(v, s.value)
+		return &ScalarStark{value: v}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarStark) Neg() Scalar {
+	return &ScalarStark{
+		value: starkn.FqNew().Neg(s.value),
+	}
+}
+
+func (*ScalarStark) SetBigInt(v *big.Int) (Scalar, error) {
+	if v == nil {
+		return nil, fmt.Errorf("'v' cannot be nil")
+	}
+	value := starkn.FqNew().SetBigInt(v)
+	return &ScalarStark{
+		value,
+	}, nil
+}
+
+func (s *ScalarStark) BigInt() *big.Int {
+	return s.value.BigInt()
+}
+
+func (s *ScalarStark) Bytes() []byte {
+	t := s.value.Bytes()
+	return internal.ReverseBytes(t[:])
+}
+
+func (*ScalarStark) SetBytes(bytes []byte) (Scalar, error) {
+	if len(bytes) != 32 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [32]byte
+	copy(seq[:], internal.ReverseBytes(bytes))
+	value, err := starkn.FqNew().SetBytes(&seq)
+	if err != nil {
+		return nil, err
+	}
+	return &ScalarStark{
+		value,
+	}, nil
+}
+
+func (*ScalarStark) SetBytesWide(bytes []byte) (Scalar, error) {
+	if len(bytes) != 64 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [64]byte
+	copy(seq[:], bytes)
+	return &ScalarStark{
+		value: starkn.FqNew().SetBytesWide(&seq),
+	}, nil
+}
+
+func (*ScalarStark) Point() Point {
+	return new(PointStark).Identity()
+}
+
+func (s *ScalarStark) Clone() Scalar {
+	return &ScalarStark{
+		value: starkn.FqNew().Set(s.value),
+	}
+}
+
+func (s *ScalarStark) MarshalBinary() ([]byte, error) {
+	return ScalarMarshalBinary(s)
+}
+
+func (s *ScalarStark) UnmarshalBinary(input []byte) error {
+	sc, err := ScalarUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarStark)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarStark) MarshalText() ([]byte, error) {
+	return ScalarMarshalText(s)
+}
+
+func (s *ScalarStark) UnmarshalText(input []byte) error {
+	sc, err := ScalarUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarStark)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarStark) MarshalJSON() ([]byte, error) {
+	return ScalarMarshalJSON(s)
+}
+
+func (s *ScalarStark) UnmarshalJSON(input []byte) error {
+	sc, err := ScalarUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	S, ok := sc.(*ScalarStark)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	s.value = S.value
+	return nil
+}
+
+func (p *PointStark) Random(reader io.Reader) Point {
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return p.Hash(seed[:])
+}
+
+func (*PointStark) Hash(bytes []byte) Point {
+	// The STARK curve has no SSWU suite so the dst names the
+	// Shallue-van de Woestijne map used by the native point
+	dst := []byte("stark-curve_XMD:SHA-256_SVDW_RO_")
+	value := starkn.PointNew()
+	err := value.Arithmetic.Hash(value, native.EllipticPointHasherSha256(), bytes, dst)
+	// TODO: change hash to return an error also
+	if err != nil {
+		return nil
+	}
+
+	return &PointStark{value}
+}
+
+func (*PointStark) Identity() Point {
+	return &PointStark{
+		value: starkn.PointNew().Identity(),
+	}
+}
+
+func (*PointStark) Generator() Point {
+	return &PointStark{
+		value: starkn.PointNew().Generator(),
+	}
+}
+
+func (p *PointStark) IsIdentity() bool {
+	return p.value.IsIdentity()
+}
+
+func (p *PointStark) IsNegative() bool {
+	return p.value.GetY().Value[0]&1 == 1
+}
+
+func (p *PointStark) IsOnCurve() bool {
+	return p.value.IsOnCurve()
+}
+
+func (p *PointStark) Double() Point {
+	value := starkn.PointNew().Double(p.value)
+	return &PointStark{value}
+}
+
+func (*PointStark) Scalar() Scalar {
+	return new(ScalarStark).Zero()
+}
+
+func (p *PointStark) Neg() Point {
+	value := starkn.PointNew().Neg(p.value)
+	return &PointStark{value}
+}
+
+func (p *PointStark) Add(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointStark)
+	if ok {
+		value := starkn.PointNew().Add(p.value, r.value)
+		return &PointStark{value}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointStark) Sub(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointStark)
+	if ok {
+		value := starkn.PointNew().Sub(p.value, r.value)
+		return &PointStark{value}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointStark) Mul(rhs Scalar) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*ScalarStark)
+	if ok {
+		value := starkn.PointNew().Mul(p.value, r.value)
+		return &PointStark{value}
+	} else {
+		return nil
+	}
+}
+
+func (*PointStark) selectPoint(row []Point, index int) Point {
+	value := starkn.PointNew().Identity()
+	for i, pt := range row {
+		value.CMove(value, pt.(*PointStark).value, ctEqual(i+1, index))
+	}
+	return &PointStark{value}
+}
+
+// mulVarTime is Mul for public scalars such as those used to verify signatures.
+func (p *PointStark) mulVarTime(rhs *ScalarStark) *PointStark {
+	return &PointStark{starkn.PointNew().MulVarTime(p.value, rhs.value)}
+}
+
+func (p *PointStark) Equal(rhs Point) bool {
+	r, ok := rhs.(*PointStark)
+	if ok {
+		return p.value.Equal(r.value) == 1
+	} else {
+		return false
+	}
+}
+
+func (*PointStark) Set(x, y *big.Int) (Point, error) {
+	value, err := starkn.PointNew().SetBigInt(x, y)
+	if err != nil {
+		return nil, err
+	}
+	return &PointStark{value}, nil
+}
+
+func (p *PointStark) ToAffineCompressed() []byte {
+	var x [33]byte
+	x[0] = byte(2)
+
+	t := starkn.PointNew().ToAffine(p.value)
+
+	x[0] |= t.Y.Bytes()[0] & 1
+
+	xBytes := t.X.Bytes()
+	copy(x[1:], internal.ReverseBytes(xBytes[:]))
+	return x[:]
+}
+
+func (p *PointStark) ToAffineUncompressed() []byte {
+	var out [65]byte
+	out[0] = byte(4)
+	t := starkn.PointNew().ToAffine(p.value)
+	arr := t.X.Bytes()
+	copy(out[1:33], internal.ReverseBytes(arr[:]))
+	arr = t.Y.Bytes()
+	copy(out[33:], internal.ReverseBytes(arr[:]))
+	return out[:]
+}
+
+func (p *PointStark) FromAffineCompressed(bytes []byte) (Point, error) {
+	var raw [native.Field4Bytes]byte
+	if len(bytes) != 33 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	sign := int(bytes[0])
+	if sign != 2 && sign != 3 {
+		return nil, fmt.Errorf("invalid sign byte")
+	}
+	sign &= 0x1
+
+	copy(raw[:], internal.ReverseBytes(bytes[1:]))
+	x, err := starkn.FpNew().SetBytes(&raw)
+	if err != nil {
+		return nil, err
+	}
+
+	value := starkn.PointNew().Identity()
+	rhs := starkn.FpNew()
+	p.value.Arithmetic.RhsEquation(rhs, x)
+	// test that rhs is quadratic residue
+	// if not, then this Point is at infinity
+	y, wasQr := starkn.FpNew().Sqrt(rhs)
+	if wasQr {
+		// fix the sign
+		sigY := int(y.Bytes()[0] & 1)
+		if sigY != sign {
+			y.Neg(y)
+		}
+		value.X = x
+		value.Y = y
+		value.Z.SetOne()
+	}
+	return &PointStark{value}, nil
+}
+
+func (*PointStark) FromAffineUncompressed(bytes []byte) (Point, error) {
+	var arr [native.Field4Bytes]byte
+	if len(bytes) != 65 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	if bytes[0] != 4 {
+		return nil, fmt.Errorf("invalid sign byte")
+	}
+
+	copy(arr[:], internal.ReverseBytes(bytes[1:33]))
+	x, err := starkn.FpNew().SetBytes(&arr)
+	if err != nil {
+		return nil, err
+	}
+	copy(arr[:], internal.ReverseBytes(bytes[33:]))
+	y, err := starkn.FpNew().SetBytes(&arr)
+	if err != nil {
+		return nil, err
+	}
+	value := starkn.PointNew()
+	value.X = x
+	value.Y = y
+	value.Z.SetOne()
+	return &PointStark{value}, nil
+}
+
+func (*PointStark) CurveName() string {
+	return StarkName
+}
+
+func (*PointStark) SumOfProducts(points []Point, scalars []Scalar) Point {
+	nPoints := make([]*native.EllipticPoint4, len(points))
+	nScalars := make([]*native.Field4, len(scalars))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointStark)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarStark)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.value
+	}
+	value := starkn.PointNew()
+	_, err := value.SumOfProducts(nPoints, nScalars)
+	if err != nil {
+		return nil
+	}
+	return &PointStark{value}
+}
+
+func (*PointStark) sumOfProductsVarTime(points []Point, scalars []Scalar, opts *MsmOptions) Point {
+	nPoints := make([]*native.EllipticPoint4, len(points))
+	nScalars := make([]*native.Field4, len(scalars))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointStark)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarStark)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.value
+	}
+	value := starkn.PointNew()
+	_, err := value.MsmVarTime(nPoints, nScalars, opts)
+	if err != nil {
+		return nil
+	}
+	return &PointStark{value}
+}
+
+func (p *PointStark) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point {
+	return p.VarTimeMultiScalarMult([]Scalar{a, b}, []Point{capA, p.Generator()})
+}
+
+func (p *PointStark) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	return p.sumOfProductsVarTime(points, scalars, nil)
+}
+
+func (*PointStark) batchNormalize(points []Point) []Point {
+	nPoints := make([]*native.EllipticPoint4, len(points))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointStark)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.value
+	}
+	out := make([]Point, len(points))
+	for i, value := range native.BatchToAffine(nPoints) {
+		out[i] = &PointStark{value}
+	}
+	return out
+}
+
+func (p *PointStark) X() *native.Field4 {
+	return p.value.GetX()
+}
+
+func (p *PointStark) Y() *native.Field4 {
+	return p.value.GetY()
+}
+
+func (p *PointStark) MarshalBinary() ([]byte, error) {
+	return PointMarshalBinary(p)
+}
+
+func (p *PointStark) UnmarshalBinary(input []byte) error {
+	pt, err := PointUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointStark)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointStark) MarshalText() ([]byte, error) {
+	return PointMarshalText(p)
+}
+
+func (p *PointStark) UnmarshalText(input []byte) error {
+	pt, err := PointUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointStark)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointStark) MarshalJSON() ([]byte, error) {
+	return PointMarshalJSON(p)
+}
+
+func (p *PointStark) UnmarshalJSON(input []byte) error {
+	pt, err := PointUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	P, ok := pt.(*PointStark)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	p.value = P.value
+	return nil
+}
@@ -0,0 +1,201 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// The generator multiples below are cross-checked against cairo-lang and
+// starknet.go where they publish them. StarkNet defines no hash to curve,
+// so the SVDW vectors come from a direct transcription of RFC 9380 written
+// with Python integers, independently of this package.
+
+func TestPointStarkGenerator(t *testing.T) {
+	stark := Stark()
+	g, ok := stark.Point.Generator().(*PointStark)
+	require.True(t, ok)
+	require.True(t, g.IsOnCurve())
+	// EC_GEN from cairo-lang
+	require.Equal(t, g.X().BigInt(), bhex("1ef15c18599971b7beced415a40f0c7deacfd9b0d1819e03d723d8bc943cfca"))
+	require.Equal(t, g.Y().BigInt(), bhex("5668060aa49730b7be4801df46ec62de53ecd11abe43a32873000c36e8dc1f"))
+}
+
+func TestPointStarkMul(t *testing.T) {
+	tests := []struct {
+		k, x, y string
+	}{
+		// private_to_stark_key(2) from starknet.go
+		{
+			k: "2",
+			x: "759ca09377679ecd535a81e83039658bf40959283187c654c5416f439403cf5",
+			y: "6f524a3400e7708d5c01a28598ad272e7455aa88778b19f93b562d7a9646c41",
+		},
+		// r of the cairo-lang signature with k = 3
+		{
+			k: "3",
+			x: "411494b501a98abd8262b0da1351e17899a0c4ef23dd2f96fec5ba847310b20",
+			y: "7e1b3ebac08924d2c26f409549191fcf94f3bf6f301ed3553e22dfb802f0686",
+		},
+		{
+			k: "1234567890abcdef",
+			x: "2abbefdcbf731195ee2acd186441eb536e86f888327b3655cffbd07b57dbf26",
+			y: "4341be0b9057298627f3b0b71c417857e34f8b52101c37427a1213277e7f5b8",
+		},
+		{
+			k: "1234567890abcdef1234567890abcdef",
+			x: "31b88d91e173d70b5bd284f7a898d99005d9ade236ee671e0db0078996aa599",
+			y: "4a2fb9ac23ecad8ad79320064b1fb095d729922857eed90ea89c04b90bb2f3",
+		},
+		// starknet.go signature test key
+		{
+			k: "3b162d58804dbfd3b0459e2fcf4d787d79fabbafdc2122761ad472cbf91191",
+			x: "43ad8702e7ea0380ff1d577356066bd75266dfeed3062504eb1452a3f350f65",
+			y: "1c42a133d0791297ca62aee3c7b42761b1c92ea4d826bdc26e597b226f330ed",
+		},
+	}
+	stark := Stark()
+	for _, tt := range tests {
+		k, err := stark.Scalar.SetBigInt(bhex(tt.k))
+		require.NoError(t, err)
+		pt := stark.ScalarBaseMult(k).(*PointStark)
+		require.Equal(t, pt.X().BigInt(), bhex(tt.x))
+		require.Equal(t, pt.Y().BigInt(), bhex(tt.y))
+		require.True(t, stark.Point.Generator().Mul(k).Equal(pt))
+	}
+}
+
+func TestPointStarkHash(t *testing.T) {
+	tests := []struct {
+		msg  []byte
+		x, y string
+	}{
+		{
+			msg: []byte{},
+			x:   "654b8a24f0ab6f4d6f2cdfd4535b665c5945b23b78c6d31007daf93f5035d83",
+			y:   "554770bc28b654cd39e85e456ad90cd848b8d4c3105dd24abafb52b6a5df4e",
+		},
+		{
+			msg: []byte("abc"),
+			x:   "4c8b193665b0f921fd773b061228beb9851b9362e5902dd346abd1d06cd92e9",
+			y:   "422f77a545dd001d3d5337548649129c2c921f393a3222165c34720d914c5ff",
+		},
+		{
+			msg: make([]byte, 32),
+			x:   "52b2f4848095309c11b41e9ea9509794fde63c6144bb466c91407642dd2ecec",
+			y:   "64c454abca7e1fcb872d1d480ce99054991e0d682682fe09200ea9784444bde",
+		},
+	}
+	stark := Stark()
+	for _, tt := range tests {
+		pt, ok := stark.Point.Hash(tt.msg).(*PointStark)
+		require.True(t, ok)
+		require.Equal(t, pt.X().BigInt(), bhex(tt.x))
+		require.Equal(t, pt.Y().BigInt(), bhex(tt.y))
+	}
+
+	// Random hashes 64 bytes read from the reader
+	pt, ok := stark.Point.Random(testRng()).(*PointStark)
+	require.True(t, ok)
+	require.Equal(t, pt.X().BigInt(), bhex("17a58bcde013e23624e31a5cc9137b674dbab9702e6c05c49e333e5f02e88f4"))
+	require.Equal(t, pt.Y().BigInt(), bhex("767b177230a26e12b612d785fbe7271a0013319f6b6c5b5121b201f7c7ea15f"))
+}
+
+func TestScalarStarkHash(t *testing.T) {
+	tests := []struct {
+		msg      []byte
+		expected string
+	}{
+		{[]byte{}, "2698dce33be16dc3b8ff91a2872b026a2df9ba9d4c72a371195d6f82caa564"},
+		{[]byte("abc"), "311dce1e1e16ada78f1eece965dc4adfa4a8b1b1627a1797851441ed134ba49"},
+		{make([]byte, 32), "3f4d9a718614c5b0f979a5842c62c8aa9d1fd8c03edc138c2491800629db3f9"},
+	}
+	stark := Stark()
+	for _, tt := range tests {
+		s, ok := stark.Scalar.Hash(tt.msg).(*ScalarStark)
+		require.True(t, ok)
+		require.Equal(t, s.value.BigInt(), bhex(tt.expected))
+	}
+
+	s, ok := stark.Scalar.Random(testRng()).(*ScalarStark)
+	require.True(t, ok)
+	require.Equal(t, s.value.BigInt(), bhex("7ee73fc7bf62ae205f1476a476cf7a8e1df4f504202134deadd6ef8832f17ab"))
+	require.Nil(t, stark.Scalar.Random(nil))
+}
+
+func TestPointStarkSerialize(t *testing.T) {
+	stark := Stark()
+	g := stark.Point.Generator()
+
+	// Coordinates are 252 bits so each encoding keeps the top nibble clear
+	cmprs := g.ToAffineCompressed()
+	require.Equal(t, 33, len(cmprs))
+	require.Equal(t, byte(0x03), cmprs[0])
+	require.Equal(t, byte(0x01), cmprs[1])
+	require.Equal(t, bhex("1ef15c18599971b7beced415a40f0c7deacfd9b0d1819e03d723d8bc943cfca"), new(big.Int).SetBytes(cmprs[1:]))
+	require.Equal(t, byte(0x02), g.Neg().ToAffineCompressed()[0])
+	un := g.ToAffineUncompressed()
+	require.Equal(t, 65, len(un))
+	require.Equal(t, byte(0x04), un[0])
+	require.Equal(t, bhex("5668060aa49730b7be4801df46ec62de53ecd11abe43a32873000c36e8dc1f"), new(big.Int).SetBytes(un[33:]))
+	retP, err := g.FromAffineCompressed(cmprs)
+	require.NoError(t, err)
+	require.True(t, g.Equal(retP))
+
+	// SEC1 public key from the starknet.go signature tests
+	raw, err := hex.DecodeString("04033f45f07e1bd1a51b45fc24ec8c8c9908db9e42191be9e169bfcac0c0d997450319d0f53f6ca077c4fa5207819144a2a4165daef6ee47a7c1d06c0dcaa3e456")
+	require.NoError(t, err)
+	pt, err := g.FromAffineUncompressed(raw)
+	require.NoError(t, err)
+	require.True(t, pt.IsOnCurve())
+	require.Equal(t, raw, pt.ToAffineUncompressed())
+	retP, err = g.FromAffineCompressed(pt.ToAffineCompressed())
+	require.NoError(t, err)
+	require.True(t, pt.Equal(retP))
+	pub, err := NewStarkPublicKey(pt.(*PointStark))
+	require.NoError(t, err)
+	r, err := new(ScalarStark).SetBigInt(bhex("56f769b850641120707a04ff5875ec8e30bdc7ff72e1d24dcd8d86237a16a07"))
+	require.NoError(t, err)
+	s, err := new(ScalarStark).SetBigInt(bhex("79ab23b1cc88bf3d30fa9979b57f0d97393f5c4d5efab065649b7315518bad9"))
+	require.NoError(t, err)
+	sig := &StarkSignature{R: r.(*ScalarStark), S: s.(*ScalarStark)}
+	require.True(t, pub.Verify(bhex("7f15c38ea577a26f4f553282fcfe4f1feeb8ecfaad8f221ae41abf8224cbddd"), sig))
+
+	// StarkNet accounts store only x, which decodes to either y
+	xOnly, err := NewStarkPublicKeyFromX(bhex("33f45f07e1bd1a51b45fc24ec8c8c9908db9e42191be9e169bfcac0c0d99745"))
+	require.NoError(t, err)
+	require.True(t, xOnly.Point().Equal(pt) || xOnly.Point().Equal(pt.Neg()))
+	require.True(t, xOnly.Verify(bhex("7f15c38ea577a26f4f553282fcfe4f1feeb8ecfaad8f221ae41abf8224cbddd"), sig))
+
+	// Signatures are r || s as 32 byte big endian values
+	sigBytes := sig.Bytes()
+	require.Equal(t, 64, len(sigBytes))
+	require.Equal(t, bhex("56f769b850641120707a04ff5875ec8e30bdc7ff72e1d24dcd8d86237a16a07"), new(big.Int).SetBytes(sigBytes[:32]))
+	require.Equal(t, bhex("79ab23b1cc88bf3d30fa9979b57f0d97393f5c4d5efab065649b7315518bad9"), new(big.Int).SetBytes(sigBytes[32:]))
+	sig2, err := new(StarkSignature).SetBytes(sig.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, 0, sig2.R.Cmp(sig.R))
+	require.Equal(t, 0, sig2.S.Cmp(sig.S))
+
+	_, err = g.FromAffineCompressed(append([]byte{0x04}, cmprs[1:]...))
+	require.Error(t, err)
+	_, err = g.FromAffineUncompressed(append([]byte{0x02}, un[1:]...))
+	require.Error(t, err)
+}
+
+func TestPointStarkGetCurveByName(t *testing.T) {
+	curve := GetCurveByName(StarkName)
+	require.NotNil(t, curve)
+	g := curve.Point.Generator()
+	bin, err := PointMarshalBinary(g)
+	require.NoError(t, err)
+	pt, err := PointUnmarshalBinary(bin)
+	require.NoError(t, err)
+	require.True(t, g.Equal(pt))
+}
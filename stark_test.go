@@ -0,0 +1,202 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	crand "crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func starkTestKey(t *testing.T, d string) *StarkPrivateKey {
+	t.Helper()
+	s, err := new(ScalarStark).SetBigInt(bhex(d))
+	require.NoError(t, err)
+	key, err := NewStarkPrivateKey(s.(*ScalarStark))
+	require.NoError(t, err)
+	return key
+}
+
+func TestStarkPedersenHash(t *testing.T) {
+	// Test vector from cairo-lang
+	h, err := StarkPedersenHash(
+		bhex("3d937c035c878245caf64531a5756109c53068da139362728feb561405371cb"),
+		bhex("208a0a10250e382e1e4bbe2880906c2791bf6275695e02fbbc6aeff9cd8b31a"),
+	)
+	require.NoError(t, err)
+	require.Equal(t, 0, h.Cmp(bhex("30e480bed5fe53fa909cc0f8c4d99b8f9f2c016be4c41e13a4848797979c662")))
+
+	h, err = StarkPedersenHash(big.NewInt(1), big.NewInt(2))
+	require.NoError(t, err)
+	require.Equal(t, 0, h.Cmp(bhex("5bb9440e27889a364bcb678b1f679ecd1347acdedcbf36e83494f857cc58026")))
+
+	// Test vectors from starknet.go
+	tests := []struct {
+		a, b, expected string
+	}{
+		{"12773", "872362", "5ed2703dfdb505c587700ce2ebfcab5b3515cd7e6114817e6026ec9d4b364ca"},
+		{
+			"13d41f388b8ea4db56c5aa6562f13359fab192b3db57651af916790f9debee9",
+			"537461726b4e6574204d61696c",
+			"180c0a3d13c1adfaa5cbc251f4fc93cc0e26cec30ca4c247305a7ce50ac807c",
+		},
+		{"64", "3e8", "45a62091df6da02dce4250cb67597444d1f465319908486b836f48d0f8bf6e7"},
+	}
+	for _, tst := range tests {
+		h, err = StarkPedersenHash(bhex(tst.a), bhex(tst.b))
+		require.NoError(t, err)
+		require.Equal(t, 0, h.Cmp(bhex(tst.expected)))
+	}
+
+	p := bhex("800000000000011000000000000000000000000000000000000000000000001")
+	_, err = StarkPedersenHash(p, big.NewInt(0))
+	require.Error(t, err)
+	_, err = StarkPedersenHash(big.NewInt(0), big.NewInt(-1))
+	require.Error(t, err)
+	_, err = StarkPedersenHash(nil, big.NewInt(0))
+	require.Error(t, err)
+}
+
+func TestStarkPublicKey(t *testing.T) {
+	key := starkTestKey(t, "3c1e9550e66958296d11b60f8e8e7a7ad990d07fa65d5f7652c4a6c87d4e3cc")
+	x := bhex("77a3b314db07c45076d11f62b6f9e748a39790441823307743cf00d6597ea43")
+	require.Equal(t, 0, key.PublicKey().Point().X().BigInt().Cmp(x))
+
+	pub, err := NewStarkPublicKeyFromX(x)
+	require.NoError(t, err)
+	require.Equal(t, 0, pub.Point().X().BigInt().Cmp(x))
+
+	_, err = NewStarkPublicKey(new(PointStark).Identity().(*PointStark))
+	require.Error(t, err)
+	_, err = NewStarkPrivateKey(new(ScalarStark).Zero().(*ScalarStark))
+	require.Error(t, err)
+}
+
+func TestStarkSignDeterministic(t *testing.T) {
+	tests := []struct {
+		d, msgHash, r, s string
+	}{
+		// Test vector from cairo-lang
+		{
+			"3c1e9550e66958296d11b60f8e8e7a7ad990d07fa65d5f7652c4a6c87d4e3cc",
+			"397e76d1667c4454bfb83514e120583af836f8e32a516765497823eabe16a3f",
+			"173fd03d8b008ee7432977ac27d1e9d1a1f6c98b1a2f05fa84a21c84c44e882",
+			"4b6d75385aed025aa222f28a0adc6d58db78ff17e51c3f59e259b131cd5a1cc",
+		},
+		{
+			"1",
+			"2",
+			"543b191c671bc1f9b2f4e643a5711535cf34cb8330ab22e2416e8cdda8db054",
+			"2f139920a75d2209e972b1bf82dc72e4c1edb8355fdbae7b4910ea7c32e70e2",
+		},
+		{
+			"1234",
+			"0",
+			"29cb8dcae7ac92ec005ba7fbc18e07fbf6c464b3ff1a71d1f7ecf0abf64df3d",
+			"794a8d9e6c82cf8661800ced49081d6875f0bd4acb4049b2f1e31a309c3a624",
+		},
+	}
+	for _, tst := range tests {
+		key := starkTestKey(t, tst.d)
+		msgHash := bhex(tst.msgHash)
+		sig, err := key.Sign(msgHash)
+		require.NoError(t, err)
+		require.Equal(t, 0, sig.R.BigInt().Cmp(bhex(tst.r)))
+		require.Equal(t, 0, sig.S.BigInt().Cmp(bhex(tst.s)))
+		require.True(t, key.PublicKey().Verify(msgHash, sig))
+
+		// Only the x-coordinate of the public key is bound
+		neg, err := NewStarkPublicKey(key.PublicKey().Point().Neg().(*PointStark))
+		require.NoError(t, err)
+		require.True(t, neg.Verify(msgHash, sig))
+	}
+}
+
+func TestStarkVerify(t *testing.T) {
+	// Test vectors from starknet.go
+	tests := []struct {
+		x, msgHash, r, s string
+	}{
+		{
+			"43ad8702e7ea0380ff1d577356066bd75266dfeed3062504eb1452a3f350f65",
+			"5ed2703dfdb505c587700ce2ebfcab5b3515cd7e6114817e6026ec9d4b364ca",
+			"157efe892f61628c638510f66cd1c2201d82aff054c77af483c985a4e063aad",
+			"100b929330239de2cf9ffb5332f18448bbb1f30c2cd0c0a91d46187833c3cfb",
+		},
+		{
+			"33f45f07e1bd1a51b45fc24ec8c8c9908db9e42191be9e169bfcac0c0d99745",
+			"7f15c38ea577a26f4f553282fcfe4f1feeb8ecfaad8f221ae41abf8224cbddd",
+			"56f769b850641120707a04ff5875ec8e30bdc7ff72e1d24dcd8d86237a16a07",
+			"79ab23b1cc88bf3d30fa9979b57f0d97393f5c4d5efab065649b7315518bad9",
+		},
+		{
+			"4e52f2f40700e9cdd0f386c31a1f160d0f310504fc508a1051b747a26070d10",
+			"324df642fcc7d98b1d9941250840704f35b9ac2e3e2b58b6a034cc09adac54c",
+			"64ca24949d64514568c6b67034fee1829fcba49333cd77d586fca524fa900d7",
+			"6fa6cf0cfa2c49e048e916bbdad7f249892ec8e483e90b202e249f6163445a7",
+		},
+	}
+	for _, tst := range tests {
+		pub, err := NewStarkPublicKeyFromX(bhex(tst.x))
+		require.NoError(t, err)
+		r, err := new(ScalarStark).SetBigInt(bhex(tst.r))
+		require.NoError(t, err)
+		s, err := new(ScalarStark).SetBigInt(bhex(tst.s))
+		require.NoError(t, err)
+		sig := &StarkSignature{R: r.(*ScalarStark), S: s.(*ScalarStark)}
+		msgHash := bhex(tst.msgHash)
+		require.True(t, pub.Verify(msgHash, sig))
+		require.False(t, pub.Verify(new(big.Int).Add(msgHash, big.NewInt(1)), sig))
+		bad := &StarkSignature{R: r.Add(new(ScalarStark).One()).(*ScalarStark), S: sig.S}
+		require.False(t, pub.Verify(msgHash, bad))
+	}
+
+	// The first key signs deterministically to the same signature
+	key := starkTestKey(t, "3b162d58804dbfd3b0459e2fcf4d787d79fabbafdc2122761ad472cbf91191")
+	sig, err := key.Sign(bhex(tests[0].msgHash))
+	require.NoError(t, err)
+	require.Equal(t, 0, sig.R.BigInt().Cmp(bhex(tests[0].r)))
+	require.Equal(t, 0, sig.S.BigInt().Cmp(bhex(tests[0].s)))
+}
+
+func TestStarkSignWithK(t *testing.T) {
+	// Test vector from cairo-lang
+	key := starkTestKey(t, "1")
+	sig := key.signWithK(big.NewInt(2), new(ScalarStark).New(3).(*ScalarStark))
+	require.NotNil(t, sig)
+	require.Equal(t, 0, sig.R.BigInt().Cmp(bhex("411494b501a98abd8262b0da1351e17899a0c4ef23dd2f96fec5ba847310b20")))
+	require.Equal(t, 0, sig.S.BigInt().Cmp(bhex("405c3191ab3883ef2b763af35bc5f5d15b3b4e99461d70e84c654a351a7c81b")))
+}
+
+func TestStarkSignVerify(t *testing.T) {
+	key, err := GenerateStarkKey(crand.Reader)
+	require.NoError(t, err)
+	msgHash := bhex("7ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	sig, err := key.Sign(msgHash)
+	require.NoError(t, err)
+	require.True(t, key.PublicKey().Verify(msgHash, sig))
+	require.False(t, key.PublicKey().Verify(new(big.Int).Sub(msgHash, big.NewInt(1)), sig))
+
+	other, err := GenerateStarkKey(crand.Reader)
+	require.NoError(t, err)
+	require.False(t, other.PublicKey().Verify(msgHash, sig))
+
+	sig2, err := new(StarkSignature).SetBytes(sig.Bytes())
+	require.NoError(t, err)
+	require.True(t, key.PublicKey().Verify(msgHash, sig2))
+
+	// Message hashes must fit in 251 bits
+	tooBig := new(big.Int).Lsh(big.NewInt(1), 251)
+	_, err = key.Sign(tooBig)
+	require.Error(t, err)
+	require.False(t, key.PublicKey().Verify(tooBig, sig))
+	require.False(t, key.PublicKey().Verify(msgHash, nil))
+	require.False(t, key.PublicKey().Verify(msgHash, &StarkSignature{R: sig.R, S: new(ScalarStark).Zero().(*ScalarStark)}))
+
+	_, err = new(StarkSignature).SetBytes(sig.Bytes()[:63])
+	require.Error(t, err)
+}
@@ -0,0 +1,106 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"math/big"
+	"reflect"
+
+	"github.com/mikelodder7/curvey/native"
+)
+
+// VarTimeMultiplier is implemented by every Point in this package.
+// The running time of the methods depends on the scalars so they must only
+// be used with public values, e.g. when verifying signatures.
+// They return nil if the arguments are from a different curve or
+// the number of scalars and points differ.
+type VarTimeMultiplier interface {
+	// VarTimeDoubleScalarBaseMult returns a * capA + b * G where G is the generator
+	VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point
+	// VarTimeMultiScalarMult returns the sum of scalars[i] * points[i]
+	VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point
+}
+
+// varTimeMultiScalarMult computes the sum of scalars[i] * points[i] with the
+// Point methods of p's curve for curves without a specialized implementation.
+// The width native.WnafWidth NAFs of the scalars are interleaved so all
+// the points share the doublings.
+func varTimeMultiScalarMult(p Point, scalars []Scalar, points []Point) Point {
+	if len(scalars) != len(points) {
+		return nil
+	}
+	pointType := reflect.TypeOf(p)
+	scalarType := reflect.TypeOf(p.Scalar())
+	tables := make([][]Point, 0, len(points))
+	digits := make([][]int8, 0, len(points))
+	top := 0
+	for i, pt := range points {
+		if reflect.TypeOf(pt) != pointType || reflect.TypeOf(scalars[i]) != scalarType {
+			return nil
+		}
+		d := native.Wnaf(bigLimbs(scalars[i].BigInt()), native.WnafWidth)
+		if len(d) == 0 || pt.IsIdentity() {
+			continue
+		}
+		// table[j] is (2j + 1) * pt
+		table := make([]Point, 1<<(native.WnafWidth-2))
+		table[0] = pt
+		double := pt.Double()
+		for j := 1; j < len(table); j++ {
+			table[j] = table[j-1].Add(double)
+		}
+		tables = append(tables, table)
+		digits = append(digits, d)
+		if len(d) > top {
+			top = len(d)
+		}
+	}
+
+	// out stays nil until the first addition to skip doubling the identity
+	var out Point
+	for i := top - 1; i >= 0; i-- {
+		if out != nil {
+			out = out.Double()
+		}
+		for j, d := range digits {
+			if i >= len(d) || d[i] == 0 {
+				continue
+			}
+			entry := tables[j][abs8(d[i])>>1]
+			switch {
+			case out == nil && d[i] > 0:
+				out = entry
+			case out == nil:
+				out = entry.Neg()
+			case d[i] > 0:
+				out = out.Add(entry)
+			default:
+				out = out.Sub(entry)
+			}
+		}
+	}
+	if out == nil {
+		return p.Identity()
+	}
+	return out
+}
+
+func abs8(d int8) int8 {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// bigLimbs returns the little endian 64-bit limbs of the non-negative v.
+func bigLimbs(v *big.Int) []uint64 {
+	b := v.Bytes()
+	limbs := make([]uint64, (len(b)+7)/8)
+	for i, c := range b {
+		j := len(b) - 1 - i
+		limbs[j>>3] |= uint64(c) << (8 * (j & 7))
+	}
+	return limbs
+}
@@ -0,0 +1,96 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	crand "crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVarTimeMultiplierCurves(t *testing.T) {
+	for _, name := range RegisteredCurves() {
+		curve := GetCurveByName(name)
+		m, ok := curve.Point.(VarTimeMultiplier)
+		require.True(t, ok, name)
+
+		a := curve.Scalar.Random(crand.Reader)
+		b := curve.Scalar.Random(crand.Reader)
+		capA := curve.Point.Random(crand.Reader)
+		expected := capA.Mul(a).Add(curve.Point.Generator().Mul(b))
+		require.True(t, expected.Equal(m.VarTimeDoubleScalarBaseMult(a, capA, b)), name)
+
+		scalars := []Scalar{
+			curve.Scalar.Random(crand.Reader),
+			curve.Scalar.Zero(),
+			curve.Scalar.One(),
+			curve.Scalar.One().Neg(),
+			curve.Scalar.New(31),
+			curve.Scalar.Random(crand.Reader),
+		}
+		points := make([]Point, len(scalars))
+		for i := range points {
+			points[i] = curve.Point.Random(crand.Reader)
+		}
+		points[5] = curve.Point.Identity()
+		expected = curve.Point.Identity()
+		for i := range points {
+			expected = expected.Add(points[i].Mul(scalars[i]))
+		}
+		require.True(t, expected.Equal(m.VarTimeMultiScalarMult(scalars, points)), name)
+		require.True(t, m.VarTimeMultiScalarMult(nil, nil).IsIdentity(), name)
+		require.True(t, m.VarTimeMultiScalarMult(scalars[1:2], points[:1]).IsIdentity(), name)
+
+		require.Nil(t, m.VarTimeMultiScalarMult(scalars, points[1:]), name)
+		require.Nil(t, m.VarTimeMultiScalarMult([]Scalar{nil}, points[:1]), name)
+		require.Nil(t, m.VarTimeMultiScalarMult(scalars[:1], []Point{nil}), name)
+		require.Nil(t, m.VarTimeDoubleScalarBaseMult(a, nil, b), name)
+	}
+}
+
+func TestVarTimeMultiplierTypeMismatch(t *testing.T) {
+	k256 := K256().Point.(VarTimeMultiplier)
+	require.Nil(t, k256.VarTimeDoubleScalarBaseMult(P256().Scalar.One(), K256().Point.Generator(), K256().Scalar.One()))
+	require.Nil(t, k256.VarTimeDoubleScalarBaseMult(K256().Scalar.One(), P256().Point.Generator(), K256().Scalar.One()))
+	p384 := P384().Point.(VarTimeMultiplier)
+	require.Nil(t, p384.VarTimeDoubleScalarBaseMult(P256().Scalar.One(), P384().Point.Generator(), P384().Scalar.One()))
+	require.Nil(t, p384.VarTimeDoubleScalarBaseMult(P384().Scalar.One(), P256().Point.Generator(), P384().Scalar.One()))
+}
+
+func TestVarTimeMultiplierGt(t *testing.T) {
+	g1 := BLS12381G1().Point.Generator().(*PointBls12381G1)
+	g2 := BLS12381G2().Point.Generator().(*PointBls12381G2)
+	gt := &PointBls12381Gt{g1.Pairing(g2).(*ScalarBls12381Gt).Value}
+	a := BLS12381G1().Scalar.Random(crand.Reader)
+	b := BLS12381G1().Scalar.Random(crand.Reader)
+	c, err := BLS12381G1().Scalar.SetBigInt(big.NewInt(12345))
+	require.NoError(t, err)
+	points := []Point{gt, gt.Double(), gt.Mul(c)}
+	scalars := []Scalar{a, b, c}
+	expected := gt.Mul(a).Add(points[1].Mul(b)).Add(points[2].Mul(c))
+	require.True(t, expected.Equal(gt.VarTimeMultiScalarMult(scalars, points)))
+	require.True(t, gt.Mul(a).Equal(gt.VarTimeDoubleScalarBaseMult(a, gt, BLS12381G1().Scalar.Zero())))
+}
+
+func BenchmarkVarTimeDoubleScalarBaseMult(b *testing.B) {
+	for _, curve := range []*Curve{K256(), P256(), P384(), PALLAS(), BLS12381G1(), BLS12381G2()} {
+		a := curve.Scalar.Random(crand.Reader)
+		s := curve.Scalar.Random(crand.Reader)
+		capA := curve.Point.Random(crand.Reader)
+		b.Run(curve.Name+"/mul", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				capA.Mul(a).Add(curve.Point.Generator().Mul(s))
+			}
+		})
+		b.Run(curve.Name+"/vartime", func(b *testing.B) {
+			m := curve.Point.(VarTimeMultiplier)
+			for i := 0; i < b.N; i++ {
+				m.VarTimeDoubleScalarBaseMult(a, capA, s)
+			}
+		})
+	}
+}
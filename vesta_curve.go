@@ -0,0 +1,655 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/mikelodder7/curvey/native"
+	"github.com/mikelodder7/curvey/native/pasta"
+	"github.com/mikelodder7/curvey/native/pasta/fp"
+	"github.com/mikelodder7/curvey/native/pasta/fq"
+)
+
+// ScalarVesta is an element of the vesta scalar field
+// which is the same field as the pallas base field.
+type ScalarVesta struct {
+	Value *native.Field4
+}
+
+func (s *ScalarVesta) Random(reader io.Reader) Scalar {
+	if reader == nil {
+		return nil
+	}
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return s.Hash(seed[:])
+}
+
+func (*ScalarVesta) Hash(bytes []byte) Scalar {
+	dst := []byte("vesta_XMD:BLAKE2b_SSWU_RO_")
+	xmd := native.ExpandMsgXmd(native.EllipticPointHasherBlake2b(), bytes, dst, 64)
+	var t [64]byte
+	copy(t[:], xmd)
+	return &ScalarVesta{
+		Value: fp.PastaFpNew().SetBytesWide(&t),
+	}
+}
+
+func (*ScalarVesta) Zero() Scalar {
+	return &ScalarVesta{
+		Value: fp.PastaFpNew().SetZero(),
+	}
+}
+
+func (*ScalarVesta) One() Scalar {
+	return &ScalarVesta{
+		Value: fp.PastaFpNew().SetOne(),
+	}
+}
+
+func (s *ScalarVesta) IsZero() bool {
+	return s.Value.IsZero() == 1
+}
+
+func (s *ScalarVesta) IsOne() bool {
+	return s.Value.IsOne() == 1
+}
+
+func (s *ScalarVesta) IsOdd() bool {
+	return (s.Value.Bytes()[0] & 1) == 1
+}
+
+func (s *ScalarVesta) IsEven() bool {
+	return (s.Value.Bytes()[0] & 1) == 0
+}
+
+func (*ScalarVesta) New(value int) Scalar {
+	v := big.NewInt(int64(value))
+	return &ScalarVesta{
+		Value: fp.PastaFpNew().SetBigInt(v),
+	}
+}
+
+func (s *ScalarVesta) Cmp(rhs Scalar) int {
+	r, ok := rhs.(*ScalarVesta)
+	if ok {
+		return s.Value.Cmp(r.Value)
+	} else {
+		return -2
+	}
+}
+
+func (s *ScalarVesta) Square() Scalar {
+	return &ScalarVesta{
+		Value: fp.PastaFpNew().Square(s.Value),
+	}
+}
+
+func (s *ScalarVesta) Pow(exp uint64) Scalar {
+	expFieldLimb := [native.Field4Limbs]uint64{exp, 0, 0, 0}
+	out := ScalarVesta{Value: fp.PastaFpNew()}
+	native.Pow(&out.Value.Value, &s.Value.Value, &expFieldLimb, s.Value.Params, s.Value.Arithmetic)
+	return &ScalarVesta{
+		Value: out.Value,
+	}
+}
+
+func (s *ScalarVesta) Double() Scalar {
+	return &ScalarVesta{
+		Value: fp.PastaFpNew().Double(s.Value),
+	}
+}
+
+func (s *ScalarVesta) Invert() (Scalar, error) {
+	value, wasInverted := fp.PastaFpNew().Invert(s.Value)
+	if !wasInverted {
+		return nil, fmt.Errorf("inverse doesn't exist")
+	}
+	return &ScalarVesta{
+		value,
+	}, nil
+}
+
+func (s *ScalarVesta) Sqrt() (Scalar, error) {
+	value, wasSquare := fp.PastaFpNew().Sqrt(s.Value)
+	if !wasSquare {
+		return nil, fmt.Errorf("not a square")
+	}
+	return &ScalarVesta{
+		value,
+	}, nil
+}
+
+func (s *ScalarVesta) Cube() Scalar {
+	value := fp.PastaFpNew().Square(s.Value)
+	value.Mul(value, s.Value)
+	return &ScalarVesta{
+		value,
+	}
+}
+
+func (s *ScalarVesta) Add(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarVesta)
+	if ok {
+		return &ScalarVesta{
+			Value: fp.PastaFpNew().Add(s.Value, r.Value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarVesta) Sub(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarVesta)
+	if ok {
+		return &ScalarVesta{
+			Value: fp.PastaFpNew().Sub(s.Value, r.Value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarVesta) Mul(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarVesta)
+	if ok {
+		return &ScalarVesta{
+			Value: fp.PastaFpNew().Mul(s.Value, r.Value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarVesta) MulAdd(y, z Scalar) Scalar {
+	return s.Mul(y).Add(z)
+}
+
+func (s *ScalarVesta) Div(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarVesta)
+	if ok {
+		v, wasInverted := fp.PastaFpNew().Invert(r.Value)
+		if !wasInverted {
+			return nil
+		}
+		v.Mul(v, s.Value)
+		return &ScalarVesta{Value: v}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarVesta) Neg() Scalar {
+	return &ScalarVesta{
+		Value: fp.PastaFpNew().Neg(s.Value),
+	}
+}
+
+func (*ScalarVesta) SetBigInt(v *big.Int) (Scalar, error) {
+	return &ScalarVesta{
+		Value: fp.PastaFpNew().SetBigInt(v),
+	}, nil
+}
+
+func (s *ScalarVesta) BigInt() *big.Int {
+	return s.Value.BigInt()
+}
+
+func (s *ScalarVesta) Bytes() []byte {
+	t := s.Value.Bytes()
+	return t[:]
+}
+
+func (*ScalarVesta) SetBytes(bytes []byte) (Scalar, error) {
+	if len(bytes) != 32 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [32]byte
+	copy(seq[:], bytes)
+	value, err := fp.PastaFpNew().SetBytes(&seq)
+	if err != nil {
+		return nil, err
+	}
+	return &ScalarVesta{
+		value,
+	}, nil
+}
+
+func (*ScalarVesta) SetBytesWide(bytes []byte) (Scalar, error) {
+	if len(bytes) != 64 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [64]byte
+	copy(seq[:], bytes)
+	return &ScalarVesta{
+		Value: fp.PastaFpNew().SetBytesWide(&seq),
+	}, nil
+}
+
+func (*ScalarVesta) Point() Point {
+	return new(PointVesta).Identity()
+}
+
+func (s *ScalarVesta) Clone() Scalar {
+	return &ScalarVesta{
+		Value: fp.PastaFpNew().Set(s.Value),
+	}
+}
+
+func (s *ScalarVesta) MarshalBinary() ([]byte, error) {
+	return ScalarMarshalBinary(s)
+}
+
+func (s *ScalarVesta) UnmarshalBinary(input []byte) error {
+	sc, err := ScalarUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarVesta)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.Value = ss.Value
+	return nil
+}
+
+func (s *ScalarVesta) MarshalText() ([]byte, error) {
+	return ScalarMarshalText(s)
+}
+
+func (s *ScalarVesta) UnmarshalText(input []byte) error {
+	sc, err := ScalarUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarVesta)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.Value = ss.Value
+	return nil
+}
+
+func (s *ScalarVesta) MarshalJSON() ([]byte, error) {
+	return ScalarMarshalJSON(s)
+}
+
+func (s *ScalarVesta) UnmarshalJSON(input []byte) error {
+	sc, err := ScalarUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	S, ok := sc.(*ScalarVesta)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	s.Value = S.Value
+	return nil
+}
+
+// PointVesta is a point on the vesta curve, the cycle partner of pallas.
+type PointVesta struct {
+	*native.EllipticPoint4
+}
+
+func (p *PointVesta) Random(reader io.Reader) Point {
+	var seed [2 * native.Field4Bytes]byte
+	n, err := reader.Read(seed[:])
+	if err != nil {
+		return nil
+	}
+	if n != 2*native.Field4Bytes {
+		return nil
+	}
+	return p.Hash(seed[:])
+}
+
+func (*PointVesta) Hash(bytes []byte) Point {
+	value, err := pasta.VestaPointNew().Hash(bytes, native.EllipticPointHasherBlake2b())
+	if err != nil {
+		return nil
+	}
+	return &PointVesta{value}
+}
+
+func (*PointVesta) Identity() Point {
+	return &PointVesta{pasta.VestaPointNew().Identity()}
+}
+
+func (*PointVesta) Generator() Point {
+	return &PointVesta{pasta.VestaPointNew().Generator()}
+}
+
+func (p *PointVesta) IsNegative() bool {
+	return p.GetY().Bytes()[0]&1 == 1
+}
+
+func (p *PointVesta) Double() Point {
+	return &PointVesta{pasta.VestaPointNew().Double(p.EllipticPoint4)}
+}
+
+func (*PointVesta) Scalar() Scalar {
+	return &ScalarVesta{fp.PastaFpNew().SetZero()}
+}
+
+func (p *PointVesta) Neg() Point {
+	return &PointVesta{pasta.VestaPointNew().Neg(p.EllipticPoint4)}
+}
+
+func (p *PointVesta) Add(rhs Point) Point {
+	r, ok := rhs.(*PointVesta)
+	if !ok {
+		return nil
+	}
+	return &PointVesta{pasta.VestaPointNew().Add(p.EllipticPoint4, r.EllipticPoint4)}
+}
+
+func (p *PointVesta) Sub(rhs Point) Point {
+	r, ok := rhs.(*PointVesta)
+	if !ok {
+		return nil
+	}
+	return &PointVesta{pasta.VestaPointNew().Sub(p.EllipticPoint4, r.EllipticPoint4)}
+}
+
+func (p *PointVesta) Mul(rhs Scalar) Point {
+	s, ok := rhs.(*ScalarVesta)
+	if !ok {
+		return nil
+	}
+	return &PointVesta{pasta.VestaPointNew().Mul(p.EllipticPoint4, s.Value)}
+}
+
+func (*PointVesta) selectPoint(row []Point, index int) Point {
+	value := pasta.VestaPointNew().Identity()
+	for i, pt := range row {
+		value.CMove(value, pt.(*PointVesta).EllipticPoint4, ctEqual(i+1, index))
+	}
+	return &PointVesta{value}
+}
+
+func (p *PointVesta) Equal(rhs Point) bool {
+	r, ok := rhs.(*PointVesta)
+	if !ok {
+		return false
+	}
+	var x1, x2, y1, y2, z1, z2 [native.Field4Limbs]uint64
+
+	u := p.EllipticPoint4.X.Arithmetic
+
+	u.Square(&z1, &p.Z.Value)
+	u.Square(&z2, &r.Z.Value)
+
+	u.Mul(&x1, &p.EllipticPoint4.X.Value, &z2)
+	u.Mul(&x2, &r.EllipticPoint4.X.Value, &z1)
+
+	u.Mul(&z1, &z1, &p.Z.Value)
+	u.Mul(&z2, &z2, &r.Z.Value)
+
+	u.Mul(&y1, &p.EllipticPoint4.Y.Value, &z2)
+	u.Mul(&y2, &r.EllipticPoint4.Y.Value, &z1)
+
+	e1 := p.Z.IsZero()
+	e2 := r.Z.IsZero()
+
+	tx := (x1[0] ^ x2[0]) | (x1[1] ^ x2[1]) | (x1[2] ^ x2[2]) | (x1[3] ^ x2[3])
+	ty := (y1[0] ^ y2[0]) | (y1[1] ^ y2[1]) | (y1[2] ^ y2[2]) | (y1[3] ^ y2[3])
+
+	e3 := int(((int64(tx) | int64(-tx)) >> 63) + 1)
+	e4 := int(((int64(ty) | int64(-ty)) >> 63) + 1)
+
+	// Both at infinity or coordinates are the same
+	return (e1&e2)|(^e1 & ^e2)&e3&e4 == 1
+}
+
+func (*PointVesta) Set(x, y *big.Int) (Point, error) {
+	value, err := pasta.VestaPointNew().SetBigInt(x, y)
+	if err != nil {
+		return nil, err
+	}
+	return &PointVesta{value}, nil
+}
+
+func (p *PointVesta) ToAffineCompressed() []byte {
+	// Use ZCash encoding where infinity is all zeros
+	// and the top bit represents the sign of y and the
+	// remainder represent the x-coordinate
+	var inf [32]byte
+	p1 := pasta.VestaPointNew().ToAffine(p.EllipticPoint4)
+	x := p1.X.Bytes()
+	x[31] |= (p1.Y.Bytes()[0] & 1) << 7
+
+	subtle.ConstantTimeCopy(p1.Z.IsZero(), x[:], inf[:])
+	return x[:]
+}
+
+func (p *PointVesta) ToAffineUncompressed() []byte {
+	p1 := pasta.VestaPointNew().ToAffine(p.EllipticPoint4)
+	x := p1.X.Bytes()
+	y := p1.Y.Bytes()
+	return append(x[:], y[:]...)
+}
+
+func (p *PointVesta) FromAffineCompressed(bytes []byte) (Point, error) {
+	if len(bytes) != 32 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+
+	var input [32]byte
+	copy(input[:], bytes)
+	sign := (input[31] >> 7) & 1 // nolint:ifshort // false positive
+	input[31] &= 0x7F
+
+	x := fq.PastaFqNew()
+	if _, err := x.SetBytes(&input); err != nil {
+		return nil, err
+	}
+	rhs := fq.PastaFqNew()
+	p.Arithmetic.RhsEquation(rhs, x)
+	if _, square := rhs.Sqrt(rhs); !square {
+		return nil, fmt.Errorf("rhs of given x-coordinate is not a square")
+	}
+	if rhs.Bytes()[0]&1 != sign {
+		rhs.Neg(rhs)
+	}
+	value := pasta.VestaPointNew()
+	value.X = x
+	value.Y = rhs
+	value.Z.SetOne()
+	if !value.IsOnCurve() {
+		return nil, fmt.Errorf("invalid point")
+	}
+	return &PointVesta{value}, nil
+}
+
+func (*PointVesta) FromAffineUncompressed(bytes []byte) (Point, error) {
+	if len(bytes) != 64 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	value := pasta.VestaPointNew()
+	value.Z.SetOne()
+	var x, y [32]byte
+	copy(x[:], bytes[:32])
+	copy(y[:], bytes[32:])
+	if _, err := value.X.SetBytes(&x); err != nil {
+		return nil, err
+	}
+	if _, err := value.Y.SetBytes(&y); err != nil {
+		return nil, err
+	}
+	if !value.IsOnCurve() {
+		return nil, fmt.Errorf("invalid point")
+	}
+	return &PointVesta{value}, nil
+}
+
+func (*PointVesta) CurveName() string {
+	return VestaName
+}
+
+func (p *PointVesta) SumOfProducts(points []Point, scalars []Scalar) Point {
+	eps := make([]*native.EllipticPoint4, len(points))
+	for i, pt := range points {
+		ps, ok := pt.(*PointVesta)
+		if !ok {
+			return nil
+		}
+		eps[i] = ps.EllipticPoint4
+	}
+	scs := make([]*native.Field4, len(scalars))
+	for i, sc := range scalars {
+		ss, ok := sc.(*ScalarVesta)
+		if !ok {
+			return nil
+		}
+		scs[i] = ss.Value
+	}
+	value, err := p.EllipticPoint4.SumOfProducts(eps, scs)
+	if err != nil {
+		return nil
+	}
+	return &PointVesta{value}
+}
+
+func (*PointVesta) sumOfProductsVarTime(points []Point, scalars []Scalar, opts *MsmOptions) Point {
+	eps := make([]*native.EllipticPoint4, len(points))
+	for i, pt := range points {
+		ps, ok := pt.(*PointVesta)
+		if !ok {
+			return nil
+		}
+		eps[i] = ps.EllipticPoint4
+	}
+	scs := make([]*native.Field4, len(scalars))
+	for i, sc := range scalars {
+		ss, ok := sc.(*ScalarVesta)
+		if !ok {
+			return nil
+		}
+		scs[i] = ss.Value
+	}
+	value, err := pasta.VestaPointNew().MsmVarTime(eps, scs, opts)
+	if err != nil {
+		return nil
+	}
+	return &PointVesta{value}
+}
+
+func (p *PointVesta) VarTimeDoubleScalarBaseMult(a Scalar, capA Point, b Scalar) Point {
+	return p.VarTimeMultiScalarMult([]Scalar{a, b}, []Point{capA, p.Generator()})
+}
+
+func (p *PointVesta) VarTimeMultiScalarMult(scalars []Scalar, points []Point) Point {
+	return p.sumOfProductsVarTime(points, scalars, nil)
+}
+
+func (*PointVesta) batchNormalize(points []Point) []Point {
+	nPoints := make([]*native.EllipticPoint4, len(points))
+	for i, pt := range points {
+		ptv, ok := pt.(*PointVesta)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = ptv.EllipticPoint4
+	}
+	out := make([]Point, len(points))
+	for i, value := range native.BatchToAffine(nPoints) {
+		out[i] = &PointVesta{value}
+	}
+	return out
+}
+
+func (p *PointVesta) MarshalBinary() ([]byte, error) {
+	return PointMarshalBinary(p)
+}
+
+func (p *PointVesta) UnmarshalBinary(input []byte) error {
+	pt, err := PointUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointVesta)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.EllipticPoint4 = ppt.EllipticPoint4
+	return nil
+}
+
+func (p *PointVesta) MarshalText() ([]byte, error) {
+	return PointMarshalText(p)
+}
+
+func (p *PointVesta) UnmarshalText(input []byte) error {
+	pt, err := PointUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointVesta)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.EllipticPoint4 = ppt.EllipticPoint4
+	return nil
+}
+
+func (p *PointVesta) MarshalJSON() ([]byte, error) {
+	return PointMarshalJSON(p)
+}
+
+func (p *PointVesta) UnmarshalJSON(input []byte) error {
+	pt, err := PointUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	P, ok := pt.(*PointVesta)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	p.EllipticPoint4 = P.EllipticPoint4
+	return nil
+}
+
+func (p *PointVesta) X() *native.Field4 {
+	return p.GetX()
+}
+
+func (p *PointVesta) Y() *native.Field4 {
+	return p.GetY()
+}
+
+// PallasBase reinterprets the scalar as an element of the pallas base field.
+func (s *ScalarVesta) PallasBase() *native.Field4 {
+	return fp.PastaFpNew().Set(s.Value)
+}
+
+// SetPallasBase reinterprets a pallas base field element as a vesta scalar.
+func (*ScalarVesta) SetPallasBase(f *native.Field4) (*ScalarVesta, error) {
+	if f == nil || f.Params.Modulus != fp.GetPastaFpParams().Modulus {
+		return nil, fmt.Errorf("invalid field element")
+	}
+	return &ScalarVesta{
+		Value: fp.PastaFpNew().Set(f),
+	}, nil
+}
+
+// VestaBase reinterprets the scalar as an element of the vesta base field.
+func (s *ScalarPallas) VestaBase() *native.Field4 {
+	return fq.PastaFqNew().Set(s.Value)
+}
+
+// SetVestaBase reinterprets a vesta base field element as a pallas scalar.
+func (*ScalarPallas) SetVestaBase(f *native.Field4) (*ScalarPallas, error) {
+	if f == nil || f.Params.Modulus != fq.PastaFqNew().Params.Modulus {
+		return nil, fmt.Errorf("invalid field element")
+	}
+	return &ScalarPallas{
+		Value: fq.PastaFqNew().Set(f),
+	}, nil
+}
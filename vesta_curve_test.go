@@ -0,0 +1,136 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	crand "crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikelodder7/curvey/native/pasta/fp"
+	"github.com/mikelodder7/curvey/native/pasta/fq"
+)
+
+func TestPointVestaAddDoubleMul(t *testing.T) {
+	curve := VESTA()
+	g := curve.NewGeneratorPoint()
+	id := curve.NewIdentityPoint()
+	require.True(t, g.Add(id).Equal(g))
+	require.True(t, g.IsOnCurve())
+
+	g2 := g.Add(g)
+	require.True(t, g.Double().Equal(g2))
+	g3 := g.Add(g2)
+	s := curve.NewScalar().New(3).(*ScalarVesta)
+	require.True(t, g3.Equal(g.Mul(s)))
+
+	s.Value.SetUint64(4)
+	g4 := g3.Add(g)
+	require.True(t, g4.Equal(g2.Double()))
+	require.True(t, g4.Equal(g.Mul(s)))
+
+	require.True(t, g.Mul(curve.NewScalar().New(-1)).Equal(g.Neg()))
+	require.True(t, g.Sub(g).IsIdentity())
+}
+
+func TestPointVestaGenerator(t *testing.T) {
+	g := VESTA().NewGeneratorPoint().(*PointVesta)
+	x, y := g.BigInt()
+	require.Equal(t, 0, x.Cmp(big.NewInt(1)))
+	require.Equal(t, 0, y.Cmp(bhex("1943666ea922ae6b13b64e3aae89754cacce3a7f298ba20c4e4389b9b0276a62")))
+}
+
+func TestPointVestaHash(t *testing.T) {
+	curve := VESTA()
+	h0 := curve.Point.Hash(nil)
+	require.True(t, h0.IsOnCurve())
+	h1 := curve.Point.Hash([]byte{})
+	require.True(t, h1.IsOnCurve())
+	require.True(t, h0.Equal(h1))
+	h2 := curve.Point.Hash([]byte{1})
+	require.True(t, h2.IsOnCurve())
+	require.False(t, h0.Equal(h2))
+	require.False(t, h2.IsIdentity())
+}
+
+func TestPointVestaNeg(t *testing.T) {
+	curve := VESTA()
+	g := curve.NewGeneratorPoint().Neg()
+	require.True(t, g.Neg().Equal(curve.NewGeneratorPoint()))
+	id := curve.NewIdentityPoint()
+	require.True(t, id.Neg().Equal(id))
+}
+
+func TestPointVestaSerialize(t *testing.T) {
+	curve := VESTA()
+	g := curve.NewGeneratorPoint()
+
+	for i := 0; i < 25; i++ {
+		s := curve.Scalar.Random(crand.Reader).(*ScalarVesta)
+		pt := g.Mul(s)
+		cmprs := pt.ToAffineCompressed()
+		require.Equal(t, len(cmprs), 32)
+		retC, err := curve.Point.FromAffineCompressed(cmprs)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(retC))
+
+		un := pt.ToAffineUncompressed()
+		require.Equal(t, len(un), 64)
+		retU, err := curve.Point.FromAffineUncompressed(un)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(retU))
+	}
+
+	// A pallas point is not on vesta
+	pallas := PALLAS().NewGeneratorPoint().Mul(PALLAS().NewScalar().New(7))
+	_, err := curve.Point.FromAffineUncompressed(pallas.ToAffineUncompressed())
+	require.Error(t, err)
+}
+
+func TestPointVestaSumOfProducts(t *testing.T) {
+	curve := VESTA()
+	s := curve.NewScalar().New(50)
+	lhs := curve.ScalarBaseMult(s)
+	points := make([]Point, 5)
+	for i := range points {
+		points[i] = curve.NewGeneratorPoint().(*PointVesta)
+	}
+	scalars := []Scalar{
+		new(ScalarVesta).New(8),
+		new(ScalarVesta).New(9),
+		new(ScalarVesta).New(10),
+		new(ScalarVesta).New(11),
+		new(ScalarVesta).New(12),
+	}
+	rhs := lhs.SumOfProducts(points, scalars)
+	require.NotNil(t, rhs)
+	require.True(t, lhs.Equal(rhs))
+}
+
+func TestScalarVestaPallasCycle(t *testing.T) {
+	// The x-coordinate of a pallas point is a vesta scalar
+	pt := PALLAS().Point.Random(crand.Reader).(*PointPallas)
+	sc, err := new(ScalarVesta).SetPallasBase(pt.X())
+	require.NoError(t, err)
+	require.Equal(t, 0, sc.BigInt().Cmp(pt.X().BigInt()))
+	require.Equal(t, 1, sc.PallasBase().Equal(pt.X()))
+
+	// The x-coordinate of a vesta point is a pallas scalar
+	vpt := VESTA().Point.Random(crand.Reader).(*PointVesta)
+	psc, err := new(ScalarPallas).SetVestaBase(vpt.X())
+	require.NoError(t, err)
+	require.Equal(t, 0, psc.BigInt().Cmp(vpt.X().BigInt()))
+	require.Equal(t, 1, psc.VestaBase().Equal(vpt.X()))
+
+	// Fields from the wrong curve are rejected
+	_, err = new(ScalarVesta).SetPallasBase(fq.PastaFqNew().SetOne())
+	require.Error(t, err)
+	_, err = new(ScalarPallas).SetVestaBase(fp.PastaFpNew().SetOne())
+	require.Error(t, err)
+
+	require.Equal(t, VestaName, GetCurveByName(VestaName).Name)
+}
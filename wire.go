@@ -0,0 +1,436 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// CurveID is the compact identifier of a curve in the binary wire format.
+type CurveID uint16
+
+// The identifiers of the built-in curves. These are part of the wire
+// format and must never change.
+const (
+	K256ID            CurveID = 1
+	BLS12381G1ID      CurveID = 2
+	BLS12381G2ID      CurveID = 3
+	P256ID            CurveID = 4
+	P384ID            CurveID = 5
+	P521ID            CurveID = 6
+	ED25519ID         CurveID = 7
+	ED448ID           CurveID = 8
+	PallasID          CurveID = 9
+	VestaID           CurveID = 10
+	Ristretto25519ID  CurveID = 11
+	Decaf448ID        CurveID = 12
+	JubjubID          CurveID = 13
+	BN254G1ID         CurveID = 14
+	BN254G2ID         CurveID = 15
+	BLS12377G1ID      CurveID = 16
+	BLS12377G2ID      CurveID = 17
+	BrainpoolP256r1ID CurveID = 18
+	BrainpoolP384r1ID CurveID = 19
+	BrainpoolP512r1ID CurveID = 20
+	Sm2ID             CurveID = 21
+	Secq256k1ID       CurveID = 22
+	GrumpkinID        CurveID = 23
+	BandersnatchID    CurveID = 24
+	BabyJubjubID      CurveID = 25
+	StarkID           CurveID = 26
+
+	// MaxCurveID is the largest identifier that fits in the
+	// two byte varint used by the wire format.
+	MaxCurveID CurveID = 1<<14 - 1
+)
+
+// The binary envelope is
+//
+//	version | tag | uvarint(curve id) | uvarint(length) | value
+//
+// and for vectors
+//
+//	version | tag | uvarint(curve id) | uvarint(count) | uvarint(length) | count values
+//
+// The version byte is never a printable character so the
+// envelope is distinguished from the legacy "name:value" format.
+const (
+	wireVersion1 byte = 1
+
+	wireTagScalar       byte = 1
+	wireTagPoint        byte = 2
+	wireTagScalarVector byte = 3
+	wireTagPointVector  byte = 4
+
+	// wireMaxLength bounds the length of a single encoded value
+	// which is far larger than any supported curve needs
+	wireMaxLength = 1024
+)
+
+func appendWireHeader(out []byte, tag byte, id CurveID) []byte {
+	out = append(out, wireVersion1, tag)
+	return binary.AppendUvarint(out, uint64(id))
+}
+
+func appendWireValue(tag byte, id CurveID, value []byte) []byte {
+	out := make([]byte, 0, len(value)+2*binary.MaxVarintLen16+2)
+	out = appendWireHeader(out, tag, id)
+	out = binary.AppendUvarint(out, uint64(len(value)))
+	return append(out, value...)
+}
+
+func isWireEnvelope(input []byte) bool {
+	return len(input) > 0 && input[0] == wireVersion1
+}
+
+// readWireHeader reads and checks the version and tag then returns
+// the curve that the identifier is registered to.
+func readWireHeader(r io.ByteReader, tag byte) (*Curve, error) {
+	id, err := readWirePrefix(r, tag)
+	if err != nil {
+		return nil, err
+	}
+	return wireCurve(id)
+}
+
+// readWirePrefix reads and checks the version and tag
+// then returns the unchecked curve identifier.
+func readWirePrefix(r io.ByteReader, tag byte) (uint64, error) {
+	version, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	if version != wireVersion1 {
+		return 0, fmt.Errorf("unsupported wire format version %d", version)
+	}
+	t, err := r.ReadByte()
+	if err != nil {
+		return 0, unexpectedEOF(err)
+	}
+	if t != tag {
+		return 0, fmt.Errorf("unexpected wire format tag %d", t)
+	}
+	id, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, unexpectedEOF(err)
+	}
+	return id, nil
+}
+
+func wireCurve(id uint64) (*Curve, error) {
+	if id == 0 || id > uint64(MaxCurveID) {
+		return nil, fmt.Errorf("invalid curve id %d", id)
+	}
+	curve := GetCurveByID(CurveID(id))
+	if curve == nil {
+		return nil, fmt.Errorf("unrecognized curve id %d", id)
+	}
+	return curve, nil
+}
+
+func readWireLength(r io.ByteReader) (int, error) {
+	length, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, unexpectedEOF(err)
+	}
+	if length > wireMaxLength {
+		return 0, fmt.Errorf("invalid value length %d", length)
+	}
+	return int(length), nil
+}
+
+// readWireValue reads a single value envelope from input which
+// must not contain any trailing bytes.
+func readWireValue(input []byte, tag byte) (*Curve, []byte, error) {
+	r := &sliceReader{input: input}
+	curve, err := readWireHeader(r, tag)
+	if err != nil {
+		return nil, nil, err
+	}
+	length, err := readWireLength(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(r.input) != length {
+		return nil, nil, fmt.Errorf("invalid byte sequence")
+	}
+	return curve, r.input, nil
+}
+
+// pointWireID returns the identifier used to encode point
+// and whether the compact encoding is available for it.
+func pointWireID(point Point) (CurveID, bool) {
+	return GetCurveID(point.CurveName())
+}
+
+// Encoder writes points and scalars to a stream
+// using the compact binary envelope.
+type Encoder struct {
+	w io.Writer
+}
+
+// NewEncoder returns an encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w}
+}
+
+// EncodePoint writes a point in compressed form.
+func (e *Encoder) EncodePoint(point Point) error {
+	if point == nil {
+		return fmt.Errorf("point cannot be nil")
+	}
+	id, ok := pointWireID(point)
+	if !ok {
+		return fmt.Errorf("curve '%s' has no wire id", point.CurveName())
+	}
+	_, err := e.w.Write(appendWireValue(wireTagPoint, id, point.ToAffineCompressed()))
+	return err
+}
+
+// EncodeScalar writes a scalar.
+func (e *Encoder) EncodeScalar(scalar Scalar) error {
+	if scalar == nil {
+		return fmt.Errorf("scalar cannot be nil")
+	}
+	id, ok := pointWireID(scalar.Point())
+	if !ok {
+		return fmt.Errorf("curve '%s' has no wire id", scalar.Point().CurveName())
+	}
+	_, err := e.w.Write(appendWireValue(wireTagScalar, id, scalar.Bytes()))
+	return err
+}
+
+// EncodePoints writes a vector of points which must all belong to the same curve.
+// The points are written one at a time after the header.
+func (e *Encoder) EncodePoints(points []Point) error {
+	values := make([]func() []byte, len(points))
+	names := make([]string, len(points))
+	for i, p := range points {
+		if p == nil {
+			return fmt.Errorf("point cannot be nil")
+		}
+		values[i] = p.ToAffineCompressed
+		names[i] = p.CurveName()
+	}
+	return e.encodeVector(wireTagPointVector, names, values)
+}
+
+// EncodeScalars writes a vector of scalars which must all belong to the same curve.
+// The scalars are written one at a time after the header.
+func (e *Encoder) EncodeScalars(scalars []Scalar) error {
+	values := make([]func() []byte, len(scalars))
+	names := make([]string, len(scalars))
+	for i, s := range scalars {
+		if s == nil {
+			return fmt.Errorf("scalar cannot be nil")
+		}
+		values[i] = s.Bytes
+		names[i] = s.Point().CurveName()
+	}
+	return e.encodeVector(wireTagScalarVector, names, values)
+}
+
+func (e *Encoder) encodeVector(tag byte, names []string, values []func() []byte) error {
+	if len(values) == 0 {
+		// An empty vector has no curve
+		_, err := e.w.Write([]byte{wireVersion1, tag, 0, 0, 0})
+		return err
+	}
+	for _, n := range names[1:] {
+		if n != names[0] {
+			return fmt.Errorf("vector contains values from different curves")
+		}
+	}
+	id, ok := GetCurveID(names[0])
+	if !ok {
+		return fmt.Errorf("curve '%s' has no wire id", names[0])
+	}
+	first := values[0]()
+	header := appendWireHeader(nil, tag, id)
+	header = binary.AppendUvarint(header, uint64(len(values)))
+	header = binary.AppendUvarint(header, uint64(len(first)))
+	if _, err := e.w.Write(header); err != nil {
+		return err
+	}
+	if _, err := e.w.Write(first); err != nil {
+		return err
+	}
+	for _, v := range values[1:] {
+		b := v()
+		if len(b) != len(first) {
+			return fmt.Errorf("vector contains values of different lengths")
+		}
+		if _, err := e.w.Write(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Decoder reads points and scalars written by an Encoder from a stream.
+// It never reads past the end of the value being decoded.
+type Decoder struct {
+	r byteReader
+}
+
+type byteReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// NewDecoder returns a decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	if br, ok := r.(byteReader); ok {
+		return &Decoder{br}
+	}
+	return &Decoder{&singleByteReader{r: r}}
+}
+
+// DecodePoint reads a point.
+func (d *Decoder) DecodePoint() (Point, error) {
+	curve, err := readWireHeader(d.r, wireTagPoint)
+	if err != nil {
+		return nil, err
+	}
+	value, err := d.readValue()
+	if err != nil {
+		return nil, err
+	}
+	return curve.Point.FromAffineCompressed(value)
+}
+
+// DecodeScalar reads a scalar.
+func (d *Decoder) DecodeScalar() (Scalar, error) {
+	curve, err := readWireHeader(d.r, wireTagScalar)
+	if err != nil {
+		return nil, err
+	}
+	value, err := d.readValue()
+	if err != nil {
+		return nil, err
+	}
+	return curve.Scalar.SetBytes(value)
+}
+
+// DecodePoints reads a vector of points.
+func (d *Decoder) DecodePoints() ([]Point, error) {
+	curve, count, length, err := d.readVectorHeader(wireTagPointVector)
+	if err != nil || curve == nil {
+		return nil, err
+	}
+	out := make([]Point, 0)
+	for i := uint64(0); i < count; i++ {
+		// Each element gets its own buffer as curves may keep their input
+		buffer := make([]byte, length)
+		if _, err = io.ReadFull(d.r, buffer); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		p, err := curve.Point.FromAffineCompressed(buffer)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+// DecodeScalars reads a vector of scalars.
+func (d *Decoder) DecodeScalars() ([]Scalar, error) {
+	curve, count, length, err := d.readVectorHeader(wireTagScalarVector)
+	if err != nil || curve == nil {
+		return nil, err
+	}
+	out := make([]Scalar, 0)
+	for i := uint64(0); i < count; i++ {
+		buffer := make([]byte, length)
+		if _, err = io.ReadFull(d.r, buffer); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		s, err := curve.Scalar.SetBytes(buffer)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+func (d *Decoder) readValue() ([]byte, error) {
+	length, err := readWireLength(d.r)
+	if err != nil {
+		return nil, err
+	}
+	value := make([]byte, length)
+	if _, err = io.ReadFull(d.r, value); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return value, nil
+}
+
+// readVectorHeader returns a nil curve and no error for an empty vector.
+func (d *Decoder) readVectorHeader(tag byte) (*Curve, uint64, int, error) {
+	id, err := readWirePrefix(d.r, tag)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	count, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		return nil, 0, 0, unexpectedEOF(err)
+	}
+	length, err := readWireLength(d.r)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if id == 0 && count == 0 && length == 0 {
+		return nil, 0, 0, nil
+	}
+	curve, err := wireCurve(id)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return curve, count, length, nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// sliceReader is an io.ByteReader over a byte slice
+// that leaves the unread bytes in input.
+type sliceReader struct {
+	input []byte
+}
+
+func (s *sliceReader) ReadByte() (byte, error) {
+	if len(s.input) == 0 {
+		return 0, io.EOF
+	}
+	b := s.input[0]
+	s.input = s.input[1:]
+	return b, nil
+}
+
+// singleByteReader adds io.ByteReader to a reader without buffering
+// so the decoder never consumes bytes beyond the current value.
+type singleByteReader struct {
+	r   io.Reader
+	buf [1]byte
+}
+
+func (s *singleByteReader) Read(p []byte) (int, error) {
+	return s.r.Read(p)
+}
+
+func (s *singleByteReader) ReadByte() (byte, error) {
+	if _, err := io.ReadFull(s.r, s.buf[:]); err != nil {
+		return 0, err
+	}
+	return s.buf[0], nil
+}
@@ -0,0 +1,178 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"bytes"
+	crand "crypto/rand"
+	"encoding/hex"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testWireInitonce sync.Once
+
+func TestWirePointEnvelope(t *testing.T) {
+	g := K256().NewGeneratorPoint()
+	bin, err := PointMarshalBinary(g)
+	require.NoError(t, err)
+	// version | tag | id | length | 33 byte compressed point
+	require.Equal(t, "01020121", hex.EncodeToString(bin[:4]))
+	require.Equal(t, 4+33, len(bin))
+	pt, err := PointUnmarshalBinary(bin)
+	require.NoError(t, err)
+	require.True(t, g.Equal(pt))
+
+	// Trailing and missing bytes are rejected
+	_, err = PointUnmarshalBinary(append(bin, 0))
+	require.Error(t, err)
+	_, err = PointUnmarshalBinary(bin[:len(bin)-1])
+	require.Error(t, err)
+	_, err = PointUnmarshalBinary(bin[:2])
+	require.Error(t, err)
+
+	// A scalar envelope is not a point
+	sc, err := ScalarMarshalBinary(K256().Scalar.New(3))
+	require.NoError(t, err)
+	_, err = PointUnmarshalBinary(sc)
+	require.Error(t, err)
+
+	unknown := append([]byte{}, bin...)
+	unknown[2] = 0x7f
+	_, err = PointUnmarshalBinary(unknown)
+	require.Error(t, err)
+	unknown[0] = 2
+	_, err = PointUnmarshalBinary(unknown)
+	require.Error(t, err)
+}
+
+func TestWireLegacyFormat(t *testing.T) {
+	for _, curve := range []*Curve{K256(), BLS12381G2(), ED448(), Stark()} {
+		pt := curve.Point.Random(crand.Reader)
+		legacy := append([]byte(pt.CurveName()+":"), pt.ToAffineCompressed()...)
+		ret, err := PointUnmarshalBinary(legacy)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(ret))
+
+		sc := curve.Scalar.Random(crand.Reader)
+		legacy = append([]byte(sc.Point().CurveName()+":"), sc.Bytes()...)
+		retS, err := ScalarUnmarshalBinary(legacy)
+		require.NoError(t, err)
+		require.Equal(t, 0, sc.Cmp(retS))
+
+		bin, err := ScalarMarshalBinary(sc)
+		require.NoError(t, err)
+		require.Less(t, len(bin), len(legacy))
+		retS, err = ScalarUnmarshalBinary(bin)
+		require.NoError(t, err)
+		require.Equal(t, 0, sc.Cmp(retS))
+	}
+}
+
+func TestWireCurveIDs(t *testing.T) {
+	seen := make(map[CurveID]bool)
+	for _, name := range RegisteredCurves() {
+		id, ok := GetCurveID(name)
+		if !ok {
+			continue
+		}
+		require.False(t, seen[id])
+		seen[id] = true
+		require.Equal(t, name, GetCurveByID(id).Name)
+	}
+	require.Equal(t, StarkName, GetCurveByID(StarkID).Name)
+	require.Nil(t, GetCurveByID(0))
+	_, ok := GetCurveID(BLS12831Name)
+	require.False(t, ok)
+
+	require.Error(t, RegisterCurveID(K256ID, BabyJubjubName))
+	require.Error(t, RegisterCurveID(1000, K256Name))
+	require.Error(t, RegisterCurveID(0, K256Name))
+	require.Error(t, RegisterCurveID(MaxCurveID+1, K256Name))
+	require.Error(t, RegisterCurveID(1001, "unregistered"))
+}
+
+func TestWireThirdPartyCurveID(t *testing.T) {
+	registerTestRegistryCurve(t)
+	testWireInitonce.Do(func() {
+		require.NoError(t, RegisterCurveID(MaxCurveID, testRegistryName))
+	})
+	pt := testRegistryPoint{new(PointK256).Generator().(*PointK256)}
+	bin, err := PointMarshalBinary(pt)
+	require.NoError(t, err)
+	// The largest id takes two bytes
+	require.Equal(t, 5+33, len(bin))
+	ret, err := PointUnmarshalBinary(bin)
+	require.NoError(t, err)
+	require.True(t, pt.Equal(ret))
+}
+
+func TestWireStream(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+
+	g1 := BLS12381G1().Point.Random(crand.Reader)
+	g2 := BLS12381G2().Point.Random(crand.Reader)
+	points := make([]Point, 10)
+	scalars := make([]Scalar, 10)
+	for i := range points {
+		points[i] = P256().Point.Random(crand.Reader)
+		scalars[i] = PALLAS().Scalar.Random(crand.Reader)
+	}
+	require.NoError(t, enc.EncodePoint(g1))
+	require.NoError(t, enc.EncodeScalar(scalars[0]))
+	require.NoError(t, enc.EncodePoints(points))
+	require.NoError(t, enc.EncodeScalars(scalars))
+	require.NoError(t, enc.EncodePoints(nil))
+	require.NoError(t, enc.EncodePoint(g2))
+
+	require.Error(t, enc.EncodePoints([]Point{g1, g2}))
+
+	// Hide the io.ByteReader implementation of bytes.Buffer
+	dec := NewDecoder(struct{ io.Reader }{&buf})
+	pt, err := dec.DecodePoint()
+	require.NoError(t, err)
+	require.True(t, g1.Equal(pt))
+	sc, err := dec.DecodeScalar()
+	require.NoError(t, err)
+	require.Equal(t, 0, scalars[0].Cmp(sc))
+	pts, err := dec.DecodePoints()
+	require.NoError(t, err)
+	require.Equal(t, len(points), len(pts))
+	for i := range points {
+		require.True(t, points[i].Equal(pts[i]))
+	}
+	scs, err := dec.DecodeScalars()
+	require.NoError(t, err)
+	require.Equal(t, len(scalars), len(scs))
+	for i := range scalars {
+		require.Equal(t, 0, scalars[i].Cmp(scs[i]))
+	}
+	pts, err = dec.DecodePoints()
+	require.NoError(t, err)
+	require.Empty(t, pts)
+	pt, err = dec.DecodePoint()
+	require.NoError(t, err)
+	require.True(t, g2.Equal(pt))
+
+	_, err = dec.DecodePoint()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestWireStreamTruncated(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, NewEncoder(&buf).EncodePoints([]Point{
+		K256().Point.Random(crand.Reader),
+		K256().Point.Random(crand.Reader),
+	}))
+	data := buf.Bytes()
+	_, err := NewDecoder(bytes.NewReader(data[:len(data)-1])).DecodePoints()
+	require.Equal(t, io.ErrUnexpectedEOF, err)
+	_, err = NewDecoder(bytes.NewReader(data)).DecodeScalars()
+	require.Error(t, err)
+}
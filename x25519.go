@@ -0,0 +1,193 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"fmt"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+)
+
+// X25519Bytes is the length of X25519 scalars and u-coordinates.
+const X25519Bytes = 32
+
+// PointX25519 is a point on Curve25519 represented only
+// by its Montgomery u-coordinate as described in RFC 7748.
+type PointX25519 struct {
+	value *field.Element
+}
+
+// X25519 computes the RFC 7748 function X25519(scalar, point).
+// The scalar is clamped before use and an error is returned
+// if the result is the all-zero value, i.e. point has low order.
+func X25519(scalar, point []byte) ([]byte, error) {
+	p, err := new(PointX25519).SetBytes(point)
+	if err != nil {
+		return nil, err
+	}
+	out, err := p.MulClamped(scalar)
+	if err != nil {
+		return nil, err
+	}
+	if out.IsZero() {
+		return nil, fmt.Errorf("x25519: low order point")
+	}
+	return out.Bytes(), nil
+}
+
+// X25519Base computes X25519(scalar, 9), the public key for scalar.
+func X25519Base(scalar []byte) ([]byte, error) {
+	return X25519(scalar, new(PointX25519).Generator().Bytes())
+}
+
+// Generator returns the base point u = 9.
+func (*PointX25519) Generator() *PointX25519 {
+	value := new(field.Element).Mult32(new(field.Element).One(), 9)
+	return &PointX25519{value}
+}
+
+// IsZero returns true if the u-coordinate is zero which is the
+// result of multiplying any low order point.
+func (p *PointX25519) IsZero() bool {
+	return p.value.Equal(new(field.Element).Zero()) == 1
+}
+
+func (p *PointX25519) Equal(rhs *PointX25519) bool {
+	if rhs == nil {
+		return false
+	}
+	return p.value.Equal(rhs.value) == 1
+}
+
+// SetBytes decodes a 32-byte little-endian u-coordinate.
+// As required by RFC 7748, the most significant bit is ignored
+// and non-canonical values are accepted and reduced.
+func (*PointX25519) SetBytes(input []byte) (*PointX25519, error) {
+	if len(input) != X25519Bytes {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	value, err := new(field.Element).SetBytes(input)
+	if err != nil {
+		return nil, err
+	}
+	return &PointX25519{value}, nil
+}
+
+// Bytes returns the canonical 32-byte little-endian u-coordinate.
+func (p *PointX25519) Bytes() []byte {
+	return p.value.Bytes()
+}
+
+func (p *PointX25519) Clone() *PointX25519 {
+	return &PointX25519{new(field.Element).Set(p.value)}
+}
+
+// Mul multiplies this point by an ed25519 scalar. Scalars created
+// with ScalarEd25519.SetBytesClamping produce the same result as
+// the clamped X25519 key when p is in the prime order subgroup.
+func (p *PointX25519) Mul(rhs *ScalarEd25519) *PointX25519 {
+	if rhs == nil {
+		return nil
+	}
+	var k [X25519Bytes]byte
+	copy(k[:], rhs.value.Bytes())
+	return &PointX25519{ladder25519(&k, p.value)}
+}
+
+// MulClamped multiplies this point by a 32-byte X25519 private key
+// after applying the clamping from RFC 7748, Section 5.
+func (p *PointX25519) MulClamped(scalar []byte) (*PointX25519, error) {
+	if len(scalar) != X25519Bytes {
+		return nil, fmt.Errorf("invalid scalar length")
+	}
+	var k [X25519Bytes]byte
+	copy(k[:], scalar)
+	k[0] &= 248
+	k[31] &= 127
+	k[31] |= 64
+	return &PointX25519{ladder25519(&k, p.value)}, nil
+}
+
+// ToEdwards returns the ed25519 point birationally equivalent to this point
+// using y = (u - 1) / (u + 1). The sign selects which of the two
+// possible x-coordinates is used, 0 for even and 1 for odd.
+func (p *PointX25519) ToEdwards(sign byte) (*PointEd25519, error) {
+	if p.value.Equal(minOne) == 1 {
+		return nil, fmt.Errorf("invalid point")
+	}
+	num := new(field.Element).Subtract(p.value, edOne)
+	den := new(field.Element).Add(p.value, edOne)
+	y := new(field.Element).Multiply(num, den.Invert(den))
+
+	b := y.Bytes()
+	b[31] |= (sign & 1) << 7
+	value, err := edwards25519.NewIdentityPoint().SetBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	return &PointEd25519{value}, nil
+}
+
+// ToMontgomery returns the Curve25519 point birationally equivalent
+// to this point using u = (1 + y) / (1 - y). The identity maps to u = 0.
+func (p *PointEd25519) ToMontgomery() *PointX25519 {
+	value, _ := new(field.Element).SetBytes(p.value.BytesMontgomery())
+	return &PointX25519{value}
+}
+
+// ladder25519 is the constant time Montgomery ladder from RFC 7748, Section 5.
+// k is a little-endian scalar whose bits 254..0 are processed.
+func ladder25519(k *[X25519Bytes]byte, u *field.Element) *field.Element {
+	x1 := new(field.Element).Set(u)
+	x2 := new(field.Element).One()
+	z2 := new(field.Element).Zero()
+	x3 := new(field.Element).Set(u)
+	z3 := new(field.Element).One()
+
+	tA := new(field.Element)
+	tAA := new(field.Element)
+	tB := new(field.Element)
+	tBB := new(field.Element)
+	tE := new(field.Element)
+	tC := new(field.Element)
+	tD := new(field.Element)
+	tDA := new(field.Element)
+	tCB := new(field.Element)
+
+	swap := 0
+	for i := 254; i >= 0; i-- {
+		bit := int(k[i>>3]>>(i&7)) & 1
+		swap ^= bit
+		x2.Swap(x3, swap)
+		z2.Swap(z3, swap)
+		swap = bit
+
+		tA.Add(x2, z2)
+		tAA.Square(tA)
+		tB.Subtract(x2, z2)
+		tBB.Square(tB)
+		tE.Subtract(tAA, tBB)
+		tC.Add(x3, z3)
+		tD.Subtract(x3, z3)
+		tDA.Multiply(tD, tA)
+		tCB.Multiply(tC, tB)
+
+		x3.Add(tDA, tCB)
+		x3.Square(x3)
+		z3.Subtract(tDA, tCB)
+		z3.Square(z3)
+		z3.Multiply(z3, x1)
+		x2.Multiply(tAA, tBB)
+		// z2 = E * (AA + a24 * E) with a24 = (486662 - 2) / 4
+		z2.Mult32(tE, 121665)
+		z2.Add(z2, tAA)
+		z2.Multiply(z2, tE)
+	}
+	x2.Swap(x3, swap)
+	z2.Swap(z3, swap)
+
+	return x2.Multiply(x2, z2.Invert(z2))
+}
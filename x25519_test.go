@@ -0,0 +1,136 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"crypto/ecdh"
+	ed "crypto/ed25519"
+	crand "crypto/rand"
+	"crypto/sha512"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestX25519Rfc7748Vectors(t *testing.T) {
+	// RFC 7748, Section 5.2
+	tests := []struct {
+		scalar, u, expected string
+	}{
+		{
+			"a546e36bf0527c9d3b16154b82465edd62144c0ac1fc5a18506a2244ba449ac4",
+			"e6db6867583030db3594c1a424b15f7c726624ec26b3353b10a903a6d0ab1c4c",
+			"c3da55379de9c6908e94ea4df28d084f32eccf03491c71f754b4075577a28552",
+		},
+		{
+			"4b66e9d4d1b4673c5ad22691957d6af5c11b6421e0ea01d42ca4169e7918ba0d",
+			"e5210f12786811d3f4b7959d0538ae2c31dbe7106fc03c3efc4cd549c715a493",
+			"95cbde9476e8907d7aade45cb4b873f88b595a68799fa152e6f8f7647aac7957",
+		},
+	}
+	for _, tst := range tests {
+		scalar, _ := hex.DecodeString(tst.scalar)
+		u, _ := hex.DecodeString(tst.u)
+		actual, err := X25519(scalar, u)
+		require.NoError(t, err)
+		require.Equal(t, tst.expected, hex.EncodeToString(actual))
+	}
+}
+
+func TestX25519Iterated(t *testing.T) {
+	k := new(PointX25519).Generator().Bytes()
+	u := new(PointX25519).Generator().Bytes()
+	for i := 0; i < 1000; i++ {
+		r, err := X25519(k, u)
+		require.NoError(t, err)
+		u = k
+		k = r
+		if i == 0 {
+			require.Equal(t, "422c8e7a6227d7bca1350b3e2bb7279f7897b87bb6854b783c60e80311ae3079", hex.EncodeToString(k))
+		}
+	}
+	require.Equal(t, "684cf59ba83309552800ef566f2f4d3c1c3887c49360e3875f2eb94d99532c51", hex.EncodeToString(k))
+}
+
+func TestX25519DiffieHellman(t *testing.T) {
+	// RFC 7748, Section 6.1
+	alice, _ := hex.DecodeString("77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a")
+	bob, _ := hex.DecodeString("5dab087e624a8a4b79e17f8b83800ee66f3bb1292618b6fd1c2f8b27ff88e0eb")
+	alicePub, err := X25519Base(alice)
+	require.NoError(t, err)
+	require.Equal(t, "8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a", hex.EncodeToString(alicePub))
+	bobPub, err := X25519Base(bob)
+	require.NoError(t, err)
+	require.Equal(t, "de9edb7d7b7dc1b4d35b61c2ece435373f8343c85b78674dadfc7e146f882b4f", hex.EncodeToString(bobPub))
+
+	k1, err := X25519(alice, bobPub)
+	require.NoError(t, err)
+	k2, err := X25519(bob, alicePub)
+	require.NoError(t, err)
+	require.Equal(t, k1, k2)
+	require.Equal(t, "4a5d9d5ba4ce2de1728e3bf480350f25e07e21c947d19e3376f09b3c1e161742", hex.EncodeToString(k1))
+}
+
+func TestX25519LowOrder(t *testing.T) {
+	var scalar [32]byte
+	_, _ = crand.Read(scalar[:])
+	var zero [32]byte
+	_, err := X25519(scalar[:], zero[:])
+	require.Error(t, err)
+	one := make([]byte, 32)
+	one[0] = 1
+	_, err = X25519(scalar[:], one)
+	require.Error(t, err)
+	_, err = X25519(scalar[:31], one)
+	require.Error(t, err)
+}
+
+func TestX25519MatchesStdlib(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		priv, err := ecdh.X25519().GenerateKey(crand.Reader)
+		require.NoError(t, err)
+		pub, err := X25519Base(priv.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, priv.PublicKey().Bytes(), pub)
+	}
+}
+
+func TestX25519FromEd25519(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		pub, priv, err := ed.GenerateKey(crand.Reader)
+		require.NoError(t, err)
+		h := sha512.Sum512(priv.Seed())
+
+		edPub, err := new(PointEd25519).FromAffineCompressed(pub)
+		require.NoError(t, err)
+		u := edPub.(*PointEd25519).ToMontgomery()
+
+		expected, err := X25519Base(h[:32])
+		require.NoError(t, err)
+		require.Equal(t, expected, u.Bytes())
+
+		sc, err := new(ScalarEd25519).SetBytesClamping(h[:32])
+		require.NoError(t, err)
+		actual := new(PointX25519).Generator().Mul(sc.(*ScalarEd25519))
+		require.True(t, u.Equal(actual))
+	}
+}
+
+func TestX25519ToEdwards(t *testing.T) {
+	g := new(PointEd25519).Generator().(*PointEd25519)
+	u := g.ToMontgomery()
+	require.True(t, u.Equal(new(PointX25519).Generator()))
+
+	for i := 0; i < 10; i++ {
+		pt := new(PointEd25519).Random(crand.Reader).(*PointEd25519)
+		u = pt.ToMontgomery()
+		sign := pt.ToAffineCompressed()[31] >> 7
+		back, err := u.ToEdwards(sign)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(back))
+	}
+	require.True(t, new(PointEd25519).Identity().(*PointEd25519).ToMontgomery().IsZero())
+}
@@ -0,0 +1,198 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	"fmt"
+	"io"
+
+	ed448n "github.com/mikelodder7/curvey/native/ed448"
+)
+
+// X448Bytes is the length of X448 scalars and u-coordinates.
+const X448Bytes = 56
+
+// PointX448 is a point on Curve448 represented only
+// by its Montgomery u-coordinate as described in RFC 7748.
+type PointX448 struct {
+	value *ed448n.MontgomeryPoint
+}
+
+// X448PrivateKey is an X448 private key. The scalar is stored
+// as given and clamped when used.
+type X448PrivateKey struct {
+	scalar    [X448Bytes]byte
+	publicKey *X448PublicKey
+}
+
+// X448PublicKey is an X448 public key which is never a low order point.
+type X448PublicKey struct {
+	point *PointX448
+}
+
+// X448 computes the RFC 7748 function X448(scalar, point).
+// The scalar is clamped before use and an error is returned
+// if the result is the all-zero value, i.e. point has low order.
+func X448(scalar, point []byte) ([]byte, error) {
+	p, err := new(PointX448).SetBytes(point)
+	if err != nil {
+		return nil, err
+	}
+	out, err := p.MulClamped(scalar)
+	if err != nil {
+		return nil, err
+	}
+	if out.IsZero() {
+		return nil, fmt.Errorf("x448: low order point")
+	}
+	return out.Bytes(), nil
+}
+
+// X448Base computes X448(scalar, 5), the public key for scalar.
+func X448Base(scalar []byte) ([]byte, error) {
+	return X448(scalar, new(PointX448).Generator().Bytes())
+}
+
+// Generator returns the base point u = 5.
+func (*PointX448) Generator() *PointX448 {
+	return &PointX448{new(ed448n.MontgomeryPoint).SetGenerator()}
+}
+
+// IsZero returns true if the u-coordinate is zero which is the
+// result of multiplying any low order point.
+func (p *PointX448) IsZero() bool {
+	var zero ed448n.MontgomeryPoint
+	return p.value.EqualI(&zero) == 1
+}
+
+// IsLowOrder returns true if this point is one of the
+// points of order 1, 2 or 4.
+func (p *PointX448) IsLowOrder() bool {
+	return p.value.IsLowOrder() == 1
+}
+
+func (p *PointX448) Equal(rhs *PointX448) bool {
+	if rhs == nil {
+		return false
+	}
+	return p.value.EqualI(rhs.value) == 1
+}
+
+// SetBytes decodes a 56-byte little-endian u-coordinate.
+// As required by RFC 7748, non-canonical values are accepted and reduced.
+func (*PointX448) SetBytes(input []byte) (*PointX448, error) {
+	if len(input) != X448Bytes {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	var wide [2 * X448Bytes]byte
+	copy(wide[:], input)
+	value := ed448n.MontgomeryPoint(ed448n.FpNew().SetBytesWide(&wide).Bytes())
+	return &PointX448{&value}, nil
+}
+
+// Bytes returns the canonical 56-byte little-endian u-coordinate.
+func (p *PointX448) Bytes() []byte {
+	return p.value.Bytes()
+}
+
+func (p *PointX448) Clone() *PointX448 {
+	value := *p.value
+	return &PointX448{&value}
+}
+
+// Mul multiplies this point by an ed448 scalar.
+func (p *PointX448) Mul(rhs *ScalarEd448) *PointX448 {
+	if rhs == nil {
+		return nil
+	}
+	return &PointX448{p.value.Mul(rhs.value)}
+}
+
+// MulClamped multiplies this point by a 56-byte X448 private key
+// after applying the clamping from RFC 7748, Section 5.
+func (p *PointX448) MulClamped(scalar []byte) (*PointX448, error) {
+	if len(scalar) != X448Bytes {
+		return nil, fmt.Errorf("invalid scalar length")
+	}
+	var k [X448Bytes]byte
+	copy(k[:], scalar)
+	k[0] &= 252
+	k[55] |= 128
+	return &PointX448{p.value.MulBytes(&k)}, nil
+}
+
+// ToMontgomery returns the Curve448 point that is the image of this
+// point under the 4-isogeny from RFC 7748, Section 4.2.
+func (p *PointEd448) ToMontgomery() *PointX448 {
+	return &PointX448{p.value.ToMontgomery()}
+}
+
+// GenerateX448Key creates a new private key using reader.
+func GenerateX448Key(reader io.Reader) (*X448PrivateKey, error) {
+	if reader == nil {
+		return nil, fmt.Errorf("invalid reader")
+	}
+	var seed [X448Bytes]byte
+	if _, err := io.ReadFull(reader, seed[:]); err != nil {
+		return nil, err
+	}
+	return NewX448PrivateKey(seed[:])
+}
+
+// NewX448PrivateKey creates a private key from 56 bytes.
+func NewX448PrivateKey(input []byte) (*X448PrivateKey, error) {
+	if len(input) != X448Bytes {
+		return nil, fmt.Errorf("invalid private key length")
+	}
+	k := new(X448PrivateKey)
+	copy(k.scalar[:], input)
+	point, err := new(PointX448).Generator().MulClamped(k.scalar[:])
+	if err != nil {
+		return nil, err
+	}
+	k.publicKey = &X448PublicKey{point}
+	return k, nil
+}
+
+// Bytes returns the private key as it was given.
+func (k *X448PrivateKey) Bytes() []byte {
+	out := make([]byte, X448Bytes)
+	copy(out, k.scalar[:])
+	return out
+}
+
+// PublicKey returns the public key for this private key.
+func (k *X448PrivateKey) PublicKey() *X448PublicKey {
+	return k.publicKey
+}
+
+// ECDH computes the shared secret between this key and remote.
+func (k *X448PrivateKey) ECDH(remote *X448PublicKey) ([]byte, error) {
+	if remote == nil {
+		return nil, fmt.Errorf("invalid public key")
+	}
+	return X448(k.scalar[:], remote.Bytes())
+}
+
+// NewX448PublicKey decodes a public key and rejects low order points.
+func NewX448PublicKey(input []byte) (*X448PublicKey, error) {
+	point, err := new(PointX448).SetBytes(input)
+	if err != nil {
+		return nil, err
+	}
+	if point.IsLowOrder() {
+		return nil, fmt.Errorf("x448: low order point")
+	}
+	return &X448PublicKey{point}, nil
+}
+
+// Bytes returns the 56-byte u-coordinate of this key.
+func (k *X448PublicKey) Bytes() []byte {
+	return k.point.Bytes()
+}
+
+func (k *X448PublicKey) Equal(rhs *X448PublicKey) bool {
+	return rhs != nil && k.point.Equal(rhs.point)
+}
@@ -0,0 +1,122 @@
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curvey
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestX448Rfc7748Vectors(t *testing.T) {
+	// RFC 7748, Section 5.2
+	tests := []struct {
+		scalar, u, expected string
+	}{
+		{
+			"3d262fddf9ec8e88495266fea19a34d28882acef045104d0d1aae121700a779c984c24f8cdd78fbff44943eba368f54b29259a4f1c600ad3",
+			"06fce640fa3487bfda5f6cf2d5263f8aad88334cbd07437f020f08f9814dc031ddbdc38c19c6da2583fa5429db94ada18aa7a7fb4ef8a086",
+			"ce3e4ff95a60dc6697da1db1d85e6afbdf79b50a2412d7546d5f239fe14fbaadeb445fc66a01b0779d98223961111e21766282f73dd96b6f",
+		},
+		{
+			"203d494428b8399352665ddca42f9de8fef600908e0d461cb021f8c538345dd77c3e4806e25f46d3315c44e0a5b4371282dd2c8d5be3095f",
+			"0fbcc2f993cd56d3305b0b7d9e55d4c1a8fb5dbb52f8e9a1e9b6201b165d015894e56c4d3570bee52fe205e28a78b91cdfbde71ce8d157db",
+			"884a02576239ff7a2f2f63b2db6a9ff37047ac13568e1e30fe63c4a7ad1b3ee3a5700df34321d62077e63633c575c1c954514e99da7c179d",
+		},
+	}
+	for _, tst := range tests {
+		scalar, _ := hex.DecodeString(tst.scalar)
+		u, _ := hex.DecodeString(tst.u)
+		actual, err := X448(scalar, u)
+		require.NoError(t, err)
+		require.Equal(t, tst.expected, hex.EncodeToString(actual))
+	}
+}
+
+func TestX448Iterated(t *testing.T) {
+	k := new(PointX448).Generator().Bytes()
+	u := new(PointX448).Generator().Bytes()
+	for i := 0; i < 1000; i++ {
+		r, err := X448(k, u)
+		require.NoError(t, err)
+		u = k
+		k = r
+		if i == 0 {
+			require.Equal(t, "3f482c8a9f19b01e6c46ee9711d9dc14fd4bf67af30765c2ae2b846a4d23a8cd0db897086239492caf350b51f833868b9bc2b3bca9cf4113", hex.EncodeToString(k))
+		}
+	}
+	require.Equal(t, "aa3b4749d55b9daf1e5b00288826c467274ce3ebbdd5c17b975e09d4af6c67cf10d087202db88286e2b79fceea3ec353ef54faa26e219f38", hex.EncodeToString(k))
+}
+
+func TestX448DiffieHellman(t *testing.T) {
+	// RFC 7748, Section 6.2
+	aliceBytes, _ := hex.DecodeString("9a8f4925d1519f5775cf46b04b5800d4ee9ee8bae8bc5565d498c28dd9c9baf574a9419744897391006382a6f127ab1d9ac2d8c0a598726b")
+	bobBytes, _ := hex.DecodeString("1c306a7ac2a0e2e0990b294470cba339e6453772b075811d8fad0d1d6927c120bb5ee8972b0d3e21374c9c921b09d1b0366f10b65173992d")
+	alice, err := NewX448PrivateKey(aliceBytes)
+	require.NoError(t, err)
+	bob, err := NewX448PrivateKey(bobBytes)
+	require.NoError(t, err)
+	require.Equal(t, aliceBytes, alice.Bytes())
+	require.Equal(t, "9b08f7cc31b7e3e67d22d5aea121074a273bd2b83de09c63faa73d2c22c5d9bbc836647241d953d40c5b12da88120d53177f80e532c41fa0", hex.EncodeToString(alice.PublicKey().Bytes()))
+	require.Equal(t, "3eb7a829b0cd20f5bcfc0b599b6feccf6da4627107bdb0d4f345b43027d8b972fc3e34fb4232a13ca706dcb57aec3dae07bdc1c67bf33609", hex.EncodeToString(bob.PublicKey().Bytes()))
+
+	bobPub, err := NewX448PublicKey(bob.PublicKey().Bytes())
+	require.NoError(t, err)
+	require.True(t, bobPub.Equal(bob.PublicKey()))
+
+	k1, err := alice.ECDH(bobPub)
+	require.NoError(t, err)
+	k2, err := bob.ECDH(alice.PublicKey())
+	require.NoError(t, err)
+	require.Equal(t, k1, k2)
+	require.Equal(t, "07fff4181ac6cc95ec1c16a94a0f74d12da232ce40a77552281d282bb60c0b56fd2464c335543936521c24403085d59a449a5037514a879d", hex.EncodeToString(k1))
+
+	pub, err := X448Base(aliceBytes)
+	require.NoError(t, err)
+	require.Equal(t, alice.PublicKey().Bytes(), pub)
+}
+
+func TestX448LowOrder(t *testing.T) {
+	key, err := GenerateX448Key(crand.Reader)
+	require.NoError(t, err)
+
+	zero := make([]byte, X448Bytes)
+	one := make([]byte, X448Bytes)
+	one[0] = 1
+	minusOne, _ := hex.DecodeString("fefffffffffffffffffffffffffffffffffffffffffffffffffffffffeffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	// p + 1 is a non-canonical encoding of one
+	pPlusOne, _ := hex.DecodeString("00000000000000000000000000000000000000000000000000000000ffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+
+	for _, u := range [][]byte{zero, one, minusOne} {
+		_, err = NewX448PublicKey(u)
+		require.Error(t, err)
+		_, err = X448(key.Bytes(), u)
+		require.Error(t, err)
+	}
+	_, err = X448(key.Bytes(), pPlusOne)
+	require.Error(t, err)
+	_, err = NewX448PublicKey(one[:55])
+	require.Error(t, err)
+	_, err = NewX448PrivateKey(one[:55])
+	require.Error(t, err)
+	_, err = key.ECDH(nil)
+	require.Error(t, err)
+}
+
+func TestX448FromEd448(t *testing.T) {
+	g := new(PointEd448).Generator().(*PointEd448)
+	require.True(t, g.ToMontgomery().Equal(new(PointX448).Generator()))
+
+	for i := 0; i < 10; i++ {
+		s := new(ScalarEd448).Random(crand.Reader).(*ScalarEd448)
+		pt := g.Mul(s).(*PointEd448)
+		expected := pt.ToMontgomery()
+		actual := new(PointX448).Generator().Mul(s)
+		require.True(t, expected.Equal(actual))
+	}
+	require.True(t, new(PointEd448).Identity().(*PointEd448).ToMontgomery().IsZero())
+}